@@ -0,0 +1,328 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+var testPair = currency.NewPair(currency.BTC, currency.USD)
+
+func testParameters() *parameters {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &parameters{
+		asset:       asset.Spot,
+		pair:        testPair,
+		candleStart: start,
+		candleEnd:   start.Add(time.Hour * 4),
+		interval:    kline.OneHour,
+	}
+}
+
+func TestConformance(t *testing.T) {
+	mockDirectory = filepath.Join("..", "..", "testdata", "http_mock")
+	cfg, err := loadConfig("conformance.json")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var testConfig config.Config
+	err = testConfig.LoadConfig(filepath.Join("..", "..", "testdata", "configtest.json"), true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	names, err := mockedExchanges(mockDirectory, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for i := range names {
+		report := testExchange(context.Background(), names[i], &testConfig, cfg.Exchanges[names[i]])
+		if report.Error != "" {
+			t.Errorf("%s setup failed: %s", names[i], report.Error)
+			continue
+		}
+		for j := range report.Results {
+			switch r := &report.Results[j]; r.Status {
+			case StatusPanic:
+				t.Errorf("%s %s panicked: %s", report.Exchange, r.Method, r.Error)
+			case StatusViolation:
+				t.Errorf("%s %s violations: %v", report.Exchange, r.Method, r.Violations)
+			}
+		}
+	}
+}
+
+func TestMockedExchanges(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join("..", "..", "testdata", "http_mock")
+	names, err := mockedExchanges(dir, "gemini+binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(names) != 1 || names[0] != "gemini" {
+		t.Errorf("received: '%v' but expected: '%v'", names, []string{"gemini"})
+	}
+	_, err = mockedExchanges(t.TempDir(), "")
+	if !errors.Is(err, errNoMockedExchanges) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoMockedExchanges)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+	for err, expected := range map[error]string{
+		fmt.Errorf("wrapped %w", common.ErrNotYetImplemented): StatusNotImplemented,
+		common.ErrFunctionNotSupported:                        StatusNotSupported,
+		asset.ErrNotSupported:                                 StatusNotSupported,
+		errors.New("bad request"):                             StatusError,
+	} {
+		if received := classifyError(err); received != expected {
+			t.Errorf("%v received: '%v' but expected: '%v'", err, received, expected)
+		}
+	}
+}
+
+func TestSplitError(t *testing.T) {
+	t.Parallel()
+	errTest := errors.New("test")
+	outputs, err := splitError(reflect.ValueOf(func() (int, error) { return 1, errTest }).Call(nil))
+	if !errors.Is(err, errTest) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errTest)
+	}
+	if len(outputs) != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", len(outputs), 1)
+	}
+	outputs, err = splitError(reflect.ValueOf(func() (int, error) { return 1, nil }).Call(nil))
+	if !errors.Is(err, nil) || len(outputs) != 1 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", err, len(outputs), nil, 1)
+	}
+	outputs, err = splitError(reflect.ValueOf(func() bool { return true }).Call(nil))
+	if !errors.Is(err, nil) || len(outputs) != 1 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", err, len(outputs), nil, 1)
+	}
+}
+
+func TestCallMethod(t *testing.T) {
+	t.Parallel()
+	_, panicked := callMethod(reflect.ValueOf(func() { panic("test") }), nil)
+	if panicked != "test" {
+		t.Errorf("received: '%v' but expected: '%v'", panicked, "test")
+	}
+	outputs, panicked := callMethod(reflect.ValueOf(func() int { return 1 }), nil)
+	if panicked != nil || len(outputs) != 1 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", panicked, len(outputs), nil, 1)
+	}
+}
+
+func TestCheckTicker(t *testing.T) {
+	t.Parallel()
+	p := testParameters()
+	if v := checkTicker(nil, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	tick := &ticker.Price{Pair: testPair, AssetType: asset.Spot, Bid: 1, Ask: 2, Low: 1, High: 2}
+	if v := checkTicker(tick, p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	tick.Bid = 3
+	tick.Low = 3
+	if v := checkTicker(tick, p); len(v) != 2 {
+		t.Errorf("received: '%v' but expected 2 violations", v)
+	}
+}
+
+func TestCheckOrderbook(t *testing.T) {
+	t.Parallel()
+	p := testParameters()
+	if v := checkOrderbook(nil, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	book := &orderbook.Base{
+		Pair: testPair,
+		Bids: orderbook.Items{{Price: 10, Amount: 1}, {Price: 9, Amount: 1}},
+		Asks: orderbook.Items{{Price: 11, Amount: 1}, {Price: 12, Amount: 1}},
+	}
+	if v := checkOrderbook(book, p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	book.Bids = orderbook.Items{{Price: 9, Amount: 1}, {Price: 9, Amount: 0}}
+	book.Asks = orderbook.Items{{Price: 12, Amount: 1}, {Price: 11, Amount: 1}}
+	if v := checkOrderbook(book, p); len(v) != 3 {
+		t.Errorf("received: '%v' but expected 3 violations", v)
+	}
+	book.PriceDuplication = true
+	book.Bids[1].Amount = 1
+	book.Asks = orderbook.Items{{Price: 8, Amount: 1}}
+	v := checkOrderbook(book, p)
+	if len(v) != 1 || !strings.Contains(v[0], "crossed") {
+		t.Errorf("received: '%v' but expected a crossed orderbook violation", v)
+	}
+	p.crossedOrderbook = true
+	if v = checkOrderbook(book, p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+}
+
+func TestCheckCandles(t *testing.T) {
+	t.Parallel()
+	p := testParameters()
+	newItem := func(offsets ...time.Duration) *kline.Item {
+		item := &kline.Item{Pair: testPair, Interval: kline.OneHour}
+		for i := range offsets {
+			item.Candles = append(item.Candles, kline.Candle{
+				Time: p.candleStart.Add(offsets[i]),
+				Open: 2, High: 3, Low: 1, Close: 2,
+			})
+		}
+		return item
+	}
+	if v := checkCandles(newItem(0, time.Hour, time.Hour*2), p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	for _, tc := range []struct {
+		offsets   []time.Duration
+		open      float64
+		violation string
+	}{
+		{offsets: []time.Duration{time.Hour, 0}, open: 2, violation: "is not after"},
+		{offsets: []time.Duration{0, time.Hour * 2}, open: 2, violation: "exceeds interval"},
+		{offsets: []time.Duration{0, time.Minute}, open: 2, violation: "closer than interval"},
+		{offsets: []time.Duration{time.Hour * 5}, open: 2, violation: "outside of requested range"},
+		{offsets: []time.Duration{0}, open: 4, violation: "outside low"},
+	} {
+		item := newItem(tc.offsets...)
+		item.Candles[0].Open = tc.open
+		v := checkCandles(item, p)
+		if len(v) != 1 || !strings.Contains(v[0], tc.violation) {
+			t.Errorf("received: '%v' but expected a '%s' violation", v, tc.violation)
+		}
+	}
+	item := newItem(0)
+	item.Interval = kline.OneDay
+	if v := checkCandles(item, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected an interval violation", v)
+	}
+}
+
+func TestCheckTrades(t *testing.T) {
+	t.Parallel()
+	p := testParameters()
+	trades := []trade.Data{{TID: "1", CurrencyPair: testPair, Price: 1, Amount: 1, Timestamp: time.Now()}}
+	if v := checkTrades(trades, p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	trades[0].Timestamp = time.Time{}
+	if v := checkTrades(trades, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	trades[0].Price = 0
+	if v := checkTrades(trades, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	trades[0].CurrencyPair = currency.NewPair(currency.LTC, currency.USD)
+	if v := checkTrades(trades, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+}
+
+func TestCheckTradablePairs(t *testing.T) {
+	t.Parallel()
+	if v := checkTradablePairs(nil); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	pairs := currency.Pairs{testPair, currency.NewPair(currency.LTC, currency.USD)}
+	if v := checkTradablePairs(pairs); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	pairs = append(pairs, currency.NewPairWithDelimiter("btc", "usd", "-"))
+	if v := checkTradablePairs(pairs); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+}
+
+func TestCheckOrders(t *testing.T) {
+	t.Parallel()
+	p := testParameters()
+	orders := []order.Detail{{OrderID: "1", Pair: testPair}, {OrderID: "2"}}
+	if v := checkOrders(orders, p); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	orders[1].OrderID = ""
+	if v := checkOrders(orders, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+	orders[1] = order.Detail{OrderID: "2", Pair: currency.NewPair(currency.LTC, currency.USD)}
+	if v := checkOrders(orders, p); len(v) != 1 {
+		t.Errorf("received: '%v' but expected a violation", v)
+	}
+}
+
+func TestCheckOrderRoundTrip(t *testing.T) {
+	t.Parallel()
+	s := testParameters().submission("test")
+	resp, err := s.DeriveSubmitResponse("1337")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	d := &order.Detail{OrderID: "1337", Pair: s.Pair, Side: s.Side, Type: s.Type, Amount: s.Amount}
+	if v := checkOrderRoundTrip(s, resp, d); len(v) != 0 {
+		t.Errorf("received: '%v' but expected no violations", v)
+	}
+	d = &order.Detail{OrderID: "1", Pair: currency.NewPair(currency.LTC, currency.USD), Side: order.Sell, Type: order.Market, Amount: 2}
+	if v := checkOrderRoundTrip(s, resp, d); len(v) != 5 {
+		t.Errorf("received: '%v' but expected 5 violations", v)
+	}
+}
+
+func TestOutputToConsole(t *testing.T) {
+	t.Parallel()
+	reports := []ExchangeReport{
+		{
+			Exchange: "test",
+			Asset:    asset.Spot,
+			Pair:     testPair,
+			Results: []MethodResult{
+				{Method: "UpdateTicker", Status: StatusViolation, Violations: []string{"bid 2 is above ask 1"}},
+				{Method: "GetServerTime", Status: StatusNotImplemented},
+			},
+		},
+		{Exchange: "broken", Error: "setup error"},
+	}
+	var buf bytes.Buffer
+	err := outputToConsole(&buf, reports)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for _, expected := range []string{
+		"UpdateTicker",
+		"SETUP FAILED",
+		"broken setup failed: setup error",
+		"UpdateTicker: bid 2 is above ask 1",
+		"0 passed, 1 violations",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected output to contain '%s':\n%s", expected, buf.String())
+		}
+	}
+	if conforms(reports) {
+		t.Error("expected reports not to conform")
+	}
+	if !conforms(nil) {
+		t.Error("expected no reports to conform")
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// checkTicker validates a ticker is for the requested pair and asset and that
+// its prices are consistent
+func checkTicker(t *ticker.Price, p *parameters) []string {
+	if t == nil {
+		return []string{"nil ticker returned"}
+	}
+	var violations []string
+	if !t.Pair.Equal(p.pair) {
+		violations = append(violations, fmt.Sprintf("ticker pair %v does not match requested pair %v", t.Pair, p.pair))
+	}
+	if t.AssetType != p.asset {
+		violations = append(violations, fmt.Sprintf("ticker asset %v does not match requested asset %v", t.AssetType, p.asset))
+	}
+	if t.Bid > 0 && t.Ask > 0 && t.Bid > t.Ask {
+		violations = append(violations, fmt.Sprintf("bid %v is above ask %v", t.Bid, t.Ask))
+	}
+	if t.Low > 0 && t.High > 0 && t.Low > t.High {
+		violations = append(violations, fmt.Sprintf("low %v is above high %v", t.Low, t.High))
+	}
+	return violations
+}
+
+// checkOrderbook validates bids are sorted descending, asks are sorted
+// ascending, the book is not crossed unless permitted and all depth has an
+// amount
+func checkOrderbook(b *orderbook.Base, p *parameters) []string {
+	if b == nil {
+		return []string{"nil orderbook returned"}
+	}
+	var violations []string
+	if !b.Pair.Equal(p.pair) {
+		violations = append(violations, fmt.Sprintf("orderbook pair %v does not match requested pair %v", b.Pair, p.pair))
+	}
+	for i := range b.Bids {
+		if b.Bids[i].Amount <= 0 {
+			violations = append(violations, fmt.Sprintf("bid at price %v has amount %v", b.Bids[i].Price, b.Bids[i].Amount))
+			break
+		}
+	}
+	for i := range b.Asks {
+		if b.Asks[i].Amount <= 0 {
+			violations = append(violations, fmt.Sprintf("ask at price %v has amount %v", b.Asks[i].Price, b.Asks[i].Amount))
+			break
+		}
+	}
+	for i := 1; i < len(b.Bids); i++ {
+		if b.Bids[i].Price > b.Bids[i-1].Price ||
+			(!b.PriceDuplication && b.Bids[i].Price == b.Bids[i-1].Price) {
+			violations = append(violations, fmt.Sprintf("bids are not sorted descending at depth %d: %v after %v", i, b.Bids[i].Price, b.Bids[i-1].Price))
+			break
+		}
+	}
+	for i := 1; i < len(b.Asks); i++ {
+		if b.Asks[i].Price < b.Asks[i-1].Price ||
+			(!b.PriceDuplication && b.Asks[i].Price == b.Asks[i-1].Price) {
+			violations = append(violations, fmt.Sprintf("asks are not sorted ascending at depth %d: %v after %v", i, b.Asks[i].Price, b.Asks[i-1].Price))
+			break
+		}
+	}
+	if !b.IsFundingRate && !p.crossedOrderbook && len(b.Bids) > 0 && len(b.Asks) > 0 && b.Bids[0].Price >= b.Asks[0].Price {
+		violations = append(violations, fmt.Sprintf("orderbook is crossed, best bid %v is not below best ask %v", b.Bids[0].Price, b.Asks[0].Price))
+	}
+	return violations
+}
+
+// checkCandles validates candles are for the requested pair and interval,
+// fall within the requested range and are in order without gaps beyond the
+// interval
+func checkCandles(item *kline.Item, p *parameters) []string {
+	var violations []string
+	if !item.Pair.Equal(p.pair) {
+		violations = append(violations, fmt.Sprintf("candle pair %v does not match requested pair %v", item.Pair, p.pair))
+	}
+	if item.Interval != p.interval {
+		violations = append(violations, fmt.Sprintf("candle interval %v does not match requested interval %v", item.Interval, p.interval))
+	}
+	for i := range item.Candles {
+		c := &item.Candles[i]
+		if c.Low > c.High || c.Open < c.Low || c.Open > c.High || c.Close < c.Low || c.Close > c.High {
+			violations = append(violations, fmt.Sprintf("candle at %v has open %v and close %v outside low %v and high %v", c.Time, c.Open, c.Close, c.Low, c.High))
+			break
+		}
+	}
+	for i := range item.Candles {
+		if item.Candles[i].Time.Before(p.candleStart.Add(-p.interval.Duration())) || item.Candles[i].Time.After(p.candleEnd) {
+			violations = append(violations, fmt.Sprintf("candle at %v is outside of requested range %v to %v", item.Candles[i].Time, p.candleStart, p.candleEnd))
+			break
+		}
+	}
+	for i := 1; i < len(item.Candles); i++ {
+		prev, curr := item.Candles[i-1].Time, item.Candles[i].Time
+		diff := curr.Sub(prev)
+		switch {
+		case diff <= 0:
+			violations = append(violations, fmt.Sprintf("candle at %v is not after candle at %v", curr, prev))
+		case diff > p.interval.Duration():
+			violations = append(violations, fmt.Sprintf("gap of %v between candles at %v and %v exceeds interval %v", diff, prev, curr, p.interval))
+		case diff < p.interval.Duration():
+			violations = append(violations, fmt.Sprintf("candles at %v and %v are closer than interval %v", prev, curr, p.interval))
+		default:
+			continue
+		}
+		break
+	}
+	return violations
+}
+
+// checkTrades validates trades are for the requested pair and have a price,
+// amount and timestamp
+func checkTrades(trades []trade.Data, p *parameters) []string {
+	for i := range trades {
+		switch {
+		case !trades[i].CurrencyPair.Equal(p.pair):
+			return []string{fmt.Sprintf("trade %s pair %v does not match requested pair %v", trades[i].TID, trades[i].CurrencyPair, p.pair)}
+		case trades[i].Price <= 0 || trades[i].Amount <= 0:
+			return []string{fmt.Sprintf("trade %s has price %v and amount %v", trades[i].TID, trades[i].Price, trades[i].Amount)}
+		case trades[i].Timestamp.IsZero():
+			return []string{fmt.Sprintf("trade %s has no timestamp", trades[i].TID)}
+		}
+	}
+	return nil
+}
+
+// checkTradablePairs validates tradable pairs are returned without duplicates
+func checkTradablePairs(pairs currency.Pairs) []string {
+	if len(pairs) == 0 {
+		return []string{"no tradable pairs returned"}
+	}
+	seen := make(map[string]struct{}, len(pairs))
+	for i := range pairs {
+		key := pairs[i].Base.Upper().String() + "-" + pairs[i].Quote.Upper().String()
+		if _, ok := seen[key]; ok {
+			return []string{fmt.Sprintf("tradable pair %v is duplicated", pairs[i])}
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// checkOrderDetail validates order details match the requested order
+func checkOrderDetail(d *order.Detail, orderID string, pair currency.Pair) []string {
+	var violations []string
+	if d.OrderID != orderID {
+		violations = append(violations, fmt.Sprintf("order ID %q does not match requested order ID %q", d.OrderID, orderID))
+	}
+	if !d.Pair.IsEmpty() && !d.Pair.Equal(pair) {
+		violations = append(violations, fmt.Sprintf("order pair %v does not match pair %v", d.Pair, pair))
+	}
+	return violations
+}
+
+// checkOrders validates orders have IDs and are for the requested pair
+func checkOrders(orders []order.Detail, p *parameters) []string {
+	for i := range orders {
+		if orders[i].OrderID == "" {
+			return []string{fmt.Sprintf("order %d has no order ID", i)}
+		}
+		if !orders[i].Pair.IsEmpty() && !orders[i].Pair.Equal(p.pair) {
+			return []string{fmt.Sprintf("order %s pair %v does not match requested pair %v", orders[i].OrderID, orders[i].Pair, p.pair)}
+		}
+	}
+	return nil
+}
+
+// checkOrderRoundTrip validates the details of a submitted order match the
+// submission once retrieved
+func checkOrderRoundTrip(s *order.Submit, resp *order.SubmitResponse, d *order.Detail) []string {
+	violations := checkOrderDetail(d, resp.OrderID, s.Pair)
+	if d.Side != order.UnknownSide && d.Side != s.Side {
+		violations = append(violations, fmt.Sprintf("order side %v does not match submitted side %v", d.Side, s.Side))
+	}
+	if d.Type != order.UnknownType && d.Type != s.Type {
+		violations = append(violations, fmt.Sprintf("order type %v does not match submitted type %v", d.Type, s.Type))
+	}
+	if d.Amount != 0 && d.Amount != s.Amount {
+		violations = append(violations, fmt.Sprintf("order amount %v does not match submitted amount %v", d.Amount, s.Amount))
+	}
+	return violations
+}
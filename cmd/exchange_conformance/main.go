@@ -0,0 +1,651 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// methodTimeout defines the maximum duration of a single method call
+const methodTimeout = time.Second * 30
+
+var (
+	errNoMockedExchanges = errors.New("no exchanges with mock data found")
+	errNoEnabledPairs    = errors.New("no enabled pairs")
+	errUnknownOutput     = errors.New("unknown output format")
+)
+
+// skippedMethods defines the methods which alter how the exchange is set up
+// or require a live websocket connection and are therefore not called
+var skippedMethods = map[string]string{
+	"AuthenticateWebsocket":          "requires a websocket connection",
+	"DisableRateLimiter":             "alters exchange setup",
+	"EnableRateLimiter":              "alters exchange setup",
+	"FlushWebsocketChannels":         "requires a websocket connection",
+	"GetDefaultConfig":               "alters exchange setup",
+	"SetClientProxyAddress":          "alters exchange setup",
+	"SetDefaults":                    "alters exchange setup",
+	"SetEnabled":                     "alters exchange setup",
+	"SetHTTPClientUserAgent":         "alters exchange setup",
+	"SetPairs":                       "alters exchange setup",
+	"Setup":                          "alters exchange setup",
+	"Start":                          "alters exchange setup",
+	"SubscribeToWebsocketChannels":   "requires a websocket connection",
+	"UnsubscribeToWebsocketChannels": "requires a websocket connection",
+}
+
+// validator checks the outputs of a successful method call against the
+// semantic invariants of its response. The outputs exclude the returned error
+type validator func(ctx context.Context, e exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string
+
+// validators defines the methods which have their responses validated
+var validators = map[string]validator{
+	"FetchTicker":                validateTicker,
+	"UpdateTicker":               validateTicker,
+	"FetchOrderbook":             validateOrderbook,
+	"UpdateOrderbook":            validateOrderbook,
+	"GetHistoricCandles":         validateCandles,
+	"GetHistoricCandlesExtended": validateCandles,
+	"GetRecentTrades":            validateTrades,
+	"GetHistoricTrades":          validateTrades,
+	"FetchTradablePairs":         validateTradablePairs,
+	"GetOrderInfo":               validateOrderInfo,
+	"GetActiveOrders":            validateOrders,
+	"GetOrderHistory":            validateOrders,
+	"SubmitOrder":                validateSubmitOrder,
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+func main() {
+	parseCLFlags()
+
+	log.Println("Loading config...")
+	conformanceConfig, err := loadConfig(configFileName)
+	if err != nil {
+		log.Printf("Error loading config: '%v', using exchange test configuration", err)
+		conformanceConfig = Config{Exchanges: make(map[string]*ExchangeConfig)}
+	}
+	var testConfig config.Config
+	err = testConfig.LoadConfig(testConfigFileName, true)
+	if err != nil {
+		log.Fatalf("Failed to load test config. Err: %s", err)
+	}
+
+	names, err := mockedExchanges(mockDirectory, exchangesToUse)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Testing exchange conformance..")
+	reports := make([]ExchangeReport, len(names))
+	for i := range names {
+		reports[i] = testExchange(context.Background(), names[i], &testConfig, conformanceConfig.Exchanges[names[i]])
+	}
+	log.Println("Done.")
+
+	switch outputFormat {
+	case "console":
+		err = outputToConsole(os.Stdout, reports)
+	case "json":
+		err = outputToJSON(reports)
+	default:
+		err = fmt.Errorf("%w %s", errUnknownOutput, outputFormat)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !conforms(reports) {
+		os.Exit(1)
+	}
+}
+
+func parseCLFlags() {
+	flag.StringVar(&configFileName, "config", "conformance.json", "the conformance parameters for each exchange")
+	flag.StringVar(&testConfigFileName, "testconfig", filepath.Join("..", "..", "testdata", "configtest.json"), "the exchange test configuration")
+	flag.StringVar(&mockDirectory, "mockdir", filepath.Join("..", "..", "testdata", "http_mock"), "the directory holding the exchange mock recordings")
+	flag.StringVar(&exchangesToUse, "exchanges", "", "a + delimited list of exchanges to test, all exchanges with mock data are tested by default")
+	flag.StringVar(&outputFormat, "output", "console", "the output format, console or json")
+	flag.StringVar(&outputFileName, "filename", "conformance_report.json", "the file name json output is saved to")
+	flag.Parse()
+}
+
+func loadConfig(path string) (Config, error) {
+	var cfg Config
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	err = json.Unmarshal(contents, &cfg)
+	return cfg, err
+}
+
+// mockedExchanges returns the supported exchanges with a mock recording,
+// optionally filtered by a + delimited list of exchange names
+func mockedExchanges(dir, filter string) ([]string, error) {
+	var include []string
+	if filter != "" {
+		include = strings.Split(strings.ToLower(filter), "+")
+	}
+	var names []string
+	for i := range exchange.Exchanges {
+		name := exchange.Exchanges[i]
+		if len(include) > 0 && !common.StringDataCompare(include, name) {
+			continue
+		}
+		if file.Exists(mockFilePath(dir, name)) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w in %s", errNoMockedExchanges, dir)
+	}
+	return names, nil
+}
+
+// mockFilePath returns the path of an exchange's mock recording
+func mockFilePath(dir, name string) string {
+	name = strings.ToLower(name)
+	return filepath.Join(dir, name, name+".json")
+}
+
+// testExchange sets up the exchange against its mock recording and calls
+// every IBotExchange method
+func testExchange(ctx context.Context, name string, testConfig *config.Config, exchCfg *ExchangeConfig) ExchangeReport {
+	report := ExchangeReport{Exchange: name}
+	if exchCfg == nil {
+		exchCfg = &ExchangeConfig{}
+	}
+	exch, err := setupExchange(name, testConfig, exchCfg)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Exchange = exch.GetName()
+	p, err := newParameters(exch, exchCfg)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Asset = p.asset
+	report.Pair = p.pair
+
+	var iExchange exchange.IBotExchange
+	methods := reflect.TypeOf(&iExchange).Elem()
+	report.Results = make([]MethodResult, methods.NumMethod())
+	for i := range report.Results {
+		report.Results[i] = testMethod(ctx, exch, p, methods.Method(i).Name)
+	}
+	return report
+}
+
+// setupExchange sets up an exchange using its test configuration and points
+// its API endpoints at a server replaying its mock recording
+func setupExchange(name string, testConfig *config.Config, exchCfg *ExchangeConfig) (exchange.IBotExchange, error) {
+	exch, err := engine.SetupExchangeManager().NewExchangeByName(name)
+	if err != nil {
+		return nil, err
+	}
+	exchConfig, err := testConfig.GetExchangeConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	b := exch.GetBase()
+	b.SkipAuthCheck = true
+	exchConfig.API.AuthenticatedSupport = true
+	exch.SetDefaults()
+	b.Websocket = sharedtestvalues.NewTestWebsocket()
+	err = exch.Setup(exchConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serverDetails, newClient, err := mock.NewPermissiveVCRServer(mockFilePath(mockDirectory, name))
+	if err != nil {
+		return nil, err
+	}
+	err = b.SetHTTPClient(newClient)
+	if err != nil {
+		return nil, err
+	}
+	endpointMap := b.API.Endpoints.GetURLMap()
+	for k := range endpointMap {
+		err = b.API.Endpoints.SetRunning(k, serverDetails+exchCfg.EndpointPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return exch, exch.DisableRateLimiter()
+}
+
+// newParameters resolves the arguments methods are called with, using the
+// exchange's first enabled asset and pair when not configured
+func newParameters(exch exchange.IBotExchange, exchCfg *ExchangeConfig) (*parameters, error) {
+	p := &parameters{
+		orderID:     exchCfg.OrderID,
+		orderAmount: exchCfg.Order.Amount,
+		orderPrice:  exchCfg.Order.Price,
+		interval:    kline.OneHour,
+		candleStart: exchCfg.CandleStart,
+		candleEnd:   exchCfg.CandleEnd,
+
+		crossedOrderbook: exchCfg.CrossedOrderbook,
+	}
+	var err error
+	if exchCfg.Asset != "" {
+		p.asset, err = asset.New(exchCfg.Asset)
+		if err != nil {
+			return nil, err
+		}
+	} else if assets := exch.GetAssetTypes(true); len(assets) > 0 {
+		p.asset = assets[0]
+	}
+
+	enabled, err := exch.GetEnabledPairs(p.asset)
+	if err != nil {
+		return nil, err
+	}
+	if exchCfg.Pair != "" {
+		p.pair, err = currency.NewPairFromString(exchCfg.Pair)
+		if err != nil {
+			return nil, err
+		}
+		for i := range enabled {
+			if enabled[i].Equal(p.pair) {
+				p.pair = enabled[i]
+				break
+			}
+		}
+	} else {
+		if len(enabled) == 0 {
+			return nil, fmt.Errorf("%s %v %w", exch.GetName(), p.asset, errNoEnabledPairs)
+		}
+		p.pair = enabled[0]
+	}
+
+	if exchCfg.CandleInterval != "" {
+		var d time.Duration
+		d, err = time.ParseDuration(exchCfg.CandleInterval)
+		if err != nil {
+			return nil, err
+		}
+		p.interval = kline.Interval(d)
+	}
+	if p.candleEnd.IsZero() {
+		p.candleEnd = time.Now().Truncate(p.interval.Duration())
+	}
+	if p.candleStart.IsZero() {
+		p.candleStart = p.candleEnd.Add(-p.interval.Duration() * 24)
+	}
+
+	p.orderSide = order.Buy
+	if exchCfg.Order.Side != "" {
+		p.orderSide, err = order.StringToOrderSide(exchCfg.Order.Side)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.orderType = order.Limit
+	if exchCfg.Order.Type != "" {
+		p.orderType, err = order.StringToOrderType(exchCfg.Order.Type)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.orderAmount == 0 {
+		p.orderAmount = 1
+	}
+	if p.orderPrice == 0 {
+		p.orderPrice = 1
+	}
+	return p, nil
+}
+
+// submission returns the order submitted to test order round tripping
+func (p *parameters) submission(exchName string) *order.Submit {
+	return &order.Submit{
+		Exchange:  exchName,
+		Type:      p.orderType,
+		Side:      p.orderSide,
+		Pair:      p.pair,
+		AssetType: p.asset,
+		Amount:    p.orderAmount,
+		Price:     p.orderPrice,
+	}
+}
+
+// arguments returns the arguments a method is called with. Arguments without
+// a conformance parameter are zero values, with pointers set to zero structs
+func (p *parameters) arguments(ctx context.Context, exchName, name string, method reflect.Type) []reflect.Value {
+	args := make([]reflect.Value, method.NumIn())
+	var times int
+	for i := range args {
+		var arg interface{}
+		switch in := method.In(i); in {
+		case contextType:
+			arg = ctx
+		case reflect.TypeOf(currency.Pair{}):
+			arg = p.pair
+		case reflect.TypeOf(currency.Code{}):
+			arg = p.pair.Base
+		case reflect.TypeOf(asset.Item(0)):
+			arg = p.asset
+		case reflect.TypeOf(kline.Interval(0)):
+			arg = p.interval
+		case reflect.TypeOf(time.Time{}):
+			arg = p.candleStart
+			if times > 0 {
+				arg = p.candleEnd
+			}
+			times++
+		case reflect.TypeOf(&order.Submit{}):
+			arg = p.submission(exchName)
+		case reflect.TypeOf(&order.Cancel{}):
+			arg = p.cancellation(exchName)
+		case reflect.TypeOf([]order.Cancel{}):
+			arg = []order.Cancel{*p.cancellation(exchName)}
+		case reflect.TypeOf(&order.Modify{}):
+			arg = &order.Modify{
+				Exchange:  exchName,
+				OrderID:   p.orderID,
+				Type:      p.orderType,
+				Side:      p.orderSide,
+				AssetType: p.asset,
+				Pair:      p.pair,
+				Price:     p.orderPrice,
+				Amount:    p.orderAmount,
+			}
+		case reflect.TypeOf(&order.GetOrdersRequest{}):
+			arg = &order.GetOrdersRequest{
+				Type:      order.AnyType,
+				Side:      p.orderSide,
+				Pairs:     currency.Pairs{p.pair},
+				AssetType: p.asset,
+			}
+		case reflect.TypeOf(&withdraw.Request{}):
+			arg = &withdraw.Request{
+				Exchange: exchName,
+				Currency: p.pair.Base,
+				Amount:   p.orderAmount,
+			}
+		default:
+			switch {
+			case name == "GetOrderInfo" && in.Kind() == reflect.String:
+				args[i] = reflect.ValueOf(p.orderID)
+			case in.Kind() == reflect.Ptr:
+				args[i] = reflect.New(in.Elem())
+			default:
+				args[i] = reflect.Zero(in)
+			}
+			continue
+		}
+		args[i] = reflect.ValueOf(arg)
+	}
+	return args
+}
+
+// cancellation returns the order cancelled by cancellation methods
+func (p *parameters) cancellation(exchName string) *order.Cancel {
+	return &order.Cancel{
+		Exchange:  exchName,
+		OrderID:   p.orderID,
+		Side:      p.orderSide,
+		Pair:      p.pair,
+		AssetType: p.asset,
+	}
+}
+
+// testMethod calls an IBotExchange method and classifies its outcome
+func testMethod(ctx context.Context, e exchange.IBotExchange, p *parameters, name string) MethodResult {
+	result := MethodResult{Method: name}
+	if reason, ok := skippedMethods[name]; ok {
+		result.Status = StatusSkipped
+		result.Error = reason
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, methodTimeout)
+	defer cancel()
+	method := reflect.ValueOf(e).MethodByName(name)
+	outputs, panicked := callMethod(method, p.arguments(ctx, e.GetName(), name, method.Type()))
+	if panicked != nil {
+		result.Status = StatusPanic
+		result.Error = fmt.Sprint(panicked)
+		return result
+	}
+	outputs, err := splitError(outputs)
+	if err != nil {
+		result.Status = classifyError(err)
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = StatusPass
+	if validate, ok := validators[name]; ok {
+		result.Violations = validate(ctx, e, p, outputs)
+		if len(result.Violations) > 0 {
+			result.Status = StatusViolation
+		}
+	}
+	return result
+}
+
+// callMethod calls a method, recovering from any panic it raises
+func callMethod(method reflect.Value, args []reflect.Value) (outputs []reflect.Value, panicked interface{}) {
+	defer func() {
+		panicked = recover()
+	}()
+	return method.Call(args), nil
+}
+
+// splitError separates a method's returned error from its other outputs
+func splitError(outputs []reflect.Value) ([]reflect.Value, error) {
+	if len(outputs) == 0 || outputs[len(outputs)-1].Type() != errorType {
+		return outputs, nil
+	}
+	last := outputs[len(outputs)-1]
+	if last.IsNil() {
+		return outputs[:len(outputs)-1], nil
+	}
+	err, _ := last.Interface().(error)
+	return outputs[:len(outputs)-1], err
+}
+
+// classifyError returns the status of a method which returned an error
+func classifyError(err error) string {
+	switch {
+	case errors.Is(err, common.ErrNotYetImplemented):
+		return StatusNotImplemented
+	case errors.Is(err, common.ErrFunctionNotSupported),
+		errors.Is(err, asset.ErrNotSupported):
+		return StatusNotSupported
+	default:
+		return StatusError
+	}
+}
+
+func validateTicker(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	t, _ := outputs[0].Interface().(*ticker.Price)
+	return checkTicker(t, p)
+}
+
+func validateOrderbook(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	b, _ := outputs[0].Interface().(*orderbook.Base)
+	return checkOrderbook(b, p)
+}
+
+func validateCandles(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	item, _ := outputs[0].Interface().(kline.Item)
+	return checkCandles(&item, p)
+}
+
+func validateTrades(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	trades, _ := outputs[0].Interface().([]trade.Data)
+	return checkTrades(trades, p)
+}
+
+func validateTradablePairs(_ context.Context, _ exchange.IBotExchange, _ *parameters, outputs []reflect.Value) []string {
+	pairs, _ := outputs[0].Interface().(currency.Pairs)
+	return checkTradablePairs(pairs)
+}
+
+func validateOrderInfo(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	d, _ := outputs[0].Interface().(order.Detail)
+	return checkOrderDetail(&d, p.orderID, p.pair)
+}
+
+func validateOrders(_ context.Context, _ exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	orders, _ := outputs[0].Interface().(order.FilteredOrders)
+	return checkOrders(orders, p)
+}
+
+// validateSubmitOrder retrieves the submitted order and validates its details
+// round trip
+func validateSubmitOrder(ctx context.Context, e exchange.IBotExchange, p *parameters, outputs []reflect.Value) []string {
+	resp, _ := outputs[0].Interface().(*order.SubmitResponse)
+	if resp == nil {
+		return []string{"nil submit response returned"}
+	}
+	if resp.OrderID == "" {
+		return []string{"submitted order has no order ID"}
+	}
+	d, err := e.GetOrderInfo(ctx, resp.OrderID, p.pair, p.asset)
+	if err != nil {
+		return []string{fmt.Sprintf("submitted order %s could not be retrieved: %v", resp.OrderID, err)}
+	}
+	return checkOrderRoundTrip(p.submission(e.GetName()), resp, &d)
+}
+
+// conforms returns false if any exchange failed to be set up, panicked or
+// violated an invariant
+func conforms(reports []ExchangeReport) bool {
+	for i := range reports {
+		if reports[i].Error != "" {
+			return false
+		}
+		for j := range reports[i].Results {
+			switch reports[i].Results[j].Status {
+			case StatusPanic, StatusViolation:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// outputToConsole prints the capability matrix followed by the details of
+// each setup failure, panic and invariant violation
+func outputToConsole(w io.Writer, reports []ExchangeReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"METHOD"}
+	for i := range reports {
+		header = append(header, reports[i].Exchange)
+	}
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	var methods []string
+	for i := range reports {
+		if len(reports[i].Results) > 0 {
+			for j := range reports[i].Results {
+				methods = append(methods, reports[i].Results[j].Method)
+			}
+			break
+		}
+	}
+	for i := range methods {
+		row := []string{methods[i]}
+		for j := range reports {
+			status := "SETUP FAILED"
+			if i < len(reports[j].Results) {
+				status = reports[j].Results[i].Status
+			}
+			row = append(row, status)
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for i := range reports {
+		if reports[i].Error != "" {
+			if _, err := fmt.Fprintf(w, "\n%s setup failed: %s\n", reports[i].Exchange, reports[i].Error); err != nil {
+				return err
+			}
+			continue
+		}
+		counts := make(map[string]int)
+		var details []string
+		for j := range reports[i].Results {
+			r := &reports[i].Results[j]
+			counts[r.Status]++
+			switch r.Status {
+			case StatusPanic:
+				details = append(details, fmt.Sprintf("\t%s panicked: %s", r.Method, r.Error))
+			case StatusViolation:
+				for k := range r.Violations {
+					details = append(details, fmt.Sprintf("\t%s: %s", r.Method, r.Violations[k]))
+				}
+			}
+		}
+		_, err := fmt.Fprintf(w, "\n%s %v %v: %d passed, %d violations, %d errors, %d not supported, %d not implemented, %d panics, %d skipped\n",
+			reports[i].Exchange,
+			reports[i].Asset,
+			reports[i].Pair,
+			counts[StatusPass],
+			counts[StatusViolation],
+			counts[StatusError],
+			counts[StatusNotSupported],
+			counts[StatusNotImplemented],
+			counts[StatusPanic],
+			counts[StatusSkipped])
+		if err != nil {
+			return err
+		}
+		for j := range details {
+			if _, err = fmt.Fprintln(w, details[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func outputToJSON(reports []ExchangeReport) error {
+	payload, err := json.MarshalIndent(reports, "", " ")
+	if err != nil {
+		return err
+	}
+	log.Printf("Outputting to: %v", outputFileName)
+	return file.Write(outputFileName, payload)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Method statuses shown in the capability matrix
+const (
+	StatusPass           = "PASS"
+	StatusViolation      = "VIOLATION"
+	StatusError          = "ERROR"
+	StatusNotSupported   = "NOT SUPPORTED"
+	StatusNotImplemented = "NOT IMPLEMENTED"
+	StatusPanic          = "PANIC"
+	StatusSkipped        = "SKIPPED"
+)
+
+// variables for command line overrides
+var (
+	configFileName     string
+	testConfigFileName string
+	mockDirectory      string
+	exchangesToUse     string
+	outputFormat       string
+	outputFileName     string
+)
+
+// Config the data structure for conformance.json which sets the parameters
+// each exchange is tested with so that requests match its mock recording
+type Config struct {
+	Exchanges map[string]*ExchangeConfig `json:"exchanges"`
+}
+
+// ExchangeConfig holds the conformance parameters for an exchange. Unset
+// values are derived from the exchange's test configuration
+type ExchangeConfig struct {
+	// EndpointPath is appended to the mock server URL when it replaces the
+	// exchange's API endpoints
+	EndpointPath   string          `json:"endpointPath"`
+	Asset          string          `json:"asset"`
+	Pair           string          `json:"pair"`
+	CandleStart    time.Time       `json:"candleStart"`
+	CandleEnd      time.Time       `json:"candleEnd"`
+	CandleInterval string          `json:"candleInterval"`
+	OrderID        string          `json:"orderID"`
+	Order          OrderSubmission `json:"order"`
+	// CrossedOrderbook allows the best bid to be above the best ask for
+	// exchanges which list unmatched offers, such as peer to peer markets
+	CrossedOrderbook bool `json:"crossedOrderbook"`
+}
+
+// OrderSubmission holds the order submitted to test order round tripping
+type OrderSubmission struct {
+	Side   string  `json:"side"`
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount"`
+	Price  float64 `json:"price"`
+}
+
+// ExchangeReport holds the conformance results of an exchange
+type ExchangeReport struct {
+	Exchange string         `json:"exchange"`
+	Asset    asset.Item     `json:"asset"`
+	Pair     currency.Pair  `json:"pair"`
+	Error    string         `json:"error,omitempty"`
+	Results  []MethodResult `json:"results"`
+}
+
+// MethodResult holds the outcome of calling an IBotExchange method
+type MethodResult struct {
+	Method     string   `json:"method"`
+	Status     string   `json:"status"`
+	Error      string   `json:"error,omitempty"`
+	Violations []string `json:"violations,omitempty"`
+}
+
+// parameters holds the resolved arguments IBotExchange methods are called
+// with
+type parameters struct {
+	asset       asset.Item
+	pair        currency.Pair
+	candleStart time.Time
+	candleEnd   time.Time
+	interval    kline.Interval
+	orderID     string
+	orderSide   order.Side
+	orderType   order.Type
+	orderAmount float64
+	orderPrice  float64
+
+	crossedOrderbook bool
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var arbitrageFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "exchange",
+		Usage: "only returns opportunities with a leg on the exchange",
+	},
+	&cli.StringFlag{
+		Name:  "type",
+		Usage: "only returns opportunities of the type e.g. triangular or cross_exchange",
+	},
+}
+
+var arbitrageCommand = &cli.Command{
+	Name:      "arbitrage",
+	Usage:     "arbitrage manager opportunity commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "returns the opportunities found by the most recent arbitrage scan",
+			ArgsUsage: "<exchange> <type>",
+			Flags:     arbitrageFlags,
+			Action:    getArbitrageOpportunities,
+		},
+		{
+			Name:      "stream",
+			Usage:     "streams arbitrage opportunities as they are found",
+			ArgsUsage: "<exchange> <type>",
+			Flags:     arbitrageFlags,
+			Action:    getArbitrageOpportunityStream,
+		},
+	},
+}
+
+func getArbitrageOpportunitiesRequest(c *cli.Context) *gctrpc.GetArbitrageOpportunitiesRequest {
+	req := &gctrpc.GetArbitrageOpportunitiesRequest{}
+	if c.IsSet("exchange") {
+		req.Exchange = c.String("exchange")
+	} else {
+		req.Exchange = c.Args().First()
+	}
+	if c.IsSet("type") {
+		req.Type = c.String("type")
+	} else {
+		req.Type = c.Args().Get(1)
+	}
+	return req
+}
+
+func getArbitrageOpportunities(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetArbitrageOpportunities(c.Context, getArbitrageOpportunitiesRequest(c))
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getArbitrageOpportunityStream(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetArbitrageOpportunityStream(c.Context, getArbitrageOpportunitiesRequest(c))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Arbitrage opportunity stream:")
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
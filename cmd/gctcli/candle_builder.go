@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var getBuiltCandlesCommand = &cli.Command{
+	Name:      "getbuiltcandles",
+	Usage:     "gets candles built from live websocket trades by the candle builder",
+	ArgsUsage: "<exchange> <pair> <asset> <interval>",
+	Action:    getBuiltCandles,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "the exchange to get the candles for",
+		},
+		&cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get the candles for",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+		&cli.Int64Flag{
+			Name:    "interval",
+			Aliases: []string{"i"},
+			Usage:   "interval in seconds, must be an interval configured for the candle builder",
+			Value:   60,
+		},
+		&cli.BoolFlag{
+			Name:  "includecurrent",
+			Usage: "includes the in-progress candle",
+		},
+	},
+}
+
+func getBuiltCandles(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().First()
+	}
+
+	currencyPair := c.String("pair")
+	if !c.IsSet("pair") {
+		currencyPair = c.Args().Get(1)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	assetType := c.String("asset")
+	if !c.IsSet("asset") {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	interval := c.Int64("interval")
+	if !c.IsSet("interval") && c.Args().Get(3) != "" {
+		interval, err = strconv.ParseInt(c.Args().Get(3), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetBuiltCandles(c.Context, &gctrpc.GetBuiltCandlesRequest{
+		Exchange: exchangeName,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		},
+		AssetType:      assetType,
+		TimeInterval:   int64(time.Duration(interval) * time.Second),
+		IncludeCurrent: c.Bool("includecurrent"),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -599,6 +599,10 @@ var getAccountInfoCommand = &cli.Command{
 			Name:  "asset",
 			Usage: "the asset type to get the account info for",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -635,6 +639,7 @@ func getAccountInfo(c *cli.Context) error {
 		&gctrpc.GetAccountInfoRequest{
 			Exchange:  exchange,
 			AssetType: assetType,
+			Account:   c.String("account"),
 		},
 	)
 	if err != nil {
@@ -659,6 +664,10 @@ var getAccountInfoStreamCommand = &cli.Command{
 			Name:  "asset",
 			Usage: "the asset type to get the account info stream for",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -694,7 +703,7 @@ func getAccountInfoStream(c *cli.Context) error {
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
 	result, err := client.GetAccountInfoStream(c.Context,
-		&gctrpc.GetAccountInfoRequest{Exchange: exchangeName, AssetType: assetType})
+		&gctrpc.GetAccountInfoRequest{Exchange: exchangeName, AssetType: assetType, Account: c.String("account")})
 	if err != nil {
 		return err
 	}
@@ -730,6 +739,10 @@ var updateAccountInfoCommand = &cli.Command{
 			Name:  "asset",
 			Usage: "the asset type to get the account info for",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -767,6 +780,7 @@ func updateAccountInfo(c *cli.Context) error {
 		&gctrpc.GetAccountInfoRequest{
 			Exchange:  exchange,
 			AssetType: assetType,
+			Account:   c.String("account"),
 		},
 	)
 	if err != nil {
@@ -1106,6 +1120,10 @@ var getOrdersCommand = &cli.Command{
 			Value:       time.Now().Format(common.SimpleTimeFormat),
 			Destination: &endTime,
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1192,6 +1210,7 @@ func getOrders(c *cli.Context) error {
 		},
 		StartDate: s.Format(common.SimpleTimeFormatWithTimezone),
 		EndDate:   e.Format(common.SimpleTimeFormatWithTimezone),
+		Account:   c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1219,6 +1238,10 @@ var getManagedOrdersCommand = &cli.Command{
 			Name:  "pair",
 			Usage: "the currency pair to get orders for",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1278,6 +1301,7 @@ func getManagedOrders(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
+		Account: c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1309,6 +1333,10 @@ var getOrderCommand = &cli.Command{
 			Name:  "order_id",
 			Usage: "the order id to retrieve",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1372,7 +1400,8 @@ func getOrder(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		Asset: assetType,
+		Asset:   assetType,
+		Account: c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1420,6 +1449,10 @@ var submitOrderCommand = &cli.Command{
 			Name:  "asset",
 			Usage: "required asset type",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1540,6 +1573,7 @@ func submitOrder(c *cli.Context) error {
 		Price:     price,
 		ClientId:  clientID,
 		AssetType: assetType,
+		Account:   c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1688,6 +1722,10 @@ var cancelOrderCommand = &cli.Command{
 			Name:  "side",
 			Usage: "the order side",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1787,6 +1825,7 @@ func cancelOrder(c *cli.Context) error {
 		AssetType:     assetType,
 		WalletAddress: walletAddress,
 		Side:          orderSide,
+		Account:       c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1830,6 +1869,10 @@ var cancelBatchOrdersCommand = &cli.Command{
 			Name:  "side",
 			Usage: "the order side",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1929,6 +1972,7 @@ func cancelBatchOrders(c *cli.Context) error {
 		AssetType:     assetType,
 		WalletAddress: walletAddress,
 		Side:          orderSide,
+		Account:       c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -1948,6 +1992,10 @@ var cancelAllOrdersCommand = &cli.Command{
 			Name:  "exchange",
 			Usage: "the exchange to cancel all orders on",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -1981,6 +2029,10 @@ var modifyOrderCommand = &cli.Command{
 			Name:  "amount",
 			Usage: "new order amount",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -2001,6 +2053,7 @@ func cancelAllOrders(c *cli.Context) error {
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
 	result, err := client.CancelAllOrders(c.Context, &gctrpc.CancelAllOrdersRequest{
 		Exchange: exchangeName,
+		Account:  c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -2087,9 +2140,10 @@ func modifyOrder(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		Asset:  assetType,
-		Price:  price,
-		Amount: amount,
+		Asset:   assetType,
+		Price:   price,
+		Amount:  amount,
+		Account: c.String("account"),
 	})
 	if err != nil {
 		return err
@@ -2566,6 +2620,10 @@ var withdrawCryptocurrencyFundsCommand = &cli.Command{
 			Name:  "chain",
 			Usage: "chain to use for the withdrawal",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -2649,6 +2707,7 @@ func withdrawCryptocurrencyFunds(c *cli.Context) error {
 			Fee:         fee,
 			Description: description,
 			Chain:       chain,
+			Account:     c.String("account"),
 		},
 	)
 	if err != nil {
@@ -2684,6 +2743,10 @@ var withdrawFiatFundsCommand = &cli.Command{
 			Name:  "description",
 			Usage: "description to submit with request",
 		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
 	},
 }
 
@@ -2742,6 +2805,7 @@ func withdrawFiatFunds(c *cli.Context) error {
 			Amount:        amount,
 			Description:   description,
 			BankAccountId: bankAccountID,
+			Account:       c.String("account"),
 		},
 	)
 	if err != nil {
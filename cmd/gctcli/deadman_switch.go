@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var deadManHeartbeatCommand = &cli.Command{
+	Name:   "deadmanheartbeat",
+	Usage:  "sends a heartbeat to the dead man's switch, deferring the cancellation of open orders until the heartbeat timeout elapses",
+	Action: deadManHeartbeat,
+}
+
+func deadManHeartbeat(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.DeadManHeartbeat(c.Context, &gctrpc.DeadManHeartbeatRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
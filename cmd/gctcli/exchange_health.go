@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var getExchangeHealthCommand = &cli.Command{
+	Name:      "getexchangehealth",
+	Usage:     "gets the circuit breaker state and health score of exchange REST endpoints",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeHealth,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "optional, the exchange to get the health of, defaults to all loaded exchanges",
+		},
+	},
+}
+
+func getExchangeHealth(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetExchangeHealth(c.Context, &gctrpc.GetExchangeHealthRequest{
+		Exchange: exchangeName,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -175,6 +175,10 @@ var futuresCommands = &cli.Command{
 					Aliases: []string{"predicted", "pr"},
 					Usage:   "if true, will return the predicted funding rate - requires --getfundingdata",
 				},
+				&cli.StringFlag{
+					Name:  "account",
+					Usage: "the named exchange account to use, defaults to the exchange credentials",
+				},
 			},
 		},
 		{
@@ -209,6 +213,10 @@ var futuresCommands = &cli.Command{
 					Aliases: []string{"z"},
 					Usage:   "include collateral values that are zero",
 				},
+				&cli.StringFlag{
+					Name:  "account",
+					Usage: "the named exchange account to use, defaults to the exchange credentials",
+				},
 			},
 		},
 		{
@@ -622,6 +630,7 @@ func getFuturesPositions(c *cli.Context) error {
 			GetFundingPayments:      getFundingData,
 			IncludeFullFundingRates: includeFundingEntries,
 			IncludePredictedRate:    includePredicted,
+			Account:                 c.String("account"),
 		})
 	if err != nil {
 		return err
@@ -696,6 +705,7 @@ func getCollateral(c *cli.Context) error {
 			IncludeBreakdown:  includeBreakdown,
 			CalculateOffline:  calculateOffline,
 			IncludeZeroValues: includeZeroValues,
+			Account:           c.String("account"),
 		})
 	if err != nil {
 		return err
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var internalTransferCommand = &cli.Command{
+	Name:      "internaltransfer",
+	Usage:     "transfers funds between the asset wallets and sub-accounts of an exchange",
+	ArgsUsage: "<exchange> <currency> <amount> <from> <to>",
+	Action:    internalTransfer,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to transfer funds on",
+		},
+		&cli.StringFlag{
+			Name:  "currency",
+			Usage: "the currency to transfer",
+		},
+		&cli.Float64Flag{
+			Name:  "amount",
+			Usage: "amount of funds to transfer",
+		},
+		&cli.StringFlag{
+			Name:  "from",
+			Usage: "the asset type wallet to transfer funds from",
+		},
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "the asset type wallet to transfer funds to",
+		},
+		&cli.StringFlag{
+			Name:  "fromsubaccount",
+			Usage: "the sub-account to transfer funds from, defaults to the main account",
+		},
+		&cli.StringFlag{
+			Name:  "tosubaccount",
+			Usage: "the sub-account to transfer funds to, defaults to the main account",
+		},
+		&cli.StringFlag{
+			Name:  "account",
+			Usage: "the named exchange account to use, defaults to the exchange credentials",
+		},
+	},
+}
+
+func internalTransfer(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchange, cur, from, to string
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else {
+		exchange = c.Args().Get(0)
+	}
+
+	if c.IsSet("currency") {
+		cur = c.String("currency")
+	} else {
+		cur = c.Args().Get(1)
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(2) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(2), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.IsSet("from") {
+		from = c.String("from")
+	} else {
+		from = c.Args().Get(3)
+	}
+	if !validAsset(from) {
+		return errInvalidAsset
+	}
+
+	if c.IsSet("to") {
+		to = c.String("to")
+	} else {
+		to = c.Args().Get(4)
+	}
+	if !validAsset(to) {
+		return errInvalidAsset
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.InternalTransfer(c.Context, &gctrpc.InternalTransferRequest{
+		Exchange:       exchange,
+		Currency:       cur,
+		Amount:         amount,
+		FromAsset:      from,
+		ToAsset:        to,
+		FromSubAccount: c.String("fromsubaccount"),
+		ToSubAccount:   c.String("tosubaccount"),
+		Account:        c.String("account"),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
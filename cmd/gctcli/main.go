@@ -198,6 +198,7 @@ func main() {
 		getAuditEventCommand,
 		getHistoricCandlesCommand,
 		getHistoricCandlesExtendedCommand,
+		getBuiltCandlesCommand,
 		findMissingSavedCandleIntervalsCommand,
 		gctScriptCommand,
 		websocketManagerCommand,
@@ -209,6 +210,15 @@ func main() {
 		technicalAnalysisCommand,
 		getMarginRatesHistoryCommand,
 		orderbookCommand,
+		arbitrageCommand,
+		rebalanceCommand,
+		portfolioHistoryCommand,
+		taxLotsCommand,
+		reconcileOrdersCommand,
+		riskLimitsCommand,
+		deadManHeartbeatCommand,
+		internalTransferCommand,
+		getExchangeHealthCommand,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -49,6 +49,8 @@ var orderbookCommand = &cli.Command{
 		getOrderbookStreamCommand,
 		getExchangeOrderbookStreamCommand,
 		whaleBombCommand,
+		getOrderbookDesyncsCommand,
+		consolidatedOrderbookCommand,
 	},
 }
 
@@ -766,3 +768,261 @@ func whaleBomb(c *cli.Context) error {
 	jsonOutput(result)
 	return nil
 }
+
+var getOrderbookDesyncsCommand = &cli.Command{
+	Name:      "getdesyncs",
+	Usage:     "gets orderbook integrity failure and resync counts per currency pair",
+	ArgsUsage: "<exchange>",
+	Action:    getOrderbookDesyncs,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get desyncs for, all exchanges are returned when empty",
+		},
+	},
+}
+
+func getOrderbookDesyncs(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetOrderbookDesyncs(c.Context,
+		&gctrpc.GetOrderbookDesyncsRequest{
+			Exchange: exchangeName,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+var consolidatedOrderbookFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "pair",
+		Usage: "the currency pair to consolidate, the quote currency is the currency prices are normalised to",
+	},
+	&cli.StringFlag{
+		Name:  "asset",
+		Usage: "the asset type of the currency pair",
+	},
+	&cli.StringSliceFlag{
+		Name:  "exchanges",
+		Usage: "comma delimited list of exchanges to consolidate, all enabled exchanges are used when empty",
+	},
+	&cli.BoolFlag{
+		Name:  "feeadjusted",
+		Usage: "adjusts prices by each exchange taker fee",
+	},
+}
+
+var consolidatedOrderbookCommand = &cli.Command{
+	Name:      "consolidated",
+	Usage:     "consolidated orderbook across exchanges commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "gets the consolidated orderbook for a currency pair across exchanges",
+			ArgsUsage: "<pair> <asset>",
+			Action:    getConsolidatedOrderbook,
+			Flags: append(consolidatedOrderbookFlags, &cli.Int64Flag{
+				Name:  "depth",
+				Usage: "the number of levels to return on each side, all levels are returned when zero",
+			}),
+		},
+		{
+			Name:      "stream",
+			Usage:     "streams the consolidated orderbook for a currency pair across exchanges",
+			ArgsUsage: "<pair> <asset>",
+			Action:    getConsolidatedOrderbookStream,
+			Flags: append(consolidatedOrderbookFlags, &cli.Int64Flag{
+				Name:  "depth",
+				Usage: "the number of levels to return on each side",
+				Value: 10,
+			}),
+		},
+		{
+			Name:      "movement",
+			Usage:     "simulates a buy or sell across the consolidated orderbook to derive liquidity impact information",
+			ArgsUsage: "<pair> <asset> <amount>",
+			Action:    getConsolidatedOrderbookMovement,
+			Flags: append(consolidatedOrderbookFlags,
+				&cli.Float64Flag{
+					Name:  "amount",
+					Usage: "the amount of quote currency to spend when buying or base currency to sell when selling",
+				},
+				&cli.BoolFlag{
+					Name:  "sell",
+					Usage: "hits the bids instead of lifting the asks",
+				},
+				&cli.BoolFlag{
+					Name:  "purchase",
+					Usage: "the amount refers to the currency to be purchased instead of deployed",
+				},
+			),
+		},
+	},
+}
+
+// getConsolidatedOrderbookRequest parses the common consolidated orderbook
+// arguments
+func getConsolidatedOrderbookRequest(c *cli.Context) (*gctrpc.GetConsolidatedOrderbookRequest, error) {
+	var pair string
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().First()
+	}
+	if !validPair(pair) {
+		return nil, errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(pair, pairDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return nil, errInvalidAsset
+	}
+
+	return &gctrpc.GetConsolidatedOrderbookRequest{
+		Pair: &gctrpc.CurrencyPair{
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+			Delimiter: p.Delimiter,
+		},
+		Asset:       assetType,
+		Exchanges:   c.StringSlice("exchanges"),
+		FeeAdjusted: c.Bool("feeadjusted"),
+		Depth:       c.Int64("depth"),
+	}, nil
+}
+
+func getConsolidatedOrderbook(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	req, err := getConsolidatedOrderbookRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetConsolidatedOrderbook(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getConsolidatedOrderbookStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	req, err := getConsolidatedOrderbookRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetConsolidatedOrderbookStream(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		err = clearScreen()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Consolidated orderbook stream for %s-%s:\n\n", resp.Pair.Base, resp.Pair.Quote)
+		jsonOutput(resp)
+	}
+}
+
+func getConsolidatedOrderbookMovement(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	req, err := getConsolidatedOrderbookRequest(c)
+	if err != nil {
+		return err
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else {
+		amount, err = strconv.ParseFloat(c.Args().Get(2), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetConsolidatedOrderbookMovement(c.Context,
+		&gctrpc.GetConsolidatedOrderbookMovementRequest{
+			Pair:        req.Pair,
+			Asset:       req.Asset,
+			Exchanges:   req.Exchanges,
+			FeeAdjusted: req.FeeAdjusted,
+			Amount:      amount,
+			Sell:        c.Bool("sell"),
+			Purchase:    c.Bool("purchase"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
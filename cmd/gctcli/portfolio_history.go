@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var portfolioHistoryFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "start",
+		Usage:       "start date, defaults to one month ago",
+		Value:       time.Now().AddDate(0, -1, 0).Format(common.SimpleTimeFormat),
+		Destination: &startTime,
+	},
+	&cli.StringFlag{
+		Name:        "end",
+		Usage:       "end date, defaults to now",
+		Value:       time.Now().Format(common.SimpleTimeFormat),
+		Destination: &endTime,
+	},
+	&cli.StringFlag{
+		Name:  "exchange",
+		Usage: "optional, limits results to an exchange or 'wallet' for portfolio addresses",
+	},
+}
+
+var portfolioHistoryCommand = &cli.Command{
+	Name:      "portfoliohistory",
+	Usage:     "portfolio history manager value and performance commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "value",
+			Usage:     "returns the value of holdings at each saved snapshot",
+			ArgsUsage: "<start> <end> <exchange>",
+			Flags:     portfolioHistoryFlags,
+			Action:    getPortfolioHistory,
+		},
+		{
+			Name:      "performance",
+			Usage:     "returns the realised and unrealised PnL per asset, excluding deposits and withdrawals",
+			ArgsUsage: "<start> <end> <exchange>",
+			Flags:     portfolioHistoryFlags,
+			Action:    getPortfolioPerformance,
+		},
+		{
+			Name:      "flows",
+			Usage:     "returns the recorded exchange deposits and withdrawals",
+			ArgsUsage: "<start> <end> <exchange>",
+			Flags:     portfolioHistoryFlags,
+			Action:    getPortfolioFlows,
+		},
+	},
+}
+
+// getPortfolioHistoryRequest builds a request from flags or positional
+// arguments
+func getPortfolioHistoryRequest(c *cli.Context) (*gctrpc.GetPortfolioHistoryRequest, error) {
+	if !c.IsSet("start") && c.Args().Get(0) != "" {
+		startTime = c.Args().Get(0)
+	}
+	if !c.IsSet("end") && c.Args().Get(1) != "" {
+		endTime = c.Args().Get(1)
+	}
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(2)
+	}
+	s, err := time.ParseInLocation(common.SimpleTimeFormat, startTime, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.ParseInLocation(common.SimpleTimeFormat, endTime, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time format for end: %v", err)
+	}
+	if e.Before(s) {
+		return nil, errors.New("start cannot be after end")
+	}
+	return &gctrpc.GetPortfolioHistoryRequest{
+		Start:    s.Format(common.SimpleTimeFormatWithTimezone),
+		End:      e.Format(common.SimpleTimeFormatWithTimezone),
+		Exchange: exchangeName,
+	}, nil
+}
+
+func getPortfolioHistory(c *cli.Context) error {
+	req, err := getPortfolioHistoryRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPortfolioHistory(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getPortfolioPerformance(c *cli.Context) error {
+	req, err := getPortfolioHistoryRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPortfolioPerformance(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getPortfolioFlows(c *cli.Context) error {
+	req, err := getPortfolioHistoryRequest(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPortfolioFlows(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
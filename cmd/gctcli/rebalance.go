@@ -0,0 +1,136 @@
+package main
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var rebalancePlanFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "id",
+		Usage: "the rebalance plan ID",
+	},
+}
+
+var rebalanceCommand = &cli.Command{
+	Name:      "rebalance",
+	Usage:     "rebalance manager plan commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "returns the retained rebalance plans, or a single plan by ID",
+			ArgsUsage: "<id>",
+			Flags:     rebalancePlanFlags,
+			Action:    getRebalancePlans,
+		},
+		{
+			Name:   "propose",
+			Usage:  "evaluates holdings against the target allocations and proposes a plan",
+			Action: proposeRebalance,
+		},
+		{
+			Name:      "approve",
+			Usage:     "executes a rebalance plan which is pending approval",
+			ArgsUsage: "<id>",
+			Flags:     rebalancePlanFlags,
+			Action:    approveRebalancePlan,
+		},
+		{
+			Name:      "reject",
+			Usage:     "discards a rebalance plan which is pending approval",
+			ArgsUsage: "<id>",
+			Flags:     rebalancePlanFlags,
+			Action:    rejectRebalancePlan,
+		},
+	},
+}
+
+func getRebalancePlanID(c *cli.Context) string {
+	if c.IsSet("id") {
+		return c.String("id")
+	}
+	return c.Args().First()
+}
+
+func getRebalancePlans(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetRebalancePlans(c.Context, &gctrpc.GetRebalancePlansRequest{
+		Id: getRebalancePlanID(c),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func proposeRebalance(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ProposeRebalance(c.Context, &gctrpc.ProposeRebalanceRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func approveRebalancePlan(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ApproveRebalancePlan(c.Context, &gctrpc.RebalancePlanRequest{
+		Id: getRebalancePlanID(c),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func rejectRebalancePlan(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.RejectRebalancePlan(c.Context, &gctrpc.RebalancePlanRequest{
+		Id: getRebalancePlanID(c),
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
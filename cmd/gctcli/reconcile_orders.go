@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var reconcileOrdersCommand = &cli.Command{
+	Name:      "reconcileorders",
+	Usage:     "compares tracked orders and balances with those reported by exchanges",
+	ArgsUsage: "<exchange> <start> <end>",
+	Action:    reconcileOrders,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "optional, limits the reconciliation to an exchange",
+		},
+		&cli.StringFlag{
+			Name:  "start",
+			Usage: "optional, start date of the order history compared, defaults to the configured window",
+		},
+		&cli.StringFlag{
+			Name:  "end",
+			Usage: "optional, end date of the order history compared, defaults to now",
+		},
+	},
+}
+
+func reconcileOrders(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(0)
+	}
+	start := c.String("start")
+	if !c.IsSet("start") {
+		start = c.Args().Get(1)
+	}
+	end := c.String("end")
+	if !c.IsSet("end") {
+		end = c.Args().Get(2)
+	}
+
+	req := &gctrpc.ReconcileOrdersRequest{Exchange: exchangeName}
+	var s, e time.Time
+	var err error
+	if start != "" {
+		s, err = time.ParseInLocation(common.SimpleTimeFormat, start, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for start: %v", err)
+		}
+		req.Start = s.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if end != "" {
+		e, err = time.ParseInLocation(common.SimpleTimeFormat, end, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for end: %v", err)
+		}
+		req.End = e.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if !s.IsZero() && !e.IsZero() && e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ReconcileOrders(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
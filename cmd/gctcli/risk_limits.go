@@ -0,0 +1,154 @@
+package main
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var riskLimitsCommand = &cli.Command{
+	Name:      "risklimits",
+	Usage:     "order manager pre-trade risk limit and kill switch commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "get",
+			Usage:  "returns the risk limits and whether the kill switch is engaged",
+			Action: getRiskLimits,
+		},
+		{
+			Name:      "set",
+			Usage:     "sets the risk limit of an exchange, account or sub-account, or the default risk limit when no exchange is provided. Unset values disable the control",
+			ArgsUsage: "<exchange> <subaccount>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "optional, the exchange to set the risk limit of",
+				},
+				&cli.StringFlag{
+					Name:  "account",
+					Usage: "optional, the named exchange account to set the risk limit of",
+				},
+				&cli.StringFlag{
+					Name:  "subaccount",
+					Usage: "optional, the exchange sub-account to set the risk limit of",
+				},
+				&cli.Float64Flag{
+					Name:  "maxordernotional",
+					Usage: "the largest order value in the notional currency",
+				},
+				&cli.StringFlag{
+					Name:  "notionalcurrency",
+					Usage: "optional, the currency of maxordernotional, defaulting to the quote currency of each pair",
+				},
+				&cli.Float64Flag{
+					Name:  "maxposition",
+					Usage: "the largest net position of a pair in the base currency",
+				},
+				&cli.Int64Flag{
+					Name:  "maxopenorders",
+					Usage: "the largest number of open orders",
+				},
+				&cli.Float64Flag{
+					Name:  "maxdailyloss",
+					Usage: "the largest loss in the quote currency of orders placed since midnight UTC",
+				},
+				&cli.Float64Flag{
+					Name:  "priceband",
+					Usage: "the largest percentage distance of an order price from the ticker mid price",
+				},
+				&cli.Float64Flag{
+					Name:  "fatfinger",
+					Usage: "the largest percentage distance of an order price from the orderbook mid price",
+				},
+			},
+			Action: setRiskLimit,
+		},
+		{
+			Name:  "killswitch",
+			Usage: "engages the kill switch, rejecting all orders and cancelling open orders on all exchanges",
+			Action: func(c *cli.Context) error {
+				return setKillSwitch(c, true)
+			},
+		},
+		{
+			Name:  "release",
+			Usage: "releases the kill switch so orders can be submitted",
+			Action: func(c *cli.Context) error {
+				return setKillSwitch(c, false)
+			},
+		},
+	},
+}
+
+func getRiskLimits(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetRiskLimits(c.Context, &gctrpc.GetRiskLimitsRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func setRiskLimit(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(0)
+	}
+	subAccount := c.String("subaccount")
+	if !c.IsSet("subaccount") {
+		subAccount = c.Args().Get(1)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SetRiskLimit(c.Context, &gctrpc.SetRiskLimitRequest{
+		Limit: &gctrpc.RiskLimit{
+			Exchange:            exchangeName,
+			SubAccount:          subAccount,
+			Account:             c.String("account"),
+			MaxOrderNotional:    c.Float64("maxordernotional"),
+			NotionalCurrency:    c.String("notionalcurrency"),
+			MaxPositionPerPair:  c.Float64("maxposition"),
+			MaxOpenOrders:       c.Int64("maxopenorders"),
+			MaxDailyLoss:        c.Float64("maxdailyloss"),
+			PriceBandPercentage: c.Float64("priceband"),
+			FatFingerPercentage: c.Float64("fatfinger"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func setKillSwitch(c *cli.Context, engage bool) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SetKillSwitch(c.Context, &gctrpc.SetKillSwitchRequest{Engage: engage})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var taxLotsCommand = &cli.Command{
+	Name:      "taxlots",
+	Usage:     "tax lot manager lot and capital gains commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "report",
+			Usage:     "returns the realised capital gains of a calendar year",
+			ArgsUsage: "<year> <method>",
+			Flags: []cli.Flag{
+				&cli.Int64Flag{
+					Name:  "year",
+					Usage: "the calendar year to report, defaults to last year",
+					Value: int64(time.Now().Year() - 1),
+				},
+				&cli.StringFlag{
+					Name:  "method",
+					Usage: "optional, the lot matching method FIFO, LIFO, HIFO or AVERAGE, defaults to the configured method",
+				},
+				&cli.StringFlag{
+					Name:  "format",
+					Usage: "optional, csv or json to output the report in that format instead of the response",
+				},
+				&cli.StringFlag{
+					Name:  "output",
+					Usage: "optional, the file to write the formatted report to",
+				},
+			},
+			Action: getCapitalGainsReport,
+		},
+		{
+			Name:      "lots",
+			Usage:     "returns the lots currently held",
+			ArgsUsage: "<exchange> <method>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "optional, limits lots to an exchange",
+				},
+				&cli.StringFlag{
+					Name:  "method",
+					Usage: "optional, the lot matching method FIFO, LIFO, HIFO or AVERAGE, defaults to the configured method",
+				},
+			},
+			Action: getTaxLots,
+		},
+	},
+}
+
+func getCapitalGainsReport(c *cli.Context) error {
+	year := c.Int64("year")
+	if !c.IsSet("year") && c.Args().Get(0) != "" {
+		var err error
+		year, err = strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid year: %v", err)
+		}
+	}
+	method := c.String("method")
+	if !c.IsSet("method") {
+		method = c.Args().Get(1)
+	}
+	format := c.String("format")
+	output := c.String("output")
+	if output != "" && format == "" {
+		return fmt.Errorf("a format is required to write the report to %s", output)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetCapitalGainsReport(c.Context,
+		&gctrpc.GetCapitalGainsReportRequest{
+			Year:   year,
+			Method: method,
+			Format: format,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case output != "":
+		if err := os.WriteFile(output, []byte(result.Data), 0o600); err != nil {
+			return err
+		}
+		fmt.Printf("Capital gains report for %d written to %s\n", result.Year, output)
+	case format != "":
+		fmt.Print(result.Data)
+	default:
+		jsonOutput(result)
+	}
+	return nil
+}
+
+func getTaxLots(c *cli.Context) error {
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().Get(0)
+	}
+	method := c.String("method")
+	if !c.IsSet("method") {
+		method = c.Args().Get(1)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetTaxLots(c.Context,
+		&gctrpc.GetTaxLotsRequest{
+			Exchange: exchangeName,
+			Method:   method,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -24,6 +24,14 @@ var (
 	taMovingAverageType string
 	taStdDevUp          float64
 	taStdDevDown        float64
+	taSecondaryPeriod   int64
+	taSmoothPeriod      int64
+	taSignalPeriod      int64
+	taDisplacement      int64
+	taMultiplier        float64
+	taAccelerationStep  float64
+	taAccelerationMax   float64
+	taPivotMethod       string
 )
 
 var commonFlag = []cli.Flag{
@@ -98,6 +106,81 @@ var (
 		Destination: &taMovingAverageType,
 	}
 
+	smoothFlag = &cli.Int64Flag{
+		Name:        "smoothperiod",
+		Usage:       "denotes the %K smoothing period for stochastic generation",
+		Value:       3,
+		Destination: &taSmoothPeriod,
+	}
+	signalFlag = &cli.Int64Flag{
+		Name:        "signalperiod",
+		Usage:       "denotes the %D signal period for stochastic generation",
+		Value:       3,
+		Destination: &taSignalPeriod,
+	}
+	stochPeriodFlag = &cli.Int64Flag{
+		Name:        "stochasticperiod",
+		Usage:       "denotes the stochastic lookback period applied to the rsi, defaults to period",
+		Destination: &taSecondaryPeriod,
+	}
+	atrPeriodFlag = &cli.Int64Flag{
+		Name:        "atrperiod",
+		Usage:       "denotes the average true range period for the channel width, defaults to period",
+		Destination: &taSecondaryPeriod,
+	}
+	multiplierFlag = &cli.Float64Flag{
+		Name:        "multiplier",
+		Usage:       "the multiplier applied to the band or channel width",
+		Value:       2,
+		Destination: &taMultiplier,
+	}
+	ichimokuFlags = []cli.Flag{
+		&cli.Int64Flag{
+			Name:        "conversionperiod",
+			Usage:       "denotes the conversion line (tenkan-sen) period",
+			Value:       9,
+			Destination: &taFastPeriod,
+		},
+		&cli.Int64Flag{
+			Name:        "baseperiod",
+			Usage:       "denotes the base line (kijun-sen) period",
+			Value:       26,
+			Destination: &taPeriod,
+		},
+		&cli.Int64Flag{
+			Name:        "spanbperiod",
+			Usage:       "denotes the leading span b (senkou span b) period",
+			Value:       52,
+			Destination: &taSlowPeriod,
+		},
+		&cli.Int64Flag{
+			Name:        "displacement",
+			Usage:       "denotes the displacement of the leading and lagging spans",
+			Value:       26,
+			Destination: &taDisplacement,
+		},
+	}
+	sarFlags = []cli.Flag{
+		&cli.Float64Flag{
+			Name:        "accelerationstep",
+			Usage:       "the acceleration factor step",
+			Value:       0.02,
+			Destination: &taAccelerationStep,
+		},
+		&cli.Float64Flag{
+			Name:        "accelerationmax",
+			Usage:       "the maximum acceleration factor",
+			Value:       0.2,
+			Destination: &taAccelerationMax,
+		},
+	}
+	pivotMethodFlag = &cli.StringFlag{
+		Name:        "pivotmethod",
+		Usage:       "defines the pivot point calculation ('classic'/'fibonacci'/'woodie'/'camarilla')",
+		Value:       "classic",
+		Destination: &taPivotMethod,
+	}
+
 	otherAssetFlag = []cli.Flag{
 		&cli.StringFlag{
 			Name:    "comparisonexchange",
@@ -200,6 +283,97 @@ var technicalAnalysisCommand = &cli.Command{
 			Flags:     append(commonFlag, periodFlag),
 			Action:    getRSI,
 		},
+		{
+			Name:      "stoch",
+			Usage:     "returns the stochastic oscillator",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, smoothFlag, signalFlag),
+			Action:    getStochastic,
+		},
+		{
+			Name:      "stochrsi",
+			Usage:     "returns the stochastic relative strength index",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, stochPeriodFlag, smoothFlag, signalFlag),
+			Action:    getStochasticRSI,
+		},
+		{
+			Name:      "adx",
+			Usage:     "returns the average directional index and directional indicators",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getADX,
+		},
+		{
+			Name:      "ichimoku",
+			Usage:     "returns the ichimoku cloud",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, ichimokuFlags...),
+			Action:    getIchimoku,
+		},
+		{
+			Name:      "keltner",
+			Usage:     "returns the keltner channels",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, atrPeriodFlag, multiplierFlag),
+			Action:    getKeltner,
+		},
+		{
+			Name:      "donchian",
+			Usage:     "returns the donchian channels",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getDonchian,
+		},
+		{
+			Name:      "supertrend",
+			Usage:     "returns the supertrend",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, multiplierFlag),
+			Action:    getSupertrend,
+		},
+		{
+			Name:      "vwapbands",
+			Usage:     "returns the volume weighted average price with standard deviation bands",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, multiplierFlag),
+			Action:    getVWAPBands,
+		},
+		{
+			Name:      "cci",
+			Usage:     "returns the commodity channel index",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getCCI,
+		},
+		{
+			Name:      "willr",
+			Usage:     "returns the williams %R",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getWilliamsR,
+		},
+		{
+			Name:      "sar",
+			Usage:     "returns the parabolic stop and reverse",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, sarFlags...),
+			Action:    getParabolicSAR,
+		},
+		{
+			Name:      "heikinashi",
+			Usage:     "returns heikin-ashi candles",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     commonFlag,
+			Action:    getHeikinAshi,
+		},
+		{
+			Name:      "pivot",
+			Usage:     "returns the pivot points derived from the previous candle",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, pivotMethodFlag),
+			Action:    getPivotPoints,
+		},
 	},
 }
 
@@ -235,6 +409,58 @@ func getRSI(c *cli.Context) error {
 	return getTecnicalAnalysis(c, "RSI")
 }
 
+func getStochastic(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "STOCH")
+}
+
+func getStochasticRSI(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "STOCHRSI")
+}
+
+func getADX(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "ADX")
+}
+
+func getIchimoku(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "ICHIMOKU")
+}
+
+func getKeltner(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "KELTNER")
+}
+
+func getDonchian(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "DONCHIAN")
+}
+
+func getSupertrend(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "SUPERTREND")
+}
+
+func getVWAPBands(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "VWAPBANDS")
+}
+
+func getCCI(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "CCI")
+}
+
+func getWilliamsR(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "WILLR")
+}
+
+func getParabolicSAR(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "SAR")
+}
+
+func getHeikinAshi(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "HEIKINASHI")
+}
+
+func getPivotPoints(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "PIVOT")
+}
+
 func getTecnicalAnalysis(c *cli.Context, algo string) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
@@ -338,6 +564,18 @@ func getTecnicalAnalysis(c *cli.Context, algo string) error {
 		Start:         timestamppb.New(s),
 		End:           timestamppb.New(e),
 		Period:        taPeriod,
+		// The following are only used by the algorithms that define their
+		// respective flags and are otherwise ignored.
+		FastPeriod:          taFastPeriod,
+		SlowPeriod:          taSlowPeriod,
+		SecondaryPeriod:     taSecondaryPeriod,
+		SmoothPeriod:        taSmoothPeriod,
+		SignalPeriod:        taSignalPeriod,
+		Displacement:        taDisplacement,
+		Multiplier:          taMultiplier,
+		AccelerationStep:    taAccelerationStep,
+		AccelerationMaximum: taAccelerationMax,
+		PivotMethod:         taPivotMethod,
 	}
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
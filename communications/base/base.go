@@ -1,6 +1,9 @@
 package base
 
 import (
+	"context"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -11,6 +14,9 @@ type Base struct {
 	Verbose        bool
 	Connected      bool
 	ServiceStarted time.Time
+	// EventTypes filters the events pushed by the relayer, all events are
+	// pushed when empty
+	EventTypes []string
 }
 
 // Event is a generalise event type
@@ -19,6 +25,30 @@ type Event struct {
 	Message string
 }
 
+// InboundMessage is a message received by a relayer from a chat user
+type InboundMessage struct {
+	Relayer  string
+	UserID   string
+	UserName string
+	Text     string
+	// CommandPrefix is the character commands are prefixed with on the
+	// relayer, used to format replies
+	CommandPrefix string
+}
+
+// CommandHandler handles commands received by relayers and returns the reply
+// to send to the user
+type CommandHandler interface {
+	HandleMessage(ctx context.Context, msg *InboundMessage) string
+}
+
+// CommandReceiver stores the command handler of a relayer which receives
+// inbound messages, the handler can be set after the relayer has connected
+type CommandReceiver struct {
+	mu      sync.RWMutex
+	handler CommandHandler
+}
+
 // CommsStatus stores the status of a comms relayer
 type CommsStatus struct {
 	Enabled   bool `json:"enabled"`
@@ -41,6 +71,35 @@ func (b *Base) GetName() string {
 	return b.Name
 }
 
+// IsEventTypeEnabled returns if events of the supplied type should be pushed
+// by the relayer
+func (b *Base) IsEventTypeEnabled(eventType string) bool {
+	if len(b.EventTypes) == 0 {
+		return true
+	}
+	for x := range b.EventTypes {
+		if strings.EqualFold(b.EventTypes[x], eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCommandHandler sets the handler inbound commands are passed to
+func (c *CommandReceiver) SetCommandHandler(h CommandHandler) {
+	c.mu.Lock()
+	c.handler = h
+	c.mu.Unlock()
+}
+
+// GetCommandHandler returns the handler inbound commands are passed to, nil
+// when commands are not enabled
+func (c *CommandReceiver) GetCommandHandler() CommandHandler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.handler
+}
+
 // GetStatus returns status data
 func (b *Base) GetStatus() string {
 	return `
@@ -60,6 +119,10 @@ type CommunicationsConfig struct {
 	SMSGlobalConfig SMSGlobalConfig `json:"smsGlobal"`
 	SMTPConfig      SMTPConfig      `json:"smtp"`
 	TelegramConfig  TelegramConfig  `json:"telegram"`
+	DiscordConfig   DiscordConfig   `json:"discord"`
+	MatrixConfig    MatrixConfig    `json:"matrix"`
+	WebhookConfig   WebhookConfig   `json:"webhook"`
+	CommandsConfig  CommandsConfig  `json:"commands"`
 }
 
 // IsAnyEnabled returns whether or any any comms relayers
@@ -68,7 +131,10 @@ func (c *CommunicationsConfig) IsAnyEnabled() bool {
 	if c.SMSGlobalConfig.Enabled ||
 		c.SMTPConfig.Enabled ||
 		c.SlackConfig.Enabled ||
-		c.TelegramConfig.Enabled {
+		c.TelegramConfig.Enabled ||
+		c.DiscordConfig.Enabled ||
+		c.MatrixConfig.Enabled ||
+		c.WebhookConfig.Enabled {
 		return true
 	}
 	return false
@@ -122,3 +188,63 @@ type TelegramConfig struct {
 	Verbose           bool   `json:"verbose"`
 	VerificationToken string `json:"verificationToken"`
 }
+
+// DiscordConfig holds all variables to start and run the Discord package. When
+// WebhookURL is set events are sent to the webhook, otherwise they are sent to
+// ChannelID by the bot authenticated with BotToken
+type DiscordConfig struct {
+	Name       string   `json:"name"`
+	Enabled    bool     `json:"enabled"`
+	Verbose    bool     `json:"verbose"`
+	WebhookURL string   `json:"webhookURL"`
+	BotToken   string   `json:"botToken"`
+	ChannelID  string   `json:"channelID"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+// MatrixConfig holds all variables to start and run the Matrix package
+type MatrixConfig struct {
+	Name          string   `json:"name"`
+	Enabled       bool     `json:"enabled"`
+	Verbose       bool     `json:"verbose"`
+	HomeserverURL string   `json:"homeserverURL"`
+	AccessToken   string   `json:"accessToken"`
+	RoomID        string   `json:"roomID"`
+	EventTypes    []string `json:"eventTypes"`
+}
+
+// WebhookConfig holds all variables to start and run the Webhook package.
+// BodyTemplate is a text/template executed with the event to build the request
+// body and requests are signed with HMAC-SHA256 when Secret is set
+type WebhookConfig struct {
+	Name            string            `json:"name"`
+	Enabled         bool              `json:"enabled"`
+	Verbose         bool              `json:"verbose"`
+	URL             string            `json:"url"`
+	Method          string            `json:"method"`
+	Headers         map[string]string `json:"headers"`
+	BodyTemplate    string            `json:"bodyTemplate"`
+	Secret          string            `json:"secret"`
+	SignatureHeader string            `json:"signatureHeader"`
+	MaxRetries      int               `json:"maxRetries"`
+	RetryDelay      time.Duration     `json:"retryDelay"`
+	EventTypes      []string          `json:"eventTypes"`
+}
+
+// CommandsConfig holds the chat commands users are permitted to run via
+// relayers which receive inbound messages
+type CommandsConfig struct {
+	Enabled bool `json:"enabled"`
+	// ConfirmationTimeout is how long a destructive command awaits
+	// confirmation before it is discarded
+	ConfirmationTimeout time.Duration `json:"confirmationTimeout"`
+	Users               []CommandUser `json:"users"`
+}
+
+// CommandUser is a chat user permitted to run commands. Commands lists the
+// command names the user may run, "*" permits all commands
+type CommandUser struct {
+	Relayer  string   `json:"relayer"`
+	UserID   string   `json:"userID"`
+	Commands []string `json:"commands"`
+}
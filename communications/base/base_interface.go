@@ -21,6 +21,12 @@ type ICommunicate interface {
 	SetServiceStarted(time.Time)
 }
 
+// ICommandReceiver is implemented by relayers which receive inbound messages
+// and can pass commands to a command handler
+type ICommandReceiver interface {
+	SetCommandHandler(CommandHandler)
+}
+
 // Setup sets up communication variables and initiates a connection to the
 // communication mediums
 func (c IComm) Setup() {
@@ -50,6 +56,21 @@ func (c IComm) PushEvent(event Event) {
 	}
 }
 
+// SetCommandHandler sets the command handler of every relayer which receives
+// inbound messages and returns the names of those relayers
+func (c IComm) SetCommandHandler(h CommandHandler) []string {
+	var receivers []string
+	for i := range c {
+		r, ok := c[i].(ICommandReceiver)
+		if !ok {
+			continue
+		}
+		r.SetCommandHandler(h)
+		receivers = append(receivers, c[i].GetName())
+	}
+	return receivers
+}
+
 // GetStatus returns the status of the comms relayers
 func (c IComm) GetStatus() map[string]CommsStatus {
 	result := make(map[string]CommsStatus)
@@ -1,6 +1,7 @@
 package base
 
 import (
+	"context"
 	"testing"
 	"time"
 )
@@ -48,6 +49,24 @@ func TestSetServiceStarted(t *testing.T) {
 	}
 }
 
+func TestIsEventTypeEnabled(t *testing.T) {
+	t.Parallel()
+	var filter Base
+	if !filter.IsEventTypeEnabled("order") {
+		t.Error("expected all event types to be enabled when no filter is set")
+	}
+	filter.EventTypes = []string{"order", "RISK"}
+	if !filter.IsEventTypeEnabled("order") {
+		t.Error("expected order event type to be enabled")
+	}
+	if !filter.IsEventTypeEnabled("risk") {
+		t.Error("expected event type matching to be case insensitive")
+	}
+	if filter.IsEventTypeEnabled("rebalance") {
+		t.Error("expected rebalance event type to be filtered")
+	}
+}
+
 type CommunicationProvider struct {
 	ICommunicate
 
@@ -151,3 +170,34 @@ func TestPushEvent(t *testing.T) {
 		}
 	}
 }
+
+type commandProvider struct {
+	CommunicationProvider
+	CommandReceiver
+}
+
+type testCommandHandler struct{}
+
+func (testCommandHandler) HandleMessage(context.Context, *InboundMessage) string {
+	return "handled"
+}
+
+func TestSetCommandHandler(t *testing.T) {
+	t.Parallel()
+	receiver := new(commandProvider)
+	ic := IComm{&CommunicationProvider{}, receiver}
+	if receiver.GetCommandHandler() != nil {
+		t.Fatal("expected nil command handler")
+	}
+	receivers := ic.SetCommandHandler(testCommandHandler{})
+	if len(receivers) != 1 || receivers[0] != "someTestProvider" {
+		t.Fatalf("expected only the command receiver to be set, received %v", receivers)
+	}
+	h := receiver.GetCommandHandler()
+	if h == nil {
+		t.Fatal("expected command handler to be set")
+	}
+	if reply := h.HandleMessage(context.Background(), &InboundMessage{}); reply != "handled" {
+		t.Errorf("unexpected reply %s", reply)
+	}
+}
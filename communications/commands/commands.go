@@ -0,0 +1,256 @@
+// Package commands maps chat commands received by communication relayers to
+// trading operations. Users are authenticated against a per-user allowlist,
+// destructive commands must be confirmed before they run and every command is
+// audit logged.
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewHandler returns a command handler which permits the configured users
+func NewHandler(cfg *base.CommandsConfig) (*Handler, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	h := &Handler{
+		confirmationTimeout: cfg.ConfirmationTimeout,
+		users:               make(map[string]map[string]bool, len(cfg.Users)),
+		commands:            make(map[string]*Command),
+		pending:             make(map[string]*confirmation),
+	}
+	if h.confirmationTimeout <= 0 {
+		h.confirmationTimeout = DefaultConfirmationTimeout
+	}
+	for i := range cfg.Users {
+		if cfg.Users[i].Relayer == "" || cfg.Users[i].UserID == "" {
+			return nil, fmt.Errorf("%w, user %d", errInvalidCommandUser, i)
+		}
+		key := userKey(cfg.Users[i].Relayer, cfg.Users[i].UserID)
+		if _, ok := h.users[key]; ok {
+			return nil, fmt.Errorf("%w %s", errDuplicateUser, key)
+		}
+		permitted := make(map[string]bool, len(cfg.Users[i].Commands))
+		for j := range cfg.Users[i].Commands {
+			permitted[strings.ToLower(cfg.Users[i].Commands[j])] = true
+		}
+		h.users[key] = permitted
+	}
+	return h, nil
+}
+
+// Register adds a command which permitted users can run
+func (h *Handler) Register(cmd *Command) error {
+	if cmd == nil {
+		return errNilCommand
+	}
+	name := strings.ToLower(cmd.Name)
+	if name == "" {
+		return errCommandNameEmpty
+	}
+	if cmd.Action == nil {
+		return fmt.Errorf("%s %w", name, errCommandActionNil)
+	}
+	switch name {
+	case cmdHelp, cmdConfirm, cmdCancel:
+		return fmt.Errorf("%s %w", name, errCommandReserved)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.commands[name]; ok {
+		return fmt.Errorf("%s %w", name, errCommandExists)
+	}
+	c := *cmd
+	c.Name = name
+	h.commands[name] = &c
+	return nil
+}
+
+// HandleMessage authenticates the sender of a message and runs the command it
+// contains, returning the reply to send to the user
+func (h *Handler) HandleMessage(ctx context.Context, msg *base.InboundMessage) string {
+	if msg == nil {
+		return ""
+	}
+	name, args := parseCommand(msg.Text, msg.CommandPrefix)
+	if name == "" {
+		return ""
+	}
+	user := userKey(msg.Relayer, msg.UserID)
+
+	h.mu.Lock()
+	permitted, ok := h.users[user]
+	h.mu.Unlock()
+	if !ok {
+		h.audit(user, msg, "denied unauthorised user", name, args)
+		return "You are not authorised to run commands"
+	}
+
+	switch name {
+	case cmdHelp:
+		return h.help(permitted, msg.CommandPrefix)
+	case cmdConfirm:
+		return h.confirm(ctx, user, msg, args)
+	case cmdCancel:
+		h.mu.Lock()
+		pending, ok := h.pending[user]
+		delete(h.pending, user)
+		h.mu.Unlock()
+		if !ok {
+			return errNoPendingCommand.Error()
+		}
+		h.audit(user, msg, "cancelled", pending.command.Name, pending.args)
+		return fmt.Sprintf("Cancelled %s", pending.command.Name)
+	}
+
+	h.mu.Lock()
+	cmd, ok := h.commands[name]
+	h.mu.Unlock()
+	if !ok {
+		return fmt.Sprintf("Command %s not recognised, send %s%s for a list of commands", name, msg.CommandPrefix, cmdHelp)
+	}
+	if !permitted[AllCommands] && !permitted[name] {
+		h.audit(user, msg, "denied", name, args)
+		return fmt.Sprintf("You are not permitted to run %s", name)
+	}
+	if len(args) < cmd.MinArgs {
+		return fmt.Sprintf("%v, usage: %s%s %s", errInsufficientArgs, msg.CommandPrefix, name, cmd.Usage)
+	}
+
+	if !cmd.Destructive {
+		return h.run(ctx, user, msg, cmd, args)
+	}
+
+	code, err := newConfirmationCode()
+	if err != nil {
+		log.Errorf(log.CommunicationMgr, "Commands: unable to generate confirmation code: %v", err)
+		return "Unable to request confirmation"
+	}
+	h.mu.Lock()
+	h.pending[user] = &confirmation{
+		command: cmd,
+		args:    args,
+		code:    code,
+		expires: time.Now().Add(h.confirmationTimeout),
+	}
+	h.mu.Unlock()
+	h.audit(user, msg, "awaiting confirmation", name, args)
+	return fmt.Sprintf("Send %s%s %s within %v to run %s, or %s%s to abort",
+		msg.CommandPrefix, cmdConfirm, code, h.confirmationTimeout,
+		strings.TrimSpace(name+" "+strings.Join(args, " ")),
+		msg.CommandPrefix, cmdCancel)
+}
+
+// confirm runs the destructive command awaiting confirmation by the user when
+// the code matches
+func (h *Handler) confirm(ctx context.Context, user string, msg *base.InboundMessage, args []string) string {
+	h.mu.Lock()
+	pending, ok := h.pending[user]
+	if !ok {
+		h.mu.Unlock()
+		return errNoPendingCommand.Error()
+	}
+	if time.Now().After(pending.expires) {
+		delete(h.pending, user)
+		h.mu.Unlock()
+		h.audit(user, msg, "confirmation expired", pending.command.Name, pending.args)
+		return fmt.Sprintf("%s %v", pending.command.Name, errConfirmationExpired)
+	}
+	if len(args) == 0 || args[0] != pending.code {
+		h.mu.Unlock()
+		h.audit(user, msg, "confirmation rejected", pending.command.Name, pending.args)
+		return errConfirmationInvalid.Error()
+	}
+	delete(h.pending, user)
+	h.mu.Unlock()
+	return h.run(ctx, user, msg, pending.command, pending.args)
+}
+
+// run performs a command action and audits the outcome
+func (h *Handler) run(ctx context.Context, user string, msg *base.InboundMessage, cmd *Command, args []string) string {
+	reply, err := cmd.Action(ctx, args)
+	if err != nil {
+		h.audit(user, msg, "failed: "+err.Error(), cmd.Name, args)
+		return fmt.Sprintf("%s failed: %v", cmd.Name, err)
+	}
+	h.audit(user, msg, "ran", cmd.Name, args)
+	return reply
+}
+
+// help lists the commands a user is permitted to run
+func (h *Handler) help(permitted map[string]bool, prefix string) string {
+	h.mu.Lock()
+	names := make([]string, 0, len(h.commands))
+	for name := range h.commands {
+		if permitted[AllCommands] || permitted[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	sb.WriteString("Available commands:")
+	for i := range names {
+		cmd := h.commands[names[i]]
+		sb.WriteString("\n" + prefix + strings.TrimSpace(cmd.Name+" "+cmd.Usage) + " - " + cmd.Description)
+		if cmd.Destructive {
+			sb.WriteString(" (requires confirmation)")
+		}
+	}
+	h.mu.Unlock()
+	sb.WriteString("\n" + prefix + cmdConfirm + " <code> - Confirms a pending command")
+	sb.WriteString("\n" + prefix + cmdCancel + " - Cancels a pending command")
+	sb.WriteString("\n" + prefix + cmdHelp + " - Displays this list")
+	return sb.String()
+}
+
+// audit logs and records a command event against the user
+func (h *Handler) audit(user string, msg *base.InboundMessage, outcome, name string, args []string) {
+	event := fmt.Sprintf("%s user %s (%s) %s command %s",
+		msg.Relayer, msg.UserID, msg.UserName, outcome,
+		strings.TrimSpace(name+" "+strings.Join(args, " ")))
+	log.Infoln(log.CommunicationMgr, "Commands: "+event)
+	audit.Event(user, auditType, event)
+}
+
+// parseCommand splits a message into a lower case command name and its
+// arguments. Messages which do not start with the relayer's command prefix
+// are not commands and return an empty name. Any bot mention suffix, such as
+// /help@bot, is removed
+func parseCommand(text, prefix string) (name string, args []string) {
+	if prefix == "" {
+		return "", nil
+	}
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], prefix) {
+		return "", nil
+	}
+	name = strings.TrimPrefix(fields[0], prefix)
+	if i := strings.IndexByte(name, '@'); i != -1 {
+		name = name[:i]
+	}
+	return strings.ToLower(name), fields[1:]
+}
+
+// userKey returns the allowlist key of a relayer user
+func userKey(relayer, userID string) string {
+	return strings.ToLower(relayer) + ":" + userID
+}
+
+// newConfirmationCode returns a random six digit code
+func newConfirmationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(confirmationCodeMax))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,263 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+var errTestAction = errors.New("test action error")
+
+// newTestHandler returns a handler permitting an operator to run every
+// command and a viewer to run positions, with a positions, a failing and a
+// destructive command registered
+func newTestHandler(t *testing.T) (*Handler, *[]string) {
+	t.Helper()
+	h, err := NewHandler(&base.CommandsConfig{
+		Users: []base.CommandUser{
+			{Relayer: "Telegram", UserID: "1", Commands: []string{AllCommands}},
+			{Relayer: "Slack", UserID: "U2", Commands: []string{"Positions"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ran []string
+	record := func(name, reply string, err error) Action {
+		return func(_ context.Context, args []string) (string, error) {
+			ran = append(ran, strings.TrimSpace(name+" "+strings.Join(args, " ")))
+			return reply, err
+		}
+	}
+	for _, cmd := range []*Command{
+		{Name: "positions", Description: "Shows positions", Action: record("positions", "no positions", nil)},
+		{Name: "broken", Description: "Always fails", Action: record("broken", "", errTestAction)},
+		{Name: "disable", Usage: "<exchange>", Description: "Disables an exchange", MinArgs: 1, Destructive: true, Action: record("disable", "disabled", nil)},
+	} {
+		if err = h.Register(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return h, &ran
+}
+
+func telegramMessage(text string) *base.InboundMessage {
+	return &base.InboundMessage{Relayer: "Telegram", UserID: "1", UserName: "operator", Text: text, CommandPrefix: "/"}
+}
+
+func slackMessage(text string) *base.InboundMessage {
+	return &base.InboundMessage{Relayer: "Slack", UserID: "U2", UserName: "viewer", Text: text, CommandPrefix: "!"}
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Parallel()
+	_, err := NewHandler(nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilConfig)
+	}
+
+	_, err = NewHandler(&base.CommandsConfig{Users: []base.CommandUser{{Relayer: "Telegram"}}})
+	if !errors.Is(err, errInvalidCommandUser) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidCommandUser)
+	}
+
+	_, err = NewHandler(&base.CommandsConfig{Users: []base.CommandUser{
+		{Relayer: "Telegram", UserID: "1"},
+		{Relayer: "telegram", UserID: "1"},
+	}})
+	if !errors.Is(err, errDuplicateUser) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errDuplicateUser)
+	}
+
+	h, err := NewHandler(&base.CommandsConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.confirmationTimeout != DefaultConfirmationTimeout {
+		t.Errorf("expected default confirmation timeout, received %v", h.confirmationTimeout)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	h, _ := newTestHandler(t)
+	err := h.Register(nil)
+	if !errors.Is(err, errNilCommand) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilCommand)
+	}
+	err = h.Register(&Command{})
+	if !errors.Is(err, errCommandNameEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCommandNameEmpty)
+	}
+	err = h.Register(&Command{Name: "pnl"})
+	if !errors.Is(err, errCommandActionNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCommandActionNil)
+	}
+	action := func(context.Context, []string) (string, error) { return "", nil }
+	err = h.Register(&Command{Name: "Confirm", Action: action})
+	if !errors.Is(err, errCommandReserved) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCommandReserved)
+	}
+	err = h.Register(&Command{Name: "POSITIONS", Action: action})
+	if !errors.Is(err, errCommandExists) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCommandExists)
+	}
+}
+
+func TestHandleMessage(t *testing.T) {
+	t.Parallel()
+	h, ran := newTestHandler(t)
+	ctx := context.Background()
+
+	if reply := h.HandleMessage(ctx, nil); reply != "" {
+		t.Errorf("expected no reply for nil message, received %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, telegramMessage("  ")); reply != "" {
+		t.Errorf("expected no reply for empty message, received %s", reply)
+	}
+
+	if reply := h.HandleMessage(ctx, telegramMessage("positions please")); reply != "" {
+		t.Errorf("expected no reply for message without command prefix, received %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, slackMessage("/positions")); reply != "" {
+		t.Errorf("expected no reply for another relayer's command prefix, received %s", reply)
+	}
+	unknown := &base.InboundMessage{Relayer: "Telegram", UserID: "3", Text: "hello"}
+	if reply := h.HandleMessage(ctx, unknown); reply != "" {
+		t.Errorf("expected chat from unknown user to be ignored, received %s", reply)
+	}
+	unknown.Text, unknown.CommandPrefix = "/positions", "/"
+	if reply := h.HandleMessage(ctx, unknown); !strings.Contains(reply, "not authorised") {
+		t.Errorf("expected unknown user to be denied, received %s", reply)
+	}
+	wrongRelayer := &base.InboundMessage{Relayer: "Slack", UserID: "1", Text: "!positions", CommandPrefix: "!"}
+	if reply := h.HandleMessage(ctx, wrongRelayer); !strings.Contains(reply, "not authorised") {
+		t.Errorf("expected user ID on another relayer to be denied, received %s", reply)
+	}
+
+	if reply := h.HandleMessage(ctx, telegramMessage("/Positions@gctbot")); reply != "no positions" {
+		t.Errorf("unexpected positions reply %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, slackMessage("!positions")); reply != "no positions" {
+		t.Errorf("unexpected positions reply %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, slackMessage("!broken")); !strings.Contains(reply, "not permitted") {
+		t.Errorf("expected viewer to be denied broken, received %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, telegramMessage("/broken")); !strings.Contains(reply, errTestAction.Error()) {
+		t.Errorf("expected action error in reply, received %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, telegramMessage("/unknown")); !strings.Contains(reply, "/help") {
+		t.Errorf("expected unrecognised command reply, received %s", reply)
+	}
+	if reply := h.HandleMessage(ctx, telegramMessage("/disable")); !strings.Contains(reply, "<exchange>") {
+		t.Errorf("expected usage in reply, received %s", reply)
+	}
+	if len(*ran) != 3 {
+		t.Errorf("expected 3 commands to run, received %v", *ran)
+	}
+}
+
+func TestConfirmation(t *testing.T) {
+	t.Parallel()
+	h, ran := newTestHandler(t)
+	ctx := context.Background()
+
+	if reply := h.HandleMessage(ctx, telegramMessage("/confirm 123")); reply != errNoPendingCommand.Error() {
+		t.Errorf("unexpected reply %s", reply)
+	}
+
+	reply := h.HandleMessage(ctx, telegramMessage("/disable Bitstamp"))
+	pending := h.pending[userKey("Telegram", "1")]
+	if pending == nil {
+		t.Fatal("expected destructive command to await confirmation")
+	}
+	if !strings.Contains(reply, "/confirm "+pending.code) || !strings.Contains(reply, "disable Bitstamp") {
+		t.Errorf("unexpected confirmation prompt %s", reply)
+	}
+	if len(*ran) != 0 {
+		t.Fatalf("expected destructive command to not run before confirmation, ran %v", *ran)
+	}
+
+	if reply = h.HandleMessage(ctx, telegramMessage("/confirm wrong")); reply != errConfirmationInvalid.Error() {
+		t.Errorf("unexpected reply %s", reply)
+	}
+	if reply = h.HandleMessage(ctx, telegramMessage("/confirm "+pending.code)); reply != "disabled" {
+		t.Errorf("unexpected reply %s", reply)
+	}
+	if len(*ran) != 1 || (*ran)[0] != "disable Bitstamp" {
+		t.Fatalf("expected confirmed command to run with its arguments, ran %v", *ran)
+	}
+	if reply = h.HandleMessage(ctx, telegramMessage("/confirm "+pending.code)); reply != errNoPendingCommand.Error() {
+		t.Errorf("expected confirmation to only be used once, received %s", reply)
+	}
+
+	h.HandleMessage(ctx, telegramMessage("/disable Bitstamp"))
+	if reply = h.HandleMessage(ctx, telegramMessage("/cancel")); reply != "Cancelled disable" {
+		t.Errorf("unexpected reply %s", reply)
+	}
+	if reply = h.HandleMessage(ctx, telegramMessage("/cancel")); reply != errNoPendingCommand.Error() {
+		t.Errorf("unexpected reply %s", reply)
+	}
+
+	h.HandleMessage(ctx, telegramMessage("/disable Bitstamp"))
+	pending = h.pending[userKey("Telegram", "1")]
+	pending.expires = time.Now().Add(-time.Second)
+	if reply = h.HandleMessage(ctx, telegramMessage("/confirm "+pending.code)); !strings.Contains(reply, errConfirmationExpired.Error()) {
+		t.Errorf("unexpected reply %s", reply)
+	}
+	if len(*ran) != 1 {
+		t.Errorf("expected cancelled and expired commands to not run, ran %v", *ran)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	t.Parallel()
+	h, _ := newTestHandler(t)
+	ctx := context.Background()
+	reply := h.HandleMessage(ctx, telegramMessage("/help"))
+	for _, expected := range []string{"/broken", "/disable <exchange> - Disables an exchange (requires confirmation)", "/positions", "/confirm <code>"} {
+		if !strings.Contains(reply, expected) {
+			t.Errorf("expected help to contain %q, received %s", expected, reply)
+		}
+	}
+	reply = h.HandleMessage(ctx, slackMessage("!help"))
+	if !strings.Contains(reply, "!positions") || strings.Contains(reply, "disable") {
+		t.Errorf("expected help to only list permitted commands, received %s", reply)
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	t.Parallel()
+	name, args := parseCommand("!Approve  1234 now", "!")
+	if name != "approve" || len(args) != 2 || args[0] != "1234" {
+		t.Errorf("unexpected command %s %v", name, args)
+	}
+	name, args = parseCommand("/help@gctbot", "/")
+	if name != "help" || len(args) != 0 {
+		t.Errorf("unexpected command %s %v", name, args)
+	}
+	for _, text := range []string{"help", "!help", " ", "ok /help"} {
+		if name, _ = parseCommand(text, "/"); name != "" {
+			t.Errorf("expected %q to not be a command, received %s", text, name)
+		}
+	}
+	if name, _ = parseCommand("/help", ""); name != "" {
+		t.Errorf("expected no command without a prefix, received %s", name)
+	}
+}
+
+func TestNewConfirmationCode(t *testing.T) {
+	t.Parallel()
+	code, err := newConfirmationCode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != 6 {
+		t.Errorf("expected six digit code, received %s", code)
+	}
+}
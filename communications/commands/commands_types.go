@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// AllCommands permits a user to run every registered command
+	AllCommands = "*"
+	// DefaultConfirmationTimeout is how long a destructive command awaits
+	// confirmation when no timeout is configured
+	DefaultConfirmationTimeout = time.Minute
+
+	auditType = "chat_command"
+
+	cmdHelp    = "help"
+	cmdConfirm = "confirm"
+	cmdCancel  = "cancel"
+
+	// confirmationCodeMax bounds the random code a user replies with to
+	// confirm a destructive command
+	confirmationCodeMax = 1000000
+)
+
+var (
+	errNilConfig           = errors.New("nil commands config received")
+	errNilCommand          = errors.New("nil command received")
+	errCommandNameEmpty    = errors.New("command name cannot be empty")
+	errCommandActionNil    = errors.New("command action cannot be nil")
+	errCommandReserved     = errors.New("command name is reserved")
+	errCommandExists       = errors.New("command already registered")
+	errInvalidCommandUser  = errors.New("command user requires a relayer and user ID")
+	errDuplicateUser       = errors.New("command user is duplicated")
+	errInsufficientArgs    = errors.New("insufficient arguments")
+	errNoPendingCommand    = errors.New("no command is awaiting confirmation")
+	errConfirmationExpired = errors.New("confirmation expired")
+	errConfirmationInvalid = errors.New("confirmation code does not match")
+)
+
+// Action performs a command with the supplied arguments and returns the reply
+// sent to the user
+type Action func(ctx context.Context, args []string) (string, error)
+
+// Command defines a chat command and the action it runs
+type Command struct {
+	Name string
+	// Usage describes the arguments of the command, such as "<exchange>"
+	Usage       string
+	Description string
+	// MinArgs is the number of arguments the command requires
+	MinArgs int
+	// Destructive commands must be confirmed by the user before they are run
+	Destructive bool
+	Action      Action
+}
+
+// Handler authenticates chat users against an allowlist and runs the
+// commands they are permitted to, prompting for confirmation before running
+// destructive commands. Every command run, denied or confirmed is audited.
+type Handler struct {
+	confirmationTimeout time.Duration
+	// users maps relayer and user ID keys to the commands they may run
+	users    map[string]map[string]bool
+	commands map[string]*Command
+	// pending holds the destructive command awaiting confirmation per user
+	pending map[string]*confirmation
+	mu      sync.Mutex
+}
+
+// confirmation is a destructive command awaiting confirmation by a user
+type confirmation struct {
+	command *Command
+	args    []string
+	code    string
+	expires time.Time
+}
@@ -4,10 +4,13 @@ import (
 	"errors"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/discord"
+	"github.com/thrasher-corp/gocryptotrader/communications/matrix"
 	"github.com/thrasher-corp/gocryptotrader/communications/slack"
 	"github.com/thrasher-corp/gocryptotrader/communications/smsglobal"
 	"github.com/thrasher-corp/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-corp/gocryptotrader/communications/telegram"
+	"github.com/thrasher-corp/gocryptotrader/communications/webhook"
 )
 
 // Communications is the overarching type across the communications packages
@@ -49,6 +52,24 @@ func NewComm(cfg *base.CommunicationsConfig) (*Communications, error) {
 		comm.IComm = append(comm.IComm, Slack)
 	}
 
+	if cfg.DiscordConfig.Enabled {
+		Discord := new(discord.Discord)
+		Discord.Setup(cfg)
+		comm.IComm = append(comm.IComm, Discord)
+	}
+
+	if cfg.MatrixConfig.Enabled {
+		Matrix := new(matrix.Matrix)
+		Matrix.Setup(cfg)
+		comm.IComm = append(comm.IComm, Matrix)
+	}
+
+	if cfg.WebhookConfig.Enabled {
+		Webhook := new(webhook.Webhook)
+		Webhook.Setup(cfg)
+		comm.IComm = append(comm.IComm, Webhook)
+	}
+
 	comm.Setup()
 	return &comm, nil
 }
@@ -0,0 +1,174 @@
+// Package discord allows events to be sent to a Discord channel either by a bot
+// or a channel webhook using the API defined in
+// https://discord.com/developers/docs/intro
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	apiURL = "https://discord.com/api/v10"
+
+	pathCurrentUser     = "/users/@me"
+	pathChannelMessages = "/channels/%s/messages"
+
+	// maxMessageLength is the maximum amount of characters Discord accepts
+	// for message content
+	maxMessageLength = 2000
+	requestTimeout   = time.Second * 15
+)
+
+var (
+	errBotTokenNotSet  = errors.New("Discord bot token or webhook URL not set")
+	errChannelIDNotSet = errors.New("Discord channel ID not set")
+	errRequestFailed   = errors.New("Discord request failed")
+)
+
+// Discord is the overarching type across this package
+type Discord struct {
+	base.Base
+	WebhookURL string
+	BotToken   string
+	ChannelID  string
+
+	apiURL string
+	client *http.Client
+}
+
+// Setup takes in a Discord configuration and sets the webhook URL or bot
+// token and channel
+func (d *Discord) Setup(cfg *base.CommunicationsConfig) {
+	d.Name = cfg.DiscordConfig.Name
+	d.Enabled = cfg.DiscordConfig.Enabled
+	d.Verbose = cfg.DiscordConfig.Verbose
+	d.EventTypes = cfg.DiscordConfig.EventTypes
+	d.WebhookURL = cfg.DiscordConfig.WebhookURL
+	d.BotToken = cfg.DiscordConfig.BotToken
+	d.ChannelID = cfg.DiscordConfig.ChannelID
+	d.apiURL = apiURL
+	d.client = common.NewHTTPClientWithTimeout(requestTimeout)
+}
+
+// IsConnected returns whether or not the connection is connected
+func (d *Discord) IsConnected() bool { return d.Connected }
+
+// Connect verifies the webhook or bot credentials with Discord
+func (d *Discord) Connect() error {
+	if d.WebhookURL != "" {
+		var hook Webhook
+		if err := d.sendRequest(http.MethodGet, d.WebhookURL, false, nil, &hook); err != nil {
+			return err
+		}
+		log.Debugf(log.CommunicationMgr, "Discord: Connected successfully to webhook %s\n", hook.Name)
+		d.Connected = true
+		return nil
+	}
+
+	if d.BotToken == "" {
+		return errBotTokenNotSet
+	}
+	if d.ChannelID == "" {
+		return errChannelIDNotSet
+	}
+	var user User
+	if err := d.sendRequest(http.MethodGet, d.apiURL+pathCurrentUser, true, nil, &user); err != nil {
+		return err
+	}
+	log.Debugf(log.CommunicationMgr, "Discord: Connected successfully as %s\n", user.Username)
+	d.Connected = true
+	return nil
+}
+
+// PushEvent sends an event to the Discord channel if its type is not filtered
+func (d *Discord) PushEvent(event base.Event) error {
+	if !d.IsEventTypeEnabled(event.Type) {
+		return nil
+	}
+	return d.SendMessage(fmt.Sprintf("**%s** %s", event.Type, event.Message))
+}
+
+// SendMessage sends a message to the webhook or the bot's channel, messages
+// over the Discord limit are truncated
+func (d *Discord) SendMessage(message string) error {
+	if runes := []rune(message); len(runes) > maxMessageLength {
+		message = string(runes[:maxMessageLength])
+	}
+	if d.WebhookURL != "" {
+		return d.sendRequest(http.MethodPost, d.WebhookURL, false, &Message{Content: message, Username: d.Name}, nil)
+	}
+	if d.ChannelID == "" {
+		return errChannelIDNotSet
+	}
+	return d.sendRequest(http.MethodPost,
+		d.apiURL+fmt.Sprintf(pathChannelMessages, d.ChannelID),
+		true,
+		&Message{Content: message},
+		nil)
+}
+
+// sendRequest sends a JSON request to Discord and decodes the response into
+// result when supplied
+func (d *Discord) sendRequest(method, path string, authenticated bool, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if authenticated {
+		req.Header.Set("Authorization", "Bot "+d.BotToken)
+	}
+
+	if d.client == nil {
+		d.client = common.NewHTTPClientWithTimeout(requestTimeout)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if d.Verbose {
+		log.Debugf(log.CommunicationMgr, "Discord: %s %s status %d response: %s\n", method, path, resp.StatusCode, contents)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErr APIError
+		if json.Unmarshal(contents, &apiErr) == nil && apiErr.Message != "" {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				return fmt.Errorf("%w: status %d: %s, retry after %vs", errRequestFailed, resp.StatusCode, apiErr.Message, apiErr.RetryAfter)
+			}
+			return fmt.Errorf("%w: status %d: %s", errRequestFailed, resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("%w: status %d", errRequestFailed, resp.StatusCode)
+	}
+
+	if result == nil || len(contents) == 0 {
+		return nil
+	}
+	return json.Unmarshal(contents, result)
+}
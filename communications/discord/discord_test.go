@@ -0,0 +1,201 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const testBotToken = "testToken"
+
+// discordStandIn is a local stand-in for the Discord API which records the
+// messages it receives
+type discordStandIn struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (s *discordStandIn) received() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *discordStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") && r.Header.Get("Authorization") != "Bot "+testBotToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"401: Unauthorized","code":0}`))
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api"+pathCurrentUser:
+		_, _ = w.Write([]byte(`{"id":"1","username":"gctbot","bot":true}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/webhooks/1/token":
+		_, _ = w.Write([]byte(`{"id":"1","name":"gct","channel_id":"2"}`))
+	case r.Method == http.MethodPost && (r.URL.Path == "/api/channels/2/messages" || r.URL.Path == "/webhooks/1/token"):
+		var m Message
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.messages = append(s.messages, m)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/channels/3/messages":
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":1.5,"global":false}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Unknown Channel","code":10003}`))
+	}
+}
+
+func newTestDiscord(t *testing.T, cfg *base.DiscordConfig) (*Discord, *discordStandIn) {
+	t.Helper()
+	standIn := new(discordStandIn)
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	if cfg.WebhookURL != "" {
+		cfg.WebhookURL = server.URL + cfg.WebhookURL
+	}
+	d := new(Discord)
+	d.Setup(&base.CommunicationsConfig{DiscordConfig: *cfg})
+	d.apiURL = server.URL + "/api"
+	return d, standIn
+}
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var d Discord
+	d.Setup(&base.CommunicationsConfig{DiscordConfig: base.DiscordConfig{
+		Name:       "Discord",
+		Enabled:    true,
+		Verbose:    true,
+		WebhookURL: "https://discord.com/api/webhooks/1/token",
+		BotToken:   testBotToken,
+		ChannelID:  "2",
+		EventTypes: []string{"order"},
+	}})
+	if d.Name != "Discord" || !d.Enabled || !d.Verbose ||
+		d.WebhookURL != "https://discord.com/api/webhooks/1/token" ||
+		d.BotToken != testBotToken || d.ChannelID != "2" ||
+		len(d.EventTypes) != 1 || d.apiURL != apiURL || d.client == nil {
+		t.Errorf("unexpected Discord setup: %+v", d)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	d, _ := newTestDiscord(t, &base.DiscordConfig{Name: "Discord", Enabled: true})
+	err := d.Connect()
+	if !errors.Is(err, errBotTokenNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errBotTokenNotSet)
+	}
+
+	d.BotToken = testBotToken
+	err = d.Connect()
+	if !errors.Is(err, errChannelIDNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChannelIDNotSet)
+	}
+
+	d.ChannelID = "2"
+	d.BotToken = "invalid"
+	err = d.Connect()
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+	if d.IsConnected() {
+		t.Error("expected Discord to not be connected with an invalid token")
+	}
+
+	d.BotToken = testBotToken
+	if err = d.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !d.IsConnected() {
+		t.Error("expected Discord bot to be connected")
+	}
+
+	hook, _ := newTestDiscord(t, &base.DiscordConfig{Name: "Discord", Enabled: true, WebhookURL: "/webhooks/1/token"})
+	if err = hook.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !hook.IsConnected() {
+		t.Error("expected Discord webhook to be connected")
+	}
+
+	hook, _ = newTestDiscord(t, &base.DiscordConfig{Name: "Discord", Enabled: true, WebhookURL: "/webhooks/1/invalid"})
+	err = hook.Connect()
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	d, standIn := newTestDiscord(t, &base.DiscordConfig{
+		Name:       "Discord",
+		Enabled:    true,
+		BotToken:   testBotToken,
+		ChannelID:  "2",
+		EventTypes: []string{"order"},
+	})
+	if err := d.PushEvent(base.Event{Type: "rebalance", Message: "filtered"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.PushEvent(base.Event{Type: "order", Message: "filled"}); err != nil {
+		t.Fatal(err)
+	}
+	received := standIn.received()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 message, received %d", len(received))
+	}
+	if received[0].Content != "**order** filled" {
+		t.Errorf("unexpected message content %q", received[0].Content)
+	}
+
+	d.ChannelID = "3"
+	err := d.PushEvent(base.Event{Type: "order", Message: "rate limited"})
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+	if !strings.Contains(err.Error(), "retry after 1.5s") {
+		t.Errorf("expected rate limit retry in error, received %v", err)
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+	d, standIn := newTestDiscord(t, &base.DiscordConfig{
+		Name:       "Discord",
+		Enabled:    true,
+		WebhookURL: "/webhooks/1/token",
+	})
+	if err := d.SendMessage(strings.Repeat("a", maxMessageLength+10)); err != nil {
+		t.Fatal(err)
+	}
+	received := standIn.received()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 message, received %d", len(received))
+	}
+	if len(received[0].Content) != maxMessageLength {
+		t.Errorf("expected message to be truncated to %d, received %d", maxMessageLength, len(received[0].Content))
+	}
+	if received[0].Username != "Discord" {
+		t.Errorf("expected webhook username Discord, received %s", received[0].Username)
+	}
+
+	var bot Discord
+	bot.BotToken = testBotToken
+	err := bot.SendMessage("test")
+	if !errors.Is(err, errChannelIDNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChannelIDNotSet)
+	}
+}
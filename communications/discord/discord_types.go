@@ -0,0 +1,30 @@
+package discord
+
+// Message is the payload used to create a message in a channel or to execute
+// a webhook
+type Message struct {
+	Content  string `json:"content"`
+	Username string `json:"username,omitempty"`
+}
+
+// User holds the user details of the authenticated bot
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+// Webhook holds the details of a channel webhook
+type Webhook struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ChannelID string `json:"channel_id"`
+	GuildID   string `json:"guild_id"`
+}
+
+// APIError is returned by the Discord API when a request fails
+type APIError struct {
+	Code       int     `json:"code"`
+	Message    string  `json:"message"`
+	RetryAfter float64 `json:"retry_after"`
+}
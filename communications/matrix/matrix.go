@@ -0,0 +1,157 @@
+// Package matrix allows events to be sent to a Matrix room using the
+// client-server API defined in https://spec.matrix.org/latest/client-server-api/
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	pathWhoAmI      = "/_matrix/client/v3/account/whoami"
+	pathSendMessage = "/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
+
+	msgTypeText    = "m.text"
+	requestTimeout = time.Second * 15
+)
+
+var (
+	errHomeserverURLNotSet = errors.New("Matrix homeserver URL not set")
+	errAccessTokenNotSet   = errors.New("Matrix access token not set")
+	errRoomIDNotSet        = errors.New("Matrix room ID not set")
+	errRequestFailed       = errors.New("Matrix request failed")
+)
+
+// Matrix is the overarching type across this package
+type Matrix struct {
+	base.Base
+	HomeserverURL string
+	AccessToken   string
+	RoomID        string
+	UserID        string
+
+	// transactionID makes each sent event idempotent so retried requests
+	// are not duplicated by the homeserver
+	transactionID int64
+	client        *http.Client
+}
+
+// Setup takes in a Matrix configuration and sets the homeserver, access
+// token and room
+func (m *Matrix) Setup(cfg *base.CommunicationsConfig) {
+	m.Name = cfg.MatrixConfig.Name
+	m.Enabled = cfg.MatrixConfig.Enabled
+	m.Verbose = cfg.MatrixConfig.Verbose
+	m.EventTypes = cfg.MatrixConfig.EventTypes
+	m.HomeserverURL = strings.TrimSuffix(cfg.MatrixConfig.HomeserverURL, "/")
+	m.AccessToken = cfg.MatrixConfig.AccessToken
+	m.RoomID = cfg.MatrixConfig.RoomID
+	m.client = common.NewHTTPClientWithTimeout(requestTimeout)
+}
+
+// IsConnected returns whether or not the connection is connected
+func (m *Matrix) IsConnected() bool { return m.Connected }
+
+// Connect verifies the access token with the homeserver
+func (m *Matrix) Connect() error {
+	if m.HomeserverURL == "" {
+		return errHomeserverURLNotSet
+	}
+	if m.AccessToken == "" {
+		return errAccessTokenNotSet
+	}
+	if m.RoomID == "" {
+		return errRoomIDNotSet
+	}
+	var resp WhoAmI
+	if err := m.sendRequest(http.MethodGet, pathWhoAmI, nil, &resp); err != nil {
+		return err
+	}
+	m.UserID = resp.UserID
+	log.Debugf(log.CommunicationMgr, "Matrix: Connected successfully as %s\n", m.UserID)
+	m.Connected = true
+	return nil
+}
+
+// PushEvent sends an event to the Matrix room if its type is not filtered
+func (m *Matrix) PushEvent(event base.Event) error {
+	if !m.IsEventTypeEnabled(event.Type) {
+		return nil
+	}
+	return m.SendMessage(fmt.Sprintf("Type: %s Message: %s", event.Type, event.Message))
+}
+
+// SendMessage sends a text message to the Matrix room
+func (m *Matrix) SendMessage(message string) error {
+	if m.RoomID == "" {
+		return errRoomIDNotSet
+	}
+	txnID := fmt.Sprintf("gct%d.%d", m.ServiceStarted.UnixNano(), atomic.AddInt64(&m.transactionID, 1))
+	var resp SendResponse
+	return m.sendRequest(http.MethodPut,
+		fmt.Sprintf(pathSendMessage, url.PathEscape(m.RoomID), txnID),
+		&Message{MsgType: msgTypeText, Body: message},
+		&resp)
+}
+
+// sendRequest sends an authenticated JSON request to the homeserver and
+// decodes the response into result
+func (m *Matrix) sendRequest(method, path string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, m.HomeserverURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.AccessToken)
+
+	if m.client == nil {
+		m.client = common.NewHTTPClientWithTimeout(requestTimeout)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if m.Verbose {
+		log.Debugf(log.CommunicationMgr, "Matrix: %s %s status %d response: %s\n", method, path, resp.StatusCode, contents)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr APIError
+		if json.Unmarshal(contents, &apiErr) == nil && apiErr.ErrCode != "" {
+			if apiErr.RetryAfterMS > 0 {
+				return fmt.Errorf("%w: status %d: %s %s, retry after %v", errRequestFailed, resp.StatusCode, apiErr.ErrCode, apiErr.Error, time.Duration(apiErr.RetryAfterMS)*time.Millisecond)
+			}
+			return fmt.Errorf("%w: status %d: %s %s", errRequestFailed, resp.StatusCode, apiErr.ErrCode, apiErr.Error)
+		}
+		return fmt.Errorf("%w: status %d", errRequestFailed, resp.StatusCode)
+	}
+	return json.Unmarshal(contents, result)
+}
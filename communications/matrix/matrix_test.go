@@ -0,0 +1,179 @@
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const (
+	testAccessToken = "testToken"
+	testRoomID      = "!room:example.org"
+)
+
+// homeserverStandIn is a local stand-in for a Matrix homeserver which records
+// the messages and transaction IDs it receives
+type homeserverStandIn struct {
+	mu           sync.Mutex
+	messages     []Message
+	transactions []string
+}
+
+func (s *homeserverStandIn) received() ([]Message, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...), append([]string(nil), s.transactions...)
+}
+
+func (s *homeserverStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testAccessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"Invalid access token passed."}`))
+		return
+	}
+	sendPrefix := "/_matrix/client/v3/rooms/" + testRoomID + "/send/m.room.message/"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == pathWhoAmI:
+		_, _ = w.Write([]byte(`{"user_id":"@gct:example.org","device_id":"GCT"}`))
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, sendPrefix):
+		var m Message
+		if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.messages = append(s.messages, m)
+		s.transactions = append(s.transactions, strings.TrimPrefix(r.URL.Path, sendPrefix))
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!limited:example.org/"):
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"errcode":"M_LIMIT_EXCEEDED","error":"Too many requests","retry_after_ms":2000}`))
+	default:
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errcode":"M_FORBIDDEN","error":"You are not in this room."}`))
+	}
+}
+
+func newTestMatrix(t *testing.T, cfg *base.MatrixConfig) (*Matrix, *homeserverStandIn) {
+	t.Helper()
+	standIn := new(homeserverStandIn)
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	cfg.HomeserverURL = server.URL + "/"
+	m := new(Matrix)
+	m.Setup(&base.CommunicationsConfig{MatrixConfig: *cfg})
+	return m, standIn
+}
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var m Matrix
+	m.Setup(&base.CommunicationsConfig{MatrixConfig: base.MatrixConfig{
+		Name:          "Matrix",
+		Enabled:       true,
+		Verbose:       true,
+		HomeserverURL: "https://matrix.example.org/",
+		AccessToken:   testAccessToken,
+		RoomID:        testRoomID,
+		EventTypes:    []string{"risk"},
+	}})
+	if m.Name != "Matrix" || !m.Enabled || !m.Verbose ||
+		m.HomeserverURL != "https://matrix.example.org" ||
+		m.AccessToken != testAccessToken || m.RoomID != testRoomID ||
+		len(m.EventTypes) != 1 || m.client == nil {
+		t.Errorf("unexpected Matrix setup: %+v", m)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var m Matrix
+	err := m.Connect()
+	if !errors.Is(err, errHomeserverURLNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errHomeserverURLNotSet)
+	}
+
+	mx, _ := newTestMatrix(t, &base.MatrixConfig{Name: "Matrix", Enabled: true})
+	err = mx.Connect()
+	if !errors.Is(err, errAccessTokenNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errAccessTokenNotSet)
+	}
+
+	mx.AccessToken = "invalid"
+	err = mx.Connect()
+	if !errors.Is(err, errRoomIDNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRoomIDNotSet)
+	}
+
+	mx.RoomID = testRoomID
+	err = mx.Connect()
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+	if !strings.Contains(err.Error(), "M_UNKNOWN_TOKEN") {
+		t.Errorf("expected error code in error, received %v", err)
+	}
+
+	mx.AccessToken = testAccessToken
+	if err = mx.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !mx.IsConnected() {
+		t.Error("expected Matrix to be connected")
+	}
+	if mx.UserID != "@gct:example.org" {
+		t.Errorf("expected user ID @gct:example.org, received %s", mx.UserID)
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	m, standIn := newTestMatrix(t, &base.MatrixConfig{
+		Name:        "Matrix",
+		Enabled:     true,
+		AccessToken: testAccessToken,
+		RoomID:      testRoomID,
+		EventTypes:  []string{"risk"},
+	})
+	if err := m.PushEvent(base.Event{Type: "order", Message: "filtered"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PushEvent(base.Event{Type: "risk", Message: "breached"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PushEvent(base.Event{Type: "risk", Message: "cleared"}); err != nil {
+		t.Fatal(err)
+	}
+	messages, transactions := standIn.received()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, received %d", len(messages))
+	}
+	if messages[0].MsgType != msgTypeText || messages[0].Body != "Type: risk Message: breached" {
+		t.Errorf("unexpected message %+v", messages[0])
+	}
+	if transactions[0] == transactions[1] {
+		t.Errorf("expected unique transaction IDs, received %s twice", transactions[0])
+	}
+
+	m.RoomID = "!limited:example.org"
+	err := m.PushEvent(base.Event{Type: "risk", Message: "limited"})
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+	if !strings.Contains(err.Error(), "retry after 2s") {
+		t.Errorf("expected rate limit retry in error, received %v", err)
+	}
+
+	m.RoomID = ""
+	err = m.PushEvent(base.Event{Type: "risk", Message: "no room"})
+	if !errors.Is(err, errRoomIDNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRoomIDNotSet)
+	}
+}
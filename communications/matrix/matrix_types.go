@@ -0,0 +1,25 @@
+package matrix
+
+// Message is an m.room.message event with the m.text message type
+type Message struct {
+	MsgType string `json:"msgtype"`
+	Body    string `json:"body"`
+}
+
+// WhoAmI holds the user the access token belongs to
+type WhoAmI struct {
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id"`
+}
+
+// SendResponse holds the ID of a sent event
+type SendResponse struct {
+	EventID string `json:"event_id"`
+}
+
+// APIError is returned by the homeserver when a request fails
+type APIError struct {
+	ErrCode      string `json:"errcode"`
+	Error        string `json:"error"`
+	RetryAfterMS int64  `json:"retry_after_ms"`
+}
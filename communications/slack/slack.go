@@ -27,6 +27,9 @@ const (
 	cmdStatus = "!status"
 	cmdHelp   = "!help"
 
+	// commandPrefix starts every message treated as a command
+	commandPrefix = "!"
+
 	getHelp = `GoCryptoTrader SlackBot, thank you for using this service!
 	Current commands are:
 	!status 		- Displays current working status of bot
@@ -37,6 +40,7 @@ const (
 // time messaging
 type Slack struct {
 	base.Base
+	base.CommandReceiver
 
 	TargetChannel     string
 	VerificationToken string
@@ -290,7 +294,7 @@ func (s *Slack) handleMessageResponse(resp []byte, data WebsocketResponse) error
 			s.GetUsernameByID(msg.User),
 			msg.User, msg.Text)
 	}
-	if string(msg.Text[0]) == "!" {
+	if strings.HasPrefix(msg.Text, commandPrefix) {
 		return s.HandleMessage(&msg)
 	}
 	return nil
@@ -384,6 +388,28 @@ func (s *Slack) HandleMessage(msg *Message) error {
 		return errors.New("slack msg is nil")
 	}
 
+	// Ignore the bot's own replies so they are never handled as commands
+	if msg.User != "" && msg.User == s.Details.Self.ID {
+		return nil
+	}
+
+	if handler := s.GetCommandHandler(); handler != nil && !strings.Contains(strings.ToLower(msg.Text), cmdStatus) {
+		if !strings.HasPrefix(strings.TrimSpace(msg.Text), commandPrefix) {
+			return nil
+		}
+		reply := handler.HandleMessage(context.TODO(), &base.InboundMessage{
+			Relayer:       s.Name,
+			UserID:        msg.User,
+			UserName:      s.GetUsernameByID(msg.User),
+			Text:          msg.Text,
+			CommandPrefix: commandPrefix,
+		})
+		if reply == "" {
+			return nil
+		}
+		return s.WebsocketSend("message", reply)
+	}
+
 	msg.Text = strings.ToLower(msg.Text)
 	switch {
 	case strings.Contains(msg.Text, cmdStatus):
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -335,3 +336,59 @@ func TestHandleMessage(t *testing.T) {
 		t.Error("slack HandleMessage(), Sent message through nil websocket")
 	}
 }
+
+type recordingHandler struct {
+	reply    string
+	received *base.InboundMessage
+}
+
+func (r *recordingHandler) HandleMessage(_ context.Context, msg *base.InboundMessage) string {
+	r.received = msg
+	return r.reply
+}
+
+func TestHandleMessageCommandHandler(t *testing.T) {
+	t.Parallel()
+	s := Slack{TargetChannelID: "C1"}
+	s.Name = "Slack"
+	h := &recordingHandler{}
+	s.SetCommandHandler(h)
+	if err := s.HandleMessage(&Message{User: "U1", Text: "!Disable Bitstamp"}); err != nil {
+		t.Fatal(err)
+	}
+	if h.received == nil {
+		t.Fatal("expected command to be passed to the command handler")
+	}
+	if h.received.Relayer != "Slack" || h.received.UserID != "U1" ||
+		h.received.Text != "!Disable Bitstamp" || h.received.CommandPrefix != "!" {
+		t.Errorf("unexpected inbound message %+v", h.received)
+	}
+
+	h.reply = "reply"
+	if err := s.HandleMessage(&Message{User: "U1", Text: "!help"}); err == nil {
+		t.Error("slack HandleMessage(), Sent reply through nil websocket")
+	}
+
+	h.received = nil
+	if err := s.HandleMessage(&Message{User: "U1", Text: "disable everything"}); err != nil {
+		t.Fatal(err)
+	}
+	if h.received != nil {
+		t.Error("expected message without command prefix to be ignored")
+	}
+
+	s.Details.Self.ID = "UBOT"
+	if err := s.HandleMessage(&Message{User: "UBOT", Text: "!help"}); err != nil {
+		t.Fatal(err)
+	}
+	if h.received != nil {
+		t.Error("expected the bot's own message to be ignored")
+	}
+
+	if err := s.HandleMessage(&Message{User: "U1", Text: cmdStatus}); err == nil {
+		t.Error("slack HandleMessage(), Sent message through nil websocket")
+	}
+	if h.received != nil {
+		t.Error("expected status command to be handled by the relayer")
+	}
+}
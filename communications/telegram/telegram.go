@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,6 +31,9 @@ const (
 	cmdHelp     = "/help"
 	cmdSettings = "/settings"
 
+	// commandPrefix starts every message treated as a command
+	commandPrefix = "/"
+
 	cmdHelpReply = `GoCryptoTrader TelegramBot, thank you for using this service!
 	Current commands are:
 	/start  		- Will authenticate your ID
@@ -49,7 +53,9 @@ var (
 // Telegram is the overarching type across this package
 type Telegram struct {
 	base.Base
+	base.CommandReceiver
 	initConnected     bool
+	botID             int64
 	Token             string
 	Offset            int64
 	AuthorisedClients []int64
@@ -116,7 +122,7 @@ func (t *Telegram) PollerStart() {
 
 		for i := range resp.Result {
 			if resp.Result[i].UpdateID > t.Offset {
-				if string(resp.Result[i].Message.Text[0]) == "/" {
+				if strings.HasPrefix(resp.Result[i].Message.Text, commandPrefix) {
 					err = t.HandleMessages(resp.Result[i].Message.Text, resp.Result[i].Message.From.ID)
 					if err != nil {
 						log.Errorf(log.CommunicationMgr, "Telegram: Unable to HandleMessages. Error: %s\n", err)
@@ -170,7 +176,27 @@ func (t *Telegram) HandleMessages(text string, chatID int64) error {
 		log.Debugf(log.CommunicationMgr, "Telegram: Received message: %s\n", text)
 	}
 
+	handler := t.GetCommandHandler()
 	switch {
+	case t.botID != 0 && chatID == t.botID:
+		// Ignore the bot's own messages so they are never handled as commands
+		return nil
+
+	case handler != nil && !strings.Contains(text, cmdStart) && !strings.Contains(text, cmdStatus):
+		if !strings.HasPrefix(strings.TrimSpace(text), commandPrefix) {
+			return nil
+		}
+		reply := handler.HandleMessage(context.TODO(), &base.InboundMessage{
+			Relayer:       t.Name,
+			UserID:        strconv.FormatInt(chatID, 10),
+			Text:          text,
+			CommandPrefix: commandPrefix,
+		})
+		if reply == "" {
+			return nil
+		}
+		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, reply), chatID)
+
 	case strings.Contains(text, cmdHelp):
 		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
 
@@ -205,6 +231,7 @@ func (t *Telegram) TestConnection() error {
 	if !isConnected.Ok {
 		return errors.New(isConnected.Description)
 	}
+	t.botID = isConnected.Result.ID
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"context"
 	"testing"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
@@ -87,6 +88,49 @@ func TestHandleMessages(t *testing.T) {
 	}
 }
 
+type recordingHandler struct {
+	received *base.InboundMessage
+}
+
+func (r *recordingHandler) HandleMessage(_ context.Context, msg *base.InboundMessage) string {
+	r.received = msg
+	return ""
+}
+
+func TestHandleMessagesCommandHandler(t *testing.T) {
+	t.Parallel()
+	var T Telegram
+	T.Name = "Telegram"
+	h := &recordingHandler{}
+	T.SetCommandHandler(h)
+	if err := T.HandleMessages("/positions", 1337); err != nil {
+		t.Fatal(err)
+	}
+	if h.received == nil {
+		t.Fatal("expected command to be passed to the command handler")
+	}
+	if h.received.Relayer != "Telegram" || h.received.UserID != "1337" ||
+		h.received.Text != "/positions" || h.received.CommandPrefix != "/" {
+		t.Errorf("unexpected inbound message %+v", h.received)
+	}
+
+	h.received = nil
+	if err := T.HandleMessages("cancel everything", 1337); err != nil {
+		t.Fatal(err)
+	}
+	if h.received != nil {
+		t.Error("expected message without command prefix to be ignored")
+	}
+
+	T.botID = 42
+	if err := T.HandleMessages("/help", 42); err != nil {
+		t.Fatal(err)
+	}
+	if h.received != nil {
+		t.Error("expected the bot's own message to be ignored")
+	}
+}
+
 func TestGetUpdates(t *testing.T) {
 	t.Parallel()
 	var T Telegram
@@ -0,0 +1,272 @@
+// Package webhook allows events to be sent to any HTTP endpoint with a body
+// built from a configurable template. Requests can be signed with HMAC-SHA256
+// so receivers can verify their origin. Events are delivered from a queue so
+// failed deliveries can be retried with exponential backoff without blocking
+// other relayers
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	// DefaultBodyTemplate is used when no body template is configured
+	DefaultBodyTemplate = `{"name":{{json .Name}},"type":{{json .Type}},"message":{{json .Message}},"timestamp":{{json .Timestamp}}}`
+	// DefaultSignatureHeader is the header the signature is sent in when no
+	// signature header is configured
+	DefaultSignatureHeader = "X-GCT-Signature"
+	// TimestampHeader is the header holding the unix timestamp included in
+	// the signature
+	TimestampHeader = "X-GCT-Timestamp"
+
+	signaturePrefix   = "sha256="
+	defaultRetryDelay = time.Second
+	requestTimeout    = time.Second * 15
+	deliveryQueueSize = 100
+)
+
+var (
+	errURLNotSet          = errors.New("webhook URL not set")
+	errInvalidURL         = errors.New("webhook URL is invalid")
+	errInvalidMethod      = errors.New("webhook method must be POST, PUT or PATCH")
+	errInvalidRetries     = errors.New("webhook max retries cannot be negative")
+	errTemplateNotLoaded  = errors.New("webhook body template not loaded")
+	errRequestFailed      = errors.New("webhook request failed")
+	errMaxRetriesExceeded = errors.New("webhook max retries exceeded")
+	errDeliveryQueueFull  = errors.New("webhook delivery queue full, event dropped")
+	errNotConnected       = errors.New("webhook not connected")
+)
+
+// Webhook is the overarching type across this package
+type Webhook struct {
+	base.Base
+	URL             string
+	Method          string
+	Headers         map[string]string
+	BodyTemplate    string
+	Secret          string
+	SignatureHeader string
+	MaxRetries      int
+	RetryDelay      time.Duration
+
+	template   *template.Template
+	client     *http.Client
+	deliveries chan *Payload
+}
+
+// Setup takes in a webhook configuration and sets the endpoint, body
+// template, signing and retry settings
+func (w *Webhook) Setup(cfg *base.CommunicationsConfig) {
+	w.Name = cfg.WebhookConfig.Name
+	w.Enabled = cfg.WebhookConfig.Enabled
+	w.Verbose = cfg.WebhookConfig.Verbose
+	w.EventTypes = cfg.WebhookConfig.EventTypes
+	w.URL = cfg.WebhookConfig.URL
+	w.Method = strings.ToUpper(cfg.WebhookConfig.Method)
+	if w.Method == "" {
+		w.Method = http.MethodPost
+	}
+	w.Headers = cfg.WebhookConfig.Headers
+	w.BodyTemplate = cfg.WebhookConfig.BodyTemplate
+	if w.BodyTemplate == "" {
+		w.BodyTemplate = DefaultBodyTemplate
+	}
+	w.Secret = cfg.WebhookConfig.Secret
+	w.SignatureHeader = cfg.WebhookConfig.SignatureHeader
+	if w.SignatureHeader == "" {
+		w.SignatureHeader = DefaultSignatureHeader
+	}
+	w.MaxRetries = cfg.WebhookConfig.MaxRetries
+	w.RetryDelay = cfg.WebhookConfig.RetryDelay
+	if w.RetryDelay <= 0 {
+		w.RetryDelay = defaultRetryDelay
+	}
+	w.client = common.NewHTTPClientWithTimeout(requestTimeout)
+}
+
+// IsConnected returns whether or not the connection is connected
+func (w *Webhook) IsConnected() bool { return w.Connected }
+
+// Connect validates the webhook settings and parses the body template, no
+// request is made as endpoints are not required to respond to anything but
+// event deliveries
+func (w *Webhook) Connect() error {
+	if w.URL == "" {
+		return errURLNotSet
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %s", errInvalidURL, w.URL)
+	}
+	switch w.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("%w, received %s", errInvalidMethod, w.Method)
+	}
+	if w.MaxRetries < 0 {
+		return errInvalidRetries
+	}
+	w.template, err = ParseBodyTemplate(w.BodyTemplate)
+	if err != nil {
+		return err
+	}
+	log.Debugf(log.CommunicationMgr, "Webhook: %s ready to send to %s\n", w.Name, u.Host)
+	if w.deliveries == nil {
+		w.deliveries = make(chan *Payload, deliveryQueueSize)
+		go w.deliver()
+	}
+	w.Connected = true
+	return nil
+}
+
+// deliver sends queued payloads to the webhook in order
+func (w *Webhook) deliver() {
+	for p := range w.deliveries {
+		if err := w.Send(p); err != nil {
+			log.Errorf(log.CommunicationMgr, "Webhook: %s failed to deliver %s event: %v\n", w.Name, p.Type, err)
+		}
+	}
+}
+
+// ParseBodyTemplate parses a body template, templates can use the json
+// function to encode values as JSON
+func ParseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(body)
+}
+
+// PushEvent queues an event for delivery to the webhook if its type is not
+// filtered. It does not wait for the delivery so a webhook retrying failed
+// deliveries does not hold up events for other relayers
+func (w *Webhook) PushEvent(event base.Event) error {
+	if !w.IsEventTypeEnabled(event.Type) {
+		return nil
+	}
+	if w.deliveries == nil {
+		return errNotConnected
+	}
+	select {
+	case w.deliveries <- &Payload{
+		Name:      w.Name,
+		Type:      event.Type,
+		Message:   event.Message,
+		Timestamp: time.Now().UTC(),
+	}:
+		return nil
+	default:
+		return errDeliveryQueueFull
+	}
+}
+
+// Send executes the body template with the payload and delivers it to the
+// webhook, retrying server errors, rate limits and connection failures
+func (w *Webhook) Send(p *Payload) error {
+	if w.template == nil {
+		return errTemplateNotLoaded
+	}
+	var body bytes.Buffer
+	if err := w.template.Execute(&body, p); err != nil {
+		return err
+	}
+
+	headers := make(map[string]string, len(w.Headers)+3)
+	headers["Content-Type"] = "application/json"
+	for k, v := range w.Headers {
+		headers[k] = v
+	}
+	if w.Secret != "" {
+		timestamp := strconv.FormatInt(p.Timestamp.Unix(), 10)
+		signature, err := Sign(w.Secret, timestamp, body.Bytes())
+		if err != nil {
+			return err
+		}
+		headers[TimestampHeader] = timestamp
+		headers[w.SignatureHeader] = signature
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := w.sendRequest(headers, body.Bytes())
+		if err == nil {
+			return nil
+		}
+		if !retry {
+			return err
+		}
+		if attempt >= w.MaxRetries {
+			return fmt.Errorf("%w after %d attempts: %v", errMaxRetriesExceeded, attempt+1, err)
+		}
+		delay := w.RetryDelay << attempt
+		if w.Verbose {
+			log.Debugf(log.CommunicationMgr, "Webhook: %s delivery attempt %d failed, retrying in %v: %v\n", w.Name, attempt+1, delay, err)
+		}
+		time.Sleep(delay)
+	}
+}
+
+// Sign returns the HMAC-SHA256 signature of the timestamp and body joined by
+// a period, receivers verify a delivery by recomputing it with the shared
+// secret
+func Sign(secret, timestamp string, body []byte) (string, error) {
+	hmac, err := crypto.GetHMAC(crypto.HashSHA256,
+		append([]byte(timestamp+"."), body...),
+		[]byte(secret))
+	if err != nil {
+		return "", err
+	}
+	return signaturePrefix + crypto.HexEncodeToString(hmac), nil
+}
+
+// sendRequest sends the body to the webhook and returns whether a failed
+// delivery can be retried
+func (w *Webhook) sendRequest(headers map[string]string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(context.Background(), w.Method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	if w.client == nil {
+		w.client = common.NewHTTPClientWithTimeout(requestTimeout)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, err
+	}
+	if w.Verbose {
+		log.Debugf(log.CommunicationMgr, "Webhook: %s %s status %d response: %s\n", w.Method, w.URL, resp.StatusCode, contents)
+	}
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+	err = fmt.Errorf("%w: status %d", errRequestFailed, resp.StatusCode)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError, err
+}
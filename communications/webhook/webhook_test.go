@@ -0,0 +1,321 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+const testSecret = "testSecret"
+
+// delivery holds a request received by the endpoint stand-in
+type delivery struct {
+	method    string
+	header    http.Header
+	body      []byte
+	signature string
+}
+
+// endpointStand records deliveries and responds with the queued status codes,
+// defaulting to 200 once the queue is empty
+type endpointStand struct {
+	mu         sync.Mutex
+	statuses   []int
+	deliveries []delivery
+}
+
+func (e *endpointStand) received() []delivery {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]delivery(nil), e.deliveries...)
+}
+
+// waitFor returns the deliveries once at least count have been received
+func (e *endpointStand) waitFor(t *testing.T, count int) []delivery {
+	t.Helper()
+	timeout := time.After(time.Second * 5)
+	for {
+		if deliveries := e.received(); len(deliveries) >= count {
+			return deliveries
+		}
+		select {
+		case <-timeout:
+			t.Fatalf("expected %d deliveries, received %d", count, len(e.received()))
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+}
+
+func (e *endpointStand) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.deliveries = append(e.deliveries, delivery{
+		method:    r.Method,
+		header:    r.Header,
+		body:      body,
+		signature: r.Header.Get(DefaultSignatureHeader),
+	})
+	status := http.StatusOK
+	if len(e.statuses) > 0 {
+		status = e.statuses[0]
+		e.statuses = e.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhook(t *testing.T, cfg *base.WebhookConfig, statuses ...int) (*Webhook, *endpointStand) {
+	t.Helper()
+	stand := &endpointStand{statuses: statuses}
+	server := httptest.NewServer(stand)
+	t.Cleanup(server.Close)
+	cfg.URL = server.URL + "/events"
+	w := new(Webhook)
+	w.Setup(&base.CommunicationsConfig{WebhookConfig: *cfg})
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	return w, stand
+}
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var w Webhook
+	w.Setup(&base.CommunicationsConfig{WebhookConfig: base.WebhookConfig{
+		Name:    "Webhook",
+		Enabled: true,
+		Method:  "put",
+	}})
+	if w.Method != http.MethodPut ||
+		w.BodyTemplate != DefaultBodyTemplate ||
+		w.SignatureHeader != DefaultSignatureHeader ||
+		w.RetryDelay != defaultRetryDelay ||
+		w.client == nil {
+		t.Errorf("unexpected webhook setup: %+v", w)
+	}
+
+	w.Setup(&base.CommunicationsConfig{WebhookConfig: base.WebhookConfig{
+		BodyTemplate:    "{{.Message}}",
+		SignatureHeader: "X-Signature",
+		RetryDelay:      time.Millisecond,
+	}})
+	if w.Method != http.MethodPost ||
+		w.BodyTemplate != "{{.Message}}" ||
+		w.SignatureHeader != "X-Signature" ||
+		w.RetryDelay != time.Millisecond {
+		t.Errorf("unexpected webhook setup: %+v", w)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var w Webhook
+	w.Setup(&base.CommunicationsConfig{})
+	err := w.Connect()
+	if !errors.Is(err, errURLNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errURLNotSet)
+	}
+
+	w.URL = "ftp://example.org"
+	err = w.Connect()
+	if !errors.Is(err, errInvalidURL) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidURL)
+	}
+
+	w.URL = "https://example.org/events"
+	w.Method = http.MethodGet
+	err = w.Connect()
+	if !errors.Is(err, errInvalidMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMethod)
+	}
+
+	w.Method = http.MethodPost
+	w.MaxRetries = -1
+	err = w.Connect()
+	if !errors.Is(err, errInvalidRetries) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRetries)
+	}
+
+	w.MaxRetries = 0
+	w.BodyTemplate = "{{.Message"
+	if err = w.Connect(); err == nil {
+		t.Fatal("expected error parsing invalid body template")
+	}
+	if w.IsConnected() {
+		t.Error("expected webhook to not be connected")
+	}
+
+	w.BodyTemplate = DefaultBodyTemplate
+	if err = w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsConnected() {
+		t.Error("expected webhook to be connected")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	w, stand := newTestWebhook(t, &base.WebhookConfig{
+		Name:       "Webhook",
+		Enabled:    true,
+		Secret:     testSecret,
+		Headers:    map[string]string{"X-Team": "on-call"},
+		EventTypes: []string{"order"},
+	})
+	if err := w.PushEvent(base.Event{Type: "risk", Message: "filtered"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PushEvent(base.Event{Type: "order", Message: `"quoted" order`}); err != nil {
+		t.Fatal(err)
+	}
+	deliveries := stand.waitFor(t, 1)
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, received %d", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.method != http.MethodPost {
+		t.Errorf("expected POST, received %s", d.method)
+	}
+	if d.header.Get("X-Team") != "on-call" || d.header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", d.header)
+	}
+
+	var payload struct {
+		Name      string    `json:"name"`
+		Type      string    `json:"type"`
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(d.body, &payload); err != nil {
+		t.Fatalf("default template did not produce JSON: %v %s", err, d.body)
+	}
+	if payload.Name != "Webhook" || payload.Type != "order" || payload.Message != `"quoted" order` || payload.Timestamp.IsZero() {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	expected, err := Sign(testSecret, d.header.Get(TimestampHeader), d.body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.signature == "" || d.signature != expected {
+		t.Errorf("expected signature %s, received %s", expected, d.signature)
+	}
+}
+
+func TestPushEventQueued(t *testing.T) {
+	t.Parallel()
+	var unconnected Webhook
+	err := unconnected.PushEvent(base.Event{Type: "risk"})
+	if !errors.Is(err, errNotConnected) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotConnected)
+	}
+
+	// Events are queued while a failed delivery waits to be retried
+	w, stand := newTestWebhook(t, &base.WebhookConfig{
+		MaxRetries: 1,
+		RetryDelay: time.Minute,
+	}, http.StatusServiceUnavailable)
+	if err = w.PushEvent(base.Event{Type: "risk", Message: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	stand.waitFor(t, 1)
+	pushed := make(chan error)
+	go func() {
+		pushed <- w.PushEvent(base.Event{Type: "risk", Message: "second"})
+	}()
+	select {
+	case err = <-pushed:
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected event to be queued while a delivery is retried")
+	}
+
+	full := &Webhook{deliveries: make(chan *Payload)}
+	err = full.PushEvent(base.Event{Type: "risk"})
+	if !errors.Is(err, errDeliveryQueueFull) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errDeliveryQueueFull)
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+	var unloaded Webhook
+	err := unloaded.Send(&Payload{})
+	if !errors.Is(err, errTemplateNotLoaded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errTemplateNotLoaded)
+	}
+
+	w, stand := newTestWebhook(t, &base.WebhookConfig{
+		Name:         "Webhook",
+		BodyTemplate: "{{.Type}}: {{.Message}}",
+		Headers:      map[string]string{"Content-Type": "text/plain"},
+		MaxRetries:   2,
+		RetryDelay:   time.Millisecond,
+	}, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	if err = w.Send(&Payload{Type: "risk", Message: "breached"}); err != nil {
+		t.Fatal(err)
+	}
+	deliveries := stand.received()
+	if len(deliveries) != 3 {
+		t.Fatalf("expected 3 delivery attempts, received %d", len(deliveries))
+	}
+	if string(deliveries[2].body) != "risk: breached" {
+		t.Errorf("unexpected templated body %s", deliveries[2].body)
+	}
+	if deliveries[2].header.Get("Content-Type") != "text/plain" {
+		t.Errorf("expected configured content type, received %s", deliveries[2].header.Get("Content-Type"))
+	}
+	if deliveries[2].signature != "" {
+		t.Errorf("expected unsigned delivery without a secret, received %s", deliveries[2].signature)
+	}
+
+	w, stand = newTestWebhook(t, &base.WebhookConfig{
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+	}, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	err = w.Send(&Payload{Type: "risk"})
+	if !errors.Is(err, errMaxRetriesExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errMaxRetriesExceeded)
+	}
+	if len(stand.received()) != 2 {
+		t.Errorf("expected 2 delivery attempts, received %d", len(stand.received()))
+	}
+
+	w, stand = newTestWebhook(t, &base.WebhookConfig{
+		MaxRetries: 3,
+		RetryDelay: time.Millisecond,
+	}, http.StatusBadRequest)
+	err = w.Send(&Payload{Type: "risk"})
+	if !errors.Is(err, errRequestFailed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRequestFailed)
+	}
+	if len(stand.received()) != 1 {
+		t.Errorf("expected client errors to not be retried, received %d attempts", len(stand.received()))
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	signature, err := Sign("secret", "1700000000", []byte(`{"message":"test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "sha256=611ba72c590cc7de410551f5591047b5785a9110d6a850de7add28d0d00597a3"
+	if signature != expected {
+		t.Errorf("expected signature %s, received %s", expected, signature)
+	}
+}
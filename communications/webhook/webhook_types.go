@@ -0,0 +1,11 @@
+package webhook
+
+import "time"
+
+// Payload is the data the body template is executed with
+type Payload struct {
+	Name      string
+	Type      string
+	Message   string
+	Timestamp time.Time
+}
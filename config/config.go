@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,14 +19,18 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/commands"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/taxlot"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 var (
@@ -173,6 +178,7 @@ func (c *Config) PurgeExchangeAPICredentials() {
 
 		c.Exchanges[x].API.Credentials.PEMKey = ""
 		c.Exchanges[x].API.Credentials.OTPSecret = ""
+		c.Exchanges[x].API.Accounts = nil
 	}
 }
 
@@ -302,10 +308,35 @@ func (c *Config) CheckCommunicationsConfig() {
 		}
 	}
 
+	if c.Communications.DiscordConfig.Name == "" {
+		c.Communications.DiscordConfig = base.DiscordConfig{
+			Name: "Discord",
+		}
+	}
+
+	if c.Communications.MatrixConfig.Name == "" {
+		c.Communications.MatrixConfig = base.MatrixConfig{
+			Name:          "Matrix",
+			HomeserverURL: "https://matrix.org",
+		}
+	}
+
+	if c.Communications.WebhookConfig.Name == "" {
+		c.Communications.WebhookConfig = base.WebhookConfig{
+			Name:       "Webhook",
+			Method:     http.MethodPost,
+			MaxRetries: 3,
+			RetryDelay: time.Second,
+		}
+	}
+
 	if c.Communications.SlackConfig.Name != "Slack" ||
 		c.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		c.Communications.SMTPConfig.Name != "SMTP" ||
-		c.Communications.TelegramConfig.Name != "Telegram" {
+		c.Communications.TelegramConfig.Name != "Telegram" ||
+		c.Communications.DiscordConfig.Name != "Discord" ||
+		c.Communications.MatrixConfig.Name != "Matrix" ||
+		c.Communications.WebhookConfig.Name != "Webhook" {
 		log.Warnln(log.ConfigMgr, "Communications config name/s not set correctly")
 	}
 	if c.Communications.SlackConfig.Enabled {
@@ -339,6 +370,52 @@ func (c *Config) CheckCommunicationsConfig() {
 			log.Warnln(log.ConfigMgr, "Telegram enabled in config but variable data not set, disabling.")
 		}
 	}
+	if c.Communications.DiscordConfig.Enabled {
+		if c.Communications.DiscordConfig.WebhookURL == "" &&
+			(c.Communications.DiscordConfig.BotToken == "" ||
+				c.Communications.DiscordConfig.ChannelID == "") {
+			c.Communications.DiscordConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Discord enabled in config but variable data not set, disabling.")
+		}
+	}
+	if c.Communications.MatrixConfig.Enabled {
+		if c.Communications.MatrixConfig.HomeserverURL == "" ||
+			c.Communications.MatrixConfig.AccessToken == "" ||
+			c.Communications.MatrixConfig.RoomID == "" {
+			c.Communications.MatrixConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Matrix enabled in config but variable data not set, disabling.")
+		}
+	}
+	if c.Communications.WebhookConfig.Enabled {
+		if c.Communications.WebhookConfig.URL == "" {
+			c.Communications.WebhookConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Webhook enabled in config but variable data not set, disabling.")
+		}
+		if c.Communications.WebhookConfig.MaxRetries < 0 {
+			log.Warnln(log.ConfigMgr, "Webhook max retries cannot be negative, defaulting to 0.")
+			c.Communications.WebhookConfig.MaxRetries = 0
+		}
+	}
+	if c.Communications.CommandsConfig.Enabled {
+		if c.Communications.CommandsConfig.ConfirmationTimeout <= 0 {
+			log.Warnf(log.ConfigMgr, "Chat command confirmation timeout not set, defaulting to %v.\n", commands.DefaultConfirmationTimeout)
+			c.Communications.CommandsConfig.ConfirmationTimeout = commands.DefaultConfirmationTimeout
+		}
+		users := c.Communications.CommandsConfig.Users[:0]
+		for i := range c.Communications.CommandsConfig.Users {
+			if c.Communications.CommandsConfig.Users[i].Relayer == "" ||
+				c.Communications.CommandsConfig.Users[i].UserID == "" {
+				log.Warnf(log.ConfigMgr, "Chat command user %d requires a relayer and user ID, removing.\n", i)
+				continue
+			}
+			users = append(users, c.Communications.CommandsConfig.Users[i])
+		}
+		c.Communications.CommandsConfig.Users = users
+		if len(users) == 0 {
+			c.Communications.CommandsConfig.Enabled = false
+			log.Warnln(log.ConfigMgr, "Chat commands enabled in config but no users set, disabling.")
+		}
+	}
 }
 
 // GetExchangeAssetTypes returns the exchanges supported asset types
@@ -955,6 +1032,7 @@ func (c *Config) CheckExchangeConfigValues() error {
 					log.Warnf(log.ConfigMgr, WarningExchangeAuthAPIDefaultOrEmptyValues, c.Exchanges[i].Name)
 				}
 			}
+			c.Exchanges[i].checkAccounts()
 			if !c.Exchanges[i].Features.Supports.RESTCapabilities.AutoPairUpdates &&
 				!c.Exchanges[i].Features.Supports.WebsocketCapabilities.AutoPairUpdates {
 				lastUpdated := convert.UnixTimestampToTime(c.Exchanges[i].CurrencyPairs.LastUpdated)
@@ -1349,6 +1427,199 @@ func (c *Config) CheckCurrencyStateManager() {
 	}
 }
 
+// CheckCandleBuilderConfig ensures the candle builder config is valid, or sets
+// default values
+func (c *Config) CheckCandleBuilderConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if len(c.CandleBuilder.Intervals) == 0 {
+		c.CandleBuilder.Intervals = []time.Duration{
+			time.Minute,
+			time.Minute * 5,
+			time.Minute * 15,
+			time.Hour,
+		}
+	}
+	if c.CandleBuilder.MaxCandles <= 0 {
+		c.CandleBuilder.MaxCandles = defaultCandleBuilderMaxCandles
+	}
+	if c.CandleBuilder.PersistInterval <= 0 {
+		c.CandleBuilder.PersistInterval = defaultCandleBuilderPersistInterval
+	}
+}
+
+// CheckOrderbookRecorderConfig ensures the orderbook recorder config is valid,
+// or sets default values
+func (c *Config) CheckOrderbookRecorderConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.OrderbookRecorder.RotationInterval <= 0 {
+		c.OrderbookRecorder.RotationInterval = defaultOrderbookRecorderRotation
+	}
+	if c.OrderbookRecorder.MaxFileSize <= 0 {
+		c.OrderbookRecorder.MaxFileSize = defaultOrderbookRecorderMaxFileSize
+	}
+}
+
+// CheckArbitrageConfig ensures the arbitrage scanner config is valid, or sets
+// default values
+func (c *Config) CheckArbitrageConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Arbitrage.ScanInterval <= 0 {
+		c.Arbitrage.ScanInterval = defaultArbitrageScanInterval
+	}
+	if c.Arbitrage.ExecutionCooldown <= 0 {
+		c.Arbitrage.ExecutionCooldown = defaultArbitrageExecutionCooldown
+	}
+	if c.Arbitrage.MinProfitPercentage < 0 {
+		c.Arbitrage.MinProfitPercentage = 0
+	}
+	if !c.Arbitrage.Triangular && !c.Arbitrage.CrossExchange {
+		// Nothing would be scanned, so default to both
+		c.Arbitrage.Triangular = true
+		c.Arbitrage.CrossExchange = true
+	}
+	if len(c.Arbitrage.Capital) == 0 {
+		c.Arbitrage.Capital = map[string]float64{
+			currency.USDT.String(): 1000,
+			currency.USD.String():  1000,
+		}
+	}
+}
+
+// CheckRebalancerConfig ensures the rebalancer config is valid, or sets
+// default values
+func (c *Config) CheckRebalancerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Rebalancer.Interval <= 0 {
+		c.Rebalancer.Interval = defaultRebalancerInterval
+	}
+	if c.Rebalancer.QuoteCurrency.IsEmpty() {
+		c.Rebalancer.QuoteCurrency = currency.USDT
+	}
+	if c.Rebalancer.DriftThreshold <= 0 {
+		c.Rebalancer.DriftThreshold = defaultRebalancerDriftThreshold
+	}
+	if c.Rebalancer.MinimumTradeValue <= 0 {
+		c.Rebalancer.MinimumTradeValue = defaultRebalancerMinimumTradeValue
+	}
+}
+
+// CheckPortfolioHistoryConfig ensures the portfolio history config is valid,
+// or sets default values
+func (c *Config) CheckPortfolioHistoryConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.PortfolioHistory.Interval <= 0 {
+		c.PortfolioHistory.Interval = defaultPortfolioHistoryInterval
+	}
+	if !c.PortfolioHistory.ValueCurrency.IsFiatCurrency() {
+		if !c.PortfolioHistory.ValueCurrency.IsEmpty() {
+			log.Warnf(log.ConfigMgr, "Portfolio history value currency %s is not a fiat currency, defaulting to USD", c.PortfolioHistory.ValueCurrency)
+		}
+		c.PortfolioHistory.ValueCurrency = currency.USD
+	}
+}
+
+// CheckTaxLotsConfig ensures the tax lots config is valid, or sets default
+// values
+func (c *Config) CheckTaxLotsConfig() {
+	m.Lock()
+	defer m.Unlock()
+	method, err := taxlot.ParseMethod(c.TaxLots.Method)
+	if err != nil {
+		if c.TaxLots.Method != "" {
+			log.Warnf(log.ConfigMgr, "Tax lots %v, defaulting to %s", err, taxlot.FIFO)
+		}
+		method = taxlot.FIFO
+	}
+	c.TaxLots.Method = string(method)
+	if !c.TaxLots.ReportCurrency.IsFiatCurrency() {
+		if !c.TaxLots.ReportCurrency.IsEmpty() {
+			log.Warnf(log.ConfigMgr, "Tax lots report currency %s is not a fiat currency, defaulting to USD", c.TaxLots.ReportCurrency)
+		}
+		c.TaxLots.ReportCurrency = currency.USD
+	}
+	if c.TaxLots.ImportInterval <= 0 {
+		c.TaxLots.ImportInterval = defaultTaxLotsImportInterval
+	}
+	if c.TaxLots.ImportStart.IsZero() {
+		c.TaxLots.ImportStart = time.Date(time.Now().UTC().Year()-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// CheckReconciliationConfig sets default reconciliation values when unset
+func (c *Config) CheckReconciliationConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Reconciliation.Interval <= 0 {
+		c.Reconciliation.Interval = defaultReconciliationInterval
+	}
+	if c.Reconciliation.Window <= 0 {
+		c.Reconciliation.Window = defaultReconciliationWindow
+	}
+	if c.Reconciliation.FeeTolerance <= 0 {
+		c.Reconciliation.FeeTolerance = defaultReconciliationTolerance
+	}
+	if c.Reconciliation.BalanceTolerance <= 0 {
+		c.Reconciliation.BalanceTolerance = defaultReconciliationTolerance
+	}
+}
+
+// CheckDeadManSwitchConfig checks and if zero value assigns default values
+func (c *Config) CheckDeadManSwitchConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.DeadManSwitch.CheckInterval <= 0 {
+		c.DeadManSwitch.CheckInterval = defaultDeadManSwitchCheckInterval
+	}
+	if c.DeadManSwitch.StaleDataTimeout < 0 {
+		c.DeadManSwitch.StaleDataTimeout = 0
+	}
+	if c.DeadManSwitch.HeartbeatTimeout < 0 {
+		c.DeadManSwitch.HeartbeatTimeout = 0
+	}
+	if c.DeadManSwitch.NativeTimeout < 0 {
+		c.DeadManSwitch.NativeTimeout = 0
+	}
+	if c.DeadManSwitch.NativeTimeout > 0 && c.DeadManSwitch.NativeTimeout <= c.DeadManSwitch.CheckInterval {
+		log.Warnf(log.ConfigMgr, "Dead man's switch native timeout %v must exceed the check interval %v, setting to %v\n",
+			c.DeadManSwitch.NativeTimeout,
+			c.DeadManSwitch.CheckInterval,
+			c.DeadManSwitch.CheckInterval*4)
+		c.DeadManSwitch.NativeTimeout = c.DeadManSwitch.CheckInterval * 4
+	}
+}
+
+// CheckMetricsConfig checks and if zero value assigns default values
+func (c *Config) CheckMetricsConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Metrics.Path == "" {
+		c.Metrics.Path = defaultMetricsPath
+	}
+	if !strings.HasPrefix(c.Metrics.Path, "/") {
+		c.Metrics.Path = "/" + c.Metrics.Path
+	}
+}
+
+// CheckTracingConfig checks and if zero value assigns default values
+func (c *Config) CheckTracingConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Tracing.Endpoint == "" {
+		c.Tracing.Endpoint = tracing.DefaultEndpoint
+	}
+	if c.Tracing.ServiceName == "" {
+		c.Tracing.ServiceName = tracing.DefaultServiceName
+	}
+	if c.Tracing.ExportInterval <= 0 {
+		c.Tracing.ExportInterval = tracing.DefaultExportInterval
+	}
+}
+
 // CheckOrderManagerConfig ensures the order manager is setup correctly
 func (c *Config) CheckOrderManagerConfig() {
 	m.Lock()
@@ -1362,6 +1633,40 @@ func (c *Config) CheckOrderManagerConfig() {
 		// for longer than a year
 		c.OrderManager.FuturesTrackingSeekDuration = -time.Hour * 24 * 365
 	}
+	if c.OrderManager.Persistence.RestorePeriod <= 0 {
+		c.OrderManager.Persistence.RestorePeriod = defaultOrderRestorePeriod
+	}
+	if c.OrderManager.Persistence.PositionSnapshotInterval <= 0 {
+		c.OrderManager.Persistence.PositionSnapshotInterval = defaultPositionSnapshotInterval
+	}
+	if !c.OrderManager.RiskLimits.Default.IsValid() {
+		log.Warnf(log.ConfigMgr, "Order manager default risk limit cannot be negative, disabling\n")
+		c.OrderManager.RiskLimits.Default = RiskLimit{}
+	}
+	limits := c.OrderManager.RiskLimits.Exchanges[:0]
+	for i := range c.OrderManager.RiskLimits.Exchanges {
+		l := &c.OrderManager.RiskLimits.Exchanges[i]
+		if l.Exchange == "" {
+			log.Warnf(log.ConfigMgr, "Order manager risk limit requires an exchange name, removing\n")
+			continue
+		}
+		if !l.IsValid() {
+			log.Warnf(log.ConfigMgr, "Order manager risk limit for %s cannot be negative, removing\n", l.Exchange)
+			continue
+		}
+		limits = append(limits, *l)
+	}
+	c.OrderManager.RiskLimits.Exchanges = limits
+}
+
+// IsValid returns whether all controls of the risk limit are zero or positive
+func (r *RiskLimit) IsValid() bool {
+	return r.MaxOrderNotional >= 0 &&
+		r.MaxPositionPerPair >= 0 &&
+		r.MaxOpenOrders >= 0 &&
+		r.MaxDailyLoss >= 0 &&
+		r.PriceBandPercentage >= 0 &&
+		r.FatFingerPercentage >= 0
 }
 
 // CheckConnectionMonitorConfig checks and if zero value assigns default values
@@ -1712,6 +2017,16 @@ func (c *Config) CheckConfig() error {
 	c.CheckConnectionMonitorConfig()
 	c.CheckDataHistoryMonitorConfig()
 	c.CheckCurrencyStateManager()
+	c.CheckCandleBuilderConfig()
+	c.CheckOrderbookRecorderConfig()
+	c.CheckArbitrageConfig()
+	c.CheckRebalancerConfig()
+	c.CheckPortfolioHistoryConfig()
+	c.CheckTaxLotsConfig()
+	c.CheckReconciliationConfig()
+	c.CheckDeadManSwitchConfig()
+	c.CheckMetricsConfig()
+	c.CheckTracingConfig()
 	c.CheckOrderManagerConfig()
 	c.CheckCommunicationsConfig()
 	c.CheckClientBankAccounts()
@@ -1816,6 +2131,24 @@ func (c *Config) GetDataPath(elem ...string) string {
 	return filepath.Join(append([]string{baseDir}, elem...)...)
 }
 
+// checkAccounts normalises the names of named exchange accounts and removes
+// accounts with an empty or duplicate name
+func (c *Exchange) checkAccounts() {
+	names := make(map[string]bool, len(c.API.Accounts))
+	for i := 0; i < len(c.API.Accounts); i++ {
+		name := account.NormaliseAccountName(c.API.Accounts[i].Name)
+		if name != "" && !names[name] {
+			names[name] = true
+			c.API.Accounts[i].Name = name
+			continue
+		}
+		log.Warnf(log.ConfigMgr, "Exchange %s account %d has an empty or duplicate name %q, removing\n",
+			c.Name, i, c.API.Accounts[i].Name)
+		c.API.Accounts = append(c.API.Accounts[:i], c.API.Accounts[i+1:]...)
+		i--
+	}
+}
+
 // Validate checks if exchange config is valid
 func (c *Exchange) Validate() error {
 	if c == nil {
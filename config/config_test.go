@@ -13,6 +13,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/commands"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -20,6 +21,8 @@ import (
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/taxlot"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 const (
@@ -396,7 +399,10 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	if cfg.Communications.SlackConfig.Name != "Slack" ||
 		cfg.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		cfg.Communications.SMTPConfig.Name != "SMTP" ||
-		cfg.Communications.TelegramConfig.Name != "Telegram" {
+		cfg.Communications.TelegramConfig.Name != "Telegram" ||
+		cfg.Communications.DiscordConfig.Name != "Discord" ||
+		cfg.Communications.MatrixConfig.Name != "Matrix" ||
+		cfg.Communications.WebhookConfig.Name != "Webhook" {
 		t.Error("CheckCommunicationsConfig unexpected data:",
 			cfg.Communications)
 	}
@@ -471,6 +477,67 @@ func TestCheckCommunicationsConfig(t *testing.T) {
 	if cfg.Communications.TelegramConfig.Enabled {
 		t.Error("CheckCommunicationsConfig TelegramConfig is enabled when it shouldn't be.")
 	}
+
+	cfg.Communications.TelegramConfig.Enabled = false
+	cfg.Communications.DiscordConfig.Enabled = true
+	cfg.Communications.DiscordConfig.BotToken = "token"
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.DiscordConfig.Enabled {
+		t.Error("CheckCommunicationsConfig DiscordConfig is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.DiscordConfig.Enabled = true
+	cfg.Communications.DiscordConfig.BotToken = ""
+	cfg.Communications.DiscordConfig.WebhookURL = "https://discord.com/api/webhooks/1/token"
+	cfg.CheckCommunicationsConfig()
+	if !cfg.Communications.DiscordConfig.Enabled {
+		t.Error("CheckCommunicationsConfig DiscordConfig should be enabled with a webhook URL.")
+	}
+
+	cfg.Communications.DiscordConfig.Enabled = false
+	cfg.Communications.MatrixConfig.Enabled = true
+	cfg.Communications.MatrixConfig.AccessToken = "token"
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.MatrixConfig.Enabled {
+		t.Error("CheckCommunicationsConfig MatrixConfig is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.WebhookConfig.Enabled = true
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.WebhookConfig.Enabled {
+		t.Error("CheckCommunicationsConfig WebhookConfig is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.WebhookConfig.Enabled = true
+	cfg.Communications.WebhookConfig.URL = "https://example.org/events"
+	cfg.Communications.WebhookConfig.MaxRetries = -1
+	cfg.CheckCommunicationsConfig()
+	if !cfg.Communications.WebhookConfig.Enabled ||
+		cfg.Communications.WebhookConfig.MaxRetries != 0 {
+		t.Error("CheckCommunicationsConfig WebhookConfig unexpected data:",
+			cfg.Communications.WebhookConfig)
+	}
+
+	cfg.Communications.CommandsConfig.Enabled = true
+	cfg.Communications.CommandsConfig.Users = []base.CommandUser{{Relayer: "Telegram"}}
+	cfg.CheckCommunicationsConfig()
+	if cfg.Communications.CommandsConfig.Enabled {
+		t.Error("CheckCommunicationsConfig CommandsConfig is enabled when it shouldn't be.")
+	}
+
+	cfg.Communications.CommandsConfig.Enabled = true
+	cfg.Communications.CommandsConfig.Users = []base.CommandUser{
+		{Relayer: "Telegram"},
+		{Relayer: "Telegram", UserID: "1337", Commands: []string{"positions"}},
+	}
+	cfg.CheckCommunicationsConfig()
+	if !cfg.Communications.CommandsConfig.Enabled ||
+		len(cfg.Communications.CommandsConfig.Users) != 1 ||
+		cfg.Communications.CommandsConfig.Users[0].UserID != "1337" ||
+		cfg.Communications.CommandsConfig.ConfirmationTimeout != commands.DefaultConfirmationTimeout {
+		t.Error("CheckCommunicationsConfig CommandsConfig unexpected data:",
+			cfg.Communications.CommandsConfig)
+	}
 }
 
 func TestGetExchangeAssetTypes(t *testing.T) {
@@ -1761,6 +1828,278 @@ func TestCheckConnectionMonitorConfig(t *testing.T) {
 	}
 }
 
+func TestCheckCandleBuilderConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckCandleBuilderConfig()
+	if len(c.CandleBuilder.Intervals) != 4 ||
+		c.CandleBuilder.MaxCandles != defaultCandleBuilderMaxCandles ||
+		c.CandleBuilder.PersistInterval != defaultCandleBuilderPersistInterval {
+		t.Error("unexpected values")
+	}
+
+	c.CandleBuilder.Intervals = []time.Duration{time.Second}
+	c.CandleBuilder.MaxCandles = 10
+	c.CheckCandleBuilderConfig()
+	if len(c.CandleBuilder.Intervals) != 1 || c.CandleBuilder.MaxCandles != 10 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckOrderbookRecorderConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckOrderbookRecorderConfig()
+	if c.OrderbookRecorder.RotationInterval != defaultOrderbookRecorderRotation ||
+		c.OrderbookRecorder.MaxFileSize != defaultOrderbookRecorderMaxFileSize {
+		t.Error("unexpected values")
+	}
+
+	c.OrderbookRecorder.RotationInterval = time.Minute
+	c.OrderbookRecorder.MaxFileSize = 1
+	c.CheckOrderbookRecorderConfig()
+	if c.OrderbookRecorder.RotationInterval != time.Minute || c.OrderbookRecorder.MaxFileSize != 1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckArbitrageConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.Arbitrage.MinProfitPercentage = -1
+	c.CheckArbitrageConfig()
+	if c.Arbitrage.ScanInterval != defaultArbitrageScanInterval ||
+		c.Arbitrage.ExecutionCooldown != defaultArbitrageExecutionCooldown ||
+		c.Arbitrage.MinProfitPercentage != 0 ||
+		!c.Arbitrage.Triangular ||
+		!c.Arbitrage.CrossExchange ||
+		len(c.Arbitrage.Capital) != 2 {
+		t.Error("unexpected values")
+	}
+
+	c.Arbitrage.ScanInterval = time.Minute
+	c.Arbitrage.Triangular = false
+	c.Arbitrage.Capital = map[string]float64{"BTC": 1}
+	c.CheckArbitrageConfig()
+	if c.Arbitrage.ScanInterval != time.Minute ||
+		c.Arbitrage.Triangular ||
+		len(c.Arbitrage.Capital) != 1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckRebalancerConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.Interval != defaultRebalancerInterval ||
+		!c.Rebalancer.QuoteCurrency.Equal(currency.USDT) ||
+		c.Rebalancer.DriftThreshold != defaultRebalancerDriftThreshold ||
+		c.Rebalancer.MinimumTradeValue != defaultRebalancerMinimumTradeValue {
+		t.Error("unexpected values")
+	}
+
+	c.Rebalancer.Interval = time.Hour
+	c.Rebalancer.QuoteCurrency = currency.USD
+	c.Rebalancer.DriftThreshold = 1
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.Interval != time.Hour ||
+		!c.Rebalancer.QuoteCurrency.Equal(currency.USD) ||
+		c.Rebalancer.DriftThreshold != 1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckPortfolioHistoryConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckPortfolioHistoryConfig()
+	if c.PortfolioHistory.Interval != defaultPortfolioHistoryInterval ||
+		!c.PortfolioHistory.ValueCurrency.Equal(currency.USD) {
+		t.Error("unexpected values")
+	}
+
+	c.PortfolioHistory.Interval = time.Minute
+	c.PortfolioHistory.ValueCurrency = currency.BTC
+	c.CheckPortfolioHistoryConfig()
+	if c.PortfolioHistory.Interval != time.Minute ||
+		!c.PortfolioHistory.ValueCurrency.Equal(currency.USD) {
+		t.Error("unexpected values")
+	}
+
+	c.PortfolioHistory.ValueCurrency = currency.EUR
+	c.CheckPortfolioHistoryConfig()
+	if !c.PortfolioHistory.ValueCurrency.Equal(currency.EUR) {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckTaxLotsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckTaxLotsConfig()
+	if c.TaxLots.Method != string(taxlot.FIFO) ||
+		!c.TaxLots.ReportCurrency.Equal(currency.USD) ||
+		c.TaxLots.ImportInterval != defaultTaxLotsImportInterval ||
+		c.TaxLots.ImportStart.IsZero() {
+		t.Error("unexpected values")
+	}
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c.TaxLots.Method = "hifo"
+	c.TaxLots.ReportCurrency = currency.BTC
+	c.TaxLots.ImportInterval = time.Minute
+	c.TaxLots.ImportStart = start
+	c.CheckTaxLotsConfig()
+	if c.TaxLots.Method != string(taxlot.HIFO) ||
+		!c.TaxLots.ReportCurrency.Equal(currency.USD) ||
+		c.TaxLots.ImportInterval != time.Minute ||
+		!c.TaxLots.ImportStart.Equal(start) {
+		t.Error("unexpected values")
+	}
+
+	c.TaxLots.Method = "bogus"
+	c.TaxLots.ReportCurrency = currency.AUD
+	c.CheckTaxLotsConfig()
+	if c.TaxLots.Method != string(taxlot.FIFO) ||
+		!c.TaxLots.ReportCurrency.Equal(currency.AUD) {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckReconciliationConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckReconciliationConfig()
+	if c.Reconciliation.Interval != defaultReconciliationInterval ||
+		c.Reconciliation.Window != defaultReconciliationWindow ||
+		c.Reconciliation.FeeTolerance != defaultReconciliationTolerance ||
+		c.Reconciliation.BalanceTolerance != defaultReconciliationTolerance {
+		t.Error("unexpected values")
+	}
+
+	c.Reconciliation.Interval = time.Hour
+	c.Reconciliation.Window = time.Minute
+	c.Reconciliation.FeeTolerance = 0.01
+	c.Reconciliation.BalanceTolerance = 0.1
+	c.CheckReconciliationConfig()
+	if c.Reconciliation.Interval != time.Hour ||
+		c.Reconciliation.Window != time.Minute ||
+		c.Reconciliation.FeeTolerance != 0.01 ||
+		c.Reconciliation.BalanceTolerance != 0.1 {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckDeadManSwitchConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.DeadManSwitch.StaleDataTimeout = -1
+	c.DeadManSwitch.HeartbeatTimeout = -1
+	c.DeadManSwitch.NativeTimeout = time.Second
+	c.CheckDeadManSwitchConfig()
+	if c.DeadManSwitch.CheckInterval != defaultDeadManSwitchCheckInterval ||
+		c.DeadManSwitch.StaleDataTimeout != 0 ||
+		c.DeadManSwitch.HeartbeatTimeout != 0 ||
+		c.DeadManSwitch.NativeTimeout != defaultDeadManSwitchCheckInterval*4 {
+		t.Error("unexpected values")
+	}
+
+	c.DeadManSwitch.CheckInterval = time.Second
+	c.DeadManSwitch.StaleDataTimeout = time.Minute
+	c.DeadManSwitch.HeartbeatTimeout = time.Minute
+	c.DeadManSwitch.NativeTimeout = time.Minute
+	c.CheckDeadManSwitchConfig()
+	if c.DeadManSwitch.CheckInterval != time.Second ||
+		c.DeadManSwitch.StaleDataTimeout != time.Minute ||
+		c.DeadManSwitch.HeartbeatTimeout != time.Minute ||
+		c.DeadManSwitch.NativeTimeout != time.Minute {
+		t.Error("unexpected values")
+	}
+}
+
+func TestCheckMetricsConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckMetricsConfig()
+	if c.Metrics.Path != defaultMetricsPath {
+		t.Errorf("received: '%v' but expected: '%v'", c.Metrics.Path, defaultMetricsPath)
+	}
+
+	c.Metrics.Path = "gct/metrics"
+	c.CheckMetricsConfig()
+	if c.Metrics.Path != "/gct/metrics" {
+		t.Errorf("received: '%v' but expected: '%v'", c.Metrics.Path, "/gct/metrics")
+	}
+}
+
+func TestCheckTracingConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckTracingConfig()
+	if c.Tracing.Endpoint != tracing.DefaultEndpoint {
+		t.Errorf("received: '%v' but expected: '%v'", c.Tracing.Endpoint, tracing.DefaultEndpoint)
+	}
+	if c.Tracing.ServiceName != tracing.DefaultServiceName {
+		t.Errorf("received: '%v' but expected: '%v'", c.Tracing.ServiceName, tracing.DefaultServiceName)
+	}
+	if c.Tracing.ExportInterval != tracing.DefaultExportInterval {
+		t.Errorf("received: '%v' but expected: '%v'", c.Tracing.ExportInterval, tracing.DefaultExportInterval)
+	}
+
+	c.Tracing.ExportInterval = time.Second
+	c.CheckTracingConfig()
+	if c.Tracing.ExportInterval != time.Second {
+		t.Errorf("received: '%v' but expected: '%v'", c.Tracing.ExportInterval, time.Second)
+	}
+}
+
+func TestCheckOrderManagerConfig(t *testing.T) {
+	t.Parallel()
+
+	var c Config
+	c.CheckOrderManagerConfig()
+	if c.OrderManager.Enabled == nil || !*c.OrderManager.Enabled ||
+		c.OrderManager.FuturesTrackingSeekDuration >= 0 ||
+		c.OrderManager.Persistence.RestorePeriod != defaultOrderRestorePeriod ||
+		c.OrderManager.Persistence.PositionSnapshotInterval != defaultPositionSnapshotInterval {
+		t.Error("unexpected values")
+	}
+
+	c.OrderManager.Persistence.RestorePeriod = time.Hour
+	c.OrderManager.Persistence.PositionSnapshotInterval = time.Minute
+	c.CheckOrderManagerConfig()
+	if c.OrderManager.Persistence.RestorePeriod != time.Hour ||
+		c.OrderManager.Persistence.PositionSnapshotInterval != time.Minute {
+		t.Error("unexpected values")
+	}
+
+	c.OrderManager.RiskLimits.Default.MaxOpenOrders = -1
+	c.OrderManager.RiskLimits.Exchanges = []ExchangeRiskLimit{
+		{Exchange: "Bitstamp", RiskLimit: RiskLimit{MaxOrderNotional: 1000}},
+		{SubAccount: "main", RiskLimit: RiskLimit{MaxOrderNotional: 1000}},
+		{Exchange: "Kraken", RiskLimit: RiskLimit{MaxDailyLoss: -1}},
+	}
+	c.CheckOrderManagerConfig()
+	if c.OrderManager.RiskLimits.Default.MaxOpenOrders != 0 {
+		t.Error("expected invalid default risk limit to be disabled")
+	}
+	if len(c.OrderManager.RiskLimits.Exchanges) != 1 ||
+		c.OrderManager.RiskLimits.Exchanges[0].Exchange != "Bitstamp" {
+		t.Errorf("unexpected risk limits %+v", c.OrderManager.RiskLimits.Exchanges)
+	}
+}
+
 func TestDefaultFilePath(t *testing.T) {
 	// This is tricky to test because we're dealing with a config file stored
 	// in a persons default directory and to properly test it, it would
@@ -2290,3 +2629,20 @@ func TestExchangeConfigValidate(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
 	}
 }
+
+func TestCheckAccounts(t *testing.T) {
+	t.Parallel()
+	e := &Exchange{Name: "test"}
+	e.API.Accounts = []APIAccountConfig{
+		{Name: "Strategy1"},
+		{},
+		{Name: "strategy1"},
+		{Name: " strategy2 "},
+	}
+	e.checkAccounts()
+	if len(e.API.Accounts) != 2 ||
+		e.API.Accounts[0].Name != "strategy1" ||
+		e.API.Accounts[1].Name != "strategy2" {
+		t.Errorf("unexpected accounts %+v", e.API.Accounts)
+	}
+}
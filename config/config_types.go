@@ -38,6 +38,24 @@ const (
 	DefaultAPIClientID                   = "ClientID"
 	defaultDataHistoryMonitorCheckTimer  = time.Minute
 	defaultCurrencyStateManagerDelay     = time.Minute
+	defaultCandleBuilderMaxCandles       = 500
+	defaultCandleBuilderPersistInterval  = time.Minute
+	defaultOrderbookRecorderRotation     = time.Hour
+	defaultOrderbookRecorderMaxFileSize  = 64 << 20
+	defaultArbitrageScanInterval         = time.Second
+	defaultArbitrageExecutionCooldown    = time.Minute
+	defaultRebalancerInterval            = time.Hour * 24 * 7
+	defaultRebalancerDriftThreshold      = 5.0
+	defaultRebalancerMinimumTradeValue   = 10.0
+	defaultPortfolioHistoryInterval      = time.Hour
+	defaultTaxLotsImportInterval         = time.Hour
+	defaultReconciliationInterval        = time.Hour * 24
+	defaultReconciliationWindow          = time.Hour * 24
+	defaultReconciliationTolerance       = 1e-8
+	defaultDeadManSwitchCheckInterval    = time.Second * 5
+	defaultMetricsPath                   = "/metrics"
+	defaultOrderRestorePeriod            = time.Hour * 24 * 7
+	defaultPositionSnapshotInterval      = time.Minute * 15
 	defaultMaxJobsPerCycle               = 5
 	DefaultOrderbookPublishPeriod        = time.Second * 10
 )
@@ -84,6 +102,16 @@ type Config struct {
 	OrderManager         OrderManager              `json:"orderManager"`
 	DataHistoryManager   DataHistoryManager        `json:"dataHistoryManager"`
 	CurrencyStateManager CurrencyStateManager      `json:"currencyStateManager"`
+	CandleBuilder        CandleBuilder             `json:"candleBuilder"`
+	OrderbookRecorder    OrderbookRecorder         `json:"orderbookRecorder"`
+	Arbitrage            Arbitrage                 `json:"arbitrage"`
+	Rebalancer           Rebalancer                `json:"rebalancer"`
+	PortfolioHistory     PortfolioHistory          `json:"portfolioHistory"`
+	TaxLots              TaxLots                   `json:"taxLots"`
+	Reconciliation       Reconciliation            `json:"reconciliation"`
+	DeadManSwitch        DeadManSwitch             `json:"deadManSwitch"`
+	Metrics              Metrics                   `json:"metrics"`
+	Tracing              Tracing                   `json:"tracing"`
 	Profiler             Profiler                  `json:"profiler"`
 	NTPClient            NTPClientConfig           `json:"ntpclient"`
 	GCTScript            gctscript.Config          `json:"gctscript"`
@@ -107,10 +135,63 @@ type Config struct {
 
 // OrderManager holds settings used for the order manager
 type OrderManager struct {
-	Enabled                       *bool         `json:"enabled"`
-	Verbose                       bool          `json:"verbose"`
-	ActivelyTrackFuturesPositions bool          `json:"activelyTrackFuturesPositions"`
-	FuturesTrackingSeekDuration   time.Duration `json:"futuresTrackingSeekDuration"`
+	Enabled                       *bool            `json:"enabled"`
+	Verbose                       bool             `json:"verbose"`
+	ActivelyTrackFuturesPositions bool             `json:"activelyTrackFuturesPositions"`
+	FuturesTrackingSeekDuration   time.Duration    `json:"futuresTrackingSeekDuration"`
+	Persistence                   OrderPersistence `json:"persistence"`
+	RiskLimits                    RiskLimits       `json:"riskLimits"`
+}
+
+// OrderPersistence defines how managed orders and futures positions are saved
+// to the database so they survive a restart
+type OrderPersistence struct {
+	Enabled bool `json:"enabled"`
+	// RestorePeriod is how far back orders are restored from the database on
+	// startup
+	RestorePeriod            time.Duration `json:"restorePeriod"`
+	PositionSnapshotInterval time.Duration `json:"positionSnapshotInterval"`
+}
+
+// RiskLimits defines the pre-trade controls applied to orders submitted to
+// exchanges via the order manager
+type RiskLimits struct {
+	Enabled bool `json:"enabled"`
+	// Default applies to exchanges and sub-accounts without their own limits
+	Default   RiskLimit           `json:"default"`
+	Exchanges []ExchangeRiskLimit `json:"exchanges"`
+}
+
+// RiskLimit defines a set of pre-trade controls. A zero value disables the
+// control
+type RiskLimit struct {
+	// MaxOrderNotional is the largest order value in the notional currency
+	MaxOrderNotional float64 `json:"maxOrderNotional"`
+	// NotionalCurrency is the currency of MaxOrderNotional. When empty the
+	// limit applies to the quote currency of each pair
+	NotionalCurrency string `json:"notionalCurrency,omitempty"`
+	// MaxPositionPerPair is the largest net position of a pair in the base
+	// currency, including the remaining amount of open orders
+	MaxPositionPerPair float64 `json:"maxPositionPerPair"`
+	MaxOpenOrders      int64   `json:"maxOpenOrders"`
+	// MaxDailyLoss is the largest loss of orders placed since midnight UTC,
+	// across pairs sharing a quote currency
+	MaxDailyLoss float64 `json:"maxDailyLoss"`
+	// PriceBandPercentage is the largest distance of an order price from the
+	// ticker mid price
+	PriceBandPercentage float64 `json:"priceBandPercentage"`
+	// FatFingerPercentage is the largest distance of an order price from the
+	// orderbook mid price
+	FatFingerPercentage float64 `json:"fatFingerPercentage"`
+}
+
+// ExchangeRiskLimit overrides the default risk limit for an exchange, or for a
+// named account or sub-account of an exchange when set
+type ExchangeRiskLimit struct {
+	Exchange   string `json:"exchange"`
+	Account    string `json:"account,omitempty"`
+	SubAccount string `json:"subAccount"`
+	RiskLimit
 }
 
 // DataHistoryManager holds all information required for the data history manager
@@ -129,6 +210,182 @@ type CurrencyStateManager struct {
 	Delay   time.Duration `json:"delay"`
 }
 
+// CandleBuilder defines the configuration options for building candles from
+// live websocket trades
+type CandleBuilder struct {
+	Enabled         bool            `json:"enabled"`
+	Intervals       []time.Duration `json:"intervals"`
+	MaxCandles      int             `json:"maxCandles"`
+	PersistCandles  bool            `json:"persistCandles"`
+	PersistInterval time.Duration   `json:"persistInterval"`
+	Verbose         bool            `json:"verbose"`
+}
+
+// OrderbookRecorder defines the configuration options for recording websocket
+// orderbook snapshots and updates to disk
+type OrderbookRecorder struct {
+	Enabled bool `json:"enabled"`
+	// Directory defaults to the orderbooks folder within the data directory
+	Directory        string        `json:"directory"`
+	RotationInterval time.Duration `json:"rotationInterval"`
+	MaxFileSize      int64         `json:"maxFileSize"`
+	// Exchanges restricts recording to the listed exchanges, all exchanges
+	// are recorded when empty
+	Exchanges []string `json:"exchanges"`
+}
+
+// Arbitrage defines the configuration options for the triangular and cross
+// exchange arbitrage scanner
+type Arbitrage struct {
+	Enabled      bool          `json:"enabled"`
+	ScanInterval time.Duration `json:"scanInterval"`
+	// MinProfitPercentage is the minimum profit net of fees and withdrawal
+	// costs for an opportunity to be reported e.g. 0.5 = 0.5%
+	MinProfitPercentage float64 `json:"minProfitPercentage"`
+	Triangular          bool    `json:"triangular"`
+	CrossExchange       bool    `json:"crossExchange"`
+	// Exchanges restricts scanning to the listed exchanges, all enabled
+	// exchanges are scanned when empty
+	Exchanges []string `json:"exchanges"`
+	// Capital is the maximum amount to deploy per opportunity keyed by the
+	// currency an opportunity starts and ends in. Only opportunities starting
+	// in a listed currency are evaluated.
+	Capital map[string]float64 `json:"capital"`
+	// AutoExecute submits the legs of an opportunity via the order manager
+	AutoExecute       bool          `json:"autoExecute"`
+	ExecutionCooldown time.Duration `json:"executionCooldown"`
+	Verbose           bool          `json:"verbose"`
+}
+
+// Rebalancer defines the configuration options for rebalancing holdings
+// across exchanges and cold storage towards target allocations
+type Rebalancer struct {
+	Enabled bool `json:"enabled"`
+	// Interval is the duration between portfolio evaluations
+	Interval time.Duration `json:"interval"`
+	// QuoteCurrency values holdings and is the currency traded against on each
+	// exchange
+	QuoteCurrency currency.Code `json:"quoteCurrency"`
+	// DriftThreshold is the allocation drift in percentage points which must
+	// be exceeded by a holding for a plan to be actioned e.g. 5 = 5%
+	DriftThreshold float64 `json:"driftThreshold"`
+	// MinimumTradeValue is the smallest trade or transfer proposed, valued in
+	// the quote currency
+	MinimumTradeValue float64 `json:"minimumTradeValue"`
+	// DryRun proposes plans without ever executing them
+	DryRun bool `json:"dryRun"`
+	// RequireApproval holds proposed plans until they are approved
+	RequireApproval bool               `json:"requireApproval"`
+	Targets         []RebalancerTarget `json:"targets"`
+	Verbose         bool               `json:"verbose"`
+}
+
+// RebalancerTarget defines the target weight of a currency held at an exchange
+// or in cold storage
+type RebalancerTarget struct {
+	// Exchange is the exchange name or coldstorage for portfolio addresses
+	// flagged as cold storage
+	Exchange string `json:"exchange"`
+	Currency string `json:"currency"`
+	// Weight is the percentage of the total managed value e.g. 25 = 25%
+	Weight float64 `json:"weight"`
+}
+
+// PortfolioHistory defines the configuration options for periodically saving
+// valued snapshots of holdings to the database
+type PortfolioHistory struct {
+	Enabled bool `json:"enabled"`
+	// Interval is the duration between snapshots
+	Interval time.Duration `json:"interval"`
+	// ValueCurrency is the fiat currency holdings are valued in
+	ValueCurrency currency.Code `json:"valueCurrency"`
+	// Exchanges limits the exchanges snapshotted, all enabled exchanges with
+	// credentials are snapshotted when empty
+	Exchanges []string `json:"exchanges"`
+	Verbose   bool     `json:"verbose"`
+}
+
+// TaxLots defines the configuration options for tracking acquired lots and
+// realised capital gains from executed trades and transfers
+type TaxLots struct {
+	Enabled bool `json:"enabled"`
+	// Method is the default lot matching method, one of FIFO, LIFO, HIFO or
+	// AVERAGE
+	Method string `json:"method"`
+	// ReportCurrency is the fiat currency gains are denominated in
+	ReportCurrency currency.Code `json:"reportCurrency"`
+	// ImportInterval is the duration between imports of exchange order,
+	// deposit and withdrawal history
+	ImportInterval time.Duration `json:"importInterval"`
+	// ImportStart is the earliest history imported, defaults to the start of
+	// the previous calendar year
+	ImportStart time.Time `json:"importStart"`
+	// Exchanges limits the exchanges history is imported from, all enabled
+	// exchanges with credentials are imported from when empty
+	Exchanges []string `json:"exchanges"`
+	Verbose   bool     `json:"verbose"`
+}
+
+// Reconciliation defines the configuration options for comparing orders, fills
+// and balances tracked by the engine against exchange history
+type Reconciliation struct {
+	Enabled bool `json:"enabled"`
+	// Interval is the duration between scheduled reconciliations
+	Interval time.Duration `json:"interval"`
+	// Window is how far back exchange order history is compared
+	Window time.Duration `json:"window"`
+	// FeeTolerance is the largest fee difference not reported as a
+	// discrepancy
+	FeeTolerance float64 `json:"feeTolerance"`
+	// BalanceTolerance is the largest difference between the observed and
+	// expected change in a balance not reported as drift
+	BalanceTolerance float64 `json:"balanceTolerance"`
+	// Exchanges limits the exchanges reconciled, all enabled exchanges with
+	// credentials are reconciled when empty
+	Exchanges []string `json:"exchanges"`
+	Verbose   bool     `json:"verbose"`
+}
+
+// DeadManSwitch defines when open orders are cancelled automatically because
+// the engine can no longer be trusted to manage them
+type DeadManSwitch struct {
+	Enabled       bool          `json:"enabled"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// StaleDataTimeout is how old the market data of a pair with open orders
+	// can be before the orders of that exchange are cancelled. Zero disables
+	// the check
+	StaleDataTimeout time.Duration `json:"staleDataTimeout"`
+	// HeartbeatTimeout is how long after the last heartbeat from an external
+	// controller all orders are cancelled. Zero disables the check
+	HeartbeatTimeout time.Duration `json:"heartbeatTimeout"`
+	// NativeTimeout is the timeout set on exchange side dead man's switches,
+	// refreshed every check while the engine is healthy. Zero disables them
+	NativeTimeout time.Duration `json:"nativeTimeout"`
+	// Exchanges limits the exchanges monitored, all enabled exchanges with
+	// credentials are monitored when empty
+	Exchanges []string `json:"exchanges"`
+	Verbose   bool     `json:"verbose"`
+}
+
+// Metrics defines the metrics exporter served in the OpenMetrics text format
+// on the REST API server
+type Metrics struct {
+	Enabled bool `json:"enabled"`
+	// Path is the REST API server path metrics are served on
+	Path string `json:"path"`
+}
+
+// Tracing defines the OTLP/HTTP collector order submission traces are exported
+// to
+type Tracing struct {
+	Enabled bool `json:"enabled"`
+	// Endpoint is the collector traces URL, such as
+	// http://localhost:4318/v1/traces
+	Endpoint       string        `json:"endpoint"`
+	ServiceName    string        `json:"serviceName"`
+	ExportInterval time.Duration `json:"exportInterval"`
+}
+
 // ConnectionMonitorConfig defines the connection monitor variables to ensure
 // that there is internet connectivity
 type ConnectionMonitorConfig struct {
@@ -156,6 +413,8 @@ type Exchange struct {
 	Features                      *FeaturesConfig        `json:"features"`
 	BankAccounts                  []banking.Account      `json:"bankAccounts,omitempty"`
 	Orderbook                     Orderbook              `json:"orderbook"`
+	RateLimit                     *RateLimit             `json:"rateLimit,omitempty"`
+	CircuitBreaker                *CircuitBreaker        `json:"circuitBreaker,omitempty"`
 
 	// Deprecated settings which will be removed in a future update
 	AvailablePairs                   *currency.Pairs      `json:"availablePairs,omitempty"`
@@ -274,6 +533,11 @@ type FeaturesEnabledConfig struct {
 	SaveTradeData   bool `json:"saveTradeData"`
 	TradeFeed       bool `json:"tradeFeed"`
 	FillsFeed       bool `json:"fillsFeed"`
+	// WebsocketOrderEntry submits, modifies and cancels orders over the
+	// authenticated websocket for exchanges which support it, falling back
+	// to REST when the websocket is unavailable. When unset the exchange
+	// default is used
+	WebsocketOrderEntry *bool `json:"websocketOrderEntry,omitempty"`
 }
 
 // FeaturesConfig stores the exchanges supported and enabled features
@@ -301,6 +565,13 @@ type APICredentialsConfig struct {
 	PIN           string `json:"pin,omitempty"`
 }
 
+// APIAccountConfig stores the credentials of a named exchange account, such as
+// a sub-account per strategy, used alongside the default credentials
+type APIAccountConfig struct {
+	Name        string               `json:"name"`
+	Credentials APICredentialsConfig `json:"credentials"`
+}
+
 // APICredentialsValidatorConfig stores the API credentials validator settings
 type APICredentialsValidatorConfig struct {
 	// For Huobi (optional)
@@ -319,16 +590,50 @@ type APIConfig struct {
 	PEMKeySupport                 bool `json:"pemKeySupport,omitempty"`
 
 	Credentials          APICredentialsConfig           `json:"credentials"`
+	Accounts             []APIAccountConfig             `json:"accounts,omitempty"`
 	CredentialsValidator *APICredentialsValidatorConfig `json:"credentialsValidator,omitempty"`
 	OldEndPoints         *APIEndpointsConfig            `json:"endpoints,omitempty"`
 	Endpoints            map[string]string              `json:"urlEndpoints"`
 }
 
+// RateLimit stores the exchange REST rate limiter configuration
+type RateLimit struct {
+	// Adaptive enables weight based rate limiting for exchanges which support
+	// it, learning how much of each weight budget is spent from exchange
+	// response headers
+	Adaptive bool `json:"adaptive"`
+	// Threshold is the fraction of a weight budget which can be spent before
+	// requests are slowed, defaulting to 0.8
+	Threshold float64 `json:"threshold,omitempty"`
+	// Capacities overrides the capacity of weight budgets by name
+	Capacities map[string]int `json:"capacities,omitempty"`
+}
+
+// CircuitBreaker stores the exchange REST circuit breaker configuration. Zero
+// values use the request package defaults
+type CircuitBreaker struct {
+	// Enabled rejects requests to an exchange endpoint which is unavailable,
+	// circuit breakers are disabled by default
+	Enabled bool `json:"enabled"`
+	// FailureThreshold is the number of consecutive failed requests to an
+	// endpoint which open its breaker
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// OpenDuration is how long an open breaker rejects requests before a
+	// probe request is allowed
+	OpenDuration time.Duration `json:"openDuration,omitempty"`
+	// SuccessThreshold is the number of successful probe requests which close
+	// a breaker
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+}
+
 // Orderbook stores the orderbook configuration variables
 type Orderbook struct {
 	VerificationBypass     bool `json:"verificationBypass"`
 	WebsocketBufferLimit   int  `json:"websocketBufferLimit"`
 	WebsocketBufferEnabled bool `json:"websocketBufferEnabled"`
+	// CrossedBookDetection invalidates and resyncs websocket books when the
+	// best bid meets or exceeds the best ask
+	CrossedBookDetection bool `json:"crossedBookDetection"`
 	// PublishPeriod here is a pointer because we want to distinguish
 	// between zeroed out and missing.
 	PublishPeriod *time.Duration `json:"publishPeriod"`
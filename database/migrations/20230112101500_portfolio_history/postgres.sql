@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS portfolio_snapshot
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    value_currency varchar(30) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_holding
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    snapshot_id uuid NOT NULL REFERENCES portfolio_snapshot(id) ON DELETE CASCADE,
+    exchange varchar NOT NULL,
+    account varchar NOT NULL,
+    asset varchar NOT NULL,
+    currency varchar(30) NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    value DOUBLE PRECISION NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS portfolio_flow
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange varchar NOT NULL,
+    transfer_id varchar NOT NULL,
+    type varchar NOT NULL,
+    currency varchar(30) NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    fee DOUBLE PRECISION NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    value_currency varchar(30) NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniqueportfolioflow
+        unique(exchange, transfer_id)
+);
+-- +goose Down
+DROP TABLE portfolio_flow;
+DROP TABLE portfolio_holding;
+DROP TABLE portfolio_snapshot;
@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE portfolio_snapshot
+(
+    id text NOT NULL primary key,
+    value_currency text NOT NULL,
+    value real NOT NULL,
+    timestamp timestamp NOT NULL,
+    UNIQUE(id) ON CONFLICT REPLACE
+);
+
+CREATE TABLE portfolio_holding
+(
+    id text NOT NULL primary key,
+    snapshot_id text NOT NULL,
+    exchange text NOT NULL,
+    account text NOT NULL,
+    asset text NOT NULL,
+    currency text NOT NULL,
+    amount real NOT NULL,
+    price real NOT NULL,
+    value real NOT NULL,
+    UNIQUE(id) ON CONFLICT REPLACE,
+    FOREIGN KEY(snapshot_id) REFERENCES portfolio_snapshot(id) ON DELETE CASCADE
+);
+
+CREATE TABLE portfolio_flow
+(
+    id text NOT NULL primary key,
+    exchange text NOT NULL,
+    transfer_id text NOT NULL,
+    type text NOT NULL,
+    currency text NOT NULL,
+    amount real NOT NULL,
+    fee real NOT NULL,
+    price real NOT NULL,
+    value real NOT NULL,
+    value_currency text NOT NULL,
+    timestamp timestamp NOT NULL,
+    CONSTRAINT uniqueportfolioflow
+        unique(exchange, transfer_id) ON CONFLICT IGNORE
+);
+-- +goose Down
+DROP TABLE portfolio_flow;
+DROP TABLE portfolio_holding;
+DROP TABLE portfolio_snapshot;
@@ -0,0 +1,77 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS managed_order
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange varchar NOT NULL,
+    account varchar NOT NULL,
+    order_id varchar NOT NULL,
+    client_order_id varchar NOT NULL,
+    asset varchar NOT NULL,
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    side varchar NOT NULL,
+    type varchar NOT NULL,
+    status varchar NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    executed_amount DOUBLE PRECISION NOT NULL,
+    remaining_amount DOUBLE PRECISION NOT NULL,
+    average_executed_price DOUBLE PRECISION NOT NULL,
+    cost DOUBLE PRECISION NOT NULL,
+    fee DOUBLE PRECISION NOT NULL,
+    fee_asset varchar(30) NOT NULL,
+    leverage DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS managed_order_transition
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    managed_order_id uuid NOT NULL REFERENCES managed_order(id) ON DELETE CASCADE,
+    status varchar NOT NULL,
+    executed_amount DOUBLE PRECISION NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS managed_order_fill
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    managed_order_id uuid NOT NULL REFERENCES managed_order(id) ON DELETE CASCADE,
+    trade_id varchar NOT NULL,
+    side varchar NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    fee DOUBLE PRECISION NOT NULL,
+    fee_asset varchar(30) NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniquemanagedorderfill
+        unique(managed_order_id, trade_id)
+);
+
+CREATE TABLE IF NOT EXISTS position_snapshot
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange varchar NOT NULL,
+    asset varchar NOT NULL,
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    status varchar NOT NULL,
+    opening_direction varchar NOT NULL,
+    opening_price DOUBLE PRECISION NOT NULL,
+    opening_size DOUBLE PRECISION NOT NULL,
+    opening_date TIMESTAMPTZ NOT NULL,
+    latest_direction varchar NOT NULL,
+    latest_price DOUBLE PRECISION NOT NULL,
+    latest_size DOUBLE PRECISION NOT NULL,
+    realised_pnl DOUBLE PRECISION NOT NULL,
+    unrealised_pnl DOUBLE PRECISION NOT NULL,
+    close_date TIMESTAMPTZ NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL
+);
+-- +goose Down
+DROP TABLE position_snapshot;
+DROP TABLE managed_order_fill;
+DROP TABLE managed_order_transition;
+DROP TABLE managed_order;
@@ -0,0 +1,82 @@
+-- +goose Up
+CREATE TABLE managed_order
+(
+    id text NOT NULL primary key,
+    exchange text NOT NULL,
+    account text NOT NULL,
+    order_id text NOT NULL,
+    client_order_id text NOT NULL,
+    asset text NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    side text NOT NULL,
+    type text NOT NULL,
+    status text NOT NULL,
+    price real NOT NULL,
+    amount real NOT NULL,
+    executed_amount real NOT NULL,
+    remaining_amount real NOT NULL,
+    average_executed_price real NOT NULL,
+    cost real NOT NULL,
+    fee real NOT NULL,
+    fee_asset text NOT NULL,
+    leverage real NOT NULL,
+    created_at timestamp NOT NULL,
+    closed_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL,
+    UNIQUE(id) ON CONFLICT REPLACE
+);
+
+CREATE TABLE managed_order_transition
+(
+    id text NOT NULL primary key,
+    managed_order_id text NOT NULL,
+    status text NOT NULL,
+    executed_amount real NOT NULL,
+    timestamp timestamp NOT NULL,
+    UNIQUE(id) ON CONFLICT REPLACE,
+    FOREIGN KEY(managed_order_id) REFERENCES managed_order(id) ON DELETE CASCADE
+);
+
+CREATE TABLE managed_order_fill
+(
+    id text NOT NULL primary key,
+    managed_order_id text NOT NULL,
+    trade_id text NOT NULL,
+    side text NOT NULL,
+    price real NOT NULL,
+    amount real NOT NULL,
+    fee real NOT NULL,
+    fee_asset text NOT NULL,
+    timestamp timestamp NOT NULL,
+    FOREIGN KEY(managed_order_id) REFERENCES managed_order(id) ON DELETE CASCADE,
+    CONSTRAINT uniquemanagedorderfill
+        unique(managed_order_id, trade_id) ON CONFLICT IGNORE
+);
+
+CREATE TABLE position_snapshot
+(
+    id text NOT NULL primary key,
+    exchange text NOT NULL,
+    asset text NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    status text NOT NULL,
+    opening_direction text NOT NULL,
+    opening_price real NOT NULL,
+    opening_size real NOT NULL,
+    opening_date timestamp NOT NULL,
+    latest_direction text NOT NULL,
+    latest_price real NOT NULL,
+    latest_size real NOT NULL,
+    realised_pnl real NOT NULL,
+    unrealised_pnl real NOT NULL,
+    close_date timestamp NOT NULL,
+    timestamp timestamp NOT NULL,
+    UNIQUE(id) ON CONFLICT REPLACE
+);
+-- +goose Down
+DROP TABLE position_snapshot;
+DROP TABLE managed_order_fill;
+DROP TABLE managed_order_transition;
+DROP TABLE managed_order;
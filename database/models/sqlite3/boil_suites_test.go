@@ -17,6 +17,13 @@ func TestParent(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobs)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresults)
 	t.Run("Exchanges", testExchanges)
+	t.Run("ManagedOrderFills", testManagedOrderFills)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitions)
+	t.Run("ManagedOrders", testManagedOrders)
+	t.Run("PortfolioFlows", testPortfolioFlows)
+	t.Run("PortfolioHoldings", testPortfolioHoldings)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshots)
+	t.Run("PositionSnapshots", testPositionSnapshots)
 	t.Run("Scripts", testScripts)
 	t.Run("ScriptExecutions", testScriptExecutions)
 	t.Run("Trades", testTrades)
@@ -31,6 +38,13 @@ func TestDelete(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsDelete)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsDelete)
 	t.Run("Exchanges", testExchangesDelete)
+	t.Run("ManagedOrderFills", testManagedOrderFillsDelete)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsDelete)
+	t.Run("ManagedOrders", testManagedOrdersDelete)
+	t.Run("PortfolioFlows", testPortfolioFlowsDelete)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsDelete)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsDelete)
+	t.Run("PositionSnapshots", testPositionSnapshotsDelete)
 	t.Run("Scripts", testScriptsDelete)
 	t.Run("ScriptExecutions", testScriptExecutionsDelete)
 	t.Run("Trades", testTradesDelete)
@@ -45,6 +59,13 @@ func TestQueryDeleteAll(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsQueryDeleteAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsQueryDeleteAll)
 	t.Run("Exchanges", testExchangesQueryDeleteAll)
+	t.Run("ManagedOrderFills", testManagedOrderFillsQueryDeleteAll)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsQueryDeleteAll)
+	t.Run("ManagedOrders", testManagedOrdersQueryDeleteAll)
+	t.Run("PortfolioFlows", testPortfolioFlowsQueryDeleteAll)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsQueryDeleteAll)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsQueryDeleteAll)
+	t.Run("PositionSnapshots", testPositionSnapshotsQueryDeleteAll)
 	t.Run("Scripts", testScriptsQueryDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsQueryDeleteAll)
 	t.Run("Trades", testTradesQueryDeleteAll)
@@ -59,6 +80,13 @@ func TestSliceDeleteAll(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsSliceDeleteAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSliceDeleteAll)
 	t.Run("Exchanges", testExchangesSliceDeleteAll)
+	t.Run("ManagedOrderFills", testManagedOrderFillsSliceDeleteAll)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsSliceDeleteAll)
+	t.Run("ManagedOrders", testManagedOrdersSliceDeleteAll)
+	t.Run("PortfolioFlows", testPortfolioFlowsSliceDeleteAll)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsSliceDeleteAll)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsSliceDeleteAll)
+	t.Run("PositionSnapshots", testPositionSnapshotsSliceDeleteAll)
 	t.Run("Scripts", testScriptsSliceDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceDeleteAll)
 	t.Run("Trades", testTradesSliceDeleteAll)
@@ -73,6 +101,13 @@ func TestExists(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsExists)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsExists)
 	t.Run("Exchanges", testExchangesExists)
+	t.Run("ManagedOrderFills", testManagedOrderFillsExists)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsExists)
+	t.Run("ManagedOrders", testManagedOrdersExists)
+	t.Run("PortfolioFlows", testPortfolioFlowsExists)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsExists)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsExists)
+	t.Run("PositionSnapshots", testPositionSnapshotsExists)
 	t.Run("Scripts", testScriptsExists)
 	t.Run("ScriptExecutions", testScriptExecutionsExists)
 	t.Run("Trades", testTradesExists)
@@ -87,6 +122,13 @@ func TestFind(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsFind)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsFind)
 	t.Run("Exchanges", testExchangesFind)
+	t.Run("ManagedOrderFills", testManagedOrderFillsFind)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsFind)
+	t.Run("ManagedOrders", testManagedOrdersFind)
+	t.Run("PortfolioFlows", testPortfolioFlowsFind)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsFind)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsFind)
+	t.Run("PositionSnapshots", testPositionSnapshotsFind)
 	t.Run("Scripts", testScriptsFind)
 	t.Run("ScriptExecutions", testScriptExecutionsFind)
 	t.Run("Trades", testTradesFind)
@@ -101,6 +143,13 @@ func TestBind(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsBind)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsBind)
 	t.Run("Exchanges", testExchangesBind)
+	t.Run("ManagedOrderFills", testManagedOrderFillsBind)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsBind)
+	t.Run("ManagedOrders", testManagedOrdersBind)
+	t.Run("PortfolioFlows", testPortfolioFlowsBind)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsBind)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsBind)
+	t.Run("PositionSnapshots", testPositionSnapshotsBind)
 	t.Run("Scripts", testScriptsBind)
 	t.Run("ScriptExecutions", testScriptExecutionsBind)
 	t.Run("Trades", testTradesBind)
@@ -115,6 +164,13 @@ func TestOne(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsOne)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsOne)
 	t.Run("Exchanges", testExchangesOne)
+	t.Run("ManagedOrderFills", testManagedOrderFillsOne)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsOne)
+	t.Run("ManagedOrders", testManagedOrdersOne)
+	t.Run("PortfolioFlows", testPortfolioFlowsOne)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsOne)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsOne)
+	t.Run("PositionSnapshots", testPositionSnapshotsOne)
 	t.Run("Scripts", testScriptsOne)
 	t.Run("ScriptExecutions", testScriptExecutionsOne)
 	t.Run("Trades", testTradesOne)
@@ -129,6 +185,13 @@ func TestAll(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsAll)
 	t.Run("Exchanges", testExchangesAll)
+	t.Run("ManagedOrderFills", testManagedOrderFillsAll)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsAll)
+	t.Run("ManagedOrders", testManagedOrdersAll)
+	t.Run("PortfolioFlows", testPortfolioFlowsAll)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsAll)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsAll)
+	t.Run("PositionSnapshots", testPositionSnapshotsAll)
 	t.Run("Scripts", testScriptsAll)
 	t.Run("ScriptExecutions", testScriptExecutionsAll)
 	t.Run("Trades", testTradesAll)
@@ -143,6 +206,13 @@ func TestCount(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsCount)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsCount)
 	t.Run("Exchanges", testExchangesCount)
+	t.Run("ManagedOrderFills", testManagedOrderFillsCount)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsCount)
+	t.Run("ManagedOrders", testManagedOrdersCount)
+	t.Run("PortfolioFlows", testPortfolioFlowsCount)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsCount)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsCount)
+	t.Run("PositionSnapshots", testPositionSnapshotsCount)
 	t.Run("Scripts", testScriptsCount)
 	t.Run("ScriptExecutions", testScriptExecutionsCount)
 	t.Run("Trades", testTradesCount)
@@ -157,6 +227,13 @@ func TestHooks(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsHooks)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsHooks)
 	t.Run("Exchanges", testExchangesHooks)
+	t.Run("ManagedOrderFills", testManagedOrderFillsHooks)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsHooks)
+	t.Run("ManagedOrders", testManagedOrdersHooks)
+	t.Run("PortfolioFlows", testPortfolioFlowsHooks)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsHooks)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsHooks)
+	t.Run("PositionSnapshots", testPositionSnapshotsHooks)
 	t.Run("Scripts", testScriptsHooks)
 	t.Run("ScriptExecutions", testScriptExecutionsHooks)
 	t.Run("Trades", testTradesHooks)
@@ -176,6 +253,20 @@ func TestInsert(t *testing.T) {
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsInsertWhitelist)
 	t.Run("Exchanges", testExchangesInsert)
 	t.Run("Exchanges", testExchangesInsertWhitelist)
+	t.Run("ManagedOrderFills", testManagedOrderFillsInsert)
+	t.Run("ManagedOrderFills", testManagedOrderFillsInsertWhitelist)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsInsert)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsInsertWhitelist)
+	t.Run("ManagedOrders", testManagedOrdersInsert)
+	t.Run("ManagedOrders", testManagedOrdersInsertWhitelist)
+	t.Run("PortfolioFlows", testPortfolioFlowsInsert)
+	t.Run("PortfolioFlows", testPortfolioFlowsInsertWhitelist)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsInsert)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsInsertWhitelist)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsInsert)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsInsertWhitelist)
+	t.Run("PositionSnapshots", testPositionSnapshotsInsert)
+	t.Run("PositionSnapshots", testPositionSnapshotsInsertWhitelist)
 	t.Run("Scripts", testScriptsInsert)
 	t.Run("Scripts", testScriptsInsertWhitelist)
 	t.Run("ScriptExecutions", testScriptExecutionsInsert)
@@ -199,6 +290,9 @@ func TestToOne(t *testing.T) {
 	t.Run("DatahistoryjobToExchangeUsingExchangeName", testDatahistoryjobToOneExchangeUsingExchangeName)
 	t.Run("DatahistoryjobToExchangeUsingSecondaryExchange", testDatahistoryjobToOneExchangeUsingSecondaryExchange)
 	t.Run("DatahistoryjobresultToDatahistoryjobUsingJob", testDatahistoryjobresultToOneDatahistoryjobUsingJob)
+	t.Run("ManagedOrderFillToManagedOrderUsingManagedOrder", testManagedOrderFillToOneManagedOrderUsingManagedOrder)
+	t.Run("ManagedOrderTransitionToManagedOrderUsingManagedOrder", testManagedOrderTransitionToOneManagedOrderUsingManagedOrder)
+	t.Run("PortfolioHoldingToPortfolioSnapshotUsingSnapshot", testPortfolioHoldingToOnePortfolioSnapshotUsingSnapshot)
 	t.Run("ScriptExecutionToScriptUsingScript", testScriptExecutionToOneScriptUsingScript)
 	t.Run("TradeToExchangeUsingExchangeName", testTradeToOneExchangeUsingExchangeName)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalHistory", testWithdrawalCryptoToOneWithdrawalHistoryUsingWithdrawalHistory)
@@ -224,6 +318,9 @@ func TestToMany(t *testing.T) {
 	t.Run("ExchangeToExchangeNameDatahistoryjobs", testExchangeToManyExchangeNameDatahistoryjobs)
 	t.Run("ExchangeToSecondaryExchangeDatahistoryjobs", testExchangeToManySecondaryExchangeDatahistoryjobs)
 	t.Run("ExchangeToExchangeNameWithdrawalHistories", testExchangeToManyExchangeNameWithdrawalHistories)
+	t.Run("ManagedOrderToManagedOrderFills", testManagedOrderToManyManagedOrderFills)
+	t.Run("ManagedOrderToManagedOrderTransitions", testManagedOrderToManyManagedOrderTransitions)
+	t.Run("PortfolioSnapshotToSnapshotPortfolioHoldings", testPortfolioSnapshotToManySnapshotPortfolioHoldings)
 	t.Run("ScriptToScriptExecutions", testScriptToManyScriptExecutions)
 	t.Run("WithdrawalHistoryToWithdrawalCryptos", testWithdrawalHistoryToManyWithdrawalCryptos)
 	t.Run("WithdrawalHistoryToWithdrawalFiats", testWithdrawalHistoryToManyWithdrawalFiats)
@@ -238,6 +335,9 @@ func TestToOneSet(t *testing.T) {
 	t.Run("DatahistoryjobToExchangeUsingExchangeNameDatahistoryjobs", testDatahistoryjobToOneSetOpExchangeUsingExchangeName)
 	t.Run("DatahistoryjobToExchangeUsingSecondaryExchangeDatahistoryjobs", testDatahistoryjobToOneSetOpExchangeUsingSecondaryExchange)
 	t.Run("DatahistoryjobresultToDatahistoryjobUsingJobDatahistoryjobresults", testDatahistoryjobresultToOneSetOpDatahistoryjobUsingJob)
+	t.Run("ManagedOrderFillToManagedOrderUsingManagedOrderFills", testManagedOrderFillToOneSetOpManagedOrderUsingManagedOrder)
+	t.Run("ManagedOrderTransitionToManagedOrderUsingManagedOrderTransitions", testManagedOrderTransitionToOneSetOpManagedOrderUsingManagedOrder)
+	t.Run("PortfolioHoldingToPortfolioSnapshotUsingSnapshotPortfolioHoldings", testPortfolioHoldingToOneSetOpPortfolioSnapshotUsingSnapshot)
 	t.Run("ScriptExecutionToScriptUsingScriptExecutions", testScriptExecutionToOneSetOpScriptUsingScript)
 	t.Run("TradeToExchangeUsingExchangeNameTrade", testTradeToOneSetOpExchangeUsingExchangeName)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalCryptos", testWithdrawalCryptoToOneSetOpWithdrawalHistoryUsingWithdrawalHistory)
@@ -275,6 +375,9 @@ func TestToManyAdd(t *testing.T) {
 	t.Run("ExchangeToExchangeNameDatahistoryjobs", testExchangeToManyAddOpExchangeNameDatahistoryjobs)
 	t.Run("ExchangeToSecondaryExchangeDatahistoryjobs", testExchangeToManyAddOpSecondaryExchangeDatahistoryjobs)
 	t.Run("ExchangeToExchangeNameWithdrawalHistories", testExchangeToManyAddOpExchangeNameWithdrawalHistories)
+	t.Run("ManagedOrderToManagedOrderFills", testManagedOrderToManyAddOpManagedOrderFills)
+	t.Run("ManagedOrderToManagedOrderTransitions", testManagedOrderToManyAddOpManagedOrderTransitions)
+	t.Run("PortfolioSnapshotToSnapshotPortfolioHoldings", testPortfolioSnapshotToManyAddOpSnapshotPortfolioHoldings)
 	t.Run("ScriptToScriptExecutions", testScriptToManyAddOpScriptExecutions)
 	t.Run("WithdrawalHistoryToWithdrawalCryptos", testWithdrawalHistoryToManyAddOpWithdrawalCryptos)
 	t.Run("WithdrawalHistoryToWithdrawalFiats", testWithdrawalHistoryToManyAddOpWithdrawalFiats)
@@ -306,6 +409,13 @@ func TestReload(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsReload)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsReload)
 	t.Run("Exchanges", testExchangesReload)
+	t.Run("ManagedOrderFills", testManagedOrderFillsReload)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsReload)
+	t.Run("ManagedOrders", testManagedOrdersReload)
+	t.Run("PortfolioFlows", testPortfolioFlowsReload)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsReload)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsReload)
+	t.Run("PositionSnapshots", testPositionSnapshotsReload)
 	t.Run("Scripts", testScriptsReload)
 	t.Run("ScriptExecutions", testScriptExecutionsReload)
 	t.Run("Trades", testTradesReload)
@@ -320,6 +430,13 @@ func TestReloadAll(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsReloadAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsReloadAll)
 	t.Run("Exchanges", testExchangesReloadAll)
+	t.Run("ManagedOrderFills", testManagedOrderFillsReloadAll)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsReloadAll)
+	t.Run("ManagedOrders", testManagedOrdersReloadAll)
+	t.Run("PortfolioFlows", testPortfolioFlowsReloadAll)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsReloadAll)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsReloadAll)
+	t.Run("PositionSnapshots", testPositionSnapshotsReloadAll)
 	t.Run("Scripts", testScriptsReloadAll)
 	t.Run("ScriptExecutions", testScriptExecutionsReloadAll)
 	t.Run("Trades", testTradesReloadAll)
@@ -334,6 +451,13 @@ func TestSelect(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsSelect)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSelect)
 	t.Run("Exchanges", testExchangesSelect)
+	t.Run("ManagedOrderFills", testManagedOrderFillsSelect)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsSelect)
+	t.Run("ManagedOrders", testManagedOrdersSelect)
+	t.Run("PortfolioFlows", testPortfolioFlowsSelect)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsSelect)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsSelect)
+	t.Run("PositionSnapshots", testPositionSnapshotsSelect)
 	t.Run("Scripts", testScriptsSelect)
 	t.Run("ScriptExecutions", testScriptExecutionsSelect)
 	t.Run("Trades", testTradesSelect)
@@ -348,6 +472,13 @@ func TestUpdate(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsUpdate)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsUpdate)
 	t.Run("Exchanges", testExchangesUpdate)
+	t.Run("ManagedOrderFills", testManagedOrderFillsUpdate)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsUpdate)
+	t.Run("ManagedOrders", testManagedOrdersUpdate)
+	t.Run("PortfolioFlows", testPortfolioFlowsUpdate)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsUpdate)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsUpdate)
+	t.Run("PositionSnapshots", testPositionSnapshotsUpdate)
 	t.Run("Scripts", testScriptsUpdate)
 	t.Run("ScriptExecutions", testScriptExecutionsUpdate)
 	t.Run("Trades", testTradesUpdate)
@@ -362,6 +493,13 @@ func TestSliceUpdateAll(t *testing.T) {
 	t.Run("Datahistoryjobs", testDatahistoryjobsSliceUpdateAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSliceUpdateAll)
 	t.Run("Exchanges", testExchangesSliceUpdateAll)
+	t.Run("ManagedOrderFills", testManagedOrderFillsSliceUpdateAll)
+	t.Run("ManagedOrderTransitions", testManagedOrderTransitionsSliceUpdateAll)
+	t.Run("ManagedOrders", testManagedOrdersSliceUpdateAll)
+	t.Run("PortfolioFlows", testPortfolioFlowsSliceUpdateAll)
+	t.Run("PortfolioHoldings", testPortfolioHoldingsSliceUpdateAll)
+	t.Run("PortfolioSnapshots", testPortfolioSnapshotsSliceUpdateAll)
+	t.Run("PositionSnapshots", testPositionSnapshotsSliceUpdateAll)
 	t.Run("Scripts", testScriptsSliceUpdateAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceUpdateAll)
 	t.Run("Trades", testTradesSliceUpdateAll)
@@ -10,6 +10,13 @@ var TableNames = struct {
 	Datahistoryjobrelations string
 	Datahistoryjobresult    string
 	Exchange                string
+	ManagedOrder            string
+	ManagedOrderFill        string
+	ManagedOrderTransition  string
+	PortfolioFlow           string
+	PortfolioHolding        string
+	PortfolioSnapshot       string
+	PositionSnapshot        string
 	Script                  string
 	ScriptExecution         string
 	Trade                   string
@@ -23,6 +30,13 @@ var TableNames = struct {
 	Datahistoryjobrelations: "datahistoryjobrelations",
 	Datahistoryjobresult:    "datahistoryjobresult",
 	Exchange:                "exchange",
+	ManagedOrder:            "managed_order",
+	ManagedOrderFill:        "managed_order_fill",
+	ManagedOrderTransition:  "managed_order_transition",
+	PortfolioFlow:           "portfolio_flow",
+	PortfolioHolding:        "portfolio_holding",
+	PortfolioSnapshot:       "portfolio_snapshot",
+	PositionSnapshot:        "position_snapshot",
 	Script:                  "script",
 	ScriptExecution:         "script_execution",
 	Trade:                   "trade",
@@ -0,0 +1,643 @@
+package managedorder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Setup returns a DBService
+func Setup(db database.IDatabase) (*DBService, error) {
+	if db == nil {
+		return nil, nil
+	}
+	if !db.IsConnected() {
+		return nil, nil
+	}
+	cfg := db.GetConfig()
+	dbCon, err := db.GetSQL()
+	if err != nil {
+		return nil, err
+	}
+	return &DBService{
+		sql:    dbCon,
+		driver: cfg.Driver,
+	}, nil
+}
+
+// UpsertOrders saves orders and any new fills to the database, recording a
+// transition when an order is new or its status or executed amount has
+// changed since it was last saved
+func (db *DBService) UpsertOrders(orders ...*Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	for i := range orders {
+		if orders[i] == nil {
+			return errNilOrder
+		}
+		if orders[i].ID == "" {
+			return fmt.Errorf("%s %s %w", orders[i].Exchange, orders[i].OrderID, errNoOrderID)
+		}
+	}
+	ctx := context.TODO()
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "UpsertOrders tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		err = upsertOrdersSQLite(ctx, tx, orders...)
+	case database.DBPostgreSQL:
+		err = upsertOrdersPostgres(ctx, tx, orders...)
+	default:
+		return database.ErrNoDatabaseProvided
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetOrdersUpdatedSince returns all orders, with their fills and transitions,
+// which were updated on or after the provided date, ordered by creation time
+func (db *DBService) GetOrdersUpdatedSince(since time.Time) ([]Order, error) {
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		return db.getOrdersUpdatedSinceSQLite(since)
+	case database.DBPostgreSQL:
+		return db.getOrdersUpdatedSincePostgres(since)
+	default:
+		return nil, database.ErrNoDatabaseProvided
+	}
+}
+
+// InsertPositionSnapshots saves position snapshots to the database
+func (db *DBService) InsertPositionSnapshots(snapshots ...*PositionSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	for i := range snapshots {
+		if snapshots[i] == nil {
+			return errNilPositionSnapshot
+		}
+	}
+	ctx := context.TODO()
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "InsertPositionSnapshots tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		err = insertPositionSnapshotsSQLite(ctx, tx, snapshots...)
+	case database.DBPostgreSQL:
+		err = insertPositionSnapshotsPostgres(ctx, tx, snapshots...)
+	default:
+		return database.ErrNoDatabaseProvided
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetPositionSnapshotsBetween returns all position snapshots between two
+// dates, ordered by time
+func (db *DBService) GetPositionSnapshotsBetween(startDate, endDate time.Time) ([]PositionSnapshot, error) {
+	if !startDate.Before(endDate) {
+		return nil, errInvalidTimePeriod
+	}
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		return db.getPositionSnapshotsBetweenSQLite(startDate, endDate)
+	case database.DBPostgreSQL:
+		return db.getPositionSnapshotsBetweenPostgres(startDate, endDate)
+	default:
+		return nil, database.ErrNoDatabaseProvided
+	}
+}
+
+// newID returns the ID when set, or a new UUID
+func newID(id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	freshUUID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return freshUUID.String(), nil
+}
+
+// transitionTime returns when an order transitioned to its current state
+func transitionTime(o *Order) time.Time {
+	if !o.UpdatedAt.IsZero() {
+		return o.UpdatedAt
+	}
+	return time.Now()
+}
+
+// sortOrder sorts the fills and transitions of an order by time
+func sortOrder(o *Order) {
+	sort.SliceStable(o.Fills, func(i, j int) bool {
+		return o.Fills[i].Timestamp.Before(o.Fills[j].Timestamp)
+	})
+	sort.SliceStable(o.Transitions, func(i, j int) bool {
+		return o.Transitions[i].Timestamp.Before(o.Transitions[j].Timestamp)
+	})
+}
+
+func upsertOrdersSQLite(ctx context.Context, tx *sql.Tx, orders ...*Order) error {
+	for i := range orders {
+		existing, err := sqlite3.FindManagedOrder(ctx, tx, orders[i].ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		var tempOrder = sqlite3.ManagedOrder{
+			ID:                   orders[i].ID,
+			Exchange:             orders[i].Exchange,
+			Account:              orders[i].Account,
+			OrderID:              orders[i].OrderID,
+			ClientOrderID:        orders[i].ClientOrderID,
+			Asset:                orders[i].Asset,
+			Base:                 orders[i].Base,
+			Quote:                orders[i].Quote,
+			Side:                 orders[i].Side,
+			Type:                 orders[i].Type,
+			Status:               orders[i].Status,
+			Price:                orders[i].Price,
+			Amount:               orders[i].Amount,
+			ExecutedAmount:       orders[i].ExecutedAmount,
+			RemainingAmount:      orders[i].RemainingAmount,
+			AverageExecutedPrice: orders[i].AverageExecutedPrice,
+			Cost:                 orders[i].Cost,
+			Fee:                  orders[i].Fee,
+			FeeAsset:             orders[i].FeeAsset,
+			Leverage:             orders[i].Leverage,
+			CreatedAt:            orders[i].CreatedAt.UTC().Format(time.RFC3339),
+			ClosedAt:             orders[i].ClosedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:            orders[i].UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if existing == nil {
+			err = tempOrder.Insert(ctx, tx, boil.Infer())
+		} else {
+			_, err = tempOrder.Update(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.Status != orders[i].Status || existing.ExecutedAmount != orders[i].ExecutedAmount {
+			var id string
+			id, err = newID("")
+			if err != nil {
+				return err
+			}
+			var tempTransition = sqlite3.ManagedOrderTransition{
+				ID:             id,
+				ManagedOrderID: orders[i].ID,
+				Status:         orders[i].Status,
+				ExecutedAmount: orders[i].ExecutedAmount,
+				Timestamp:      transitionTime(orders[i]).UTC().Format(time.RFC3339),
+			}
+			err = tempTransition.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+		for j := range orders[i].Fills {
+			orders[i].Fills[j].ID, err = newID(orders[i].Fills[j].ID)
+			if err != nil {
+				return err
+			}
+			var tempFill = sqlite3.ManagedOrderFill{
+				ID:             orders[i].Fills[j].ID,
+				ManagedOrderID: orders[i].ID,
+				TradeID:        orders[i].Fills[j].TradeID,
+				Side:           orders[i].Fills[j].Side,
+				Price:          orders[i].Fills[j].Price,
+				Amount:         orders[i].Fills[j].Amount,
+				Fee:            orders[i].Fills[j].Fee,
+				FeeAsset:       orders[i].Fills[j].FeeAsset,
+				Timestamp:      orders[i].Fills[j].Timestamp.UTC().Format(time.RFC3339),
+			}
+			err = tempFill.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func upsertOrdersPostgres(ctx context.Context, tx *sql.Tx, orders ...*Order) error {
+	for i := range orders {
+		existing, err := postgres.FindManagedOrder(ctx, tx, orders[i].ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		var tempOrder = postgres.ManagedOrder{
+			ID:                   orders[i].ID,
+			Exchange:             orders[i].Exchange,
+			Account:              orders[i].Account,
+			OrderID:              orders[i].OrderID,
+			ClientOrderID:        orders[i].ClientOrderID,
+			Asset:                orders[i].Asset,
+			Base:                 orders[i].Base,
+			Quote:                orders[i].Quote,
+			Side:                 orders[i].Side,
+			Type:                 orders[i].Type,
+			Status:               orders[i].Status,
+			Price:                orders[i].Price,
+			Amount:               orders[i].Amount,
+			ExecutedAmount:       orders[i].ExecutedAmount,
+			RemainingAmount:      orders[i].RemainingAmount,
+			AverageExecutedPrice: orders[i].AverageExecutedPrice,
+			Cost:                 orders[i].Cost,
+			Fee:                  orders[i].Fee,
+			FeeAsset:             orders[i].FeeAsset,
+			Leverage:             orders[i].Leverage,
+			CreatedAt:            orders[i].CreatedAt.UTC(),
+			ClosedAt:             orders[i].ClosedAt.UTC(),
+			UpdatedAt:            orders[i].UpdatedAt.UTC(),
+		}
+		if existing == nil {
+			err = tempOrder.Insert(ctx, tx, boil.Infer())
+		} else {
+			_, err = tempOrder.Update(ctx, tx, boil.Infer())
+		}
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.Status != orders[i].Status || existing.ExecutedAmount != orders[i].ExecutedAmount {
+			var tempTransition = postgres.ManagedOrderTransition{
+				ManagedOrderID: orders[i].ID,
+				Status:         orders[i].Status,
+				ExecutedAmount: orders[i].ExecutedAmount,
+				Timestamp:      transitionTime(orders[i]).UTC(),
+			}
+			err = tempTransition.Insert(ctx, tx, boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+		for j := range orders[i].Fills {
+			var tempFill = postgres.ManagedOrderFill{
+				ID:             orders[i].Fills[j].ID,
+				ManagedOrderID: orders[i].ID,
+				TradeID:        orders[i].Fills[j].TradeID,
+				Side:           orders[i].Fills[j].Side,
+				Price:          orders[i].Fills[j].Price,
+				Amount:         orders[i].Fills[j].Amount,
+				Fee:            orders[i].Fills[j].Fee,
+				FeeAsset:       orders[i].Fills[j].FeeAsset,
+				Timestamp:      orders[i].Fills[j].Timestamp.UTC(),
+			}
+			err = tempFill.Upsert(ctx, tx, false, []string{"managed_order_id", "trade_id"}, boil.Infer(), boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (db *DBService) getOrdersUpdatedSinceSQLite(since time.Time) ([]Order, error) {
+	query := sqlite3.ManagedOrders(
+		qm.Where("updated_at >= ?", since.UTC().Format(time.RFC3339)),
+		qm.OrderBy("created_at"),
+		qm.Load(sqlite3.ManagedOrderRels.ManagedOrderFills),
+		qm.Load(sqlite3.ManagedOrderRels.ManagedOrderTransitions))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Order, len(results))
+	for i := range results {
+		var created, closed, updated time.Time
+		created, err = time.Parse(time.RFC3339, results[i].CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		closed, err = time.Parse(time.RFC3339, results[i].ClosedAt)
+		if err != nil {
+			return nil, err
+		}
+		updated, err = time.Parse(time.RFC3339, results[i].UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Order{
+			ID:                   results[i].ID,
+			Exchange:             results[i].Exchange,
+			Account:              results[i].Account,
+			OrderID:              results[i].OrderID,
+			ClientOrderID:        results[i].ClientOrderID,
+			Asset:                results[i].Asset,
+			Base:                 results[i].Base,
+			Quote:                results[i].Quote,
+			Side:                 results[i].Side,
+			Type:                 results[i].Type,
+			Status:               results[i].Status,
+			Price:                results[i].Price,
+			Amount:               results[i].Amount,
+			ExecutedAmount:       results[i].ExecutedAmount,
+			RemainingAmount:      results[i].RemainingAmount,
+			AverageExecutedPrice: results[i].AverageExecutedPrice,
+			Cost:                 results[i].Cost,
+			Fee:                  results[i].Fee,
+			FeeAsset:             results[i].FeeAsset,
+			Leverage:             results[i].Leverage,
+			CreatedAt:            created,
+			ClosedAt:             closed,
+			UpdatedAt:            updated,
+		}
+		if results[i].R == nil {
+			continue
+		}
+		fills := results[i].R.ManagedOrderFills
+		resp[i].Fills = make([]Fill, len(fills))
+		for j := range fills {
+			var ts time.Time
+			ts, err = time.Parse(time.RFC3339, fills[j].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			resp[i].Fills[j] = Fill{
+				ID:        fills[j].ID,
+				TradeID:   fills[j].TradeID,
+				Side:      fills[j].Side,
+				Price:     fills[j].Price,
+				Amount:    fills[j].Amount,
+				Fee:       fills[j].Fee,
+				FeeAsset:  fills[j].FeeAsset,
+				Timestamp: ts,
+			}
+		}
+		transitions := results[i].R.ManagedOrderTransitions
+		resp[i].Transitions = make([]Transition, len(transitions))
+		for j := range transitions {
+			var ts time.Time
+			ts, err = time.Parse(time.RFC3339, transitions[j].Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			resp[i].Transitions[j] = Transition{
+				ID:             transitions[j].ID,
+				Status:         transitions[j].Status,
+				ExecutedAmount: transitions[j].ExecutedAmount,
+				Timestamp:      ts,
+			}
+		}
+		sortOrder(&resp[i])
+	}
+	return resp, nil
+}
+
+func (db *DBService) getOrdersUpdatedSincePostgres(since time.Time) ([]Order, error) {
+	query := postgres.ManagedOrders(
+		qm.Where("updated_at >= ?", since.UTC()),
+		qm.OrderBy("created_at"),
+		qm.Load(postgres.ManagedOrderRels.ManagedOrderFills),
+		qm.Load(postgres.ManagedOrderRels.ManagedOrderTransitions))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Order, len(results))
+	for i := range results {
+		resp[i] = Order{
+			ID:                   results[i].ID,
+			Exchange:             results[i].Exchange,
+			Account:              results[i].Account,
+			OrderID:              results[i].OrderID,
+			ClientOrderID:        results[i].ClientOrderID,
+			Asset:                results[i].Asset,
+			Base:                 results[i].Base,
+			Quote:                results[i].Quote,
+			Side:                 results[i].Side,
+			Type:                 results[i].Type,
+			Status:               results[i].Status,
+			Price:                results[i].Price,
+			Amount:               results[i].Amount,
+			ExecutedAmount:       results[i].ExecutedAmount,
+			RemainingAmount:      results[i].RemainingAmount,
+			AverageExecutedPrice: results[i].AverageExecutedPrice,
+			Cost:                 results[i].Cost,
+			Fee:                  results[i].Fee,
+			FeeAsset:             results[i].FeeAsset,
+			Leverage:             results[i].Leverage,
+			CreatedAt:            results[i].CreatedAt,
+			ClosedAt:             results[i].ClosedAt,
+			UpdatedAt:            results[i].UpdatedAt,
+		}
+		if results[i].R == nil {
+			continue
+		}
+		fills := results[i].R.ManagedOrderFills
+		resp[i].Fills = make([]Fill, len(fills))
+		for j := range fills {
+			resp[i].Fills[j] = Fill{
+				ID:        fills[j].ID,
+				TradeID:   fills[j].TradeID,
+				Side:      fills[j].Side,
+				Price:     fills[j].Price,
+				Amount:    fills[j].Amount,
+				Fee:       fills[j].Fee,
+				FeeAsset:  fills[j].FeeAsset,
+				Timestamp: fills[j].Timestamp,
+			}
+		}
+		transitions := results[i].R.ManagedOrderTransitions
+		resp[i].Transitions = make([]Transition, len(transitions))
+		for j := range transitions {
+			resp[i].Transitions[j] = Transition{
+				ID:             transitions[j].ID,
+				Status:         transitions[j].Status,
+				ExecutedAmount: transitions[j].ExecutedAmount,
+				Timestamp:      transitions[j].Timestamp,
+			}
+		}
+		sortOrder(&resp[i])
+	}
+	return resp, nil
+}
+
+func insertPositionSnapshotsSQLite(ctx context.Context, tx *sql.Tx, snapshots ...*PositionSnapshot) error {
+	for i := range snapshots {
+		var err error
+		snapshots[i].ID, err = newID(snapshots[i].ID)
+		if err != nil {
+			return err
+		}
+		var tempSnapshot = sqlite3.PositionSnapshot{
+			ID:               snapshots[i].ID,
+			Exchange:         snapshots[i].Exchange,
+			Asset:            snapshots[i].Asset,
+			Base:             snapshots[i].Base,
+			Quote:            snapshots[i].Quote,
+			Status:           snapshots[i].Status,
+			OpeningDirection: snapshots[i].OpeningDirection,
+			OpeningPrice:     snapshots[i].OpeningPrice,
+			OpeningSize:      snapshots[i].OpeningSize,
+			OpeningDate:      snapshots[i].OpeningDate.UTC().Format(time.RFC3339),
+			LatestDirection:  snapshots[i].LatestDirection,
+			LatestPrice:      snapshots[i].LatestPrice,
+			LatestSize:       snapshots[i].LatestSize,
+			RealisedPNL:      snapshots[i].RealisedPNL,
+			UnrealisedPNL:    snapshots[i].UnrealisedPNL,
+			CloseDate:        snapshots[i].CloseDate.UTC().Format(time.RFC3339),
+			Timestamp:        snapshots[i].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err = tempSnapshot.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertPositionSnapshotsPostgres(ctx context.Context, tx *sql.Tx, snapshots ...*PositionSnapshot) error {
+	for i := range snapshots {
+		var err error
+		snapshots[i].ID, err = newID(snapshots[i].ID)
+		if err != nil {
+			return err
+		}
+		var tempSnapshot = postgres.PositionSnapshot{
+			ID:               snapshots[i].ID,
+			Exchange:         snapshots[i].Exchange,
+			Asset:            snapshots[i].Asset,
+			Base:             snapshots[i].Base,
+			Quote:            snapshots[i].Quote,
+			Status:           snapshots[i].Status,
+			OpeningDirection: snapshots[i].OpeningDirection,
+			OpeningPrice:     snapshots[i].OpeningPrice,
+			OpeningSize:      snapshots[i].OpeningSize,
+			OpeningDate:      snapshots[i].OpeningDate.UTC(),
+			LatestDirection:  snapshots[i].LatestDirection,
+			LatestPrice:      snapshots[i].LatestPrice,
+			LatestSize:       snapshots[i].LatestSize,
+			RealisedPNL:      snapshots[i].RealisedPNL,
+			UnrealisedPNL:    snapshots[i].UnrealisedPNL,
+			CloseDate:        snapshots[i].CloseDate.UTC(),
+			Timestamp:        snapshots[i].Timestamp.UTC(),
+		}
+		err = tempSnapshot.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DBService) getPositionSnapshotsBetweenSQLite(startDate, endDate time.Time) ([]PositionSnapshot, error) {
+	query := sqlite3.PositionSnapshots(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)),
+		qm.OrderBy("timestamp"))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]PositionSnapshot, len(results))
+	for i := range results {
+		var opened, closed, ts time.Time
+		opened, err = time.Parse(time.RFC3339, results[i].OpeningDate)
+		if err != nil {
+			return nil, err
+		}
+		closed, err = time.Parse(time.RFC3339, results[i].CloseDate)
+		if err != nil {
+			return nil, err
+		}
+		ts, err = time.Parse(time.RFC3339, results[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = PositionSnapshot{
+			ID:               results[i].ID,
+			Exchange:         results[i].Exchange,
+			Asset:            results[i].Asset,
+			Base:             results[i].Base,
+			Quote:            results[i].Quote,
+			Status:           results[i].Status,
+			OpeningDirection: results[i].OpeningDirection,
+			OpeningPrice:     results[i].OpeningPrice,
+			OpeningSize:      results[i].OpeningSize,
+			OpeningDate:      opened,
+			LatestDirection:  results[i].LatestDirection,
+			LatestPrice:      results[i].LatestPrice,
+			LatestSize:       results[i].LatestSize,
+			RealisedPNL:      results[i].RealisedPNL,
+			UnrealisedPNL:    results[i].UnrealisedPNL,
+			CloseDate:        closed,
+			Timestamp:        ts,
+		}
+	}
+	return resp, nil
+}
+
+func (db *DBService) getPositionSnapshotsBetweenPostgres(startDate, endDate time.Time) ([]PositionSnapshot, error) {
+	query := postgres.PositionSnapshots(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC(), endDate.UTC()),
+		qm.OrderBy("timestamp"))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]PositionSnapshot, len(results))
+	for i := range results {
+		resp[i] = PositionSnapshot{
+			ID:               results[i].ID,
+			Exchange:         results[i].Exchange,
+			Asset:            results[i].Asset,
+			Base:             results[i].Base,
+			Quote:            results[i].Quote,
+			Status:           results[i].Status,
+			OpeningDirection: results[i].OpeningDirection,
+			OpeningPrice:     results[i].OpeningPrice,
+			OpeningSize:      results[i].OpeningSize,
+			OpeningDate:      results[i].OpeningDate,
+			LatestDirection:  results[i].LatestDirection,
+			LatestPrice:      results[i].LatestPrice,
+			LatestSize:       results[i].LatestSize,
+			RealisedPNL:      results[i].RealisedPNL,
+			UnrealisedPNL:    results[i].UnrealisedPNL,
+			CloseDate:        results[i].CloseDate,
+			Timestamp:        results[i].Timestamp,
+		}
+	}
+	return resp, nil
+}
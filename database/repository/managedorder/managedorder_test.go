@@ -0,0 +1,200 @@
+package managedorder
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestManagedOrders(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			db, err := Setup(dbConn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = db.UpsertOrders(nil)
+			if !errors.Is(err, errNilOrder) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errNilOrder)
+			}
+			err = db.UpsertOrders(&Order{Exchange: "one"})
+			if !errors.Is(err, errNoOrderID) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errNoOrderID)
+			}
+
+			start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+			o := &Order{
+				ID:              "a5ac4b9a-5a1d-4ad1-a7c6-9c2a07fae0f3",
+				Exchange:        "one",
+				Account:         "main",
+				OrderID:         "1337",
+				Asset:           "spot",
+				Base:            "BTC",
+				Quote:           "USD",
+				Side:            "BUY",
+				Type:            "LIMIT",
+				Status:          "NEW",
+				Price:           20000,
+				Amount:          1,
+				RemainingAmount: 1,
+				CreatedAt:       start,
+				UpdatedAt:       start,
+			}
+			err = db.UpsertOrders(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// saving an unchanged order must not record a transition
+			err = db.UpsertOrders(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.Status = "PARTIALLY_FILLED"
+			o.ExecutedAmount = 0.5
+			o.RemainingAmount = 0.5
+			o.UpdatedAt = start.Add(time.Hour)
+			o.Fills = []Fill{
+				{TradeID: "t1", Side: "BUY", Price: 20000, Amount: 0.5, Fee: 1, FeeAsset: "USD", Timestamp: start.Add(time.Hour)},
+			}
+			err = db.UpsertOrders(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// saving the same fills again must not duplicate them
+			o.Fills = []Fill{{TradeID: "t1", Side: "BUY", Price: 20000, Amount: 0.5, Timestamp: start.Add(time.Hour)}}
+			err = db.UpsertOrders(o)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			orders, err := db.GetOrdersUpdatedSince(start.Add(time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(orders) != 1 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(orders), 1)
+			}
+			if orders[0].Status != "PARTIALLY_FILLED" || orders[0].ExecutedAmount != 0.5 || !orders[0].CreatedAt.Equal(start) || orders[0].Account != "main" {
+				t.Errorf("unexpected order %+v", orders[0])
+			}
+			if len(orders[0].Fills) != 1 || orders[0].Fills[0].Fee != 1 {
+				t.Errorf("unexpected fills %+v", orders[0].Fills)
+			}
+			if len(orders[0].Transitions) != 2 || orders[0].Transitions[0].Status != "NEW" {
+				t.Errorf("unexpected transitions %+v", orders[0].Transitions)
+			}
+			orders, err = db.GetOrdersUpdatedSince(start.Add(time.Hour * 2))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(orders) != 0 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(orders), 0)
+			}
+
+			err = db.InsertPositionSnapshots(nil)
+			if !errors.Is(err, errNilPositionSnapshot) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errNilPositionSnapshot)
+			}
+			for i := 0; i < 3; i++ {
+				err = db.InsertPositionSnapshots(&PositionSnapshot{
+					Exchange:         "one",
+					Asset:            "futures",
+					Base:             "BTC",
+					Quote:            "USD",
+					Status:           "OPEN",
+					OpeningDirection: "LONG",
+					OpeningPrice:     20000,
+					OpeningSize:      1,
+					OpeningDate:      start,
+					LatestDirection:  "LONG",
+					LatestPrice:      float64(20000 + i),
+					LatestSize:       1,
+					UnrealisedPNL:    float64(i),
+					Timestamp:        start.Add(time.Hour * time.Duration(i)),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err = db.GetPositionSnapshotsBetween(start, start)
+			if !errors.Is(err, errInvalidTimePeriod) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimePeriod)
+			}
+			snapshots, err := db.GetPositionSnapshotsBetween(start.Add(time.Hour), start.Add(time.Hour*2))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots) != 2 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(snapshots), 2)
+			}
+			if snapshots[0].UnrealisedPNL != 1 || !snapshots[0].OpeningDate.Equal(start) {
+				t.Errorf("unexpected snapshot %+v", snapshots[0])
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package managedorder
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errNilOrder            = errors.New("nil order received")
+	errNoOrderID           = errors.New("order ID not set")
+	errNilPositionSnapshot = errors.New("nil position snapshot received")
+	errInvalidTimePeriod   = errors.New("start date must be before end date")
+)
+
+// Order is a DTO for an order tracked by the order manager. ID is the
+// internal order ID assigned by the order manager.
+type Order struct {
+	ID       string
+	Exchange string
+	// Account is the name of the exchange account the order was placed with,
+	// empty for the default credentials
+	Account              string
+	OrderID              string
+	ClientOrderID        string
+	Asset                string
+	Base                 string
+	Quote                string
+	Side                 string
+	Type                 string
+	Status               string
+	Price                float64
+	Amount               float64
+	ExecutedAmount       float64
+	RemainingAmount      float64
+	AverageExecutedPrice float64
+	Cost                 float64
+	Fee                  float64
+	FeeAsset             string
+	Leverage             float64
+	CreatedAt            time.Time
+	ClosedAt             time.Time
+	UpdatedAt            time.Time
+	Fills                []Fill
+	// Transitions are recorded when an order is saved with a status or
+	// executed amount which differs from the saved order and are ignored on
+	// insert
+	Transitions []Transition
+}
+
+// Fill is a DTO for a trade which filled part of an order
+type Fill struct {
+	ID        string
+	TradeID   string
+	Side      string
+	Price     float64
+	Amount    float64
+	Fee       float64
+	FeeAsset  string
+	Timestamp time.Time
+}
+
+// Transition is a DTO for a change in the status or executed amount of an
+// order
+type Transition struct {
+	ID             string
+	Status         string
+	ExecutedAmount float64
+	Timestamp      time.Time
+}
+
+// PositionSnapshot is a DTO for the state and PnL of a futures position at a
+// point in time
+type PositionSnapshot struct {
+	ID               string
+	Exchange         string
+	Asset            string
+	Base             string
+	Quote            string
+	Status           string
+	OpeningDirection string
+	OpeningPrice     float64
+	OpeningSize      float64
+	OpeningDate      time.Time
+	LatestDirection  string
+	LatestPrice      float64
+	LatestSize       float64
+	RealisedPNL      float64
+	UnrealisedPNL    float64
+	CloseDate        time.Time
+	Timestamp        time.Time
+}
+
+// DBService is a service which allows the interaction with
+// the database without a direct reference to a global
+type DBService struct {
+	sql    database.ISQL
+	driver string
+}
+
+// IDBService allows using managed order database service
+// without needing to care about implementation
+type IDBService interface {
+	UpsertOrders(orders ...*Order) error
+	GetOrdersUpdatedSince(since time.Time) ([]Order, error)
+	InsertPositionSnapshots(snapshots ...*PositionSnapshot) error
+	GetPositionSnapshotsBetween(startDate, endDate time.Time) ([]PositionSnapshot, error)
+}
@@ -0,0 +1,438 @@
+package portfoliohistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Setup returns a DBService
+func Setup(db database.IDatabase) (*DBService, error) {
+	if db == nil {
+		return nil, nil
+	}
+	if !db.IsConnected() {
+		return nil, nil
+	}
+	cfg := db.GetConfig()
+	dbCon, err := db.GetSQL()
+	if err != nil {
+		return nil, err
+	}
+	return &DBService{
+		sql:    dbCon,
+		driver: cfg.Driver,
+	}, nil
+}
+
+// InsertSnapshot saves a snapshot and its holdings to the database
+func (db *DBService) InsertSnapshot(snapshot *Snapshot) error {
+	if snapshot == nil {
+		return errNilSnapshot
+	}
+	ctx := context.TODO()
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "InsertSnapshot tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		err = insertSnapshotSQLite(ctx, tx, snapshot)
+	case database.DBPostgreSQL:
+		err = insertSnapshotPostgres(ctx, tx, snapshot)
+	default:
+		return database.ErrNoDatabaseProvided
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetSnapshotsBetween returns all snapshots and their holdings between two
+// dates, ordered by time
+func (db *DBService) GetSnapshotsBetween(startDate, endDate time.Time) ([]Snapshot, error) {
+	if !startDate.Before(endDate) {
+		return nil, errInvalidTimePeriod
+	}
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		return db.getSnapshotsBetweenSQLite(startDate, endDate)
+	case database.DBPostgreSQL:
+		return db.getSnapshotsBetweenPostgres(startDate, endDate)
+	default:
+		return nil, database.ErrNoDatabaseProvided
+	}
+}
+
+// InsertFlows saves deposits and withdrawals to the database. Flows which
+// have already been saved for an exchange transfer ID are ignored
+func (db *DBService) InsertFlows(flows ...*Flow) error {
+	if len(flows) == 0 {
+		return nil
+	}
+	for i := range flows {
+		if flows[i].Type != Deposit && flows[i].Type != Withdrawal {
+			return fmt.Errorf("%w %s", errInvalidFlowType, flows[i].Type)
+		}
+		if flows[i].TransferID == "" {
+			return fmt.Errorf("%s %w", flows[i].Exchange, errNoTransferID)
+		}
+	}
+	ctx := context.TODO()
+
+	tx, err := db.sql.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "InsertFlows tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		err = insertFlowsSQLite(ctx, tx, flows...)
+	case database.DBPostgreSQL:
+		err = insertFlowsPostgres(ctx, tx, flows...)
+	default:
+		return database.ErrNoDatabaseProvided
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetFlowsBetween returns all deposits and withdrawals between two dates,
+// ordered by time
+func (db *DBService) GetFlowsBetween(startDate, endDate time.Time) ([]Flow, error) {
+	if !startDate.Before(endDate) {
+		return nil, errInvalidTimePeriod
+	}
+	switch db.driver {
+	case database.DBSQLite3, database.DBSQLite:
+		return db.getFlowsBetweenSQLite(startDate, endDate)
+	case database.DBPostgreSQL:
+		return db.getFlowsBetweenPostgres(startDate, endDate)
+	default:
+		return nil, database.ErrNoDatabaseProvided
+	}
+}
+
+func insertSnapshotSQLite(ctx context.Context, tx *sql.Tx, snapshot *Snapshot) error {
+	if snapshot.ID == "" {
+		freshUUID, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		snapshot.ID = freshUUID.String()
+	}
+	var tempSnapshot = sqlite3.PortfolioSnapshot{
+		ID:            snapshot.ID,
+		ValueCurrency: snapshot.ValueCurrency,
+		Value:         snapshot.Value,
+		Timestamp:     snapshot.Timestamp.UTC().Format(time.RFC3339),
+	}
+	err := tempSnapshot.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+	for i := range snapshot.Holdings {
+		if snapshot.Holdings[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			snapshot.Holdings[i].ID = freshUUID.String()
+		}
+		var tempHolding = sqlite3.PortfolioHolding{
+			ID:         snapshot.Holdings[i].ID,
+			SnapshotID: snapshot.ID,
+			Exchange:   snapshot.Holdings[i].Exchange,
+			Account:    snapshot.Holdings[i].Account,
+			Asset:      snapshot.Holdings[i].Asset,
+			Currency:   snapshot.Holdings[i].Currency,
+			Amount:     snapshot.Holdings[i].Amount,
+			Price:      snapshot.Holdings[i].Price,
+			Value:      snapshot.Holdings[i].Value,
+		}
+		err = tempHolding.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertSnapshotPostgres(ctx context.Context, tx *sql.Tx, snapshot *Snapshot) error {
+	if snapshot.ID == "" {
+		freshUUID, err := uuid.NewV4()
+		if err != nil {
+			return err
+		}
+		snapshot.ID = freshUUID.String()
+	}
+	var tempSnapshot = postgres.PortfolioSnapshot{
+		ID:            snapshot.ID,
+		ValueCurrency: snapshot.ValueCurrency,
+		Value:         snapshot.Value,
+		Timestamp:     snapshot.Timestamp.UTC(),
+	}
+	err := tempSnapshot.Insert(ctx, tx, boil.Infer())
+	if err != nil {
+		return err
+	}
+	for i := range snapshot.Holdings {
+		if snapshot.Holdings[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			snapshot.Holdings[i].ID = freshUUID.String()
+		}
+		var tempHolding = postgres.PortfolioHolding{
+			ID:         snapshot.Holdings[i].ID,
+			SnapshotID: snapshot.ID,
+			Exchange:   snapshot.Holdings[i].Exchange,
+			Account:    snapshot.Holdings[i].Account,
+			Asset:      snapshot.Holdings[i].Asset,
+			Currency:   snapshot.Holdings[i].Currency,
+			Amount:     snapshot.Holdings[i].Amount,
+			Price:      snapshot.Holdings[i].Price,
+			Value:      snapshot.Holdings[i].Value,
+		}
+		err = tempHolding.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertFlowsSQLite(ctx context.Context, tx *sql.Tx, flows ...*Flow) error {
+	for i := range flows {
+		if flows[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			flows[i].ID = freshUUID.String()
+		}
+		var tempFlow = sqlite3.PortfolioFlow{
+			ID:            flows[i].ID,
+			Exchange:      flows[i].Exchange,
+			TransferID:    flows[i].TransferID,
+			Type:          flows[i].Type,
+			Currency:      flows[i].Currency,
+			Amount:        flows[i].Amount,
+			Fee:           flows[i].Fee,
+			Price:         flows[i].Price,
+			Value:         flows[i].Value,
+			ValueCurrency: flows[i].ValueCurrency,
+			Timestamp:     flows[i].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err := tempFlow.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertFlowsPostgres(ctx context.Context, tx *sql.Tx, flows ...*Flow) error {
+	for i := range flows {
+		if flows[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			flows[i].ID = freshUUID.String()
+		}
+		var tempFlow = postgres.PortfolioFlow{
+			ID:            flows[i].ID,
+			Exchange:      flows[i].Exchange,
+			TransferID:    flows[i].TransferID,
+			Type:          flows[i].Type,
+			Currency:      flows[i].Currency,
+			Amount:        flows[i].Amount,
+			Fee:           flows[i].Fee,
+			Price:         flows[i].Price,
+			Value:         flows[i].Value,
+			ValueCurrency: flows[i].ValueCurrency,
+			Timestamp:     flows[i].Timestamp.UTC(),
+		}
+		err := tempFlow.Upsert(ctx, tx, false, []string{"exchange", "transfer_id"}, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DBService) getSnapshotsBetweenSQLite(startDate, endDate time.Time) ([]Snapshot, error) {
+	query := sqlite3.PortfolioSnapshots(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)),
+		qm.OrderBy("timestamp"),
+		qm.Load(sqlite3.PortfolioSnapshotRels.SnapshotPortfolioHoldings))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Snapshot, len(results))
+	for i := range results {
+		var ts time.Time
+		ts, err = time.Parse(time.RFC3339, results[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Snapshot{
+			ID:            results[i].ID,
+			Timestamp:     ts,
+			ValueCurrency: results[i].ValueCurrency,
+			Value:         results[i].Value,
+		}
+		if results[i].R == nil {
+			continue
+		}
+		holdings := results[i].R.SnapshotPortfolioHoldings
+		resp[i].Holdings = make([]Holding, len(holdings))
+		for j := range holdings {
+			resp[i].Holdings[j] = Holding{
+				ID:       holdings[j].ID,
+				Exchange: holdings[j].Exchange,
+				Account:  holdings[j].Account,
+				Asset:    holdings[j].Asset,
+				Currency: holdings[j].Currency,
+				Amount:   holdings[j].Amount,
+				Price:    holdings[j].Price,
+				Value:    holdings[j].Value,
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (db *DBService) getSnapshotsBetweenPostgres(startDate, endDate time.Time) ([]Snapshot, error) {
+	query := postgres.PortfolioSnapshots(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC(), endDate.UTC()),
+		qm.OrderBy("timestamp"),
+		qm.Load(postgres.PortfolioSnapshotRels.SnapshotPortfolioHoldings))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Snapshot, len(results))
+	for i := range results {
+		resp[i] = Snapshot{
+			ID:            results[i].ID,
+			Timestamp:     results[i].Timestamp,
+			ValueCurrency: results[i].ValueCurrency,
+			Value:         results[i].Value,
+		}
+		if results[i].R == nil {
+			continue
+		}
+		holdings := results[i].R.SnapshotPortfolioHoldings
+		resp[i].Holdings = make([]Holding, len(holdings))
+		for j := range holdings {
+			resp[i].Holdings[j] = Holding{
+				ID:       holdings[j].ID,
+				Exchange: holdings[j].Exchange,
+				Account:  holdings[j].Account,
+				Asset:    holdings[j].Asset,
+				Currency: holdings[j].Currency,
+				Amount:   holdings[j].Amount,
+				Price:    holdings[j].Price,
+				Value:    holdings[j].Value,
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (db *DBService) getFlowsBetweenSQLite(startDate, endDate time.Time) ([]Flow, error) {
+	query := sqlite3.PortfolioFlows(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)),
+		qm.OrderBy("timestamp"))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Flow, len(results))
+	for i := range results {
+		var ts time.Time
+		ts, err = time.Parse(time.RFC3339, results[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Flow{
+			ID:            results[i].ID,
+			Exchange:      results[i].Exchange,
+			TransferID:    results[i].TransferID,
+			Type:          results[i].Type,
+			Currency:      results[i].Currency,
+			Amount:        results[i].Amount,
+			Fee:           results[i].Fee,
+			Price:         results[i].Price,
+			Value:         results[i].Value,
+			ValueCurrency: results[i].ValueCurrency,
+			Timestamp:     ts,
+		}
+	}
+	return resp, nil
+}
+
+func (db *DBService) getFlowsBetweenPostgres(startDate, endDate time.Time) ([]Flow, error) {
+	query := postgres.PortfolioFlows(
+		qm.Where("timestamp BETWEEN ? AND ?", startDate.UTC(), endDate.UTC()),
+		qm.OrderBy("timestamp"))
+	results, err := query.All(context.TODO(), db.sql)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Flow, len(results))
+	for i := range results {
+		resp[i] = Flow{
+			ID:            results[i].ID,
+			Exchange:      results[i].Exchange,
+			TransferID:    results[i].TransferID,
+			Type:          results[i].Type,
+			Currency:      results[i].Currency,
+			Amount:        results[i].Amount,
+			Fee:           results[i].Fee,
+			Price:         results[i].Price,
+			Value:         results[i].Value,
+			ValueCurrency: results[i].ValueCurrency,
+			Timestamp:     results[i].Timestamp,
+		}
+	}
+	return resp, nil
+}
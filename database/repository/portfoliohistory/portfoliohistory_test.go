@@ -0,0 +1,156 @@
+package portfoliohistory
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t := m.Run()
+	err = os.RemoveAll(testhelpers.TempDir)
+	if err != nil {
+		fmt.Printf("Failed to remove temp db file: %v", err)
+	}
+
+	os.Exit(t)
+}
+
+func TestPortfolioHistory(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			db, err := Setup(dbConn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = db.InsertSnapshot(nil)
+			if !errors.Is(err, errNilSnapshot) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errNilSnapshot)
+			}
+
+			start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 5; i++ {
+				err = db.InsertSnapshot(&Snapshot{
+					Timestamp:     start.Add(time.Hour * time.Duration(i)),
+					ValueCurrency: "USD",
+					Value:         float64(20000 + i),
+					Holdings: []Holding{
+						{Exchange: "one", Account: "main", Asset: "spot", Currency: "BTC", Amount: 1, Price: float64(20000 + i), Value: float64(20000 + i)},
+						{Exchange: "wallet", Account: "1337", Asset: "spot", Currency: "USD", Amount: 0, Price: 1},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			snapshots, err := db.GetSnapshotsBetween(start.Add(time.Hour), start.Add(time.Hour*3))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots) != 3 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(snapshots), 3)
+			}
+			if !snapshots[0].Timestamp.Equal(start.Add(time.Hour)) || snapshots[0].Value != 20001 {
+				t.Errorf("unexpected first snapshot %+v", snapshots[0])
+			}
+			if len(snapshots[2].Holdings) != 2 {
+				t.Errorf("received: '%v' but expected: '%v'", len(snapshots[2].Holdings), 2)
+			}
+
+			_, err = db.GetSnapshotsBetween(start, start)
+			if !errors.Is(err, errInvalidTimePeriod) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimePeriod)
+			}
+
+			err = db.InsertFlows(&Flow{Exchange: "one", TransferID: "1", Type: "TRANSFER"})
+			if !errors.Is(err, errInvalidFlowType) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidFlowType)
+			}
+			err = db.InsertFlows(&Flow{Exchange: "one", Type: Deposit})
+			if !errors.Is(err, errNoTransferID) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, errNoTransferID)
+			}
+
+			flows := []*Flow{
+				{Exchange: "one", TransferID: "1", Type: Deposit, Currency: "BTC", Amount: 1, Price: 20000, Value: 20000, ValueCurrency: "USD", Timestamp: start},
+				{Exchange: "one", TransferID: "2", Type: Withdrawal, Currency: "BTC", Amount: 0.5, Fee: 0.0005, Price: 20002, Value: 10001, ValueCurrency: "USD", Timestamp: start.Add(time.Hour * 2)},
+			}
+			err = db.InsertFlows(flows...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// saving the same transfers again must not duplicate them
+			err = db.InsertFlows(&Flow{Exchange: "one", TransferID: "1", Type: Deposit, Currency: "BTC", Amount: 1, Timestamp: start})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := db.GetFlowsBetween(start, start.Add(time.Hour*4))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(results), 2)
+			}
+			if results[1].Type != Withdrawal || results[1].Fee != 0.0005 || !results[1].Timestamp.Equal(start.Add(time.Hour*2)) {
+				t.Errorf("unexpected flow %+v", results[1])
+			}
+
+			err = testhelpers.CloseDatabase(dbConn)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
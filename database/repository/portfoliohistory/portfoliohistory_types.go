@@ -0,0 +1,75 @@
+package portfoliohistory
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+// Flow types
+const (
+	Deposit    = "DEPOSIT"
+	Withdrawal = "WITHDRAWAL"
+)
+
+var (
+	errNilSnapshot       = errors.New("nil snapshot received")
+	errInvalidFlowType   = errors.New("invalid flow type")
+	errNoTransferID      = errors.New("flow transfer ID not set")
+	errInvalidTimePeriod = errors.New("start date must be before end date")
+)
+
+// Snapshot is a DTO for a point in time valuation of all holdings
+type Snapshot struct {
+	ID            string
+	Timestamp     time.Time
+	ValueCurrency string
+	Value         float64
+	Holdings      []Holding
+}
+
+// Holding is a DTO for a currency balance held at an exchange account or
+// wallet, valued in the snapshot value currency
+type Holding struct {
+	ID       string
+	Exchange string
+	Account  string
+	Asset    string
+	Currency string
+	Amount   float64
+	Price    float64
+	Value    float64
+}
+
+// Flow is a DTO for a deposit into or withdrawal from an exchange, valued
+// when it was recorded
+type Flow struct {
+	ID            string
+	Exchange      string
+	TransferID    string
+	Type          string
+	Currency      string
+	Amount        float64
+	Fee           float64
+	Price         float64
+	Value         float64
+	ValueCurrency string
+	Timestamp     time.Time
+}
+
+// DBService is a service which allows the interaction with
+// the database without a direct reference to a global
+type DBService struct {
+	sql    database.ISQL
+	driver string
+}
+
+// IDBService allows using portfolio history database service
+// without needing to care about implementation
+type IDBService interface {
+	InsertSnapshot(snapshot *Snapshot) error
+	GetSnapshotsBetween(startDate, endDate time.Time) ([]Snapshot, error)
+	InsertFlows(flows ...*Flow) error
+	GetFlowsBetween(startDate, endDate time.Time) ([]Flow, error)
+}
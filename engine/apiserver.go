@@ -137,6 +137,14 @@ func (m *apiServerManager) newRouter(isREST bool) *mux.Router {
 				common.ExtractPort(m.websocketListenAddress))
 			router.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
 		}
+
+		if m.metricsServer != nil {
+			router.
+				Methods(http.MethodGet).
+				Path(m.metricsServer.GetPath()).
+				Name("Metrics").
+				Handler(restLogger(m.metricsServer, "Metrics"))
+		}
 	} else {
 		routes = []Route{
 			{"ws", http.MethodGet, "/ws", m.WebsocketClientHandler},
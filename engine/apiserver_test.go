@@ -280,3 +280,31 @@ type fakeBot struct{}
 func (f *fakeBot) SetupExchanges() error {
 	return nil
 }
+
+func TestNewRouterMetrics(t *testing.T) {
+	t.Parallel()
+	wd, _ := os.Getwd()
+	m, err := setupAPIServerManager(&config.RemoteControlConfig{}, &config.Profiler{}, &ExchangeManager{}, &fakeBot{}, nil, wd)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if m.newRouter(true).Get("Metrics") != nil {
+		t.Error("expected metrics route to not be registered")
+	}
+
+	m.metricsServer, err = SetupMetricsManager(&config.Metrics{Path: "/metrics"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	route := m.newRouter(true).Get("Metrics")
+	if route == nil {
+		t.Fatal("expected metrics route to be registered")
+	}
+	path, err := route.GetPathTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/metrics" {
+		t.Errorf("received '%v', expected '%v'", path, "/metrics")
+	}
+}
@@ -57,6 +57,14 @@ type apiServerManager struct {
 	exchangeManager  iExchangeManager
 	bot              iBot
 	portfolioManager iPortfolioManager
+	// metricsServer is served on the REST server when set
+	metricsServer iMetricsServer
+}
+
+// iMetricsServer limits exposure of the metrics manager to serving metrics
+type iMetricsServer interface {
+	http.Handler
+	GetPath() string
 }
 
 // websocketClient stores information related to the websocket client
@@ -0,0 +1,534 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupArbitrageManager creates a new arbitrage scanner from config. The order
+// manager is only required when auto execution is enabled and the event
+// manager is optional.
+func SetupArbitrageManager(cfg *config.Arbitrage, exchangeManager iExchangeManager, orderManager iOrderSubmitter, eventManager iArbitrageEventProcessor, verbose bool) (*ArbitrageManager, error) {
+	if cfg == nil {
+		return nil, errNilArbitrageConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if !cfg.Triangular && !cfg.CrossExchange {
+		return nil, errNoArbitrageStrategy
+	}
+	if len(cfg.Capital) == 0 {
+		return nil, errNoArbitrageCapital
+	}
+	if cfg.AutoExecute && orderManager == nil {
+		return nil, errArbitrageNeedsOrders
+	}
+	capital := make(map[*currency.Item]float64, len(cfg.Capital))
+	for code, amount := range cfg.Capital {
+		if amount <= 0 {
+			return nil, fmt.Errorf("%s %w", code, errInvalidArbitrageCapital)
+		}
+		capital[currency.NewCode(code).Item] = amount
+	}
+	mux := dispatch.GetNewMux(nil)
+	id, err := mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+	m := &ArbitrageManager{
+		verbose:           verbose || cfg.Verbose,
+		scanInterval:      cfg.ScanInterval,
+		minProfit:         cfg.MinProfitPercentage,
+		triangular:        cfg.Triangular,
+		crossExchange:     cfg.CrossExchange,
+		exchanges:         cfg.Exchanges,
+		capital:           capital,
+		autoExecute:       cfg.AutoExecute,
+		executionCooldown: cfg.ExecutionCooldown,
+		exchangeManager:   exchangeManager,
+		orderManager:      orderManager,
+		eventManager:      eventManager,
+		mux:               mux,
+		id:                id,
+		tradeFees:         make(map[arbitrageFeeKey]float64),
+		withdrawalFees:    make(map[arbitrageFeeKey]float64),
+		lastExecuted:      make(map[string]time.Time),
+		shutdown:          make(chan struct{}),
+	}
+	if m.scanInterval <= 0 {
+		m.scanInterval = DefaultArbitrageScanInterval
+	}
+	if m.executionCooldown <= 0 {
+		m.executionCooldown = DefaultArbitrageExecutionCooldown
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *ArbitrageManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *ArbitrageManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", ArbitrageManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", ArbitrageManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Arbitrage manager %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Arbitrage manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *ArbitrageManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", ArbitrageManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", ArbitrageManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Arbitrage manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.Global, "Arbitrage manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetOpportunities returns the opportunities found by the most recent scan,
+// ordered by net profit percentage
+func (m *ArbitrageManager) GetOpportunities() ([]ArbitrageOpportunity, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", ArbitrageManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", ArbitrageManagerName, ErrSubSystemNotStarted)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	resp := make([]ArbitrageOpportunity, len(m.opportunities))
+	copy(resp, m.opportunities)
+	return resp, nil
+}
+
+// Subscribe returns a pipe which receives every opportunity found as an
+// ArbitrageOpportunity
+func (m *ArbitrageManager) Subscribe() (dispatch.Pipe, error) {
+	if m == nil {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", ArbitrageManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", ArbitrageManagerName, ErrSubSystemNotStarted)
+	}
+	return m.mux.Subscribe(m.id)
+}
+
+// run scans for opportunities at every scan interval
+func (m *ArbitrageManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.scanInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			m.scan()
+		}
+	}
+}
+
+// scan evaluates every enabled strategy, executes opportunities when auto
+// execution is enabled and publishes them
+func (m *ArbitrageManager) scan() {
+	markets, err := m.getMarkets()
+	if err != nil {
+		log.Errorf(log.Global, "Arbitrage manager cannot retrieve markets: %v", err)
+		return
+	}
+	var found []ArbitrageOpportunity
+	if m.triangular {
+		for x := range markets {
+			found = append(found, m.findTriangular(markets[x])...)
+		}
+	}
+	if m.crossExchange {
+		found = append(found, m.findCrossExchange(markets)...)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].NetProfitPercentage > found[j].NetProfitPercentage
+	})
+	for x := range found {
+		if m.autoExecute {
+			m.execute(&found[x])
+		}
+		m.publish(&found[x])
+	}
+	m.mu.Lock()
+	m.opportunities = found
+	m.mu.Unlock()
+}
+
+// getMarkets returns the valid spot orderbooks for each scanned exchange,
+// grouped by exchange
+func (m *ArbitrageManager) getMarkets() ([][]*arbitrageMarket, error) {
+	exchs, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(exchs, func(i, j int) bool { return exchs[i].GetName() < exchs[j].GetName() })
+	var markets [][]*arbitrageMarket
+	for x := range exchs {
+		name := exchs[x].GetName()
+		if !exchs[x].IsEnabled() ||
+			(len(m.exchanges) > 0 && !common.StringDataCompareInsensitive(m.exchanges, name)) ||
+			!exchs[x].GetAssetTypes(true).Contains(asset.Spot) {
+			continue
+		}
+		pairs, err := exchs[x].GetEnabledPairs(asset.Spot)
+		if err != nil {
+			return nil, err
+		}
+		var exchMarkets []*arbitrageMarket
+		for y := range pairs {
+			depth, err := orderbook.GetDepth(name, pairs[y], asset.Spot)
+			if err != nil || !depth.IsValid() {
+				continue
+			}
+			fee, err := m.getTradeFee(exchs[x], pairs[y])
+			if err != nil {
+				if m.verbose {
+					log.Warnf(log.Global, "Arbitrage manager excluding %s %s, fee unavailable: %v", name, pairs[y], err)
+				}
+				continue
+			}
+			exchMarkets = append(exchMarkets, &arbitrageMarket{
+				exchange: name,
+				pair:     pairs[y],
+				depth:    depth,
+				fee:      fee,
+			})
+		}
+		if len(exchMarkets) > 0 {
+			markets = append(markets, exchMarkets)
+		}
+	}
+	return markets, nil
+}
+
+// getTradeFee returns the cached taker fee rate for an exchange pair. The
+// offline fee for a single unit priced at one is the fee rate.
+func (m *ArbitrageManager) getTradeFee(exch exchange.IBotExchange, p currency.Pair) (float64, error) {
+	key := arbitrageFeeKey{Exchange: exch.GetName(), Base: p.Base.Item, Quote: p.Quote.Item}
+	if fee, ok := m.tradeFees[key]; ok {
+		return fee, nil
+	}
+	fee, err := exch.GetFeeByType(context.TODO(), &exchange.FeeBuilder{
+		FeeType:       exchange.OfflineTradeFee,
+		Pair:          p,
+		PurchasePrice: 1,
+		Amount:        1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.tradeFees[key] = fee
+	return fee, nil
+}
+
+// getWithdrawalFee returns the cached cryptocurrency withdrawal fee for a
+// currency on an exchange
+func (m *ArbitrageManager) getWithdrawalFee(exchName string, c currency.Code) (float64, error) {
+	key := arbitrageFeeKey{Exchange: exchName, Base: c.Item}
+	if fee, ok := m.withdrawalFees[key]; ok {
+		return fee, nil
+	}
+	exch, err := m.exchangeManager.GetExchangeByName(exchName)
+	if err != nil {
+		return 0, err
+	}
+	fee, err := exch.GetFeeByType(context.TODO(), &exchange.FeeBuilder{
+		FeeType: exchange.CryptocurrencyWithdrawalFee,
+		Pair:    currency.Pair{Base: c},
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.withdrawalFees[key] = fee
+	return fee, nil
+}
+
+// findTriangular evaluates every three leg cycle within an exchange which
+// starts and ends in a currency with configured capital
+func (m *ArbitrageManager) findTriangular(markets []*arbitrageMarket) []ArbitrageOpportunity {
+	conversions := make(map[*currency.Item][]arbitrageConversion)
+	for x := range markets {
+		quote, base := markets[x].pair.Quote.Item, markets[x].pair.Base.Item
+		conversions[quote] = append(conversions[quote], arbitrageConversion{market: markets[x], buy: true})
+		conversions[base] = append(conversions[base], arbitrageConversion{market: markets[x], buy: false})
+	}
+	var found []ArbitrageOpportunity
+	for start, capital := range m.capital {
+		for _, first := range conversions[start] {
+			for _, second := range conversions[first.to().Item] {
+				if second.market == first.market || second.to().Item == start {
+					continue
+				}
+				for _, third := range conversions[second.to().Item] {
+					if third.market == second.market || third.to().Item != start {
+						continue
+					}
+					o := m.size(TriangularArbitrage, []arbitrageConversion{first, second, third}, capital, 0)
+					if o != nil {
+						found = append(found, *o)
+					}
+				}
+			}
+		}
+	}
+	return found
+}
+
+// findCrossExchange evaluates buying on one exchange, transferring the
+// purchased currency and selling on another exchange
+func (m *ArbitrageManager) findCrossExchange(markets [][]*arbitrageMarket) []ArbitrageOpportunity {
+	byPair := make(map[arbitrageFeeKey][]*arbitrageMarket)
+	var keys []arbitrageFeeKey
+	for x := range markets {
+		for y := range markets[x] {
+			key := arbitrageFeeKey{Base: markets[x][y].pair.Base.Item, Quote: markets[x][y].pair.Quote.Item}
+			if _, ok := byPair[key]; !ok {
+				keys = append(keys, key)
+			}
+			byPair[key] = append(byPair[key], markets[x][y])
+		}
+	}
+	var found []ArbitrageOpportunity
+	for _, key := range keys {
+		capital, ok := m.capital[key.Quote]
+		if !ok {
+			continue
+		}
+		pairMarkets := byPair[key]
+		for _, buy := range pairMarkets {
+			for _, sell := range pairMarkets {
+				if buy == sell {
+					continue
+				}
+				ask, err := buy.depth.GetBestAsk()
+				if err != nil {
+					continue
+				}
+				bid, err := sell.depth.GetBestBid()
+				if err != nil {
+					continue
+				}
+				if bid*(1-sell.fee) <= ask*(1+buy.fee) {
+					continue
+				}
+				withdrawalFee, err := m.getWithdrawalFee(buy.exchange, buy.pair.Base)
+				if err != nil {
+					if m.verbose {
+						log.Warnf(log.Global, "Arbitrage manager cannot retrieve %s %s withdrawal fee: %v", buy.exchange, buy.pair.Base, err)
+					}
+					continue
+				}
+				o := m.size(CrossExchangeArbitrage, []arbitrageConversion{
+					{market: buy, buy: true},
+					{market: sell, buy: false},
+				}, capital, withdrawalFee)
+				if o != nil {
+					found = append(found, *o)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// size evaluates a path at increasing fractions of the available capital
+// through orderbook depth and returns the most profitable size, or nil when
+// the path does not meet the minimum profit. The withdrawal fee is deducted
+// from the amount received by the first leg.
+func (m *ArbitrageManager) size(typ ArbitrageType, path []arbitrageConversion, capital, withdrawalFee float64) *ArbitrageOpportunity {
+	var best *ArbitrageOpportunity
+	for step := 1; step <= arbitrageSizingSteps; step++ {
+		amount := capital * float64(step) / arbitrageSizingSteps
+		end, legs, err := evaluateArbitragePath(path, amount, withdrawalFee)
+		if err != nil {
+			break
+		}
+		profit := end - amount
+		if best != nil && profit <= best.NetProfit {
+			continue
+		}
+		if withdrawalFee == 0 && step == 1 && profit/amount*100 < m.minProfit {
+			// Without a fixed cost deploying more capital only increases
+			// slippage
+			return nil
+		}
+		best = &ArbitrageOpportunity{
+			Type:                typ,
+			Legs:                legs,
+			Currency:            path[0].from(),
+			StartAmount:         amount,
+			EndAmount:           end,
+			WithdrawalFee:       withdrawalFee,
+			NetProfit:           profit,
+			NetProfitPercentage: profit / amount * 100,
+			Detected:            time.Now(),
+		}
+	}
+	if best == nil || best.NetProfit <= 0 || best.NetProfitPercentage < m.minProfit {
+		return nil
+	}
+	return best
+}
+
+// evaluateArbitragePath converts an amount through each leg of a path using
+// orderbook movement, net of each market fee. The withdrawal fee is deducted
+// after the first leg when set.
+func evaluateArbitragePath(path []arbitrageConversion, amount, withdrawalFee float64) (float64, []ArbitrageLeg, error) {
+	legs := make([]ArbitrageLeg, len(path))
+	for x := range path {
+		mkt := path[x].market
+		leg := ArbitrageLeg{
+			Exchange: mkt.exchange,
+			Pair:     mkt.pair,
+			Asset:    asset.Spot,
+			FeeRate:  mkt.fee,
+		}
+		var move *orderbook.Movement
+		var err error
+		if path[x].buy {
+			move, err = mkt.depth.LiftTheAsksFromBest(amount, false)
+			if err != nil {
+				return 0, nil, err
+			}
+			leg.Side, leg.Amount, leg.QuoteAmount = order.Buy, move.Purchased, move.Sold
+		} else {
+			move, err = mkt.depth.HitTheBidsFromBest(amount, false)
+			if err != nil {
+				return 0, nil, err
+			}
+			leg.Side, leg.Amount, leg.QuoteAmount = order.Sell, move.Sold, move.Purchased
+		}
+		if move.FullBookSideConsumed {
+			return 0, nil, fmt.Errorf("%s %s %w", mkt.exchange, mkt.pair, errInsufficientLiquidity)
+		}
+		leg.AveragePrice = move.AverageOrderCost
+		legs[x] = leg
+		amount = move.Purchased * (1 - mkt.fee)
+		if x == 0 && withdrawalFee > 0 {
+			if withdrawalFee >= amount {
+				return 0, nil, errWithdrawalExceedsAmount
+			}
+			amount -= withdrawalFee
+		}
+	}
+	return amount, legs, nil
+}
+
+// execute submits each leg of an opportunity via the order manager, unless the
+// same opportunity was executed within the cooldown period
+func (m *ArbitrageManager) execute(o *ArbitrageOpportunity) {
+	key := o.key()
+	if last, ok := m.lastExecuted[key]; ok && time.Since(last) < m.executionCooldown {
+		return
+	}
+	m.lastExecuted[key] = time.Now()
+	for x := range o.Legs {
+		submit := &order.Submit{
+			Exchange:  o.Legs[x].Exchange,
+			Pair:      o.Legs[x].Pair,
+			AssetType: o.Legs[x].Asset,
+			Side:      o.Legs[x].Side,
+			Type:      order.Market,
+			Amount:    o.Legs[x].Amount,
+		}
+		if o.Legs[x].Side == order.Buy {
+			submit.QuoteAmount = o.Legs[x].QuoteAmount
+		}
+		if _, err := m.orderManager.Submit(context.TODO(), submit); err != nil {
+			o.ExecutionError = fmt.Sprintf("leg %d: %v", x+1, err)
+			log.Errorf(log.Global, "Arbitrage manager failed to execute %s: %v", key, err)
+			return
+		}
+	}
+	o.Executed = true
+	log.Infof(log.Global, "Arbitrage manager executed %s net profit %f %s", key, o.NetProfit, o.Currency)
+}
+
+// publish sends an opportunity to dispatch subscribers and the event manager
+func (m *ArbitrageManager) publish(o *ArbitrageOpportunity) {
+	if m.verbose {
+		log.Debugf(log.Global, "Arbitrage manager found %s net profit %f %s (%.4f%%)", o.key(), o.NetProfit, o.Currency, o.NetProfitPercentage)
+	}
+	if err := m.mux.Publish(*o, m.id); err != nil {
+		log.Errorf(log.Global, "Arbitrage manager cannot publish opportunity: %v", err)
+	}
+	if m.eventManager != nil {
+		m.eventManager.ProcessArbitrageOpportunity(o)
+	}
+}
+
+// from returns the currency spent by the conversion
+func (c arbitrageConversion) from() currency.Code {
+	if c.buy {
+		return c.market.pair.Quote
+	}
+	return c.market.pair.Base
+}
+
+// to returns the currency received by the conversion
+func (c arbitrageConversion) to() currency.Code {
+	if c.buy {
+		return c.market.pair.Base
+	}
+	return c.market.pair.Quote
+}
+
+// key returns a description of the opportunity legs which identifies
+// repeated opportunities
+func (o *ArbitrageOpportunity) key() string {
+	legs := make([]string, len(o.Legs))
+	for x := range o.Legs {
+		legs[x] = o.Legs[x].Exchange + " " + o.Legs[x].Side.String() + " " + o.Legs[x].Pair.String()
+	}
+	return string(o.Type) + " [" + strings.Join(legs, ", ") + "]"
+}
+
+// Involves returns whether a leg of the opportunity trades on the exchange
+// and, when set, the currency pair
+func (o *ArbitrageOpportunity) Involves(exchangeName string, p currency.Pair) bool {
+	for x := range o.Legs {
+		if strings.EqualFold(o.Legs[x].Exchange, exchangeName) &&
+			(p.IsEmpty() || o.Legs[x].Pair.Equal(p)) {
+			return true
+		}
+	}
+	return false
+}
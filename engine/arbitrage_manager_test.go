@@ -0,0 +1,388 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+type fakeArbitrageSubmitter struct {
+	mu        sync.Mutex
+	submitted []*order.Submit
+	err       error
+}
+
+func (f *fakeArbitrageSubmitter) Submit(_ context.Context, s *order.Submit) (*OrderSubmitResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.submitted = append(f.submitted, s)
+	return &OrderSubmitResponse{}, nil
+}
+
+type fakeArbitrageEventProcessor struct {
+	processed []*ArbitrageOpportunity
+}
+
+func (f *fakeArbitrageEventProcessor) ProcessArbitrageOpportunity(o *ArbitrageOpportunity) {
+	f.processed = append(f.processed, o)
+}
+
+func testArbitrageConfig() *config.Arbitrage {
+	return &config.Arbitrage{
+		Triangular:    true,
+		CrossExchange: true,
+		Capital:       map[string]float64{"USDT": 1000},
+	}
+}
+
+// testArbitrageMarket returns a market with a single bid and ask level
+func testArbitrageMarket(t *testing.T, exch string, p currency.Pair, fee, bid, ask, amount float64) *arbitrageMarket {
+	t.Helper()
+	depth := orderbook.NewDepth(uuid.Nil)
+	depth.AssignOptions(&orderbook.Base{Exchange: exch, Pair: p, Asset: asset.Spot})
+	depth.LoadSnapshot(
+		[]orderbook.Item{{Price: bid, Amount: amount}, {Price: bid * 0.5, Amount: amount}},
+		[]orderbook.Item{{Price: ask, Amount: amount}, {Price: ask * 2, Amount: amount}},
+		0, time.Now(), false)
+	return &arbitrageMarket{exchange: exch, pair: p, depth: depth, fee: fee}
+}
+
+func TestSetupArbitrageManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupArbitrageManager(nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilArbitrageConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilArbitrageConfig)
+	}
+	_, err = SetupArbitrageManager(&config.Arbitrage{}, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	em := SetupExchangeManager()
+	_, err = SetupArbitrageManager(&config.Arbitrage{}, em, nil, nil, false)
+	if !errors.Is(err, errNoArbitrageStrategy) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoArbitrageStrategy)
+	}
+	_, err = SetupArbitrageManager(&config.Arbitrage{Triangular: true}, em, nil, nil, false)
+	if !errors.Is(err, errNoArbitrageCapital) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoArbitrageCapital)
+	}
+	cfg := testArbitrageConfig()
+	cfg.Capital["BTC"] = 0
+	_, err = SetupArbitrageManager(cfg, em, nil, nil, false)
+	if !errors.Is(err, errInvalidArbitrageCapital) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidArbitrageCapital)
+	}
+	cfg = testArbitrageConfig()
+	cfg.AutoExecute = true
+	_, err = SetupArbitrageManager(cfg, em, nil, nil, false)
+	if !errors.Is(err, errArbitrageNeedsOrders) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errArbitrageNeedsOrders)
+	}
+	m, err := SetupArbitrageManager(testArbitrageConfig(), em, nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.scanInterval != DefaultArbitrageScanInterval {
+		t.Fatal("expected default scan interval to be set")
+	}
+	if m.executionCooldown != DefaultArbitrageExecutionCooldown {
+		t.Fatal("expected default execution cooldown to be set")
+	}
+}
+
+func TestArbitrageManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *ArbitrageManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+
+	m, err = SetupArbitrageManager(testArbitrageConfig(), SetupExchangeManager(), nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !m.IsRunning() {
+		t.Fatal("expected manager to be running")
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestArbitrageManagerGetOpportunities(t *testing.T) {
+	t.Parallel()
+	var m *ArbitrageManager
+	_, err := m.GetOpportunities()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = m.Subscribe()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	m, err = SetupArbitrageManager(testArbitrageConfig(), SetupExchangeManager(), nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.GetOpportunities()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	_, err = m.Subscribe()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.mu.Lock()
+	m.opportunities = []ArbitrageOpportunity{{Type: TriangularArbitrage}}
+	m.mu.Unlock()
+	opportunities, err := m.GetOpportunities()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(opportunities) != 1 || opportunities[0].Type != TriangularArbitrage {
+		t.Fatalf("unexpected opportunities %+v", opportunities)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestArbitrageManagerFindTriangular(t *testing.T) {
+	t.Parallel()
+	m, err := SetupArbitrageManager(testArbitrageConfig(), SetupExchangeManager(), nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	markets := []*arbitrageMarket{
+		testArbitrageMarket(t, testExchange, currency.NewPair(currency.BTC, currency.USDT), 0.001, 19990, 20000, 10),
+		testArbitrageMarket(t, testExchange, currency.NewPair(currency.ETH, currency.BTC), 0.001, 0.0499, 0.05, 1000),
+		testArbitrageMarket(t, testExchange, currency.NewPair(currency.ETH, currency.USDT), 0.001, 1100, 1101, 1000),
+	}
+	found := m.findTriangular(markets)
+	if len(found) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(found), 1)
+	}
+	o := found[0]
+	if o.Type != TriangularArbitrage || !o.Currency.Equal(currency.USDT) || len(o.Legs) != 3 {
+		t.Fatalf("unexpected opportunity %+v", o)
+	}
+	if o.StartAmount != 1000 {
+		t.Fatalf("received: '%v' but expected: '%v'", o.StartAmount, 1000)
+	}
+	// 1000 USDT buys 0.05 BTC, buys 1 ETH and sells for 1100 USDT less three
+	// fees
+	expected := 1100 * math.Pow(0.999, 3)
+	if math.Abs(o.EndAmount-expected) > 1e-8 {
+		t.Fatalf("received: '%v' but expected: '%v'", o.EndAmount, expected)
+	}
+	if o.Legs[0].Side != order.Buy || o.Legs[1].Side != order.Buy || o.Legs[2].Side != order.Sell {
+		t.Fatalf("unexpected leg sides %v %v %v", o.Legs[0].Side, o.Legs[1].Side, o.Legs[2].Side)
+	}
+
+	m.minProfit = 20
+	if found = m.findTriangular(markets); len(found) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(found), 0)
+	}
+}
+
+func TestArbitrageManagerFindCrossExchange(t *testing.T) {
+	t.Parallel()
+	m, err := SetupArbitrageManager(testArbitrageConfig(), SetupExchangeManager(), nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	btcusdt := currency.NewPair(currency.BTC, currency.USDT)
+	markets := [][]*arbitrageMarket{
+		{testArbitrageMarket(t, "cheap", btcusdt, 0, 19990, 20000, 10)},
+		{testArbitrageMarket(t, "dear", btcusdt, 0, 20500, 20510, 10)},
+	}
+	m.withdrawalFees[arbitrageFeeKey{Exchange: "cheap", Base: currency.BTC.Item}] = 0.0001
+	found := m.findCrossExchange(markets)
+	if len(found) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(found), 1)
+	}
+	o := found[0]
+	if o.Type != CrossExchangeArbitrage || o.Legs[0].Exchange != "cheap" || o.Legs[1].Exchange != "dear" {
+		t.Fatalf("unexpected opportunity %+v", o)
+	}
+	// 1000 USDT buys 0.05 BTC of which 0.0001 is spent on withdrawal
+	expected := 0.0499 * 20500
+	if math.Abs(o.EndAmount-expected) > 1e-8 {
+		t.Fatalf("received: '%v' but expected: '%v'", o.EndAmount, expected)
+	}
+
+	m.withdrawalFees[arbitrageFeeKey{Exchange: "cheap", Base: currency.BTC.Item}] = 1
+	if found = m.findCrossExchange(markets); len(found) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(found), 0)
+	}
+}
+
+func TestEvaluateArbitragePath(t *testing.T) {
+	t.Parallel()
+	mkt := testArbitrageMarket(t, testExchange, currency.NewPair(currency.BTC, currency.USDT), 0, 19990, 20000, 0.01)
+	_, _, err := evaluateArbitragePath([]arbitrageConversion{{market: mkt, buy: true}}, 1000000, 0)
+	if !errors.Is(err, errInsufficientLiquidity) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInsufficientLiquidity)
+	}
+	_, _, err = evaluateArbitragePath([]arbitrageConversion{{market: mkt, buy: true}}, 100, 1)
+	if !errors.Is(err, errWithdrawalExceedsAmount) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errWithdrawalExceedsAmount)
+	}
+	end, legs, err := evaluateArbitragePath([]arbitrageConversion{{market: mkt, buy: false}}, 0.005, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if end != 99.95 || len(legs) != 1 || legs[0].Side != order.Sell || legs[0].Amount != 0.005 {
+		t.Fatalf("unexpected result %v %+v", end, legs)
+	}
+}
+
+func TestArbitrageManagerExecute(t *testing.T) {
+	t.Parallel()
+	cfg := testArbitrageConfig()
+	cfg.AutoExecute = true
+	submitter := &fakeArbitrageSubmitter{}
+	events := &fakeArbitrageEventProcessor{}
+	m, err := SetupArbitrageManager(cfg, SetupExchangeManager(), submitter, events, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	o := &ArbitrageOpportunity{
+		Type: CrossExchangeArbitrage,
+		Legs: []ArbitrageLeg{
+			{Exchange: "cheap", Pair: currency.NewPair(currency.BTC, currency.USDT), Asset: asset.Spot, Side: order.Buy, Amount: 0.05, QuoteAmount: 1000},
+			{Exchange: "dear", Pair: currency.NewPair(currency.BTC, currency.USDT), Asset: asset.Spot, Side: order.Sell, Amount: 0.0499, QuoteAmount: 1022.95},
+		},
+	}
+	m.execute(o)
+	if !o.Executed || len(submitter.submitted) != 2 {
+		t.Fatalf("expected both legs to be executed %+v", o)
+	}
+	if submitter.submitted[0].QuoteAmount != 1000 || submitter.submitted[0].Type != order.Market {
+		t.Fatalf("unexpected buy submission %+v", submitter.submitted[0])
+	}
+	if submitter.submitted[1].QuoteAmount != 0 || submitter.submitted[1].Amount != 0.0499 {
+		t.Fatalf("unexpected sell submission %+v", submitter.submitted[1])
+	}
+
+	// Within cooldown the same opportunity is not executed again
+	repeat := *o
+	repeat.Executed = false
+	m.execute(&repeat)
+	if repeat.Executed || len(submitter.submitted) != 2 {
+		t.Fatal("expected opportunity to not be executed within cooldown")
+	}
+
+	submitter.err = errors.New("rejected")
+	m.lastExecuted = make(map[string]time.Time)
+	m.execute(&repeat)
+	if repeat.Executed || repeat.ExecutionError == "" {
+		t.Fatal("expected execution error to be recorded")
+	}
+
+	m.publish(o)
+	if len(events.processed) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(events.processed), 1)
+	}
+}
+
+func TestArbitrageOpportunityInvolves(t *testing.T) {
+	t.Parallel()
+	o := &ArbitrageOpportunity{Legs: []ArbitrageLeg{{Exchange: testExchange, Pair: currency.NewPair(currency.BTC, currency.USDT)}}}
+	if !o.Involves("bitstamp", currency.EMPTYPAIR) {
+		t.Fatal("expected opportunity to involve exchange")
+	}
+	if !o.Involves(testExchange, currency.NewPair(currency.BTC, currency.USDT)) {
+		t.Fatal("expected opportunity to involve pair")
+	}
+	if o.Involves(testExchange, currency.NewPair(currency.ETH, currency.USDT)) {
+		t.Fatal("expected opportunity to not involve pair")
+	}
+}
+
+func TestProcessArbitrageOpportunity(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	m, err := setupEventManager(&CommunicationManager{}, em, 0, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cond := EventConditionParams{Condition: ConditionGreaterThanOrEqual, Price: 1}
+	_, err = m.Add(testExchange, ItemArbitrage, cond, currency.NewPair(currency.BTC, currency.USDT), asset.Spot, ActionTest)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.Add(testExchange, ItemArbitrage, EventConditionParams{Condition: ConditionGreaterThan}, currency.NewPair(currency.BTC, currency.USDT), asset.Spot, ActionTest)
+	if !errors.Is(err, errInvalidCondition) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidCondition)
+	}
+
+	o := &ArbitrageOpportunity{
+		Legs:                []ArbitrageLeg{{Exchange: testExchange, Pair: currency.NewPair(currency.BTC, currency.USDT)}},
+		NetProfitPercentage: 0.5,
+	}
+	m.ProcessArbitrageOpportunity(o)
+	if m.events[0].Executed {
+		t.Fatal("expected event to not be triggered below threshold")
+	}
+	o.NetProfitPercentage = 1.5
+	m.ProcessArbitrageOpportunity(o)
+	if !m.events[0].Executed {
+		t.Fatal("expected event to be triggered")
+	}
+	m.m.Lock()
+	err = m.checkEventCondition(&m.events[0])
+	if !errors.Is(err, errArbitrageEventPushed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errArbitrageEventPushed)
+	}
+	m.m.Unlock()
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// ArbitrageManagerName is an exported subsystem name
+const ArbitrageManagerName = "arbitrage_manager"
+
+const (
+	// DefaultArbitrageScanInterval is the default duration between scans
+	DefaultArbitrageScanInterval = time.Second
+	// DefaultArbitrageExecutionCooldown is the default duration before the
+	// same opportunity can be executed again
+	DefaultArbitrageExecutionCooldown = time.Minute
+	// arbitrageSizingSteps is the number of capital fractions evaluated when
+	// sizing an opportunity against orderbook depth
+	arbitrageSizingSteps = 10
+)
+
+// Arbitrage opportunity types
+const (
+	TriangularArbitrage    ArbitrageType = "TRIANGULAR"
+	CrossExchangeArbitrage ArbitrageType = "CROSS_EXCHANGE"
+)
+
+var (
+	errNilArbitrageConfig      = errors.New("nil arbitrage config received")
+	errNoArbitrageCapital      = errors.New("no arbitrage capital set")
+	errInvalidArbitrageCapital = errors.New("arbitrage capital must be greater than zero")
+	errNoArbitrageStrategy     = errors.New("neither triangular nor cross exchange arbitrage is enabled")
+	errArbitrageNeedsOrders    = errors.New("auto execution requires the order manager")
+	errInsufficientLiquidity   = errors.New("insufficient orderbook liquidity")
+	errWithdrawalExceedsAmount = errors.New("withdrawal fee exceeds transferred amount")
+	errArbitrageEventPushed    = errors.New("arbitrage events are evaluated when opportunities are published")
+)
+
+// ArbitrageType defines the kind of arbitrage opportunity
+type ArbitrageType string
+
+// iArbitrageEventProcessor limits the event manager functionality used to
+// publish opportunities
+type iArbitrageEventProcessor interface {
+	ProcessArbitrageOpportunity(*ArbitrageOpportunity)
+}
+
+// ArbitrageManager continuously evaluates triangular cycles within an exchange
+// and spatial arbitrage across exchanges using orderbook depth
+type ArbitrageManager struct {
+	started           int32
+	verbose           bool
+	scanInterval      time.Duration
+	minProfit         float64
+	triangular        bool
+	crossExchange     bool
+	exchanges         []string
+	capital           map[*currency.Item]float64
+	autoExecute       bool
+	executionCooldown time.Duration
+	exchangeManager   iExchangeManager
+	orderManager      iOrderSubmitter
+	eventManager      iArbitrageEventProcessor
+	mux               *dispatch.Mux
+	id                uuid.UUID
+	tradeFees         map[arbitrageFeeKey]float64
+	withdrawalFees    map[arbitrageFeeKey]float64
+	opportunities     []ArbitrageOpportunity
+	lastExecuted      map[string]time.Time
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	mu                sync.Mutex
+}
+
+// ArbitrageLeg defines a single order required by an opportunity
+type ArbitrageLeg struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	Side     order.Side
+	// Amount is the base currency amount filled through the orderbook
+	Amount float64
+	// QuoteAmount is the quote currency amount filled through the orderbook
+	QuoteAmount  float64
+	AveragePrice float64
+	FeeRate      float64
+}
+
+// ArbitrageOpportunity defines a profitable sequence of orders which starts
+// and ends in the same currency
+type ArbitrageOpportunity struct {
+	Type     ArbitrageType
+	Legs     []ArbitrageLeg
+	Currency currency.Code
+	// StartAmount is the amount of currency deployed and EndAmount the amount
+	// returned after fees and withdrawal costs
+	StartAmount float64
+	EndAmount   float64
+	// WithdrawalFee is the cost of moving the bought currency to the selling
+	// exchange for cross exchange opportunities, denominated in that currency
+	WithdrawalFee       float64
+	NetProfit           float64
+	NetProfitPercentage float64
+	Detected            time.Time
+	Executed            bool
+	ExecutionError      string
+}
+
+// arbitrageFeeKey is used to cache fee rates
+type arbitrageFeeKey struct {
+	Exchange string
+	Base     *currency.Item
+	Quote    *currency.Item
+}
+
+// arbitrageMarket defines an orderbook which can be traded in a path
+type arbitrageMarket struct {
+	exchange string
+	pair     currency.Pair
+	depth    *orderbook.Depth
+	fee      float64
+}
+
+// arbitrageConversion defines a currency conversion through a market, buy
+// spends the quote currency for the base currency
+type arbitrageConversion struct {
+	market *arbitrageMarket
+	buy    bool
+}
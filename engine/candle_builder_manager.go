@@ -0,0 +1,462 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupCandleBuilderManager creates a new candle builder from config. A nil
+// database connection manager disables persistence of closed candles.
+func SetupCandleBuilderManager(cfg *config.CandleBuilder, dbManager iDatabaseConnectionManager, verbose bool) (*CandleBuilderManager, error) {
+	if cfg == nil {
+		return nil, errNilCandleBuilderConfig
+	}
+	m := &CandleBuilderManager{
+		verbose:         verbose || cfg.Verbose,
+		persist:         cfg.PersistCandles,
+		maxCandles:      cfg.MaxCandles,
+		persistInterval: cfg.PersistInterval,
+		dbManager:       dbManager,
+		candleSaver:     kline.StoreInDatabase,
+		mux:             dispatch.GetNewMux(nil),
+		series:          make(map[candleSeriesKey]*candleSeries),
+		pending:         make(map[candleSeriesKey][]kline.Candle),
+		shutdown:        make(chan struct{}),
+	}
+	if len(cfg.Intervals) == 0 {
+		m.intervals = append(m.intervals, DefaultCandleBuilderIntervals...)
+	}
+	for i := range cfg.Intervals {
+		if cfg.Intervals[i] <= 0 {
+			return nil, fmt.Errorf("%w %v", kline.ErrUnsupportedInterval, cfg.Intervals[i])
+		}
+		m.intervals = append(m.intervals, kline.Interval(cfg.Intervals[i]))
+	}
+	if m.maxCandles <= 0 {
+		m.maxCandles = DefaultCandleBuilderMaxCandles
+	}
+	if m.persistInterval <= 0 {
+		m.persistInterval = DefaultCandleBuilderPersistInterval
+	}
+	if m.persist && m.dbManager == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *CandleBuilderManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *CandleBuilderManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem, persisting any outstanding closed candles
+func (m *CandleBuilderManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run closes elapsed candles which have not been superseded by a trade and
+// periodically persists closed candles
+func (m *CandleBuilderManager) run() {
+	defer m.wg.Done()
+	checkTicker := time.NewTicker(candleBuilderCheckInterval)
+	persistTicker := time.NewTicker(m.persistInterval)
+	defer func() {
+		checkTicker.Stop()
+		persistTicker.Stop()
+		m.persistCandles()
+	}()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-checkTicker.C:
+			m.closeElapsedCandles(time.Now())
+		case <-persistTicker.C:
+			m.persistCandles()
+		}
+	}
+}
+
+// websocketDataHandler is registered with the websocket routine manager and
+// filters out trade data for processing
+func (m *CandleBuilderManager) websocketDataHandler(exchName string, data interface{}) error {
+	trades, ok := data.([]trade.Data)
+	if !ok || !m.IsRunning() {
+		return nil
+	}
+	return m.ProcessTrades(exchName, trades)
+}
+
+// ProcessTrades amends or closes candles for each configured interval using
+// the supplied trades. Trades which are older than the current in-progress
+// candle are discarded.
+func (m *CandleBuilderManager) ProcessTrades(exchName string, trades []trade.Data) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemNotStarted)
+	}
+	var closed []candlePublication
+	amended := make(map[*candleSeries]CandleUpdate)
+	m.mu.Lock()
+	for x := range trades {
+		if trades[x].Price <= 0 || trades[x].Amount < 0 || trades[x].CurrencyPair.IsEmpty() {
+			continue
+		}
+		exch := trades[x].Exchange
+		if exch == "" {
+			exch = exchName
+		}
+		ts := trades[x].Timestamp
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		for y := range m.intervals {
+			s, err := m.getSeries(exch, trades[x].CurrencyPair, trades[x].AssetType, m.intervals[y])
+			if err != nil {
+				m.mu.Unlock()
+				return err
+			}
+			start := ts.Truncate(m.intervals[y].Duration())
+			if s.active && start.Before(s.current.Time) {
+				if m.verbose {
+					log.Debugf(log.Trade, "Candle builder %s %s %s %s discarding late trade at %s",
+						exch, trades[x].CurrencyPair, trades[x].AssetType, m.intervals[y], ts)
+				}
+				continue
+			}
+			if s.active && start.After(s.current.Time) {
+				// The closed update supersedes any in-progress update
+				delete(amended, s)
+				closed = append(closed, candlePublication{
+					series: s,
+					update: m.closeCandle(s),
+				})
+			}
+			if !s.active {
+				s.current = kline.Candle{
+					Time: start,
+					Open: trades[x].Price,
+					High: trades[x].Price,
+					Low:  trades[x].Price,
+				}
+				s.active = true
+			}
+			if trades[x].Price > s.current.High {
+				s.current.High = trades[x].Price
+			}
+			if trades[x].Price < s.current.Low {
+				s.current.Low = trades[x].Price
+			}
+			s.current.Close = trades[x].Price
+			s.current.Volume += trades[x].Amount
+			amended[s] = CandleUpdate{
+				Exchange: s.exchange,
+				Pair:     s.pair,
+				Asset:    trades[x].AssetType,
+				Interval: m.intervals[y],
+				Candle:   s.current,
+			}
+		}
+	}
+	m.mu.Unlock()
+	m.publish(closed)
+	for s, upd := range amended {
+		m.publishUpdate(s, upd)
+	}
+	return nil
+}
+
+// getSeries returns the series associated with the parameters, creating it
+// if it does not exist. Must be called with the lock held.
+func (m *CandleBuilderManager) getSeries(exch string, p currency.Pair, a asset.Item, i kline.Interval) (*candleSeries, error) {
+	key := candleSeriesKey{
+		Exchange: strings.ToLower(exch),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+		Interval: i,
+	}
+	s, ok := m.series[key]
+	if ok {
+		return s, nil
+	}
+	id, err := m.mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+	s = &candleSeries{id: id, key: key, exchange: exch, pair: p}
+	m.series[key] = s
+	return s, nil
+}
+
+// closeCandle moves the in-progress candle to the closed list, queueing it
+// for persistence. Must be called with the lock held.
+func (m *CandleBuilderManager) closeCandle(s *candleSeries) CandleUpdate {
+	closed := s.current
+	s.closed = append(s.closed, closed)
+	if len(s.closed) > m.maxCandles {
+		s.closed = s.closed[len(s.closed)-m.maxCandles:]
+	}
+	s.active = false
+	if m.persist {
+		// Pending candles are capped so an unavailable database cannot grow
+		// memory indefinitely
+		pending := append(m.pending[s.key], closed)
+		if len(pending) > m.maxCandles {
+			pending = pending[len(pending)-m.maxCandles:]
+		}
+		m.pending[s.key] = pending
+	}
+	if m.verbose {
+		log.Debugf(log.Trade, "Candle builder %s %s %s %s candle closed %+v",
+			s.exchange, s.pair, s.key.Asset, s.key.Interval, closed)
+	}
+	return CandleUpdate{
+		Exchange: s.exchange,
+		Pair:     s.pair,
+		Asset:    s.key.Asset,
+		Interval: s.key.Interval,
+		Candle:   closed,
+		Closed:   true,
+	}
+}
+
+// closeElapsedCandles closes all in-progress candles whose interval has
+// finished by the supplied time
+func (m *CandleBuilderManager) closeElapsedCandles(now time.Time) {
+	var closed []candlePublication
+	m.mu.Lock()
+	for _, s := range m.series {
+		if !s.active || now.Before(s.current.Time.Add(s.key.Interval.Duration())) {
+			continue
+		}
+		closed = append(closed, candlePublication{
+			series: s,
+			update: m.closeCandle(s),
+		})
+	}
+	m.mu.Unlock()
+	m.publish(closed)
+}
+
+// publish sends closed candle updates to subscribers
+func (m *CandleBuilderManager) publish(closed []candlePublication) {
+	for x := range closed {
+		m.publishUpdate(closed[x].series, closed[x].update)
+	}
+}
+
+// publishUpdate pushes a single update to the series subscribers
+func (m *CandleBuilderManager) publishUpdate(s *candleSeries, upd CandleUpdate) {
+	if err := m.mux.Publish(upd, s.id); err != nil {
+		log.Errorf(log.Trade, "Candle builder %s %s %s %s publish error: %v",
+			upd.Exchange, upd.Pair, upd.Asset, upd.Interval, err)
+	}
+}
+
+// lookupSeries returns an existing series. Must be called with at least a
+// read lock held.
+func (m *CandleBuilderManager) lookupSeries(exch string, p currency.Pair, a asset.Item, i kline.Interval) (*candleSeries, error) {
+	s, ok := m.series[candleSeriesKey{
+		Exchange: strings.ToLower(exch),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+		Interval: i,
+	}]
+	if !ok {
+		return nil, fmt.Errorf("%w %s %s %s %s", errCandleSeriesNotFound, exch, p, a, i)
+	}
+	return s, nil
+}
+
+// isBuiltInterval checks whether the interval is configured
+func (m *CandleBuilderManager) isBuiltInterval(i kline.Interval) bool {
+	for x := range m.intervals {
+		if m.intervals[x] == i {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIntervals returns the intervals candles are built for
+func (m *CandleBuilderManager) GetIntervals() []kline.Interval {
+	if m == nil {
+		return nil
+	}
+	intervals := make([]kline.Interval, len(m.intervals))
+	copy(intervals, m.intervals)
+	return intervals
+}
+
+// GetCandles returns the closed candles built for the exchange, pair, asset
+// and interval in ascending time order. When includeCurrent is set, the
+// in-progress candle is appended.
+func (m *CandleBuilderManager) GetCandles(exch string, p currency.Pair, a asset.Item, i kline.Interval, includeCurrent bool) (*kline.Item, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", CandleBuilderManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemNotStarted)
+	}
+	if exch == "" {
+		return nil, ErrExchangeNameIsEmpty
+	}
+	if p.IsEmpty() {
+		return nil, currency.ErrCurrencyPairEmpty
+	}
+	if !a.IsValid() {
+		return nil, fmt.Errorf("%s %w", a, asset.ErrNotSupported)
+	}
+	if !m.isBuiltInterval(i) {
+		return nil, fmt.Errorf("%w %s", errCandleIntervalNotBuilt, i)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, err := m.lookupSeries(exch, p, a, i)
+	if err != nil {
+		return nil, err
+	}
+	candles := make([]kline.Candle, len(s.closed), len(s.closed)+1)
+	copy(candles, s.closed)
+	if includeCurrent && s.active {
+		candles = append(candles, s.current)
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("%w %s %s %s %s", errNoCandlesBuilt, exch, p, a, i)
+	}
+	return &kline.Item{
+		Exchange: s.exchange,
+		Pair:     s.pair,
+		Asset:    a,
+		Interval: i,
+		Candles:  candles,
+	}, nil
+}
+
+// SubscribeCandles returns a dispatch pipe which receives a CandleUpdate
+// each time a candle for the series is amended or closed
+func (m *CandleBuilderManager) SubscribeCandles(exch string, p currency.Pair, a asset.Item, i kline.Interval) (dispatch.Pipe, error) {
+	if m == nil {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", CandleBuilderManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemNotStarted)
+	}
+	if exch == "" {
+		return dispatch.Pipe{}, ErrExchangeNameIsEmpty
+	}
+	if p.IsEmpty() {
+		return dispatch.Pipe{}, currency.ErrCurrencyPairEmpty
+	}
+	if !a.IsValid() {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", a, asset.ErrNotSupported)
+	}
+	if !m.isBuiltInterval(i) {
+		return dispatch.Pipe{}, fmt.Errorf("%w %s", errCandleIntervalNotBuilt, i)
+	}
+	m.mu.Lock()
+	// Create the series ahead of any trades so subscribers can wait on it
+	s, err := m.getSeries(exch, p, a, i)
+	m.mu.Unlock()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	return m.mux.Subscribe(s.id)
+}
+
+// persistCandles writes closed candles to the database via the candle
+// repository
+func (m *CandleBuilderManager) persistCandles() {
+	if !m.persist {
+		return
+	}
+	if db := m.dbManager.GetInstance(); db == nil || !db.IsConnected() {
+		if m.verbose {
+			log.Warnf(log.Trade, "Candle builder database not connected, deferring candle persistence")
+		}
+		return
+	}
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = make(map[candleSeriesKey][]kline.Candle)
+	m.mu.Unlock()
+	for key, candles := range pending {
+		m.mu.RLock()
+		s, ok := m.series[key]
+		m.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		sort.Slice(candles, func(i, j int) bool {
+			return candles[i].Time.Before(candles[j].Time)
+		})
+		_, err := m.candleSaver(&kline.Item{
+			Exchange: s.exchange,
+			Pair:     s.pair,
+			Asset:    key.Asset,
+			Interval: key.Interval,
+			Candles:  candles,
+		}, false)
+		if err != nil {
+			log.Errorf(log.Trade, "Candle builder %s %s %s %s failed to store candles, retrying next persist: %v",
+				s.exchange, s.pair, key.Asset, key.Interval, err)
+			m.requeueCandles(key, candles)
+		}
+	}
+}
+
+// requeueCandles returns candles which failed to persist to the front of the
+// pending queue, ahead of candles closed since
+func (m *CandleBuilderManager) requeueCandles(key candleSeriesKey, candles []kline.Candle) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := append(candles, m.pending[key]...)
+	if len(pending) > m.maxCandles {
+		pending = pending[len(pending)-m.maxCandles:]
+	}
+	m.pending[key] = pending
+}
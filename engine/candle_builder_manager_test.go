@@ -0,0 +1,321 @@
+package engine
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+func TestSetupCandleBuilderManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupCandleBuilderManager(nil, nil, false)
+	if !errors.Is(err, errNilCandleBuilderConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilCandleBuilderConfig)
+	}
+
+	_, err = SetupCandleBuilderManager(&config.CandleBuilder{Intervals: []time.Duration{-1}}, nil, false)
+	if !errors.Is(err, kline.ErrUnsupportedInterval) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, kline.ErrUnsupportedInterval)
+	}
+
+	_, err = SetupCandleBuilderManager(&config.CandleBuilder{PersistCandles: true}, nil, false)
+	if !errors.Is(err, errNilDatabaseConnectionManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilDatabaseConnectionManager)
+	}
+
+	m, err := SetupCandleBuilderManager(&config.CandleBuilder{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(m.GetIntervals()) != len(DefaultCandleBuilderIntervals) {
+		t.Fatal("expected default intervals to be set")
+	}
+	if m.maxCandles != DefaultCandleBuilderMaxCandles {
+		t.Fatal("expected default max candles to be set")
+	}
+	if m.persistInterval != DefaultCandleBuilderPersistInterval {
+		t.Fatal("expected default persist interval to be set")
+	}
+}
+
+func TestCandleBuilderManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *CandleBuilderManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+
+	m, err = SetupCandleBuilderManager(&config.CandleBuilder{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !m.IsRunning() {
+		t.Fatal("expected manager to be running")
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestCandleBuilderProcessTrades(t *testing.T) {
+	t.Parallel()
+	m, err := SetupCandleBuilderManager(&config.CandleBuilder{
+		Intervals:  []time.Duration{time.Minute, time.Minute * 5},
+		MaxCandles: 2,
+	}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	err = m.ProcessTrades(testExchange, nil)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	// Started without the run routine so elapsed candles are only closed
+	// when called below
+	m.started = 1
+
+	tn := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	trades := []trade.Data{
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: tn},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 105, Amount: 2, Timestamp: tn.Add(time.Second * 10)},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 95, Amount: 1, Timestamp: tn.Add(time.Second * 20)},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 0, Amount: 1, Timestamp: tn.Add(time.Second * 30)},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 101, Amount: 3, Timestamp: tn.Add(time.Minute)},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 999, Amount: 3, Timestamp: tn.Add(time.Second * 40)},
+	}
+	err = m.ProcessTrades(testExchange, trades)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	_, err = m.GetCandles("", p, asset.Spot, kline.OneMin, false)
+	if !errors.Is(err, ErrExchangeNameIsEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNameIsEmpty)
+	}
+	_, err = m.GetCandles(testExchange, currency.EMPTYPAIR, asset.Spot, kline.OneMin, false)
+	if !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
+	_, err = m.GetCandles(testExchange, p, asset.Empty, kline.OneMin, false)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	_, err = m.GetCandles(testExchange, p, asset.Spot, kline.OneHour, false)
+	if !errors.Is(err, errCandleIntervalNotBuilt) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleIntervalNotBuilt)
+	}
+	_, err = m.GetCandles(testExchange, p, asset.Futures, kline.OneMin, false)
+	if !errors.Is(err, errCandleSeriesNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleSeriesNotFound)
+	}
+	_, err = m.GetCandles(testExchange, p, asset.Spot, kline.FiveMin, false)
+	if !errors.Is(err, errNoCandlesBuilt) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoCandlesBuilt)
+	}
+
+	k, err := m.GetCandles(testExchange, p, asset.Spot, kline.OneMin, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(k.Candles) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(k.Candles), 2)
+	}
+	closed := k.Candles[0]
+	if !closed.Time.Equal(tn) || closed.Open != 100 || closed.High != 105 ||
+		closed.Low != 95 || closed.Close != 95 || closed.Volume != 4 {
+		t.Fatalf("unexpected closed candle %+v", closed)
+	}
+	current := k.Candles[1]
+	if !current.Time.Equal(tn.Add(time.Minute)) || current.Open != 101 || current.Volume != 3 {
+		t.Fatalf("unexpected in-progress candle %+v", current)
+	}
+
+	k, err = m.GetCandles(testExchange, p, asset.Spot, kline.FiveMin, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(k.Candles) != 1 || k.Candles[0].High != 999 || k.Candles[0].Close != 999 || k.Candles[0].Volume != 10 {
+		t.Fatalf("unexpected five minute candle %+v", k.Candles)
+	}
+
+	m.closeElapsedCandles(tn.Add(time.Minute * 2))
+	k, err = m.GetCandles(testExchange, p, asset.Spot, kline.OneMin, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(k.Candles) != 2 || !k.Candles[1].Time.Equal(tn.Add(time.Minute)) {
+		t.Fatalf("expected in-progress candle to be closed %+v", k.Candles)
+	}
+
+	// Max candles is set to two so the oldest candle should be dropped
+	err = m.ProcessTrades(testExchange, []trade.Data{
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 102, Amount: 1, Timestamp: tn.Add(time.Minute * 2)},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 103, Amount: 1, Timestamp: tn.Add(time.Minute * 3)},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	k, err = m.GetCandles(testExchange, p, asset.Spot, kline.OneMin, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(k.Candles) != 2 || !k.Candles[0].Time.Equal(tn.Add(time.Minute)) {
+		t.Fatalf("expected oldest candle to be dropped %+v", k.Candles)
+	}
+}
+
+func TestCandleBuilderWebsocketDataHandler(t *testing.T) {
+	t.Parallel()
+	m, err := SetupCandleBuilderManager(&config.CandleBuilder{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	trades := []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: time.Now()}}
+	err = m.websocketDataHandler(testExchange, trades)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(m.series) != 0 {
+		t.Fatal("expected trades to be ignored when not running")
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.websocketDataHandler(testExchange, "not a trade")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.websocketDataHandler(testExchange, trades)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(m.series) != len(DefaultCandleBuilderIntervals) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(m.series), len(DefaultCandleBuilderIntervals))
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestCandleBuilderSubscribeCandles(t *testing.T) {
+	t.Parallel()
+	var m *CandleBuilderManager
+	_, err := m.SubscribeCandles(testExchange, currency.NewPair(currency.BTC, currency.USDT), asset.Spot, kline.OneMin)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	m, err = SetupCandleBuilderManager(&config.CandleBuilder{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.SubscribeCandles(testExchange, currency.NewPair(currency.BTC, currency.USDT), asset.Spot, kline.OneMin)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.SubscribeCandles(testExchange, currency.NewPair(currency.BTC, currency.USDT), asset.Spot, kline.TwoHour)
+	if !errors.Is(err, errCandleIntervalNotBuilt) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleIntervalNotBuilt)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+// fCandleDatabase is a database connection manager which is always connected
+type fCandleDatabase struct{}
+
+func (fCandleDatabase) GetInstance() database.IDatabase {
+	inst := &database.Instance{}
+	inst.SetConnected(true)
+	return inst
+}
+
+func TestCandleBuilderPersistCandles(t *testing.T) {
+	t.Parallel()
+	m, err := SetupCandleBuilderManager(&config.CandleBuilder{
+		Intervals:      []time.Duration{time.Minute},
+		MaxCandles:     3,
+		PersistCandles: true,
+	}, fCandleDatabase{}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	storeErr := errors.New("database unavailable")
+	var stored []kline.Candle
+	m.candleSaver = func(k *kline.Item, _ bool) (uint64, error) {
+		if storeErr != nil {
+			return 0, storeErr
+		}
+		stored = append(stored, k.Candles...)
+		return uint64(len(k.Candles)), nil
+	}
+	m.started = 1
+
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tn := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		err = m.ProcessTrades(testExchange, []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: tn.Add(time.Minute * time.Duration(i))}})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+
+	// Failed candles are requeued ahead of candles closed since
+	m.persistCandles()
+	err = m.ProcessTrades(testExchange, []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: tn.Add(time.Minute * 3)}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.persistCandles()
+	key := candleSeriesKey{Exchange: strings.ToLower(testExchange), Base: p.Base.Item, Quote: p.Quote.Item, Asset: asset.Spot, Interval: kline.OneMin}
+	if len(m.pending[key]) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(m.pending[key]), 3)
+	}
+	if !m.pending[key][0].Time.Equal(tn) {
+		t.Fatalf("received: '%v' but expected: '%v'", m.pending[key][0].Time, tn)
+	}
+
+	storeErr = nil
+	m.persistCandles()
+	if len(stored) != 3 || len(m.pending[key]) != 0 {
+		t.Fatalf("received: '%v' stored and '%v' pending but expected: '%v' and '%v'", len(stored), len(m.pending[key]), 3, 0)
+	}
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// CandleBuilderManagerName is an exported subsystem name
+const CandleBuilderManagerName = "candle_builder"
+
+const (
+	// DefaultCandleBuilderMaxCandles is the default amount of closed candles
+	// retained in memory per series
+	DefaultCandleBuilderMaxCandles = 500
+	// DefaultCandleBuilderPersistInterval is the default duration between
+	// database writes of closed candles
+	DefaultCandleBuilderPersistInterval = time.Minute
+	// candleBuilderCheckInterval is how often in-progress candles are checked
+	// to see if their interval has elapsed without receiving a new trade
+	candleBuilderCheckInterval = time.Second
+)
+
+var (
+	errNilCandleBuilderConfig = errors.New("nil candle builder config received")
+	errNoCandleIntervals      = errors.New("no candle intervals set")
+	errCandleSeriesNotFound   = errors.New("candle series not found")
+	errCandleIntervalNotBuilt = errors.New("candle interval is not built by the candle builder")
+	errNoCandlesBuilt         = errors.New("no candles built")
+	// DefaultCandleBuilderIntervals are used when no intervals are configured
+	DefaultCandleBuilderIntervals = []kline.Interval{
+		kline.OneMin,
+		kline.FiveMin,
+		kline.FifteenMin,
+		kline.OneHour,
+	}
+)
+
+// CandleBuilderManager consumes live websocket trades and builds candles
+// in memory at multiple intervals for every exchange, pair and asset seen
+type CandleBuilderManager struct {
+	started         int32
+	verbose         bool
+	persist         bool
+	intervals       []kline.Interval
+	maxCandles      int
+	persistInterval time.Duration
+	dbManager       iDatabaseConnectionManager
+	candleSaver     func(*kline.Item, bool) (uint64, error)
+	mux             *dispatch.Mux
+	series          map[candleSeriesKey]*candleSeries
+	pending         map[candleSeriesKey][]kline.Candle
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	mu              sync.RWMutex
+}
+
+// CandleUpdate is published via dispatch every time a candle is amended by a
+// trade or is closed
+type CandleUpdate struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	Interval kline.Interval
+	Candle   kline.Candle
+	// Closed is true when the candle interval has elapsed and the candle will
+	// no longer be amended
+	Closed bool
+}
+
+// candleSeriesKey is used to look up a series of candles
+type candleSeriesKey struct {
+	Exchange string
+	Base     *currency.Item
+	Quote    *currency.Item
+	Asset    asset.Item
+	Interval kline.Interval
+}
+
+// candleSeries holds the candles built for a single interval
+type candleSeries struct {
+	id       uuid.UUID
+	key      candleSeriesKey
+	exchange string
+	pair     currency.Pair
+	closed   []kline.Candle
+	current  kline.Candle
+	active   bool
+}
+
+// candlePublication links an update to the series it is published on
+type candlePublication struct {
+	series *candleSeries
+	update CandleUpdate
+}
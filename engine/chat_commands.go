@@ -0,0 +1,362 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/communications/commands"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	// defaultChatPNLPeriod is the period PnL is reported over when none is
+	// supplied
+	defaultChatPNLPeriod = time.Hour * 24
+)
+
+var (
+	errUnknownChatStrategy  = errors.New("unknown strategy")
+	errInvalidChatPeriod    = errors.New("invalid period, use a duration such as 12h or a number of days such as 7d")
+	errNoChatCommandRelayer = errors.New("no enabled relayer receives inbound messages")
+)
+
+// chatStrategies are the subsystems which trade automatically and can be
+// paused and resumed by chat commands
+var chatStrategies = []string{RebalanceManagerName, vm.Name}
+
+// setupChatCommands registers the engine's chat commands with a command
+// handler and passes it to the relayers which receive inbound messages
+func (bot *Engine) setupChatCommands() error {
+	if bot == nil {
+		return errNilBot
+	}
+	cfg := bot.Config.GetCommunicationsConfig()
+	if !cfg.CommandsConfig.Enabled {
+		return nil
+	}
+	h, err := commands.NewHandler(&cfg.CommandsConfig)
+	if err != nil {
+		return err
+	}
+	cmds := bot.chatCommands()
+	for i := range cmds {
+		if err = h.Register(&cmds[i]); err != nil {
+			return err
+		}
+	}
+	receivers, err := bot.CommunicationsManager.SetCommandHandler(h)
+	if err != nil {
+		return err
+	}
+	if len(receivers) == 0 {
+		return errNoChatCommandRelayer
+	}
+	log.Debugf(log.CommunicationMgr, "Chat commands enabled for %s", strings.Join(receivers, ", "))
+	return nil
+}
+
+// chatCommands returns the engine actions which can be run by chat commands
+func (bot *Engine) chatCommands() []commands.Command {
+	return []commands.Command{
+		{
+			Name:        "positions",
+			Description: "Shows open futures positions",
+			Action:      bot.chatPositions,
+		},
+		{
+			Name:        "pnl",
+			Usage:       "[period]",
+			Description: "Shows portfolio PnL over a period, default 24h",
+			Action:      bot.chatPNL,
+		},
+		{
+			Name:        "cancelall",
+			Usage:       "[exchange]",
+			Description: "Cancels all orders on an exchange or every enabled exchange",
+			Destructive: true,
+			Action:      bot.chatCancelAll,
+		},
+		{
+			Name:        "strategies",
+			Description: "Lists strategies and whether they are running",
+			Action:      bot.chatStrategies,
+		},
+		{
+			Name:        "pause",
+			Usage:       "<strategy>",
+			Description: "Pauses a strategy",
+			MinArgs:     1,
+			Destructive: true,
+			Action:      bot.chatPauseStrategy,
+		},
+		{
+			Name:        "resume",
+			Usage:       "<strategy>",
+			Description: "Resumes a paused strategy",
+			MinArgs:     1,
+			Action:      bot.chatResumeStrategy,
+		},
+		{
+			Name:        "enable",
+			Usage:       "<exchange>",
+			Description: "Enables an exchange",
+			MinArgs:     1,
+			Action:      bot.chatEnableExchange,
+		},
+		{
+			Name:        "disable",
+			Usage:       "<exchange>",
+			Description: "Disables an exchange",
+			MinArgs:     1,
+			Destructive: true,
+			Action:      bot.chatDisableExchange,
+		},
+		{
+			Name:        "withdrawals",
+			Description: "Lists rebalance plans with withdrawals pending approval",
+			Action:      bot.chatPendingWithdrawals,
+		},
+		{
+			Name:        "approve",
+			Usage:       "<plan ID>",
+			Description: "Approves a rebalance plan and its pending withdrawals",
+			MinArgs:     1,
+			Destructive: true,
+			Action:      bot.chatApproveWithdrawals,
+		},
+	}
+}
+
+// chatPositions lists open futures positions
+func (bot *Engine) chatPositions(_ context.Context, _ []string) (string, error) {
+	positions, err := bot.OrderManager.GetAllOpenFuturesPositions()
+	if err != nil {
+		return "", err
+	}
+	if len(positions) == 0 {
+		return "No open positions", nil
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Exchange != positions[j].Exchange {
+			return positions[i].Exchange < positions[j].Exchange
+		}
+		return positions[i].Pair.String() < positions[j].Pair.String()
+	})
+	var sb strings.Builder
+	sb.WriteString("Open positions:")
+	for i := range positions {
+		p := &positions[i]
+		fmt.Fprintf(&sb, "\n%s %s %s %s %s @ %s, unrealised PnL %s, realised PnL %s",
+			p.Exchange, p.Asset, p.Pair, p.LatestDirection, p.LatestSize, p.LatestPrice,
+			p.UnrealisedPNL, p.RealisedPNL)
+	}
+	return sb.String(), nil
+}
+
+// chatPNL summarises portfolio performance over a period
+func (bot *Engine) chatPNL(_ context.Context, args []string) (string, error) {
+	period := defaultChatPNLPeriod
+	if len(args) > 0 {
+		var err error
+		period, err = parseChatPeriod(args[0])
+		if err != nil {
+			return "", err
+		}
+	}
+	end := time.Now()
+	perf, err := bot.portfolioHistoryManager.GetPerformance(end.Add(-period), end, "")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("PnL over %v in %s:\nStart value %.2f\nEnd value %.2f\nDeposits %.2f\nWithdrawals %.2f\nRealised PnL %.2f\nUnrealised PnL %.2f\nNet performance %.2f",
+		period, perf.ValueCurrency,
+		perf.StartValue, perf.EndValue,
+		perf.Deposits, perf.Withdrawals,
+		perf.RealisedPNL, perf.UnrealisedPNL,
+		perf.NetPerformance), nil
+}
+
+// chatCancelAll cancels all orders of every account on an exchange, or every
+// enabled exchange when none is supplied
+func (bot *Engine) chatCancelAll(ctx context.Context, args []string) (string, error) {
+	var exchanges []exchange.IBotExchange
+	if len(args) > 0 {
+		exch, err := bot.GetExchangeByName(args[0])
+		if err != nil {
+			return "", err
+		}
+		exchanges = append(exchanges, exch)
+	} else {
+		exchanges = bot.GetExchanges()
+	}
+	var cancelled int64
+	var failed []string
+	for i := range exchanges {
+		accounts := exchangeAccountNames(exchanges[i])
+		for j := range accounts {
+			count, err := cancelAllExchangeOrders(account.DeployAccountToContext(ctx, accounts[j]), exchanges[i])
+			cancelled += count
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", reconciliationBalanceKey(exchanges[i].GetName(), accounts[j]), err))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return "", fmt.Errorf("cancelled %d orders, %s", cancelled, strings.Join(failed, ", "))
+	}
+	return fmt.Sprintf("Cancelled %d orders across %d exchanges", cancelled, len(exchanges)), nil
+}
+
+// chatStrategies lists the strategies which can be paused and whether they
+// are running
+func (bot *Engine) chatStrategies(_ context.Context, _ []string) (string, error) {
+	status := bot.GetSubsystemsStatus()
+	var sb strings.Builder
+	sb.WriteString("Strategies:")
+	for i := range chatStrategies {
+		state := "paused"
+		if status[chatStrategies[i]] {
+			state = "running"
+		}
+		fmt.Fprintf(&sb, "\n%s %s", chatStrategies[i], state)
+	}
+	return sb.String(), nil
+}
+
+// chatPauseStrategy stops a strategy subsystem
+func (bot *Engine) chatPauseStrategy(_ context.Context, args []string) (string, error) {
+	name, err := getChatStrategy(args[0])
+	if err != nil {
+		return "", err
+	}
+	if err = bot.SetSubsystem(name, false); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Paused %s", name), nil
+}
+
+// chatResumeStrategy starts a strategy subsystem
+func (bot *Engine) chatResumeStrategy(_ context.Context, args []string) (string, error) {
+	name, err := getChatStrategy(args[0])
+	if err != nil {
+		return "", err
+	}
+	if err = bot.SetSubsystem(name, true); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Resumed %s", name), nil
+}
+
+// chatEnableExchange loads an exchange
+func (bot *Engine) chatEnableExchange(_ context.Context, args []string) (string, error) {
+	if err := bot.LoadExchange(args[0], nil); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Enabled %s", args[0]), nil
+}
+
+// chatDisableExchange unloads an exchange
+func (bot *Engine) chatDisableExchange(_ context.Context, args []string) (string, error) {
+	if err := bot.UnloadExchange(args[0]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Disabled %s", args[0]), nil
+}
+
+// chatPendingWithdrawals lists the rebalance plans pending approval which
+// withdraw funds
+func (bot *Engine) chatPendingWithdrawals(_ context.Context, _ []string) (string, error) {
+	plans, err := bot.rebalanceManager.GetPlans()
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for i := range plans {
+		if plans[i].Status != RebalanceProposed {
+			continue
+		}
+		var transfers []string
+		for j := range plans[i].Actions {
+			a := &plans[i].Actions[j]
+			if a.Type != RebalanceTransfer || a.Manual {
+				continue
+			}
+			transfers = append(transfers, fmt.Sprintf("%v %s from %s to %s", a.Amount, a.Currency, a.Exchange, a.Destination))
+		}
+		if len(transfers) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\nPlan %s proposed %s with %d actions:\n%s",
+			plans[i].ID, plans[i].Created.Format(time.RFC3339), len(plans[i].Actions), strings.Join(transfers, "\n"))
+	}
+	if sb.Len() == 0 {
+		return "No withdrawals pending approval", nil
+	}
+	return "Withdrawals pending approval:" + sb.String(), nil
+}
+
+// chatApproveWithdrawals approves a rebalance plan, executing its trades and
+// withdrawals
+func (bot *Engine) chatApproveWithdrawals(ctx context.Context, args []string) (string, error) {
+	id, err := uuid.FromString(args[0])
+	if err != nil {
+		return "", err
+	}
+	plan, err := bot.rebalanceManager.Approve(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	var executed int
+	for i := range plan.Actions {
+		if plan.Actions[i].Executed {
+			executed++
+		}
+	}
+	reply := fmt.Sprintf("Plan %s %s, %d of %d actions executed", plan.ID, plan.Status, executed, len(plan.Actions))
+	if plan.Error != "" {
+		reply += ": " + plan.Error
+	}
+	return reply, nil
+}
+
+// getChatStrategy returns the subsystem name of a strategy
+func getChatStrategy(name string) (string, error) {
+	for i := range chatStrategies {
+		if strings.EqualFold(chatStrategies[i], name) {
+			return chatStrategies[i], nil
+		}
+	}
+	return "", fmt.Errorf("%w %s, available strategies: %s", errUnknownChatStrategy, name, strings.Join(chatStrategies, ", "))
+}
+
+// parseChatPeriod parses a duration, additionally accepting a number of days
+// such as 7d
+func parseChatPeriod(period string) (time.Duration, error) {
+	var d time.Duration
+	if days := strings.TrimSuffix(strings.ToLower(period), "d"); days != strings.ToLower(period) {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errInvalidChatPeriod
+		}
+		d = time.Duration(n) * time.Hour * 24
+	} else {
+		var err error
+		d, err = time.ParseDuration(period)
+		if err != nil {
+			return 0, errInvalidChatPeriod
+		}
+	}
+	if d <= 0 {
+		return 0, errInvalidChatPeriod
+	}
+	return d, nil
+}
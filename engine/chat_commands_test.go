@@ -0,0 +1,299 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/communications"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/commands"
+	"github.com/thrasher-corp/gocryptotrader/communications/smsglobal"
+	"github.com/thrasher-corp/gocryptotrader/communications/telegram"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+)
+
+func chatCommandsConfig() *config.Config {
+	return &config.Config{
+		Communications: base.CommunicationsConfig{
+			CommandsConfig: base.CommandsConfig{
+				Enabled: true,
+				Users:   []base.CommandUser{{Relayer: "Telegram", UserID: "1", Commands: []string{commands.AllCommands}}},
+			},
+		},
+	}
+}
+
+func TestSetupChatCommands(t *testing.T) {
+	t.Parallel()
+	var bot *Engine
+	err := bot.setupChatCommands()
+	if !errors.Is(err, errNilBot) {
+		t.Errorf("error '%v', expected '%v'", err, errNilBot)
+	}
+
+	bot = &Engine{Config: &config.Config{}}
+	err = bot.setupChatCommands()
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+
+	bot.Config = chatCommandsConfig()
+	err = bot.setupChatCommands()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+
+	sms := &smsglobal.SMSGlobal{}
+	sms.Name = "SMSGlobal"
+	bot.CommunicationsManager = &CommunicationManager{
+		comms: &communications.Communications{IComm: base.IComm{sms}},
+	}
+	err = bot.setupChatCommands()
+	if !errors.Is(err, errNoChatCommandRelayer) {
+		t.Errorf("error '%v', expected '%v'", err, errNoChatCommandRelayer)
+	}
+
+	tg := &telegram.Telegram{}
+	tg.Name = "Telegram"
+	bot.CommunicationsManager.comms.IComm = append(bot.CommunicationsManager.comms.IComm, tg)
+	err = bot.setupChatCommands()
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	h := tg.GetCommandHandler()
+	if h == nil {
+		t.Fatal("expected command handler to be set on telegram")
+	}
+	reply := h.HandleMessage(context.Background(), &base.InboundMessage{Relayer: "Telegram", UserID: "1", Text: "/help", CommandPrefix: "/"})
+	for _, cmd := range []string{"/positions", "/pnl", "/cancelall", "/pause", "/resume", "/enable", "/disable", "/withdrawals", "/approve"} {
+		if !strings.Contains(reply, cmd) {
+			t.Errorf("expected help to contain %s, received %s", cmd, reply)
+		}
+	}
+}
+
+func TestChatPositions(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{}
+	_, err := bot.chatPositions(context.Background(), nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+}
+
+func TestChatPNL(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{}
+	_, err := bot.chatPNL(context.Background(), []string{"soon"})
+	if !errors.Is(err, errInvalidChatPeriod) {
+		t.Errorf("error '%v', expected '%v'", err, errInvalidChatPeriod)
+	}
+	_, err = bot.chatPNL(context.Background(), nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+}
+
+// fChatExchange records the accounts orders are cancelled for
+type fChatExchange struct {
+	exchange.IBotExchange
+	cancelled *[]string
+}
+
+func (f fChatExchange) GetAccountNames() []string {
+	return []string{"main", "hedge"}
+}
+
+func (f fChatExchange) CancelAllOrders(ctx context.Context, _ *order.Cancel) (order.CancelAllResponse, error) {
+	*f.cancelled = append(*f.cancelled, account.GetAccountFromContext(ctx))
+	return order.CancelAllResponse{Count: 1}, nil
+}
+
+func TestChatCancelAll(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	bot := &Engine{ExchangeManager: em}
+	_, err := bot.chatCancelAll(context.Background(), []string{"fake"})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("error '%v', expected '%v'", err, ErrExchangeNotFound)
+	}
+
+	b, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	b.GetBase().Name = "ChatCancelAll"
+	b.GetBase().Enabled = true
+	err = b.GetBase().CurrencyPairs.SetAssetEnabled(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cancelled []string
+	em.Add(fChatExchange{IBotExchange: b, cancelled: &cancelled})
+	reply, err := bot.chatCancelAll(context.Background(), []string{"ChatCancelAll"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if len(cancelled) != 3 || cancelled[0] != "" || cancelled[1] != "main" || cancelled[2] != "hedge" {
+		t.Errorf("expected orders to be cancelled for every account, cancelled %v", cancelled)
+	}
+	if reply != "Cancelled 3 orders across 1 exchanges" {
+		t.Errorf("unexpected reply %s", reply)
+	}
+}
+
+func TestChatStrategies(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{rebalanceManager: &RebalanceManager{started: 1}}
+	reply, err := bot.chatStrategies(context.Background(), nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if !strings.Contains(reply, RebalanceManagerName+" running") || !strings.Contains(reply, vm.Name+" paused") {
+		t.Errorf("unexpected strategies reply %s", reply)
+	}
+}
+
+func TestChatPauseResumeStrategy(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{Config: &config.Config{}}
+	_, err := bot.chatPauseStrategy(context.Background(), []string{"martingale"})
+	if !errors.Is(err, errUnknownChatStrategy) {
+		t.Errorf("error '%v', expected '%v'", err, errUnknownChatStrategy)
+	}
+	_, err = bot.chatResumeStrategy(context.Background(), []string{"martingale"})
+	if !errors.Is(err, errUnknownChatStrategy) {
+		t.Errorf("error '%v', expected '%v'", err, errUnknownChatStrategy)
+	}
+	_, err = bot.chatPauseStrategy(context.Background(), []string{strings.ToUpper(RebalanceManagerName)})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+}
+
+func TestChatEnableDisableExchange(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{Config: &config.Config{}, ExchangeManager: SetupExchangeManager()}
+	_, err := bot.chatEnableExchange(context.Background(), []string{"fake"})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("error '%v', expected '%v'", err, ErrExchangeNotFound)
+	}
+	_, err = bot.chatDisableExchange(context.Background(), []string{"fake"})
+	if !errors.Is(err, config.ErrExchangeNotFound) {
+		t.Errorf("error '%v', expected '%v'", err, config.ErrExchangeNotFound)
+	}
+}
+
+func TestChatPendingWithdrawals(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{}
+	_, err := bot.chatPendingWithdrawals(context.Background(), nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+
+	pending, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tradeOnly, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.rebalanceManager = &RebalanceManager{
+		started: 1,
+		plans: []*RebalancePlan{
+			{
+				ID:      pending,
+				Created: time.Now(),
+				Status:  RebalanceProposed,
+				Actions: []RebalanceAction{
+					{Type: RebalanceTransfer, Exchange: "Bitstamp", Destination: "Binance", Currency: currency.BTC, Amount: 0.5},
+					{Type: RebalanceTransfer, Exchange: "Bitstamp", Destination: "cold", Currency: currency.ETH, Amount: 2, Manual: true},
+				},
+			},
+			{
+				ID:      tradeOnly,
+				Created: time.Now(),
+				Status:  RebalanceProposed,
+				Actions: []RebalanceAction{{Type: RebalanceBuy, Exchange: "Bitstamp"}},
+			},
+		},
+	}
+	reply, err := bot.chatPendingWithdrawals(context.Background(), nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if !strings.Contains(reply, pending.String()) || !strings.Contains(reply, "0.5 BTC from Bitstamp to Binance") {
+		t.Errorf("expected pending withdrawal in reply, received %s", reply)
+	}
+	if strings.Contains(reply, tradeOnly.String()) || strings.Contains(reply, "ETH") {
+		t.Errorf("expected trades and manual transfers to be excluded, received %s", reply)
+	}
+
+	bot.rebalanceManager.plans[0].Status = RebalanceExecuted
+	reply, err = bot.chatPendingWithdrawals(context.Background(), nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if reply != "No withdrawals pending approval" {
+		t.Errorf("unexpected reply %s", reply)
+	}
+}
+
+func TestChatApproveWithdrawals(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{}
+	_, err := bot.chatApproveWithdrawals(context.Background(), []string{"not-a-uuid"})
+	if err == nil {
+		t.Error("expected error for invalid plan ID")
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = bot.chatApproveWithdrawals(context.Background(), []string{id.String()})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	bot.rebalanceManager = &RebalanceManager{started: 1}
+	_, err = bot.chatApproveWithdrawals(context.Background(), []string{id.String()})
+	if !errors.Is(err, errRebalancePlanNotFound) {
+		t.Errorf("error '%v', expected '%v'", err, errRebalancePlanNotFound)
+	}
+}
+
+func TestParseChatPeriod(t *testing.T) {
+	t.Parallel()
+	d, err := parseChatPeriod("7D")
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if d != time.Hour*24*7 {
+		t.Errorf("expected 168h, received %v", d)
+	}
+	d, err = parseChatPeriod("12h")
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if d != time.Hour*12 {
+		t.Errorf("expected 12h, received %v", d)
+	}
+	for _, period := range []string{"0d", "-1h", "xd", "x"} {
+		_, err = parseChatPeriod(period)
+		if !errors.Is(err, errInvalidChatPeriod) {
+			t.Errorf("%s error '%v', expected '%v'", period, err, errInvalidChatPeriod)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sync/atomic"
 
@@ -12,6 +13,8 @@ import (
 // CommunicationsManagerName is an exported subsystem name
 const CommunicationsManagerName = "communications"
 
+var errNilCommandHandler = errors.New("nil command handler received")
+
 // CommunicationManager ensures operations of communications
 type CommunicationManager struct {
 	started  int32
@@ -67,6 +70,18 @@ func (m *CommunicationManager) GetStatus() (map[string]base.CommsStatus, error)
 	return m.comms.GetStatus(), nil
 }
 
+// SetCommandHandler passes inbound chat commands received by relayers to the
+// handler and returns the names of the relayers which receive them
+func (m *CommunicationManager) SetCommandHandler(h base.CommandHandler) ([]string, error) {
+	if m == nil {
+		return nil, fmt.Errorf("communications manager %w", ErrNilSubsystem)
+	}
+	if h == nil {
+		return nil, errNilCommandHandler
+	}
+	return m.comms.SetCommandHandler(h), nil
+}
+
 // Stop attempts to shutdown the subsystem
 func (m *CommunicationManager) Stop() error {
 	if m == nil {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/commands"
 )
 
 func TestSetup(t *testing.T) {
@@ -154,3 +155,36 @@ func TestPushEvent(t *testing.T) {
 	m = nil
 	m.PushEvent(base.Event{})
 }
+
+func TestCommunicationManagerSetCommandHandler(t *testing.T) {
+	t.Parallel()
+	var m *CommunicationManager
+	_, err := m.SetCommandHandler(nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	m, err = SetupCommunicationManager(&base.CommunicationsConfig{
+		SlackConfig: base.SlackConfig{
+			Name:    "Slack",
+			Enabled: true,
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	_, err = m.SetCommandHandler(nil)
+	if !errors.Is(err, errNilCommandHandler) {
+		t.Errorf("error '%v', expected '%v'", err, errNilCommandHandler)
+	}
+	h, err := commands.NewHandler(&base.CommandsConfig{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	receivers, err := m.SetCommandHandler(h)
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+	if len(receivers) != 1 || receivers[0] != "Slack" {
+		t.Errorf("expected Slack to receive commands, received %v", receivers)
+	}
+}
@@ -0,0 +1,348 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupDeadManSwitch creates a new dead man's switch from config. The
+// connection manager is optional and connectivity is not checked without it.
+// The communications manager is optional and receives an alert when orders are
+// cancelled
+func SetupDeadManSwitch(cfg *config.DeadManSwitch, exchangeManager iExchangeManager, orderManager iOrderCanceller, connectionManager iConnectivityChecker, commsManager iCommsManager, verbose bool) (*DeadManSwitch, error) {
+	if cfg == nil {
+		return nil, errNilDeadManSwitchConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if orderManager == nil {
+		return nil, errNilOrderCanceller
+	}
+	m := &DeadManSwitch{
+		verbose:           verbose || cfg.Verbose,
+		interval:          cfg.CheckInterval,
+		staleDataTimeout:  cfg.StaleDataTimeout,
+		heartbeatTimeout:  cfg.HeartbeatTimeout,
+		nativeTimeout:     cfg.NativeTimeout,
+		exchanges:         cfg.Exchanges,
+		exchangeManager:   exchangeManager,
+		orderManager:      orderManager,
+		connectionManager: connectionManager,
+		commsManager:      commsManager,
+		tripped:           make(map[string]string),
+		cancelFailed:      make(map[string]bool),
+		nativeUnsupported: make(map[string]bool),
+		shutdown:          make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DefaultDeadManSwitchCheckInterval
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *DeadManSwitch) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem. Starting counts as the first heartbeat
+func (m *DeadManSwitch) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.OrderMgr, "Dead man's switch %s", MsgSubSystemStarting)
+	m.mu.Lock()
+	m.lastHeartbeat = time.Now()
+	m.mu.Unlock()
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.OrderMgr, "Dead man's switch %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem and disables exchange side dead man's switches, as
+// orders are expected to rest once the engine is deliberately stopped
+func (m *DeadManSwitch) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.OrderMgr, "Dead man's switch %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	m.disableNative(context.TODO())
+	log.Debugf(log.OrderMgr, "Dead man's switch %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// checkRunning returns an error when the subsystem is not running
+func (m *DeadManSwitch) checkRunning() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", DeadManSwitchName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// Heartbeat records a heartbeat from an external controller and returns the
+// duration within which the next heartbeat must be received
+func (m *DeadManSwitch) Heartbeat() (time.Duration, error) {
+	if err := m.checkRunning(); err != nil {
+		return 0, err
+	}
+	if m.heartbeatTimeout <= 0 {
+		return 0, errHeartbeatDisabled
+	}
+	m.mu.Lock()
+	m.lastHeartbeat = time.Now()
+	m.mu.Unlock()
+	return m.heartbeatTimeout, nil
+}
+
+// run checks every interval
+func (m *DeadManSwitch) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	m.check(context.TODO())
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			m.check(context.TODO())
+		}
+	}
+}
+
+// check cancels the open orders of each monitored exchange once while the
+// engine is offline, heartbeats have stopped or the exchange's market data is
+// stale. Otherwise exchange side dead man's switches are refreshed. The lock is
+// not held while calling exchanges so heartbeats are not blocked
+func (m *DeadManSwitch) check(ctx context.Context) {
+	exchanges, err := getAccountExchanges(m.exchangeManager, m.exchanges)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Dead man's switch unable to get exchanges: %v", err)
+		return
+	}
+	m.mu.Lock()
+	lastHeartbeat := m.lastHeartbeat
+	m.mu.Unlock()
+	var reason string
+	switch {
+	case m.connectionManager != nil && !m.connectionManager.IsOnline():
+		reason = "internet connectivity lost"
+	case m.heartbeatTimeout > 0 && time.Since(lastHeartbeat) > m.heartbeatTimeout:
+		reason = fmt.Sprintf("no heartbeat received since %v", lastHeartbeat.UTC().Format(common.SimpleTimeFormatWithTimezone))
+	}
+	for i := range exchanges {
+		name := exchanges[i].GetName()
+		active, err := m.orderManager.GetOrdersActive(&order.Filter{Exchange: name})
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Dead man's switch unable to get %s active orders: %v", name, err)
+			continue
+		}
+		exchangeReason := reason
+		if exchangeReason == "" {
+			exchangeReason = m.staleData(name, active)
+		}
+		if exchangeReason != "" {
+			if !m.setTripped(name, exchangeReason, len(active)) {
+				continue
+			}
+			cancelled := m.trip(ctx, exchanges[i], len(active))
+			m.mu.Lock()
+			if cancelled {
+				delete(m.cancelFailed, name)
+			} else {
+				m.cancelFailed[name] = true
+			}
+			m.mu.Unlock()
+			continue
+		}
+		m.mu.Lock()
+		previous, ok := m.tripped[name]
+		delete(m.tripped, name)
+		delete(m.cancelFailed, name)
+		m.mu.Unlock()
+		if ok {
+			log.Infof(log.OrderMgr, "Dead man's switch for %s reset, %s has cleared", name, previous)
+		}
+		m.refreshNative(ctx, exchanges[i])
+	}
+}
+
+// setTripped records the reason the orders of an exchange are being cancelled,
+// alerting the first time a condition is found. It returns true when orders
+// need to be cancelled, which is once per trip unless cancelling failed
+func (m *DeadManSwitch) setTripped(name, reason string, activeOrders int) bool {
+	m.mu.Lock()
+	_, tripped := m.tripped[name]
+	retry := m.cancelFailed[name]
+	if !tripped {
+		m.tripped[name] = reason
+	}
+	m.mu.Unlock()
+	if tripped {
+		return retry
+	}
+	msg := fmt.Sprintf("Dead man's switch triggered for %s, %s. Cancelling all open orders, %d tracked", name, reason, activeOrders)
+	log.Warnln(log.OrderMgr, msg)
+	if m.commsManager != nil {
+		m.commsManager.PushEvent(base.Event{Type: "deadman_switch", Message: msg})
+	}
+	return true
+}
+
+// trip cancels the open orders of an exchange. Orders tracked by the order
+// manager are cancelled first, then cancel all is issued on the exchange for
+// the default credentials and each named account so orders unknown to the
+// engine are cancelled too. It returns false when cancel all failed for any
+// account
+func (m *DeadManSwitch) trip(ctx context.Context, exch exchange.IBotExchange, activeOrders int) bool {
+	if activeOrders > 0 {
+		m.orderManager.CancelAllOrders(ctx, []exchange.IBotExchange{exch})
+	}
+	cancelled := true
+	accounts := exchangeAccountNames(exch)
+	for i := range accounts {
+		name := reconciliationBalanceKey(exch.GetName(), accounts[i])
+		count, err := cancelAllExchangeOrders(account.DeployAccountToContext(ctx, accounts[i]), exch)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Dead man's switch unable to cancel %s orders, retrying next check: %v", name, err)
+			cancelled = false
+			continue
+		}
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Dead man's switch cancelled %d %s orders", count, name)
+		}
+	}
+	return cancelled
+}
+
+// staleData returns why the market data of an exchange is stale for the pairs
+// of its active orders, or an empty string when it is fresh. Pairs without any
+// market data are not checked
+func (m *DeadManSwitch) staleData(exchangeName string, active []order.Detail) string {
+	if m.staleDataTimeout <= 0 {
+		return ""
+	}
+	checked := make(map[string]bool)
+	for i := range active {
+		key := active[i].AssetType.String() + active[i].Pair.String()
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+		updated := lastMarketDataUpdate(exchangeName, active[i].Pair, active[i].AssetType)
+		if updated.IsZero() {
+			continue
+		}
+		if age := time.Since(updated); age > m.staleDataTimeout {
+			return fmt.Sprintf("%s %s market data last updated %v ago", active[i].AssetType, active[i].Pair, age.Truncate(time.Second))
+		}
+	}
+	return ""
+}
+
+// lastMarketDataUpdate returns the latest update time of the ticker or
+// orderbook of a pair
+func lastMarketDataUpdate(exchangeName string, p currency.Pair, a asset.Item) time.Time {
+	var updated time.Time
+	if t, err := ticker.GetTicker(exchangeName, p, a); err == nil {
+		updated = t.LastUpdated
+	}
+	if depth, err := orderbook.GetDepth(exchangeName, p, a); err == nil {
+		if book, err := depth.Retrieve(); err == nil && book.LastUpdated.After(updated) {
+			updated = book.LastUpdated
+		}
+	}
+	return updated
+}
+
+// refreshNative resets the timer of an exchange side dead man's switch for the
+// default credentials and each named account
+func (m *DeadManSwitch) refreshNative(ctx context.Context, exch exchange.IBotExchange) {
+	name := exch.GetName()
+	m.mu.Lock()
+	unsupported := m.nativeUnsupported[name]
+	m.mu.Unlock()
+	if m.nativeTimeout <= 0 || unsupported {
+		return
+	}
+	accounts := exchangeAccountNames(exch)
+	for i := range accounts {
+		accountName := reconciliationBalanceKey(name, accounts[i])
+		err := exch.CancelAllOrdersAfter(account.DeployAccountToContext(ctx, accounts[i]), m.nativeTimeout)
+		switch {
+		case errors.Is(err, common.ErrFunctionNotSupported), errors.Is(err, common.ErrNotYetImplemented):
+			m.mu.Lock()
+			m.nativeUnsupported[name] = true
+			m.mu.Unlock()
+			if m.verbose {
+				log.Debugf(log.OrderMgr, "Dead man's switch: %s does not support cancelling orders after a timeout", name)
+			}
+			return
+		case err != nil:
+			log.Errorf(log.OrderMgr, "Dead man's switch unable to refresh %s timer: %v", accountName, err)
+		case m.verbose:
+			log.Debugf(log.OrderMgr, "Dead man's switch %s orders will be cancelled if not refreshed within %v", accountName, m.nativeTimeout)
+		}
+	}
+}
+
+// disableNative disables the exchange side dead man's switch of each exchange
+// for the default credentials and each named account
+func (m *DeadManSwitch) disableNative(ctx context.Context) {
+	if m.nativeTimeout <= 0 {
+		return
+	}
+	exchanges, err := getAccountExchanges(m.exchangeManager, m.exchanges)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Dead man's switch unable to get exchanges: %v", err)
+		return
+	}
+	for i := range exchanges {
+		m.mu.Lock()
+		unsupported := m.nativeUnsupported[exchanges[i].GetName()]
+		m.mu.Unlock()
+		if unsupported {
+			continue
+		}
+		accounts := exchangeAccountNames(exchanges[i])
+		for j := range accounts {
+			if err := exchanges[i].CancelAllOrdersAfter(account.DeployAccountToContext(ctx, accounts[j]), 0); err != nil &&
+				!errors.Is(err, common.ErrFunctionNotSupported) &&
+				!errors.Is(err, common.ErrNotYetImplemented) {
+				log.Errorf(log.OrderMgr, "Dead man's switch unable to disable %s timer: %v", reconciliationBalanceKey(exchanges[i].GetName(), accounts[j]), err)
+			}
+		}
+	}
+}
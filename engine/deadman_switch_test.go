@@ -0,0 +1,416 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// fDeadManExchange records exchange side dead man's switch timeouts and the
+// accounts orders are cancelled for
+type fDeadManExchange struct {
+	exchange.IBotExchange
+	m         *sync.Mutex
+	accounts  []string
+	timeouts  *[]time.Duration
+	refreshed *[]string
+	cancelled *[]string
+	err       error
+	cancelErr *error
+	// entered and release block cancel all when set
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (f fDeadManExchange) GetAccountNames() []string {
+	return f.accounts
+}
+
+func (f fDeadManExchange) CancelAllOrdersAfter(ctx context.Context, timeout time.Duration) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	*f.timeouts = append(*f.timeouts, timeout)
+	*f.refreshed = append(*f.refreshed, account.GetAccountFromContext(ctx))
+	return f.err
+}
+
+func (f fDeadManExchange) CancelAllOrders(ctx context.Context, _ *order.Cancel) (order.CancelAllResponse, error) {
+	if f.entered != nil {
+		f.entered <- struct{}{}
+		<-f.release
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	*f.cancelled = append(*f.cancelled, account.GetAccountFromContext(ctx))
+	return order.CancelAllResponse{}, *f.cancelErr
+}
+
+func (f fDeadManExchange) getTimeouts() []time.Duration {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]time.Duration(nil), *f.timeouts...)
+}
+
+func (f fDeadManExchange) getRefreshed() []string {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]string(nil), *f.refreshed...)
+}
+
+func (f fDeadManExchange) getCancelled() []string {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]string(nil), *f.cancelled...)
+}
+
+// fDeadManOrders returns static active orders and records cancellations
+type fDeadManOrders struct {
+	m         sync.Mutex
+	active    []order.Detail
+	cancelled []string
+}
+
+func (f *fDeadManOrders) GetOrdersActive(*order.Filter) ([]order.Detail, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]order.Detail(nil), f.active...), nil
+}
+
+func (f *fDeadManOrders) CancelAllOrders(_ context.Context, exchanges []exchange.IBotExchange) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for i := range exchanges {
+		f.cancelled = append(f.cancelled, exchanges[i].GetName())
+	}
+}
+
+func (f *fDeadManOrders) getCancelled() []string {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return append([]string(nil), f.cancelled...)
+}
+
+var errTestDeadMan = errors.New("test dead man's switch error")
+
+// fConnectivity reports a static connectivity state
+type fConnectivity struct {
+	online bool
+}
+
+func (f *fConnectivity) IsOnline() bool {
+	return f.online
+}
+
+func setupDeadManSwitch(t *testing.T, name string, cfg *config.DeadManSwitch, nativeErr error, orders iOrderCanceller, conn iConnectivityChecker, comms iCommsManager, accounts ...string) (*DeadManSwitch, fDeadManExchange) {
+	t.Helper()
+	em := SetupExchangeManager()
+	b, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	b.GetBase().Name = name
+	b.GetBase().Enabled = true
+	b.GetBase().API.AuthenticatedSupport = true
+	err = b.GetBase().CurrencyPairs.SetAssetEnabled(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := fDeadManExchange{
+		IBotExchange: b,
+		m:            new(sync.Mutex),
+		accounts:     accounts,
+		timeouts:     new([]time.Duration),
+		refreshed:    new([]string),
+		cancelled:    new([]string),
+		err:          nativeErr,
+		cancelErr:    new(error),
+	}
+	em.Add(exch)
+	m, err := SetupDeadManSwitch(cfg, em, orders, conn, comms, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return m, exch
+}
+
+func TestSetupDeadManSwitch(t *testing.T) {
+	t.Parallel()
+	_, err := SetupDeadManSwitch(nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilDeadManSwitchConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilDeadManSwitchConfig)
+	}
+	cfg := &config.DeadManSwitch{}
+	_, err = SetupDeadManSwitch(cfg, nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupDeadManSwitch(cfg, SetupExchangeManager(), nil, nil, nil, false)
+	if !errors.Is(err, errNilOrderCanceller) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOrderCanceller)
+	}
+	m, err := SetupDeadManSwitch(cfg, SetupExchangeManager(), &fDeadManOrders{}, nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.interval != DefaultDeadManSwitchCheckInterval {
+		t.Fatalf("received: '%v' but expected: '%v'", m.interval, DefaultDeadManSwitchCheckInterval)
+	}
+}
+
+func TestDeadManSwitchStartStop(t *testing.T) {
+	t.Parallel()
+	var m *DeadManSwitch
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil subsystem to not be running")
+	}
+
+	m, exch := setupDeadManSwitch(t, "DeadManStartStop", &config.DeadManSwitch{
+		CheckInterval: time.Hour,
+		NativeTimeout: time.Minute,
+	}, nil, &fDeadManOrders{}, nil, nil)
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	// The first check refreshes the timer and stopping disables it
+	timeouts := exch.getTimeouts()
+	if len(timeouts) != 2 || timeouts[0] != time.Minute || timeouts[1] != 0 {
+		t.Fatalf("unexpected timeouts %v", timeouts)
+	}
+}
+
+func TestDeadManSwitchHeartbeat(t *testing.T) {
+	t.Parallel()
+	m, _ := setupDeadManSwitch(t, "DeadManHeartbeat", &config.DeadManSwitch{CheckInterval: time.Hour}, nil, &fDeadManOrders{}, nil, nil)
+	_, err := m.Heartbeat()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	m.started = 1
+	_, err = m.Heartbeat()
+	if !errors.Is(err, errHeartbeatDisabled) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errHeartbeatDisabled)
+	}
+	m.heartbeatTimeout = time.Minute
+	m.lastHeartbeat = time.Now().Add(-time.Hour)
+	timeout, err := m.Heartbeat()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if timeout != time.Minute || time.Since(m.lastHeartbeat) > time.Minute {
+		t.Fatalf("unexpected heartbeat %v %v", timeout, m.lastHeartbeat)
+	}
+}
+
+func TestDeadManSwitchCheck(t *testing.T) {
+	t.Parallel()
+	name := "DeadManCheck"
+	pair := currency.NewPair(currency.NewCode("DEADMAN"), currency.USD)
+	orders := &fDeadManOrders{active: []order.Detail{{Exchange: name, OrderID: "1", Pair: pair, AssetType: asset.Spot, Status: order.Active, Amount: 1}}}
+	conn := &fConnectivity{online: true}
+	comms := &fReconcileComms{}
+	m, exch := setupDeadManSwitch(t, name, &config.DeadManSwitch{
+		CheckInterval:    time.Hour,
+		StaleDataTimeout: time.Minute,
+		HeartbeatTimeout: time.Hour,
+		NativeTimeout:    time.Minute,
+	}, nil, orders, conn, comms)
+	m.lastHeartbeat = time.Now()
+
+	m.check(context.Background())
+	if len(orders.getCancelled()) != 0 || len(exch.getTimeouts()) != 1 {
+		t.Fatalf("unexpected healthy check cancelled %v timeouts %v", orders.getCancelled(), exch.getTimeouts())
+	}
+
+	// Orders are cancelled once per trip
+	conn.online = false
+	m.check(context.Background())
+	m.check(context.Background())
+	if cancelled := orders.getCancelled(); len(cancelled) != 1 || cancelled[0] != name {
+		t.Fatalf("unexpected cancellations %v", cancelled)
+	}
+	if cancelled := exch.getCancelled(); len(cancelled) != 1 {
+		t.Fatalf("unexpected exchange cancellations %v", cancelled)
+	}
+	if len(exch.getTimeouts()) != 1 {
+		t.Fatal("expected timer to not be refreshed while offline")
+	}
+	comms.m.Lock()
+	if len(comms.events) != 1 || comms.events[0].Type != "deadman_switch" {
+		t.Errorf("unexpected events %+v", comms.events)
+	}
+	comms.m.Unlock()
+
+	conn.online = true
+	m.check(context.Background())
+	if len(m.tripped) != 0 || len(exch.getTimeouts()) != 2 {
+		t.Fatal("expected switch to reset once online")
+	}
+
+	m.lastHeartbeat = time.Now().Add(-time.Hour * 2)
+	m.check(context.Background())
+	if len(orders.getCancelled()) != 2 || m.tripped[name] == "" {
+		t.Fatalf("expected cancellation after heartbeat timeout %v", orders.getCancelled())
+	}
+	m.lastHeartbeat = time.Now()
+	m.check(context.Background())
+	if len(m.tripped) != 0 {
+		t.Fatal("expected switch to reset after heartbeat")
+	}
+
+	err := ticker.ProcessTicker(&ticker.Price{ExchangeName: name, Pair: pair, AssetType: asset.Spot, Last: 1, LastUpdated: time.Now().Add(-time.Hour)})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.check(context.Background())
+	if len(orders.getCancelled()) != 3 || m.tripped[name] == "" {
+		t.Fatalf("expected cancellation after stale data %v", orders.getCancelled())
+	}
+	err = ticker.ProcessTicker(&ticker.Price{ExchangeName: name, Pair: pair, AssetType: asset.Spot, Last: 1, LastUpdated: time.Now()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.check(context.Background())
+	if len(orders.getCancelled()) != 3 || len(m.tripped) != 0 {
+		t.Fatal("expected switch to reset once data is fresh")
+	}
+}
+
+func TestDeadManSwitchNativeUnsupported(t *testing.T) {
+	t.Parallel()
+	m, exch := setupDeadManSwitch(t, "DeadManUnsupported", &config.DeadManSwitch{
+		CheckInterval: time.Hour,
+		NativeTimeout: time.Minute,
+	}, common.ErrFunctionNotSupported, &fDeadManOrders{}, nil, nil)
+	m.check(context.Background())
+	m.check(context.Background())
+	m.disableNative(context.Background())
+	if timeouts := exch.getTimeouts(); len(timeouts) != 1 {
+		t.Fatalf("unexpected timeouts %v", timeouts)
+	}
+	if !m.nativeUnsupported["DeadManUnsupported"] {
+		t.Fatal("expected exchange to be marked unsupported")
+	}
+}
+
+func TestDeadManSwitchAccounts(t *testing.T) {
+	t.Parallel()
+	orders := &fDeadManOrders{}
+	conn := &fConnectivity{online: true}
+	m, exch := setupDeadManSwitch(t, "DeadManAccounts", &config.DeadManSwitch{
+		CheckInterval: time.Hour,
+		NativeTimeout: time.Minute,
+	}, nil, orders, conn, nil, "main", "hedge")
+
+	m.check(context.Background())
+	if refreshed := exch.getRefreshed(); len(refreshed) != 3 || refreshed[0] != "" || refreshed[1] != "main" || refreshed[2] != "hedge" {
+		t.Fatalf("expected timer to be refreshed for every account, refreshed %v", refreshed)
+	}
+
+	// Cancel all is issued on the exchange even without tracked orders
+	conn.online = false
+	m.check(context.Background())
+	if len(orders.getCancelled()) != 0 {
+		t.Fatalf("unexpected order manager cancellations %v", orders.getCancelled())
+	}
+	if cancelled := exch.getCancelled(); len(cancelled) != 3 || cancelled[0] != "" || cancelled[1] != "main" || cancelled[2] != "hedge" {
+		t.Fatalf("expected orders to be cancelled for every account, cancelled %v", cancelled)
+	}
+
+	m.disableNative(context.Background())
+	timeouts := exch.getTimeouts()
+	if refreshed := exch.getRefreshed(); len(refreshed) != 6 || refreshed[3] != "" || refreshed[4] != "main" || refreshed[5] != "hedge" {
+		t.Fatalf("expected timer to be disabled for every account, refreshed %v", refreshed)
+	}
+	for i := 3; i < len(timeouts); i++ {
+		if timeouts[i] != 0 {
+			t.Fatalf("unexpected disable timeout %v", timeouts[i])
+		}
+	}
+}
+
+func TestDeadManSwitchCancelRetry(t *testing.T) {
+	t.Parallel()
+	conn := &fConnectivity{}
+	m, exch := setupDeadManSwitch(t, "DeadManCancelRetry", &config.DeadManSwitch{CheckInterval: time.Hour}, nil, &fDeadManOrders{}, conn, nil)
+	*exch.cancelErr = errTestDeadMan
+	m.check(context.Background())
+	if !m.cancelFailed["DeadManCancelRetry"] {
+		t.Fatal("expected failed cancellation to be recorded")
+	}
+	*exch.cancelErr = nil
+	m.check(context.Background())
+	m.check(context.Background())
+	if cancelled := exch.getCancelled(); len(cancelled) != 2 {
+		t.Fatalf("expected failed cancellation to be retried once, cancelled %v", cancelled)
+	}
+	if len(m.cancelFailed) != 0 {
+		t.Fatal("expected retry to clear the failed cancellation")
+	}
+}
+
+func TestDeadManSwitchHeartbeatDuringCancel(t *testing.T) {
+	t.Parallel()
+	m, exch := setupDeadManSwitch(t, "DeadManHeartbeatCancel", &config.DeadManSwitch{
+		CheckInterval:    time.Hour,
+		HeartbeatTimeout: time.Minute,
+	}, nil, &fDeadManOrders{}, nil, nil)
+	exch.entered = make(chan struct{})
+	exch.release = make(chan struct{})
+	m.exchangeManager.(*ExchangeManager).Add(exch)
+	m.started = 1
+	m.lastHeartbeat = time.Now().Add(-time.Hour)
+	done := make(chan struct{})
+	go func() {
+		m.check(context.Background())
+		close(done)
+	}()
+	<-exch.entered
+	heartbeat := make(chan error)
+	go func() {
+		_, err := m.Heartbeat()
+		heartbeat <- err
+	}()
+	select {
+	case err := <-heartbeat:
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("expected heartbeat to not be blocked by cancelling orders")
+	}
+	close(exch.release)
+	<-done
+}
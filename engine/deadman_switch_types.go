@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// DeadManSwitchName is an exported subsystem name
+const DeadManSwitchName = "deadman_switch"
+
+// DefaultDeadManSwitchCheckInterval is the default duration between checks
+const DefaultDeadManSwitchCheckInterval = time.Second * 5
+
+var (
+	errNilDeadManSwitchConfig = errors.New("nil dead man's switch config received")
+	errNilOrderCanceller      = errors.New("order manager is required for the dead man's switch")
+	errHeartbeatDisabled      = errors.New("heartbeat timeout is not configured")
+)
+
+// iOrderCanceller limits exposure of the order manager to retrieving and
+// cancelling the orders it tracks
+type iOrderCanceller interface {
+	GetOrdersActive(*order.Filter) ([]order.Detail, error)
+	CancelAllOrders(context.Context, []exchange.IBotExchange)
+}
+
+// iConnectivityChecker limits exposure of the connection manager to whether
+// the engine is online
+type iConnectivityChecker interface {
+	IsOnline() bool
+}
+
+// DeadManSwitch cancels open orders when the engine loses connectivity, the
+// market data of pairs with open orders goes stale or an external controller
+// stops sending heartbeats. Exchange side dead man's switches are refreshed
+// while the engine is healthy so orders are cancelled even if the engine
+// cannot reach the exchange
+type DeadManSwitch struct {
+	started           int32
+	verbose           bool
+	interval          time.Duration
+	staleDataTimeout  time.Duration
+	heartbeatTimeout  time.Duration
+	nativeTimeout     time.Duration
+	exchanges         []string
+	exchangeManager   iExchangeManager
+	orderManager      iOrderCanceller
+	connectionManager iConnectivityChecker
+	commsManager      iCommsManager
+	mu                sync.Mutex
+	lastHeartbeat     time.Time
+	// tripped holds the reason the orders of an exchange were cancelled until
+	// the condition clears
+	tripped map[string]string
+	// cancelFailed holds the tripped exchanges whose orders could not be
+	// cancelled, which are retried on the next check
+	cancelFailed map[string]bool
+	// nativeUnsupported holds the exchanges without an exchange side dead
+	// man's switch
+	nativeUnsupported map[string]bool
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+}
@@ -19,11 +19,14 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/alert"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook/recorder"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	gctlog "github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 )
 
@@ -47,6 +50,15 @@ type Engine struct {
 	WithdrawManager         *WithdrawManager
 	dataHistoryManager      *DataHistoryManager
 	currencyStateManager    *CurrencyStateManager
+	CandleBuilder           *CandleBuilderManager
+	arbitrageManager        *ArbitrageManager
+	rebalanceManager        *RebalanceManager
+	portfolioHistoryManager *PortfolioHistoryManager
+	taxLotManager           *TaxLotManager
+	reconciliationManager   *ReconciliationManager
+	deadManSwitch           *DeadManSwitch
+	metricsManager          *MetricsManager
+	orderbookRecorder       *recorder.Recorder
 	Settings                Settings
 	uptime                  time.Time
 	GRPCShutdownSignal      chan struct{}
@@ -176,6 +188,16 @@ func validateSettings(b *Engine, s *Settings, flagSet FlagSet) {
 	flagSet.WithBool("datahistorymanager", &b.Settings.EnableDataHistoryManager, b.Config.DataHistoryManager.Enabled)
 	flagSet.WithBool("currencystatemanager", &b.Settings.EnableCurrencyStateManager, b.Config.CurrencyStateManager.Enabled != nil && *b.Config.CurrencyStateManager.Enabled)
 	flagSet.WithBool("gctscriptmanager", &b.Settings.EnableGCTScriptManager, b.Config.GCTScript.Enabled)
+	flagSet.WithBool("candlebuilder", &b.Settings.EnableCandleBuilder, b.Config.CandleBuilder.Enabled)
+	flagSet.WithBool("arbitrage", &b.Settings.EnableArbitrageManager, b.Config.Arbitrage.Enabled)
+	flagSet.WithBool("rebalancer", &b.Settings.EnableRebalanceManager, b.Config.Rebalancer.Enabled)
+	flagSet.WithBool("portfoliohistory", &b.Settings.EnablePortfolioHistoryManager, b.Config.PortfolioHistory.Enabled)
+	flagSet.WithBool("taxlots", &b.Settings.EnableTaxLotManager, b.Config.TaxLots.Enabled)
+	flagSet.WithBool("reconciliation", &b.Settings.EnableReconciliationManager, b.Config.Reconciliation.Enabled)
+	flagSet.WithBool("deadmanswitch", &b.Settings.EnableDeadManSwitch, b.Config.DeadManSwitch.Enabled)
+	flagSet.WithBool("metrics", &b.Settings.EnableMetricsManager, b.Config.Metrics.Enabled)
+	flagSet.WithBool("tracing", &b.Settings.EnableTracing, b.Config.Tracing.Enabled)
+	flagSet.WithBool("orderbookrecorder", &b.Settings.EnableOrderbookRecorder, b.Config.OrderbookRecorder.Enabled)
 
 	if b.Settings.EnablePortfolioManager &&
 		b.Settings.PortfolioManagerDelay <= 0 {
@@ -276,6 +298,16 @@ func PrintSettings(s *Settings) {
 	gctlog.Debugf(gctlog.Global, "\t Enable portfolio manager: %v", s.EnablePortfolioManager)
 	gctlog.Debugf(gctlog.Global, "\t Enable data history manager: %v", s.EnableDataHistoryManager)
 	gctlog.Debugf(gctlog.Global, "\t Enable currency state manager: %v", s.EnableCurrencyStateManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable candle builder: %v", s.EnableCandleBuilder)
+	gctlog.Debugf(gctlog.Global, "\t Enable arbitrage manager: %v", s.EnableArbitrageManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable rebalance manager: %v", s.EnableRebalanceManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable portfolio history manager: %v", s.EnablePortfolioHistoryManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable tax lot manager: %v", s.EnableTaxLotManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable reconciliation manager: %v", s.EnableReconciliationManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable dead man's switch: %v", s.EnableDeadManSwitch)
+	gctlog.Debugf(gctlog.Global, "\t Enable metrics manager: %v", s.EnableMetricsManager)
+	gctlog.Debugf(gctlog.Global, "\t Enable tracing: %v", s.EnableTracing)
+	gctlog.Debugf(gctlog.Global, "\t Enable orderbook recorder: %v", s.EnableOrderbookRecorder)
 	gctlog.Debugf(gctlog.Global, "\t Portfolio manager sleep delay: %v\n", s.PortfolioManagerDelay)
 	gctlog.Debugf(gctlog.Global, "\t Enable gPRC: %v", s.EnableGRPC)
 	gctlog.Debugf(gctlog.Global, "\t Enable gRPC Proxy: %v", s.EnableGRPCProxy)
@@ -363,6 +395,12 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnableTracing {
+		if err = tracing.Start(bot.Config.Tracing.Endpoint, bot.Config.Tracing.ServiceName, bot.Config.Tracing.ExportInterval); err != nil {
+			gctlog.Errorf(gctlog.Global, "Tracer unable to start: %v", err)
+		}
+	}
+
 	// Sets up internet connectivity monitor
 	if bot.Settings.EnableConnectivityMonitor {
 		bot.connectionManager, err = setupConnectionManager(&bot.Config.ConnectionMonitor)
@@ -419,6 +457,42 @@ func (bot *Engine) Start() error {
 		bot.Config.PurgeExchangeAPICredentials()
 	}
 
+	if bot.Settings.EnableOrderbookRecorder {
+		// The recorder must be set before exchanges are setup as each
+		// orderbook buffer takes a reference to it when initialised.
+		dir := bot.Config.OrderbookRecorder.Directory
+		if dir == "" {
+			dir = filepath.Join(bot.Settings.DataDir, "orderbooks")
+		}
+		bot.orderbookRecorder, err = recorder.New(&recorder.Config{
+			Directory:        dir,
+			RotationInterval: bot.Config.OrderbookRecorder.RotationInterval,
+			MaxFileSize:      bot.Config.OrderbookRecorder.MaxFileSize,
+			Exchanges:        bot.Config.OrderbookRecorder.Exchanges,
+		})
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise orderbook recorder. Err: %s", err)
+		} else {
+			buffer.SetupGlobalRecorder(bot.orderbookRecorder)
+			gctlog.Debugf(gctlog.Global, "Recording orderbooks to: %s\n", dir)
+		}
+	}
+
+	if bot.Settings.EnableMetricsManager {
+		// The metrics manager must be set before exchanges are setup as each
+		// requester and websocket connection takes a reference to it when
+		// initialised.
+		bot.metricsManager, err = bot.setupMetricsManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise metrics manager. Err: %s", err)
+		} else {
+			err = bot.metricsManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start metrics manager. Err: %s", err)
+			}
+		}
+	}
+
 	gctlog.Debugln(gctlog.Global, "Setting up exchanges..")
 	err = bot.SetupExchanges()
 	if err != nil {
@@ -434,6 +508,10 @@ func (bot *Engine) Start() error {
 			if err != nil {
 				gctlog.Errorf(gctlog.Global, "Communications manager unable to start: %s", err)
 			}
+			err = bot.setupChatCommands()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "Chat commands unable to setup: %s", err)
+			}
 		}
 	}
 
@@ -499,6 +577,9 @@ func (bot *Engine) Start() error {
 		if err != nil {
 			gctlog.Errorf(gctlog.Global, "API Server unable to start: %s", err)
 		} else {
+			if bot.metricsManager != nil {
+				bot.apiServer.metricsServer = bot.metricsManager
+			}
 			if bot.Settings.EnableDeprecatedRPC {
 				err = bot.apiServer.StartRESTServer()
 				if err != nil {
@@ -535,6 +616,14 @@ func (bot *Engine) Start() error {
 		if err != nil {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to setup: %s", err)
 		} else {
+			err = bot.setupOrderPersistence()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "Order manager unable to setup persistence: %s", err)
+			}
+			err = bot.OrderManager.SetRiskLimits(&bot.Config.OrderManager.RiskLimits)
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "Order manager unable to setup risk limits: %s", err)
+			}
 			err = bot.OrderManager.Start()
 			if err != nil {
 				gctlog.Errorf(gctlog.Global, "Order manager unable to start: %s", err)
@@ -597,6 +686,101 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnableCandleBuilder {
+		bot.CandleBuilder, err = SetupCandleBuilderManager(&bot.Config.CandleBuilder, bot.DatabaseManager, bot.Settings.Verbose)
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise candle builder. Err: %s", err)
+		} else {
+			err = bot.websocketRoutineManager.registerWebsocketDataHandler(bot.CandleBuilder.websocketDataHandler, false)
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "Candle builder unable to register websocket data handler. Err: %s", err)
+			}
+			err = bot.CandleBuilder.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start candle builder. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableArbitrageManager {
+		bot.arbitrageManager, err = bot.setupArbitrageManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise arbitrage manager. Err: %s", err)
+		} else {
+			err = bot.arbitrageManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start arbitrage manager. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableRebalanceManager {
+		bot.rebalanceManager, err = bot.setupRebalanceManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise rebalance manager. Err: %s", err)
+		} else {
+			err = bot.rebalanceManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start rebalance manager. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnablePortfolioHistoryManager {
+		bot.portfolioHistoryManager, err = bot.setupPortfolioHistoryManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise portfolio history manager. Err: %s", err)
+		} else {
+			err = bot.portfolioHistoryManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start portfolio history manager. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableTaxLotManager {
+		bot.taxLotManager, err = bot.setupTaxLotManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise tax lot manager. Err: %s", err)
+		} else {
+			err = bot.taxLotManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start tax lot manager. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableReconciliationManager {
+		bot.reconciliationManager, err = bot.setupReconciliationManager()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise reconciliation manager. Err: %s", err)
+		} else {
+			err = bot.reconciliationManager.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start reconciliation manager. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableDeadManSwitch {
+		bot.deadManSwitch, err = bot.setupDeadManSwitch()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Unable to initialise dead man's switch. Err: %s", err)
+		} else {
+			err = bot.deadManSwitch.Start()
+			if err != nil {
+				gctlog.Errorf(gctlog.Global, "failed to start dead man's switch. Err: %s", err)
+			}
+		}
+	}
+
+	if bot.metricsManager != nil {
+		err = bot.setMetricsSources()
+		if err != nil {
+			gctlog.Errorf(gctlog.Global, "Metrics manager unable to register websocket data handler. Err: %s", err)
+		}
+	}
+
 	if bot.Settings.EnableGCTScriptManager {
 		bot.gctScriptManager, err = gctscript.NewManager(&bot.Config.GCTScript)
 		if err != nil {
@@ -700,11 +884,56 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.DispatchMgr, "Dispatch system unable to stop. Error: %v", err)
 		}
 	}
+	if tracing.IsRunning() {
+		if err := tracing.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Tracer unable to stop. Error: %v", err)
+		}
+	}
 	if bot.websocketRoutineManager.IsRunning() {
 		if err := bot.websocketRoutineManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "websocket routine manager unable to stop. Error: %v", err)
 		}
 	}
+	if bot.deadManSwitch.IsRunning() {
+		if err := bot.deadManSwitch.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "dead man's switch unable to stop. Error: %v", err)
+		}
+	}
+	if bot.metricsManager.IsRunning() {
+		if err := bot.metricsManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Metrics manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.reconciliationManager.IsRunning() {
+		if err := bot.reconciliationManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "reconciliation manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.taxLotManager.IsRunning() {
+		if err := bot.taxLotManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "tax lot manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.portfolioHistoryManager.IsRunning() {
+		if err := bot.portfolioHistoryManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "portfolio history manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.rebalanceManager.IsRunning() {
+		if err := bot.rebalanceManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "rebalance manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.arbitrageManager.IsRunning() {
+		if err := bot.arbitrageManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "arbitrage manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.CandleBuilder.IsRunning() {
+		if err := bot.CandleBuilder.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "candle builder unable to stop. Error: %v", err)
+		}
+	}
 	if bot.currencyStateManager.IsRunning() {
 		if err := bot.currencyStateManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global,
@@ -712,6 +941,12 @@ func (bot *Engine) Stop() {
 				err)
 		}
 	}
+	if bot.orderbookRecorder != nil {
+		buffer.SetupGlobalRecorder(nil)
+		if err := bot.orderbookRecorder.Close(); err != nil {
+			gctlog.Errorf(gctlog.Global, "orderbook recorder unable to close. Error: %v", err)
+		}
+	}
 
 	if err := currency.ShutdownStorageUpdater(); err != nil {
 		gctlog.Errorf(gctlog.Global, "ExchangeSettings storage system. Error: %v", err)
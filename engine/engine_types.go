@@ -15,32 +15,42 @@ type Settings struct {
 	CheckParamInteraction bool
 
 	// Core Settings
-	EnableDryRun                bool
-	EnableAllExchanges          bool
-	EnableAllPairs              bool
-	EnableCoinmarketcapAnalysis bool
-	EnablePortfolioManager      bool
-	EnableDataHistoryManager    bool
-	PortfolioManagerDelay       time.Duration
-	EnableGRPC                  bool
-	EnableGRPCProxy             bool
-	EnableGRPCShutdown          bool
-	EnableWebsocketRPC          bool
-	EnableDeprecatedRPC         bool
-	EnableCommsRelayer          bool
-	EnableExchangeSyncManager   bool
-	EnableDepositAddressManager bool
-	EnableEventManager          bool
-	EnableOrderManager          bool
-	EnableConnectivityMonitor   bool
-	EnableDatabaseManager       bool
-	EnableGCTScriptManager      bool
-	EnableNTPClient             bool
-	EnableWebsocketRoutine      bool
-	EnableCurrencyStateManager  bool
-	EventManagerDelay           time.Duration
-	EnableFuturesTracking       bool
-	Verbose                     bool
+	EnableDryRun                  bool
+	EnableAllExchanges            bool
+	EnableAllPairs                bool
+	EnableCoinmarketcapAnalysis   bool
+	EnablePortfolioManager        bool
+	EnableDataHistoryManager      bool
+	PortfolioManagerDelay         time.Duration
+	EnableGRPC                    bool
+	EnableGRPCProxy               bool
+	EnableGRPCShutdown            bool
+	EnableWebsocketRPC            bool
+	EnableDeprecatedRPC           bool
+	EnableCommsRelayer            bool
+	EnableExchangeSyncManager     bool
+	EnableDepositAddressManager   bool
+	EnableEventManager            bool
+	EnableOrderManager            bool
+	EnableConnectivityMonitor     bool
+	EnableDatabaseManager         bool
+	EnableGCTScriptManager        bool
+	EnableNTPClient               bool
+	EnableWebsocketRoutine        bool
+	EnableCurrencyStateManager    bool
+	EnableCandleBuilder           bool
+	EnableArbitrageManager        bool
+	EnableRebalanceManager        bool
+	EnablePortfolioHistoryManager bool
+	EnableTaxLotManager           bool
+	EnableReconciliationManager   bool
+	EnableDeadManSwitch           bool
+	EnableMetricsManager          bool
+	EnableTracing                 bool
+	EnableOrderbookRecorder       bool
+	EventManagerDelay             time.Duration
+	EnableFuturesTracking         bool
+	Verbose                       bool
 
 	// Exchange syncer settings
 	EnableTickerSyncing    bool
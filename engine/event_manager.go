@@ -183,12 +183,42 @@ func (m *eventManager) checkEventCondition(e *Event) error {
 	if e == nil {
 		return errNilEvent
 	}
+	if strings.EqualFold(e.Item, ItemArbitrage) {
+		return errArbitrageEventPushed
+	}
 	if e.Item == ItemPrice {
 		return e.processTicker()
 	}
 	return e.processOrderbook()
 }
 
+// ProcessArbitrageOpportunity triggers any arbitrage events which involve an
+// exchange and pair traded by the opportunity and whose condition is met by
+// the opportunity net profit percentage
+func (m *eventManager) ProcessArbitrageOpportunity(o *ArbitrageOpportunity) {
+	if o == nil || !m.IsRunning() {
+		return
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	for i := range m.events {
+		e := &m.events[i]
+		if e.Executed || !strings.EqualFold(e.Item, ItemArbitrage) || !o.Involves(e.Exchange, e.Pair) {
+			continue
+		}
+		if err := e.shouldProcessEvent(o.NetProfitPercentage, e.Condition.Price); err != nil {
+			continue
+		}
+		msg := fmt.Sprintf(
+			"Events: ID: %d triggered on %s successfully [%v] by %s net profit %f %s (%.4f%%)\n", e.ID,
+			e.Exchange, e.String(), o.key(), o.NetProfit, o.Currency, o.NetProfitPercentage,
+		)
+		log.Infoln(log.EventMgr, msg)
+		m.comms.PushEvent(base.Event{Type: "event", Message: msg})
+		e.Executed = true
+	}
+}
+
 // isValidEvent checks the actions to be taken and returns an error if incorrect
 func (m *eventManager) isValidEvent(exchange, item string, condition EventConditionParams, action string) error {
 	exchange = strings.ToUpper(exchange)
@@ -207,7 +237,7 @@ func (m *eventManager) isValidEvent(exchange, item string, condition EventCondit
 		return errInvalidCondition
 	}
 
-	if item == ItemPrice {
+	if item == ItemPrice || item == ItemArbitrage {
 		if condition.Price <= 0 {
 			return errInvalidCondition
 		}
@@ -251,7 +281,7 @@ func isValidCondition(condition string) bool {
 func isValidItem(item string) bool {
 	item = strings.ToUpper(item)
 	switch item {
-	case ItemPrice, ItemOrderbook:
+	case ItemPrice, ItemOrderbook, ItemArbitrage:
 		return true
 	}
 	return false
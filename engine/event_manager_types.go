@@ -13,6 +13,9 @@ import (
 const (
 	ItemPrice     = "PRICE"
 	ItemOrderbook = "ORDERBOOK"
+	// ItemArbitrage events use the condition price as the net profit
+	// percentage of opportunities published by the arbitrage manager
+	ItemArbitrage = "ARBITRAGE"
 
 	ConditionGreaterThan        = ">"
 	ConditionGreaterThanOrEqual = ">="
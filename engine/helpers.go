@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -23,16 +24,21 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/managedorder"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 var (
@@ -59,8 +65,17 @@ func (bot *Engine) GetSubsystemsStatus() map[string]bool {
 		DeprecatedName:                bot.Settings.EnableDeprecatedRPC,
 		WebsocketName:                 bot.Settings.EnableWebsocketRPC,
 		dispatch.Name:                 dispatch.IsRunning(),
+		tracing.Name:                  tracing.IsRunning(),
 		dataHistoryManagerName:        bot.dataHistoryManager.IsRunning(),
 		CurrencyStateManagementName:   bot.currencyStateManager.IsRunning(),
+		CandleBuilderManagerName:      bot.CandleBuilder.IsRunning(),
+		ArbitrageManagerName:          bot.arbitrageManager.IsRunning(),
+		RebalanceManagerName:          bot.rebalanceManager.IsRunning(),
+		PortfolioHistoryManagerName:   bot.portfolioHistoryManager.IsRunning(),
+		TaxLotManagerName:             bot.taxLotManager.IsRunning(),
+		ReconciliationManagerName:     bot.reconciliationManager.IsRunning(),
+		DeadManSwitchName:             bot.deadManSwitch.IsRunning(),
+		MetricsManagerName:            bot.metricsManager.IsRunning(),
 	}
 }
 
@@ -143,6 +158,14 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 				if err != nil {
 					return err
 				}
+				err = bot.setupOrderPersistence()
+				if err != nil {
+					return err
+				}
+				err = bot.OrderManager.SetRiskLimits(&bot.Config.OrderManager.RiskLimits)
+				if err != nil {
+					return err
+				}
 			}
 			return bot.OrderManager.Start()
 		}
@@ -213,6 +236,11 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 			return dispatch.Start(bot.Settings.DispatchMaxWorkerAmount, bot.Settings.DispatchJobsLimit)
 		}
 		return dispatch.Stop()
+	case tracing.Name:
+		if enable {
+			return tracing.Start(bot.Config.Tracing.Endpoint, bot.Config.Tracing.ServiceName, bot.Config.Tracing.ExportInterval)
+		}
+		return tracing.Stop()
 	case DeprecatedName:
 		if enable {
 			if bot.apiServer == nil {
@@ -226,6 +254,9 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 					return err
 				}
 			}
+			if bot.metricsManager != nil {
+				bot.apiServer.metricsServer = bot.metricsManager
+			}
 			return bot.apiServer.StartRESTServer()
 		}
 		return bot.apiServer.StopRESTServer()
@@ -282,10 +313,256 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 			return bot.currencyStateManager.Start()
 		}
 		return bot.currencyStateManager.Stop()
+	case CandleBuilderManagerName:
+		if enable {
+			if bot.CandleBuilder == nil {
+				var cb *CandleBuilderManager
+				cb, err = SetupCandleBuilderManager(&bot.Config.CandleBuilder, bot.DatabaseManager, bot.Settings.Verbose)
+				if err != nil {
+					return err
+				}
+				err = bot.websocketRoutineManager.registerWebsocketDataHandler(cb.websocketDataHandler, false)
+				if err != nil {
+					return err
+				}
+				bot.CandleBuilder = cb
+			}
+			return bot.CandleBuilder.Start()
+		}
+		return bot.CandleBuilder.Stop()
+	case ArbitrageManagerName:
+		if enable {
+			if bot.arbitrageManager == nil {
+				bot.arbitrageManager, err = bot.setupArbitrageManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.arbitrageManager.Start()
+		}
+		return bot.arbitrageManager.Stop()
+	case RebalanceManagerName:
+		if enable {
+			if bot.rebalanceManager == nil {
+				bot.rebalanceManager, err = bot.setupRebalanceManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.rebalanceManager.Start()
+		}
+		return bot.rebalanceManager.Stop()
+	case PortfolioHistoryManagerName:
+		if enable {
+			if bot.portfolioHistoryManager == nil {
+				bot.portfolioHistoryManager, err = bot.setupPortfolioHistoryManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.portfolioHistoryManager.Start()
+		}
+		return bot.portfolioHistoryManager.Stop()
+	case TaxLotManagerName:
+		if enable {
+			if bot.taxLotManager == nil {
+				bot.taxLotManager, err = bot.setupTaxLotManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.taxLotManager.Start()
+		}
+		return bot.taxLotManager.Stop()
+	case ReconciliationManagerName:
+		if enable {
+			if bot.reconciliationManager == nil {
+				bot.reconciliationManager, err = bot.setupReconciliationManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.reconciliationManager.Start()
+		}
+		return bot.reconciliationManager.Stop()
+	case DeadManSwitchName:
+		if enable {
+			if bot.deadManSwitch == nil {
+				bot.deadManSwitch, err = bot.setupDeadManSwitch()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.deadManSwitch.Start()
+		}
+		return bot.deadManSwitch.Stop()
+	case MetricsManagerName:
+		if enable {
+			if bot.metricsManager == nil {
+				bot.metricsManager, err = bot.setupMetricsManager()
+				if err != nil {
+					return err
+				}
+				err = bot.setMetricsSources()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.metricsManager.Start()
+		}
+		return bot.metricsManager.Stop()
 	}
 	return fmt.Errorf("%s: %w", subSystemName, errSubsystemNotFound)
 }
 
+// setupArbitrageManager creates the arbitrage manager using the order and
+// event managers when they are running
+func (bot *Engine) setupArbitrageManager() (*ArbitrageManager, error) {
+	var orders iOrderSubmitter
+	if bot.OrderManager.IsRunning() {
+		orders = bot.OrderManager
+	}
+	var events iArbitrageEventProcessor
+	if bot.eventManager.IsRunning() {
+		events = bot.eventManager
+	}
+	return SetupArbitrageManager(&bot.Config.Arbitrage, bot.ExchangeManager, orders, events, bot.Settings.Verbose)
+}
+
+// setupRebalanceManager creates the rebalance manager using the portfolio,
+// order, withdraw, deposit address and communications managers when they are
+// available
+func (bot *Engine) setupRebalanceManager() (*RebalanceManager, error) {
+	var portfolios iPortfolioAddressProvider
+	if bot.portfolioManager.IsRunning() {
+		portfolios = bot.portfolioManager
+	}
+	var orders iOrderSubmitter
+	if bot.OrderManager.IsRunning() {
+		orders = bot.OrderManager
+	}
+	var withdrawals iWithdrawSubmitter
+	if bot.WithdrawManager != nil {
+		withdrawals = bot.WithdrawManager
+	}
+	var depositAddresses iDepositAddressProvider
+	if bot.DepositAddressManager != nil {
+		depositAddresses = bot.DepositAddressManager
+	}
+	var comms iCommsManager
+	if bot.CommunicationsManager.IsRunning() {
+		comms = bot.CommunicationsManager
+	}
+	return SetupRebalanceManager(&bot.Config.Rebalancer, bot.ExchangeManager, portfolios, orders, withdrawals, depositAddresses, comms, bot.Settings.Verbose)
+}
+
+// setupPortfolioHistoryManager creates the portfolio history manager using the
+// portfolio manager for wallet holdings when it is running
+func (bot *Engine) setupPortfolioHistoryManager() (*PortfolioHistoryManager, error) {
+	var portfolios iPortfolioAddressProvider
+	if bot.portfolioManager.IsRunning() {
+		portfolios = bot.portfolioManager
+	}
+	var dbManager iDatabaseConnectionManager
+	if bot.DatabaseManager != nil {
+		dbManager = bot.DatabaseManager
+	}
+	return SetupPortfolioHistoryManager(&bot.Config.PortfolioHistory, bot.ExchangeManager, portfolios, dbManager, bot.Settings.Verbose)
+}
+
+// setupTaxLotManager creates the tax lot manager using the order manager for
+// fills of orders executed by the engine when it is running
+func (bot *Engine) setupTaxLotManager() (*TaxLotManager, error) {
+	var orders iOrderSnapshotProvider
+	if bot.OrderManager.IsRunning() {
+		orders = bot.OrderManager
+	}
+	return SetupTaxLotManager(&bot.Config.TaxLots, bot.ExchangeManager, orders, bot.Settings.Verbose)
+}
+
+// setupReconciliationManager creates the reconciliation manager, which
+// requires the order manager to be running
+func (bot *Engine) setupReconciliationManager() (*ReconciliationManager, error) {
+	var orders iOrderSnapshotProvider
+	if bot.OrderManager.IsRunning() {
+		orders = bot.OrderManager
+	}
+	var comms iCommsManager
+	if bot.CommunicationsManager.IsRunning() {
+		comms = bot.CommunicationsManager
+	}
+	return SetupReconciliationManager(&bot.Config.Reconciliation, bot.ExchangeManager, orders, comms, bot.Settings.Verbose)
+}
+
+// setupDeadManSwitch creates the dead man's switch, which requires the order
+// manager to be running. Connectivity is only checked when the connection
+// manager is running
+func (bot *Engine) setupDeadManSwitch() (*DeadManSwitch, error) {
+	var orders iOrderCanceller
+	if bot.OrderManager.IsRunning() {
+		orders = bot.OrderManager
+	}
+	var connection iConnectivityChecker
+	if bot.connectionManager.IsRunning() {
+		connection = bot.connectionManager
+	}
+	var comms iCommsManager
+	if bot.CommunicationsManager.IsRunning() {
+		comms = bot.CommunicationsManager
+	}
+	return SetupDeadManSwitch(&bot.Config.DeadManSwitch, bot.ExchangeManager, orders, connection, comms, bot.Settings.Verbose)
+}
+
+// setupMetricsManager creates the metrics manager and sets it as the global
+// request and stream reporter. Only exchanges setup afterwards are observed
+func (bot *Engine) setupMetricsManager() (*MetricsManager, error) {
+	m, err := SetupMetricsManager(&bot.Config.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	request.SetupGlobalReporter(m)
+	stream.SetupGlobalReporter(m.streamReporter())
+	return m, nil
+}
+
+// setMetricsSources provides the metrics manager with the order manager, sync
+// manager and websocket orderbook updates when they are available
+func (bot *Engine) setMetricsSources() error {
+	var orders iOrderQueueProvider
+	if bot.OrderManager != nil {
+		orders = bot.OrderManager
+	}
+	var syncer iSyncStatusProvider
+	if bot.currencyPairSyncer != nil {
+		syncer = bot.currencyPairSyncer
+	}
+	bot.metricsManager.setSources(orders, syncer)
+	if !bot.websocketRoutineManager.IsRunning() {
+		return nil
+	}
+	return bot.websocketRoutineManager.registerWebsocketDataHandler(bot.metricsManager.websocketDataHandler, false)
+}
+
+// setupOrderPersistence saves and restores managed orders and futures
+// positions via the database when enabled in config
+func (bot *Engine) setupOrderPersistence() error {
+	cfg := &bot.Config.OrderManager.Persistence
+	if !cfg.Enabled {
+		return nil
+	}
+	if !bot.DatabaseManager.IsRunning() {
+		return fmt.Errorf("order persistence %w", database.ErrDatabaseSupportDisabled)
+	}
+	db, err := managedorder.Setup(bot.DatabaseManager.GetInstance())
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return fmt.Errorf("order persistence %w", database.ErrDatabaseNotConnected)
+	}
+	return bot.OrderManager.SetPersistence(db, cfg.RestorePeriod, cfg.PositionSnapshotInterval)
+}
+
 // GetExchangeOTPs returns OTP codes for all exchanges which have a otpsecret
 // stored
 func (bot *Engine) GetExchangeOTPs() (map[string]string, error) {
@@ -962,3 +1239,84 @@ func genCert(targetDir string) error {
 	log.Infof(log.Global, "gRPC TLS key.pem and cert.pem files written to %s\n", targetDir)
 	return nil
 }
+
+// exchangeAccountNames returns the names of the accounts of an exchange,
+// starting with an empty name for the default credentials
+func exchangeAccountNames(exch exchange.IBotExchange) []string {
+	return append([]string{""}, exch.GetAccountNames()...)
+}
+
+// deployExchangeAccount sets a named exchange account to context so its
+// credentials are used, returning an error when the account has not been
+// loaded. An empty name selects the default credentials
+func deployExchangeAccount(ctx context.Context, exch exchange.IBotExchange, accountName string) (context.Context, error) {
+	accountName = account.NormaliseAccountName(accountName)
+	if accountName == "" {
+		return ctx, nil
+	}
+	names := exch.GetAccountNames()
+	for i := range names {
+		if names[i] == accountName {
+			return account.DeployAccountToContext(ctx, accountName), nil
+		}
+	}
+	return nil, fmt.Errorf("%s %w: %s", exch.GetName(), exchange.ErrAccountNotFound, accountName)
+}
+
+// orderAccountContext sets the account an order was placed with to context
+// unless an account has already been selected
+func orderAccountContext(ctx context.Context, accountName string) context.Context {
+	if account.GetAccountFromContext(ctx) != "" {
+		return ctx
+	}
+	return account.DeployAccountToContext(ctx, accountName)
+}
+
+// assetRESTURLs are the REST endpoints which serve an asset type when an
+// exchange defines a separate endpoint for it, otherwise the spot endpoints
+// are used
+var assetRESTURLs = map[asset.Item][]exchange.URL{
+	asset.CoinMarginedFutures: {exchange.RestCoinMargined},
+	asset.USDTMarginedFutures: {exchange.RestUSDTMargined},
+	asset.USDCMarginedFutures: {exchange.RestUSDCMargined},
+	asset.Futures:             {exchange.RestFutures},
+	asset.PerpetualSwap:       {exchange.RestSwap},
+}
+
+// checkExchangeCircuit returns a request.CircuitOpenError if the exchange's
+// REST circuit breaker for the endpoints serving an asset type is rejecting
+// requests
+func checkExchangeCircuit(exch exchange.IBotExchange, a asset.Item) error {
+	b := exch.GetBase()
+	if b == nil {
+		return nil
+	}
+	return b.Requester.CheckCircuit(exchangeAssetHosts(b, a)...)
+}
+
+// exchangeAssetHosts returns the hosts of the REST endpoints an exchange
+// serves an asset type from
+func exchangeAssetHosts(b *exchange.Base, a asset.Item) []string {
+	if b.API.Endpoints == nil {
+		return nil
+	}
+	hosts := func(keys []exchange.URL) []string {
+		var resp []string
+		for i := range keys {
+			u, err := b.API.Endpoints.GetURL(keys[i])
+			if err != nil {
+				continue
+			}
+			parsed, err := url.Parse(u)
+			if err != nil || parsed.Host == "" {
+				continue
+			}
+			resp = append(resp, parsed.Host)
+		}
+		return resp
+	}
+	if resp := hosts(assetRESTURLs[a]); len(resp) > 0 {
+		return resp
+	}
+	return hosts([]exchange.URL{exchange.RestSpot, exchange.RestSpotSupplementary})
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 var testExchange = "Bitstamp"
@@ -97,9 +98,40 @@ func CreateTestBot(t *testing.T) *Engine {
 }
 
 func TestGetSubsystemsStatus(t *testing.T) {
+	expected := []string{
+		CommunicationsManagerName,
+		ConnectionManagerName,
+		OrderManagerName,
+		PortfolioManagerName,
+		NTPManagerName,
+		DatabaseConnectionManagerName,
+		SyncManagerName,
+		grpcName,
+		grpcProxyName,
+		vm.Name,
+		DeprecatedName,
+		WebsocketName,
+		dispatch.Name,
+		tracing.Name,
+		dataHistoryManagerName,
+		CurrencyStateManagementName,
+		CandleBuilderManagerName,
+		ArbitrageManagerName,
+		RebalanceManagerName,
+		PortfolioHistoryManagerName,
+		TaxLotManagerName,
+		ReconciliationManagerName,
+		DeadManSwitchName,
+		MetricsManagerName,
+	}
 	m := (&Engine{}).GetSubsystemsStatus()
-	if len(m) != 15 {
-		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", 15, len(m))
+	if len(m) != len(expected) {
+		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", len(expected), len(m))
+	}
+	for i := range expected {
+		if _, ok := m[expected[i]]; !ok {
+			t.Errorf("subsystem %s status not returned", expected[i])
+		}
 	}
 }
 
@@ -211,6 +243,48 @@ func TestSetSubsystem(t *testing.T) { //nolint // TO-DO: Fix race t.Parallel() u
 			EnableError:  nil,
 			DisableError: nil,
 		},
+		{
+			Subsystem:    CandleBuilderManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    ArbitrageManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNoArbitrageStrategy,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    RebalanceManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNoRebalanceTargets,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    PortfolioHistoryManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNilDatabaseConnectionManager,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    TaxLotManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  nil,
+			DisableError: nil,
+		},
+		{
+			Subsystem:    ReconciliationManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNilOrderSnapshot,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    DeadManSwitchName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNilOrderCanceller,
+			DisableError: ErrNilSubsystem,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -1344,3 +1418,45 @@ func TestCheckAndGenCerts(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDeployExchangeAccount(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	err = exch.GetBase().SetAccountCredentials("alpha", &account.Credentials{Key: "key", Secret: "secret"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if names := exchangeAccountNames(exch); len(names) != 2 || names[0] != "" || names[1] != "alpha" {
+		t.Fatalf("unexpected account names %v", names)
+	}
+
+	ctx, err := deployExchangeAccount(context.Background(), exch, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if acc := account.GetAccountFromContext(ctx); acc != "" {
+		t.Fatalf("received: '%v' but expected: '%v'", acc, "")
+	}
+	_, err = deployExchangeAccount(context.Background(), exch, "beta")
+	if !errors.Is(err, exchange.ErrAccountNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, exchange.ErrAccountNotFound)
+	}
+	ctx, err = deployExchangeAccount(context.Background(), exch, "alpha")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if acc := account.GetAccountFromContext(ctx); acc != "alpha" {
+		t.Fatalf("received: '%v' but expected: '%v'", acc, "alpha")
+	}
+	if acc := account.GetAccountFromContext(orderAccountContext(ctx, "beta")); acc != "alpha" {
+		t.Fatalf("received: '%v' but expected: '%v'", acc, "alpha")
+	}
+	if acc := account.GetAccountFromContext(orderAccountContext(context.Background(), "beta")); acc != "beta" {
+		t.Fatalf("received: '%v' but expected: '%v'", acc, "beta")
+	}
+}
@@ -0,0 +1,537 @@
+package engine
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupMetricsManager creates the metrics manager from config. The manager
+// must be registered as the global request and stream reporter before
+// exchanges are setup for their requests and websocket connections to be
+// observed
+func SetupMetricsManager(cfg *config.Metrics) (*MetricsManager, error) {
+	if cfg == nil {
+		return nil, errNilMetricsConfig
+	}
+	path := cfg.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	return &MetricsManager{
+		path:         path,
+		restLatency:  make(map[restMetricKey]*histogram),
+		restErrors:   make(map[restMetricKey]uint64),
+		rateLimit:    make(map[string]*histogram),
+		wsLatency:    make(map[string]*histogram),
+		wsMessages:   make(map[string]uint64),
+		wsReconnects: make(map[string]uint64),
+		orderEntry:   make(map[orderEntryMetricKey]*histogram),
+		orderErrors:  make(map[orderEntryMetricKey]uint64),
+		orderbookLag: make(map[orderbookMetricKey]float64),
+	}, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *MetricsManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *MetricsManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", MetricsManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", MetricsManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Metrics manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem. Collected metrics are retained so counters remain
+// monotonic if the subsystem is restarted
+func (m *MetricsManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", MetricsManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", MetricsManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Metrics manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetPath returns the REST API server path metrics are served on
+func (m *MetricsManager) GetPath() string {
+	return m.path
+}
+
+// setSources sets the order manager and sync manager sampled when metrics are
+// served, either of which may be nil
+func (m *MetricsManager) setSources(orders iOrderQueueProvider, syncer iSyncStatusProvider) {
+	m.mu.Lock()
+	m.orderManager = orders
+	m.syncManager = syncer
+	m.mu.Unlock()
+}
+
+// Latency records the latency of a successful exchange REST request
+func (m *MetricsManager) Latency(name, method, path string, t time.Duration) {
+	if !m.IsRunning() {
+		return
+	}
+	key := restMetricKey{exchange: name, method: method, endpoint: restEndpoint(path)}
+	m.mu.Lock()
+	h, ok := m.restLatency[key]
+	if !ok {
+		h = newHistogram()
+		m.restLatency[key] = h
+	}
+	h.observe(t.Seconds())
+	m.mu.Unlock()
+}
+
+// RequestError records a failed exchange REST request attempt
+func (m *MetricsManager) RequestError(name, method, path string, _ error) {
+	if !m.IsRunning() {
+		return
+	}
+	key := restMetricKey{exchange: name, method: method, endpoint: restEndpoint(path)}
+	m.mu.Lock()
+	m.restErrors[key]++
+	m.mu.Unlock()
+}
+
+// RateLimitWait records the time an exchange REST request waited on the rate
+// limiter
+func (m *MetricsManager) RateLimitWait(name string, t time.Duration) {
+	if !m.IsRunning() {
+		return
+	}
+	m.mu.Lock()
+	h, ok := m.rateLimit[name]
+	if !ok {
+		h = newHistogram()
+		m.rateLimit[name] = h
+	}
+	h.observe(t.Seconds())
+	m.mu.Unlock()
+}
+
+// streamReporter returns the reporter which records websocket metrics
+func (m *MetricsManager) streamReporter() stream.Reporter {
+	return &streamMetricsReporter{m: m}
+}
+
+// Latency records the latency of a websocket request and its response
+func (r *streamMetricsReporter) Latency(name string, _ []byte, t time.Duration) {
+	if !r.m.IsRunning() {
+		return
+	}
+	r.m.mu.Lock()
+	h, ok := r.m.wsLatency[name]
+	if !ok {
+		h = newHistogram()
+		r.m.wsLatency[name] = h
+	}
+	h.observe(t.Seconds())
+	r.m.mu.Unlock()
+}
+
+// Message records a received websocket message
+func (r *streamMetricsReporter) Message(name string) {
+	if !r.m.IsRunning() {
+		return
+	}
+	r.m.mu.Lock()
+	r.m.wsMessages[name]++
+	r.m.mu.Unlock()
+}
+
+// Reconnect records a websocket reconnection
+func (r *streamMetricsReporter) Reconnect(name string) {
+	if !r.m.IsRunning() {
+		return
+	}
+	r.m.mu.Lock()
+	r.m.wsReconnects[name]++
+	r.m.mu.Unlock()
+}
+
+// OrderEntry records the latency of an order management request by transport,
+// allowing websocket and REST order entry to be compared
+func (r *streamMetricsReporter) OrderEntry(name, action, transport string, t time.Duration, err error) {
+	if !r.m.IsRunning() {
+		return
+	}
+	key := orderEntryMetricKey{exchange: name, action: action, transport: transport}
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	if err != nil {
+		r.m.orderErrors[key]++
+		return
+	}
+	h, ok := r.m.orderEntry[key]
+	if !ok {
+		h = newHistogram()
+		r.m.orderEntry[key] = h
+	}
+	h.observe(t.Seconds())
+}
+
+// websocketDataHandler records the lag between an orderbook update being
+// stamped and it being processed
+func (m *MetricsManager) websocketDataHandler(exchName string, data interface{}) error {
+	depth, ok := data.(*orderbook.Depth)
+	if !ok || !m.IsRunning() {
+		return nil
+	}
+	lastUpdated, err := depth.LastUpdated()
+	if err != nil || lastUpdated.IsZero() {
+		return nil //nolint:nilerr // invalid books are reported by the default handler
+	}
+	a, p := depth.GetAssetAndPair()
+	key := orderbookMetricKey{exchange: exchName, asset: a, pair: p.String()}
+	m.mu.Lock()
+	m.orderbookLag[key] = time.Since(lastUpdated).Seconds()
+	m.mu.Unlock()
+	return nil
+}
+
+// ServeHTTP serves the collected metrics in the OpenMetrics text format
+func (m *MetricsManager) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !m.IsRunning() {
+		http.Error(w, fmt.Sprintf("%s %v", MetricsManagerName, ErrSubSystemNotStarted), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", openMetricsContentType)
+	if _, err := w.Write(m.collect(time.Now())); err != nil {
+		log.Errorf(log.Global, "Metrics manager unable to write metrics: %v", err)
+	}
+}
+
+// collect renders all metrics in the OpenMetrics text format
+func (m *MetricsManager) collect(now time.Time) []byte {
+	w := &metricWriter{}
+	m.mu.Lock()
+	restKeys := make([]restMetricKey, 0, len(m.restLatency))
+	for k := range m.restLatency {
+		restKeys = append(restKeys, k)
+	}
+	sortRESTMetricKeys(restKeys)
+	w.family("gct_rest_request_duration_seconds", "histogram", "Latency of successful exchange REST requests.")
+	for _, k := range restKeys {
+		w.histogram("gct_rest_request_duration_seconds", m.restLatency[k], "exchange", k.exchange, "method", k.method, "endpoint", k.endpoint)
+	}
+
+	restKeys = restKeys[:0]
+	for k := range m.restErrors {
+		restKeys = append(restKeys, k)
+	}
+	sortRESTMetricKeys(restKeys)
+	w.family("gct_rest_request_errors", "counter", "Failed exchange REST request attempts, including unsuccessful status codes.")
+	for _, k := range restKeys {
+		w.sample("gct_rest_request_errors_total", formatUint(m.restErrors[k]), "exchange", k.exchange, "method", k.method, "endpoint", k.endpoint)
+	}
+
+	w.family("gct_rate_limiter_wait_seconds", "histogram", "Time exchange REST requests waited on the rate limiter.")
+	for _, k := range sortedHistogramKeys(m.rateLimit) {
+		w.histogram("gct_rate_limiter_wait_seconds", m.rateLimit[k], "exchange", k)
+	}
+
+	w.family("gct_websocket_request_duration_seconds", "histogram", "Latency of exchange websocket requests and their responses.")
+	for _, k := range sortedHistogramKeys(m.wsLatency) {
+		w.histogram("gct_websocket_request_duration_seconds", m.wsLatency[k], "exchange", k)
+	}
+
+	w.family("gct_websocket_messages", "counter", "Exchange websocket messages received.")
+	for _, k := range sortedCounterKeys(m.wsMessages) {
+		w.sample("gct_websocket_messages_total", formatUint(m.wsMessages[k]), "exchange", k)
+	}
+
+	w.family("gct_websocket_reconnects", "counter", "Exchange websocket reconnections.")
+	for _, k := range sortedCounterKeys(m.wsReconnects) {
+		w.sample("gct_websocket_reconnects_total", formatUint(m.wsReconnects[k]), "exchange", k)
+	}
+
+	orderKeys := make([]orderEntryMetricKey, 0, len(m.orderEntry))
+	for k := range m.orderEntry {
+		orderKeys = append(orderKeys, k)
+	}
+	sortOrderEntryMetricKeys(orderKeys)
+	w.family("gct_order_entry_duration_seconds", "histogram", "Latency of successful exchange order management requests by transport.")
+	for _, k := range orderKeys {
+		w.histogram("gct_order_entry_duration_seconds", m.orderEntry[k], "exchange", k.exchange, "action", k.action, "transport", k.transport)
+	}
+
+	orderKeys = orderKeys[:0]
+	for k := range m.orderErrors {
+		orderKeys = append(orderKeys, k)
+	}
+	sortOrderEntryMetricKeys(orderKeys)
+	w.family("gct_order_entry_errors", "counter", "Failed exchange order management requests by transport.")
+	for _, k := range orderKeys {
+		w.sample("gct_order_entry_errors_total", formatUint(m.orderErrors[k]), "exchange", k.exchange, "action", k.action, "transport", k.transport)
+	}
+
+	bookKeys := make([]orderbookMetricKey, 0, len(m.orderbookLag))
+	for k := range m.orderbookLag {
+		bookKeys = append(bookKeys, k)
+	}
+	sort.Slice(bookKeys, func(i, j int) bool {
+		if bookKeys[i].exchange != bookKeys[j].exchange {
+			return bookKeys[i].exchange < bookKeys[j].exchange
+		}
+		if bookKeys[i].asset != bookKeys[j].asset {
+			return bookKeys[i].asset < bookKeys[j].asset
+		}
+		return bookKeys[i].pair < bookKeys[j].pair
+	})
+	w.family("gct_orderbook_update_lag_seconds", "gauge", "Time between the last websocket orderbook update being stamped and processed.")
+	for _, k := range bookKeys {
+		w.sample("gct_orderbook_update_lag_seconds", formatFloat(m.orderbookLag[k]), "exchange", k.exchange, "asset", k.asset.String(), "pair", k.pair)
+	}
+	orders, syncer := m.orderManager, m.syncManager
+	m.mu.Unlock()
+
+	if orders != nil {
+		if stats, err := orders.GetQueueStats(); err == nil {
+			w.writeOrderQueueStats(stats)
+		}
+	}
+	if syncer != nil {
+		if statuses, err := syncer.GetSyncStatuses(); err == nil {
+			w.writeSyncStaleness(statuses, now)
+		}
+	}
+	w.buf = append(w.buf, "# EOF\n"...)
+	return w.buf
+}
+
+// writeOrderQueueStats writes the order manager queue sizes
+func (w *metricWriter) writeOrderQueueStats(stats *OrderQueueStats) {
+	exchanges := make([]string, 0, len(stats.Tracked))
+	for k := range stats.Tracked {
+		exchanges = append(exchanges, k)
+	}
+	sort.Strings(exchanges)
+	w.family("gct_order_manager_tracked_orders", "gauge", "Orders tracked by the order manager.")
+	for _, k := range exchanges {
+		w.sample("gct_order_manager_tracked_orders", strconv.Itoa(stats.Tracked[k]), "exchange", k)
+	}
+	w.family("gct_order_manager_active_orders", "gauge", "Active orders tracked by the order manager.")
+	for _, k := range exchanges {
+		w.sample("gct_order_manager_active_orders", strconv.Itoa(stats.Active[k]), "exchange", k)
+	}
+	w.family("gct_order_manager_pending_persistence_orders", "gauge", "Changed orders waiting to be saved to the database.")
+	w.sample("gct_order_manager_pending_persistence_orders", strconv.Itoa(stats.PendingPersistence))
+}
+
+// writeSyncStaleness writes the time since each synced item of a pair was last
+// updated
+func (w *metricWriter) writeSyncStaleness(statuses []PairSyncStatus, now time.Time) {
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Exchange != statuses[j].Exchange {
+			return statuses[i].Exchange < statuses[j].Exchange
+		}
+		if statuses[i].Asset != statuses[j].Asset {
+			return statuses[i].Asset < statuses[j].Asset
+		}
+		return statuses[i].Pair.String() < statuses[j].Pair.String()
+	})
+	w.family("gct_sync_staleness_seconds", "gauge", "Time since the sync manager last updated a pair.")
+	for i := range statuses {
+		items := []struct {
+			name        string
+			lastUpdated time.Time
+		}{
+			{"ticker", statuses[i].Ticker},
+			{"orderbook", statuses[i].Orderbook},
+			{"trade", statuses[i].Trade},
+		}
+		for _, item := range items {
+			if item.lastUpdated.IsZero() {
+				continue
+			}
+			w.sample("gct_sync_staleness_seconds",
+				formatFloat(now.Sub(item.lastUpdated).Seconds()),
+				"exchange", statuses[i].Exchange,
+				"asset", statuses[i].Asset.String(),
+				"pair", statuses[i].Pair.String(),
+				"item", item.name)
+		}
+	}
+}
+
+// family writes the type and help metadata of a metric family
+func (w *metricWriter) family(name, metricType, help string) {
+	w.buf = append(w.buf, "# TYPE "...)
+	w.buf = append(w.buf, name...)
+	w.buf = append(w.buf, ' ')
+	w.buf = append(w.buf, metricType...)
+	w.buf = append(w.buf, "\n# HELP "...)
+	w.buf = append(w.buf, name...)
+	w.buf = append(w.buf, ' ')
+	w.buf = append(w.buf, help...)
+	w.buf = append(w.buf, '\n')
+}
+
+// sample writes a sample with its labels, supplied as alternating names and
+// values
+func (w *metricWriter) sample(name, value string, labels ...string) {
+	w.buf = append(w.buf, name...)
+	if len(labels) > 1 {
+		w.buf = append(w.buf, '{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				w.buf = append(w.buf, ',')
+			}
+			w.buf = append(w.buf, labels[i]...)
+			w.buf = append(w.buf, `="`...)
+			w.buf = append(w.buf, escapeLabelValue(labels[i+1])...)
+			w.buf = append(w.buf, '"')
+		}
+		w.buf = append(w.buf, '}')
+	}
+	w.buf = append(w.buf, ' ')
+	w.buf = append(w.buf, value...)
+	w.buf = append(w.buf, '\n')
+}
+
+// histogram writes the cumulative buckets, sum and count of a histogram
+func (w *metricWriter) histogram(name string, h *histogram, labels ...string) {
+	bucketLabels := make([]string, len(labels), len(labels)+2)
+	copy(bucketLabels, labels)
+	bucketLabels = append(bucketLabels, "le", "")
+	var cumulative uint64
+	for i := range latencyBuckets {
+		cumulative += h.counts[i]
+		bucketLabels[len(bucketLabels)-1] = formatFloat(latencyBuckets[i])
+		w.sample(name+"_bucket", formatUint(cumulative), bucketLabels...)
+	}
+	bucketLabels[len(bucketLabels)-1] = "+Inf"
+	w.sample(name+"_bucket", formatUint(h.count), bucketLabels...)
+	w.sample(name+"_sum", formatFloat(h.sum), labels...)
+	w.sample(name+"_count", formatUint(h.count), labels...)
+}
+
+// newHistogram returns a histogram with latencyBuckets
+func newHistogram() *histogram {
+	return &histogram{counts: make([]uint64, len(latencyBuckets))}
+}
+
+// observe counts a value into the first bucket it does not exceed
+func (h *histogram) observe(v float64) {
+	for i := range latencyBuckets {
+		if v <= latencyBuckets[i] {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// restEndpoint returns the host and path of a request URL without its query.
+// Path segments which look like identifiers are replaced to bound the number
+// of series
+func restEndpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		if i := strings.IndexByte(rawURL, '?'); i >= 0 {
+			return rawURL[:i]
+		}
+		return rawURL
+	}
+	segments := strings.Split(u.Path, "/")
+	for i := range segments {
+		if isIdentifierSegment(segments[i]) {
+			segments[i] = ":id"
+		}
+	}
+	return u.Host + strings.Join(segments, "/")
+}
+
+// isIdentifierSegment returns whether a path segment is numeric or is long and
+// contains digits, such as an order ID or UUID
+func isIdentifierSegment(s string) bool {
+	var digits int
+	for i := range s {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits++
+		}
+	}
+	return digits > 0 && (digits == len(s) || len(s) >= 16)
+}
+
+// escapeLabelValue escapes backslashes, double quotes and line feeds
+func escapeLabelValue(s string) string {
+	if !strings.ContainsAny(s, "\\\"\n") {
+		return s
+	}
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func formatUint(u uint64) string {
+	return strconv.FormatUint(u, 10)
+}
+
+func sortRESTMetricKeys(keys []restMetricKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].exchange != keys[j].exchange {
+			return keys[i].exchange < keys[j].exchange
+		}
+		if keys[i].endpoint != keys[j].endpoint {
+			return keys[i].endpoint < keys[j].endpoint
+		}
+		return keys[i].method < keys[j].method
+	})
+}
+
+func sortOrderEntryMetricKeys(keys []orderEntryMetricKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].exchange != keys[j].exchange {
+			return keys[i].exchange < keys[j].exchange
+		}
+		if keys[i].action != keys[j].action {
+			return keys[i].action < keys[j].action
+		}
+		return keys[i].transport < keys[j].transport
+	})
+}
+
+func sortedHistogramKeys(m map[string]*histogram) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCounterKeys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+)
+
+type fakeOrderQueueProvider struct{}
+
+func (fakeOrderQueueProvider) GetQueueStats() (*OrderQueueStats, error) {
+	return &OrderQueueStats{
+		Tracked:            map[string]int{"binance": 3},
+		Active:             map[string]int{"binance": 1},
+		PendingPersistence: 2,
+	}, nil
+}
+
+type fakeSyncStatusProvider struct {
+	lastUpdated time.Time
+}
+
+func (f fakeSyncStatusProvider) GetSyncStatuses() ([]PairSyncStatus, error) {
+	return []PairSyncStatus{{
+		Exchange: "binance",
+		Asset:    asset.Spot,
+		Pair:     currency.NewPair(currency.BTC, currency.USDT),
+		Ticker:   f.lastUpdated,
+	}}, nil
+}
+
+func TestSetupMetricsManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupMetricsManager(nil)
+	if !errors.Is(err, errNilMetricsConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilMetricsConfig)
+	}
+	m, err := SetupMetricsManager(&config.Metrics{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.GetPath() != "/metrics" {
+		t.Errorf("received: '%v' but expected: '%v'", m.GetPath(), "/metrics")
+	}
+}
+
+func TestMetricsManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *MetricsManager
+	if m.IsRunning() {
+		t.Error("expected nil metrics manager to not be running")
+	}
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, err = SetupMetricsManager(&config.Metrics{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !m.IsRunning() {
+		t.Error("expected metrics manager to be running")
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestMetricsManagerReporters(t *testing.T) {
+	t.Parallel()
+	m, err := SetupMetricsManager(&config.Metrics{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ws := m.streamReporter().(*streamMetricsReporter)
+
+	// Nothing is recorded while stopped
+	m.Latency("binance", http.MethodGet, "https://api.binance.com/api/v3/ticker", time.Millisecond)
+	ws.Message("binance")
+	if len(m.restLatency) != 0 || len(m.wsMessages) != 0 {
+		t.Fatal("expected no metrics to be recorded when stopped")
+	}
+
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.Latency("binance", http.MethodGet, "https://api.binance.com/api/v3/order/123456?symbol=BTCUSDT", 20*time.Millisecond)
+	m.Latency("binance", http.MethodGet, "https://api.binance.com/api/v3/order/654321", 20*time.Second)
+	m.RequestError("binance", http.MethodPost, "https://api.binance.com/api/v3/order", errors.New("test"))
+	m.RateLimitWait("binance", time.Millisecond)
+	ws.Latency("binance", nil, 300*time.Millisecond)
+	ws.Message("binance")
+	ws.Message("binance")
+	ws.Reconnect("binance")
+	ws.OrderEntry("binance", stream.SubmitOrderAction, stream.WebsocketTransport, 5*time.Millisecond, nil)
+	ws.OrderEntry("binance", stream.SubmitOrderAction, stream.RESTTransport, 60*time.Millisecond, nil)
+	ws.OrderEntry("binance", stream.CancelOrderAction, stream.WebsocketTransport, time.Millisecond, errors.New("test"))
+	m.setSources(fakeOrderQueueProvider{}, fakeSyncStatusProvider{lastUpdated: time.Unix(100, 0)})
+
+	out := string(m.collect(time.Unix(130, 0)))
+	for _, expected := range []string{
+		"# TYPE gct_rest_request_duration_seconds histogram\n",
+		`gct_rest_request_duration_seconds_bucket{exchange="binance",method="GET",endpoint="api.binance.com/api/v3/order/:id",le="0.025"} 1` + "\n",
+		`gct_rest_request_duration_seconds_bucket{exchange="binance",method="GET",endpoint="api.binance.com/api/v3/order/:id",le="+Inf"} 2` + "\n",
+		`gct_rest_request_duration_seconds_count{exchange="binance",method="GET",endpoint="api.binance.com/api/v3/order/:id"} 2` + "\n",
+		"# TYPE gct_rest_request_errors counter\n",
+		`gct_rest_request_errors_total{exchange="binance",method="POST",endpoint="api.binance.com/api/v3/order"} 1` + "\n",
+		`gct_rate_limiter_wait_seconds_count{exchange="binance"} 1` + "\n",
+		`gct_websocket_request_duration_seconds_bucket{exchange="binance",le="0.5"} 1` + "\n",
+		`gct_websocket_messages_total{exchange="binance"} 2` + "\n",
+		`gct_websocket_reconnects_total{exchange="binance"} 1` + "\n",
+		`gct_order_entry_duration_seconds_bucket{exchange="binance",action="submit",transport="websocket",le="0.005"} 1` + "\n",
+		`gct_order_entry_duration_seconds_bucket{exchange="binance",action="submit",transport="rest",le="0.05"} 0` + "\n",
+		`gct_order_entry_duration_seconds_count{exchange="binance",action="submit",transport="rest"} 1` + "\n",
+		`gct_order_entry_errors_total{exchange="binance",action="cancel",transport="websocket"} 1` + "\n",
+		`gct_order_manager_tracked_orders{exchange="binance"} 3` + "\n",
+		`gct_order_manager_active_orders{exchange="binance"} 1` + "\n",
+		"gct_order_manager_pending_persistence_orders 2\n",
+		`gct_sync_staleness_seconds{exchange="binance",asset="spot",pair="BTCUSDT",item="ticker"} 30` + "\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q", expected)
+		}
+	}
+	if strings.Contains(out, `item="orderbook"`) {
+		t.Error("expected unsynced items to be omitted")
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Error("expected output to end with EOF marker")
+	}
+}
+
+func TestMetricsManagerWebsocketDataHandler(t *testing.T) {
+	t.Parallel()
+	m, err := SetupMetricsManager(&config.Metrics{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.websocketDataHandler("binance", "not a book")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	d := orderbook.NewDepth(uuid.Must(uuid.NewV4()))
+	d.AssignOptions(&orderbook.Base{Asset: asset.Spot, Pair: currency.NewPair(currency.BTC, currency.USDT)})
+	d.LoadSnapshot([]orderbook.Item{{Price: 1, Amount: 1}}, []orderbook.Item{{Price: 2, Amount: 1}}, 0, time.Now().Add(-time.Second), false)
+	err = m.websocketDataHandler("binance", d)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	lag, ok := m.orderbookLag[orderbookMetricKey{exchange: "binance", asset: asset.Spot, pair: "BTCUSDT"}]
+	if !ok {
+		t.Fatal("expected orderbook lag to be recorded")
+	}
+	if lag < 1 {
+		t.Errorf("received: '%v' but expected at least: '%v'", lag, 1)
+	}
+}
+
+func TestMetricsManagerServeHTTP(t *testing.T) {
+	t.Parallel()
+	m, err := SetupMetricsManager(&config.Metrics{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != openMetricsContentType {
+		t.Errorf("received: '%v' but expected: '%v'", ct, openMetricsContentType)
+	}
+}
+
+func TestRestEndpoint(t *testing.T) {
+	t.Parallel()
+	for input, expected := range map[string]string{
+		"https://api.binance.com/api/v3/ticker?symbol=BTCUSDT":                    "api.binance.com/api/v3/ticker",
+		"https://api.exchange.com/v2/orders/123456":                               "api.exchange.com/v2/orders/:id",
+		"https://api.exchange.com/v2/orders/3f2b8c1e-5a4d-4e8f-9b7a-1c2d3e4f5a6b": "api.exchange.com/v2/orders/:id",
+		"https://api.exchange.com/v2/markets/BTC-USD":                             "api.exchange.com/v2/markets/BTC-USD",
+		"https://api.exchange.com/v1/pubticker/btcusd":                            "api.exchange.com/v1/pubticker/btcusd",
+	} {
+		if received := restEndpoint(input); received != expected {
+			t.Errorf("received: '%v' but expected: '%v'", received, expected)
+		}
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	t.Parallel()
+	if received := escapeLabelValue("a\\b\"c\nd"); received != `a\\b\"c\nd` {
+		t.Errorf("received: '%v' but expected: '%v'", received, `a\\b\"c\nd`)
+	}
+}
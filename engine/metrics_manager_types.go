@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// MetricsManagerName is an exported subsystem name
+const MetricsManagerName = "metrics_manager"
+
+// openMetricsContentType is the content type of the OpenMetrics text format
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+var (
+	errNilMetricsConfig = errors.New("nil metrics config received")
+
+	// latencyBuckets are the upper bounds in seconds of the latency
+	// histograms
+	latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+)
+
+// iOrderQueueProvider limits exposure of the order manager to its queue sizes
+type iOrderQueueProvider interface {
+	GetQueueStats() (*OrderQueueStats, error)
+}
+
+// iSyncStatusProvider limits exposure of the sync manager to when pairs were
+// last synced
+type iSyncStatusProvider interface {
+	GetSyncStatuses() ([]PairSyncStatus, error)
+}
+
+// MetricsManager collects exchange REST, rate limiter, websocket, order entry
+// and orderbook metrics via the request and stream reporters and serves them,
+// along with
+// order manager queue sizes and sync manager staleness, in the OpenMetrics
+// text format
+type MetricsManager struct {
+	started      int32
+	path         string
+	mu           sync.Mutex
+	restLatency  map[restMetricKey]*histogram
+	restErrors   map[restMetricKey]uint64
+	rateLimit    map[string]*histogram
+	wsLatency    map[string]*histogram
+	wsMessages   map[string]uint64
+	wsReconnects map[string]uint64
+	orderEntry   map[orderEntryMetricKey]*histogram
+	orderErrors  map[orderEntryMetricKey]uint64
+	orderbookLag map[orderbookMetricKey]float64
+	orderManager iOrderQueueProvider
+	syncManager  iSyncStatusProvider
+}
+
+// streamMetricsReporter adapts the metrics manager to the stream reporter
+// interfaces, as their latency signature differs from the request reporter
+type streamMetricsReporter struct {
+	m *MetricsManager
+}
+
+// restMetricKey identifies the metrics of an exchange REST endpoint
+type restMetricKey struct {
+	exchange string
+	method   string
+	endpoint string
+}
+
+// orderEntryMetricKey identifies the metrics of an exchange order management
+// action sent over a transport
+type orderEntryMetricKey struct {
+	exchange  string
+	action    string
+	transport string
+}
+
+// orderbookMetricKey identifies the metrics of an exchange orderbook
+type orderbookMetricKey struct {
+	exchange string
+	asset    asset.Item
+	pair     string
+}
+
+// histogram counts observations into latencyBuckets
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// metricWriter writes metric families in the OpenMetrics text format
+type metricWriter struct {
+	buf []byte
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync"
@@ -16,9 +17,11 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 // SetupOrderManager will boot up the OrderManager
@@ -72,6 +75,10 @@ func (m *OrderManager) Start() error {
 	}
 	log.Debugln(log.OrderMgr, "Order manager starting...")
 	m.shutdown = make(chan struct{})
+	if restored := m.restoreOrders(); len(restored) > 0 {
+		m.orderStore.wg.Add(1)
+		go m.reconcileOrders(restored)
+	}
 	m.orderStore.wg.Add(1)
 	go m.run()
 	return nil
@@ -113,6 +120,8 @@ func (m *OrderManager) run() {
 		select {
 		case <-m.shutdown:
 			m.gracefulShutdown()
+			m.persistOrders()
+			m.snapshotPositions(true)
 			if !timer.Stop() {
 				<-timer.C
 			}
@@ -122,6 +131,8 @@ func (m *OrderManager) run() {
 		case <-timer.C:
 			// Process orders go routine allows shutdown procedures to continue
 			go m.processOrders()
+			m.persistOrders()
+			m.snapshotPositions(false)
 			timer.Reset(orderManagerDelay)
 		}
 	}
@@ -203,6 +214,9 @@ func (m *OrderManager) Cancel(ctx context.Context, cancel *order.Cancel) error {
 	log.Debugf(log.OrderMgr, "Cancelling order ID %v [%+v]",
 		cancel.OrderID, cancel)
 
+	if stored, getErr := m.orderStore.getByExchangeAndID(cancel.Exchange, cancel.OrderID); getErr == nil {
+		ctx = orderAccountContext(ctx, stored.Account)
+	}
 	err = exch.CancelOrder(ctx, cancel)
 	if err != nil {
 		err = fmt.Errorf("%v - Failed to cancel order: %w", cancel.Exchange, err)
@@ -332,6 +346,7 @@ func (m *OrderManager) GetOrderInfo(ctx context.Context, exchangeName, orderID s
 	if err != nil {
 		return order.Detail{}, err
 	}
+	result.Account = account.GetAccountFromContext(ctx)
 
 	upsertResponse, err := m.orderStore.upsert(&result)
 	if err != nil {
@@ -413,6 +428,23 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 	if err != nil {
 		return nil, err
 	}
+
+	// Modifications are risk checked as an order for the remaining amount at
+	// the new price, replacing the existing order
+	ctx = orderAccountContext(ctx, det.Account)
+	err = m.checkRiskLimits(ctx, exch, &order.Submit{
+		Exchange:  det.Exchange,
+		Pair:      det.Pair,
+		AssetType: det.AssetType,
+		Side:      det.Side,
+		Type:      det.Type,
+		Amount:    math.Max(mod.Amount-det.ExecutedAmount, 0),
+		Price:     mod.Price,
+	}, det.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := exch.ModifyOrder(ctx, mod)
 	if err != nil {
 		message := fmt.Sprintf(
@@ -457,6 +489,16 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "OrderManager.Submit", orderSpanAttributes(newOrder)...)
+	resp, err := m.submit(ctx, newOrder)
+	span.RecordError(err)
+	span.End()
+	return resp, err
+}
+
+// submit validates and risk checks an order before submitting it to the
+// exchange
+func (m *OrderManager) submit(ctx context.Context, newOrder *order.Submit) (*OrderSubmitResponse, error) {
 	err := m.validate(newOrder)
 	if err != nil {
 		return nil, err
@@ -466,6 +508,20 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 		return nil, err
 	}
 
+	// Rejects the order without waiting on an exchange which is considered
+	// unavailable
+	err = checkExchangeCircuit(exch, newOrder.AssetType)
+	if err != nil {
+		return nil, fmt.Errorf("order manager: exchange %s unable to place order: %w",
+			newOrder.Exchange,
+			err)
+	}
+
+	err = m.checkRiskLimits(ctx, exch, newOrder, "")
+	if err != nil {
+		return nil, err
+	}
+
 	// Checks for exchange min max limits for order amounts before order
 	// execution can occur
 	err = exch.CheckOrderExecutionLimits(newOrder.AssetType,
@@ -490,12 +546,29 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 			err)
 	}
 
-	result, err := exch.SubmitOrder(ctx, newOrder)
+	exchCtx, span := tracing.StartSpan(ctx, "exchange.SubmitOrder", tracing.String("exchange", exch.GetName()))
+	result, err := exch.SubmitOrder(exchCtx, newOrder)
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		return nil, err
 	}
 
-	return m.processSubmittedOrder(result)
+	return m.processSubmittedOrder(result, account.GetAccountFromContext(ctx))
+}
+
+// orderSpanAttributes returns the attributes describing an order submission
+func orderSpanAttributes(s *order.Submit) []tracing.Attribute {
+	if s == nil {
+		return nil
+	}
+	return []tracing.Attribute{
+		tracing.String("exchange", s.Exchange),
+		tracing.String("asset", s.AssetType.String()),
+		tracing.String("pair", s.Pair.String()),
+		tracing.String("side", s.Side.String()),
+		tracing.String("type", s.Type.String()),
+	}
 }
 
 // SubmitFakeOrder runs through the same process as order submission
@@ -531,7 +604,7 @@ func (m *OrderManager) SubmitFakeOrder(newOrder *order.Submit, resultingOrder *o
 				err)
 		}
 	}
-	return m.processSubmittedOrder(resultingOrder)
+	return m.processSubmittedOrder(resultingOrder, "")
 }
 
 // GetOrdersSnapshot returns a snapshot of all orders in the orderstore. It optionally filters any orders that do not match the status
@@ -578,8 +651,38 @@ func (m *OrderManager) GetOrdersActive(f *order.Filter) ([]order.Detail, error)
 	return m.orderStore.getActiveOrders(f), nil
 }
 
-// processSubmittedOrder adds a new order to the manager
-func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse) (*OrderSubmitResponse, error) {
+// GetQueueStats returns the number of tracked and active orders per exchange
+// and the number of orders waiting to be persisted
+func (m *OrderManager) GetQueueStats() (*OrderQueueStats, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	m.orderStore.m.RLock()
+	defer m.orderStore.m.RUnlock()
+	stats := &OrderQueueStats{
+		Tracked:            make(map[string]int, len(m.orderStore.Orders)),
+		Active:             make(map[string]int, len(m.orderStore.Orders)),
+		PendingPersistence: len(m.orderStore.dirty),
+	}
+	for exch, orders := range m.orderStore.Orders {
+		stats.Tracked[exch] = len(orders)
+		var active int
+		for i := range orders {
+			if orders[i].IsActive() {
+				active++
+			}
+		}
+		stats.Active[exch] = active
+	}
+	return stats, nil
+}
+
+// processSubmittedOrder adds a new order placed with an exchange account to the
+// manager
+func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse, accountName string) (*OrderSubmitResponse, error) {
 	if newOrderResp == nil {
 		return nil, order.ErrOrderDetailIsNil
 	}
@@ -593,6 +696,7 @@ func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse)
 	if err != nil {
 		return nil, err
 	}
+	detail.Account = accountName
 
 	msg := fmt.Sprintf("Exchange %s submitted order ID=%v [Ours: %v] pair=%v price=%v amount=%v quoteAmount=%v side=%v type=%v for time %v.",
 		detail.Exchange,
@@ -616,6 +720,7 @@ func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse)
 		return nil, fmt.Errorf("unable to add %v order %v to orderStore: %s",
 			detail.Exchange, detail.OrderID, err)
 	}
+	m.persistOrders()
 
 	return &OrderSubmitResponse{Detail: detail, InternalOrderID: id.String()}, nil
 }
@@ -665,46 +770,9 @@ func (m *OrderManager) processOrders() {
 				continue
 			}
 
-			filter := &order.Filter{Exchange: exchanges[x].GetName()}
-			orders := m.orderStore.getActiveOrders(filter)
-			order.FilterOrdersByPairs(&orders, pairs)
-			var result []order.Detail
-			result, err = exchanges[x].GetActiveOrders(context.TODO(), &order.GetOrdersRequest{
-				Side:      order.AnySide,
-				Type:      order.AnyType,
-				Pairs:     pairs,
-				AssetType: enabledAssets[y],
-			})
-			if err != nil {
-				log.Errorf(log.OrderMgr,
-					"Unable to get active orders for %s and asset type %s: %s",
-					exchanges[x].GetName(),
-					enabledAssets[y],
-					err)
-				continue
-			}
-			if len(orders) > 0 && len(result) > 0 {
-				for z := range result {
-					var upsertResponse *OrderUpsertResponse
-					upsertResponse, err = m.UpsertOrder(&result[z])
-					if err != nil {
-						log.Error(log.OrderMgr, err)
-					} else {
-						for i := range orders {
-							if orders[i].InternalOrderID != upsertResponse.OrderDetails.InternalOrderID {
-								continue
-							}
-							orders[i] = orders[len(orders)-1]
-							orders = orders[:len(orders)-1]
-							break
-						}
-					}
-				}
-			}
-
-			if exchanges[x].GetBase().GetSupportedFeatures().RESTCapabilities.GetOrder {
-				wg.Add(1)
-				go m.processMatchingOrders(exchanges[x], orders, &wg)
+			accounts := exchangeAccountNames(exchanges[x])
+			for z := range accounts {
+				m.processAccountOrders(exchanges[x], accounts[z], enabledAssets[y], pairs, &wg)
 			}
 
 			if m.activelyTrackFuturesPositions && enabledAssets[y].IsFutures() {
@@ -747,6 +815,61 @@ func (m *OrderManager) processOrders() {
 	}
 }
 
+// processAccountOrders upserts the active orders of an exchange account for an
+// asset type, then checks the status of orders tracked as active which were not
+// returned
+func (m *OrderManager) processAccountOrders(exch exchange.IBotExchange, accountName string, a asset.Item, pairs currency.Pairs, wg *sync.WaitGroup) {
+	orders := m.orderStore.getActiveOrders(&order.Filter{Exchange: exch.GetName()})
+	order.FilterOrdersByPairs(&orders, pairs)
+	target := 0
+	for i := range orders {
+		if orders[i].Account == accountName {
+			orders[target] = orders[i]
+			target++
+		}
+	}
+	orders = orders[:target]
+	result, err := exch.GetActiveOrders(account.DeployAccountToContext(context.TODO(), accountName), &order.GetOrdersRequest{
+		Side:      order.AnySide,
+		Type:      order.AnyType,
+		Pairs:     pairs,
+		AssetType: a,
+	})
+	if err != nil {
+		log.Errorf(log.OrderMgr,
+			"Unable to get active orders for %s account %q and asset type %s: %s",
+			exch.GetName(),
+			accountName,
+			a,
+			err)
+		return
+	}
+	if len(orders) > 0 && len(result) > 0 {
+		for z := range result {
+			result[z].Account = accountName
+			var upsertResponse *OrderUpsertResponse
+			upsertResponse, err = m.UpsertOrder(&result[z])
+			if err != nil {
+				log.Error(log.OrderMgr, err)
+			} else {
+				for i := range orders {
+					if orders[i].InternalOrderID != upsertResponse.OrderDetails.InternalOrderID {
+						continue
+					}
+					orders[i] = orders[len(orders)-1]
+					orders = orders[:len(orders)-1]
+					break
+				}
+			}
+		}
+	}
+
+	if exch.GetBase().GetSupportedFeatures().RESTCapabilities.GetOrder {
+		wg.Add(1)
+		go m.processMatchingOrders(exch, orders, wg)
+	}
+}
+
 // processFuturesPositions ensures any open position found is kept up to date in the order manager
 func (m *OrderManager) processFuturesPositions(exch exchange.IBotExchange, position *order.PositionDetails) error {
 	if !m.activelyTrackFuturesPositions {
@@ -834,12 +957,13 @@ func (m *OrderManager) FetchAndUpdateExchangeOrder(exch exchange.IBotExchange, o
 	if ord == nil {
 		return errors.New("order manager: Order is nil")
 	}
-	fetchedOrder, err := exch.GetOrderInfo(context.TODO(), ord.OrderID, ord.Pair, assetType)
+	fetchedOrder, err := exch.GetOrderInfo(account.DeployAccountToContext(context.TODO(), ord.Account), ord.OrderID, ord.Pair, assetType)
 	if err != nil {
 		ord.Status = order.UnknownStatus
 		return err
 	}
 	fetchedOrder.LastUpdated = time.Now()
+	fetchedOrder.Account = ord.Account
 	_, err = m.UpsertOrder(&fetchedOrder)
 	return err
 }
@@ -978,6 +1102,7 @@ func (s *store) updateExisting(od *order.Detail) error {
 		if err != nil {
 			return err
 		}
+		s.markDirty(r[x])
 		if !r[x].AssetType.IsFutures() {
 			return nil
 		}
@@ -1004,6 +1129,7 @@ func (s *store) modifyExisting(id string, mod *order.ModifyResponse) error {
 			continue
 		}
 		r[x].UpdateOrderFromModifyResponse(mod)
+		s.markDirty(r[x])
 		if !r[x].AssetType.IsFutures() {
 			return nil
 		}
@@ -1046,6 +1172,7 @@ func (s *store) upsert(od *order.Detail) (*OrderUpsertResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		s.markDirty(exchangeOrders[x])
 		return &OrderUpsertResponse{
 			OrderDetails: exchangeOrders[x].Copy(),
 			IsNewOrder:   false,
@@ -1054,9 +1181,18 @@ func (s *store) upsert(od *order.Detail) (*OrderUpsertResponse, error) {
 	// Untracked websocket orders will not have internalIDs yet
 	od.GenerateInternalOrderID()
 	s.Orders[lName] = append(s.Orders[lName], od)
+	s.markDirty(od)
 	return &OrderUpsertResponse{OrderDetails: od.Copy(), IsNewOrder: true}, nil
 }
 
+// markDirty flags an order to be saved when persistence is enabled, the store
+// must be locked by the caller
+func (s *store) markDirty(det *order.Detail) {
+	if s.dirty != nil {
+		s.dirty[det] = struct{}{}
+	}
+}
+
 // exists verifies if the orderstore contains the provided order
 func (s *store) exists(det *order.Detail) bool {
 	if det == nil {
@@ -1092,6 +1228,7 @@ func (s *store) add(det *order.Detail) error {
 	s.m.Lock()
 	defer s.m.Unlock()
 	s.Orders[name] = append(s.Orders[name], det)
+	s.markDirty(det)
 	if !det.AssetType.IsFutures() {
 		return nil
 	}
@@ -0,0 +1,439 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/managedorder"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetPersistence saves managed orders and futures positions to the database so
+// they can be restored when the order manager next starts. It must be called
+// before the order manager is started
+func (m *OrderManager) SetPersistence(db managedorder.IDBService, restorePeriod, snapshotInterval time.Duration) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 1 {
+		return fmt.Errorf("order manager %w", ErrSubSystemAlreadyStarted)
+	}
+	if db == nil {
+		return fmt.Errorf("%w managed order database service", common.ErrNilPointer)
+	}
+	if restorePeriod <= 0 {
+		return fmt.Errorf("%w restore period %v", errInvalidPersistencePeriod, restorePeriod)
+	}
+	if snapshotInterval <= 0 {
+		return fmt.Errorf("%w position snapshot interval %v", errInvalidPersistencePeriod, snapshotInterval)
+	}
+	m.persistence = orderPersistence{
+		db:               db,
+		restorePeriod:    restorePeriod,
+		snapshotInterval: snapshotInterval,
+		openPositions:    make(map[positionKey]struct{}),
+	}
+	m.orderStore.m.Lock()
+	m.orderStore.dirty = make(map[*order.Detail]struct{})
+	m.orderStore.m.Unlock()
+	return nil
+}
+
+// restoreOrders loads orders updated within the restore period into the order
+// store and returns copies of those which were still active when saved
+func (m *OrderManager) restoreOrders() []order.Detail {
+	if m.persistence.db == nil {
+		return nil
+	}
+	saved, err := m.persistence.db.GetOrdersUpdatedSince(time.Now().Add(-m.persistence.restorePeriod))
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Order manager unable to restore orders: %v", err)
+		return nil
+	}
+	var active []order.Detail
+	var restored int
+	m.orderStore.m.Lock()
+	defer m.orderStore.m.Unlock()
+	for i := range saved {
+		var d *order.Detail
+		d, err = managedOrderToDetail(&saved[i])
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to restore %v order %v: %v", saved[i].Exchange, saved[i].OrderID, err)
+			continue
+		}
+		lName := strings.ToLower(d.Exchange)
+		_, err = m.orderStore.exchangeManager.GetExchangeByName(lName)
+		if err != nil {
+			continue
+		}
+		var exists bool
+		for _, o := range m.orderStore.Orders[lName] {
+			if o.OrderID == d.OrderID {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+		m.orderStore.Orders[lName] = append(m.orderStore.Orders[lName], d)
+		restored++
+		if d.AssetType.IsFutures() {
+			err = m.orderStore.futuresPositionController.TrackNewOrder(d)
+			if err != nil && !errors.Is(err, order.ErrPositionClosed) {
+				log.Errorf(log.OrderMgr, "Order manager unable to track restored %v order %v: %v", d.Exchange, d.OrderID, err)
+			}
+		}
+		if d.Status == order.UnknownStatus || d.IsActive() {
+			active = append(active, d.Copy())
+		}
+	}
+	log.Debugf(log.OrderMgr, "Order manager restored %d orders, %d active.", restored, len(active))
+	return active
+}
+
+// reconcileOrders checks restored active orders against the exchange so that
+// orders which filled or were cancelled while the bot was offline are updated
+func (m *OrderManager) reconcileOrders(restored []order.Detail) {
+	defer m.orderStore.wg.Done()
+	type key struct {
+		exchange string
+		asset    asset.Item
+	}
+	grouped := make(map[key][]order.Detail)
+	for i := range restored {
+		k := key{exchange: strings.ToLower(restored[i].Exchange), asset: restored[i].AssetType}
+		grouped[k] = append(grouped[k], restored[i])
+	}
+	for k, orders := range grouped {
+		select {
+		case <-m.shutdown:
+			return
+		default:
+		}
+		exch, err := m.orderStore.exchangeManager.GetExchangeByName(k.exchange)
+		if err != nil {
+			log.Error(log.OrderMgr, err)
+			continue
+		}
+		if !exch.IsRESTAuthenticationSupported() {
+			continue
+		}
+		var pairs currency.Pairs
+		for i := range orders {
+			pairs = pairs.Add(orders[i].Pair)
+		}
+		accounts := exchangeAccountNames(exch)
+		reconciled := 0
+		for i := range accounts {
+			var accountOrders []order.Detail
+			for j := range orders {
+				if orders[j].Account == accounts[i] {
+					accountOrders = append(accountOrders, orders[j])
+				}
+			}
+			reconciled += len(accountOrders)
+			m.reconcileAccountOrders(exch, accounts[i], k.asset, pairs, accountOrders)
+		}
+		if reconciled < len(orders) {
+			log.Warnf(log.OrderMgr,
+				"Unable to reconcile %d restored %s %s orders placed with accounts which are no longer configured",
+				len(orders)-reconciled,
+				exch.GetName(),
+				k.asset)
+		}
+	}
+	m.persistOrders()
+}
+
+// reconcileAccountOrders refreshes restored orders of an exchange account and
+// asset type with the account's active orders, then fetches the status of
+// restored orders which are no longer active
+func (m *OrderManager) reconcileAccountOrders(exch exchange.IBotExchange, accountName string, a asset.Item, pairs currency.Pairs, orders []order.Detail) {
+	result, err := exch.GetActiveOrders(account.DeployAccountToContext(context.TODO(), accountName), &order.GetOrdersRequest{
+		Side:      order.AnySide,
+		Type:      order.AnyType,
+		Pairs:     pairs,
+		AssetType: a,
+	})
+	if err != nil {
+		log.Errorf(log.OrderMgr,
+			"Unable to reconcile restored orders for %s account %q and asset type %s: %s",
+			exch.GetName(),
+			accountName,
+			a,
+			err)
+		return
+	}
+	for i := range result {
+		result[i].Account = accountName
+		_, err = m.UpsertOrder(&result[i])
+		if err != nil {
+			log.Error(log.OrderMgr, err)
+			continue
+		}
+		for j := range orders {
+			if orders[j].OrderID != result[i].OrderID {
+				continue
+			}
+			orders[j] = orders[len(orders)-1]
+			orders = orders[:len(orders)-1]
+			break
+		}
+	}
+	if len(orders) == 0 || !exch.GetBase().GetSupportedFeatures().RESTCapabilities.GetOrder {
+		return
+	}
+	// Orders which are no longer active on the exchange have been filled
+	// or cancelled since they were saved
+	for i := range orders {
+		err = m.FetchAndUpdateExchangeOrder(exch, &orders[i], orders[i].AssetType)
+		if err != nil {
+			log.Error(log.OrderMgr, err)
+		}
+	}
+}
+
+// persistOrders saves all orders which have changed since they were last saved
+func (m *OrderManager) persistOrders() {
+	if m.persistence.db == nil {
+		return
+	}
+	m.orderStore.m.Lock()
+	if len(m.orderStore.dirty) == 0 {
+		m.orderStore.m.Unlock()
+		return
+	}
+	changed := make([]*order.Detail, 0, len(m.orderStore.dirty))
+	orders := make([]*managedorder.Order, 0, len(m.orderStore.dirty))
+	for d := range m.orderStore.dirty {
+		changed = append(changed, d)
+		orders = append(orders, detailToManagedOrder(d))
+	}
+	m.orderStore.dirty = make(map[*order.Detail]struct{})
+	m.orderStore.m.Unlock()
+
+	err := m.persistence.db.UpsertOrders(orders...)
+	if err == nil {
+		return
+	}
+	log.Errorf(log.OrderMgr, "Order manager unable to save orders: %v", err)
+	// Retry on the next cycle
+	m.orderStore.m.Lock()
+	for i := range changed {
+		m.orderStore.dirty[changed[i]] = struct{}{}
+	}
+	m.orderStore.m.Unlock()
+}
+
+// snapshotPositions saves the state of all open futures positions and a final
+// snapshot of any position which has closed since the previous snapshot
+func (m *OrderManager) snapshotPositions(force bool) {
+	if m.persistence.db == nil {
+		return
+	}
+	now := time.Now()
+	if !force && now.Sub(m.persistence.lastSnapshot) < m.persistence.snapshotInterval {
+		return
+	}
+	m.persistence.lastSnapshot = now
+	positions, err := m.orderStore.futuresPositionController.GetAllOpenPositions()
+	if err != nil && !errors.Is(err, order.ErrNoPositionsFound) {
+		log.Errorf(log.OrderMgr, "Order manager unable to get open positions: %v", err)
+		return
+	}
+	open := make(map[positionKey]struct{}, len(positions))
+	snapshots := make([]*managedorder.PositionSnapshot, 0, len(positions))
+	for i := range positions {
+		open[positionKey{
+			exchange: strings.ToLower(positions[i].Exchange),
+			asset:    positions[i].Asset,
+			pair:     positions[i].Pair,
+		}] = struct{}{}
+		snapshots = append(snapshots, positionToSnapshot(&positions[i], now))
+	}
+	for k := range m.persistence.openPositions {
+		if _, ok := open[k]; ok {
+			continue
+		}
+		var p *order.Position
+		p, err = m.lastClosedPosition(k)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to snapshot closed position %v: %v", k, err)
+			continue
+		}
+		snapshots = append(snapshots, positionToSnapshot(p, now))
+	}
+	if len(snapshots) == 0 {
+		return
+	}
+	err = m.persistence.db.InsertPositionSnapshots(snapshots...)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Order manager unable to save position snapshots: %v", err)
+		return
+	}
+	m.persistence.openPositions = open
+}
+
+// lastClosedPosition returns the most recently closed position tracked for an
+// exchange, asset and pair
+func (m *OrderManager) lastClosedPosition(k positionKey) (*order.Position, error) {
+	positions, err := m.orderStore.futuresPositionController.GetPositionsForExchange(k.exchange, k.asset, k.pair)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(positions) - 1; i >= 0; i-- {
+		if positions[i].Status.IsInactive() {
+			return &positions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w no closed position for %v %v %v", order.ErrPositionNotFound, k.exchange, k.asset, k.pair)
+}
+
+// detailToManagedOrder converts an order to its database representation
+func detailToManagedOrder(d *order.Detail) *managedorder.Order {
+	o := &managedorder.Order{
+		ID:                   d.InternalOrderID.String(),
+		Exchange:             d.Exchange,
+		Account:              d.Account,
+		OrderID:              d.OrderID,
+		ClientOrderID:        d.ClientOrderID,
+		Asset:                d.AssetType.String(),
+		Base:                 d.Pair.Base.String(),
+		Quote:                d.Pair.Quote.String(),
+		Side:                 d.Side.String(),
+		Type:                 d.Type.String(),
+		Status:               d.Status.String(),
+		Price:                d.Price,
+		Amount:               d.Amount,
+		ExecutedAmount:       d.ExecutedAmount,
+		RemainingAmount:      d.RemainingAmount,
+		AverageExecutedPrice: d.AverageExecutedPrice,
+		Cost:                 d.Cost,
+		Fee:                  d.Fee,
+		FeeAsset:             d.FeeAsset.String(),
+		Leverage:             d.Leverage,
+		CreatedAt:            d.Date,
+		ClosedAt:             d.CloseTime,
+		UpdatedAt:            d.LastUpdated,
+	}
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = time.Now()
+	}
+	o.Fills = make([]managedorder.Fill, len(d.Trades))
+	for i := range d.Trades {
+		tradeID := d.Trades[i].TID
+		if tradeID == "" {
+			tradeID = fmt.Sprintf("%s-%d", d.OrderID, i)
+		}
+		side := d.Trades[i].Side
+		if side == order.UnknownSide {
+			side = d.Side
+		}
+		o.Fills[i] = managedorder.Fill{
+			TradeID:   tradeID,
+			Side:      side.String(),
+			Price:     d.Trades[i].Price,
+			Amount:    d.Trades[i].Amount,
+			Fee:       d.Trades[i].Fee,
+			FeeAsset:  d.Trades[i].FeeAsset,
+			Timestamp: d.Trades[i].Timestamp,
+		}
+	}
+	return o
+}
+
+// managedOrderToDetail converts a saved order back to an order detail
+func managedOrderToDetail(o *managedorder.Order) (*order.Detail, error) {
+	id, err := uuid.FromString(o.ID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := asset.New(o.Asset)
+	if err != nil {
+		return nil, err
+	}
+	side, err := order.StringToOrderSide(o.Side)
+	if err != nil {
+		return nil, err
+	}
+	// Unrecognised types and statuses are restored as unknown, unknown
+	// statuses are refreshed from the exchange on startup
+	oType, _ := order.StringToOrderType(o.Type)
+	status, _ := order.StringToOrderStatus(o.Status)
+	d := &order.Detail{
+		Leverage:             o.Leverage,
+		Price:                o.Price,
+		Amount:               o.Amount,
+		AverageExecutedPrice: o.AverageExecutedPrice,
+		ExecutedAmount:       o.ExecutedAmount,
+		RemainingAmount:      o.RemainingAmount,
+		Cost:                 o.Cost,
+		Fee:                  o.Fee,
+		FeeAsset:             currency.NewCode(o.FeeAsset),
+		Exchange:             o.Exchange,
+		Account:              o.Account,
+		InternalOrderID:      id,
+		OrderID:              o.OrderID,
+		ClientOrderID:        o.ClientOrderID,
+		Type:                 oType,
+		Side:                 side,
+		Status:               status,
+		AssetType:            item,
+		Date:                 o.CreatedAt,
+		CloseTime:            o.ClosedAt,
+		LastUpdated:          o.UpdatedAt,
+		Pair:                 currency.NewPair(currency.NewCode(o.Base), currency.NewCode(o.Quote)),
+	}
+	if len(o.Fills) > 0 {
+		d.Trades = make([]order.TradeHistory, len(o.Fills))
+		for i := range o.Fills {
+			fillSide, _ := order.StringToOrderSide(o.Fills[i].Side)
+			d.Trades[i] = order.TradeHistory{
+				Price:     o.Fills[i].Price,
+				Amount:    o.Fills[i].Amount,
+				Fee:       o.Fills[i].Fee,
+				Exchange:  o.Exchange,
+				TID:       o.Fills[i].TradeID,
+				Side:      fillSide,
+				Timestamp: o.Fills[i].Timestamp,
+				FeeAsset:  o.Fills[i].FeeAsset,
+			}
+		}
+	}
+	return d, nil
+}
+
+// positionToSnapshot converts a futures position to its database
+// representation
+func positionToSnapshot(p *order.Position, ts time.Time) *managedorder.PositionSnapshot {
+	return &managedorder.PositionSnapshot{
+		Exchange:         p.Exchange,
+		Asset:            p.Asset.String(),
+		Base:             p.Pair.Base.String(),
+		Quote:            p.Pair.Quote.String(),
+		Status:           p.Status.String(),
+		OpeningDirection: p.OpeningDirection.String(),
+		OpeningPrice:     p.OpeningPrice.InexactFloat64(),
+		OpeningSize:      p.OpeningSize.InexactFloat64(),
+		OpeningDate:      p.OpeningDate,
+		LatestDirection:  p.LatestDirection.String(),
+		LatestPrice:      p.LatestPrice.InexactFloat64(),
+		LatestSize:       p.LatestSize.InexactFloat64(),
+		RealisedPNL:      p.RealisedPNL.InexactFloat64(),
+		UnrealisedPNL:    p.UnrealisedPNL.InexactFloat64(),
+		CloseDate:        p.CloseDate,
+		Timestamp:        ts,
+	}
+}
@@ -0,0 +1,565 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/managedorder"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+)
+
+var errFakeOrderDB = errors.New("fake order database error")
+
+// fOrderDB is an in memory managed order database service
+type fOrderDB struct {
+	m         sync.Mutex
+	orders    map[string]managedorder.Order
+	snapshots []managedorder.PositionSnapshot
+	err       error
+}
+
+func (f *fOrderDB) UpsertOrders(orders ...*managedorder.Order) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	for i := range orders {
+		f.orders[orders[i].ID] = *orders[i]
+	}
+	return nil
+}
+
+func (f *fOrderDB) GetOrdersUpdatedSince(since time.Time) ([]managedorder.Order, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	var resp []managedorder.Order
+	for _, o := range f.orders {
+		if o.UpdatedAt.Before(since) {
+			continue
+		}
+		resp = append(resp, o)
+	}
+	return resp, f.err
+}
+
+func (f *fOrderDB) InsertPositionSnapshots(snapshots ...*managedorder.PositionSnapshot) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for i := range snapshots {
+		f.snapshots = append(f.snapshots, *snapshots[i])
+	}
+	return f.err
+}
+
+func (f *fOrderDB) GetPositionSnapshotsBetween(time.Time, time.Time) ([]managedorder.PositionSnapshot, error) {
+	return nil, common.ErrNotYetImplemented
+}
+
+func (f *fOrderDB) get(orderID string) (managedorder.Order, bool) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for _, o := range f.orders {
+		if o.OrderID == orderID {
+			return o, true
+		}
+	}
+	return managedorder.Order{}, false
+}
+
+// setupPersistenceOrderManager returns a stopped order manager with an
+// authenticated fake exchange which does not require network access
+func setupPersistenceOrderManager(t *testing.T) *OrderManager {
+	t.Helper()
+	var wg sync.WaitGroup
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	exch.GetBase().API = exchange.API{AuthenticatedSupport: true}
+	exch.GetBase().Features = exchange.Features{
+		Supports: exchange.FeaturesSupported{
+			REST:             true,
+			RESTCapabilities: protocol.Features{GetOrder: true},
+		},
+	}
+	em.Add(omfExchange{IBotExchange: exch})
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &wg, false, true, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return m
+}
+
+func TestSetPersistence(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	err := m.SetPersistence(nil, 0, 0)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	m = setupPersistenceOrderManager(t)
+	m.started = 1
+	err = m.SetPersistence(nil, 0, 0)
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	m.started = 0
+	err = m.SetPersistence(nil, 0, 0)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	db := &fOrderDB{orders: make(map[string]managedorder.Order)}
+	err = m.SetPersistence(db, 0, time.Minute)
+	if !errors.Is(err, errInvalidPersistencePeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPersistencePeriod)
+	}
+	err = m.SetPersistence(db, time.Hour, 0)
+	if !errors.Is(err, errInvalidPersistencePeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPersistencePeriod)
+	}
+	err = m.SetPersistence(db, time.Hour, time.Minute)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.orderStore.dirty == nil {
+		t.Fatal("expected changed orders to be tracked")
+	}
+}
+
+func TestPersistOrders(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	// No database is a no-op
+	m.persistOrders()
+
+	db := &fOrderDB{orders: make(map[string]managedorder.Order), err: errFakeOrderDB}
+	err := m.SetPersistence(db, time.Hour, time.Minute)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	err = m.orderStore.add(&order.Detail{
+		Exchange:  testExchange,
+		OrderID:   "persist",
+		Pair:      pair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Status:    order.New,
+		Amount:    1,
+		Date:      time.Now(),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.persistOrders()
+	if len(m.orderStore.dirty) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(m.orderStore.dirty), 1)
+	}
+
+	db.m.Lock()
+	db.err = nil
+	db.m.Unlock()
+	m.persistOrders()
+	if len(m.orderStore.dirty) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(m.orderStore.dirty), 0)
+	}
+	saved, ok := db.get("persist")
+	if !ok || saved.Status != order.New.String() {
+		t.Fatalf("unexpected saved order %+v", saved)
+	}
+
+	err = m.orderStore.updateExisting(&order.Detail{
+		Exchange:       testExchange,
+		OrderID:        "persist",
+		Pair:           pair,
+		AssetType:      asset.Spot,
+		Status:         order.PartiallyFilled,
+		ExecutedAmount: 0.5,
+		Trades:         []order.TradeHistory{{TID: "t1", Amount: 0.5, Price: 1337}},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.persistOrders()
+	saved, _ = db.get("persist")
+	if saved.Status != order.PartiallyFilled.String() || len(saved.Fills) != 1 ||
+		saved.Fills[0].Side != order.Buy.String() {
+		t.Fatalf("unexpected saved order %+v", saved)
+	}
+}
+
+func TestRestoreOrders(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	if restored := m.restoreOrders(); len(restored) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(restored), 0)
+	}
+
+	db := &fOrderDB{orders: make(map[string]managedorder.Order)}
+	err := m.SetPersistence(db, time.Hour, time.Minute)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	for _, d := range []*order.Detail{
+		{Exchange: testExchange, OrderID: "active", Status: order.Active, Side: order.Buy, Amount: 1},
+		{Exchange: testExchange, OrderID: "filled", Status: order.Filled, Side: order.Sell},
+		{Exchange: "bogus", OrderID: "unloaded", Status: order.Active, Side: order.Buy},
+		{Exchange: testExchange, OrderID: "stale", Status: order.Active, Side: order.Buy, LastUpdated: now.Add(-time.Hour * 2)},
+		{Exchange: testExchange, OrderID: "futures", Status: order.Open, Side: order.Long, AssetType: asset.Futures, Amount: 1, Price: 1337},
+	} {
+		d.InternalOrderID = uuid.Must(uuid.NewV4())
+		d.Pair = pair
+		if d.AssetType == asset.Empty {
+			d.AssetType = asset.Spot
+		}
+		d.Date = now
+		if d.LastUpdated.IsZero() {
+			d.LastUpdated = now
+		}
+		err = db.UpsertOrders(detailToManagedOrder(d))
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+
+	restored := m.restoreOrders()
+	m.started = 1
+	if len(restored) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(restored), 2)
+	}
+	orders, err := m.GetOrdersFiltered(&order.Filter{Exchange: testExchange})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(orders) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(orders), 3)
+	}
+	if len(m.orderStore.dirty) != 0 {
+		t.Fatal("restored orders should not be saved again")
+	}
+	_, err = m.orderStore.futuresPositionController.GetOpenPosition(testExchange, asset.Futures, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// Restoring again must not duplicate orders
+	m.restoreOrders()
+	orders, err = m.GetOrdersFiltered(&order.Filter{Exchange: testExchange})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(orders) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(orders), 3)
+	}
+}
+
+func TestReconcileOrders(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	db := &fOrderDB{orders: make(map[string]managedorder.Order)}
+	err := m.SetPersistence(db, time.Hour, time.Minute)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	restored := []order.Detail{
+		{Exchange: testExchange, OrderID: "Order3-unknown-to-active", Pair: pair, AssetType: asset.Spot, Status: order.UnknownStatus},
+		{Exchange: testExchange, OrderID: "cancelled-while-offline", Pair: pair, AssetType: asset.Spot, Status: order.Active},
+	}
+	for i := range restored {
+		err = m.orderStore.add(restored[i].CopyToPointer())
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	m.orderStore.m.Lock()
+	m.orderStore.dirty = make(map[*order.Detail]struct{})
+	m.orderStore.m.Unlock()
+
+	m.orderStore.wg.Add(1)
+	m.reconcileOrders(restored)
+
+	d, err := m.orderStore.getByExchangeAndID(testExchange, "Order3-unknown-to-active")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if d.Status != order.Active {
+		t.Fatalf("received: '%v' but expected: '%v'", d.Status, order.Active)
+	}
+	d, err = m.orderStore.getByExchangeAndID(testExchange, "cancelled-while-offline")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if d.Status != order.Cancelled {
+		t.Fatalf("received: '%v' but expected: '%v'", d.Status, order.Cancelled)
+	}
+	saved, ok := db.get("cancelled-while-offline")
+	if !ok || saved.Status != order.Cancelled.String() {
+		t.Fatalf("unexpected saved order %+v", saved)
+	}
+}
+
+// fAccountOrderExchange returns active orders only for its named account
+type fAccountOrderExchange struct {
+	omfExchange
+	queried *[]string
+}
+
+func (f fAccountOrderExchange) GetAccountNames() []string {
+	return []string{"main"}
+}
+
+func (f fAccountOrderExchange) GetActiveOrders(ctx context.Context, req *order.GetOrdersRequest) (order.FilteredOrders, error) {
+	accountName := account.GetAccountFromContext(ctx)
+	*f.queried = append(*f.queried, accountName)
+	if accountName != "main" {
+		return nil, nil
+	}
+	return []order.Detail{{
+		Exchange:  testExchange,
+		Pair:      req.Pairs[0],
+		AssetType: req.AssetType,
+		Amount:    1,
+		Side:      order.Buy,
+		Status:    order.Active,
+		OrderID:   "main-active",
+	}}, nil
+}
+
+func TestReconcileOrdersAccounts(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	exch, err := m.orderStore.exchangeManager.GetExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var queried []string
+	em, ok := m.orderStore.exchangeManager.(*ExchangeManager)
+	if !ok {
+		t.Fatal("unexpected exchange manager type")
+	}
+	em.Add(fAccountOrderExchange{omfExchange: exch.(omfExchange), queried: &queried})
+	m.started = 1
+
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	restored := []order.Detail{
+		{Exchange: testExchange, Account: "main", OrderID: "main-active", Pair: pair, AssetType: asset.Spot, Status: order.UnknownStatus},
+		{Exchange: testExchange, OrderID: "default-cancelled", Pair: pair, AssetType: asset.Spot, Status: order.Active},
+	}
+	for i := range restored {
+		err = m.orderStore.add(restored[i].CopyToPointer())
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+
+	m.orderStore.wg.Add(1)
+	m.reconcileOrders(restored)
+
+	if len(queried) != 2 || queried[0] != "" || queried[1] != "main" {
+		t.Fatalf("expected active orders to be reconciled for every account, queried %v", queried)
+	}
+	d, err := m.orderStore.getByExchangeAndID(testExchange, "main-active")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if d.Status != order.Active || d.Account != "main" {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", d.Status, d.Account, order.Active, "main")
+	}
+	d, err = m.orderStore.getByExchangeAndID(testExchange, "default-cancelled")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if d.Status != order.Cancelled {
+		t.Fatalf("received: '%v' but expected: '%v'", d.Status, order.Cancelled)
+	}
+}
+
+func TestSnapshotPositions(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	// No database is a no-op
+	m.snapshotPositions(true)
+
+	db := &fOrderDB{orders: make(map[string]managedorder.Order)}
+	err := m.SetPersistence(db, time.Hour, time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	// No positions is a no-op
+	m.snapshotPositions(true)
+	if len(db.snapshots) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(db.snapshots), 0)
+	}
+
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	err = m.orderStore.add(&order.Detail{
+		Exchange:  testExchange,
+		OrderID:   "long",
+		Pair:      pair,
+		AssetType: asset.Futures,
+		Side:      order.Long,
+		Status:    order.Filled,
+		Amount:    1,
+		Price:     1337,
+		Date:      now,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.snapshotPositions(true)
+	if len(db.snapshots) != 1 || db.snapshots[0].OpeningPrice != 1337 {
+		t.Fatalf("unexpected snapshots %+v", db.snapshots)
+	}
+	// Snapshots are only taken every interval
+	m.snapshotPositions(false)
+	if len(db.snapshots) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(db.snapshots), 1)
+	}
+
+	err = m.orderStore.add(&order.Detail{
+		Exchange:  testExchange,
+		OrderID:   "close",
+		Pair:      pair,
+		AssetType: asset.Futures,
+		Side:      order.Short,
+		Status:    order.Filled,
+		Amount:    1,
+		Price:     1400,
+		Date:      now.Add(time.Minute),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.snapshotPositions(true)
+	if len(db.snapshots) != 2 || db.snapshots[1].Status != order.Closed.String() ||
+		db.snapshots[1].RealisedPNL == 0 {
+		t.Fatalf("unexpected snapshots %+v", db.snapshots)
+	}
+	// Closed positions are only saved once
+	m.snapshotPositions(true)
+	if len(db.snapshots) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(db.snapshots), 2)
+	}
+}
+
+func TestManagedOrderConversion(t *testing.T) {
+	t.Parallel()
+	now := time.Now().UTC()
+	d := &order.Detail{
+		Exchange:        testExchange,
+		Account:         "main",
+		InternalOrderID: uuid.Must(uuid.NewV4()),
+		OrderID:         "1337",
+		ClientOrderID:   "leet",
+		Pair:            currency.NewPair(currency.BTC, currency.USDT),
+		AssetType:       asset.Futures,
+		Side:            order.Short,
+		Type:            order.Limit,
+		Status:          order.PartiallyFilled,
+		Price:           1337,
+		Amount:          2,
+		ExecutedAmount:  1,
+		RemainingAmount: 1,
+		Fee:             0.1,
+		FeeAsset:        currency.USDT,
+		Leverage:        10,
+		Date:            now,
+		LastUpdated:     now,
+		Trades: []order.TradeHistory{
+			{Amount: 1, Price: 1337, Fee: 0.1, FeeAsset: "USDT", Timestamp: now},
+		},
+	}
+	o := detailToManagedOrder(d)
+	if o.ID != d.InternalOrderID.String() || o.Account != d.Account || o.Asset != "futures" || len(o.Fills) != 1 ||
+		o.Fills[0].TradeID != "1337-0" || o.Fills[0].Side != order.Short.String() {
+		t.Fatalf("unexpected managed order %+v", o)
+	}
+	o.ID = "bogus"
+	_, err := managedOrderToDetail(o)
+	if err == nil {
+		t.Fatal("expected error for invalid internal order ID")
+	}
+	o.ID = d.InternalOrderID.String()
+	resp, err := managedOrderToDetail(o)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.InternalOrderID != d.InternalOrderID || resp.Account != d.Account || !resp.Pair.Equal(d.Pair) ||
+		resp.AssetType != d.AssetType || resp.Side != d.Side || resp.Type != d.Type ||
+		resp.Status != d.Status || resp.Leverage != d.Leverage || !resp.FeeAsset.Equal(d.FeeAsset) ||
+		len(resp.Trades) != 1 || resp.Trades[0].TID != "1337-0" {
+		t.Fatalf("unexpected order %+v", resp)
+	}
+}
+
+func TestOrderManagerStartWithPersistence(t *testing.T) {
+	t.Parallel()
+	m := setupPersistenceOrderManager(t)
+	db := &fOrderDB{orders: make(map[string]managedorder.Order)}
+	err := m.SetPersistence(db, time.Hour, time.Minute)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = db.UpsertOrders(detailToManagedOrder(&order.Detail{
+		Exchange:        testExchange,
+		InternalOrderID: uuid.Must(uuid.NewV4()),
+		OrderID:         "restart",
+		Pair:            currency.NewPair(currency.BTC, currency.USD),
+		AssetType:       asset.Spot,
+		Side:            order.Buy,
+		Status:          order.Active,
+		Amount:          1,
+		Date:            time.Now(),
+		LastUpdated:     time.Now(),
+	}))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !m.Exists(&order.Detail{Exchange: testExchange, OrderID: "restart"}) {
+		t.Fatal("expected order to be restored on start")
+	}
+	// The restored order is reconciled against the exchange in the background
+	for i := 0; i < 100; i++ {
+		var d *order.Detail
+		d, err = m.orderStore.getByExchangeAndID(testExchange, "restart")
+		if err == nil && d.Status == order.Cancelled {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.orderStore.wg.Wait()
+	saved, ok := db.get("restart")
+	if !ok || saved.Status != order.Cancelled.String() {
+		t.Fatalf("unexpected saved order %+v", saved)
+	}
+}
@@ -0,0 +1,486 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// riskAuditType is the audit event type of risk limit changes and rejections
+const riskAuditType = "risk"
+
+// SetRiskLimits replaces the pre-trade risk limits applied to submitted orders
+func (m *OrderManager) SetRiskLimits(cfg *config.RiskLimits) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if cfg == nil {
+		return fmt.Errorf("%w risk limits", common.ErrNilPointer)
+	}
+	if !cfg.Default.IsValid() {
+		return fmt.Errorf("default %w", errInvalidRiskLimit)
+	}
+	limits := config.RiskLimits{
+		Enabled:   cfg.Enabled,
+		Default:   cfg.Default,
+		Exchanges: make([]config.ExchangeRiskLimit, len(cfg.Exchanges)),
+	}
+	for i := range cfg.Exchanges {
+		if cfg.Exchanges[i].Exchange == "" {
+			return ErrExchangeNameIsEmpty
+		}
+		if !cfg.Exchanges[i].IsValid() {
+			return fmt.Errorf("%s %w", cfg.Exchanges[i].Exchange, errInvalidRiskLimit)
+		}
+		limits.Exchanges[i] = cfg.Exchanges[i]
+	}
+	m.risk.m.Lock()
+	m.risk.limits = limits
+	m.risk.m.Unlock()
+	return nil
+}
+
+// GetRiskLimits returns a copy of the pre-trade risk limits and whether the
+// kill switch is engaged
+func (m *OrderManager) GetRiskLimits() (limits config.RiskLimits, killSwitch bool, err error) {
+	if m == nil {
+		return config.RiskLimits{}, false, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	m.risk.m.RLock()
+	defer m.risk.m.RUnlock()
+	limits = m.risk.limits
+	limits.Exchanges = append([]config.ExchangeRiskLimit(nil), m.risk.limits.Exchanges...)
+	return limits, m.risk.killSwitch, nil
+}
+
+// SetRiskLimit sets the risk limit of an exchange, or of a named account or
+// sub-account of an exchange. An empty exchange name sets the default risk
+// limit. Risk limits are enabled when set and the change is audited
+func (m *OrderManager) SetRiskLimit(exchangeName, accountName, subAccount string, limit config.RiskLimit) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if !limit.IsValid() {
+		return errInvalidRiskLimit
+	}
+	if exchangeName == "" && (accountName != "" || subAccount != "") {
+		return ErrExchangeNameIsEmpty
+	}
+	m.risk.m.Lock()
+	m.risk.limits.Enabled = true
+	if exchangeName == "" {
+		m.risk.limits.Default = limit
+	} else {
+		i := m.risk.findLimit(exchangeName, accountName, subAccount)
+		if i == -1 {
+			m.risk.limits.Exchanges = append(m.risk.limits.Exchanges, config.ExchangeRiskLimit{
+				Exchange:   exchangeName,
+				Account:    accountName,
+				SubAccount: subAccount,
+			})
+			i = len(m.risk.limits.Exchanges) - 1
+		}
+		m.risk.limits.Exchanges[i].RiskLimit = limit
+	}
+	m.risk.m.Unlock()
+
+	identifier := exchangeName
+	if identifier == "" {
+		identifier = "default"
+	}
+	if accountName != "" {
+		identifier += " " + accountName
+	}
+	if subAccount != "" {
+		identifier += " " + subAccount
+	}
+	msg := fmt.Sprintf("Risk limit for %s set to %+v", identifier, limit)
+	log.Infoln(log.OrderMgr, msg)
+	audit.Event(identifier, riskAuditType, msg)
+	return nil
+}
+
+// SetKillSwitch engages or releases the kill switch. When engaged all orders
+// are rejected and open orders are cancelled on every enabled exchange with
+// authenticated API support, for the default credentials and each named
+// account. It returns the number of orders cancelled
+func (m *OrderManager) SetKillSwitch(ctx context.Context, engage bool) (int64, error) {
+	if m == nil {
+		return 0, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	m.risk.m.Lock()
+	m.risk.killSwitch = engage
+	m.risk.m.Unlock()
+
+	if !engage {
+		log.Warnln(log.OrderMgr, "Kill switch released, orders can be submitted")
+		audit.Event("kill_switch", riskAuditType, "Kill switch released")
+		return 0, nil
+	}
+	log.Warnln(log.OrderMgr, "Kill switch engaged, cancelling all orders")
+	audit.Event("kill_switch", riskAuditType, "Kill switch engaged")
+	exchanges, err := getAccountExchanges(m.orderStore.exchangeManager, nil)
+	if err != nil {
+		return 0, err
+	}
+	var cancelled int64
+	var failed []string
+	for i := range exchanges {
+		accounts := exchangeAccountNames(exchanges[i])
+		for j := range accounts {
+			count, err := cancelAllExchangeOrders(account.DeployAccountToContext(ctx, accounts[j]), exchanges[i])
+			cancelled += count
+			if err != nil {
+				name := reconciliationBalanceKey(exchanges[i].GetName(), accounts[j])
+				log.Errorf(log.OrderMgr, "Kill switch unable to cancel %s orders: %v", name, err)
+				failed = append(failed, name)
+			}
+		}
+	}
+	msg := fmt.Sprintf("Kill switch cancelled %d orders", cancelled)
+	log.Warnln(log.OrderMgr, msg)
+	audit.Event("kill_switch", riskAuditType, msg)
+	if m.orderStore.commsManager != nil {
+		m.orderStore.commsManager.PushEvent(base.Event{Type: "risk", Message: msg})
+	}
+	if len(failed) > 0 {
+		return cancelled, fmt.Errorf("%w for %s", errCancelAllFailed, strings.Join(failed, ", "))
+	}
+	return cancelled, nil
+}
+
+// cancelAllExchangeOrders cancels the orders of each enabled asset type of an
+// exchange
+func cancelAllExchangeOrders(ctx context.Context, exch exchange.IBotExchange) (int64, error) {
+	var cancelled int64
+	var errs []string
+	assets := exch.GetAssetTypes(true)
+	for i := range assets {
+		resp, err := exch.CancelAllOrders(ctx, &order.Cancel{
+			Exchange:  exch.GetName(),
+			AssetType: assets[i],
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", assets[i], err))
+			continue
+		}
+		if resp.Count > 0 {
+			cancelled += resp.Count
+		} else {
+			cancelled += int64(len(resp.Status))
+		}
+	}
+	if len(errs) > 0 {
+		return cancelled, fmt.Errorf("%w %s", errCancelAllFailed, strings.Join(errs, ", "))
+	}
+	return cancelled, nil
+}
+
+// findLimit returns the index of the risk limit of an exchange, account and
+// sub-account or -1 when not found. The caller must hold the lock
+func (r *riskControls) findLimit(exchangeName, accountName, subAccount string) int {
+	for i := range r.limits.Exchanges {
+		if strings.EqualFold(r.limits.Exchanges[i].Exchange, exchangeName) &&
+			r.limits.Exchanges[i].Account == accountName &&
+			strings.EqualFold(r.limits.Exchanges[i].SubAccount, subAccount) {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkRiskLimits rejects orders when the kill switch is engaged or an order
+// would breach the risk limit of its exchange, account and sub-account. When
+// an order modification is checked, replacing is the ID of the modified order
+// which is excluded from open orders and positions. Rejections are audited
+func (m *OrderManager) checkRiskLimits(ctx context.Context, exch exchange.IBotExchange, newOrder *order.Submit, replacing string) error {
+	m.risk.m.RLock()
+	killSwitch := m.risk.killSwitch
+	enabled := m.risk.limits.Enabled
+	m.risk.m.RUnlock()
+	var err error
+	switch {
+	case killSwitch:
+		err = errKillSwitchEngaged
+	case enabled:
+		var subAccount string
+		if creds, credErr := exch.GetCredentials(ctx); credErr == nil {
+			subAccount = creds.SubAccount
+		}
+		accountName := account.GetAccountFromContext(ctx)
+		err = m.checkRiskLimit(newOrder, accountName, replacing, m.getRiskLimit(newOrder.Exchange, accountName, subAccount))
+	}
+	if err == nil {
+		return nil
+	}
+	msg := fmt.Sprintf("Order rejected for %s %s %s %s amount %v price %v: %v",
+		newOrder.Exchange,
+		newOrder.AssetType,
+		newOrder.Pair,
+		newOrder.Side,
+		newOrder.Amount,
+		newOrder.Price,
+		err)
+	log.Warnln(log.OrderMgr, msg)
+	audit.Event(newOrder.Exchange, riskAuditType, msg)
+	return fmt.Errorf("order manager: %w", err)
+}
+
+// getRiskLimit returns the most specific risk limit of an account and
+// sub-account, falling back to the exchange and then the default risk limit
+func (m *OrderManager) getRiskLimit(exchangeName, accountName, subAccount string) config.RiskLimit {
+	m.risk.m.RLock()
+	defer m.risk.m.RUnlock()
+	// Limits are matched from the most to the least specific
+	keys := [][2]string{
+		{accountName, subAccount},
+		{accountName, ""},
+		{"", subAccount},
+		{"", ""},
+	}
+	for i := range keys {
+		if j := m.risk.findLimit(exchangeName, keys[i][0], keys[i][1]); j != -1 {
+			return m.risk.limits.Exchanges[j].RiskLimit
+		}
+	}
+	return m.risk.limits.Default
+}
+
+// checkRiskLimit checks an order placed with an exchange account against each
+// enabled control of a risk limit. Open orders, positions and losses are
+// limited to orders of the same account, excluding the replaced order
+func (m *OrderManager) checkRiskLimit(newOrder *order.Submit, accountName, replacing string, limit config.RiskLimit) error {
+	var midPrice float64
+	if limit.FatFingerPercentage > 0 || limit.MaxOrderNotional > 0 || limit.MaxDailyLoss > 0 {
+		depth, err := orderbook.GetDepth(newOrder.Exchange, newOrder.Pair, newOrder.AssetType)
+		if err == nil {
+			midPrice, _ = depth.GetMidPrice()
+		}
+	}
+
+	if limit.FatFingerPercentage > 0 && newOrder.Price > 0 {
+		if midPrice <= 0 {
+			return fmt.Errorf("%w: orderbook mid price for fat finger check", errReferencePriceNotFound)
+		}
+		if deviation := math.Abs(newOrder.Price-midPrice) / midPrice * 100; deviation > limit.FatFingerPercentage {
+			return fmt.Errorf("%w: price %v is %.2f%% from orderbook mid price %v, exceeding fat finger limit of %v%%",
+				errRiskLimitBreached, newOrder.Price, deviation, midPrice, limit.FatFingerPercentage)
+		}
+	}
+
+	if limit.PriceBandPercentage > 0 && newOrder.Price > 0 {
+		reference, err := getTickerMidPrice(newOrder)
+		if err != nil {
+			return err
+		}
+		if deviation := math.Abs(newOrder.Price-reference) / reference * 100; deviation > limit.PriceBandPercentage {
+			return fmt.Errorf("%w: price %v is %.2f%% from reference mid price %v, outside price band of %v%%",
+				errRiskLimitBreached, newOrder.Price, deviation, reference, limit.PriceBandPercentage)
+		}
+	}
+
+	if limit.MaxOrderNotional > 0 {
+		notional := newOrder.QuoteAmount
+		if notional <= 0 {
+			price := newOrder.Price
+			if price <= 0 {
+				price = midPrice
+			}
+			if price <= 0 {
+				return fmt.Errorf("%w: orderbook mid price for order notional", errReferencePriceNotFound)
+			}
+			notional = newOrder.Amount * price
+		}
+		notionalCurrency := newOrder.Pair.Quote
+		if limit.NotionalCurrency != "" {
+			notionalCurrency = currency.NewCode(limit.NotionalCurrency)
+			var err error
+			notional, err = convertNotional(newOrder.Exchange, notional, newOrder.Pair.Quote, notionalCurrency)
+			if err != nil {
+				return err
+			}
+		}
+		if notional > limit.MaxOrderNotional {
+			return fmt.Errorf("%w: order notional %v %s exceeds limit of %v %s",
+				errRiskLimitBreached, notional, notionalCurrency, limit.MaxOrderNotional, notionalCurrency)
+		}
+	}
+
+	if limit.MaxOpenOrders > 0 {
+		var open int64
+		active := m.orderStore.getActiveOrders(&order.Filter{Exchange: newOrder.Exchange})
+		for i := range active {
+			if active[i].Account == accountName && active[i].OrderID != replacing {
+				open++
+			}
+		}
+		if open >= limit.MaxOpenOrders {
+			return fmt.Errorf("%w: %d open orders reaches limit of %d",
+				errRiskLimitBreached, open, limit.MaxOpenOrders)
+		}
+	}
+
+	if limit.MaxPositionPerPair > 0 {
+		position := m.projectedPosition(newOrder, accountName, replacing)
+		if math.Abs(position) > limit.MaxPositionPerPair {
+			return fmt.Errorf("%w: projected %s position %v exceeds limit of %v",
+				errRiskLimitBreached, newOrder.Pair, position, limit.MaxPositionPerPair)
+		}
+	}
+
+	if limit.MaxDailyLoss > 0 {
+		loss := m.dailyLoss(newOrder.Exchange, accountName, newOrder.Pair.Quote)
+		if loss >= limit.MaxDailyLoss {
+			return fmt.Errorf("%w: daily %s loss %v reaches limit of %v",
+				errRiskLimitBreached, newOrder.Pair.Quote, loss, limit.MaxDailyLoss)
+		}
+	}
+	return nil
+}
+
+// getTickerMidPrice returns the mid price of the latest ticker of an order's
+// pair, falling back to the last price
+func getTickerMidPrice(newOrder *order.Submit) (float64, error) {
+	t, err := ticker.GetTicker(newOrder.Exchange, newOrder.Pair, newOrder.AssetType)
+	if err != nil {
+		return 0, fmt.Errorf("%w: ticker for price band check %v", errReferencePriceNotFound, err)
+	}
+	if t.Bid > 0 && t.Ask > 0 {
+		return (t.Bid + t.Ask) / 2, nil
+	}
+	if t.Last > 0 {
+		return t.Last, nil
+	}
+	return 0, fmt.Errorf("%w: ticker for price band check has no prices", errReferencePriceNotFound)
+}
+
+// convertNotional converts an order notional from the quote currency of its
+// pair into the notional currency of a risk limit. Fiat currencies use forex
+// rates and other currencies use the last ticker price of a pair of both
+// currencies on the same exchange
+func convertNotional(exchangeName string, notional float64, from, to currency.Code) (float64, error) {
+	if from.Equal(to) {
+		return notional, nil
+	}
+	if from.IsFiatCurrency() && to.IsFiatCurrency() {
+		converted, err := currency.ConvertFiat(notional, from, to)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s to %s rate for order notional %v", errReferencePriceNotFound, from, to, err)
+		}
+		return converted, nil
+	}
+	if t, err := ticker.GetTicker(exchangeName, currency.NewPair(from, to), asset.Spot); err == nil && t.Last > 0 {
+		return notional * t.Last, nil
+	}
+	if t, err := ticker.GetTicker(exchangeName, currency.NewPair(to, from), asset.Spot); err == nil && t.Last > 0 {
+		return notional / t.Last, nil
+	}
+	return 0, fmt.Errorf("%w: %s to %s rate for order notional", errReferencePriceNotFound, from, to)
+}
+
+// signedAmount returns an amount as negative for short sides
+func signedAmount(side order.Side, amount float64) float64 {
+	if side.IsShort() {
+		return -amount
+	}
+	return amount
+}
+
+// projectedPosition returns the net executed amount of an order's exchange
+// account, asset and pair, including the remaining amount of open orders on the
+// same side and the order itself. The remaining amount of the replaced order is
+// excluded as the order itself takes its place
+func (m *OrderManager) projectedPosition(newOrder *order.Submit, accountName, replacing string) float64 {
+	position := signedAmount(newOrder.Side, newOrder.Amount)
+	m.orderStore.m.RLock()
+	defer m.orderStore.m.RUnlock()
+	orders := m.orderStore.Orders[strings.ToLower(newOrder.Exchange)]
+	for i := range orders {
+		if orders[i].Account != accountName ||
+			orders[i].AssetType != newOrder.AssetType ||
+			!orders[i].Pair.Equal(newOrder.Pair) {
+			continue
+		}
+		position += signedAmount(orders[i].Side, orders[i].ExecutedAmount)
+		if orders[i].IsActive() &&
+			orders[i].OrderID != replacing &&
+			orders[i].Side.IsShort() == newOrder.Side.IsShort() {
+			position += signedAmount(orders[i].Side, orders[i].Amount-orders[i].ExecutedAmount)
+		}
+	}
+	return position
+}
+
+// dailyLoss returns the loss of orders placed with an exchange account since
+// midnight UTC for pairs quoted in a currency. Net amounts are valued at the
+// orderbook mid price, falling back to the last execution price
+func (m *OrderManager) dailyLoss(exchangeName, accountName string, quote currency.Code) float64 {
+	type pairValue struct {
+		net   float64
+		price float64
+		order *order.Detail
+	}
+	midnight := time.Now().UTC().Truncate(time.Hour * 24)
+	var cash float64
+	values := make(map[string]*pairValue)
+	m.orderStore.m.RLock()
+	orders := m.orderStore.Orders[strings.ToLower(exchangeName)]
+	for i := range orders {
+		if orders[i].Account != accountName ||
+			orders[i].ExecutedAmount <= 0 ||
+			orders[i].Date.Before(midnight) ||
+			!orders[i].Pair.Quote.Equal(quote) {
+			continue
+		}
+		price := orders[i].AverageExecutedPrice
+		if price <= 0 {
+			price = orders[i].Price
+		}
+		cash -= signedAmount(orders[i].Side, orders[i].ExecutedAmount) * price
+		if orders[i].FeeAsset.IsEmpty() || orders[i].FeeAsset.Equal(quote) {
+			cash -= orders[i].Fee
+		}
+		key := orders[i].AssetType.String() + orders[i].Pair.String()
+		v, ok := values[key]
+		if !ok {
+			v = &pairValue{order: orders[i]}
+			values[key] = v
+		}
+		v.net += signedAmount(orders[i].Side, orders[i].ExecutedAmount)
+		v.price = price
+	}
+	m.orderStore.m.RUnlock()
+
+	pnl := cash
+	for _, v := range values {
+		if v.net == 0 {
+			continue
+		}
+		price := v.price
+		if depth, err := orderbook.GetDepth(exchangeName, v.order.Pair, v.order.AssetType); err == nil {
+			if mid, err := depth.GetMidPrice(); err == nil && mid > 0 {
+				price = mid
+			}
+		}
+		pnl += v.net * price
+	}
+	if pnl >= 0 {
+		return 0
+	}
+	return -pnl
+}
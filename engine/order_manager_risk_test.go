@@ -0,0 +1,515 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+var riskPair = currency.NewPair(currency.NewCode("RISK"), currency.USD)
+
+// fRiskExchange overrides credentials and cancelling all orders
+type fRiskExchange struct {
+	exchange.IBotExchange
+	subAccount string
+	cancelled  *int64
+}
+
+func (f fRiskExchange) GetCredentials(context.Context) (*account.Credentials, error) {
+	return &account.Credentials{SubAccount: f.subAccount}, nil
+}
+
+func (f fRiskExchange) CancelAllOrders(context.Context, *order.Cancel) (order.CancelAllResponse, error) {
+	*f.cancelled += 2
+	return order.CancelAllResponse{Count: 2}, nil
+}
+
+func (f fRiskExchange) ModifyOrder(_ context.Context, action *order.Modify) (*order.ModifyResponse, error) {
+	return action.DeriveModifyResponse()
+}
+
+func setupRiskOrderManager(t *testing.T, name, subAccount string) (*OrderManager, fRiskExchange) {
+	t.Helper()
+	var wg sync.WaitGroup
+	em := SetupExchangeManager()
+	b, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	b.GetBase().Name = name
+	b.GetBase().Enabled = true
+	b.GetBase().API.AuthenticatedSupport = true
+	err = b.GetBase().CurrencyPairs.SetAssetEnabled(asset.Spot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch := fRiskExchange{IBotExchange: b, subAccount: subAccount, cancelled: new(int64)}
+	em.Add(exch)
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &wg, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	return m, exch
+}
+
+func TestSetRiskLimits(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	err := m.SetRiskLimits(nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, _, err = m.GetRiskLimits()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	m, _ = setupRiskOrderManager(t, "RiskSetLimits", "")
+	err = m.SetRiskLimits(&config.RiskLimits{Default: config.RiskLimit{MaxDailyLoss: -1}})
+	if !errors.Is(err, errInvalidRiskLimit) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRiskLimit)
+	}
+	err = m.SetRiskLimits(&config.RiskLimits{Exchanges: []config.ExchangeRiskLimit{{}}})
+	if !errors.Is(err, ErrExchangeNameIsEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNameIsEmpty)
+	}
+	err = m.SetRiskLimits(&config.RiskLimits{Exchanges: []config.ExchangeRiskLimit{{Exchange: "RiskSetLimits", RiskLimit: config.RiskLimit{MaxOpenOrders: -1}}}})
+	if !errors.Is(err, errInvalidRiskLimit) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRiskLimit)
+	}
+	cfg := &config.RiskLimits{
+		Enabled:   true,
+		Default:   config.RiskLimit{MaxOpenOrders: 10},
+		Exchanges: []config.ExchangeRiskLimit{{Exchange: "RiskSetLimits", RiskLimit: config.RiskLimit{MaxOpenOrders: 5}}},
+	}
+	err = m.SetRiskLimits(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cfg.Exchanges[0].MaxOpenOrders = 1
+	limits, killSwitch, err := m.GetRiskLimits()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !limits.Enabled || killSwitch || limits.Default.MaxOpenOrders != 10 ||
+		len(limits.Exchanges) != 1 || limits.Exchanges[0].MaxOpenOrders != 5 {
+		t.Fatalf("unexpected risk limits %+v", limits)
+	}
+}
+
+func TestSetRiskLimit(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	err := m.SetRiskLimit("", "", "", config.RiskLimit{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	m, _ = setupRiskOrderManager(t, "RiskSetLimit", "")
+	err = m.SetRiskLimit("", "", "", config.RiskLimit{MaxOrderNotional: -1})
+	if !errors.Is(err, errInvalidRiskLimit) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRiskLimit)
+	}
+	err = m.SetRiskLimit("", "", "sub", config.RiskLimit{})
+	if !errors.Is(err, ErrExchangeNameIsEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNameIsEmpty)
+	}
+	for _, l := range []struct {
+		exchange, subAccount string
+		maxOpenOrders        int64
+	}{
+		{"", "", 1},
+		{"RiskSetLimit", "", 2},
+		{"RiskSetLimit", "sub", 3},
+		{"risksetlimit", "SUB", 4},
+	} {
+		err = m.SetRiskLimit(l.exchange, "", l.subAccount, config.RiskLimit{MaxOpenOrders: l.maxOpenOrders})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	limits, _, err := m.GetRiskLimits()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !limits.Enabled || len(limits.Exchanges) != 2 {
+		t.Fatalf("unexpected risk limits %+v", limits)
+	}
+	if l := m.getRiskLimit("RiskSetLimit", "", "sub"); l.MaxOpenOrders != 4 {
+		t.Errorf("received: '%v' but expected: '%v'", l.MaxOpenOrders, 4)
+	}
+	if l := m.getRiskLimit("RiskSetLimit", "", "other"); l.MaxOpenOrders != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", l.MaxOpenOrders, 2)
+	}
+	if l := m.getRiskLimit("Other", "", ""); l.MaxOpenOrders != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", l.MaxOpenOrders, 1)
+	}
+}
+
+func TestCheckRiskLimit(t *testing.T) {
+	t.Parallel()
+	name := "RiskCheckLimit"
+	m, _ := setupRiskOrderManager(t, name, "")
+	o := &order.Submit{Exchange: name, Pair: riskPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Amount: 1, Price: 110}
+
+	err := m.checkRiskLimit(o, "", "", config.RiskLimit{FatFingerPercentage: 5})
+	if !errors.Is(err, errReferencePriceNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errReferencePriceNotFound)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{PriceBandPercentage: 5})
+	if !errors.Is(err, errReferencePriceNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errReferencePriceNotFound)
+	}
+
+	err = (&orderbook.Base{
+		Exchange: name,
+		Pair:     riskPair,
+		Asset:    asset.Spot,
+		Bids:     []orderbook.Item{{Price: 99, Amount: 10}},
+		Asks:     []orderbook.Item{{Price: 101, Amount: 10}},
+	}).Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = ticker.ProcessTicker(&ticker.Price{ExchangeName: name, Pair: riskPair, AssetType: asset.Spot, Bid: 104, Ask: 106, Last: 105})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{FatFingerPercentage: 5})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	o.Price = 104
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{FatFingerPercentage: 5})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	o.Price = 100
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{PriceBandPercentage: 2})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	o.Price = 104
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{PriceBandPercentage: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	o.Amount = 11
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOrderNotional: 1000})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	market := &order.Submit{Exchange: name, Pair: riskPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Market, Amount: 5}
+	err = m.checkRiskLimit(market, "", "", config.RiskLimit{MaxOrderNotional: 1000})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	market.QuoteAmount = 2000
+	err = m.checkRiskLimit(market, "", "", config.RiskLimit{MaxOrderNotional: 1000})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+
+	// A filled buy of 3 at 110 today with a mid price of 100 is a loss of 30
+	// and the active buy leaves a remaining 1
+	for _, d := range []order.Detail{
+		{OrderID: "filled", Status: order.Filled, Side: order.Buy, Amount: 3, ExecutedAmount: 3, AverageExecutedPrice: 110, Date: time.Now()},
+		{OrderID: "active", Status: order.Active, Side: order.Buy, Amount: 1, Price: 90, Date: time.Now()},
+		{OrderID: "old", Status: order.Filled, Side: order.Sell, Amount: 1, ExecutedAmount: 1, Price: 50, Date: time.Now().AddDate(0, 0, -2)},
+	} {
+		d := d
+		d.Exchange = name
+		d.Pair = riskPair
+		d.AssetType = asset.Spot
+		err = m.orderStore.add(&d)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOpenOrders: 1})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOpenOrders: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	o.Amount = 2
+	if position := m.projectedPosition(o, "", ""); position != 5 {
+		t.Fatalf("received: '%v' but expected: '%v'", position, 5)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxPositionPerPair: 4})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	o.Side = order.Sell
+	if position := m.projectedPosition(o, "", ""); position != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", position, 0)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxPositionPerPair: 4})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	if loss := m.dailyLoss(name, "", currency.USD); loss != 30 {
+		t.Fatalf("received: '%v' but expected: '%v'", loss, 30)
+	}
+	if loss := m.dailyLoss(name, "", currency.BTC); loss != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", loss, 0)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxDailyLoss: 30})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxDailyLoss: 50})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestCheckRiskLimits(t *testing.T) {
+	t.Parallel()
+	name := "RiskCheckLimits"
+	m, exch := setupRiskOrderManager(t, name, "sub")
+	o := &order.Submit{Exchange: name, Pair: riskPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Amount: 1, Price: 100}
+	err := m.checkRiskLimits(context.Background(), exch, o, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.SetRiskLimits(&config.RiskLimits{
+		Enabled:   true,
+		Default:   config.RiskLimit{MaxOrderNotional: 1000},
+		Exchanges: []config.ExchangeRiskLimit{{Exchange: name, SubAccount: "sub", RiskLimit: config.RiskLimit{MaxOrderNotional: 50}}},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.checkRiskLimits(context.Background(), exch, o, "")
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	exch.subAccount = ""
+	err = m.checkRiskLimits(context.Background(), exch, o, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestSetKillSwitch(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	_, err := m.SetKillSwitch(context.Background(), true)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	name := "RiskKillSwitch"
+	m, exch := setupRiskOrderManager(t, name, "")
+	cancelled, err := m.SetKillSwitch(context.Background(), true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cancelled == 0 || cancelled != *exch.cancelled {
+		t.Fatalf("received: '%v' but expected: '%v'", cancelled, *exch.cancelled)
+	}
+	_, killSwitch, err := m.GetRiskLimits()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !killSwitch {
+		t.Fatal("expected kill switch to be engaged")
+	}
+	_, err = m.Submit(context.Background(), &order.Submit{
+		Exchange:  name,
+		Pair:      riskPair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     100,
+	})
+	if !errors.Is(err, errKillSwitchEngaged) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errKillSwitchEngaged)
+	}
+	cancelled, err = m.SetKillSwitch(context.Background(), false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cancelled != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", cancelled, 0)
+	}
+	err = m.checkRiskLimits(context.Background(), exch, &order.Submit{Exchange: name}, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestRiskLimitAccounts(t *testing.T) {
+	t.Parallel()
+	name := "RiskAccounts"
+	m, _ := setupRiskOrderManager(t, name, "")
+	for _, l := range []struct {
+		account, subAccount string
+		maxOpenOrders       int64
+	}{
+		{"", "", 1},
+		{"alpha", "", 2},
+		{"alpha", "sub", 3},
+		{"", "sub", 4},
+	} {
+		err := m.SetRiskLimit(name, l.account, l.subAccount, config.RiskLimit{MaxOpenOrders: l.maxOpenOrders})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	for _, tt := range []struct {
+		account, subAccount string
+		expected            int64
+	}{
+		{"", "", 1},
+		{"alpha", "", 2},
+		{"alpha", "sub", 3},
+		{"beta", "sub", 4},
+		{"beta", "", 1},
+	} {
+		if l := m.getRiskLimit(name, tt.account, tt.subAccount); l.MaxOpenOrders != tt.expected {
+			t.Errorf("%s/%s received: '%v' but expected: '%v'", tt.account, tt.subAccount, l.MaxOpenOrders, tt.expected)
+		}
+	}
+
+	err := m.orderStore.add(&order.Detail{
+		Exchange:  name,
+		OrderID:   "alpha",
+		Pair:      riskPair,
+		AssetType: asset.Spot,
+		Status:    order.Active,
+		Side:      order.Buy,
+		Amount:    3,
+		Price:     90,
+		Date:      time.Now(),
+		Account:   "alpha",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	o := &order.Submit{Exchange: name, Pair: riskPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Amount: 1, Price: 90}
+	if position := m.projectedPosition(o, "", ""); position != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", position, 1)
+	}
+	if position := m.projectedPosition(o, "alpha", ""); position != 4 {
+		t.Fatalf("received: '%v' but expected: '%v'", position, 4)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOpenOrders: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.checkRiskLimit(o, "alpha", "", config.RiskLimit{MaxOpenOrders: 1})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+}
+
+func TestConvertNotional(t *testing.T) {
+	t.Parallel()
+	name := "RiskConvertNotional"
+	m, _ := setupRiskOrderManager(t, name, "")
+	o := &order.Submit{Exchange: name, Pair: riskPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Amount: 1, Price: 100}
+	err := m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOrderNotional: 0.01, NotionalCurrency: "BTC"})
+	if !errors.Is(err, errReferencePriceNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errReferencePriceNotFound)
+	}
+	err = ticker.ProcessTicker(&ticker.Price{ExchangeName: name, Pair: currency.NewPair(currency.BTC, currency.USD), AssetType: asset.Spot, Last: 20000})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	notional, err := convertNotional(name, 100, currency.USD, currency.BTC)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if notional != 0.005 {
+		t.Fatalf("received: '%v' but expected: '%v'", notional, 0.005)
+	}
+	notional, err = convertNotional(name, 1, currency.BTC, currency.USD)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if notional != 20000 {
+		t.Fatalf("received: '%v' but expected: '%v'", notional, 20000)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOrderNotional: 0.001, NotionalCurrency: "btc"})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOrderNotional: 0.01, NotionalCurrency: "BTC"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.checkRiskLimit(o, "", "", config.RiskLimit{MaxOrderNotional: 150, NotionalCurrency: "USD"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestModifyRiskLimits(t *testing.T) {
+	t.Parallel()
+	name := "RiskModify"
+	m, _ := setupRiskOrderManager(t, name, "")
+	err := m.orderStore.add(&order.Detail{
+		Exchange:  name,
+		OrderID:   "modify",
+		Pair:      riskPair,
+		AssetType: asset.Spot,
+		Status:    order.Active,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     100,
+		Date:      time.Now(),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.SetRiskLimits(&config.RiskLimits{
+		Enabled: true,
+		Default: config.RiskLimit{MaxOrderNotional: 500, MaxOpenOrders: 1, MaxPositionPerPair: 3},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// The modified order does not count towards open orders or positions
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: name, OrderID: "modify", Amount: 3})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: name, OrderID: "modify", Amount: 4})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: name, OrderID: "modify", Price: 200})
+	if !errors.Is(err, errRiskLimitBreached) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRiskLimitBreached)
+	}
+	_, err = m.SetKillSwitch(context.Background(), true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: name, OrderID: "modify", Amount: 1})
+	if !errors.Is(err, errKillSwitchEngaged) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errKillSwitchEngaged)
+	}
+}
@@ -3,6 +3,8 @@ package engine
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -18,6 +20,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
@@ -642,6 +645,109 @@ func TestSubmit(t *testing.T) {
 	}
 }
 
+func TestSubmitCircuitOpen(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	var wg sync.WaitGroup
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &wg, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	err = exch.GetBase().API.Endpoints.SetRunning(exchange.RestSpot.String(), srv.URL)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	requester := exch.GetBase().Requester
+	err = requester.SetCircuitBreaker(request.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = requester.SendPayload(context.Background(), request.Unset, func() (*request.Item, error) {
+		return &request.Item{Method: http.MethodGet, Path: srv.URL}, nil
+	})
+	if err == nil {
+		t.Fatal("expected error from unavailable exchange")
+	}
+
+	_, err = m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+	})
+	var openErr *request.CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, request.ErrCircuitOpen)
+	}
+	if openErr.Exchange != exch.GetName() {
+		t.Errorf("received: '%v' but expected: '%v'", openErr.Exchange, exch.GetName())
+	}
+}
+
+func TestCheckExchangeCircuit(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	err = b.API.Endpoints.SetDefaultEndpoints(map[exchange.URL]string{
+		exchange.RestSpot:         "https://spot.example.com",
+		exchange.RestUSDTMargined: "https://futures.example.com",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	hosts := exchangeAssetHosts(b, asset.USDTMarginedFutures)
+	if len(hosts) != 1 || hosts[0] != "futures.example.com" {
+		t.Errorf("received: '%v' but expected: '%v'", hosts, "futures.example.com")
+	}
+	hosts = exchangeAssetHosts(b, asset.Margin)
+	if len(hosts) == 0 || hosts[0] != "spot.example.com" {
+		t.Errorf("received: '%v' but expected: '%v'", hosts, "spot.example.com")
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	err = b.API.Endpoints.SetRunning(exchange.RestUSDTMargined.String(), srv.URL)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = b.Requester.SetCircuitBreaker(request.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_ = b.Requester.SendPayload(context.Background(), request.Unset, func() (*request.Item, error) {
+		return &request.Item{Method: http.MethodGet, Path: srv.URL}, nil
+	})
+	err = checkExchangeCircuit(exch, asset.USDTMarginedFutures)
+	if !errors.Is(err, request.ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, request.ErrCircuitOpen)
+	}
+	// A degraded futures endpoint does not block spot requests
+	err = checkExchangeCircuit(exch, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
 func TestOrderManager_Modify(t *testing.T) {
 	pair := currency.Pair{
 		Base:  currency.NewCode("XXXXX"),
@@ -1328,6 +1434,53 @@ func TestSubmitFakeOrder(t *testing.T) {
 	}
 }
 
+func TestProcessSubmittedOrderAccount(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	var wg sync.WaitGroup
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &wg, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	ord := &order.Submit{
+		Exchange:  testExchange,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Amount:    1,
+		Price:     1,
+	}
+	resp, err := ord.DeriveSubmitResponse("account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.processSubmittedOrder(resp, "alpha")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	det, err := m.orderStore.getByExchangeAndID(testExchange, "account")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if det.Account != "alpha" {
+		t.Fatalf("received: '%v' but expected: '%v'", det.Account, "alpha")
+	}
+	if orders, err := m.GetOrdersFiltered(&order.Filter{Exchange: testExchange, Account: "beta"}); err != nil || len(orders) != 0 {
+		t.Fatalf("unexpected orders %v %v", orders, err)
+	}
+	if orders, err := m.GetOrdersFiltered(&order.Filter{Exchange: testExchange, Account: "alpha"}); err != nil || len(orders) != 1 {
+		t.Fatalf("unexpected orders %v %v", orders, err)
+	}
+}
+
 func TestGetOrdersSnapshot(t *testing.T) {
 	t.Parallel()
 	o := &OrderManager{}
@@ -5,7 +5,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/managedorder"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -23,6 +26,12 @@ var (
 
 	errNilCommunicationsManager = errors.New("cannot start with nil communications manager")
 	errNilOrder                 = errors.New("nil order received")
+	errInvalidPersistencePeriod = errors.New("invalid order persistence period")
+	errRiskLimitBreached        = errors.New("pre-trade risk limit breached")
+	errKillSwitchEngaged        = errors.New("kill switch engaged")
+	errInvalidRiskLimit         = errors.New("risk limit values cannot be negative")
+	errReferencePriceNotFound   = errors.New("reference price not found")
+	errCancelAllFailed          = errors.New("unable to cancel all orders")
 	errFuturesTrackingDisabled  = errors.New("tracking futures positions disabled. enable it via config under orderManager activelyTrackFuturesPositions")
 	orderManagerDelay           = time.Second * 10
 	defaultOrderSeekTime        = -time.Hour * 24 * 365
@@ -48,6 +57,33 @@ type OrderManager struct {
 	verbose                       bool
 	activelyTrackFuturesPositions bool
 	futuresPositionSeekDuration   time.Duration
+	persistence                   orderPersistence
+	risk                          riskControls
+}
+
+// riskControls holds the pre-trade risk limits and kill switch state applied
+// to submitted orders
+type riskControls struct {
+	m          sync.RWMutex
+	killSwitch bool
+	limits     config.RiskLimits
+}
+
+// orderPersistence holds the database service used to save managed orders and
+// futures position snapshots
+type orderPersistence struct {
+	db               managedorder.IDBService
+	restorePeriod    time.Duration
+	snapshotInterval time.Duration
+	lastSnapshot     time.Time
+	openPositions    map[positionKey]struct{}
+}
+
+// positionKey identifies the futures positions for an exchange, asset and pair
+type positionKey struct {
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
 }
 
 // store holds all orders by exchange
@@ -58,6 +94,17 @@ type store struct {
 	exchangeManager           iExchangeManager
 	wg                        *sync.WaitGroup
 	futuresPositionController order.PositionController
+	// dirty holds orders which have changed since they were last saved and
+	// is nil when persistence is disabled
+	dirty map[*order.Detail]struct{}
+}
+
+// OrderQueueStats holds the size of the order manager queues. Tracked and
+// Active are keyed by lower case exchange name
+type OrderQueueStats struct {
+	Tracked            map[string]int
+	Active             map[string]int
+	PendingPersistence int
 }
 
 // OrderSubmitResponse contains the order response along with an internal order ID
@@ -0,0 +1,698 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+// SetupPortfolioHistoryManager creates a new portfolio history manager from
+// config. The database must be connected. The portfolio manager is optional
+// and provides wallet holdings.
+func SetupPortfolioHistoryManager(cfg *config.PortfolioHistory, exchangeManager iExchangeManager, portfolioManager iPortfolioAddressProvider, dbManager iDatabaseConnectionManager, verbose bool) (*PortfolioHistoryManager, error) {
+	if cfg == nil {
+		return nil, errNilPortfolioHistoryConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if dbManager == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	valueCurrency := cfg.ValueCurrency
+	if valueCurrency.IsEmpty() {
+		valueCurrency = currency.USD
+	}
+	if !valueCurrency.IsFiatCurrency() {
+		return nil, fmt.Errorf("%w received %s", errValueCurrencyNotFiat, valueCurrency)
+	}
+	db, err := portfoliohistory.Setup(dbManager.GetInstance())
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, fmt.Errorf("%s %w", PortfolioHistoryManagerName, database.ErrDatabaseNotConnected)
+	}
+	m := &PortfolioHistoryManager{
+		verbose:          verbose || cfg.Verbose,
+		interval:         cfg.Interval,
+		valueCurrency:    valueCurrency,
+		exchanges:        cfg.Exchanges,
+		exchangeManager:  exchangeManager,
+		portfolioManager: portfolioManager,
+		db:               db,
+		shutdown:         make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DefaultPortfolioHistoryInterval
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *PortfolioHistoryManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *PortfolioHistoryManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.PortfolioMgr, "Portfolio history manager %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.PortfolioMgr, "Portfolio history manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *PortfolioHistoryManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.PortfolioMgr, "Portfolio history manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.PortfolioMgr, "Portfolio history manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run saves a snapshot on start and at every interval
+func (m *PortfolioHistoryManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	for {
+		snapshot, err := m.snapshot(context.TODO())
+		if err != nil {
+			log.Errorf(log.PortfolioMgr, "Portfolio history manager cannot save snapshot: %v", err)
+		} else if m.verbose {
+			log.Debugf(log.PortfolioMgr, "Portfolio history manager saved snapshot with %d holdings valued at %f %s",
+				len(snapshot.Holdings), snapshot.Value, snapshot.ValueCurrency)
+		}
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Snapshot values the current holdings of exchange accounts and portfolio
+// wallets and saves them, along with any new exchange deposits and
+// withdrawals, to the database
+func (m *PortfolioHistoryManager) Snapshot(ctx context.Context) (*portfoliohistory.Snapshot, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	return m.snapshot(ctx)
+}
+
+// snapshot values and saves the current holdings
+func (m *PortfolioHistoryManager) snapshot(ctx context.Context) (*portfoliohistory.Snapshot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	exchanges, err := m.getExchanges()
+	if err != nil {
+		return nil, err
+	}
+	pricer := newPortfolioPricer(m.exchangeManager, m.valueCurrency)
+	snapshot := &portfoliohistory.Snapshot{
+		Timestamp:     time.Now().UTC(),
+		ValueCurrency: m.valueCurrency.String(),
+	}
+	for x := range exchanges {
+		holdings := m.getExchangeHoldings(ctx, exchanges[x])
+		snapshot.Holdings = append(snapshot.Holdings, holdings...)
+	}
+	snapshot.Holdings = append(snapshot.Holdings, m.getWalletHoldings()...)
+	for x := range snapshot.Holdings {
+		h := &snapshot.Holdings[x]
+		price, err := pricer.getPrice(currency.NewCode(h.Currency))
+		if err != nil {
+			log.Warnf(log.PortfolioMgr, "Portfolio history manager cannot value %s %s: %v", h.Exchange, h.Currency, err)
+			continue
+		}
+		h.Price = price
+		h.Value = h.Amount * price
+		snapshot.Value += h.Value
+	}
+	err = m.db.InsertSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []*portfoliohistory.Flow
+	for x := range exchanges {
+		flows = append(flows, m.getExchangeFlows(ctx, exchanges[x], pricer)...)
+	}
+	if len(flows) > 0 {
+		err = m.db.InsertFlows(flows...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// GetValueHistory returns the value of holdings at each snapshot between the
+// start and end dates, limited to an exchange or portfolio wallets when set
+func (m *PortfolioHistoryManager) GetValueHistory(start, end time.Time, exchangeName string) ([]PortfolioValue, error) {
+	snapshots, err := m.getSnapshots(start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]PortfolioValue, len(snapshots))
+	for x := range snapshots {
+		resp[x] = PortfolioValue{
+			Timestamp:     snapshots[x].Timestamp,
+			ValueCurrency: currency.NewCode(snapshots[x].ValueCurrency),
+			Value:         snapshots[x].Value,
+		}
+		if exchangeName == "" {
+			continue
+		}
+		resp[x].Value = 0
+		for y := range snapshots[x].Holdings {
+			if strings.EqualFold(snapshots[x].Holdings[y].Exchange, exchangeName) {
+				resp[x].Value += snapshots[x].Holdings[y].Value
+			}
+		}
+	}
+	return resp, nil
+}
+
+// GetFlows returns the deposits and withdrawals recorded between the start and
+// end dates, limited to an exchange when set
+func (m *PortfolioHistoryManager) GetFlows(start, end time.Time, exchangeName string) ([]portfoliohistory.Flow, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	flows, err := m.db.GetFlowsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if exchangeName == "" {
+		return flows, nil
+	}
+	resp := make([]portfoliohistory.Flow, 0, len(flows))
+	for x := range flows {
+		if strings.EqualFold(flows[x].Exchange, exchangeName) {
+			resp = append(resp, flows[x])
+		}
+	}
+	return resp, nil
+}
+
+// GetPerformance calculates the performance of holdings between the first and
+// last snapshots within the start and end dates, limited to an exchange or
+// portfolio wallets when set. Changes in exchange balances not explained by
+// recorded deposits and withdrawals are treated as trades at the snapshot
+// price, while changes in wallet balances are treated as deposits and
+// withdrawals. PnL is realised against the average cost of each currency,
+// with holdings at the first snapshot costed at their value.
+func (m *PortfolioHistoryManager) GetPerformance(start, end time.Time, exchangeName string) (*PortfolioPerformance, error) {
+	snapshots, err := m.getSnapshots(start, end)
+	if err != nil {
+		return nil, err
+	}
+	flows, err := m.GetFlows(start, end, exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	included := func(name string) bool {
+		return exchangeName == "" || strings.EqualFold(name, exchangeName)
+	}
+
+	positions := make(map[string]*assetPosition)
+	getPosition := func(code string) *assetPosition {
+		code = strings.ToUpper(code)
+		p, ok := positions[code]
+		if !ok {
+			p = &assetPosition{}
+			p.perf.Currency = currency.NewCode(code)
+			positions[code] = p
+		}
+		return p
+	}
+
+	amounts, prevWallets, prices := summariseSnapshot(&snapshots[0], included)
+	for code, amount := range amounts {
+		p := getPosition(code)
+		p.amount = amount
+		p.lastPrice = prices[code]
+		p.cost = amount * p.lastPrice
+		p.perf.StartAmount = amount
+		p.perf.StartValue = p.cost
+	}
+
+	var f int
+	for x := 1; x < len(snapshots); x++ {
+		var wallets map[string]float64
+		amounts, wallets, prices = summariseSnapshot(&snapshots[x], included)
+		for code := range amounts {
+			getPosition(code)
+		}
+		for code, p := range positions {
+			if price := prices[code]; price > 0 {
+				p.lastPrice = price
+			}
+		}
+		// Apply recorded exchange flows up to and including this snapshot
+		for ; f < len(flows) && !flows[f].Timestamp.After(snapshots[x].Timestamp); f++ {
+			if !flows[f].Timestamp.After(snapshots[0].Timestamp) {
+				continue
+			}
+			p := getPosition(flows[f].Currency)
+			price := p.lastPrice
+			if flows[f].Price > 0 && strings.EqualFold(flows[f].ValueCurrency, snapshots[x].ValueCurrency) {
+				price = flows[f].Price
+			}
+			if flows[f].Type == portfoliohistory.Deposit {
+				p.deposit(flows[f].Amount, price)
+			} else {
+				p.withdraw(flows[f].Amount, flows[f].Fee, price)
+			}
+		}
+		for code, p := range positions {
+			walletChange := wallets[code] - prevWallets[code]
+			if walletChange > 0 {
+				p.deposit(walletChange, p.lastPrice)
+			} else if walletChange < 0 {
+				p.withdraw(-walletChange, 0, p.lastPrice)
+			}
+			// The remaining change was traded on exchanges
+			traded := amounts[code] - p.amount
+			if traded > 0 {
+				p.buy(traded, p.lastPrice)
+			} else if traded < 0 {
+				p.sell(-traded, p.lastPrice)
+			}
+		}
+		prevWallets = wallets
+	}
+
+	last := &snapshots[len(snapshots)-1]
+	resp := &PortfolioPerformance{
+		Exchange:      exchangeName,
+		ValueCurrency: currency.NewCode(last.ValueCurrency),
+		Start:         snapshots[0].Timestamp,
+		End:           last.Timestamp,
+		Assets:        make([]PortfolioAssetPerformance, 0, len(positions)),
+	}
+	for _, p := range positions {
+		p.perf.EndAmount = p.amount
+		p.perf.EndValue = p.amount * p.lastPrice
+		p.perf.UnrealisedPNL = p.perf.EndValue - p.cost
+		if p.amount > 0 {
+			p.perf.AverageCost = p.cost / p.amount
+		}
+		resp.StartValue += p.perf.StartValue
+		resp.EndValue += p.perf.EndValue
+		resp.Deposits += p.perf.Deposits
+		resp.Withdrawals += p.perf.Withdrawals
+		resp.RealisedPNL += p.perf.RealisedPNL
+		resp.UnrealisedPNL += p.perf.UnrealisedPNL
+		resp.Assets = append(resp.Assets, p.perf)
+	}
+	resp.NetPerformance = resp.EndValue - resp.StartValue - resp.Deposits + resp.Withdrawals
+	sort.Slice(resp.Assets, func(i, j int) bool {
+		return resp.Assets[i].Currency.String() < resp.Assets[j].Currency.String()
+	})
+	return resp, nil
+}
+
+// checkRunning returns an error when the subsystem is nil or not running
+func (m *PortfolioHistoryManager) checkRunning() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("%s %w", PortfolioHistoryManagerName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// getSnapshots returns the snapshots between the start and end dates, oldest
+// first
+func (m *PortfolioHistoryManager) getSnapshots(start, end time.Time) ([]portfoliohistory.Snapshot, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	snapshots, err := m.db.GetSnapshotsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w between %s and %s", errNoPortfolioSnapshots,
+			start.Format(common.SimpleTimeFormatWithTimezone), end.Format(common.SimpleTimeFormatWithTimezone))
+	}
+	return snapshots, nil
+}
+
+// getExchanges returns the configured exchanges, or all enabled exchanges with
+// authenticated API support when none are configured
+func (m *PortfolioHistoryManager) getExchanges() ([]exchange.IBotExchange, error) {
+	return getAccountExchanges(m.exchangeManager, m.exchanges)
+}
+
+// getAccountExchanges returns the named exchanges, or all enabled exchanges
+// with authenticated API support when no names are provided
+func getAccountExchanges(exchangeManager iExchangeManager, names []string) ([]exchange.IBotExchange, error) {
+	if len(names) > 0 {
+		resp := make([]exchange.IBotExchange, len(names))
+		for x := range names {
+			exch, err := exchangeManager.GetExchangeByName(names[x])
+			if err != nil {
+				return nil, err
+			}
+			resp[x] = exch
+		}
+		return resp, nil
+	}
+	exchs, err := exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]exchange.IBotExchange, 0, len(exchs))
+	for x := range exchs {
+		if !exchs[x].IsEnabled() || !exchs[x].IsRESTAuthenticationSupported() {
+			continue
+		}
+		resp = append(resp, exchs[x])
+	}
+	return resp, nil
+}
+
+// getExchangeHoldings returns the non-zero balances of each account and asset
+// type of an exchange. Balances of named exchange accounts are identified by
+// the account name
+func (m *PortfolioHistoryManager) getExchangeHoldings(ctx context.Context, exch exchange.IBotExchange) []portfoliohistory.Holding {
+	assetTypes := asset.Items{asset.Spot}
+	if exch.HasAssetTypeAccountSegregation() {
+		assetTypes = exch.GetAssetTypes(true)
+	}
+	accounts := exchangeAccountNames(exch)
+	var resp []portfoliohistory.Holding
+	for a := range accounts {
+		accountCtx := account.DeployAccountToContext(ctx, accounts[a])
+		for x := range assetTypes {
+			h, err := exch.UpdateAccountInfo(accountCtx, assetTypes[x])
+			if err != nil {
+				log.Errorf(log.PortfolioMgr, "Portfolio history manager cannot retrieve %s %s account info: %v",
+					exch.GetName(), assetTypes[x], err)
+				continue
+			}
+			for y := range h.Accounts {
+				accountID := h.Accounts[y].ID
+				if accounts[a] != "" {
+					accountID = strings.TrimSpace(accounts[a] + " " + accountID)
+				}
+				for z := range h.Accounts[y].Currencies {
+					bal := &h.Accounts[y].Currencies[z]
+					if bal.Total == 0 {
+						continue
+					}
+					resp = append(resp, portfoliohistory.Holding{
+						Exchange: exch.GetName(),
+						Account:  accountID,
+						Asset:    assetTypes[x].String(),
+						Currency: bal.CurrencyName.Upper().String(),
+						Amount:   bal.Total,
+					})
+				}
+			}
+		}
+	}
+	return resp
+}
+
+// getWalletHoldings returns the balances of portfolio addresses which are not
+// exchange addresses
+func (m *PortfolioHistoryManager) getWalletHoldings() []portfoliohistory.Holding {
+	if m.portfolioManager == nil {
+		return nil
+	}
+	addresses := m.portfolioManager.GetAddresses()
+	var resp []portfoliohistory.Holding
+	for x := range addresses {
+		if addresses[x].Description == portfolio.ExchangeAddress || addresses[x].Balance == 0 {
+			continue
+		}
+		resp = append(resp, portfoliohistory.Holding{
+			Exchange: PortfolioHistoryWallet,
+			Account:  addresses[x].Address,
+			Currency: addresses[x].CoinType.Upper().String(),
+			Amount:   addresses[x].Balance,
+		})
+	}
+	return resp
+}
+
+// getExchangeFlows returns the deposits and withdrawals of an exchange valued
+// at the current price. Transfers which failed or were cancelled are ignored.
+func (m *PortfolioHistoryManager) getExchangeFlows(ctx context.Context, exch exchange.IBotExchange, pricer *portfolioPricer) []*portfoliohistory.Flow {
+	history, err := exch.GetFundingHistory(ctx)
+	if err != nil {
+		if m.verbose || !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+			log.Warnf(log.PortfolioMgr, "Portfolio history manager cannot retrieve %s funding history: %v", exch.GetName(), err)
+		}
+		return nil
+	}
+	resp := make([]*portfoliohistory.Flow, 0, len(history))
+	for x := range history {
+		flowType := fundingFlowType(&history[x])
+		if flowType == "" {
+			continue
+		}
+		code := currency.NewCode(history[x].Currency).Upper()
+		f := &portfoliohistory.Flow{
+			Exchange:      exch.GetName(),
+			TransferID:    history[x].TransferID,
+			Type:          flowType,
+			Currency:      code.String(),
+			Amount:        history[x].Amount,
+			Fee:           history[x].Fee,
+			ValueCurrency: m.valueCurrency.String(),
+			Timestamp:     history[x].Timestamp.UTC(),
+		}
+		if f.TransferID == "" {
+			f.TransferID = history[x].CryptoTxID
+		}
+		if f.TransferID == "" {
+			f.TransferID = fmt.Sprintf("%s-%s-%d-%v", flowType, f.Currency, f.Timestamp.UnixNano(), f.Amount)
+		}
+		if price, err := pricer.getPrice(code); err == nil {
+			f.Price = price
+			f.Value = f.Amount * price
+		}
+		resp = append(resp, f)
+	}
+	return resp
+}
+
+// fundingFlowType returns the flow type of a funding history entry, or an
+// empty string when it is not a completed deposit or withdrawal
+func fundingFlowType(h *exchange.FundHistory) string {
+	if transferFailed(h.Status) {
+		return ""
+	}
+	transferType := strings.ToLower(h.TransferType)
+	switch {
+	case strings.Contains(transferType, "deposit"):
+		return portfoliohistory.Deposit
+	case strings.Contains(transferType, "withdraw"):
+		return portfoliohistory.Withdrawal
+	}
+	return ""
+}
+
+// transferFailed returns whether a transfer status indicates the transfer
+// failed or was cancelled
+func transferFailed(status string) bool {
+	status = strings.ToLower(status)
+	return strings.Contains(status, "fail") ||
+		strings.Contains(status, "cancel") ||
+		strings.Contains(status, "reject")
+}
+
+// newPortfolioPricer returns a pricer valuing currencies in the value currency
+// using the tickers of the exchange manager's exchanges
+func newPortfolioPricer(exchangeManager iExchangeManager, valueCurrency currency.Code) *portfolioPricer {
+	return &portfolioPricer{
+		exchangeManager: exchangeManager,
+		valueCurrency:   valueCurrency,
+		prices:          make(map[*currency.Item]float64),
+	}
+}
+
+// getPrice returns the price of a currency in the value currency. Fiat
+// currencies are converted using forex rates, other currencies are priced
+// using tickers directly or via an intermediate currency, with stablecoins
+// falling back to their USD peg.
+func (p *portfolioPricer) getPrice(c currency.Code) (float64, error) {
+	return p.price(c, portfolioPriceDepth)
+}
+
+func (p *portfolioPricer) price(c currency.Code, depth int) (float64, error) {
+	if c.Equal(p.valueCurrency) {
+		return 1, nil
+	}
+	if price, ok := p.prices[c.Item]; ok {
+		return price, nil
+	}
+	price, err := p.lookup(c, depth)
+	if err != nil {
+		return 0, err
+	}
+	p.prices[c.Item] = price
+	return price, nil
+}
+
+func (p *portfolioPricer) lookup(c currency.Code, depth int) (float64, error) {
+	if c.IsFiatCurrency() {
+		price, err := currency.ConvertFiat(1, c, p.valueCurrency)
+		if err == nil && price > 0 {
+			return price, nil
+		}
+	}
+	if price, ok := p.tickerPrice(c, p.valueCurrency); ok {
+		return price, nil
+	}
+	if depth > 0 {
+		for x := range portfolioIntermediates {
+			if portfolioIntermediates[x].Equal(c) || portfolioIntermediates[x].Equal(p.valueCurrency) {
+				continue
+			}
+			rate, ok := p.tickerPrice(c, portfolioIntermediates[x])
+			if !ok {
+				continue
+			}
+			price, err := p.price(portfolioIntermediates[x], depth-1)
+			if err == nil {
+				return rate * price, nil
+			}
+		}
+	}
+	if c.IsStableCurrency() && depth > 0 {
+		return p.price(currency.USD, depth-1)
+	}
+	return 0, fmt.Errorf("%s %s %w", c, p.valueCurrency, errNoPortfolioPrice)
+}
+
+// tickerPrice returns the last traded spot price of a currency in a quote
+// currency from the first exchange with a ticker for the pair or its inverse
+func (p *portfolioPricer) tickerPrice(c, quote currency.Code) (float64, bool) {
+	exchs, err := p.exchangeManager.GetExchanges()
+	if err != nil {
+		return 0, false
+	}
+	for x := range exchs {
+		name := exchs[x].GetName()
+		if t, err := ticker.GetTicker(name, currency.NewPair(c, quote), asset.Spot); err == nil && t.Last > 0 {
+			return t.Last, true
+		}
+		if t, err := ticker.GetTicker(name, currency.NewPair(quote, c), asset.Spot); err == nil && t.Last > 0 {
+			return 1 / t.Last, true
+		}
+	}
+	return 0, false
+}
+
+// summariseSnapshot returns the total and wallet amounts and the price of each
+// currency held by the included exchanges
+func summariseSnapshot(s *portfoliohistory.Snapshot, included func(string) bool) (amounts, wallets, prices map[string]float64) {
+	amounts = make(map[string]float64)
+	wallets = make(map[string]float64)
+	prices = make(map[string]float64)
+	for x := range s.Holdings {
+		h := &s.Holdings[x]
+		if !included(h.Exchange) {
+			continue
+		}
+		code := strings.ToUpper(h.Currency)
+		amounts[code] += h.Amount
+		if h.Exchange == PortfolioHistoryWallet {
+			wallets[code] += h.Amount
+		}
+		if h.Price > 0 {
+			prices[code] = h.Price
+		}
+	}
+	return amounts, wallets, prices
+}
+
+// deposit adds an external inflow at its value
+func (a *assetPosition) deposit(amount, price float64) {
+	a.amount += amount
+	a.cost += amount * price
+	a.perf.Deposits += amount * price
+}
+
+// withdraw removes an external outflow at average cost, realising the
+// difference to its value. Fees are realised as a loss.
+func (a *assetPosition) withdraw(amount, fee, price float64) {
+	avg := a.averageCost()
+	a.amount -= amount + fee
+	a.cost -= avg * (amount + fee)
+	a.perf.Withdrawals += amount * price
+	a.perf.RealisedPNL += (price-avg)*amount - avg*fee
+}
+
+// buy adds a traded inflow at its value
+func (a *assetPosition) buy(amount, price float64) {
+	a.amount += amount
+	a.cost += amount * price
+	a.perf.Bought += amount * price
+}
+
+// sell removes a traded outflow at average cost, realising the difference to
+// its value
+func (a *assetPosition) sell(amount, price float64) {
+	avg := a.averageCost()
+	a.amount -= amount
+	a.cost -= avg * amount
+	a.perf.Sold += amount * price
+	a.perf.RealisedPNL += (price - avg) * amount
+}
+
+// averageCost returns the cost per unit held, or zero when nothing is held
+func (a *assetPosition) averageCost() float64 {
+	if a.amount <= 0 {
+		return 0
+	}
+	return a.cost / a.amount
+}
@@ -0,0 +1,362 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+// fPortfolioHistoryExchange overrides account info and funding history with
+// static values
+type fPortfolioHistoryExchange struct {
+	exchange.IBotExchange
+	balances []account.Balance
+	funding  []exchange.FundHistory
+}
+
+func (f *fPortfolioHistoryExchange) HasAssetTypeAccountSegregation() bool {
+	return false
+}
+
+func (f *fPortfolioHistoryExchange) UpdateAccountInfo(_ context.Context, a asset.Item) (account.Holdings, error) {
+	return account.Holdings{
+		Exchange: f.GetName(),
+		Accounts: []account.SubAccount{{ID: "main", AssetType: a, Currencies: f.balances}},
+	}, nil
+}
+
+func (f *fPortfolioHistoryExchange) GetFundingHistory(context.Context) ([]exchange.FundHistory, error) {
+	return f.funding, nil
+}
+
+// fakePortfolioHistoryDB stores snapshots and flows in memory
+type fakePortfolioHistoryDB struct {
+	snapshots []portfoliohistory.Snapshot
+	flows     []portfoliohistory.Flow
+}
+
+func (f *fakePortfolioHistoryDB) InsertSnapshot(s *portfoliohistory.Snapshot) error {
+	f.snapshots = append(f.snapshots, *s)
+	return nil
+}
+
+func (f *fakePortfolioHistoryDB) GetSnapshotsBetween(start, end time.Time) ([]portfoliohistory.Snapshot, error) {
+	var resp []portfoliohistory.Snapshot
+	for x := range f.snapshots {
+		if !f.snapshots[x].Timestamp.Before(start) && !f.snapshots[x].Timestamp.After(end) {
+			resp = append(resp, f.snapshots[x])
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakePortfolioHistoryDB) InsertFlows(flows ...*portfoliohistory.Flow) error {
+next:
+	for x := range flows {
+		for y := range f.flows {
+			if f.flows[y].Exchange == flows[x].Exchange && f.flows[y].TransferID == flows[x].TransferID {
+				continue next
+			}
+		}
+		f.flows = append(f.flows, *flows[x])
+	}
+	return nil
+}
+
+func (f *fakePortfolioHistoryDB) GetFlowsBetween(start, end time.Time) ([]portfoliohistory.Flow, error) {
+	var resp []portfoliohistory.Flow
+	for x := range f.flows {
+		if !f.flows[x].Timestamp.Before(start) && !f.flows[x].Timestamp.After(end) {
+			resp = append(resp, f.flows[x])
+		}
+	}
+	return resp, nil
+}
+
+// setupPortfolioHistoryManager returns a running manager backed by an in
+// memory database with an exchange holding the supplied balances and a BTC-USD
+// ticker of 20000
+func setupPortfolioHistoryManager(t *testing.T, exch *fPortfolioHistoryExchange, pm iPortfolioAddressProvider) (*PortfolioHistoryManager, *fakePortfolioHistoryDB) {
+	t.Helper()
+	em := SetupExchangeManager()
+	base, err := em.NewExchangeByName("ftx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.SetDefaults()
+	b := base.GetBase()
+	b.Name = "PortfolioHistory"
+	b.Enabled = true
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Spot: {
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+			RequestFormat: &currency.PairFormat{Delimiter: "/"},
+			Available:     currency.Pairs{btcusd},
+			Enabled:       currency.Pairs{btcusd},
+		},
+	}
+	exch.IBotExchange = base
+	em.Add(exch)
+	err = ticker.ProcessTicker(&ticker.Price{
+		ExchangeName: "PortfolioHistory",
+		Pair:         btcusd,
+		AssetType:    asset.Spot,
+		Last:         20000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &fakePortfolioHistoryDB{}
+	m := &PortfolioHistoryManager{
+		interval:         time.Hour,
+		valueCurrency:    currency.USD,
+		exchanges:        []string{"PortfolioHistory"},
+		exchangeManager:  em,
+		portfolioManager: pm,
+		db:               db,
+		started:          1,
+	}
+	return m, db
+}
+
+func TestSetupPortfolioHistoryManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupPortfolioHistoryManager(nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilPortfolioHistoryConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilPortfolioHistoryConfig)
+	}
+	cfg := &config.PortfolioHistory{}
+	_, err = SetupPortfolioHistoryManager(cfg, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	em := SetupExchangeManager()
+	_, err = SetupPortfolioHistoryManager(cfg, em, nil, nil, false)
+	if !errors.Is(err, errNilDatabaseConnectionManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilDatabaseConnectionManager)
+	}
+	cfg.ValueCurrency = currency.BTC
+	_, err = SetupPortfolioHistoryManager(cfg, em, nil, &DatabaseConnectionManager{}, false)
+	if !errors.Is(err, errValueCurrencyNotFiat) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errValueCurrencyNotFiat)
+	}
+	cfg.ValueCurrency = currency.EUR
+	_, err = SetupPortfolioHistoryManager(cfg, em, nil, &DatabaseConnectionManager{}, false)
+	if !errors.Is(err, database.ErrDatabaseNotConnected) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, database.ErrDatabaseNotConnected)
+	}
+}
+
+func TestPortfolioHistoryManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *PortfolioHistoryManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+	_, err = m.GetFlows(time.Now().Add(-time.Hour), time.Now(), "")
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, db := setupPortfolioHistoryManager(t, &fPortfolioHistoryExchange{
+		balances: []account.Balance{{CurrencyName: currency.BTC, Total: 1}},
+	}, nil)
+	m.started = 0
+	_, err = m.Snapshot(context.Background())
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// A snapshot is saved on start
+	if len(db.snapshots) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(db.snapshots), 1)
+	}
+}
+
+func TestPortfolioHistoryManagerSnapshot(t *testing.T) {
+	t.Parallel()
+	exch := &fPortfolioHistoryExchange{
+		balances: []account.Balance{
+			{CurrencyName: currency.BTC, Total: 1},
+			{CurrencyName: currency.USD, Total: 5000},
+			{CurrencyName: currency.LTC},
+		},
+		funding: []exchange.FundHistory{
+			{TransferID: "1", TransferType: "deposit", Currency: "btc", Amount: 1, Timestamp: time.Now()},
+			{CryptoTxID: "0x1", TransferType: "Withdrawal", Currency: "USD", Amount: 100, Fee: 1, Timestamp: time.Now()},
+			{TransferID: "3", TransferType: "withdrawal", Status: "Cancelled", Currency: "USD", Amount: 100},
+			{TransferID: "4", TransferType: "trade", Currency: "USD", Amount: 100},
+		},
+	}
+	pm := &fakeRebalancePortfolio{addresses: []portfolio.Address{
+		{Address: "hot", CoinType: currency.BTC, Balance: 0.5},
+		{Address: "exchange", CoinType: currency.BTC, Balance: 10, Description: portfolio.ExchangeAddress},
+		{Address: "unpriced", CoinType: currency.NewCode("PORTFOLIOHISTORYCOIN"), Balance: 10},
+	}}
+	m, db := setupPortfolioHistoryManager(t, exch, pm)
+
+	snapshot, err := m.Snapshot(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(snapshot.Holdings) != 4 {
+		t.Fatalf("received: '%v' but expected: '%v' %+v", len(snapshot.Holdings), 4, snapshot.Holdings)
+	}
+	if snapshot.Value != 35000 || snapshot.ValueCurrency != "USD" {
+		t.Fatalf("received: '%v %v' but expected: '%v'", snapshot.Value, snapshot.ValueCurrency, "35000 USD")
+	}
+	if snapshot.Holdings[2].Exchange != PortfolioHistoryWallet || snapshot.Holdings[2].Account != "hot" {
+		t.Fatalf("unexpected wallet holding %+v", snapshot.Holdings[2])
+	}
+	if len(db.flows) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v' %+v", len(db.flows), 2, db.flows)
+	}
+	if db.flows[0].Type != portfoliohistory.Deposit || db.flows[0].Currency != "BTC" || db.flows[0].Value != 20000 {
+		t.Fatalf("unexpected flow %+v", db.flows[0])
+	}
+	if db.flows[1].Type != portfoliohistory.Withdrawal || db.flows[1].TransferID != "0x1" || db.flows[1].Value != 100 {
+		t.Fatalf("unexpected flow %+v", db.flows[1])
+	}
+}
+
+func TestPortfolioHistoryManagerGetPrice(t *testing.T) {
+	t.Parallel()
+	m, _ := setupPortfolioHistoryManager(t, &fPortfolioHistoryExchange{}, nil)
+	err := ticker.ProcessTicker(&ticker.Price{
+		ExchangeName: "PortfolioHistory",
+		Pair:         currency.NewPair(currency.ETH, currency.BTC),
+		AssetType:    asset.Spot,
+		Last:         0.05,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newPortfolioPricer(m.exchangeManager, m.valueCurrency)
+	price, err := p.getPrice(currency.USD)
+	if !errors.Is(err, nil) || price != 1 {
+		t.Fatalf("received: '%v %v' but expected: '%v'", price, err, 1)
+	}
+	price, err = p.getPrice(currency.ETH)
+	if !errors.Is(err, nil) || math.Abs(price-1000) > 1e-9 {
+		t.Fatalf("received: '%v %v' but expected: '%v'", price, err, 1000)
+	}
+	// Stablecoins without a market fall back to their USD peg
+	price, err = p.getPrice(currency.USDC)
+	if !errors.Is(err, nil) || price != 1 {
+		t.Fatalf("received: '%v %v' but expected: '%v'", price, err, 1)
+	}
+	_, err = p.getPrice(currency.NewCode("PORTFOLIOHISTORYCOIN"))
+	if !errors.Is(err, errNoPortfolioPrice) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoPortfolioPrice)
+	}
+}
+
+func TestPortfolioHistoryManagerPerformance(t *testing.T) {
+	t.Parallel()
+	m, db := setupPortfolioHistoryManager(t, &fPortfolioHistoryExchange{}, nil)
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	holding := func(exch, c string, amount, price float64) portfoliohistory.Holding {
+		return portfoliohistory.Holding{Exchange: exch, Currency: c, Amount: amount, Price: price, Value: amount * price}
+	}
+	snapshot := func(offset time.Duration, holdings ...portfoliohistory.Holding) {
+		s := portfoliohistory.Snapshot{Timestamp: start.Add(offset), ValueCurrency: "USD", Holdings: holdings}
+		for x := range holdings {
+			s.Value += holdings[x].Value
+		}
+		db.snapshots = append(db.snapshots, s)
+	}
+	// 1 BTC at 10000 and 10000 USD
+	snapshot(0, holding("one", "BTC", 1, 10000), holding("one", "USD", 10000, 1))
+	// 1 BTC deposited at 12000 and 1 BTC sold at 12000
+	snapshot(time.Hour, holding("one", "BTC", 1, 12000), holding("one", "USD", 22000, 1))
+	// 0.5 BTC withdrawn at 15000 with a fee of 0.1 and 0.5 BTC moved into
+	// a wallet
+	snapshot(time.Hour*2, holding("one", "BTC", 0.4, 15000), holding("one", "USD", 22000, 1), holding(PortfolioHistoryWallet, "BTC", 0.5, 15000))
+	db.flows = []portfoliohistory.Flow{
+		{Exchange: "one", Type: portfoliohistory.Deposit, Currency: "BTC", Amount: 1, Price: 12000, ValueCurrency: "USD", Timestamp: start.Add(time.Minute)},
+		{Exchange: "one", Type: portfoliohistory.Withdrawal, Currency: "BTC", Amount: 0.5, Fee: 0.1, Timestamp: start.Add(time.Hour * 2)},
+	}
+
+	_, err := m.GetPerformance(start.Add(time.Hour*3), start.Add(time.Hour*4), "")
+	if !errors.Is(err, errNoPortfolioSnapshots) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoPortfolioSnapshots)
+	}
+
+	perf, err := m.GetPerformance(start, start.Add(time.Hour*2), "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(perf.Assets) != 2 || !perf.Assets[0].Currency.Equal(currency.BTC) {
+		t.Fatalf("unexpected assets %+v", perf.Assets)
+	}
+	btc := perf.Assets[0]
+	// Average cost is 11000 after the deposit, the sale realises 1000, the
+	// withdrawal realises 2000 less a fee of 1100 and the wallet deposit adds
+	// 7500 of cost
+	expected := map[string][2]float64{
+		"StartValue":    {btc.StartValue, 10000},
+		"EndValue":      {btc.EndValue, 13500},
+		"Deposits":      {btc.Deposits, 19500},
+		"Withdrawals":   {btc.Withdrawals, 7500},
+		"Sold":          {btc.Sold, 12000},
+		"RealisedPNL":   {btc.RealisedPNL, 1900},
+		"UnrealisedPNL": {btc.UnrealisedPNL, 1600},
+	}
+	for k, v := range expected {
+		if math.Abs(v[0]-v[1]) > 1e-6 {
+			t.Errorf("%s received: '%v' but expected: '%v'", k, v[0], v[1])
+		}
+	}
+	if math.Abs(perf.NetPerformance-perf.RealisedPNL-perf.UnrealisedPNL) > 1e-6 {
+		t.Errorf("net performance %v does not match PnL %v", perf.NetPerformance, perf.RealisedPNL+perf.UnrealisedPNL)
+	}
+
+	values, err := m.GetValueHistory(start, start.Add(time.Hour*2), PortfolioHistoryWallet)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(values) != 3 || values[0].Value != 0 || values[2].Value != 7500 {
+		t.Fatalf("unexpected values %+v", values)
+	}
+	flows, err := m.GetFlows(start, start.Add(time.Hour*2), "two")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(flows) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(flows), 0)
+	}
+}
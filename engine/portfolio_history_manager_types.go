@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
+)
+
+// PortfolioHistoryManagerName is an exported subsystem name
+const PortfolioHistoryManagerName = "portfolio_history_manager"
+
+// PortfolioHistoryWallet is the exchange name holdings of portfolio addresses
+// are recorded against
+const PortfolioHistoryWallet = "wallet"
+
+const (
+	// DefaultPortfolioHistoryInterval is the default duration between
+	// snapshots
+	DefaultPortfolioHistoryInterval = time.Hour
+	// portfolioPriceDepth is the number of intermediate currencies allowed
+	// when valuing a currency
+	portfolioPriceDepth = 2
+)
+
+var (
+	errNilPortfolioHistoryConfig = errors.New("nil portfolio history config received")
+	errNoPortfolioPrice          = errors.New("no price available")
+	errNoPortfolioSnapshots      = errors.New("no portfolio snapshots found")
+	errValueCurrencyNotFiat      = errors.New("value currency must be a fiat currency")
+
+	// portfolioIntermediates are the currencies used to value a currency
+	// which cannot be priced directly in the value currency
+	portfolioIntermediates = currency.Currencies{currency.USD, currency.USDT, currency.USDC, currency.BTC}
+)
+
+// PortfolioHistoryManager periodically values holdings across exchange
+// accounts and portfolio wallets in a fiat currency and saves the snapshots,
+// along with exchange deposits and withdrawals, to the database to report
+// value and performance over time
+type PortfolioHistoryManager struct {
+	started          int32
+	verbose          bool
+	interval         time.Duration
+	valueCurrency    currency.Code
+	exchanges        []string
+	exchangeManager  iExchangeManager
+	portfolioManager iPortfolioAddressProvider
+	db               portfoliohistory.IDBService
+	shutdown         chan struct{}
+	wg               sync.WaitGroup
+	mu               sync.Mutex
+}
+
+// PortfolioValue defines the value of holdings at a point in time
+type PortfolioValue struct {
+	Timestamp     time.Time
+	ValueCurrency currency.Code
+	Value         float64
+}
+
+// PortfolioAssetPerformance defines the performance of a currency held over a
+// period. Values are denominated in the value currency. Deposits and
+// withdrawals are valued when they occur and are excluded from PnL.
+type PortfolioAssetPerformance struct {
+	Currency      currency.Code
+	StartAmount   float64
+	EndAmount     float64
+	StartValue    float64
+	EndValue      float64
+	Deposits      float64
+	Withdrawals   float64
+	Bought        float64
+	Sold          float64
+	AverageCost   float64
+	RealisedPNL   float64
+	UnrealisedPNL float64
+}
+
+// PortfolioPerformance defines the performance of holdings over a period.
+// NetPerformance is the change in value less deposits plus withdrawals.
+type PortfolioPerformance struct {
+	Exchange       string
+	ValueCurrency  currency.Code
+	Start          time.Time
+	End            time.Time
+	StartValue     float64
+	EndValue       float64
+	Deposits       float64
+	Withdrawals    float64
+	NetPerformance float64
+	RealisedPNL    float64
+	UnrealisedPNL  float64
+	Assets         []PortfolioAssetPerformance
+}
+
+// portfolioPricer values currencies in the value currency, caching prices for
+// the duration of a snapshot
+type portfolioPricer struct {
+	exchangeManager iExchangeManager
+	valueCurrency   currency.Code
+	prices          map[*currency.Item]float64
+}
+
+// assetPosition tracks the quantity and cost basis of a currency while
+// calculating performance
+type assetPosition struct {
+	perf      PortfolioAssetPerformance
+	amount    float64
+	cost      float64
+	lastPrice float64
+}
@@ -256,22 +256,29 @@ func (m *portfolioManager) getExchangeAccountInfo(exchanges []exchange.IBotExcha
 			assetTypes = exchanges[x].GetAssetTypes(true)
 		}
 
+		// Balances of named exchange accounts are aggregated with the
+		// balances of the default credentials.
+		accounts := exchangeAccountNames(exchanges[x])
 		exchangeHoldings := account.Holdings{
 			Exchange: exchanges[x].GetName(),
-			Accounts: make([]account.SubAccount, 0, len(assetTypes)),
+			Accounts: make([]account.SubAccount, 0, len(assetTypes)*len(accounts)),
 		}
-		for y := range assetTypes {
-			// Update account info to process account updates in memory on
-			// every fetch.
-			accountHoldings, err := exchanges[x].UpdateAccountInfo(context.TODO(), assetTypes[y])
-			if err != nil {
-				log.Errorf(log.PortfolioMgr,
-					"Error encountered retrieving exchange account info for %s. Error %s\n",
-					exchanges[x].GetName(),
-					err)
-				continue
+		for a := range accounts {
+			ctx := account.DeployAccountToContext(context.TODO(), accounts[a])
+			for y := range assetTypes {
+				// Update account info to process account updates in memory on
+				// every fetch.
+				accountHoldings, err := exchanges[x].UpdateAccountInfo(ctx, assetTypes[y])
+				if err != nil {
+					log.Errorf(log.PortfolioMgr,
+						"Error encountered retrieving exchange account info for %s account %q. Error %s\n",
+						exchanges[x].GetName(),
+						accounts[a],
+						err)
+					continue
+				}
+				exchangeHoldings.Accounts = append(exchangeHoldings.Accounts, accountHoldings.Accounts...)
 			}
-			exchangeHoldings.Accounts = append(exchangeHoldings.Accounts, accountHoldings.Accounts...)
 		}
 		if len(exchangeHoldings.Accounts) > 0 {
 			response = append(response, exchangeHoldings)
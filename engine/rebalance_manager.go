@@ -0,0 +1,837 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// SetupRebalanceManager creates a new rebalancer from config. The portfolio
+// manager is required for cold storage targets. The order and withdraw
+// managers are required unless dry run is enabled. The deposit address and
+// communications managers are optional.
+func SetupRebalanceManager(cfg *config.Rebalancer, exchangeManager iExchangeManager, portfolioManager iPortfolioAddressProvider, orderManager iOrderSubmitter, withdrawManager iWithdrawSubmitter, depositAddresses iDepositAddressProvider, commsManager iCommsManager, verbose bool) (*RebalanceManager, error) {
+	if cfg == nil {
+		return nil, errNilRebalancerConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, errNoRebalanceTargets
+	}
+	if !cfg.DryRun && (orderManager == nil || withdrawManager == nil) {
+		return nil, errRebalanceNeedsExecutor
+	}
+	targets := make([]rebalanceTarget, 0, len(cfg.Targets))
+	var total float64
+	for x := range cfg.Targets {
+		t := rebalanceTarget{
+			exchange: cfg.Targets[x].Exchange,
+			currency: currency.NewCode(cfg.Targets[x].Currency),
+			weight:   cfg.Targets[x].Weight,
+		}
+		if t.exchange == "" || t.currency.IsEmpty() || t.weight < 0 {
+			return nil, fmt.Errorf("%w %+v", errInvalidRebalanceTarget, cfg.Targets[x])
+		}
+		if strings.EqualFold(t.exchange, RebalanceColdStorage) {
+			if portfolioManager == nil {
+				return nil, errRebalanceNeedsPortfolio
+			}
+			t.exchange = RebalanceColdStorage
+		}
+		for y := range targets {
+			if strings.EqualFold(targets[y].exchange, t.exchange) && targets[y].currency.Equal(t.currency) {
+				return nil, fmt.Errorf("%w duplicate %s %s", errInvalidRebalanceTarget, t.exchange, t.currency)
+			}
+		}
+		total += t.weight
+		targets = append(targets, t)
+	}
+	if math.Abs(total-100) > rebalanceWeightTolerance {
+		return nil, fmt.Errorf("%w received %v", errRebalanceWeightsInvalid, total)
+	}
+	m := &RebalanceManager{
+		verbose:           verbose || cfg.Verbose,
+		interval:          cfg.Interval,
+		quote:             cfg.QuoteCurrency,
+		driftThreshold:    cfg.DriftThreshold,
+		minimumTradeValue: cfg.MinimumTradeValue,
+		dryRun:            cfg.DryRun,
+		requireApproval:   cfg.RequireApproval,
+		targets:           targets,
+		exchangeManager:   exchangeManager,
+		portfolioManager:  portfolioManager,
+		orderManager:      orderManager,
+		withdrawManager:   withdrawManager,
+		depositAddresses:  depositAddresses,
+		commsManager:      commsManager,
+		shutdown:          make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DefaultRebalanceInterval
+	}
+	if m.quote.IsEmpty() {
+		m.quote = currency.USDT
+	}
+	if m.driftThreshold <= 0 {
+		m.driftThreshold = DefaultRebalanceDriftThreshold
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *RebalanceManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *RebalanceManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Rebalance manager %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Rebalance manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *RebalanceManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Rebalance manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.Global, "Rebalance manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetPlans returns the retained plans, oldest first
+func (m *RebalanceManager) GetPlans() ([]RebalancePlan, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	resp := make([]RebalancePlan, len(m.plans))
+	for x := range m.plans {
+		resp[x] = m.plans[x].copy()
+	}
+	return resp, nil
+}
+
+// GetPlan returns a plan by ID
+func (m *RebalanceManager) GetPlan(id uuid.UUID) (*RebalancePlan, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	plan, err := m.getPlan(id)
+	if err != nil {
+		return nil, err
+	}
+	resp := plan.copy()
+	return &resp, nil
+}
+
+// Propose evaluates holdings against the target allocations and retains the
+// resulting plan. A plan pending approval is kept unless the new plan differs
+// materially, in which case it is superseded. The plan is executed immediately
+// unless dry run or approval is required. Holdings are evaluated and plans
+// executed without holding the lock.
+func (m *RebalanceManager) Propose(ctx context.Context) (*RebalancePlan, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	plan, err := m.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	if executing := m.getPlanByStatus(RebalanceExecuting); executing != nil {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w %s", errRebalanceExecuting, executing.ID)
+	}
+	if pending := m.getPlanByStatus(RebalanceProposed); pending != nil &&
+		plan.Status == RebalanceProposed &&
+		!plan.differs(pending) {
+		resp := pending.copy()
+		m.mu.Unlock()
+		if m.verbose {
+			log.Debugf(log.Global, "Rebalance manager plan %s pending approval is unchanged", pending.ID)
+		}
+		return &resp, nil
+	}
+	for x := range m.plans {
+		if m.plans[x].Status == RebalanceProposed {
+			m.plans[x].Status = RebalanceSuperseded
+			m.plans[x].Updated = plan.Created
+		}
+	}
+	m.plans = append(m.plans, plan)
+	if len(m.plans) > maxRebalancePlans {
+		m.plans = m.plans[len(m.plans)-maxRebalancePlans:]
+	}
+	execute := plan.Status == RebalanceProposed && !m.requireApproval
+	if execute {
+		plan.Status = RebalanceExecuting
+	}
+	resp := plan.copy()
+	m.mu.Unlock()
+	switch {
+	case execute:
+		resp = m.execute(ctx, plan)
+	case resp.Status == RebalanceProposed:
+		m.notify(fmt.Sprintf("Rebalance plan %s with %d actions requires approval, max drift %.2f%%", resp.ID, len(resp.Actions), resp.MaxDrift))
+	}
+	return &resp, nil
+}
+
+// Approve executes a plan which is pending approval. The plan is marked as
+// executing before orders and withdrawals are submitted without the lock held.
+func (m *RebalanceManager) Approve(ctx context.Context, id uuid.UUID) (*RebalancePlan, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	if m.dryRun {
+		return nil, errRebalanceDryRun
+	}
+	m.mu.Lock()
+	plan, err := m.getPlan(id)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	if plan.Status != RebalanceProposed {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w %s status %s", errRebalancePlanNotPending, id, plan.Status)
+	}
+	if executing := m.getPlanByStatus(RebalanceExecuting); executing != nil {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w %s", errRebalanceExecuting, executing.ID)
+	}
+	plan.Status = RebalanceExecuting
+	plan.Updated = time.Now()
+	m.mu.Unlock()
+	resp := m.execute(ctx, plan)
+	return &resp, nil
+}
+
+// Reject discards a plan which is pending approval
+func (m *RebalanceManager) Reject(id uuid.UUID) error {
+	if err := m.checkRunning(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	plan, err := m.getPlan(id)
+	if err != nil {
+		return err
+	}
+	if plan.Status != RebalanceProposed {
+		return fmt.Errorf("%w %s status %s", errRebalancePlanNotPending, id, plan.Status)
+	}
+	plan.Status = RebalanceRejected
+	plan.Updated = time.Now()
+	return nil
+}
+
+// checkRunning returns an error when the subsystem is nil or not running
+func (m *RebalanceManager) checkRunning() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("%s %w", RebalanceManagerName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// getPlan returns a retained plan by ID, the lock must be held
+func (m *RebalanceManager) getPlan(id uuid.UUID) (*RebalancePlan, error) {
+	for x := range m.plans {
+		if m.plans[x].ID == id {
+			return m.plans[x], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %s", errRebalancePlanNotFound, id)
+}
+
+// getPlanByStatus returns the latest retained plan with a status, the lock
+// must be held
+func (m *RebalanceManager) getPlanByStatus(status RebalancePlanStatus) *RebalancePlan {
+	for x := len(m.plans) - 1; x >= 0; x-- {
+		if m.plans[x].Status == status {
+			return m.plans[x]
+		}
+	}
+	return nil
+}
+
+// run proposes a plan at every interval
+func (m *RebalanceManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			plan, err := m.Propose(context.TODO())
+			if err != nil {
+				log.Errorf(log.Global, "Rebalance manager cannot propose plan: %v", err)
+				continue
+			}
+			if m.verbose {
+				log.Debugf(log.Global, "Rebalance manager plan %s status %s max drift %.2f%%", plan.ID, plan.Status, plan.MaxDrift)
+			}
+		}
+	}
+}
+
+// evaluate values the managed holdings and builds the actions required to
+// restore the target allocations
+func (m *RebalanceManager) evaluate(ctx context.Context) (*RebalancePlan, error) {
+	holdings, coldStorage, err := m.getHoldings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The quote currency is always managed as it funds and receives trades
+	managed := currency.Currencies{m.quote}
+	codes := map[*currency.Item]currency.Code{m.quote.Item: m.quote}
+	exchanges := make([]string, 0, len(m.targets))
+	targetWeights := make(map[rebalanceLocation]float64)
+	var locations []rebalanceLocation
+	for x := range m.targets {
+		if _, ok := codes[m.targets[x].currency.Item]; !ok {
+			managed = append(managed, m.targets[x].currency)
+			codes[m.targets[x].currency.Item] = m.targets[x].currency
+		}
+		if m.targets[x].exchange != RebalanceColdStorage && !common.StringDataCompareInsensitive(exchanges, m.targets[x].exchange) {
+			exchanges = append(exchanges, m.targets[x].exchange)
+		}
+		loc := rebalanceLocation{exchange: m.targets[x].exchange, currency: m.targets[x].currency.Item}
+		targetWeights[loc] = m.targets[x].weight
+		locations = append(locations, loc)
+	}
+	// Managed currencies held without a target have a target weight of zero
+	var untargeted []rebalanceLocation
+	for loc, balance := range holdings {
+		if _, ok := targetWeights[loc]; ok || balance <= 0 {
+			continue
+		}
+		if _, ok := codes[loc.currency]; !ok {
+			continue
+		}
+		untargeted = append(untargeted, loc)
+	}
+	sort.Slice(untargeted, func(i, j int) bool {
+		if untargeted[i].exchange != untargeted[j].exchange {
+			return untargeted[i].exchange < untargeted[j].exchange
+		}
+		return untargeted[i].currency.Symbol < untargeted[j].currency.Symbol
+	})
+	locations = append(locations, untargeted...)
+
+	prices := make(map[*currency.Item]float64, len(managed))
+	for x := range managed {
+		prices[managed[x].Item], err = m.getPrice(managed[x], exchanges)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	plan := &RebalancePlan{
+		ID:            id,
+		Created:       now,
+		Updated:       now,
+		QuoteCurrency: m.quote,
+		Allocations:   make([]RebalanceAllocation, len(locations)),
+	}
+	for x := range locations {
+		plan.Allocations[x] = RebalanceAllocation{
+			Exchange:     locations[x].exchange,
+			Currency:     codes[locations[x].currency],
+			Balance:      holdings[locations[x]],
+			Price:        prices[locations[x].currency],
+			TargetWeight: targetWeights[locations[x]],
+		}
+		plan.Allocations[x].Value = plan.Allocations[x].Balance * plan.Allocations[x].Price
+		plan.TotalValue += plan.Allocations[x].Value
+	}
+	if plan.TotalValue <= 0 {
+		return nil, errNoRebalanceHoldings
+	}
+	deltas := make(map[rebalanceLocation]float64, len(locations))
+	for x := range plan.Allocations {
+		a := &plan.Allocations[x]
+		a.TargetValue = plan.TotalValue * a.TargetWeight / 100
+		a.CurrentWeight = a.Value / plan.TotalValue * 100
+		a.Drift = a.CurrentWeight - a.TargetWeight
+		if math.Abs(a.Drift) > plan.MaxDrift {
+			plan.MaxDrift = math.Abs(a.Drift)
+		}
+		deltas[locations[x]] = a.TargetValue - a.Value
+	}
+	if plan.MaxDrift < m.driftThreshold {
+		plan.Status = RebalanceBalanced
+		return plan, nil
+	}
+	plan.Status = RebalanceProposed
+	if m.dryRun {
+		plan.Status = RebalanceDryRun
+	}
+	plan.Actions = m.buildActions(locations, deltas, prices, managed, exchanges, coldStorage)
+	return plan, nil
+}
+
+// buildActions converts the value required by each location, positive when
+// the location is below target, into actions. Currencies are first
+// transferred between locations with a surplus and deficit, the remainder is
+// traded against the quote currency on each exchange and the quote currency is
+// then transferred between exchanges. Cold storage cannot trade, so its
+// remainder is transferred via the exchange with the largest target for the
+// currency.
+func (m *RebalanceManager) buildActions(locations []rebalanceLocation, deltas map[rebalanceLocation]float64, prices map[*currency.Item]float64, managed currency.Currencies, exchanges []string, coldStorage map[*currency.Item]portfolio.Address) []RebalanceAction {
+	var transfers, sells, buys, outbound []RebalanceAction
+	for x := range managed {
+		if managed[x].Equal(m.quote) {
+			continue
+		}
+		c := managed[x]
+		transfers = append(transfers, m.matchTransfers(c, locations, deltas, prices, coldStorage)...)
+		cold := rebalanceLocation{exchange: RebalanceColdStorage, currency: c.Item}
+		residual := deltas[cold]
+		if residual == 0 || len(exchanges) == 0 {
+			continue
+		}
+		via := rebalanceLocation{exchange: m.routeExchange(c, exchanges), currency: c.Item}
+		deltas[via] += residual
+		deltas[cold] = 0
+		if residual > 0 {
+			outbound = append(outbound, m.newTransfer(via.exchange, RebalanceColdStorage, c, residual, prices[c.Item], coldStorage))
+		} else {
+			transfers = append(transfers, m.newTransfer(RebalanceColdStorage, via.exchange, c, -residual, prices[c.Item], coldStorage))
+		}
+	}
+
+	for x := range exchanges {
+		quoteLoc := rebalanceLocation{exchange: exchanges[x], currency: m.quote.Item}
+		for y := range managed {
+			if managed[y].Equal(m.quote) {
+				continue
+			}
+			loc := rebalanceLocation{exchange: exchanges[x], currency: managed[y].Item}
+			delta := deltas[loc]
+			if delta == 0 {
+				continue
+			}
+			// Selling adds the sold value to the quote holdings and buying
+			// spends it
+			deltas[quoteLoc] += delta
+			deltas[loc] = 0
+			if delta < 0 {
+				sells = append(sells, m.newTrade(exchanges[x], managed[y], order.Sell, -delta, prices[loc.currency]))
+			} else {
+				buys = append(buys, m.newTrade(exchanges[x], managed[y], order.Buy, delta, prices[loc.currency]))
+			}
+		}
+	}
+	// Quote deltas sum to zero so matching leaves no remainder
+	quoteTransfers := m.matchTransfers(m.quote, locations, deltas, prices, coldStorage)
+
+	var actions []RebalanceAction
+	for _, group := range [][]RebalanceAction{transfers, sells, quoteTransfers, buys, outbound} {
+		for x := range group {
+			if group[x].Value < m.minimumTradeValue {
+				continue
+			}
+			actions = append(actions, group[x])
+		}
+	}
+	return actions
+}
+
+// matchTransfers transfers a currency from locations with a surplus to
+// locations with a deficit, largest first
+func (m *RebalanceManager) matchTransfers(c currency.Code, locations []rebalanceLocation, deltas map[rebalanceLocation]float64, prices map[*currency.Item]float64, coldStorage map[*currency.Item]portfolio.Address) []RebalanceAction {
+	var surplus, deficit []rebalanceLocation
+	seen := make(map[rebalanceLocation]bool)
+	add := func(loc rebalanceLocation) {
+		if loc.currency != c.Item || seen[loc] {
+			return
+		}
+		seen[loc] = true
+		switch {
+		case deltas[loc] < 0:
+			surplus = append(surplus, loc)
+		case deltas[loc] > 0:
+			deficit = append(deficit, loc)
+		}
+	}
+	for x := range locations {
+		add(locations[x])
+	}
+	// Trades can create quote deltas for locations without an allocation
+	var extra []rebalanceLocation
+	for loc := range deltas {
+		if loc.currency == c.Item && !seen[loc] {
+			extra = append(extra, loc)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].exchange < extra[j].exchange })
+	for x := range extra {
+		add(extra[x])
+	}
+	sort.SliceStable(surplus, func(i, j int) bool { return deltas[surplus[i]] < deltas[surplus[j]] })
+	sort.SliceStable(deficit, func(i, j int) bool { return deltas[deficit[i]] > deltas[deficit[j]] })
+
+	var actions []RebalanceAction
+	for s, d := 0, 0; s < len(surplus) && d < len(deficit); {
+		value := math.Min(-deltas[surplus[s]], deltas[deficit[d]])
+		actions = append(actions, m.newTransfer(surplus[s].exchange, deficit[d].exchange, c, value, prices[c.Item], coldStorage))
+		deltas[surplus[s]] += value
+		deltas[deficit[d]] -= value
+		if deltas[surplus[s]] >= 0 {
+			deltas[surplus[s]] = 0
+			s++
+		}
+		if deltas[deficit[d]] <= 0 {
+			deltas[deficit[d]] = 0
+			d++
+		}
+	}
+	return actions
+}
+
+// routeExchange returns the exchange with the largest target weight for a
+// currency, or the first target exchange when there is none
+func (m *RebalanceManager) routeExchange(c currency.Code, exchanges []string) string {
+	via := exchanges[0]
+	var weight float64
+	for x := range m.targets {
+		if m.targets[x].currency.Equal(c) &&
+			m.targets[x].exchange != RebalanceColdStorage &&
+			m.targets[x].weight > weight {
+			via, weight = m.targets[x].exchange, m.targets[x].weight
+		}
+	}
+	return via
+}
+
+// newTransfer returns a transfer action with the resolved destination address.
+// Transfers out of cold storage are manual.
+func (m *RebalanceManager) newTransfer(from, to string, code currency.Code, value, price float64, coldStorage map[*currency.Item]portfolio.Address) RebalanceAction {
+	a := RebalanceAction{
+		Type:        RebalanceTransfer,
+		Exchange:    from,
+		Destination: to,
+		Currency:    code,
+		Amount:      value / price,
+		Value:       value,
+		Manual:      from == RebalanceColdStorage,
+	}
+	if to == RebalanceColdStorage {
+		addr, ok := coldStorage[code.Item]
+		if !ok {
+			a.Error = fmt.Sprintf("%s %v", code, errNoColdStorageAddress)
+			return a
+		}
+		a.Address, a.AddressTag = addr.Address, addr.AddressTag
+		return a
+	}
+	if m.depositAddresses == nil {
+		a.Error = fmt.Sprintf("%s %s %v", to, code, ErrDepositAddressNotFound)
+		return a
+	}
+	addr, err := m.depositAddresses.GetDepositAddressByExchangeAndCurrency(to, "", code)
+	if err != nil {
+		a.Error = fmt.Sprintf("%s %s %v", to, code, err)
+		return a
+	}
+	a.Address, a.AddressTag, a.Chain = addr.Address, addr.Tag, addr.Chain
+	return a
+}
+
+// newTrade returns a trade action for a currency against the quote currency
+// using the enabled spot pair on the exchange, inverting the side when the
+// quote currency is the base of the pair
+func (m *RebalanceManager) newTrade(exchName string, c currency.Code, side order.Side, value, price float64) RebalanceAction {
+	a := RebalanceAction{
+		Type:     RebalanceBuy,
+		Exchange: exchName,
+		Side:     side,
+		Currency: c,
+		Amount:   value / price,
+		Value:    value,
+	}
+	if side == order.Sell {
+		a.Type = RebalanceSell
+	}
+	exch, err := m.exchangeManager.GetExchangeByName(exchName)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	pairs, err := exch.GetEnabledPairs(asset.Spot)
+	if err != nil {
+		a.Error = err.Error()
+		return a
+	}
+	for x := range pairs {
+		switch {
+		case pairs[x].Base.Equal(c) && pairs[x].Quote.Equal(m.quote):
+			a.Pair = pairs[x]
+			return a
+		case pairs[x].Base.Equal(m.quote) && pairs[x].Quote.Equal(c):
+			a.Pair = pairs[x]
+			// The quote currency is sold to buy the currency and vice versa,
+			// with the amount denominated in the quote currency
+			a.Amount = value
+			if side == order.Buy {
+				a.Side = order.Sell
+			} else {
+				a.Side = order.Buy
+			}
+			return a
+		}
+	}
+	a.Error = fmt.Sprintf("%s %s %s %v", exchName, c, m.quote, errNoRebalancePair)
+	return a
+}
+
+// getHoldings returns the total spot balances of each target exchange and the
+// balances of cold storage addresses, along with the first cold storage
+// address of each currency
+func (m *RebalanceManager) getHoldings(ctx context.Context) (map[rebalanceLocation]float64, map[*currency.Item]portfolio.Address, error) {
+	holdings := make(map[rebalanceLocation]float64)
+	coldStorage := make(map[*currency.Item]portfolio.Address)
+	var fetched []string
+	for x := range m.targets {
+		name := m.targets[x].exchange
+		if name == RebalanceColdStorage || common.StringDataCompareInsensitive(fetched, name) {
+			continue
+		}
+		fetched = append(fetched, name)
+		exch, err := m.exchangeManager.GetExchangeByName(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		h, err := exch.FetchAccountInfo(ctx, asset.Spot)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %w", name, err)
+		}
+		for y := range h.Accounts {
+			for z := range h.Accounts[y].Currencies {
+				bal := &h.Accounts[y].Currencies[z]
+				holdings[rebalanceLocation{exchange: name, currency: bal.CurrencyName.Item}] += bal.Total
+			}
+		}
+	}
+	if m.portfolioManager != nil {
+		addresses := m.portfolioManager.GetAddresses()
+		for x := range addresses {
+			if !addresses[x].ColdStorage {
+				continue
+			}
+			c := addresses[x].CoinType.Item
+			holdings[rebalanceLocation{exchange: RebalanceColdStorage, currency: c}] += addresses[x].Balance
+			if _, ok := coldStorage[c]; !ok {
+				coldStorage[c] = addresses[x]
+			}
+		}
+	}
+	return holdings, coldStorage, nil
+}
+
+// getPrice returns the last traded price of a currency in the quote currency,
+// preferring target exchanges
+func (m *RebalanceManager) getPrice(c currency.Code, exchanges []string) (float64, error) {
+	if c.Equal(m.quote) {
+		return 1, nil
+	}
+	names := append([]string(nil), exchanges...)
+	if exchs, err := m.exchangeManager.GetExchanges(); err == nil {
+		for x := range exchs {
+			if !common.StringDataCompareInsensitive(names, exchs[x].GetName()) {
+				names = append(names, exchs[x].GetName())
+			}
+		}
+	}
+	for x := range names {
+		if t, err := ticker.GetTicker(names[x], currency.NewPair(c, m.quote), asset.Spot); err == nil && t.Last > 0 {
+			return t.Last, nil
+		}
+		if t, err := ticker.GetTicker(names[x], currency.NewPair(m.quote, c), asset.Spot); err == nil && t.Last > 0 {
+			return 1 / t.Last, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %s %w", c, m.quote, errNoRebalancePrice)
+}
+
+// execute performs the actions of a plan marked as executing in order,
+// stopping at the first failure. Manual actions are skipped. The lock must not
+// be held as it is only taken to record the outcome of each action. A copy of
+// the plan once executed is returned.
+func (m *RebalanceManager) execute(ctx context.Context, plan *RebalancePlan) RebalancePlan {
+	m.mu.Lock()
+	plan.Status = RebalanceExecuting
+	plan.Updated = time.Now()
+	actions := append([]RebalanceAction(nil), plan.Actions...)
+	m.mu.Unlock()
+	for x := range actions {
+		a := &actions[x]
+		if a.Manual {
+			continue
+		}
+		err := m.executeAction(ctx, plan.ID, a)
+		m.mu.Lock()
+		if err != nil {
+			a.Error = err.Error()
+			plan.Actions[x] = *a
+			plan.Status = RebalanceFailed
+			plan.Error = fmt.Sprintf("action %d: %v", x+1, err)
+			plan.Updated = time.Now()
+			resp := plan.copy()
+			m.mu.Unlock()
+			log.Errorf(log.Global, "Rebalance manager plan %s failed %s", plan.ID, resp.Error)
+			m.notify(fmt.Sprintf("Rebalance plan %s failed %s", plan.ID, resp.Error))
+			return resp
+		}
+		a.Executed = true
+		plan.Actions[x] = *a
+		m.mu.Unlock()
+	}
+	m.mu.Lock()
+	plan.Status = RebalanceExecuted
+	plan.Updated = time.Now()
+	resp := plan.copy()
+	m.mu.Unlock()
+	log.Infof(log.Global, "Rebalance manager executed plan %s with %d actions", plan.ID, len(plan.Actions))
+	m.notify(fmt.Sprintf("Rebalance plan %s executed", plan.ID))
+	return resp
+}
+
+// executeAction submits an order or withdrawal for an action
+func (m *RebalanceManager) executeAction(ctx context.Context, planID uuid.UUID, a *RebalanceAction) error {
+	if a.Error != "" {
+		return errors.New(a.Error)
+	}
+	if a.Type == RebalanceTransfer {
+		resp, err := m.withdrawManager.SubmitWithdrawal(ctx, &withdraw.Request{
+			Exchange:    a.Exchange,
+			Currency:    a.Currency,
+			Description: "rebalance " + planID.String(),
+			Amount:      a.Amount,
+			Type:        withdraw.Crypto,
+			Crypto: withdraw.CryptoRequest{
+				Address:    a.Address,
+				AddressTag: a.AddressTag,
+				Chain:      a.Chain,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		a.ID = resp.ID.String()
+		return nil
+	}
+	resp, err := m.orderManager.Submit(ctx, &order.Submit{
+		Exchange:  a.Exchange,
+		Pair:      a.Pair,
+		AssetType: asset.Spot,
+		Side:      a.Side,
+		Type:      order.Market,
+		Amount:    a.Amount,
+	})
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		a.ID = resp.InternalOrderID
+		if resp.Detail != nil && resp.OrderID != "" {
+			a.ID = resp.OrderID
+		}
+	}
+	return nil
+}
+
+// notify pushes a message via the communications manager when set
+func (m *RebalanceManager) notify(msg string) {
+	if m.commsManager == nil {
+		return
+	}
+	m.commsManager.PushEvent(base.Event{Type: "rebalance", Message: msg})
+}
+
+// differs returns true when a plan proposes different actions to another, or
+// the amount of any action changed by more than rebalancePlanTolerance
+func (p *RebalancePlan) differs(other *RebalancePlan) bool {
+	if len(p.Actions) != len(other.Actions) {
+		return true
+	}
+	for x := range p.Actions {
+		a, b := &p.Actions[x], &other.Actions[x]
+		if a.Type != b.Type ||
+			a.Exchange != b.Exchange ||
+			a.Destination != b.Destination ||
+			a.Address != b.Address ||
+			a.Side != b.Side ||
+			a.Manual != b.Manual ||
+			!a.Currency.Equal(b.Currency) ||
+			!a.Pair.Equal(b.Pair) ||
+			(a.Error == "") != (b.Error == "") {
+			return true
+		}
+		if math.Abs(a.Amount-b.Amount) > math.Abs(b.Amount)*rebalancePlanTolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// copy returns a copy of the plan which does not share slices
+func (p *RebalancePlan) copy() RebalancePlan {
+	resp := *p
+	resp.Allocations = append([]RebalanceAllocation(nil), p.Allocations...)
+	resp.Actions = append([]RebalanceAction(nil), p.Actions...)
+	return resp
+}
@@ -0,0 +1,496 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// fRebalanceExchange overrides account info with static balances
+type fRebalanceExchange struct {
+	exchange.IBotExchange
+	balances []account.Balance
+}
+
+func (f fRebalanceExchange) FetchAccountInfo(_ context.Context, a asset.Item) (account.Holdings, error) {
+	return account.Holdings{
+		Exchange: f.GetName(),
+		Accounts: []account.SubAccount{{AssetType: a, Currencies: f.balances}},
+	}, nil
+}
+
+type fakeRebalancePortfolio struct {
+	addresses []portfolio.Address
+}
+
+func (f *fakeRebalancePortfolio) GetAddresses() []portfolio.Address {
+	return f.addresses
+}
+
+type fakeWithdrawSubmitter struct {
+	requests []*withdraw.Request
+}
+
+func (f *fakeWithdrawSubmitter) SubmitWithdrawal(_ context.Context, req *withdraw.Request) (*withdraw.Response, error) {
+	f.requests = append(f.requests, req)
+	return &withdraw.Response{ID: uuid.Must(uuid.NewV4())}, nil
+}
+
+type fakeDepositAddresses struct{}
+
+func (f fakeDepositAddresses) GetDepositAddressByExchangeAndCurrency(exchName, _ string, c currency.Code) (deposit.Address, error) {
+	return deposit.Address{Address: exchName + "-" + c.String()}, nil
+}
+
+// setupRebalanceExchanges adds two exchanges with the supplied balances and a
+// BTC-USDT ticker of 20000
+func setupRebalanceExchanges(t *testing.T, a, b []account.Balance) *ExchangeManager {
+	t.Helper()
+	em := SetupExchangeManager()
+	btcusdt := currency.NewPair(currency.BTC, currency.USDT)
+	for name, balances := range map[string][]account.Balance{"RebalanceA": a, "RebalanceB": b} {
+		exch, err := em.NewExchangeByName("ftx")
+		if err != nil {
+			t.Fatal(err)
+		}
+		exch.SetDefaults()
+		base := exch.GetBase()
+		base.Name = name
+		base.Enabled = true
+		base.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+			asset.Spot: {
+				AssetEnabled:  convert.BoolPtr(true),
+				ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+				RequestFormat: &currency.PairFormat{Delimiter: "/"},
+				Available:     currency.Pairs{btcusdt, currency.NewPair(currency.USDT, currency.EUR)},
+				Enabled:       currency.Pairs{btcusdt, currency.NewPair(currency.USDT, currency.EUR)},
+			},
+		}
+		em.Add(fRebalanceExchange{IBotExchange: exch, balances: balances})
+	}
+	err := ticker.ProcessTicker(&ticker.Price{
+		ExchangeName: "RebalanceA",
+		Pair:         btcusdt,
+		AssetType:    asset.Spot,
+		Last:         20000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return em
+}
+
+func testRebalancerConfig() *config.Rebalancer {
+	return &config.Rebalancer{
+		QuoteCurrency:     currency.USDT,
+		MinimumTradeValue: 10,
+		RequireApproval:   true,
+		Targets: []config.RebalancerTarget{
+			{Exchange: "RebalanceA", Currency: "BTC", Weight: 40},
+			{Exchange: "RebalanceA", Currency: "USDT", Weight: 10},
+			{Exchange: "RebalanceB", Currency: "BTC", Weight: 10},
+			{Exchange: "RebalanceB", Currency: "USDT", Weight: 20},
+			{Exchange: RebalanceColdStorage, Currency: "BTC", Weight: 20},
+		},
+	}
+}
+
+func TestSetupRebalanceManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupRebalanceManager(nil, nil, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilRebalancerConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRebalancerConfig)
+	}
+	_, err = SetupRebalanceManager(&config.Rebalancer{}, nil, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	em := SetupExchangeManager()
+	_, err = SetupRebalanceManager(&config.Rebalancer{}, em, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errNoRebalanceTargets) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoRebalanceTargets)
+	}
+	cfg := testRebalancerConfig()
+	_, err = SetupRebalanceManager(cfg, em, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errRebalanceNeedsExecutor) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalanceNeedsExecutor)
+	}
+	cfg.DryRun = true
+	_, err = SetupRebalanceManager(cfg, em, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, errRebalanceNeedsPortfolio) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalanceNeedsPortfolio)
+	}
+	cfg.Targets[0].Weight = -1
+	_, err = SetupRebalanceManager(cfg, em, &fakeRebalancePortfolio{}, nil, nil, nil, nil, false)
+	if !errors.Is(err, errInvalidRebalanceTarget) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRebalanceTarget)
+	}
+	cfg.Targets[0].Weight = 40
+	cfg.Targets = append(cfg.Targets, config.RebalancerTarget{Exchange: "rebalancea", Currency: "btc"})
+	_, err = SetupRebalanceManager(cfg, em, &fakeRebalancePortfolio{}, nil, nil, nil, nil, false)
+	if !errors.Is(err, errInvalidRebalanceTarget) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRebalanceTarget)
+	}
+	cfg.Targets = cfg.Targets[:len(cfg.Targets)-1]
+	cfg.Targets[0].Weight = 50
+	_, err = SetupRebalanceManager(cfg, em, &fakeRebalancePortfolio{}, nil, nil, nil, nil, false)
+	if !errors.Is(err, errRebalanceWeightsInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalanceWeightsInvalid)
+	}
+	cfg.Targets[0].Weight = 40
+	m, err := SetupRebalanceManager(cfg, em, &fakeRebalancePortfolio{}, nil, nil, nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.interval != DefaultRebalanceInterval || m.driftThreshold != DefaultRebalanceDriftThreshold {
+		t.Fatal("expected defaults to be set")
+	}
+}
+
+func TestRebalanceManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *RebalanceManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+	_, err = m.GetPlans()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	cfg := testRebalancerConfig()
+	cfg.DryRun = true
+	m, err = SetupRebalanceManager(cfg, SetupExchangeManager(), &fakeRebalancePortfolio{}, nil, nil, nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = m.Propose(context.Background())
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestRebalanceManagerPropose(t *testing.T) {
+	t.Parallel()
+	em := setupRebalanceExchanges(t,
+		[]account.Balance{{CurrencyName: currency.BTC, Total: 1}},
+		[]account.Balance{{CurrencyName: currency.USDT, Total: 20000}})
+	pm := &fakeRebalancePortfolio{addresses: []portfolio.Address{
+		{Address: "cold", CoinType: currency.BTC, Balance: 1, ColdStorage: true},
+		{Address: "hot", CoinType: currency.BTC, Balance: 5},
+	}}
+	orders := &fakeArbitrageSubmitter{}
+	withdrawals := &fakeWithdrawSubmitter{}
+	m, err := SetupRebalanceManager(testRebalancerConfig(), em, pm, orders, withdrawals, fakeDepositAddresses{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer func() {
+		if err = m.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	plan, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if plan.Status != RebalanceProposed || plan.TotalValue != 60000 || len(plan.Allocations) != 5 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	// 20000 of cold storage BTC against a target of 12000
+	if math.Abs(plan.MaxDrift-13.333333333333334) > 1e-9 {
+		t.Fatalf("received: '%v' but expected: '%v'", plan.MaxDrift, 13.333333333333334)
+	}
+	expected := []RebalanceAction{
+		{Type: RebalanceTransfer, Exchange: RebalanceColdStorage, Destination: "RebalanceB", Amount: 0.3, Manual: true},
+		{Type: RebalanceTransfer, Exchange: RebalanceColdStorage, Destination: "RebalanceA", Amount: 0.1, Manual: true},
+		{Type: RebalanceTransfer, Exchange: "RebalanceB", Destination: "RebalanceA", Amount: 8000},
+		{Type: RebalanceBuy, Exchange: "RebalanceA", Side: order.Buy, Amount: 0.1},
+	}
+	if len(plan.Actions) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v' %+v", len(plan.Actions), len(expected), plan.Actions)
+	}
+	for x := range expected {
+		a := plan.Actions[x]
+		if a.Type != expected[x].Type ||
+			a.Exchange != expected[x].Exchange ||
+			a.Destination != expected[x].Destination ||
+			a.Manual != expected[x].Manual ||
+			a.Side != expected[x].Side ||
+			math.Abs(a.Amount-expected[x].Amount) > 1e-9 {
+			t.Fatalf("action %d received: '%+v' but expected: '%+v'", x, a, expected[x])
+		}
+	}
+	if plan.Actions[2].Address != "RebalanceA-USDT" {
+		t.Fatalf("received: '%v' but expected: '%v'", plan.Actions[2].Address, "RebalanceA-USDT")
+	}
+
+	// An unchanged proposal keeps the pending plan so it can be approved
+	unchanged, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if unchanged.ID != plan.ID || unchanged.Status != RebalanceProposed {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", unchanged.ID, unchanged.Status, plan.ID, RebalanceProposed)
+	}
+
+	// A materially different proposal supersedes the pending plan
+	pm.addresses[0].Balance = 2
+	changed, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	superseded, err := m.GetPlan(plan.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if changed.ID == plan.ID || superseded.Status != RebalanceSuperseded {
+		t.Fatalf("received: '%v' but expected: '%v'", superseded.Status, RebalanceSuperseded)
+	}
+	_, err = m.Approve(context.Background(), plan.ID)
+	if !errors.Is(err, errRebalancePlanNotPending) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalancePlanNotPending)
+	}
+	pm.addresses[0].Balance = 1
+	next, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	executed, err := m.Approve(context.Background(), next.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if executed.Status != RebalanceExecuted {
+		t.Fatalf("received: '%v' but expected: '%v' %s", executed.Status, RebalanceExecuted, executed.Error)
+	}
+	if executed.Actions[0].Executed || !executed.Actions[2].Executed || !executed.Actions[3].Executed {
+		t.Fatal("expected manual actions to be skipped and others executed")
+	}
+	if len(withdrawals.requests) != 1 || withdrawals.requests[0].Amount != 8000 || withdrawals.requests[0].Crypto.Address != "RebalanceA-USDT" {
+		t.Fatalf("unexpected withdrawals %+v", withdrawals.requests)
+	}
+	if len(orders.submitted) != 1 || orders.submitted[0].Side != order.Buy || orders.submitted[0].Type != order.Market {
+		t.Fatalf("unexpected orders %+v", orders.submitted)
+	}
+
+	_, err = m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	plans, err := m.GetPlans()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(plans) != 4 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(plans), 4)
+	}
+	err = m.Reject(plans[3].ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Reject(plans[3].ID)
+	if !errors.Is(err, errRebalancePlanNotPending) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalancePlanNotPending)
+	}
+	err = m.Reject(uuid.Nil)
+	if !errors.Is(err, errRebalancePlanNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalancePlanNotFound)
+	}
+}
+
+// fBlockingSubmitter blocks order submission until released
+type fBlockingSubmitter struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (f *fBlockingSubmitter) Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error) {
+	f.entered <- struct{}{}
+	<-f.release
+	return &OrderSubmitResponse{}, nil
+}
+
+func TestRebalanceManagerApproveUnlocked(t *testing.T) {
+	t.Parallel()
+	em := setupRebalanceExchanges(t,
+		[]account.Balance{{CurrencyName: currency.BTC, Total: 1}},
+		[]account.Balance{{CurrencyName: currency.USDT, Total: 20000}})
+	pm := &fakeRebalancePortfolio{addresses: []portfolio.Address{
+		{Address: "cold", CoinType: currency.BTC, Balance: 1, ColdStorage: true},
+	}}
+	orders := &fBlockingSubmitter{entered: make(chan struct{}), release: make(chan struct{})}
+	m, err := SetupRebalanceManager(testRebalancerConfig(), em, pm, orders, &fakeWithdrawSubmitter{}, fakeDepositAddresses{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	plan, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	approved := make(chan *RebalancePlan)
+	go func() {
+		resp, approveErr := m.Approve(context.Background(), plan.ID)
+		if approveErr != nil {
+			t.Error(approveErr)
+		}
+		approved <- resp
+	}()
+	<-orders.entered
+
+	// Plans can be retrieved while orders are submitted
+	executing, err := m.GetPlan(plan.ID)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if executing.Status != RebalanceExecuting {
+		t.Fatalf("received: '%v' but expected: '%v'", executing.Status, RebalanceExecuting)
+	}
+	_, err = m.Propose(context.Background())
+	if !errors.Is(err, errRebalanceExecuting) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalanceExecuting)
+	}
+	err = m.Reject(plan.ID)
+	if !errors.Is(err, errRebalancePlanNotPending) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalancePlanNotPending)
+	}
+	close(orders.release)
+	if resp := <-approved; resp == nil || resp.Status != RebalanceExecuted {
+		t.Fatalf("unexpected approved plan %+v", resp)
+	}
+}
+
+func TestRebalanceManagerProposeBalancedAndDryRun(t *testing.T) {
+	t.Parallel()
+	em := setupRebalanceExchanges(t,
+		[]account.Balance{{CurrencyName: currency.BTC, Total: 1}},
+		[]account.Balance{{CurrencyName: currency.USDT, Total: 20000}})
+	cfg := &config.Rebalancer{
+		DryRun:        true,
+		QuoteCurrency: currency.USDT,
+		Targets: []config.RebalancerTarget{
+			{Exchange: "RebalanceA", Currency: "BTC", Weight: 52},
+			{Exchange: "RebalanceB", Currency: "USDT", Weight: 48},
+		},
+	}
+	m, err := SetupRebalanceManager(cfg, em, nil, nil, nil, nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer func() {
+		if err = m.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	plan, err := m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if plan.Status != RebalanceBalanced || len(plan.Actions) != 0 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+
+	m.targets[0].weight, m.targets[1].weight = 25, 75
+	plan, err = m.Propose(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if plan.Status != RebalanceDryRun || len(plan.Actions) != 2 {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+	// BTC surplus is sold for USDT which is transferred to the other exchange
+	if plan.Actions[0].Type != RebalanceSell || plan.Actions[0].Amount != 0.5 ||
+		plan.Actions[1].Type != RebalanceTransfer || plan.Actions[1].Value != 10000 {
+		t.Fatalf("unexpected actions %+v", plan.Actions)
+	}
+	if plan.Actions[1].Error == "" {
+		t.Fatal("expected missing deposit address error")
+	}
+	_, err = m.Approve(context.Background(), plan.ID)
+	if !errors.Is(err, errRebalanceDryRun) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalanceDryRun)
+	}
+}
+
+func TestRebalanceManagerNewTrade(t *testing.T) {
+	t.Parallel()
+	em := setupRebalanceExchanges(t, nil, nil)
+	m := &RebalanceManager{exchangeManager: em, quote: currency.USDT}
+	a := m.newTrade("RebalanceA", currency.EUR, order.Buy, 100, 1.1)
+	if a.Error != "" || a.Side != order.Sell || a.Amount != 100 {
+		t.Fatalf("unexpected inverted trade %+v", a)
+	}
+	a = m.newTrade("RebalanceA", currency.ETH, order.Buy, 100, 1000)
+	if a.Error == "" {
+		t.Fatal("expected missing pair error")
+	}
+	a = m.newTrade("bogus", currency.ETH, order.Buy, 100, 1000)
+	if a.Error == "" {
+		t.Fatal("expected missing exchange error")
+	}
+}
+
+func TestRebalanceManagerExecuteFailure(t *testing.T) {
+	t.Parallel()
+	orders := &fakeArbitrageSubmitter{err: errors.New("rejected")}
+	m := &RebalanceManager{orderManager: orders, withdrawManager: &fakeWithdrawSubmitter{}}
+	plan := &RebalancePlan{
+		Status: RebalanceProposed,
+		Actions: []RebalanceAction{
+			{Type: RebalanceSell, Exchange: testExchange, Side: order.Sell, Amount: 1},
+			{Type: RebalanceTransfer, Exchange: testExchange, Destination: "b", Amount: 1},
+		},
+	}
+	m.execute(context.Background(), plan)
+	if plan.Status != RebalanceFailed || plan.Actions[0].Error == "" || plan.Actions[1].Executed {
+		t.Fatalf("unexpected plan %+v", plan)
+	}
+}
@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// RebalanceManagerName is an exported subsystem name
+const RebalanceManagerName = "rebalance_manager"
+
+// RebalanceColdStorage is the target exchange name which refers to portfolio
+// addresses flagged as cold storage
+const RebalanceColdStorage = "coldstorage"
+
+const (
+	// DefaultRebalanceInterval is the default duration between evaluations
+	DefaultRebalanceInterval = time.Hour * 24 * 7
+	// DefaultRebalanceDriftThreshold is the default allocation drift in
+	// percentage points required to action a plan
+	DefaultRebalanceDriftThreshold = 5.0
+	// rebalanceWeightTolerance is the allowed difference between the sum of
+	// target weights and 100%
+	rebalanceWeightTolerance = 0.0001
+	// maxRebalancePlans is the number of plans retained
+	maxRebalancePlans = 100
+	// rebalancePlanTolerance is the relative change in the amount of an action
+	// required for a new plan to supersede a plan pending approval
+	rebalancePlanTolerance = 0.1
+)
+
+// Rebalance action types
+const (
+	RebalanceBuy      RebalanceActionType = "BUY"
+	RebalanceSell     RebalanceActionType = "SELL"
+	RebalanceTransfer RebalanceActionType = "TRANSFER"
+)
+
+// Rebalance plan statuses
+const (
+	// RebalanceBalanced is set when no holding drifts beyond the threshold
+	RebalanceBalanced RebalancePlanStatus = "BALANCED"
+	// RebalanceProposed is set when a plan awaits approval or execution
+	RebalanceProposed RebalancePlanStatus = "PROPOSED"
+	// RebalanceDryRun is set when a plan is proposed in dry run mode and will
+	// never be executed
+	RebalanceDryRun    RebalancePlanStatus = "DRY_RUN"
+	RebalanceRejected  RebalancePlanStatus = "REJECTED"
+	RebalanceExecuting RebalancePlanStatus = "EXECUTING"
+	RebalanceExecuted  RebalancePlanStatus = "EXECUTED"
+	RebalanceFailed    RebalancePlanStatus = "FAILED"
+	// RebalanceSuperseded is set when a materially different plan is proposed
+	// before a plan was approved
+	RebalanceSuperseded RebalancePlanStatus = "SUPERSEDED"
+)
+
+var (
+	errNilRebalancerConfig     = errors.New("nil rebalancer config received")
+	errNoRebalanceTargets      = errors.New("no rebalance targets set")
+	errInvalidRebalanceTarget  = errors.New("invalid rebalance target")
+	errRebalanceWeightsInvalid = errors.New("rebalance target weights must sum to 100")
+	errRebalanceNeedsPortfolio = errors.New("cold storage targets require the portfolio manager")
+	errRebalanceNeedsExecutor  = errors.New("executing plans requires the order and withdraw managers")
+	errRebalancePlanNotFound   = errors.New("rebalance plan not found")
+	errRebalancePlanNotPending = errors.New("rebalance plan is not pending approval")
+	errRebalanceDryRun         = errors.New("rebalancer is in dry run mode")
+	errRebalanceExecuting      = errors.New("rebalance plan is executing")
+	errNoRebalanceHoldings     = errors.New("no holdings to rebalance")
+	errNoRebalancePrice        = errors.New("no price available")
+	errNoRebalancePair         = errors.New("no enabled pair with the quote currency")
+	errNoColdStorageAddress    = errors.New("no cold storage address")
+)
+
+// RebalanceActionType defines a trade or transfer proposed by a plan
+type RebalanceActionType string
+
+// RebalancePlanStatus defines the state of a plan
+type RebalancePlanStatus string
+
+// iWithdrawSubmitter limits the withdraw manager functionality used to
+// transfer holdings
+type iWithdrawSubmitter interface {
+	SubmitWithdrawal(context.Context, *withdraw.Request) (*withdraw.Response, error)
+}
+
+// iDepositAddressProvider limits the deposit address manager functionality
+// used to resolve transfer destinations
+type iDepositAddressProvider interface {
+	GetDepositAddressByExchangeAndCurrency(exchName, chain string, currencyItem currency.Code) (deposit.Address, error)
+}
+
+// RebalanceManager periodically compares holdings across exchanges and cold
+// storage with target allocations and proposes, and optionally executes, the
+// trades and transfers required to restore them
+type RebalanceManager struct {
+	started           int32
+	verbose           bool
+	interval          time.Duration
+	quote             currency.Code
+	driftThreshold    float64
+	minimumTradeValue float64
+	dryRun            bool
+	requireApproval   bool
+	targets           []rebalanceTarget
+	exchangeManager   iExchangeManager
+	portfolioManager  iPortfolioAddressProvider
+	orderManager      iOrderSubmitter
+	withdrawManager   iWithdrawSubmitter
+	depositAddresses  iDepositAddressProvider
+	commsManager      iCommsManager
+	plans             []*RebalancePlan
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	mu                sync.Mutex
+}
+
+// rebalanceTarget defines a parsed target weight
+type rebalanceTarget struct {
+	exchange string
+	currency currency.Code
+	weight   float64
+}
+
+// rebalanceLocation identifies a currency held at an exchange or in cold
+// storage
+type rebalanceLocation struct {
+	exchange string
+	currency *currency.Item
+}
+
+// RebalanceAllocation defines the current and target allocation of a currency
+// held at an exchange or in cold storage
+type RebalanceAllocation struct {
+	Exchange string
+	Currency currency.Code
+	Balance  float64
+	// Price and Value are denominated in the plan quote currency
+	Price         float64
+	Value         float64
+	TargetValue   float64
+	CurrentWeight float64
+	TargetWeight  float64
+	// Drift is the current weight less the target weight in percentage points
+	Drift float64
+}
+
+// RebalanceAction defines a trade or transfer proposed by a plan
+type RebalanceAction struct {
+	Type RebalanceActionType
+	// Exchange is the exchange traded on, or the source of a transfer
+	Exchange string
+	// Destination and Address are the target of a transfer
+	Destination string
+	Address     string
+	AddressTag  string
+	Chain       string
+	Pair        currency.Pair
+	Side        order.Side
+	Currency    currency.Code
+	// Amount is the base amount traded or the currency amount transferred
+	Amount float64
+	// Value is denominated in the plan quote currency
+	Value float64
+	// Manual is set when the action cannot be performed by the bot
+	Manual   bool
+	Executed bool
+	// ID is the order or withdrawal ID once executed
+	ID    string
+	Error string
+}
+
+// RebalancePlan defines the allocations evaluated and the actions required to
+// restore target allocations
+type RebalancePlan struct {
+	ID            uuid.UUID
+	Created       time.Time
+	Updated       time.Time
+	Status        RebalancePlanStatus
+	QuoteCurrency currency.Code
+	TotalValue    float64
+	// MaxDrift is the largest absolute drift of any allocation
+	MaxDrift    float64
+	Allocations []RebalanceAllocation
+	Actions     []RebalanceAction
+	Error       string
+}
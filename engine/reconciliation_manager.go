@@ -0,0 +1,562 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupReconciliationManager creates a new reconciliation manager from config.
+// The communications manager is optional and receives an alert when a
+// reconciliation finds mismatches.
+func SetupReconciliationManager(cfg *config.Reconciliation, exchangeManager iExchangeManager, orderManager iOrderSnapshotProvider, commsManager iCommsManager, verbose bool) (*ReconciliationManager, error) {
+	if cfg == nil {
+		return nil, errNilReconciliationConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if orderManager == nil {
+		return nil, errNilOrderSnapshot
+	}
+	m := &ReconciliationManager{
+		verbose:          verbose || cfg.Verbose,
+		interval:         cfg.Interval,
+		window:           cfg.Window,
+		feeTolerance:     cfg.FeeTolerance,
+		balanceTolerance: cfg.BalanceTolerance,
+		exchanges:        cfg.Exchanges,
+		exchangeManager:  exchangeManager,
+		orderManager:     orderManager,
+		commsManager:     commsManager,
+		balances:         make(map[string]*reconciliationBalances),
+		shutdown:         make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DefaultReconciliationInterval
+	}
+	if m.window <= 0 {
+		m.window = DefaultReconciliationWindow
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *ReconciliationManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *ReconciliationManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.OrderMgr, "Reconciliation manager %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.OrderMgr, "Reconciliation manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *ReconciliationManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.OrderMgr, "Reconciliation manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "Reconciliation manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run reconciles every interval. The first reconciliation only records
+// balances to compare against.
+func (m *ReconciliationManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	m.mu.Lock()
+	m.recordBalances(context.TODO())
+	m.mu.Unlock()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			if _, err := m.Reconcile(context.TODO(), "", time.Time{}, time.Time{}); err != nil {
+				log.Errorf(log.OrderMgr, "Reconciliation manager unable to reconcile: %v", err)
+			}
+		}
+	}
+}
+
+// Reconcile compares the orders tracked by the order manager with exchange
+// active orders and the order history between start and end, limited to an
+// exchange when set. The window defaults to the configured window ending now.
+// Balance drift is checked against the balances recorded by the previous
+// reconciliation when the window ends now. An alert is sent when mismatches
+// are found.
+func (m *ReconciliationManager) Reconcile(ctx context.Context, exchangeName string, start, end time.Time) (*ReconciliationReport, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if end.IsZero() {
+		end = now
+	}
+	if start.IsZero() {
+		start = end.Add(-m.window)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	var names []string
+	if exchangeName != "" {
+		names = []string{exchangeName}
+	} else {
+		names = m.exchanges
+	}
+	exchs, err := getAccountExchanges(m.exchangeManager, names)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := &ReconciliationReport{
+		Start:     start,
+		End:       end,
+		Generated: now,
+	}
+	local := m.orderManager.GetOrdersSnapshot(order.AnyStatus)
+	checkBalances := !end.Before(now.Add(-time.Minute))
+	for x := range exchs {
+		report.Exchanges = append(report.Exchanges, exchs[x].GetName())
+		accounts := exchangeAccountNames(exchs[x])
+		for a := range accounts {
+			first := len(report.Mismatches)
+			m.reconcileExchange(account.DeployAccountToContext(ctx, accounts[a]), exchs[x], accounts[a], local, start, end, checkBalances, report)
+			for i := first; i < len(report.Mismatches); i++ {
+				report.Mismatches[i].Account = accounts[a]
+			}
+		}
+	}
+	m.alert(report)
+	return report, nil
+}
+
+// checkRunning returns an error when the subsystem is nil or not running
+func (m *ReconciliationManager) checkRunning() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("%s %w", ReconciliationManagerName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// reconcileExchange compares the orders and balances of an exchange account
+func (m *ReconciliationManager) reconcileExchange(ctx context.Context, exch exchange.IBotExchange, accountName string, local []order.Detail, start, end time.Time, checkBalances bool, report *ReconciliationReport) {
+	historyStart := start
+	balanceKey := reconciliationBalanceKey(exch.GetName(), accountName)
+	previous := m.balances[balanceKey]
+	if checkBalances && previous != nil && previous.timestamp.Before(historyStart) {
+		historyStart = previous.timestamp
+	}
+	var spotHistory order.FilteredOrders
+	assetTypes := exch.GetAssetTypes(true)
+	for x := range assetTypes {
+		pairs, err := exch.GetEnabledPairs(assetTypes[x])
+		if err != nil || len(pairs) == 0 {
+			continue
+		}
+		var tracked []order.Detail
+		for y := range local {
+			if local[y].AssetType == assetTypes[x] &&
+				local[y].Account == accountName &&
+				strings.EqualFold(local[y].Exchange, exch.GetName()) {
+				tracked = append(tracked, local[y])
+			}
+		}
+
+		active, err := exch.GetActiveOrders(ctx, &order.GetOrdersRequest{
+			Type:      order.AnyType,
+			Side:      order.AnySide,
+			Pairs:     pairs,
+			AssetType: assetTypes[x],
+		})
+		if err != nil {
+			report.addError(fmt.Errorf("%s %s active orders: %w", exch.GetName(), assetTypes[x], err))
+		} else {
+			report.OrdersChecked += len(active)
+			m.compareActiveOrders(exch.GetName(), assetTypes[x], pairs, tracked, active, report)
+		}
+
+		history, err := exch.GetOrderHistory(ctx, &order.GetOrdersRequest{
+			Type:      order.AnyType,
+			Side:      order.AnySide,
+			StartTime: historyStart,
+			EndTime:   end,
+			Pairs:     pairs,
+			AssetType: assetTypes[x],
+		})
+		if err != nil {
+			report.addError(fmt.Errorf("%s %s order history: %w", exch.GetName(), assetTypes[x], err))
+			if assetTypes[x] == asset.Spot {
+				// Balance changes cannot be explained without fills
+				checkBalances = false
+			}
+			continue
+		}
+		for y := range history {
+			if history[y].Exchange == "" {
+				history[y].Exchange = exch.GetName()
+			}
+		}
+		if assetTypes[x] == asset.Spot {
+			spotHistory = history
+		}
+		inWindow := make([]order.Detail, 0, len(history))
+		for y := range history {
+			filled := orderFillTime(&history[y])
+			if filled.Before(start) || filled.After(end) {
+				continue
+			}
+			inWindow = append(inWindow, history[y])
+		}
+		report.OrdersChecked += len(inWindow)
+		m.compareOrderHistory(exch.GetName(), assetTypes[x], tracked, inWindow, report)
+	}
+	if checkBalances {
+		m.compareBalances(ctx, exch, balanceKey, previous, spotHistory, report)
+	}
+}
+
+// compareActiveOrders reports orders active on the exchange which are not
+// tracked, and tracked active orders which are no longer active on the
+// exchange
+func (m *ReconciliationManager) compareActiveOrders(exchName string, a asset.Item, pairs currency.Pairs, tracked, active []order.Detail, report *ReconciliationReport) {
+	remote := make(map[string]struct{}, len(active))
+	for x := range active {
+		remote[active[x].OrderID] = struct{}{}
+		if findOrder(tracked, active[x].OrderID) != nil {
+			continue
+		}
+		report.addMismatch(&ReconciliationMismatch{
+			Type:     OrphanOrder,
+			Exchange: exchName,
+			Asset:    a,
+			Pair:     active[x].Pair,
+			OrderID:  active[x].OrderID,
+			Expected: active[x].Amount,
+			Details:  fmt.Sprintf("%s %s order is active on the exchange but not tracked", active[x].Side, active[x].Type),
+		})
+	}
+	for x := range tracked {
+		if !tracked[x].IsActive() || !pairs.Contains(tracked[x].Pair, false) {
+			continue
+		}
+		if _, ok := remote[tracked[x].OrderID]; ok {
+			continue
+		}
+		report.addMismatch(&ReconciliationMismatch{
+			Type:     StaleOrder,
+			Exchange: exchName,
+			Asset:    a,
+			Pair:     tracked[x].Pair,
+			OrderID:  tracked[x].OrderID,
+			Actual:   tracked[x].Amount,
+			Details:  fmt.Sprintf("order is %s locally but not active on the exchange", tracked[x].Status),
+		})
+	}
+}
+
+// compareOrderHistory reports executed amounts and fees from exchange order
+// history which differ from the tracked orders
+func (m *ReconciliationManager) compareOrderHistory(exchName string, a asset.Item, tracked, history []order.Detail, report *ReconciliationReport) {
+	for x := range history {
+		remote := &history[x]
+		executed := executedAmount(remote)
+		d := findOrder(tracked, remote.OrderID)
+		var localExecuted float64
+		if d != nil {
+			localExecuted = executedAmount(d)
+		}
+		if executed > 0 && executed-localExecuted > m.balanceTolerance {
+			details := "fills were not seen by the order manager"
+			if d == nil {
+				details = "order is not tracked by the order manager"
+			}
+			report.addMismatch(&ReconciliationMismatch{
+				Type:     MissingFill,
+				Exchange: exchName,
+				Asset:    a,
+				Pair:     remote.Pair,
+				OrderID:  remote.OrderID,
+				Currency: remote.Pair.Base,
+				Expected: executed,
+				Actual:   localExecuted,
+				Details:  details,
+			})
+		}
+		if d == nil || remote.Fee == 0 && remote.FeeAsset.IsEmpty() {
+			// Exchanges which do not report fees cannot be compared
+			continue
+		}
+		if math.Abs(remote.Fee-d.Fee) > m.feeTolerance {
+			report.addMismatch(&ReconciliationMismatch{
+				Type:     FeeDiscrepancy,
+				Exchange: exchName,
+				Asset:    a,
+				Pair:     remote.Pair,
+				OrderID:  remote.OrderID,
+				Currency: remote.FeeAsset,
+				Expected: remote.Fee,
+				Actual:   d.Fee,
+				Details:  "fee charged by the exchange differs from the tracked fee",
+			})
+		}
+	}
+}
+
+// compareBalances reports spot balance changes since the previous
+// reconciliation which are not explained by fills, deposits and withdrawals,
+// then records the current balances for the next reconciliation
+func (m *ReconciliationManager) compareBalances(ctx context.Context, exch exchange.IBotExchange, balanceKey string, previous *reconciliationBalances, history []order.Detail, report *ReconciliationReport) {
+	current, err := getSpotBalances(ctx, exch)
+	if err != nil {
+		report.addError(fmt.Errorf("%s balances: %w", balanceKey, err))
+		return
+	}
+	m.balances[balanceKey] = current
+	if previous == nil {
+		return
+	}
+	expected := make(map[*currency.Item]float64)
+	codes := make(map[*currency.Item]currency.Code)
+	add := func(c currency.Code, amount float64) {
+		expected[c.Item] += amount
+		codes[c.Item] = c
+	}
+	for x := range history {
+		fills := orderFills(&history[x])
+		for y := range fills {
+			if !fills[y].Timestamp.After(previous.timestamp) || fills[y].Timestamp.After(current.timestamp) {
+				continue
+			}
+			value := fills[y].Amount * fills[y].Price
+			if fills[y].Buy {
+				add(fills[y].Base, fills[y].Amount)
+				add(fills[y].Quote, -value)
+			} else {
+				add(fills[y].Base, -fills[y].Amount)
+				add(fills[y].Quote, value)
+			}
+			feeAsset := fills[y].FeeAsset
+			if feeAsset.IsEmpty() {
+				feeAsset = fills[y].Quote
+			}
+			add(feeAsset, -fills[y].Fee)
+		}
+	}
+	transfers, err := exch.GetFundingHistory(ctx)
+	if err != nil && !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+		report.addError(fmt.Errorf("%s funding history: %w", exch.GetName(), err))
+		return
+	}
+	for x := range transfers {
+		if !transfers[x].Timestamp.After(previous.timestamp) || transfers[x].Timestamp.After(current.timestamp) {
+			continue
+		}
+		code := currency.NewCode(transfers[x].Currency)
+		switch fundingFlowType(&transfers[x]) {
+		case portfoliohistory.Deposit:
+			add(code, transfers[x].Amount)
+		case portfoliohistory.Withdrawal:
+			add(code, -transfers[x].Amount-transfers[x].Fee)
+		}
+	}
+
+	items := make(map[*currency.Item]struct{}, len(current.amounts)+len(previous.amounts))
+	for c := range current.amounts {
+		items[c] = struct{}{}
+	}
+	for c := range previous.amounts {
+		items[c] = struct{}{}
+	}
+	for c := range items {
+		code, ok := codes[c]
+		if !ok {
+			code = currency.Code{Item: c, UpperCase: true}
+		}
+		observed := current.amounts[c] - previous.amounts[c]
+		if math.Abs(observed-expected[c]) <= m.balanceTolerance {
+			continue
+		}
+		report.addMismatch(&ReconciliationMismatch{
+			Type:     BalanceDrift,
+			Exchange: exch.GetName(),
+			Asset:    asset.Spot,
+			Currency: code.Upper(),
+			Expected: expected[c],
+			Actual:   observed,
+			Details:  fmt.Sprintf("balance changed by %v since %v but fills and transfers account for %v", observed, previous.timestamp.UTC().Format(time.RFC3339), expected[c]),
+		})
+	}
+}
+
+// recordBalances records the current spot balances of each exchange to
+// compare against on the next reconciliation
+func (m *ReconciliationManager) recordBalances(ctx context.Context) {
+	exchs, err := getAccountExchanges(m.exchangeManager, m.exchanges)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Reconciliation manager cannot retrieve exchanges: %v", err)
+		return
+	}
+	for x := range exchs {
+		accounts := exchangeAccountNames(exchs[x])
+		for a := range accounts {
+			key := reconciliationBalanceKey(exchs[x].GetName(), accounts[a])
+			b, err := getSpotBalances(account.DeployAccountToContext(ctx, accounts[a]), exchs[x])
+			if err != nil {
+				log.Errorf(log.OrderMgr, "Reconciliation manager cannot retrieve %s balances: %v", key, err)
+				continue
+			}
+			m.balances[key] = b
+		}
+	}
+}
+
+// alert logs and sends a summary of the mismatches found via the
+// communications manager
+func (m *ReconciliationManager) alert(report *ReconciliationReport) {
+	for x := range report.Errors {
+		log.Warnf(log.OrderMgr, "Reconciliation manager check incomplete: %s", report.Errors[x])
+	}
+	if len(report.Mismatches) == 0 {
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Reconciliation manager checked %d orders, no mismatches found", report.OrdersChecked)
+		}
+		return
+	}
+	if m.verbose {
+		for x := range report.Mismatches {
+			mm := &report.Mismatches[x]
+			log.Warnf(log.OrderMgr, "Reconciliation mismatch %s %s %s %s order %s: %s expected %v actual %v",
+				mm.Type, mm.Exchange, mm.Asset, mm.Pair, mm.OrderID, mm.Details, mm.Expected, mm.Actual)
+		}
+	}
+	msg := report.Summary()
+	log.Warnln(log.OrderMgr, msg)
+	if m.commsManager != nil {
+		m.commsManager.PushEvent(base.Event{Type: "reconciliation", Message: msg})
+	}
+}
+
+// Summary returns the number of mismatches of each type found
+func (r *ReconciliationReport) Summary() string {
+	counts := make(map[ReconciliationMismatchType]int)
+	for x := range r.Mismatches {
+		counts[r.Mismatches[x].Type]++
+	}
+	types := make([]string, 0, len(counts))
+	for t, count := range counts {
+		types = append(types, fmt.Sprintf("%s: %d", t, count))
+	}
+	sort.Strings(types)
+	return fmt.Sprintf("Reconciliation of %s between %v and %v found %d mismatches (%s)",
+		strings.Join(r.Exchanges, ", "),
+		r.Start.UTC().Format(time.RFC3339),
+		r.End.UTC().Format(time.RFC3339),
+		len(r.Mismatches),
+		strings.Join(types, ", "))
+}
+
+// addMismatch appends a mismatch to the report
+func (r *ReconciliationReport) addMismatch(mm *ReconciliationMismatch) {
+	r.Mismatches = append(r.Mismatches, *mm)
+}
+
+// addError records a check which could not be completed
+func (r *ReconciliationReport) addError(err error) {
+	r.Errors = append(r.Errors, err.Error())
+}
+
+// getSpotBalances returns the total spot balance of each currency across the
+// accounts of an exchange
+func getSpotBalances(ctx context.Context, exch exchange.IBotExchange) (*reconciliationBalances, error) {
+	h, err := exch.UpdateAccountInfo(ctx, asset.Spot)
+	if err != nil {
+		return nil, err
+	}
+	resp := &reconciliationBalances{
+		timestamp: time.Now(),
+		amounts:   make(map[*currency.Item]float64),
+	}
+	for x := range h.Accounts {
+		for y := range h.Accounts[x].Currencies {
+			bal := &h.Accounts[x].Currencies[y]
+			resp.amounts[bal.CurrencyName.Item] += bal.Total
+		}
+	}
+	return resp, nil
+}
+
+// reconciliationBalanceKey returns the key of the recorded balances of an
+// exchange account
+func reconciliationBalanceKey(exchangeName, accountName string) string {
+	if accountName == "" {
+		return exchangeName
+	}
+	return exchangeName + " " + accountName
+}
+
+// findOrder returns the order with a matching order ID
+func findOrder(orders []order.Detail, orderID string) *order.Detail {
+	for x := range orders {
+		if orders[x].OrderID == orderID {
+			return &orders[x]
+		}
+	}
+	return nil
+}
+
+// executedAmount returns the executed amount of an order, inferring it from
+// the order amount when filled
+func executedAmount(d *order.Detail) float64 {
+	if d.ExecutedAmount > 0 {
+		return d.ExecutedAmount
+	}
+	if d.Status == order.Filled {
+		return d.Amount
+	}
+	return 0
+}
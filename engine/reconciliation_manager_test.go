@@ -0,0 +1,295 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// fReconcileExchange overrides active orders, order history, balances and
+// funding history with static values
+type fReconcileExchange struct {
+	exchange.IBotExchange
+	m        sync.Mutex
+	active   []order.Detail
+	history  []order.Detail
+	balances []account.Balance
+	funding  []exchange.FundHistory
+}
+
+func (f *fReconcileExchange) GetActiveOrders(context.Context, *order.GetOrdersRequest) (order.FilteredOrders, error) {
+	return append(order.FilteredOrders(nil), f.active...), nil
+}
+
+func (f *fReconcileExchange) GetOrderHistory(context.Context, *order.GetOrdersRequest) (order.FilteredOrders, error) {
+	return append(order.FilteredOrders(nil), f.history...), nil
+}
+
+func (f *fReconcileExchange) UpdateAccountInfo(context.Context, asset.Item) (account.Holdings, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	return account.Holdings{
+		Exchange: f.GetName(),
+		Accounts: []account.SubAccount{{AssetType: asset.Spot, Currencies: append([]account.Balance(nil), f.balances...)}},
+	}, nil
+}
+
+func (f *fReconcileExchange) GetFundingHistory(context.Context) ([]exchange.FundHistory, error) {
+	return f.funding, nil
+}
+
+// fReconcileComms records events pushed
+type fReconcileComms struct {
+	m      sync.Mutex
+	events []base.Event
+}
+
+func (f *fReconcileComms) PushEvent(evt base.Event) {
+	f.m.Lock()
+	f.events = append(f.events, evt)
+	f.m.Unlock()
+}
+
+func setupReconciliationManager(t *testing.T, name string, exch *fReconcileExchange, om iOrderSnapshotProvider, comms iCommsManager) *ReconciliationManager {
+	t.Helper()
+	em := SetupExchangeManager()
+	b, err := em.NewExchangeByName("ftx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	base := b.GetBase()
+	base.Name = name
+	base.Enabled = true
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	base.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Spot: {
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+			RequestFormat: &currency.PairFormat{Delimiter: "/"},
+			Available:     currency.Pairs{btcusd},
+			Enabled:       currency.Pairs{btcusd},
+		},
+	}
+	exch.IBotExchange = b
+	em.Add(exch)
+	m, err := SetupReconciliationManager(&config.Reconciliation{
+		Exchanges:        []string{name},
+		FeeTolerance:     1e-8,
+		BalanceTolerance: 1e-8,
+	}, em, om, comms, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.started = 1
+	return m
+}
+
+func TestSetupReconciliationManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupReconciliationManager(nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilReconciliationConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilReconciliationConfig)
+	}
+	cfg := &config.Reconciliation{}
+	_, err = SetupReconciliationManager(cfg, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	em := SetupExchangeManager()
+	_, err = SetupReconciliationManager(cfg, em, nil, nil, false)
+	if !errors.Is(err, errNilOrderSnapshot) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOrderSnapshot)
+	}
+	m, err := SetupReconciliationManager(cfg, em, &fTaxLotOrders{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.interval != DefaultReconciliationInterval || m.window != DefaultReconciliationWindow {
+		t.Fatalf("unexpected manager values %+v", m)
+	}
+}
+
+func TestReconciliationManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *ReconciliationManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+	_, err = m.Reconcile(context.Background(), "", time.Time{}, time.Time{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	exch := &fReconcileExchange{balances: []account.Balance{{CurrencyName: currency.BTC, Total: 1}}}
+	m = setupReconciliationManager(t, "ReconcileStartStop", exch, &fTaxLotOrders{}, nil)
+	m.started = 0
+	_, err = m.Reconcile(context.Background(), "", time.Time{}, time.Time{})
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// Balances are recorded on start
+	m.mu.Lock()
+	_, ok := m.balances["ReconcileStartStop"]
+	m.mu.Unlock()
+	if !ok {
+		t.Fatal("expected balances to be recorded on start")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	exch := &fReconcileExchange{
+		active: []order.Detail{
+			{OrderID: "tracked", Pair: btcusd, AssetType: asset.Spot, Side: order.Buy, Status: order.Active, Amount: 1},
+			{OrderID: "orphan", Pair: btcusd, AssetType: asset.Spot, Side: order.Sell, Status: order.Active, Amount: 2},
+		},
+		history: []order.Detail{
+			{OrderID: "partial", Pair: btcusd, AssetType: asset.Spot, Side: order.Buy, Status: order.Filled, Amount: 1, ExecutedAmount: 1, Price: 100, Fee: 2, FeeAsset: currency.USD, CloseTime: now.Add(-time.Hour)},
+			{OrderID: "untracked", Pair: btcusd, AssetType: asset.Spot, Side: order.Sell, Status: order.Filled, Amount: 2, Price: 100, CloseTime: now.Add(-time.Hour)},
+			{OrderID: "old", Pair: btcusd, AssetType: asset.Spot, Side: order.Sell, Status: order.Filled, Amount: 2, Price: 100, CloseTime: now.AddDate(0, 0, -3)},
+		},
+		balances: []account.Balance{{CurrencyName: currency.BTC, Total: 1}},
+	}
+	om := &fTaxLotOrders{orders: []order.Detail{
+		{Exchange: "Reconcile", OrderID: "tracked", Pair: btcusd, AssetType: asset.Spot, Status: order.Active, Amount: 1},
+		{Exchange: "Reconcile", OrderID: "stale", Pair: btcusd, AssetType: asset.Spot, Status: order.Active, Amount: 1},
+		{Exchange: "Reconcile", OrderID: "partial", Pair: btcusd, AssetType: asset.Spot, Status: order.PartiallyFilled, Amount: 1, ExecutedAmount: 0.5, Fee: 1},
+	}}
+	comms := &fReconcileComms{}
+	m := setupReconciliationManager(t, "Reconcile", exch, om, comms)
+
+	_, err := m.Reconcile(context.Background(), "", now, now.Add(-time.Hour))
+	if !errors.Is(err, common.ErrStartAfterEnd) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrStartAfterEnd)
+	}
+	_, err = m.Reconcile(context.Background(), "bogus", time.Time{}, time.Time{})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+
+	report, err := m.Reconcile(context.Background(), "", time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	counts := make(map[ReconciliationMismatchType]int)
+	for x := range report.Mismatches {
+		counts[report.Mismatches[x].Type]++
+		if report.Mismatches[x].Type == MissingFill && report.Mismatches[x].OrderID == "partial" &&
+			(report.Mismatches[x].Expected != 1 || report.Mismatches[x].Actual != 0.5) {
+			t.Errorf("unexpected missing fill %+v", report.Mismatches[x])
+		}
+	}
+	if counts[OrphanOrder] != 1 || counts[StaleOrder] != 2 || counts[MissingFill] != 2 ||
+		counts[FeeDiscrepancy] != 1 || counts[BalanceDrift] != 0 {
+		t.Fatalf("unexpected mismatches %+v", report.Mismatches)
+	}
+	if report.OrdersChecked != 4 || len(report.Exchanges) != 1 || len(report.Errors) != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	comms.m.Lock()
+	if len(comms.events) != 1 || comms.events[0].Type != "reconciliation" {
+		t.Errorf("unexpected events %+v", comms.events)
+	}
+	comms.m.Unlock()
+	m.mu.Lock()
+	_, ok := m.balances["Reconcile"]
+	m.mu.Unlock()
+	if !ok {
+		t.Fatal("expected balances to be recorded")
+	}
+}
+
+func TestReconcileBalances(t *testing.T) {
+	t.Parallel()
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	now := time.Now()
+	exch := &fReconcileExchange{
+		balances: []account.Balance{
+			{CurrencyName: currency.BTC, Total: 1.5},
+			{CurrencyName: currency.USD, Total: 1049},
+		},
+		funding: []exchange.FundHistory{
+			{TransferType: "deposit", Currency: "usd", Amount: 100, Timestamp: now.Add(-time.Minute * 10)},
+			{TransferType: "withdrawal", Status: "cancelled", Currency: "USD", Amount: 100, Timestamp: now.Add(-time.Minute * 10)},
+			{TransferType: "deposit", Currency: "USD", Amount: 100, Timestamp: now.Add(-time.Hour * 2)},
+		},
+	}
+	m := setupReconciliationManager(t, "ReconcileBalances", exch, &fTaxLotOrders{}, nil)
+	history := []order.Detail{
+		{Exchange: "ReconcileBalances", OrderID: "1", Pair: btcusd, AssetType: asset.Spot, Side: order.Buy, Status: order.Filled, ExecutedAmount: 0.5, Price: 100, CloseTime: now.Add(-time.Minute * 30),
+			Trades: []order.TradeHistory{{TID: "t1", Amount: 0.5, Price: 100, Fee: 1, Timestamp: now.Add(-time.Minute * 30)}}},
+	}
+	previous := &reconciliationBalances{
+		timestamp: now.Add(-time.Hour),
+		amounts: map[*currency.Item]float64{
+			currency.BTC.Item: 1,
+			currency.USD.Item: 1000,
+		},
+	}
+
+	report := &ReconciliationReport{}
+	m.compareBalances(context.Background(), exch, exch.GetName(), previous, history, report)
+	if len(report.Mismatches) != 0 || len(report.Errors) != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	exch.m.Lock()
+	exch.balances[1].Total = 1000
+	exch.m.Unlock()
+	m.compareBalances(context.Background(), exch, exch.GetName(), previous, history, report)
+	if len(report.Mismatches) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(report.Mismatches), 1)
+	}
+	mm := report.Mismatches[0]
+	if mm.Type != BalanceDrift || !mm.Currency.Equal(currency.USD) || mm.Expected != 49 || mm.Actual != 0 {
+		t.Fatalf("unexpected mismatch %+v", mm)
+	}
+}
+
+func TestReconciliationReportSummary(t *testing.T) {
+	t.Parallel()
+	r := &ReconciliationReport{
+		Exchanges: []string{"one", "two"},
+		Mismatches: []ReconciliationMismatch{
+			{Type: OrphanOrder}, {Type: BalanceDrift}, {Type: OrphanOrder},
+		},
+	}
+	if s := r.Summary(); s != "Reconciliation of one, two between 0001-01-01T00:00:00Z and 0001-01-01T00:00:00Z found 3 mismatches (balance_drift: 1, orphan_order: 2)" {
+		t.Fatalf("unexpected summary %v", s)
+	}
+}
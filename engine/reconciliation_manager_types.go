@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// ReconciliationManagerName is an exported subsystem name
+const ReconciliationManagerName = "reconciliation_manager"
+
+// DefaultReconciliationInterval is the default duration between scheduled
+// reconciliations
+const DefaultReconciliationInterval = time.Hour * 24
+
+// DefaultReconciliationWindow is the default duration of exchange order
+// history compared
+const DefaultReconciliationWindow = time.Hour * 24
+
+// Mismatch types reported by a reconciliation
+const (
+	// OrphanOrder is an order active on the exchange which is not tracked by
+	// the order manager
+	OrphanOrder ReconciliationMismatchType = "orphan_order"
+	// StaleOrder is an order active in the order manager which is no longer
+	// active on the exchange
+	StaleOrder ReconciliationMismatchType = "stale_order"
+	// MissingFill is an executed amount reported by the exchange which the
+	// order manager has not seen
+	MissingFill ReconciliationMismatchType = "missing_fill"
+	// FeeDiscrepancy is a difference between the fee charged by the exchange
+	// and the fee tracked by the order manager
+	FeeDiscrepancy ReconciliationMismatchType = "fee_discrepancy"
+	// BalanceDrift is a change in an exchange balance which is not explained
+	// by fills, deposits and withdrawals
+	BalanceDrift ReconciliationMismatchType = "balance_drift"
+)
+
+var (
+	errNilReconciliationConfig = errors.New("nil reconciliation config received")
+	errNilOrderSnapshot        = errors.New("order manager is required for reconciliation")
+)
+
+// ReconciliationMismatchType describes a mismatch found by a reconciliation
+type ReconciliationMismatchType string
+
+// ReconciliationMismatch is a difference between the state tracked by the
+// engine and the state reported by an exchange
+type ReconciliationMismatch struct {
+	Type     ReconciliationMismatchType
+	Exchange string
+	// Account is the name of the exchange account, empty for the default
+	// credentials
+	Account  string
+	Asset    asset.Item
+	Pair     currency.Pair
+	OrderID  string
+	Currency currency.Code
+	// Expected is the value reported by the exchange, or the expected balance
+	// change for balance drift
+	Expected float64
+	// Actual is the value tracked by the engine, or the observed balance
+	// change for balance drift
+	Actual  float64
+	Details string
+}
+
+// ReconciliationReport holds the mismatches found between the engine and
+// exchanges for a window of time
+type ReconciliationReport struct {
+	Start     time.Time
+	End       time.Time
+	Generated time.Time
+	Exchanges []string
+	// OrdersChecked is the number of exchange orders compared
+	OrdersChecked int
+	Mismatches    []ReconciliationMismatch
+	// Errors holds the checks which could not be completed
+	Errors []string
+}
+
+// ReconciliationManager compares the orders tracked by the order manager with
+// the active orders, order history and balances reported by exchanges
+type ReconciliationManager struct {
+	started          int32
+	verbose          bool
+	interval         time.Duration
+	window           time.Duration
+	feeTolerance     float64
+	balanceTolerance float64
+	exchanges        []string
+	exchangeManager  iExchangeManager
+	orderManager     iOrderSnapshotProvider
+	commsManager     iCommsManager
+	// balances holds the spot balances of each exchange account from the
+	// previous reconciliation
+	balances map[string]*reconciliationBalances
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+}
+
+// reconciliationBalances are the total balances of an exchange at a point in
+// time
+type reconciliationBalances struct {
+	timestamp time.Time
+	amounts   map[*currency.Item]float64
+}
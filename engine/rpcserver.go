@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -25,6 +26,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
 	"github.com/thrasher-corp/gocryptotrader/common/timeperiods"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
@@ -38,6 +40,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook/consolidated"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -48,6 +51,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -77,6 +81,7 @@ var (
 	errGRPCShutdownSignalIsNil = errors.New("cannot shutdown, gRPC shutdown channel is nil")
 	errInvalidStrategy         = errors.New("invalid strategy")
 	errSpecificPairNotEnabled  = errors.New("specified pair is not enabled")
+	errNoConsolidatedVenues    = errors.New("no enabled exchange supports the requested pair")
 )
 
 // RPCServer struct
@@ -124,6 +129,21 @@ func (s *RPCServer) authenticateClient(ctx context.Context) (context.Context, er
 	return ctx, nil
 }
 
+// traceRequest starts a span for each gRPC request, continuing the trace of a
+// caller which supplies a traceparent header
+func traceRequest(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if traceparent := md.Get("traceparent"); len(traceparent) > 0 {
+			ctx = tracing.Extract(ctx, traceparent[0])
+		}
+	}
+	ctx, span := tracing.StartSpan(ctx, "gctrpc.Handler", tracing.String("rpc.method", info.FullMethod))
+	resp, err := handler(ctx, req)
+	span.RecordError(err)
+	span.End()
+	return resp, err
+}
+
 // StartRPCServer starts a gRPC server with TLS auth
 func StartRPCServer(engine *Engine) {
 	targetDir := utils.GetTLSDir(engine.Settings.DataDir)
@@ -147,7 +167,7 @@ func StartRPCServer(engine *Engine) {
 	s := RPCServer{Engine: engine}
 	opts := []grpc.ServerOption{
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(s.authenticateClient)),
+		grpc.ChainUnaryInterceptor(traceRequest, grpcauth.UnaryServerInterceptor(s.authenticateClient)),
 	}
 	server := grpc.NewServer(opts...)
 	gctrpc.RegisterGoCryptoTraderServiceServer(server, &s)
@@ -589,6 +609,11 @@ func (s *RPCServer) GetAccountInfo(ctx context.Context, r *gctrpc.GetAccountInfo
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := exch.FetchAccountInfo(ctx, assetType)
 	if err != nil {
 		return nil, err
@@ -614,6 +639,11 @@ func (s *RPCServer) UpdateAccountInfo(ctx context.Context, r *gctrpc.GetAccountI
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := exch.UpdateAccountInfo(ctx, assetType)
 	if err != nil {
 		return nil, err
@@ -667,7 +697,12 @@ func (s *RPCServer) GetAccountInfoStream(r *gctrpc.GetAccountInfoRequest, stream
 		return err
 	}
 
-	initAcc, err := exch.FetchAccountInfo(stream.Context(), assetType)
+	ctx, err := deployExchangeAccount(stream.Context(), exch, r.Account)
+	if err != nil {
+		return err
+	}
+
+	initAcc, err := exch.FetchAccountInfo(ctx, assetType)
 	if err != nil {
 		return err
 	}
@@ -934,6 +969,11 @@ func (s *RPCServer) GetOrders(ctx context.Context, r *gctrpc.GetOrdersRequest) (
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var start, end time.Time
 	if r.StartDate != "" {
 		start, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.StartDate)
@@ -1006,6 +1046,7 @@ func (s *RPCServer) GetOrders(ctx context.Context, r *gctrpc.GetOrdersRequest) (
 			Fee:           resp[x].Fee,
 			Cost:          resp[x].Cost,
 			Trades:        trades,
+			Account:       account.GetAccountFromContext(ctx),
 		}
 		if !resp[x].Date.IsZero() {
 			o.CreationTime = resp[x].Date.Format(common.SimpleTimeFormatWithTimezone)
@@ -1054,6 +1095,7 @@ func (s *RPCServer) GetManagedOrders(_ context.Context, r *gctrpc.GetOrdersReque
 		Exchange:  exch.GetName(),
 		Pair:      cp,
 		AssetType: a,
+		Account:   account.NormaliseAccountName(r.Account),
 	}
 	resp, err = s.OrderManager.GetOrdersFiltered(&filter)
 	if err != nil {
@@ -1095,6 +1137,7 @@ func (s *RPCServer) GetManagedOrders(_ context.Context, r *gctrpc.GetOrdersReque
 			Fee:           resp[x].Fee,
 			Cost:          resp[x].Cost,
 			Trades:        trades,
+			Account:       resp[x].Account,
 		}
 		if !resp[x].Date.IsZero() {
 			o.CreationTime = resp[x].Date.Format(common.SimpleTimeFormatWithTimezone)
@@ -1139,6 +1182,11 @@ func (s *RPCServer) GetOrder(ctx context.Context, r *gctrpc.GetOrderRequest) (*g
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := s.OrderManager.GetOrderInfo(ctx,
 		r.Exchange,
 		r.OrderId,
@@ -1188,6 +1236,7 @@ func (s *RPCServer) GetOrder(ctx context.Context, r *gctrpc.GetOrderRequest) (*g
 		Trades:        trades,
 		Cost:          result.Cost,
 		UpdateTime:    updateTime,
+		Account:       result.Account,
 	}, err
 }
 
@@ -1219,6 +1268,11 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	side, err := order.StringToOrderSide(r.Side)
 	if err != nil {
 		return nil, err
@@ -1405,6 +1459,11 @@ func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderReques
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var side order.Side
 	side, err = order.StringToOrderSide(r.Side)
 	if err != nil {
@@ -1451,6 +1510,11 @@ func (s *RPCServer) CancelBatchOrders(ctx context.Context, r *gctrpc.CancelBatch
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	var side order.Side
 	side, err = order.StringToOrderSide(r.Side)
 	if err != nil {
@@ -1494,6 +1558,11 @@ func (s *RPCServer) CancelAllOrders(ctx context.Context, r *gctrpc.CancelAllOrde
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: Change to order manager
 	resp, err := exch.CancelAllOrders(ctx, nil)
 	if err != nil {
@@ -1526,6 +1595,11 @@ func (s *RPCServer) ModifyOrder(ctx context.Context, r *gctrpc.ModifyOrderReques
 	if err != nil {
 		return nil, err
 	}
+
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := s.OrderManager.Modify(ctx, &order.Modify{
 		Exchange:  r.Exchange,
 		AssetType: assetType,
@@ -1684,7 +1758,12 @@ func (s *RPCServer) GetAvailableTransferChains(ctx context.Context, r *gctrpc.Ge
 // WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
 // exchange
 func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
-	_, err := s.GetExchangeByName(r.Exchange)
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
 	if err != nil {
 		return nil, err
 	}
@@ -1707,9 +1786,10 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.W
 	if err != nil {
 		return nil, err
 	}
+	creds := withdrawalCredentials(exchCfg, account.GetAccountFromContext(ctx))
 
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
+	if creds.OTPSecret != "" {
+		code, errOTP := totp.GenerateCode(creds.OTPSecret, time.Now())
 		if errOTP != nil {
 			return nil, errOTP
 		}
@@ -1721,15 +1801,15 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.W
 		request.OneTimePassword = codeNum
 	}
 
-	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPin := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
+	if creds.PIN != "" {
+		pinCode, errPin := strconv.ParseInt(creds.PIN, 10, 64)
 		if err != nil {
 			return nil, errPin
 		}
 		request.PIN = pinCode
 	}
 
-	request.TradePassword = exchCfg.API.Credentials.TradePassword
+	request.TradePassword = creds.TradePassword
 
 	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, request)
 	if err != nil {
@@ -1749,6 +1829,11 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 		return nil, err
 	}
 
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+
 	bankAccount, err := banking.GetBankAccountByID(r.BankAccountId)
 	if err != nil {
 		base := exch.GetBase()
@@ -1777,9 +1862,10 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 	if err != nil {
 		return nil, err
 	}
+	creds := withdrawalCredentials(exchCfg, account.GetAccountFromContext(ctx))
 
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
+	if creds.OTPSecret != "" {
+		code, errOTP := totp.GenerateCode(creds.OTPSecret, time.Now())
 		if err != nil {
 			return nil, errOTP
 		}
@@ -1791,15 +1877,15 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 		request.OneTimePassword = codeNum
 	}
 
-	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPIN := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
+	if creds.PIN != "" {
+		pinCode, errPIN := strconv.ParseInt(creds.PIN, 10, 64)
 		if err != nil {
 			return nil, errPIN
 		}
 		request.PIN = pinCode
 	}
 
-	request.TradePassword = exchCfg.API.Credentials.TradePassword
+	request.TradePassword = creds.TradePassword
 
 	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, request)
 	if err != nil {
@@ -1812,6 +1898,18 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 	}, nil
 }
 
+// withdrawalCredentials returns the credentials of the exchange account a
+// withdrawal is made from, which hold its one time password secret, PIN and
+// trade password
+func withdrawalCredentials(exchCfg *config.Exchange, accountName string) *config.APICredentialsConfig {
+	for i := range exchCfg.API.Accounts {
+		if accountName != "" && exchCfg.API.Accounts[i].Name == accountName {
+			return &exchCfg.API.Accounts[i].Credentials
+		}
+	}
+	return &exchCfg.API.Credentials
+}
+
 // WithdrawalEventByID returns previous withdrawal request details
 func (s *RPCServer) WithdrawalEventByID(_ context.Context, r *gctrpc.WithdrawalEventByIDRequest) (*gctrpc.WithdrawalEventByIDResponse, error) {
 	if !s.Config.Database.Enabled {
@@ -3301,6 +3399,53 @@ func (s *RPCServer) ConvertTradesToCandles(_ context.Context, r *gctrpc.ConvertT
 	return resp, nil
 }
 
+// GetBuiltCandles returns the candles built from live websocket trades by the
+// candle builder, optionally including the in-progress candle
+func (s *RPCServer) GetBuiltCandles(_ context.Context, r *gctrpc.GetBuiltCandlesRequest) (*gctrpc.GetHistoricCandlesResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetBuiltCandlesRequest", common.ErrNilPointer)
+	}
+	if r.Exchange == "" || r.Pair == nil || r.AssetType == "" || r.Pair.String() == "" || r.TimeInterval <= 0 {
+		return nil, errInvalidArguments
+	}
+	if !s.CandleBuilder.IsRunning() {
+		return nil, fmt.Errorf("%s %w", CandleBuilderManagerName, ErrSubSystemNotStarted)
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	p := currency.Pair{
+		Delimiter: r.Pair.Delimiter,
+		Base:      currency.NewCode(r.Pair.Base),
+		Quote:     currency.NewCode(r.Pair.Quote),
+	}
+	interval := kline.Interval(r.TimeInterval)
+	klineItem, err := s.CandleBuilder.GetCandles(r.Exchange, p, a, interval, r.IncludeCurrent)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetHistoricCandlesResponse{
+		Exchange: klineItem.Exchange,
+		Pair:     r.Pair,
+		Start:    klineItem.Candles[0].Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+		End:      klineItem.Candles[len(klineItem.Candles)-1].Time.Add(interval.Duration()).In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+		Interval: interval.String(),
+		Candle:   make([]*gctrpc.Candle, len(klineItem.Candles)),
+	}
+	for i := range klineItem.Candles {
+		resp.Candle[i] = &gctrpc.Candle{
+			Time:   klineItem.Candles[i].Time.In(time.UTC).Format(common.SimpleTimeFormatWithTimezone),
+			Low:    klineItem.Candles[i].Low,
+			High:   klineItem.Candles[i].High,
+			Open:   klineItem.Candles[i].Open,
+			Close:  klineItem.Candles[i].Close,
+			Volume: klineItem.Candles[i].Volume,
+		}
+	}
+	return resp, nil
+}
+
 // FindMissingSavedCandleIntervals is used to help determine what candle data is missing
 func (s *RPCServer) FindMissingSavedCandleIntervals(_ context.Context, r *gctrpc.FindMissingCandlePeriodsRequest) (*gctrpc.FindMissingIntervalsResponse, error) {
 	if r.End == "" || r.Start == "" || r.ExchangeName == "" || r.Pair == nil || r.AssetType == "" || r.Pair.String() == "" || r.Interval <= 0 {
@@ -4404,6 +4549,11 @@ func (s *RPCServer) GetFuturesPositions(ctx context.Context, r *gctrpc.GetFuture
 	if err != nil {
 		return nil, err
 	}
+
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
 	if !ai.IsFutures() {
 		return nil, fmt.Errorf("%s %w", ai, order.ErrNotFuturesAsset)
 	}
@@ -4778,6 +4928,11 @@ func (s *RPCServer) GetCollateral(ctx context.Context, r *gctrpc.GetCollateralRe
 	if err != nil {
 		return nil, err
 	}
+
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
 	if !a.IsFutures() {
 		return nil, fmt.Errorf("%s %w", a, order.ErrNotFuturesAsset)
 	}
@@ -5156,6 +5311,130 @@ func (s *RPCServer) GetTechnicalAnalysis(ctx context.Context, r *gctrpc.GetTechn
 			return nil, err
 		}
 		signals["RSI"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "STOCH":
+		var stoch *kline.Stochastic
+		stoch, err = klines.GetStochastic(r.Period, r.SmoothPeriod, r.SignalPeriod)
+		if err != nil {
+			return nil, err
+		}
+		signals["K"] = &gctrpc.ListOfSignals{Signals: stoch.K}
+		signals["D"] = &gctrpc.ListOfSignals{Signals: stoch.D}
+	case "STOCHRSI":
+		stochPeriod := r.SecondaryPeriod
+		if stochPeriod == 0 {
+			stochPeriod = r.Period
+		}
+		var stoch *kline.Stochastic
+		stoch, err = klines.GetStochasticRSIOnClose(r.Period, stochPeriod, r.SmoothPeriod, r.SignalPeriod)
+		if err != nil {
+			return nil, err
+		}
+		signals["K"] = &gctrpc.ListOfSignals{Signals: stoch.K}
+		signals["D"] = &gctrpc.ListOfSignals{Signals: stoch.D}
+	case "ADX", "DMI":
+		var dmi *kline.DirectionalMovement
+		dmi, err = klines.GetDirectionalMovementIndex(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["ADX"] = &gctrpc.ListOfSignals{Signals: dmi.ADX}
+		signals["PLUS_DI"] = &gctrpc.ListOfSignals{Signals: dmi.PlusDI}
+		signals["MINUS_DI"] = &gctrpc.ListOfSignals{Signals: dmi.MinusDI}
+	case "ICHIMOKU":
+		var cloud *kline.Ichimoku
+		cloud, err = klines.GetIchimokuCloud(r.FastPeriod, r.Period, r.SlowPeriod, r.Displacement)
+		if err != nil {
+			return nil, err
+		}
+		signals["CONVERSION"] = &gctrpc.ListOfSignals{Signals: cloud.Conversion}
+		signals["BASE"] = &gctrpc.ListOfSignals{Signals: cloud.Base}
+		signals["LEADING_SPAN_A"] = &gctrpc.ListOfSignals{Signals: cloud.LeadingSpanA}
+		signals["LEADING_SPAN_B"] = &gctrpc.ListOfSignals{Signals: cloud.LeadingSpanB}
+		signals["LAGGING_SPAN"] = &gctrpc.ListOfSignals{Signals: cloud.LaggingSpan}
+	case "KELTNER":
+		atrPeriod := r.SecondaryPeriod
+		if atrPeriod == 0 {
+			atrPeriod = r.Period
+		}
+		var channel *kline.Channel
+		channel, err = klines.GetKeltnerChannels(r.Period, atrPeriod, r.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		signals["UPPER"] = &gctrpc.ListOfSignals{Signals: channel.Upper}
+		signals["MIDDLE"] = &gctrpc.ListOfSignals{Signals: channel.Middle}
+		signals["LOWER"] = &gctrpc.ListOfSignals{Signals: channel.Lower}
+	case "DONCHIAN":
+		var channel *kline.Channel
+		channel, err = klines.GetDonchianChannels(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["UPPER"] = &gctrpc.ListOfSignals{Signals: channel.Upper}
+		signals["MIDDLE"] = &gctrpc.ListOfSignals{Signals: channel.Middle}
+		signals["LOWER"] = &gctrpc.ListOfSignals{Signals: channel.Lower}
+	case "SUPERTREND":
+		var supertrend *kline.Supertrend
+		supertrend, err = klines.GetSupertrend(r.Period, r.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		signals["SUPERTREND"] = &gctrpc.ListOfSignals{Signals: supertrend.Values}
+		signals["DIRECTION"] = &gctrpc.ListOfSignals{Signals: supertrend.Direction}
+	case "VWAPBANDS":
+		var channel *kline.Channel
+		channel, err = klines.GetVWAPBands(r.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		signals["UPPER"] = &gctrpc.ListOfSignals{Signals: channel.Upper}
+		signals["VWAP"] = &gctrpc.ListOfSignals{Signals: channel.Middle}
+		signals["LOWER"] = &gctrpc.ListOfSignals{Signals: channel.Lower}
+	case "CCI":
+		var prices []float64
+		prices, err = klines.GetCommodityChannelIndex(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["CCI"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "WILLR":
+		var prices []float64
+		prices, err = klines.GetWilliamsPercentRange(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["WILLR"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "SAR":
+		var prices []float64
+		prices, err = klines.GetParabolicSAR(r.AccelerationStep, r.AccelerationMaximum)
+		if err != nil {
+			return nil, err
+		}
+		signals["SAR"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "HEIKINASHI":
+		var ha *kline.Item
+		ha, err = klines.GetHeikinAshi()
+		if err != nil {
+			return nil, err
+		}
+		ohlc := ha.GetOHLC()
+		signals["OPEN"] = &gctrpc.ListOfSignals{Signals: ohlc.Open}
+		signals["HIGH"] = &gctrpc.ListOfSignals{Signals: ohlc.High}
+		signals["LOW"] = &gctrpc.ListOfSignals{Signals: ohlc.Low}
+		signals["CLOSE"] = &gctrpc.ListOfSignals{Signals: ohlc.Close}
+	case "PIVOT":
+		var pivots *kline.PivotPoints
+		pivots, err = klines.GetPivotPoints(kline.PivotMethod(strings.ToLower(r.PivotMethod)))
+		if err != nil {
+			return nil, err
+		}
+		signals["PIVOT"] = &gctrpc.ListOfSignals{Signals: pivots.Pivot}
+		signals["R1"] = &gctrpc.ListOfSignals{Signals: pivots.Resistance1}
+		signals["R2"] = &gctrpc.ListOfSignals{Signals: pivots.Resistance2}
+		signals["R3"] = &gctrpc.ListOfSignals{Signals: pivots.Resistance3}
+		signals["S1"] = &gctrpc.ListOfSignals{Signals: pivots.Support1}
+		signals["S2"] = &gctrpc.ListOfSignals{Signals: pivots.Support2}
+		signals["S3"] = &gctrpc.ListOfSignals{Signals: pivots.Support3}
 	default:
 		return nil, fmt.Errorf("%w '%s'", errInvalidStrategy, r.AlgorithmType)
 	}
@@ -5564,3 +5843,998 @@ func (s *RPCServer) GetOrderbookAmountByImpact(ctx context.Context, r *gctrpc.Ge
 		AverageOrderCost:                    impact.AverageOrderCost,
 	}, nil
 }
+
+// GetOrderbookDesyncs returns orderbook integrity failure counts for each
+// exchange asset pair, optionally filtered by exchange
+func (s *RPCServer) GetOrderbookDesyncs(_ context.Context, r *gctrpc.GetOrderbookDesyncsRequest) (*gctrpc.GetOrderbookDesyncsResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetOrderbookDesyncsRequest", common.ErrNilPointer)
+	}
+	if !s.currencyPairSyncer.IsRunning() {
+		return nil, fmt.Errorf("%s %w", SyncManagerName, ErrSubSystemNotStarted)
+	}
+	if r.Exchange != "" {
+		if _, err := s.GetExchangeByName(r.Exchange); err != nil {
+			return nil, err
+		}
+	}
+	desyncs, err := s.currencyPairSyncer.GetOrderbookDesyncs(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetOrderbookDesyncsResponse{
+		Desyncs: make([]*gctrpc.OrderbookDesync, len(desyncs)),
+	}
+	for i := range desyncs {
+		resp.Desyncs[i] = &gctrpc.OrderbookDesync{
+			Exchange: desyncs[i].Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: desyncs[i].Pair.Delimiter,
+				Base:      desyncs[i].Pair.Base.String(),
+				Quote:     desyncs[i].Pair.Quote.String(),
+			},
+			Asset:      desyncs[i].Asset.String(),
+			Desyncs:    desyncs[i].Desyncs,
+			Recovered:  desyncs[i].Recovered,
+			Resyncs:    desyncs[i].Resyncs,
+			LastDesync: desyncs[i].LastDesync.Format(common.SimpleTimeFormatWithTimezone),
+			LastReason: desyncs[i].LastReason,
+		}
+	}
+	return resp, nil
+}
+
+// GetConsolidatedOrderbook returns an orderbook merged across exchanges for the
+// requested pair, quote currencies are normalised and optionally fee adjusted
+func (s *RPCServer) GetConsolidatedOrderbook(ctx context.Context, r *gctrpc.GetConsolidatedOrderbookRequest) (*gctrpc.ConsolidatedOrderbookResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetConsolidatedOrderbookRequest", common.ErrNilPointer)
+	}
+	agg, err := s.getConsolidatedAggregator(ctx, r.Pair, r.Asset, r.Exchanges, r.FeeAdjusted)
+	if err != nil {
+		return nil, err
+	}
+	book, err := agg.Consolidate()
+	if err != nil {
+		return nil, err
+	}
+	return consolidatedBookToRPC(book, int(r.Depth)), nil
+}
+
+// GetConsolidatedOrderbookStream streams the consolidated orderbook each time
+// the orderbook of a contributing exchange changes
+func (s *RPCServer) GetConsolidatedOrderbookStream(r *gctrpc.GetConsolidatedOrderbookRequest, stream gctrpc.GoCryptoTraderService_GetConsolidatedOrderbookStreamServer) error {
+	if r == nil {
+		return fmt.Errorf("%w GetConsolidatedOrderbookRequest", common.ErrNilPointer)
+	}
+	agg, err := s.getConsolidatedAggregator(stream.Context(), r.Pair, r.Asset, r.Exchanges, r.FeeAdjusted)
+	if err != nil {
+		return err
+	}
+	updates, err := agg.Updates(stream.Context())
+	if err != nil {
+		return err
+	}
+	for {
+		book, err := agg.Consolidate()
+		if err != nil {
+			return err
+		}
+		err = stream.Send(consolidatedBookToRPC(book, int(r.Depth)))
+		if err != nil {
+			return err
+		}
+		if _, ok := <-updates; !ok {
+			return stream.Context().Err()
+		}
+	}
+}
+
+// GetConsolidatedOrderbookMovement derives slippage information for an amount
+// deployed across the consolidated orderbook
+func (s *RPCServer) GetConsolidatedOrderbookMovement(ctx context.Context, r *gctrpc.GetConsolidatedOrderbookMovementRequest) (*gctrpc.GetOrderbookMovementResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetConsolidatedOrderbookMovementRequest", common.ErrNilPointer)
+	}
+	agg, err := s.getConsolidatedAggregator(ctx, r.Pair, r.Asset, r.Exchanges, r.FeeAdjusted)
+	if err != nil {
+		return nil, err
+	}
+	book, err := agg.Consolidate()
+	if err != nil {
+		return nil, err
+	}
+	depth, err := book.Depth()
+	if err != nil {
+		return nil, err
+	}
+
+	var move *orderbook.Movement
+	var bought, sold, side string
+	if r.Sell {
+		move, err = depth.HitTheBidsFromBest(r.Amount, r.Purchase)
+		bought = book.Quote.Upper().String()
+		sold = book.Base.Upper().String()
+		side = order.Bid.String()
+	} else {
+		move, err = depth.LiftTheAsksFromBest(r.Amount, r.Purchase)
+		bought = book.Base.Upper().String()
+		sold = book.Quote.Upper().String()
+		side = order.Ask.String()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.GetOrderbookMovementResponse{
+		NominalPercentage:         move.NominalPercentage,
+		ImpactPercentage:          move.ImpactPercentage,
+		SlippageCost:              move.SlippageCost,
+		CurrencyBought:            bought,
+		Bought:                    move.Purchased,
+		CurrencySold:              sold,
+		Sold:                      move.Sold,
+		SideAffected:              side,
+		UpdateProtocol:            "CONSOLIDATED",
+		FullOrderbookSideConsumed: move.FullBookSideConsumed,
+		NoSlippageOccurred:        move.ImpactPercentage == 0,
+		StartPrice:                move.StartPrice,
+		EndPrice:                  move.EndPrice,
+		AverageOrderCost:          move.AverageOrderCost,
+	}, nil
+}
+
+// getConsolidatedAggregator builds a consolidated orderbook aggregator from
+// every enabled pair which shares the requested base currency and has a quote
+// currency convertible to the requested quote currency. When no exchanges are
+// supplied all enabled exchanges are used.
+func (s *RPCServer) getConsolidatedAggregator(ctx context.Context, cp *gctrpc.CurrencyPair, assetType string, exchanges []string, feeAdjusted bool) (*consolidated.Aggregator, error) {
+	if cp == nil || cp.Base == "" || cp.Quote == "" {
+		return nil, errCurrencyPairUnset
+	}
+	a, err := asset.New(assetType)
+	if err != nil {
+		return nil, err
+	}
+	base, quote := currency.NewCode(cp.Base), currency.NewCode(cp.Quote)
+
+	var exchs []exchange.IBotExchange
+	if len(exchanges) == 0 {
+		exchs, err = s.ExchangeManager.GetExchanges()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for x := range exchanges {
+			var exch exchange.IBotExchange
+			exch, err = s.GetExchangeByName(exchanges[x])
+			if err != nil {
+				return nil, err
+			}
+			exchs = append(exchs, exch)
+		}
+	}
+
+	var venues []consolidated.Venue
+	for x := range exchs {
+		if !exchs[x].IsEnabled() || !exchs[x].GetAssetTypes(true).Contains(a) {
+			continue
+		}
+		pairs, err := exchs[x].GetEnabledPairs(a)
+		if err != nil {
+			return nil, err
+		}
+		for y := range pairs {
+			if !pairs[y].Base.Equal(base) {
+				continue
+			}
+			if _, err = consolidated.DefaultConverter(pairs[y].Quote, quote); err != nil {
+				continue
+			}
+			venue := consolidated.Venue{
+				Exchange: exchs[x].GetName(),
+				Pair:     pairs[y],
+				Asset:    a,
+			}
+			if feeAdjusted {
+				// The offline fee for a single unit priced at one is the taker
+				// fee rate
+				venue.Fee, err = exchs[x].GetFeeByType(ctx, &exchange.FeeBuilder{
+					FeeType:       exchange.OfflineTradeFee,
+					Pair:          pairs[y],
+					PurchasePrice: 1,
+					Amount:        1,
+				})
+				if err != nil {
+					log.Warnf(log.GRPCSys, "Consolidated orderbook cannot fee adjust %s %s %s: %v", venue.Exchange, venue.Pair, a, err)
+					venue.Fee = 0
+				}
+			}
+			venues = append(venues, venue)
+		}
+	}
+	if len(venues) == 0 {
+		return nil, fmt.Errorf("%w %s-%s %s", errNoConsolidatedVenues, base, quote, a)
+	}
+	return consolidated.New(&consolidated.Config{
+		Base:        base,
+		Quote:       quote,
+		Venues:      venues,
+		FeeAdjusted: feeAdjusted,
+	})
+}
+
+// consolidatedBookToRPC converts a consolidated book to its RPC response
+// limited to the supplied depth, a depth of zero returns all levels
+func consolidatedBookToRPC(book *consolidated.Book, depth int) *gctrpc.ConsolidatedOrderbookResponse {
+	resp := &gctrpc.ConsolidatedOrderbookResponse{
+		Pair: &gctrpc.CurrencyPair{
+			Base:  book.Base.String(),
+			Quote: book.Quote.String(),
+		},
+		FeeAdjusted: book.FeeAdjusted,
+		LastUpdated: book.LastUpdated.Format(common.SimpleTimeFormatWithTimezone),
+		Venues:      make([]*gctrpc.ConsolidatedOrderbookVenue, len(book.Venues)),
+	}
+	levelToRPC := func(l *consolidated.Level) *gctrpc.ConsolidatedOrderbookLevel {
+		return &gctrpc.ConsolidatedOrderbookLevel{
+			Exchange:   l.Exchange,
+			Pair:       l.Pair.String(),
+			Price:      l.Price,
+			VenuePrice: l.VenuePrice,
+			Amount:     l.Amount,
+		}
+	}
+	if bid, err := book.BestBid(); err == nil {
+		resp.BestBid = levelToRPC(&bid)
+	}
+	if ask, err := book.BestAsk(); err == nil {
+		resp.BestAsk = levelToRPC(&ask)
+	}
+	var err error
+	if resp.MidPrice, err = book.GetMidPrice(); err != nil {
+		resp.Error = err.Error()
+	}
+	resp.Spread, _ = book.GetSpreadAmount()
+	resp.TotalBidAmount, _ = book.TotalBidAmounts()
+	resp.TotalAskAmount, _ = book.TotalAskAmounts()
+	bids, asks := book.GetLevels(depth)
+	resp.Bids = make([]*gctrpc.ConsolidatedOrderbookLevel, len(bids))
+	for i := range bids {
+		resp.Bids[i] = levelToRPC(&bids[i])
+	}
+	resp.Asks = make([]*gctrpc.ConsolidatedOrderbookLevel, len(asks))
+	for i := range asks {
+		resp.Asks[i] = levelToRPC(&asks[i])
+	}
+	for i := range book.Venues {
+		v := &book.Venues[i]
+		resp.Venues[i] = &gctrpc.ConsolidatedOrderbookVenue{
+			Exchange: v.Exchange,
+			Pair:     v.Pair.String(),
+			Asset:    v.Asset.String(),
+			Rate:     v.Rate,
+			Fee:      v.Fee,
+			Bids:     int64(v.Bids),
+			Asks:     int64(v.Asks),
+		}
+		if !v.LastUpdated.IsZero() {
+			resp.Venues[i].LastUpdated = v.LastUpdated.Format(common.SimpleTimeFormatWithTimezone)
+		}
+		if v.Error != nil {
+			resp.Venues[i].Error = v.Error.Error()
+		}
+	}
+	return resp
+}
+
+// GetArbitrageOpportunities returns the opportunities found by the most recent
+// arbitrage scan, optionally filtered by exchange and type
+func (s *RPCServer) GetArbitrageOpportunities(_ context.Context, r *gctrpc.GetArbitrageOpportunitiesRequest) (*gctrpc.GetArbitrageOpportunitiesResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetArbitrageOpportunitiesRequest", common.ErrNilPointer)
+	}
+	opportunities, err := s.arbitrageManager.GetOpportunities()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetArbitrageOpportunitiesResponse{}
+	for x := range opportunities {
+		if !arbitrageOpportunityMatches(&opportunities[x], r) {
+			continue
+		}
+		resp.Opportunities = append(resp.Opportunities, arbitrageOpportunityToRPC(&opportunities[x]))
+	}
+	return resp, nil
+}
+
+// GetArbitrageOpportunityStream streams arbitrage opportunities as they are
+// found, optionally filtered by exchange and type
+func (s *RPCServer) GetArbitrageOpportunityStream(r *gctrpc.GetArbitrageOpportunitiesRequest, stream gctrpc.GoCryptoTraderService_GetArbitrageOpportunityStreamServer) error {
+	if r == nil {
+		return fmt.Errorf("%w GetArbitrageOpportunitiesRequest", common.ErrNilPointer)
+	}
+	pipe, err := s.arbitrageManager.Subscribe()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		pipeErr := pipe.Release()
+		if pipeErr != nil {
+			log.Error(log.DispatchMgr, pipeErr)
+		}
+	}()
+
+	for {
+		var data interface{}
+		var ok bool
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case data, ok = <-pipe.C:
+			if !ok {
+				return errDispatchSystem
+			}
+		}
+		o, ok := data.(ArbitrageOpportunity)
+		if !ok {
+			return common.GetAssertError("ArbitrageOpportunity", data)
+		}
+		if !arbitrageOpportunityMatches(&o, r) {
+			continue
+		}
+		err = stream.Send(arbitrageOpportunityToRPC(&o))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// arbitrageOpportunityMatches returns whether an opportunity satisfies the
+// request filters
+func arbitrageOpportunityMatches(o *ArbitrageOpportunity, r *gctrpc.GetArbitrageOpportunitiesRequest) bool {
+	if r.Type != "" && !strings.EqualFold(string(o.Type), r.Type) {
+		return false
+	}
+	return r.Exchange == "" || o.Involves(r.Exchange, currency.EMPTYPAIR)
+}
+
+// arbitrageOpportunityToRPC converts an arbitrage opportunity to its RPC
+// representation
+func arbitrageOpportunityToRPC(o *ArbitrageOpportunity) *gctrpc.ArbitrageOpportunity {
+	resp := &gctrpc.ArbitrageOpportunity{
+		Type:                string(o.Type),
+		Legs:                make([]*gctrpc.ArbitrageLeg, len(o.Legs)),
+		Currency:            o.Currency.String(),
+		StartAmount:         o.StartAmount,
+		EndAmount:           o.EndAmount,
+		WithdrawalFee:       o.WithdrawalFee,
+		NetProfit:           o.NetProfit,
+		NetProfitPercentage: o.NetProfitPercentage,
+		Detected:            o.Detected.Format(common.SimpleTimeFormatWithTimezone),
+		Executed:            o.Executed,
+		ExecutionError:      o.ExecutionError,
+	}
+	for x := range o.Legs {
+		resp.Legs[x] = &gctrpc.ArbitrageLeg{
+			Exchange: o.Legs[x].Exchange,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: o.Legs[x].Pair.Delimiter,
+				Base:      o.Legs[x].Pair.Base.String(),
+				Quote:     o.Legs[x].Pair.Quote.String(),
+			},
+			Asset:        o.Legs[x].Asset.String(),
+			Side:         o.Legs[x].Side.String(),
+			Amount:       o.Legs[x].Amount,
+			QuoteAmount:  o.Legs[x].QuoteAmount,
+			AveragePrice: o.Legs[x].AveragePrice,
+			FeeRate:      o.Legs[x].FeeRate,
+		}
+	}
+	return resp
+}
+
+// GetRebalancePlans returns the plans retained by the rebalance manager,
+// optionally filtered by plan ID
+func (s *RPCServer) GetRebalancePlans(_ context.Context, r *gctrpc.GetRebalancePlansRequest) (*gctrpc.GetRebalancePlansResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetRebalancePlansRequest", common.ErrNilPointer)
+	}
+	if r.Id != "" {
+		id, err := uuid.FromString(r.Id)
+		if err != nil {
+			return nil, err
+		}
+		plan, err := s.rebalanceManager.GetPlan(id)
+		if err != nil {
+			return nil, err
+		}
+		return &gctrpc.GetRebalancePlansResponse{
+			Plans: []*gctrpc.RebalancePlan{rebalancePlanToRPC(plan)},
+		}, nil
+	}
+	plans, err := s.rebalanceManager.GetPlans()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetRebalancePlansResponse{
+		Plans: make([]*gctrpc.RebalancePlan, len(plans)),
+	}
+	for x := range plans {
+		resp.Plans[x] = rebalancePlanToRPC(&plans[x])
+	}
+	return resp, nil
+}
+
+// ProposeRebalance evaluates holdings against the target allocations
+// immediately and returns the resulting plan
+func (s *RPCServer) ProposeRebalance(ctx context.Context, r *gctrpc.ProposeRebalanceRequest) (*gctrpc.RebalancePlan, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w ProposeRebalanceRequest", common.ErrNilPointer)
+	}
+	plan, err := s.rebalanceManager.Propose(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rebalancePlanToRPC(plan), nil
+}
+
+// ApproveRebalancePlan executes a rebalance plan which is pending approval
+func (s *RPCServer) ApproveRebalancePlan(ctx context.Context, r *gctrpc.RebalancePlanRequest) (*gctrpc.RebalancePlan, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w RebalancePlanRequest", common.ErrNilPointer)
+	}
+	id, err := uuid.FromString(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.rebalanceManager.Approve(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return rebalancePlanToRPC(plan), nil
+}
+
+// RejectRebalancePlan discards a rebalance plan which is pending approval
+func (s *RPCServer) RejectRebalancePlan(_ context.Context, r *gctrpc.RebalancePlanRequest) (*gctrpc.GenericResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w RebalancePlanRequest", common.ErrNilPointer)
+	}
+	id, err := uuid.FromString(r.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.rebalanceManager.Reject(id)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{
+		Status: MsgStatusSuccess,
+		Data:   fmt.Sprintf("rebalance plan %s rejected", id),
+	}, nil
+}
+
+// rebalancePlanToRPC converts a rebalance plan to its RPC representation
+func rebalancePlanToRPC(p *RebalancePlan) *gctrpc.RebalancePlan {
+	resp := &gctrpc.RebalancePlan{
+		Id:            p.ID.String(),
+		Created:       p.Created.Format(common.SimpleTimeFormatWithTimezone),
+		Updated:       p.Updated.Format(common.SimpleTimeFormatWithTimezone),
+		Status:        string(p.Status),
+		QuoteCurrency: p.QuoteCurrency.String(),
+		TotalValue:    p.TotalValue,
+		MaxDrift:      p.MaxDrift,
+		Allocations:   make([]*gctrpc.RebalanceAllocation, len(p.Allocations)),
+		Actions:       make([]*gctrpc.RebalanceAction, len(p.Actions)),
+		Error:         p.Error,
+	}
+	for x := range p.Allocations {
+		resp.Allocations[x] = &gctrpc.RebalanceAllocation{
+			Exchange:      p.Allocations[x].Exchange,
+			Currency:      p.Allocations[x].Currency.String(),
+			Balance:       p.Allocations[x].Balance,
+			Price:         p.Allocations[x].Price,
+			Value:         p.Allocations[x].Value,
+			TargetValue:   p.Allocations[x].TargetValue,
+			CurrentWeight: p.Allocations[x].CurrentWeight,
+			TargetWeight:  p.Allocations[x].TargetWeight,
+			Drift:         p.Allocations[x].Drift,
+		}
+	}
+	for x := range p.Actions {
+		a := &p.Actions[x]
+		resp.Actions[x] = &gctrpc.RebalanceAction{
+			Type:        string(a.Type),
+			Exchange:    a.Exchange,
+			Destination: a.Destination,
+			Address:     a.Address,
+			AddressTag:  a.AddressTag,
+			Chain:       a.Chain,
+			Currency:    a.Currency.String(),
+			Amount:      a.Amount,
+			Value:       a.Value,
+			Manual:      a.Manual,
+			Executed:    a.Executed,
+			Id:          a.ID,
+			Error:       a.Error,
+		}
+		if a.Type != RebalanceTransfer {
+			resp.Actions[x].Pair = &gctrpc.CurrencyPair{
+				Delimiter: a.Pair.Delimiter,
+				Base:      a.Pair.Base.String(),
+				Quote:     a.Pair.Quote.String(),
+			}
+			resp.Actions[x].Side = a.Side.String()
+		}
+	}
+	return resp
+}
+
+// GetPortfolioHistory returns the value of holdings at each saved portfolio
+// snapshot
+func (s *RPCServer) GetPortfolioHistory(_ context.Context, r *gctrpc.GetPortfolioHistoryRequest) (*gctrpc.GetPortfolioHistoryResponse, error) {
+	start, end, err := parsePortfolioHistoryRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.portfolioHistoryManager.GetValueHistory(start, end, r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPortfolioHistoryResponse{
+		Values: make([]*gctrpc.PortfolioValue, len(values)),
+	}
+	for x := range values {
+		resp.ValueCurrency = values[x].ValueCurrency.String()
+		resp.Values[x] = &gctrpc.PortfolioValue{
+			Timestamp: values[x].Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+			Value:     values[x].Value,
+		}
+	}
+	return resp, nil
+}
+
+// GetPortfolioPerformance returns the realised and unrealised PnL of each
+// asset held between saved portfolio snapshots, separated from deposits and
+// withdrawals
+func (s *RPCServer) GetPortfolioPerformance(_ context.Context, r *gctrpc.GetPortfolioHistoryRequest) (*gctrpc.GetPortfolioPerformanceResponse, error) {
+	start, end, err := parsePortfolioHistoryRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	perf, err := s.portfolioHistoryManager.GetPerformance(start, end, r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPortfolioPerformanceResponse{
+		Exchange:       perf.Exchange,
+		ValueCurrency:  perf.ValueCurrency.String(),
+		Start:          perf.Start.Format(common.SimpleTimeFormatWithTimezone),
+		End:            perf.End.Format(common.SimpleTimeFormatWithTimezone),
+		StartValue:     perf.StartValue,
+		EndValue:       perf.EndValue,
+		Deposits:       perf.Deposits,
+		Withdrawals:    perf.Withdrawals,
+		NetPerformance: perf.NetPerformance,
+		RealisedPnl:    perf.RealisedPNL,
+		UnrealisedPnl:  perf.UnrealisedPNL,
+		Assets:         make([]*gctrpc.PortfolioAssetPerformance, len(perf.Assets)),
+	}
+	for x := range perf.Assets {
+		a := &perf.Assets[x]
+		resp.Assets[x] = &gctrpc.PortfolioAssetPerformance{
+			Currency:      a.Currency.String(),
+			StartAmount:   a.StartAmount,
+			EndAmount:     a.EndAmount,
+			StartValue:    a.StartValue,
+			EndValue:      a.EndValue,
+			Deposits:      a.Deposits,
+			Withdrawals:   a.Withdrawals,
+			Bought:        a.Bought,
+			Sold:          a.Sold,
+			AverageCost:   a.AverageCost,
+			RealisedPnl:   a.RealisedPNL,
+			UnrealisedPnl: a.UnrealisedPNL,
+		}
+	}
+	return resp, nil
+}
+
+// GetPortfolioFlows returns the recorded exchange deposits and withdrawals
+func (s *RPCServer) GetPortfolioFlows(_ context.Context, r *gctrpc.GetPortfolioHistoryRequest) (*gctrpc.GetPortfolioFlowsResponse, error) {
+	start, end, err := parsePortfolioHistoryRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	flows, err := s.portfolioHistoryManager.GetFlows(start, end, r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPortfolioFlowsResponse{
+		Flows: make([]*gctrpc.PortfolioFlow, len(flows)),
+	}
+	for x := range flows {
+		resp.Flows[x] = &gctrpc.PortfolioFlow{
+			Exchange:      flows[x].Exchange,
+			TransferId:    flows[x].TransferID,
+			Type:          flows[x].Type,
+			Currency:      flows[x].Currency,
+			Amount:        flows[x].Amount,
+			Fee:           flows[x].Fee,
+			Price:         flows[x].Price,
+			Value:         flows[x].Value,
+			ValueCurrency: flows[x].ValueCurrency,
+			Timestamp:     flows[x].Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+		}
+	}
+	return resp, nil
+}
+
+// GetCapitalGainsReport returns the realised capital gains of a calendar year,
+// along with the report encoded in CSV or JSON when a format is requested
+func (s *RPCServer) GetCapitalGainsReport(_ context.Context, r *gctrpc.GetCapitalGainsReportRequest) (*gctrpc.GetCapitalGainsReportResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetCapitalGainsReportRequest", common.ErrNilPointer)
+	}
+	report, err := s.taxLotManager.GenerateReport(int(r.Year), r.Method)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetCapitalGainsReportResponse{
+		Year:           int64(report.Year),
+		Method:         string(report.Method),
+		ReportCurrency: report.ReportCurrency.String(),
+		Proceeds:       report.Proceeds,
+		CostBasis:      report.CostBasis,
+		Gain:           report.Gain,
+		ShortTermGain:  report.ShortTermGain,
+		LongTermGain:   report.LongTermGain,
+		Currencies:     make([]*gctrpc.CapitalGainsCurrencySummary, len(report.Currencies)),
+		Gains:          make([]*gctrpc.CapitalGain, len(report.Gains)),
+		Warnings:       report.Warnings,
+	}
+	for x := range report.Currencies {
+		c := &report.Currencies[x]
+		resp.Currencies[x] = &gctrpc.CapitalGainsCurrencySummary{
+			Currency:      c.Currency.String(),
+			Amount:        c.Amount,
+			Proceeds:      c.Proceeds,
+			CostBasis:     c.CostBasis,
+			Gain:          c.Gain,
+			ShortTermGain: c.ShortTermGain,
+			LongTermGain:  c.LongTermGain,
+		}
+	}
+	for x := range report.Gains {
+		g := &report.Gains[x]
+		resp.Gains[x] = &gctrpc.CapitalGain{
+			Exchange:   g.Exchange,
+			Currency:   g.Currency.String(),
+			Amount:     g.Amount,
+			Acquired:   g.Acquired.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			Disposed:   g.Disposed.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			Proceeds:   g.Proceeds,
+			CostBasis:  g.CostBasis,
+			Gain:       g.Gain,
+			Term:       string(g.Term),
+			LotId:      g.LotID,
+			DisposalId: g.DisposalID,
+		}
+	}
+	if r.Format != "" {
+		var buf bytes.Buffer
+		if err := report.Write(&buf, r.Format); err != nil {
+			return nil, err
+		}
+		resp.Data = buf.String()
+	}
+	return resp, nil
+}
+
+// GetTaxLots returns the lots currently held, limited to an exchange when set
+func (s *RPCServer) GetTaxLots(_ context.Context, r *gctrpc.GetTaxLotsRequest) (*gctrpc.GetTaxLotsResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetTaxLotsRequest", common.ErrNilPointer)
+	}
+	lots, err := s.taxLotManager.GetLots(r.Exchange, r.Method)
+	if err != nil {
+		return nil, err
+	}
+	method, err := s.taxLotManager.getMethod(r.Method)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetTaxLotsResponse{
+		Method:         string(method),
+		ReportCurrency: s.taxLotManager.reportCurrency.String(),
+		Lots:           make([]*gctrpc.TaxLot, len(lots)),
+	}
+	for x := range lots {
+		resp.Lots[x] = &gctrpc.TaxLot{
+			Id:             lots[x].ID,
+			Exchange:       lots[x].Exchange,
+			Currency:       lots[x].Currency.String(),
+			Acquired:       lots[x].Acquired.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			OriginalAmount: lots[x].OriginalAmount,
+			Amount:         lots[x].Amount,
+			UnitCost:       lots[x].UnitCost,
+			InTransit:      lots[x].InTransit,
+		}
+	}
+	return resp, nil
+}
+
+// ReconcileOrders compares the orders, fills and balances tracked by the engine
+// against exchange history and returns any mismatches found. The start and end
+// are optional and default to the configured window ending now.
+func (s *RPCServer) ReconcileOrders(ctx context.Context, r *gctrpc.ReconcileOrdersRequest) (*gctrpc.ReconcileOrdersResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w ReconcileOrdersRequest", common.ErrNilPointer)
+	}
+	var start, end time.Time
+	var err error
+	if r.Start != "" {
+		start, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+		if err != nil {
+			return nil, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+		}
+	}
+	if r.End != "" {
+		end, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+		if err != nil {
+			return nil, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+		}
+	}
+	report, err := s.reconciliationManager.Reconcile(ctx, r.Exchange, start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.ReconcileOrdersResponse{
+		Start:         report.Start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		End:           report.End.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		Exchanges:     report.Exchanges,
+		OrdersChecked: int64(report.OrdersChecked),
+		Mismatches:    make([]*gctrpc.ReconciliationMismatch, len(report.Mismatches)),
+		Errors:        report.Errors,
+	}
+	for x := range report.Mismatches {
+		mm := &report.Mismatches[x]
+		resp.Mismatches[x] = &gctrpc.ReconciliationMismatch{
+			Type:     string(mm.Type),
+			Exchange: mm.Exchange,
+			Account:  mm.Account,
+			Asset:    mm.Asset.String(),
+			OrderId:  mm.OrderID,
+			Expected: mm.Expected,
+			Actual:   mm.Actual,
+			Details:  mm.Details,
+		}
+		if !mm.Pair.IsEmpty() {
+			resp.Mismatches[x].Pair = mm.Pair.String()
+		}
+		if !mm.Currency.IsEmpty() {
+			resp.Mismatches[x].Currency = mm.Currency.String()
+		}
+	}
+	return resp, nil
+}
+
+// parsePortfolioHistoryRequest returns the start and end dates of a portfolio
+// history request
+func parsePortfolioHistoryRequest(r *gctrpc.GetPortfolioHistoryRequest) (start, end time.Time, err error) {
+	if r == nil {
+		return start, end, fmt.Errorf("%w GetPortfolioHistoryRequest", common.ErrNilPointer)
+	}
+	start, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+	if err != nil {
+		return start, end, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+	}
+	end, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+	if err != nil {
+		return start, end, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+	}
+	return start, end, nil
+}
+
+// GetRiskLimits returns the pre-trade risk limits applied by the order manager
+// and whether the kill switch is engaged
+func (s *RPCServer) GetRiskLimits(_ context.Context, r *gctrpc.GetRiskLimitsRequest) (*gctrpc.GetRiskLimitsResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetRiskLimitsRequest", common.ErrNilPointer)
+	}
+	limits, killSwitch, err := s.OrderManager.GetRiskLimits()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetRiskLimitsResponse{
+		Enabled:        limits.Enabled,
+		KillSwitch:     killSwitch,
+		DefaultLimit:   riskLimitToRPC(&config.ExchangeRiskLimit{RiskLimit: limits.Default}),
+		ExchangeLimits: make([]*gctrpc.RiskLimit, len(limits.Exchanges)),
+	}
+	for i := range limits.Exchanges {
+		resp.ExchangeLimits[i] = riskLimitToRPC(&limits.Exchanges[i])
+	}
+	return resp, nil
+}
+
+// SetRiskLimit sets the pre-trade risk limit of an exchange or sub-account,
+// or the default risk limit when no exchange is provided
+func (s *RPCServer) SetRiskLimit(ctx context.Context, r *gctrpc.SetRiskLimitRequest) (*gctrpc.GenericResponse, error) {
+	if r == nil || r.Limit == nil {
+		return nil, fmt.Errorf("%w SetRiskLimitRequest", common.ErrNilPointer)
+	}
+	if r.Limit.Exchange != "" {
+		exch, err := s.GetExchangeByName(r.Limit.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = deployExchangeAccount(ctx, exch, r.Limit.Account); err != nil {
+			return nil, err
+		}
+	}
+	err := s.OrderManager.SetRiskLimit(r.Limit.Exchange, r.Limit.Account, r.Limit.SubAccount, config.RiskLimit{
+		MaxOrderNotional:    r.Limit.MaxOrderNotional,
+		NotionalCurrency:    r.Limit.NotionalCurrency,
+		MaxPositionPerPair:  r.Limit.MaxPositionPerPair,
+		MaxOpenOrders:       r.Limit.MaxOpenOrders,
+		MaxDailyLoss:        r.Limit.MaxDailyLoss,
+		PriceBandPercentage: r.Limit.PriceBandPercentage,
+		FatFingerPercentage: r.Limit.FatFingerPercentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	name := r.Limit.Exchange
+	if name == "" {
+		name = "default"
+	}
+	return &gctrpc.GenericResponse{
+		Status: MsgStatusSuccess,
+		Data:   fmt.Sprintf("%s risk limit set", name),
+	}, nil
+}
+
+// SetKillSwitch engages or releases the kill switch, cancelling all orders when
+// engaged
+func (s *RPCServer) SetKillSwitch(ctx context.Context, r *gctrpc.SetKillSwitchRequest) (*gctrpc.SetKillSwitchResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w SetKillSwitchRequest", common.ErrNilPointer)
+	}
+	cancelled, err := s.OrderManager.SetKillSwitch(ctx, r.Engage)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.SetKillSwitchResponse{
+		Engaged:         r.Engage,
+		OrdersCancelled: cancelled,
+	}, nil
+}
+
+// riskLimitToRPC converts a risk limit to its RPC representation
+func riskLimitToRPC(l *config.ExchangeRiskLimit) *gctrpc.RiskLimit {
+	return &gctrpc.RiskLimit{
+		Exchange:            l.Exchange,
+		Account:             l.Account,
+		SubAccount:          l.SubAccount,
+		MaxOrderNotional:    l.MaxOrderNotional,
+		NotionalCurrency:    l.NotionalCurrency,
+		MaxPositionPerPair:  l.MaxPositionPerPair,
+		MaxOpenOrders:       l.MaxOpenOrders,
+		MaxDailyLoss:        l.MaxDailyLoss,
+		PriceBandPercentage: l.PriceBandPercentage,
+		FatFingerPercentage: l.FatFingerPercentage,
+	}
+}
+
+// DeadManHeartbeat records a heartbeat from an external controller, keeping
+// the dead man's switch from cancelling all orders
+func (s *RPCServer) DeadManHeartbeat(_ context.Context, r *gctrpc.DeadManHeartbeatRequest) (*gctrpc.DeadManHeartbeatResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w DeadManHeartbeatRequest", common.ErrNilPointer)
+	}
+	timeout, err := s.deadManSwitch.Heartbeat()
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.DeadManHeartbeatResponse{
+		Timeout:          timeout.String(),
+		NextHeartbeatDue: time.Now().Add(timeout).UTC().Format(common.SimpleTimeFormatWithTimezone),
+	}, nil
+}
+
+// InternalTransfer moves funds between the asset wallets and sub-accounts of
+// an exchange
+func (s *RPCServer) InternalTransfer(ctx context.Context, r *gctrpc.InternalTransferRequest) (*gctrpc.InternalTransferResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w InternalTransferRequest", common.ErrNilPointer)
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	from, err := asset.New(r.FromAsset)
+	if err != nil {
+		return nil, err
+	}
+	to, err := asset.New(r.ToAsset)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = deployExchangeAccount(ctx, exch, r.Account)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.WithdrawManager.SubmitTransfer(ctx, &withdraw.TransferRequest{
+		Exchange:       exch.GetName(),
+		Currency:       currency.NewCode(strings.ToUpper(r.Currency)),
+		Amount:         r.Amount,
+		From:           from,
+		To:             to,
+		FromSubAccount: r.FromSubAccount,
+		ToSubAccount:   r.ToSubAccount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.InternalTransferResponse{
+		Id:     resp.ID,
+		Status: resp.Status,
+	}, nil
+}
+
+// GetExchangeHealth returns the REST circuit breaker state and health score
+// of each endpoint of an exchange, or of all loaded exchanges if unset
+func (s *RPCServer) GetExchangeHealth(_ context.Context, r *gctrpc.GetExchangeHealthRequest) (*gctrpc.GetExchangeHealthResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetExchangeHealthRequest", common.ErrNilPointer)
+	}
+	var exchs []exchange.IBotExchange
+	if r.Exchange == "" {
+		var err error
+		exchs, err = s.ExchangeManager.GetExchanges()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		exch, err := s.GetExchangeByName(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		exchs = append(exchs, exch)
+	}
+
+	resp := &gctrpc.GetExchangeHealthResponse{
+		Exchanges: make([]*gctrpc.ExchangeHealth, 0, len(exchs)),
+	}
+	for x := range exchs {
+		b := exchs[x].GetBase()
+		if b == nil {
+			continue
+		}
+		health := b.Requester.EndpointHealth()
+		h := &gctrpc.ExchangeHealth{
+			Exchange:  exchs[x].GetName(),
+			State:     b.Requester.CircuitState().String(),
+			Score:     b.Requester.HealthScore(),
+			Endpoints: make([]*gctrpc.EndpointHealth, len(health)),
+		}
+		for y := range health {
+			h.Endpoints[y] = &gctrpc.EndpointHealth{
+				Endpoint:            health[y].Endpoint,
+				State:               health[y].State.String(),
+				Score:               health[y].Score,
+				ConsecutiveFailures: int64(health[y].ConsecutiveFailures),
+				Requests:            health[y].Requests,
+				Failures:            health[y].Failures,
+				LastError:           health[y].LastError,
+			}
+			if !health[y].OpenedAt.IsZero() {
+				h.Endpoints[y].OpenedAt = health[y].OpenedAt.UTC().Format(common.SimpleTimeFormatWithTimezone)
+			}
+		}
+		resp.Exchanges = append(resp.Exchanges, h)
+	}
+	return resp, nil
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -23,6 +25,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/repository"
 	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
@@ -33,12 +36,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 	"github.com/thrasher-corp/goose"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -1182,6 +1188,18 @@ func TestGetAccountInfo(t *testing.T) {
 	if !errors.Is(err, nil) {
 		t.Errorf("received '%v', expected '%v'", err, nil)
 	}
+	_, err = s.GetAccountInfo(context.Background(), &gctrpc.GetAccountInfoRequest{Exchange: fakeExchangeName, AssetType: asset.Spot.String(), Account: "alpha"})
+	if !errors.Is(err, exchange.ErrAccountNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, exchange.ErrAccountNotFound)
+	}
+	err = b.SetAccountCredentials("alpha", &account.Credentials{Key: "key", Secret: "secret"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = s.GetAccountInfo(context.Background(), &gctrpc.GetAccountInfoRequest{Exchange: fakeExchangeName, AssetType: asset.Spot.String(), Account: "alpha"})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
 }
 
 func TestUpdateAccountInfo(t *testing.T) {
@@ -1960,6 +1978,48 @@ func TestGetManagedOrders(t *testing.T) {
 	} else if oo == nil || len(oo.GetOrders()) != 1 {
 		t.Errorf("unexpected order result: %v", oo)
 	}
+
+	// Account selectors are matched case insensitively
+	err = om.Add(&order.Detail{
+		Price:     100000,
+		Amount:    0.002,
+		Exchange:  "Binance",
+		OrderID:   "strategy",
+		Account:   "strategy",
+		Type:      order.Limit,
+		Side:      order.Sell,
+		Status:    order.New,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USDT),
+	})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+	}
+	oo, err = s.GetManagedOrders(context.Background(), &gctrpc.GetOrdersRequest{
+		Exchange:  exchName,
+		AssetType: "spot",
+		Pair:      p,
+		Account:   " Strategy ",
+	})
+	if err != nil {
+		t.Errorf("non expected Error: %v", err)
+	} else if len(oo.GetOrders()) != 1 || oo.GetOrders()[0].Account != "strategy" {
+		t.Errorf("unexpected order result: %v", oo)
+	}
+}
+
+func TestWithdrawalCredentials(t *testing.T) {
+	t.Parallel()
+	exchCfg := &config.Exchange{API: config.APIConfig{
+		Credentials: config.APICredentialsConfig{PIN: "1"},
+		Accounts:    []config.APIAccountConfig{{Name: "strategy", Credentials: config.APICredentialsConfig{PIN: "2"}}},
+	}}
+	if creds := withdrawalCredentials(exchCfg, ""); creds.PIN != "1" {
+		t.Errorf("received '%v', expected '%v'", creds.PIN, "1")
+	}
+	if creds := withdrawalCredentials(exchCfg, "strategy"); creds.PIN != "2" {
+		t.Errorf("received '%v', expected '%v'", creds.PIN, "2")
+	}
 }
 
 func TestRPCServer_unixTimestamp(t *testing.T) {
@@ -2728,6 +2788,37 @@ func TestGetTechnicalAnalysis(t *testing.T) {
 	if len(resp.Signals["RSI"].Signals) != 33 {
 		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Signals["RSI"].Signals), 33)
 	}
+
+	for _, tc := range []struct {
+		request *gctrpc.GetTechnicalAnalysisRequest
+		signal  string
+	}{
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "stoch", Period: 9, SmoothPeriod: 3, SignalPeriod: 3}, "D"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "stochrsi", Period: 9, SmoothPeriod: 3, SignalPeriod: 3}, "K"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "adx", Period: 9}, "ADX"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "ichimoku", FastPeriod: 3, Period: 9, SlowPeriod: 18, Displacement: 9}, "LEADING_SPAN_B"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "keltner", Period: 9, Multiplier: 2}, "UPPER"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "donchian", Period: 9}, "MIDDLE"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "supertrend", Period: 9, Multiplier: 3}, "DIRECTION"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "vwapbands", Multiplier: 2}, "VWAP"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "cci", Period: 9}, "CCI"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "willr", Period: 9}, "WILLR"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "sar", AccelerationStep: 0.02, AccelerationMaximum: 0.2}, "SAR"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "heikinashi"}, "CLOSE"},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "pivot", PivotMethod: "Camarilla"}, "S3"},
+	} {
+		tc.request.Exchange = fakeExchangeName
+		tc.request.AssetType = "spot"
+		tc.request.Pair = &gctrpc.CurrencyPair{Base: "btc", Quote: "usd"}
+		tc.request.Interval = int64(kline.OneDay)
+		resp, err = s.GetTechnicalAnalysis(context.Background(), tc.request)
+		if !errors.Is(err, nil) {
+			t.Fatalf("%s received: '%v' but expected: '%v'", tc.request.AlgorithmType, err, nil)
+		}
+		if len(resp.Signals[tc.signal].Signals) != 33 {
+			t.Fatalf("%s received: '%v' but expected: '%v'", tc.request.AlgorithmType, len(resp.Signals[tc.signal].Signals), 33)
+		}
+	}
 }
 
 func TestGetMarginRatesHistory(t *testing.T) {
@@ -3513,3 +3604,707 @@ func TestGetOrderbookAmountByImpact(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", impact.AmountRequired, 1)
 	}
 }
+
+func TestGetOrderbookDesyncs(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{ExchangeManager: SetupExchangeManager()}}
+	_, err := s.GetOrderbookDesyncs(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetOrderbookDesyncs(context.Background(), &gctrpc.GetOrderbookDesyncsRequest{})
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+
+	s.currencyPairSyncer, err = setupSyncManager(&SyncManagerConfig{SynchronizeOrderbook: true, FiatDisplayCurrency: currency.USD, PairFormatDisplay: &currency.EMPTYFORMAT}, s.ExchangeManager, &config.RemoteControlConfig{}, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s.currencyPairSyncer.started = 1
+	s.currencyPairSyncer.desyncs[desyncKey{Exchange: "bitstamp", Asset: asset.Spot}] = &OrderbookDesync{
+		Exchange:   "Bitstamp",
+		Pair:       currency.NewPair(currency.BTC, currency.USD),
+		Asset:      asset.Spot,
+		Desyncs:    2,
+		Resyncs:    1,
+		LastReason: "checksum mismatch",
+	}
+
+	_, err = s.GetOrderbookDesyncs(context.Background(), &gctrpc.GetOrderbookDesyncsRequest{Exchange: "meow"})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+	resp, err := s.GetOrderbookDesyncs(context.Background(), &gctrpc.GetOrderbookDesyncsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Desyncs) != 1 || resp.Desyncs[0].Desyncs != 2 || resp.Desyncs[0].Resyncs != 1 {
+		t.Fatalf("unexpected response %+v", resp.Desyncs)
+	}
+}
+
+func TestGetConsolidatedOrderbook(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName("ftx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Name = "ConsolidatedFake"
+	b.Enabled = true
+
+	usd := currency.NewPair(currency.BTC, currency.USD)
+	usdt := currency.NewPair(currency.BTC, currency.USDT)
+	b.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	b.CurrencyPairs.Pairs[asset.Spot] = &currency.PairStore{
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+		RequestFormat: &currency.PairFormat{Delimiter: "/"},
+		Available:     currency.Pairs{usd, usdt, currency.NewPair(currency.ETH, currency.USD)},
+		Enabled:       currency.Pairs{usd, usdt, currency.NewPair(currency.ETH, currency.USD)},
+	}
+	em.Add(fExchange{IBotExchange: exch})
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetConsolidatedOrderbook(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	req := &gctrpc.GetConsolidatedOrderbookRequest{}
+	_, err = s.GetConsolidatedOrderbook(context.Background(), req)
+	if !errors.Is(err, errCurrencyPairUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCurrencyPairUnset)
+	}
+	req.Pair = &gctrpc.CurrencyPair{Base: "BTC", Quote: "USD"}
+	_, err = s.GetConsolidatedOrderbook(context.Background(), req)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	req.Asset = asset.Spot.String()
+	req.Pair.Base = "LTC"
+	_, err = s.GetConsolidatedOrderbook(context.Background(), req)
+	if !errors.Is(err, errNoConsolidatedVenues) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoConsolidatedVenues)
+	}
+
+	for _, book := range []*orderbook.Base{
+		{Pair: usd, Bids: orderbook.Items{{Price: 100, Amount: 1}}, Asks: orderbook.Items{{Price: 103, Amount: 1}}},
+		{Pair: usdt, Bids: orderbook.Items{{Price: 101, Amount: 1}}, Asks: orderbook.Items{{Price: 102, Amount: 1}}},
+	} {
+		book.Exchange = b.Name
+		book.Asset = asset.Spot
+		if err = book.Process(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req.Pair.Base = "BTC"
+	req.Exchanges = []string{b.Name}
+	resp, err := s.GetConsolidatedOrderbook(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Venues) != 2 || len(resp.Bids) != 2 || len(resp.Asks) != 2 {
+		t.Fatalf("unexpected consolidated book %+v", resp)
+	}
+	bestBidPair, err := currency.NewPairFromString(resp.BestBid.Pair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.BestBid.Price != 101 || !bestBidPair.Equal(usdt) {
+		t.Fatalf("unexpected best bid %+v", resp.BestBid)
+	}
+	if resp.BestAsk.Price != 102 || resp.MidPrice != 101.5 {
+		t.Fatalf("unexpected best ask %+v mid %v", resp.BestAsk, resp.MidPrice)
+	}
+	req.Depth = 1
+	resp, err = s.GetConsolidatedOrderbook(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Bids) != 1 || len(resp.Asks) != 1 {
+		t.Fatalf("unexpected depth bids %d asks %d", len(resp.Bids), len(resp.Asks))
+	}
+
+	move, err := s.GetConsolidatedOrderbookMovement(context.Background(), &gctrpc.GetConsolidatedOrderbookMovementRequest{
+		Pair:     req.Pair,
+		Asset:    req.Asset,
+		Amount:   2,
+		Purchase: true,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if move.Bought != 2 || move.Sold != 205 || move.CurrencyBought != "BTC" {
+		t.Fatalf("unexpected movement %+v", move)
+	}
+}
+
+func TestGetArbitrageOpportunities(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetArbitrageOpportunities(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, err := SetupArbitrageManager(&config.Arbitrage{
+		Triangular: true,
+		Capital:    map[string]float64{"USDT": 1000},
+	}, SetupExchangeManager(), nil, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.mu.Lock()
+	m.opportunities = []ArbitrageOpportunity{
+		{
+			Type:     TriangularArbitrage,
+			Currency: currency.USDT,
+			Legs:     []ArbitrageLeg{{Exchange: testExchange, Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Buy}},
+		},
+		{
+			Type:     CrossExchangeArbitrage,
+			Currency: currency.USDT,
+			Legs:     []ArbitrageLeg{{Exchange: "Binance", Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Buy}},
+		},
+	}
+	m.mu.Unlock()
+	s.arbitrageManager = m
+
+	resp, err := s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Opportunities) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Opportunities), 2)
+	}
+	resp, err = s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{Exchange: "binance"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Opportunities) != 1 || resp.Opportunities[0].Type != string(CrossExchangeArbitrage) {
+		t.Fatalf("unexpected opportunities %v", resp.Opportunities)
+	}
+	resp, err = s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{Type: "triangular"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Opportunities) != 1 || resp.Opportunities[0].Legs[0].Exchange != testExchange {
+		t.Fatalf("unexpected opportunities %v", resp.Opportunities)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestRebalancePlanRPCs(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetRebalancePlans(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetRebalancePlans(context.Background(), &gctrpc.GetRebalancePlansRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = s.ApproveRebalancePlan(context.Background(), &gctrpc.RebalancePlanRequest{Id: "invalid"})
+	if err == nil {
+		t.Fatal("expected error parsing invalid plan ID")
+	}
+
+	m, err := SetupRebalanceManager(testRebalancerConfig(), SetupExchangeManager(), &fakeRebalancePortfolio{}, &fakeArbitrageSubmitter{}, &fakeWithdrawSubmitter{}, fakeDepositAddresses{}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	id, err := uuid.NewV4()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.mu.Lock()
+	m.plans = []*RebalancePlan{{
+		ID:            id,
+		Status:        RebalanceProposed,
+		QuoteCurrency: currency.USDT,
+		Allocations:   []RebalanceAllocation{{Exchange: "RebalanceA", Currency: currency.BTC}},
+		Actions: []RebalanceAction{
+			{Type: RebalanceSell, Exchange: "RebalanceA", Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Sell, Currency: currency.BTC},
+			{Type: RebalanceTransfer, Exchange: "RebalanceA", Destination: "RebalanceB", Currency: currency.USDT},
+		},
+	}}
+	m.mu.Unlock()
+	s.rebalanceManager = m
+
+	resp, err := s.GetRebalancePlans(context.Background(), &gctrpc.GetRebalancePlansRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Plans) != 1 || len(resp.Plans[0].Actions) != 2 {
+		t.Fatalf("unexpected plans %v", resp.Plans)
+	}
+	if resp.Plans[0].Actions[0].Pair == nil || resp.Plans[0].Actions[1].Pair != nil {
+		t.Fatalf("unexpected actions %v", resp.Plans[0].Actions)
+	}
+	resp, err = s.GetRebalancePlans(context.Background(), &gctrpc.GetRebalancePlansRequest{Id: id.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Plans) != 1 || resp.Plans[0].Id != id.String() {
+		t.Fatalf("unexpected plans %v", resp.Plans)
+	}
+
+	_, err = s.RejectRebalancePlan(context.Background(), &gctrpc.RebalancePlanRequest{Id: id.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.ApproveRebalancePlan(context.Background(), &gctrpc.RebalancePlanRequest{Id: id.String()})
+	if !errors.Is(err, errRebalancePlanNotPending) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRebalancePlanNotPending)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestPortfolioHistoryRPCs(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetPortfolioHistory(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetPortfolioPerformance(context.Background(), &gctrpc.GetPortfolioHistoryRequest{Start: "bad"})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &gctrpc.GetPortfolioHistoryRequest{
+		Start: start.Format(common.SimpleTimeFormatWithTimezone),
+		End:   start.Add(time.Hour).Format(common.SimpleTimeFormatWithTimezone),
+	}
+	_, err = s.GetPortfolioFlows(context.Background(), req)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, db := setupPortfolioHistoryManager(t, &fPortfolioHistoryExchange{}, nil)
+	s.portfolioHistoryManager = m
+	for x := 0; x < 2; x++ {
+		db.snapshots = append(db.snapshots, portfoliohistory.Snapshot{
+			Timestamp:     start.Add(time.Hour * time.Duration(x)),
+			ValueCurrency: "USD",
+			Value:         float64(10000 * (x + 1)),
+			Holdings: []portfoliohistory.Holding{
+				{Exchange: "one", Currency: "BTC", Amount: 1, Price: float64(10000 * (x + 1)), Value: float64(10000 * (x + 1))},
+			},
+		})
+	}
+	db.flows = []portfoliohistory.Flow{
+		{Exchange: "one", TransferID: "1", Type: portfoliohistory.Deposit, Currency: "BTC", Amount: 1, Timestamp: start.Add(time.Minute)},
+	}
+
+	history, err := s.GetPortfolioHistory(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if history.ValueCurrency != "USD" || len(history.Values) != 2 || history.Values[1].Value != 20000 {
+		t.Fatalf("unexpected history %v", history)
+	}
+	perf, err := s.GetPortfolioPerformance(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(perf.Assets) != 1 || perf.Deposits != 20000 || perf.NetPerformance != -10000 {
+		t.Fatalf("unexpected performance %v", perf)
+	}
+	flows, err := s.GetPortfolioFlows(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(flows.Flows) != 1 || flows.Flows[0].TransferId != "1" {
+		t.Fatalf("unexpected flows %v", flows)
+	}
+}
+
+func TestTaxLotRPCs(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetCapitalGainsReport(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetTaxLots(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetTaxLots(context.Background(), &gctrpc.GetTaxLotsRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	bought := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	m := setupTaxLotManager(t, "TaxLotRPC", &fTaxLotExchange{
+		orders: []order.Detail{
+			{OrderID: "1", Side: order.Buy, Status: order.Filled, AssetType: asset.Spot, Pair: btcusd, ExecutedAmount: 2, Price: 10000, Date: bought},
+			{OrderID: "2", Side: order.Sell, Status: order.Filled, AssetType: asset.Spot, Pair: btcusd, ExecutedAmount: 1, Price: 15000, Date: bought.AddDate(0, 6, 0)},
+		},
+	}, nil)
+	m.importHistory(context.Background())
+	s.taxLotManager = m
+
+	_, err = s.GetCapitalGainsReport(context.Background(), &gctrpc.GetCapitalGainsReportRequest{Year: 2021, Format: "xml"})
+	if err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	report, err := s.GetCapitalGainsReport(context.Background(), &gctrpc.GetCapitalGainsReportRequest{Year: 2021, Format: "csv"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if report.Method != "FIFO" || report.Gain != 5000 || report.ShortTermGain != 5000 ||
+		len(report.Gains) != 1 || len(report.Currencies) != 1 || !strings.Contains(report.Data, "disposal_id") {
+		t.Fatalf("unexpected report %v", report)
+	}
+	lots, err := s.GetTaxLots(context.Background(), &gctrpc.GetTaxLotsRequest{Method: "hifo"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if lots.Method != "HIFO" || lots.ReportCurrency != "USD" || len(lots.Lots) != 1 || lots.Lots[0].Amount != 1 {
+		t.Fatalf("unexpected lots %v", lots)
+	}
+}
+
+func TestReconcileOrdersRPC(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.ReconcileOrders(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.ReconcileOrders(context.Background(), &gctrpc.ReconcileOrdersRequest{Start: "bad"})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	_, err = s.ReconcileOrders(context.Background(), &gctrpc.ReconcileOrdersRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	exch := &fReconcileExchange{
+		active: []order.Detail{{OrderID: "orphan", Pair: btcusd, AssetType: asset.Spot, Side: order.Buy, Status: order.Active, Amount: 1}},
+	}
+	s.reconciliationManager = setupReconciliationManager(t, "ReconcileRPC", exch, &fTaxLotOrders{}, nil)
+	resp, err := s.ReconcileOrders(context.Background(), &gctrpc.ReconcileOrdersRequest{Exchange: "ReconcileRPC"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Mismatches) != 1 || resp.Mismatches[0].Type != string(OrphanOrder) || resp.Mismatches[0].Pair != "BTCUSD" {
+		t.Fatalf("unexpected mismatches %v", resp.Mismatches)
+	}
+}
+
+func TestRiskLimitRPCs(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetRiskLimits(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetRiskLimits(context.Background(), &gctrpc.GetRiskLimitsRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = s.SetRiskLimit(context.Background(), &gctrpc.SetRiskLimitRequest{})
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.SetKillSwitch(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+
+	m, _ := setupRiskOrderManager(t, "RiskRPC", "")
+	em, ok := m.orderStore.exchangeManager.(*ExchangeManager)
+	if !ok {
+		t.Fatal("unexpected exchange manager type")
+	}
+	s.OrderManager = m
+	s.ExchangeManager = em
+	_, err = s.SetRiskLimit(context.Background(), &gctrpc.SetRiskLimitRequest{Limit: &gctrpc.RiskLimit{Exchange: "bogus"}})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+	_, err = s.SetRiskLimit(context.Background(), &gctrpc.SetRiskLimitRequest{Limit: &gctrpc.RiskLimit{MaxOrderNotional: -1}})
+	if !errors.Is(err, errInvalidRiskLimit) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidRiskLimit)
+	}
+	_, err = s.SetRiskLimit(context.Background(), &gctrpc.SetRiskLimitRequest{Limit: &gctrpc.RiskLimit{Exchange: "RiskRPC", SubAccount: "sub", MaxOpenOrders: 5}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	resp, err := s.SetKillSwitch(context.Background(), &gctrpc.SetKillSwitchRequest{Engage: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !resp.Engaged || resp.OrdersCancelled == 0 {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	limits, err := s.GetRiskLimits(context.Background(), &gctrpc.GetRiskLimitsRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !limits.Enabled || !limits.KillSwitch || limits.DefaultLimit == nil ||
+		len(limits.ExchangeLimits) != 1 || limits.ExchangeLimits[0].SubAccount != "sub" ||
+		limits.ExchangeLimits[0].MaxOpenOrders != 5 {
+		t.Fatalf("unexpected limits %v", limits)
+	}
+}
+
+func TestDeadManHeartbeatRPC(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.DeadManHeartbeat(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.DeadManHeartbeat(context.Background(), &gctrpc.DeadManHeartbeatRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, _ := setupDeadManSwitch(t, "DeadManRPC", &config.DeadManSwitch{
+		CheckInterval:    time.Hour,
+		HeartbeatTimeout: time.Minute,
+	}, nil, &fDeadManOrders{}, nil, nil)
+	s.deadManSwitch = m
+	_, err = s.DeadManHeartbeat(context.Background(), &gctrpc.DeadManHeartbeatRequest{})
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	resp, err := s.DeadManHeartbeat(context.Background(), &gctrpc.DeadManHeartbeatRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Timeout != time.Minute.String() || resp.NextHeartbeatDue == "" {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestInternalTransfer(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	b, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	exch := fTransferExchange{IBotExchange: b, requests: new([]withdraw.TransferRequest)}
+	em.Add(exch)
+	wm, err := SetupWithdrawManager(em, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, WithdrawManager: wm}}
+	_, err = s.InternalTransfer(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	req := &gctrpc.InternalTransferRequest{
+		Exchange:  testExchange,
+		Currency:  "usdt",
+		Amount:    1,
+		FromAsset: "spot",
+		ToAsset:   "bogus",
+	}
+	_, err = s.InternalTransfer(context.Background(), req)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	req.ToAsset = "margin"
+	req.Account = "bogus"
+	_, err = s.InternalTransfer(context.Background(), req)
+	if !errors.Is(err, exchange.ErrAccountNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, exchange.ErrAccountNotFound)
+	}
+	req.Account = ""
+	resp, err := s.InternalTransfer(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Id != "1337" {
+		t.Fatalf("received: '%v' but expected: '%v'", resp.Id, "1337")
+	}
+	if reqs := *exch.requests; len(reqs) != 1 || !reqs[0].Currency.Equal(currency.USDT) || reqs[0].To != asset.Margin {
+		t.Fatalf("unexpected transfer requests %+v", reqs)
+	}
+}
+
+// TestTraceRequest does not run in parallel as the tracer is package global
+func TestTraceRequest(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer collector.Close()
+	err := tracing.Start(collector.URL, "test", time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	defer func() {
+		if err = tracing.Stop(); !errors.Is(err, nil) {
+			t.Errorf("received '%v', expected '%v'", err, nil)
+		}
+	}()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTraderService/SubmitOrder"}
+	errTest := errors.New("test")
+	var traceparent string
+	resp, err := traceRequest(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		_, span := tracing.StartSpan(ctx, "test")
+		traceparent = span.TraceParent()
+		span.End()
+		return "response", errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("received '%v', expected '%v'", err, errTest)
+	}
+	if resp != "response" {
+		t.Errorf("received '%v', expected '%v'", resp, "response")
+	}
+	if !strings.HasPrefix(traceparent, "00-"+traceID+"-") {
+		t.Errorf("received '%v', expected the caller's trace to be continued", traceparent)
+	}
+}
+
+func TestGetExchangeHealth(t *testing.T) {
+	t.Parallel()
+	em := SetupExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	em.Add(exch)
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err = s.GetExchangeHealth(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = s.GetExchangeHealth(context.Background(), &gctrpc.GetExchangeHealthRequest{Exchange: "bogus"})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+	requester := exch.GetBase().Requester
+	err = requester.SetCircuitBreaker(request.BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_ = requester.SendPayload(context.Background(), request.Unset, func() (*request.Item, error) {
+		return &request.Item{Method: http.MethodGet, Path: srv.URL}, nil
+	})
+
+	resp, err := s.GetExchangeHealth(context.Background(), &gctrpc.GetExchangeHealthRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Exchanges) != 1 || len(resp.Exchanges[0].Endpoints) != 1 {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	h := resp.Exchanges[0]
+	if h.State != request.BreakerOpen.String() || h.Score != 0 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", h.State, h.Score, request.BreakerOpen, 0)
+	}
+	if e := h.Endpoints[0]; e.Failures != 1 || e.OpenedAt == "" || e.LastError == "" {
+		t.Errorf("unexpected endpoint health: %v", e)
+	}
+}
+
+func TestGetBuiltCandles(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetBuiltCandles(context.Background(), nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	pair := &gctrpc.CurrencyPair{Delimiter: "-", Base: "BTC", Quote: "USDT"}
+	_, err = s.GetBuiltCandles(context.Background(), &gctrpc.GetBuiltCandlesRequest{Exchange: testExchange, Pair: pair, AssetType: "spot"})
+	if !errors.Is(err, errInvalidArguments) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidArguments)
+	}
+	req := &gctrpc.GetBuiltCandlesRequest{
+		Exchange:     testExchange,
+		Pair:         pair,
+		AssetType:    "spot",
+		TimeInterval: int64(kline.OneMin),
+	}
+	_, err = s.GetBuiltCandles(context.Background(), req)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+
+	s.CandleBuilder, err = SetupCandleBuilderManager(&config.CandleBuilder{Intervals: []time.Duration{time.Minute}}, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s.CandleBuilder.started = 1
+	tn := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	err = s.CandleBuilder.ProcessTrades(testExchange, []trade.Data{
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 100, Amount: 1, Timestamp: tn},
+		{CurrencyPair: p, AssetType: asset.Spot, Price: 101, Amount: 2, Timestamp: tn.Add(time.Minute)},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	req.TimeInterval = int64(kline.FiveMin)
+	_, err = s.GetBuiltCandles(context.Background(), req)
+	if !errors.Is(err, errCandleIntervalNotBuilt) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleIntervalNotBuilt)
+	}
+	req.TimeInterval = int64(kline.OneMin)
+	resp, err := s.GetBuiltCandles(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Candle) != 1 || resp.Candle[0].Open != 100 || resp.Interval != kline.OneMin.String() {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	req.IncludeCurrent = true
+	resp, err = s.GetBuiltCandles(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Candle) != 2 || resp.Candle[1].Volume != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
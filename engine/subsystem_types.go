@@ -60,6 +60,17 @@ type iOrderManager interface {
 	UpdateExistingOrder(*order.Detail) error
 }
 
+// iOrderSubmitter limits exposure of the order manager to order submission
+type iOrderSubmitter interface {
+	Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error)
+}
+
+// iOrderSnapshotProvider limits exposure of the order manager to retrieving
+// the orders it tracks
+type iOrderSnapshotProvider interface {
+	GetOrdersSnapshot(order.Status) []order.Detail
+}
+
 // iPortfolioManager limits exposure of accessible functions to portfolio manager
 type iPortfolioManager interface {
 	GetPortfolioSummary() portfolio.Summary
@@ -67,6 +78,12 @@ type iPortfolioManager interface {
 	IsExchangeSupported(string, string) bool
 }
 
+// iPortfolioAddressProvider limits the portfolio manager functionality used
+// to retrieve wallet and cold storage holdings
+type iPortfolioAddressProvider interface {
+	GetAddresses() []portfolio.Address
+}
+
 // iBot limits exposure of accessible functions to engine bot
 type iBot interface {
 	SetupExchanges() error
@@ -78,6 +95,7 @@ type iCurrencyPairSyncer interface {
 	PrintTickerSummary(*ticker.Price, string, error)
 	PrintOrderbookSummary(*orderbook.Base, string, error)
 	Update(string, currency.Pair, asset.Item, int, error) error
+	OrderbookDesync(string, currency.Pair, asset.Item, error) error
 }
 
 // iDatabaseConnectionManager defines a limited scoped databaseConnectionManager
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -40,6 +41,12 @@ var (
 	errUnknownSyncItem            = errors.New("unknown sync item")
 	errSyncPairNotFound           = errors.New("exchange currency pair syncer not found")
 	errCouldNotSyncNewData        = errors.New("could not sync new data")
+	errNoResyncMethod             = errors.New("no websocket subscription or REST support to resync orderbook")
+
+	// DefaultOrderbookResyncDelay is the time an exchange is given to recover
+	// an invalidated orderbook before the syncer resubscribes or fetches a
+	// REST snapshot
+	DefaultOrderbookResyncDelay = time.Second * 5
 )
 
 // setupSyncManager starts a new CurrencyPairSyncer
@@ -90,6 +97,8 @@ func setupSyncManager(c *SyncManagerConfig, exchangeManager iExchangeManager, re
 		fiatDisplayCurrency:            c.FiatDisplayCurrency,
 		format:                         *c.PairFormatDisplay,
 		tickerBatchLastRequested:       make(map[string]time.Time),
+		desyncs:                        make(map[desyncKey]*OrderbookDesync),
+		resyncDelay:                    DefaultOrderbookResyncDelay,
 	}
 
 	log.Debugf(log.SyncMgr,
@@ -465,6 +474,196 @@ func (m *syncManager) Update(exchangeName string, p currency.Pair, a asset.Item,
 	return fmt.Errorf("%w for %s %s %s", errCouldNotSyncNewData, exchangeName, p, a)
 }
 
+// OrderbookDesync records an orderbook integrity failure. If the book has not
+// been recovered by the exchange after the resync delay, its websocket
+// subscriptions are renewed, falling back to a REST snapshot.
+func (m *syncManager) OrderbookDesync(exchangeName string, p currency.Pair, a asset.Item, reason error) error {
+	if m == nil {
+		return fmt.Errorf("exchange CurrencyPairSyncer %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("exchange CurrencyPairSyncer %w", ErrSubSystemNotStarted)
+	}
+	key := desyncKey{
+		Exchange: strings.ToLower(exchangeName),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	d, ok := m.desyncs[key]
+	if !ok {
+		d = &OrderbookDesync{Exchange: exchangeName, Pair: p, Asset: a}
+		m.desyncs[key] = d
+	}
+	d.Desyncs++
+	d.LastDesync = time.Now()
+	if reason != nil {
+		d.LastReason = reason.Error()
+	}
+	log.Warnf(log.SyncMgr, "%s %s %s: orderbook desync: %v",
+		exchangeName,
+		m.FormatCurrency(p),
+		strings.ToUpper(a.String()),
+		reason)
+	if d.resyncPending {
+		return nil
+	}
+	d.resyncPending = true
+	go m.resyncOrderbook(key, exchangeName, p, a)
+	return nil
+}
+
+// resyncOrderbook waits for the resync delay and then requests a new snapshot
+// if the book is still invalid
+func (m *syncManager) resyncOrderbook(key desyncKey, exchangeName string, p currency.Pair, a asset.Item) {
+	time.Sleep(m.resyncDelay)
+	var recovered, resynced bool
+	defer func() {
+		m.mux.Lock()
+		d := m.desyncs[key]
+		d.resyncPending = false
+		if recovered {
+			d.Recovered++
+		}
+		if resynced {
+			d.Resyncs++
+		}
+		m.mux.Unlock()
+	}()
+	if atomic.LoadInt32(&m.started) == 0 {
+		return
+	}
+	depth, err := orderbook.GetDepth(exchangeName, p, a)
+	if err == nil && depth.IsValid() {
+		recovered = true
+		if m.config.Verbose {
+			log.Debugf(log.SyncMgr, "%s %s %s: orderbook recovered by exchange, resync not required",
+				exchangeName,
+				m.FormatCurrency(p),
+				strings.ToUpper(a.String()))
+		}
+		return
+	}
+	err = m.requestOrderbookResync(exchangeName, p, a)
+	if err != nil {
+		log.Errorf(log.SyncMgr, "%s %s %s: orderbook resync failed: %v",
+			exchangeName,
+			m.FormatCurrency(p),
+			strings.ToUpper(a.String()),
+			err)
+		return
+	}
+	resynced = true
+}
+
+// requestOrderbookResync resubscribes to all websocket channels for the pair so
+// that the exchange sends a fresh snapshot, otherwise a REST snapshot is
+// fetched
+func (m *syncManager) requestOrderbookResync(exchangeName string, p currency.Pair, a asset.Item) error {
+	exch, err := m.exchangeManager.GetExchangeByName(exchangeName)
+	if err != nil {
+		return err
+	}
+	if exch.SupportsWebsocket() && exch.IsWebsocketEnabled() {
+		ws, err := exch.GetWebsocket()
+		if err != nil {
+			return err
+		}
+		if ws.IsConnected() {
+			subs := ws.GetSubscriptions()
+			var errs common.Errors
+			var resubscribed bool
+			for i := range subs {
+				if !subs[i].Currency.Equal(p) ||
+					(subs[i].Asset != asset.Empty && subs[i].Asset != a) {
+					continue
+				}
+				if err = ws.ResubscribeToChannel(&subs[i]); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				resubscribed = true
+			}
+			if resubscribed {
+				log.Infof(log.SyncMgr, "%s %s %s: orderbook resynced via websocket resubscription",
+					exchangeName,
+					m.FormatCurrency(p),
+					strings.ToUpper(a.String()))
+				return nil
+			}
+			if len(errs) > 0 {
+				return errs
+			}
+		}
+	}
+	if !exch.SupportsREST() {
+		return errNoResyncMethod
+	}
+	result, err := exch.UpdateOrderbook(context.TODO(), p, a)
+	m.PrintOrderbookSummary(result, "REST", err)
+	return err
+}
+
+// GetOrderbookDesyncs returns orderbook integrity failure statistics. An empty
+// exchange name returns statistics for all exchanges.
+func (m *syncManager) GetOrderbookDesyncs(exchangeName string) ([]OrderbookDesync, error) {
+	if m == nil {
+		return nil, fmt.Errorf("exchange CurrencyPairSyncer %w", ErrNilSubsystem)
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	resp := make([]OrderbookDesync, 0, len(m.desyncs))
+	for _, d := range m.desyncs {
+		if exchangeName != "" && !strings.EqualFold(d.Exchange, exchangeName) {
+			continue
+		}
+		resp = append(resp, *d)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if resp[i].Asset != resp[j].Asset {
+			return resp[i].Asset < resp[j].Asset
+		}
+		return resp[i].Pair.String() < resp[j].Pair.String()
+	})
+	return resp, nil
+}
+
+// GetSyncStatuses returns when the enabled sync items of each exchange asset
+// pair were last updated
+func (m *syncManager) GetSyncStatuses() ([]PairSyncStatus, error) {
+	if m == nil {
+		return nil, fmt.Errorf("exchange CurrencyPairSyncer %w", ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("exchange CurrencyPairSyncer %w", ErrSubSystemNotStarted)
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	resp := make([]PairSyncStatus, len(m.currencyPairs))
+	for x := range m.currencyPairs {
+		resp[x] = PairSyncStatus{
+			Exchange: m.currencyPairs[x].Exchange,
+			Asset:    m.currencyPairs[x].AssetType,
+			Pair:     m.currencyPairs[x].Pair,
+		}
+		if m.config.SynchronizeTicker {
+			resp[x].Ticker = m.currencyPairs[x].Ticker.LastUpdated
+		}
+		if m.config.SynchronizeOrderbook {
+			resp[x].Orderbook = m.currencyPairs[x].Orderbook.LastUpdated
+		}
+		if m.config.SynchronizeTrades {
+			resp[x].Trade = m.currencyPairs[x].Trade.LastUpdated
+		}
+	}
+	return resp, nil
+}
+
 func (m *syncManager) worker() {
 	cleanup := func() {
 		log.Debugln(log.SyncMgr,
@@ -472,6 +671,9 @@ func (m *syncManager) worker() {
 	}
 	defer cleanup()
 
+	// paused tracks exchange asset types whose REST circuit breaker is open,
+	// polling is paused until the breaker allows a probe request
+	paused := make(map[string]bool)
 	for atomic.LoadInt32(&m.started) != 0 {
 		exchanges, err := m.exchangeManager.GetExchanges()
 		if err != nil {
@@ -505,6 +707,19 @@ func (m *syncManager) worker() {
 
 			assetTypes := exchanges[x].GetAssetTypes(true)
 			for y := range assetTypes {
+				pausedKey := exchangeName + " " + assetTypes[y].String()
+				if err = checkExchangeCircuit(exchanges[x], assetTypes[y]); err != nil {
+					if !paused[pausedKey] {
+						log.Warnf(log.SyncMgr, "%s %s pausing REST polling: %v", exchangeName, assetTypes[y], err)
+						paused[pausedKey] = true
+					}
+					time.Sleep(time.Millisecond * 50)
+					continue
+				}
+				if paused[pausedKey] {
+					log.Infof(log.SyncMgr, "%s %s resuming REST polling", exchangeName, assetTypes[y])
+					delete(paused, pausedKey)
+				}
 				wsAssetSupported := exchanges[x].IsAssetWebsocketSupported(assetTypes[y])
 				enabledPairs, err := exchanges[x].GetEnabledPairs(assetTypes[y])
 				if err != nil {
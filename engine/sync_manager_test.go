@@ -4,10 +4,12 @@ import (
 	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
@@ -321,3 +323,93 @@ func TestSyncManagerUpdate(t *testing.T) {
 		t.Fatalf("received %v, but expected: %v", err, nil)
 	}
 }
+
+func TestOrderbookDesync(t *testing.T) {
+	t.Parallel()
+	var m *syncManager
+	err := m.OrderbookDesync("", currency.EMPTYPAIR, asset.Spot, nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = m.GetOrderbookDesyncs("")
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m, err = setupSyncManager(&SyncManagerConfig{SynchronizeOrderbook: true, FiatDisplayCurrency: currency.USD, PairFormatDisplay: &currency.EMPTYFORMAT}, &ExchangeManager{}, &config.RemoteControlConfig{}, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.resyncDelay = 0
+	const exchName = "DesyncTest"
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	err = m.OrderbookDesync(exchName, p, asset.Spot, nil)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	atomic.StoreInt32(&m.started, 1)
+
+	book := &orderbook.Base{
+		Exchange: exchName,
+		Pair:     p,
+		Asset:    asset.Spot,
+		Bids:     orderbook.Items{{Price: 1, Amount: 1}},
+		Asks:     orderbook.Items{{Price: 2, Amount: 1}},
+	}
+	err = book.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	waitForResync := func() OrderbookDesync {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			desyncs, err := m.GetOrderbookDesyncs(exchName)
+			if !errors.Is(err, nil) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+			}
+			if len(desyncs) != 1 {
+				t.Fatalf("received: '%v' but expected: '%v'", len(desyncs), 1)
+			}
+			if !desyncs[0].resyncPending {
+				return desyncs[0]
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+		t.Fatal("resync did not complete")
+		return OrderbookDesync{}
+	}
+
+	// Book is valid by the time the resync is checked
+	err = m.OrderbookDesync(exchName, p, asset.Spot, errors.New("checksum mismatch"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	d := waitForResync()
+	if d.Desyncs != 1 || d.Recovered != 1 || d.Resyncs != 0 || d.LastReason != "checksum mismatch" {
+		t.Fatalf("unexpected desync stats %+v", d)
+	}
+
+	// Book remains invalid and the exchange cannot be found to resync
+	depth, err := orderbook.GetDepth(exchName, p, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_ = depth.Invalidate(errors.New("crossed"))
+	err = m.OrderbookDesync(exchName, p, asset.Spot, errors.New("crossed"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	d = waitForResync()
+	if d.Desyncs != 2 || d.Recovered != 1 || d.Resyncs != 0 {
+		t.Fatalf("unexpected desync stats %+v", d)
+	}
+
+	desyncs, err := m.GetOrderbookDesyncs("someOtherExchange")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(desyncs) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(desyncs), 0)
+	}
+}
@@ -30,6 +30,43 @@ type currencyPairSyncAgent struct {
 	Trade     syncBase
 }
 
+// PairSyncStatus holds when each sync item of an exchange asset pair was last
+// updated. A zero time indicates the item is not synced or has no data yet
+type PairSyncStatus struct {
+	Exchange  string
+	Asset     asset.Item
+	Pair      currency.Pair
+	Ticker    time.Time
+	Orderbook time.Time
+	Trade     time.Time
+}
+
+// desyncKey is used to look up orderbook desync statistics
+type desyncKey struct {
+	Exchange string
+	Base     *currency.Item
+	Quote    *currency.Item
+	Asset    asset.Item
+}
+
+// OrderbookDesync stores orderbook integrity failure statistics for an
+// exchange asset pair
+type OrderbookDesync struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	// Desyncs is the total amount of integrity failures
+	Desyncs int64
+	// Recovered is the amount of failures the exchange recovered from before
+	// a resync was required
+	Recovered int64
+	// Resyncs is the amount of resubscriptions or REST snapshots requested
+	Resyncs       int64
+	LastDesync    time.Time
+	LastReason    string
+	resyncPending bool
+}
+
 // SyncManagerConfig stores the currency pair synchronization manager config
 type SyncManagerConfig struct {
 	SynchronizeTicker       bool
@@ -59,6 +96,8 @@ type syncManager struct {
 
 	currencyPairs            []currencyPairSyncAgent
 	tickerBatchLastRequested map[string]time.Time
+	desyncs                  map[desyncKey]*OrderbookDesync
+	resyncDelay              time.Duration
 
 	remoteConfig    *config.RemoteControlConfig
 	config          SyncManagerConfig
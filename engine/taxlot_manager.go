@@ -0,0 +1,446 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/portfoliohistory"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/taxlot"
+)
+
+// SetupTaxLotManager creates a new tax lot manager from config. The order
+// manager is optional and provides fills of orders executed by the engine.
+func SetupTaxLotManager(cfg *config.TaxLots, exchangeManager iExchangeManager, orderManager iOrderSnapshotProvider, verbose bool) (*TaxLotManager, error) {
+	if cfg == nil {
+		return nil, errNilTaxLotsConfig
+	}
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	method := taxlot.FIFO
+	if cfg.Method != "" {
+		var err error
+		method, err = taxlot.ParseMethod(cfg.Method)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reportCurrency := cfg.ReportCurrency
+	if reportCurrency.IsEmpty() {
+		reportCurrency = currency.USD
+	}
+	if !reportCurrency.IsFiatCurrency() {
+		return nil, fmt.Errorf("%w received %s", errReportCurrencyNotFiat, reportCurrency)
+	}
+	tracker, err := taxlot.NewTracker(reportCurrency, &taxLotRates{
+		exchangeManager: exchangeManager,
+		reportCurrency:  reportCurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m := &TaxLotManager{
+		verbose:         verbose || cfg.Verbose,
+		interval:        cfg.ImportInterval,
+		method:          method,
+		reportCurrency:  reportCurrency,
+		importStart:     cfg.ImportStart,
+		exchanges:       cfg.Exchanges,
+		exchangeManager: exchangeManager,
+		orderManager:    orderManager,
+		tracker:         tracker,
+		importedUntil:   make(map[string]time.Time),
+		shutdown:        make(chan struct{}),
+	}
+	if m.interval <= 0 {
+		m.interval = DefaultTaxLotImportInterval
+	}
+	if m.importStart.IsZero() {
+		m.importStart = time.Date(time.Now().UTC().Year()-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *TaxLotManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *TaxLotManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.PortfolioMgr, "Tax lot manager %s", MsgSubSystemStarting)
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.PortfolioMgr, "Tax lot manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *TaxLotManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.PortfolioMgr, "Tax lot manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.PortfolioMgr, "Tax lot manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run imports history on start and at every interval
+func (m *TaxLotManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	for {
+		m.importHistory(context.TODO())
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// GenerateReport returns the realised gains of disposals within a calendar
+// year. The configured lot matching method is used when the method is empty.
+func (m *TaxLotManager) GenerateReport(year int, method string) (*taxlot.Report, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	if year <= 0 {
+		return nil, fmt.Errorf("%w %d", errInvalidTaxYear, year)
+	}
+	lotMethod, err := m.getMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	return m.tracker.GenerateReport(year, lotMethod)
+}
+
+// GetLots returns the lots currently held, limited to an exchange when set.
+// The configured lot matching method is used when the method is empty.
+func (m *TaxLotManager) GetLots(exchangeName, method string) ([]taxlot.Lot, error) {
+	if err := m.checkRunning(); err != nil {
+		return nil, err
+	}
+	lotMethod, err := m.getMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	lots, err := m.tracker.GetLots(lotMethod)
+	if err != nil {
+		return nil, err
+	}
+	if exchangeName == "" {
+		return lots, nil
+	}
+	resp := make([]taxlot.Lot, 0, len(lots))
+	for x := range lots {
+		if strings.EqualFold(lots[x].Exchange, exchangeName) {
+			resp = append(resp, lots[x])
+		}
+	}
+	return resp, nil
+}
+
+// checkRunning returns an error when the subsystem is nil or not running
+func (m *TaxLotManager) checkRunning() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("%s %w", TaxLotManagerName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// getMethod returns the lot matching method, defaulting to the configured
+// method
+func (m *TaxLotManager) getMethod(method string) (taxlot.Method, error) {
+	if method == "" {
+		return m.method, nil
+	}
+	return taxlot.ParseMethod(method)
+}
+
+// importHistory records the fills of orders tracked by the order manager and
+// imports order, deposit and withdrawal history from each exchange
+func (m *TaxLotManager) importHistory(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.orderManager != nil {
+		orders := m.orderManager.GetOrdersSnapshot(order.AnyStatus)
+		for x := range orders {
+			m.addFills(&orders[x])
+		}
+	}
+	exchs, err := getAccountExchanges(m.exchangeManager, m.exchanges)
+	if err != nil {
+		log.Errorf(log.PortfolioMgr, "Tax lot manager cannot retrieve exchanges: %v", err)
+		return
+	}
+	for x := range exchs {
+		m.importOrderHistory(ctx, exchs[x])
+		m.importTransfers(ctx, exchs[x])
+	}
+}
+
+// importOrderHistory records the fills of spot orders of the enabled pairs
+// of an exchange since the last import. Each import overlaps the previous by
+// an interval so orders filled after the previous import are updated.
+func (m *TaxLotManager) importOrderHistory(ctx context.Context, exch exchange.IBotExchange) {
+	pairs, err := exch.GetEnabledPairs(asset.Spot)
+	if err != nil || len(pairs) == 0 {
+		return
+	}
+	start := m.importStart
+	if until, ok := m.importedUntil[exch.GetName()]; ok && until.Add(-m.interval).After(start) {
+		start = until.Add(-m.interval)
+	}
+	end := time.Now()
+	orders, err := exch.GetOrderHistory(ctx, &order.GetOrdersRequest{
+		Type:      order.AnyType,
+		Side:      order.AnySide,
+		StartTime: start,
+		EndTime:   end,
+		Pairs:     pairs,
+		AssetType: asset.Spot,
+	})
+	if err != nil {
+		if m.verbose || !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+			log.Warnf(log.PortfolioMgr, "Tax lot manager cannot retrieve %s order history: %v", exch.GetName(), err)
+		}
+		return
+	}
+	for x := range orders {
+		if orders[x].Exchange == "" {
+			orders[x].Exchange = exch.GetName()
+		}
+		m.addFills(&orders[x])
+	}
+	m.importedUntil[exch.GetName()] = end
+	if m.verbose {
+		log.Debugf(log.PortfolioMgr, "Tax lot manager imported %d %s orders", len(orders), exch.GetName())
+	}
+}
+
+// importTransfers records the deposits and withdrawals of an exchange from its
+// funding history, and the withdrawal history of each currency held on the
+// exchange. Transfers which failed or were cancelled are ignored.
+func (m *TaxLotManager) importTransfers(ctx context.Context, exch exchange.IBotExchange) {
+	history, err := exch.GetFundingHistory(ctx)
+	if err != nil {
+		if m.verbose || !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+			log.Warnf(log.PortfolioMgr, "Tax lot manager cannot retrieve %s funding history: %v", exch.GetName(), err)
+		}
+	}
+	for x := range history {
+		direction := taxlot.TransferIn
+		switch fundingFlowType(&history[x]) {
+		case "":
+			continue
+		case portfoliohistory.Withdrawal:
+			direction = taxlot.TransferOut
+		}
+		m.addTransfer(&taxlot.Transfer{
+			Exchange:  exch.GetName(),
+			ID:        history[x].TransferID,
+			TxID:      history[x].CryptoTxID,
+			Direction: direction,
+			Currency:  currency.NewCode(history[x].Currency),
+			Amount:    history[x].Amount,
+			Fee:       history[x].Fee,
+			Timestamp: history[x].Timestamp,
+		})
+	}
+
+	lots, err := m.tracker.GetLots(m.method)
+	if err != nil {
+		log.Errorf(log.PortfolioMgr, "Tax lot manager cannot retrieve lots: %v", err)
+		return
+	}
+	var held currency.Currencies
+	for x := range lots {
+		if !lots[x].InTransit && strings.EqualFold(lots[x].Exchange, exch.GetName()) && !held.Contains(lots[x].Currency) {
+			held = append(held, lots[x].Currency)
+		}
+	}
+	for x := range held {
+		withdrawals, err := exch.GetWithdrawalsHistory(ctx, held[x], asset.Spot)
+		if err != nil {
+			if m.verbose || !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+				log.Warnf(log.PortfolioMgr, "Tax lot manager cannot retrieve %s %s withdrawal history: %v", exch.GetName(), held[x], err)
+			}
+			return
+		}
+		for y := range withdrawals {
+			if transferFailed(withdrawals[y].Status) {
+				continue
+			}
+			code := currency.NewCode(withdrawals[y].Currency)
+			if code.IsEmpty() {
+				code = held[x]
+			}
+			m.addTransfer(&taxlot.Transfer{
+				Exchange:  exch.GetName(),
+				ID:        withdrawals[y].TransferID,
+				TxID:      withdrawals[y].CryptoTxID,
+				Direction: taxlot.TransferOut,
+				Currency:  code,
+				Amount:    withdrawals[y].Amount,
+				Fee:       withdrawals[y].Fee,
+				Timestamp: withdrawals[y].Timestamp,
+			})
+		}
+	}
+}
+
+// addFills records the fills of an order
+func (m *TaxLotManager) addFills(d *order.Detail) {
+	fills := orderFills(d)
+	for x := range fills {
+		if err := m.tracker.AddFill(&fills[x]); err != nil && m.verbose {
+			log.Warnf(log.PortfolioMgr, "Tax lot manager cannot record fill: %v", err)
+		}
+	}
+}
+
+// addTransfer records a transfer, deriving an ID when the exchange provides
+// none
+func (m *TaxLotManager) addTransfer(t *taxlot.Transfer) {
+	if t.ID == "" && t.TxID == "" {
+		t.ID = fmt.Sprintf("%s-%s-%d-%v", t.Direction, t.Currency, t.Timestamp.UnixNano(), t.Amount)
+	}
+	if err := m.tracker.AddTransfer(t); err != nil && m.verbose {
+		log.Warnf(log.PortfolioMgr, "Tax lot manager cannot record transfer: %v", err)
+	}
+}
+
+// orderFills returns the fills of a spot order. Trades are used when provided,
+// otherwise the executed amount of the order is treated as a single fill at
+// the average executed price.
+func orderFills(d *order.Detail) []taxlot.Fill {
+	if d.AssetType != asset.Spot || d.Pair.IsEmpty() || d.Exchange == "" {
+		return nil
+	}
+	if len(d.Trades) > 0 {
+		resp := make([]taxlot.Fill, 0, len(d.Trades))
+		for x := range d.Trades {
+			t := &d.Trades[x]
+			side := t.Side
+			if side == order.UnknownSide || side == order.AnySide {
+				side = d.Side
+			}
+			buy, ok := isBuySide(side)
+			if !ok {
+				continue
+			}
+			id := t.TID
+			if id == "" {
+				id = fmt.Sprintf("%s-%d", d.OrderID, x)
+			}
+			timestamp := t.Timestamp
+			if timestamp.IsZero() {
+				timestamp = orderFillTime(d)
+			}
+			resp = append(resp, taxlot.Fill{
+				Exchange:  d.Exchange,
+				ID:        id,
+				OrderID:   d.OrderID,
+				Base:      d.Pair.Base,
+				Quote:     d.Pair.Quote,
+				Buy:       buy,
+				Amount:    t.Amount,
+				Price:     t.Price,
+				Fee:       t.Fee,
+				FeeAsset:  currency.NewCode(t.FeeAsset),
+				Timestamp: timestamp,
+			})
+		}
+		return resp
+	}
+	buy, ok := isBuySide(d.Side)
+	if !ok {
+		return nil
+	}
+	amount := d.ExecutedAmount
+	if amount <= 0 && d.Status == order.Filled {
+		amount = d.Amount
+	}
+	price := d.AverageExecutedPrice
+	if price <= 0 {
+		price = d.Price
+	}
+	if amount <= 0 || price <= 0 {
+		return nil
+	}
+	return []taxlot.Fill{{
+		Exchange:  d.Exchange,
+		ID:        d.OrderID,
+		OrderID:   d.OrderID,
+		Base:      d.Pair.Base,
+		Quote:     d.Pair.Quote,
+		Buy:       buy,
+		Amount:    amount,
+		Price:     price,
+		Fee:       d.Fee,
+		FeeAsset:  d.FeeAsset,
+		Timestamp: orderFillTime(d),
+	}}
+}
+
+// isBuySide returns whether an order side buys the base currency, and false
+// for the second value when the side is neither a buy nor a sell
+func isBuySide(s order.Side) (buy, ok bool) {
+	switch s {
+	case order.Buy, order.Bid:
+		return true, true
+	case order.Sell, order.Ask:
+		return false, true
+	}
+	return false, false
+}
+
+// orderFillTime returns the time an order was filled, falling back to when it
+// was placed
+func orderFillTime(d *order.Detail) time.Time {
+	if !d.CloseTime.IsZero() {
+		return d.CloseTime
+	}
+	if !d.Date.IsZero() {
+		return d.Date
+	}
+	return d.LastUpdated
+}
+
+// GetRate returns the current price of a currency in the report currency.
+// Historical prices are not available, so the current price is used for
+// currencies never traded against the report currency.
+func (r *taxLotRates) GetRate(c currency.Code, _ time.Time) (float64, error) {
+	return newPortfolioPricer(r.exchangeManager, r.reportCurrency).getPrice(c)
+}
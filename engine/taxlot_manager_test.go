@@ -0,0 +1,303 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/taxlot"
+)
+
+// fTaxLotExchange overrides order, funding and withdrawal history with static
+// values
+type fTaxLotExchange struct {
+	exchange.IBotExchange
+	orders      []order.Detail
+	funding     []exchange.FundHistory
+	withdrawals []exchange.WithdrawalHistory
+}
+
+func (f *fTaxLotExchange) GetOrderHistory(context.Context, *order.GetOrdersRequest) (order.FilteredOrders, error) {
+	return append(order.FilteredOrders(nil), f.orders...), nil
+}
+
+func (f *fTaxLotExchange) GetFundingHistory(context.Context) ([]exchange.FundHistory, error) {
+	return f.funding, nil
+}
+
+func (f *fTaxLotExchange) GetWithdrawalsHistory(context.Context, currency.Code, asset.Item) ([]exchange.WithdrawalHistory, error) {
+	return f.withdrawals, nil
+}
+
+// fTaxLotOrders returns a static order snapshot
+type fTaxLotOrders struct {
+	orders []order.Detail
+}
+
+func (f *fTaxLotOrders) GetOrdersSnapshot(order.Status) []order.Detail {
+	return f.orders
+}
+
+func setupTaxLotManager(t *testing.T, name string, exch *fTaxLotExchange, om iOrderSnapshotProvider) *TaxLotManager {
+	t.Helper()
+	em := SetupExchangeManager()
+	base, err := em.NewExchangeByName("ftx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.SetDefaults()
+	b := base.GetBase()
+	b.Name = name
+	b.Enabled = true
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Spot: {
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+			RequestFormat: &currency.PairFormat{Delimiter: "/"},
+			Available:     currency.Pairs{btcusd},
+			Enabled:       currency.Pairs{btcusd},
+		},
+	}
+	exch.IBotExchange = base
+	em.Add(exch)
+	m, err := SetupTaxLotManager(&config.TaxLots{
+		Exchanges:   []string{name},
+		ImportStart: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}, em, om, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.started = 1
+	return m
+}
+
+func TestSetupTaxLotManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupTaxLotManager(nil, nil, nil, false)
+	if !errors.Is(err, errNilTaxLotsConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilTaxLotsConfig)
+	}
+	cfg := &config.TaxLots{}
+	_, err = SetupTaxLotManager(cfg, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
+	}
+	em := SetupExchangeManager()
+	cfg.Method = "bogus"
+	_, err = SetupTaxLotManager(cfg, em, nil, false)
+	if !errors.Is(err, taxlot.ErrInvalidMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, taxlot.ErrInvalidMethod)
+	}
+	cfg.Method = "hifo"
+	cfg.ReportCurrency = currency.BTC
+	_, err = SetupTaxLotManager(cfg, em, nil, false)
+	if !errors.Is(err, errReportCurrencyNotFiat) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errReportCurrencyNotFiat)
+	}
+	cfg.ReportCurrency = currency.EMPTYCODE
+	m, err := SetupTaxLotManager(cfg, em, nil, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if m.method != taxlot.HIFO || !m.reportCurrency.Equal(currency.USD) ||
+		m.interval != DefaultTaxLotImportInterval || m.importStart.IsZero() {
+		t.Fatalf("unexpected manager values %+v", m)
+	}
+}
+
+func TestTaxLotManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *TaxLotManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Fatal("expected nil manager to not be running")
+	}
+	_, err = m.GetLots("", "")
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m = setupTaxLotManager(t, "TaxLotStartStop", &fTaxLotExchange{}, nil)
+	m.started = 0
+	_, err = m.GenerateReport(2021, "")
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// History is imported on start
+	m.mu.Lock()
+	_, ok := m.importedUntil["TaxLotStartStop"]
+	m.mu.Unlock()
+	if !ok {
+		t.Fatal("expected order history to be imported on start")
+	}
+}
+
+func TestTaxLotManagerImportHistory(t *testing.T) {
+	t.Parallel()
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	bought := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	exch := &fTaxLotExchange{
+		orders: []order.Detail{
+			{
+				OrderID:        "1",
+				Side:           order.Buy,
+				Status:         order.Filled,
+				AssetType:      asset.Spot,
+				Pair:           btcusd,
+				ExecutedAmount: 2,
+				Price:          10000,
+				Fee:            20,
+				FeeAsset:       currency.USD,
+				Date:           bought,
+			},
+			{
+				OrderID:   "2",
+				Side:      order.Buy,
+				Status:    order.Cancelled,
+				AssetType: asset.Spot,
+				Pair:      btcusd,
+				Amount:    1,
+				Price:     10000,
+				Date:      bought,
+			},
+		},
+		withdrawals: []exchange.WithdrawalHistory{
+			{TransferID: "w1", Currency: "BTC", Amount: 0.5, Timestamp: bought.Add(time.Hour)},
+			{TransferID: "w2", Status: "Rejected", Currency: "BTC", Amount: 0.5, Timestamp: bought.Add(time.Hour)},
+		},
+		funding: []exchange.FundHistory{
+			{TransferID: "d1", TransferType: "deposit", Currency: "ETH", Amount: 1, Timestamp: bought},
+		},
+	}
+	om := &fTaxLotOrders{orders: []order.Detail{
+		{
+			Exchange:  "TaxLotImport",
+			OrderID:   "3",
+			Side:      order.Sell,
+			Status:    order.PartiallyFilled,
+			AssetType: asset.Spot,
+			Pair:      btcusd,
+			Trades: []order.TradeHistory{
+				{TID: "t1", Amount: 0.5, Price: 30000, Timestamp: bought.AddDate(1, 0, 1)},
+			},
+		},
+	}}
+	m := setupTaxLotManager(t, "TaxLotImport", exch, om)
+	m.importHistory(context.Background())
+	// Withdrawals are only requested for currencies held, so import again
+	m.importHistory(context.Background())
+
+	lots, err := m.GetLots("TaxLotImport", "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var held, inTransit float64
+	for x := range lots {
+		if !lots[x].Currency.Equal(currency.BTC) {
+			continue
+		}
+		if lots[x].UnitCost != 10010 {
+			t.Fatalf("received: '%v' but expected: '%v'", lots[x].UnitCost, 10010)
+		}
+		if lots[x].InTransit {
+			inTransit += lots[x].Amount
+		} else {
+			held += lots[x].Amount
+		}
+	}
+	if held != 1 || inTransit != 0.5 {
+		t.Fatalf("received: '%v %v' but expected: '%v %v'", held, inTransit, 1, 0.5)
+	}
+
+	lots, err = m.GetLots("bogus", "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(lots) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(lots), 0)
+	}
+
+	_, err = m.GenerateReport(0, "")
+	if !errors.Is(err, errInvalidTaxYear) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTaxYear)
+	}
+	_, err = m.GenerateReport(2022, "bogus")
+	if !errors.Is(err, taxlot.ErrInvalidMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, taxlot.ErrInvalidMethod)
+	}
+	r, err := m.GenerateReport(2022, "lifo")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(r.Gains) != 1 || r.Method != taxlot.LIFO || r.Gain != 0.5*(30000-10010) ||
+		r.Gains[0].Term != taxlot.LongTerm {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestOrderFills(t *testing.T) {
+	t.Parallel()
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	placed := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d := &order.Detail{
+		Exchange:  "test",
+		OrderID:   "1",
+		Side:      order.Ask,
+		Status:    order.Filled,
+		AssetType: asset.Futures,
+		Pair:      btcusd,
+		Amount:    1,
+		Price:     100,
+		Date:      placed,
+	}
+	if fills := orderFills(d); len(fills) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(fills), 0)
+	}
+	d.AssetType = asset.Spot
+	d.AverageExecutedPrice = 101
+	fills := orderFills(d)
+	if len(fills) != 1 || fills[0].Buy || fills[0].Amount != 1 || fills[0].Price != 101 ||
+		fills[0].ID != "1" || !fills[0].Timestamp.Equal(placed) {
+		t.Fatalf("unexpected fills %+v", fills)
+	}
+	d.Side = order.AnySide
+	if fills = orderFills(d); len(fills) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(fills), 0)
+	}
+	d.Trades = []order.TradeHistory{
+		{Amount: 0.4, Price: 100, Side: order.Bid, Fee: 0.1, FeeAsset: "bnb"},
+		{TID: "t2", Amount: 0.6, Price: 102, Timestamp: placed.Add(time.Minute)},
+	}
+	fills = orderFills(d)
+	if len(fills) != 1 || !fills[0].Buy || fills[0].ID != "1-0" ||
+		!fills[0].FeeAsset.Equal(currency.BNB) || !fills[0].Timestamp.Equal(placed) {
+		t.Fatalf("unexpected fills %+v", fills)
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/taxlot"
+)
+
+// TaxLotManagerName is an exported subsystem name
+const TaxLotManagerName = "tax_lot_manager"
+
+// DefaultTaxLotImportInterval is the default duration between imports of
+// exchange order, deposit and withdrawal history
+const DefaultTaxLotImportInterval = time.Hour
+
+var (
+	errNilTaxLotsConfig      = errors.New("nil tax lots config received")
+	errReportCurrencyNotFiat = errors.New("report currency must be a fiat currency")
+	errInvalidTaxYear        = errors.New("invalid tax year")
+)
+
+// TaxLotManager records fills from orders tracked by the order manager and
+// imported from exchange order history, along with deposits and withdrawals,
+// to track acquired lots and report realised capital gains
+type TaxLotManager struct {
+	started         int32
+	verbose         bool
+	interval        time.Duration
+	method          taxlot.Method
+	reportCurrency  currency.Code
+	importStart     time.Time
+	exchanges       []string
+	exchangeManager iExchangeManager
+	orderManager    iOrderSnapshotProvider
+	tracker         *taxlot.Tracker
+	// importedUntil holds the end of the last successful order history
+	// import of each exchange
+	importedUntil map[string]time.Time
+	shutdown      chan struct{}
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+}
+
+// taxLotRates values currencies in the report currency using current prices
+// when no fill against the report currency has been observed
+type taxLotRates struct {
+	exchangeManager iExchangeManager
+	reportCurrency  currency.Code
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -241,6 +242,15 @@ func (m *websocketRoutineManager) websocketDataHandler(exchName string, data int
 			}
 		}
 		m.syncer.PrintOrderbookSummary(base, "websocket", nil)
+	case buffer.Desync:
+		if m.syncer.IsRunning() {
+			return m.syncer.OrderbookDesync(exchName, d.Pair, d.Asset, d.Reason)
+		}
+		log.Warnf(log.WebsocketMgr, "%s websocket %s %s orderbook desync: %v",
+			exchName,
+			m.FormatCurrency(d.Pair),
+			d.Asset,
+			d.Reason)
 	case *order.Detail:
 		if !m.orderManager.Exists(d) {
 			err := m.orderManager.Add(d)
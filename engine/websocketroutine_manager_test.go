@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
@@ -172,6 +173,15 @@ func TestWebsocketRoutineManagerHandleData(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	err = m.websocketDataHandler(exchName, buffer.Desync{
+		Exchange: exchName,
+		Pair:     currency.NewPair(currency.BTC, currency.USDC),
+		Asset:    asset.Spot,
+		Reason:   errors.New("checksum mismatch"),
+	})
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
 	origOrder := &order.Detail{
 		Exchange: exchName,
 		OrderID:  orderID,
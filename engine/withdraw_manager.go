@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	dbwithdraw "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -93,6 +95,63 @@ func (m *WithdrawManager) SubmitWithdrawal(ctx context.Context, req *withdraw.Re
 	return resp, err
 }
 
+// SubmitTransfer performs validation and submits an internal transfer between
+// the asset wallets and sub-accounts of an exchange. Transfers are recorded as
+// audit events
+func (m *WithdrawManager) SubmitTransfer(ctx context.Context, req *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	exch, err := m.exchangeManager.GetExchangeByName(req.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *withdraw.ExchangeResponse
+	if m.isDryRun {
+		log.Warnln(log.Global, "Dry run enabled, no transfer request will be submitted")
+		resp = &withdraw.ExchangeResponse{
+			ID:     withdraw.DryRunID.String(),
+			Status: "dryrun",
+		}
+	} else {
+		resp, err = exch.Transfer(ctx, req)
+	}
+	if err == nil && resp == nil {
+		resp = &withdraw.ExchangeResponse{}
+	}
+
+	msg := fmt.Sprintf("Transfer %v %s from %s to %s",
+		req.Amount,
+		req.Currency,
+		transferWallet(req.From, req.FromSubAccount),
+		transferWallet(req.To, req.ToSubAccount))
+	if accountName := account.GetAccountFromContext(ctx); accountName != "" {
+		msg += " with account " + accountName
+	}
+	if err != nil {
+		msg += fmt.Sprintf(" failed: %v", err)
+		log.Errorln(log.Global, exch.GetName()+" "+msg)
+	} else {
+		resp.Name = exch.GetName()
+		msg += " submitted with ID " + resp.ID
+		log.Infoln(log.Global, exch.GetName()+" "+msg)
+	}
+	audit.Event(exch.GetName(), transferAuditType, msg)
+	return resp, err
+}
+
+// transferWallet describes the wallet of a transfer
+func transferWallet(a asset.Item, subAccount string) string {
+	if subAccount == "" {
+		return a.String()
+	}
+	return a.String() + " of sub-account " + subAccount
+}
+
 // WithdrawalEventByID returns a withdrawal request by ID
 func (m *WithdrawManager) WithdrawalEventByID(id string) (*withdraw.Response, error) {
 	if m == nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -220,3 +221,76 @@ func TestWithdrawalEventByExchangeID(t *testing.T) {
 			ErrExchangeNotFound)
 	}
 }
+
+// fTransferExchange overrides internal transfers
+type fTransferExchange struct {
+	exchange.IBotExchange
+	requests *[]withdraw.TransferRequest
+}
+
+func (f fTransferExchange) Transfer(_ context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if r.ToSubAccount == "fail" {
+		return nil, common.ErrFunctionNotSupported
+	}
+	*f.requests = append(*f.requests, *r)
+	return &withdraw.ExchangeResponse{ID: "1337"}, nil
+}
+
+func TestSubmitTransfer(t *testing.T) {
+	t.Parallel()
+	var m *WithdrawManager
+	_, err := m.SubmitTransfer(context.Background(), nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	em := SetupExchangeManager()
+	b, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetDefaults()
+	exch := fTransferExchange{IBotExchange: b, requests: new([]withdraw.TransferRequest)}
+	em.Add(exch)
+	m, err = SetupWithdrawManager(em, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.SubmitTransfer(context.Background(), nil)
+	if !errors.Is(err, withdraw.ErrRequestCannotBeNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, withdraw.ErrRequestCannotBeNil)
+	}
+	req := &withdraw.TransferRequest{
+		Exchange: "bogus",
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.USDTMarginedFutures,
+	}
+	_, err = m.SubmitTransfer(context.Background(), req)
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+	req.Exchange = testExchange
+	resp, err := m.SubmitTransfer(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.ID != "1337" || resp.Name != testExchange || len(*exch.requests) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	req.ToSubAccount = "fail"
+	_, err = m.SubmitTransfer(context.Background(), req)
+	if !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrFunctionNotSupported)
+	}
+
+	m.isDryRun = true
+	req.ToSubAccount = ""
+	resp, err = m.SubmitTransfer(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Status != "dryrun" || len(*exch.requests) != 1 {
+		t.Fatalf("unexpected dry run response %+v", resp)
+	}
+}
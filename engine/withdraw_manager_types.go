@@ -4,6 +4,9 @@ import (
 	"errors"
 )
 
+// transferAuditType is the audit event type of internal transfers
+const transferAuditType = "transfer"
+
 var (
 	// ErrWithdrawRequestNotFound message to display when no record is found
 	ErrWithdrawRequestNotFound = errors.New("request not found")
@@ -21,6 +21,10 @@ const (
 	// context, when the default config credentials sub account needs to be
 	// changed while the same keys can be used.
 	ContextSubAccountFlag contextCredential = "subaccountoverride"
+	// ContextAccountFlag used for retrieving the name of an exchange account
+	// loaded by config from context, selecting its credentials over the
+	// default credentials.
+	ContextAccountFlag contextCredential = "account"
 
 	apiKeyDisplaySize = 16
 )
@@ -210,6 +214,44 @@ func DeploySubAccountOverrideToContext(ctx context.Context, subAccount string) c
 	return context.WithValue(ctx, ContextSubAccountFlag, subAccount)
 }
 
+// NormaliseAccountName returns the form exchange account names are stored and
+// looked up in, as account names are case insensitive
+func NormaliseAccountName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// DeployAccountToContext sets the name of an exchange account loaded by config
+// to context so its credentials are used for authenticated requests. An empty
+// name leaves the context unchanged and the default credentials are used.
+func DeployAccountToContext(ctx context.Context, name string) context.Context {
+	name = NormaliseAccountName(name)
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextAccountFlag, name)
+}
+
+// GetAccountFromContext returns the name of the exchange account set to
+// context, or an empty string when the default credentials are used.
+func GetAccountFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(ContextAccountFlag).(string)
+	return name
+}
+
+// HasCredentialsOverride returns true when the context selects credentials
+// other than the default credentials, either by exchange account name,
+// credentials or a sub account override.
+func HasCredentialsOverride(ctx context.Context) bool {
+	if GetAccountFromContext(ctx) != "" {
+		return true
+	}
+	if ctx.Value(ContextCredentialsFlag) != nil {
+		return true
+	}
+	subAccount, _ := ctx.Value(ContextSubAccountFlag).(string)
+	return subAccount != ""
+}
+
 // String strings the credentials in a protected way.
 func (p *Protected) String() string {
 	return p.creds.String()
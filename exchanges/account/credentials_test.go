@@ -238,3 +238,39 @@ func TestProtectedCredentialsEqual(t *testing.T) {
 		t.Fatal("unexpected value")
 	}
 }
+
+func TestDeployAccountToContext(t *testing.T) {
+	t.Parallel()
+	ctx := DeployAccountToContext(context.Background(), "")
+	if name := GetAccountFromContext(ctx); name != "" {
+		t.Fatalf("received: '%v' but expected: '%v'", name, "")
+	}
+	ctx = DeployAccountToContext(context.Background(), "Strategy")
+	if name := GetAccountFromContext(ctx); name != "strategy" {
+		t.Fatalf("received: '%v' but expected: '%v'", name, "strategy")
+	}
+}
+
+func TestNormaliseAccountName(t *testing.T) {
+	t.Parallel()
+	if name := NormaliseAccountName(" Strategy "); name != "strategy" {
+		t.Fatalf("received: '%v' but expected: '%v'", name, "strategy")
+	}
+}
+
+func TestHasCredentialsOverride(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	if HasCredentialsOverride(ctx) {
+		t.Fatal("expected default credentials to not be an override")
+	}
+	if !HasCredentialsOverride(DeployAccountToContext(ctx, "strategy")) {
+		t.Fatal("expected exchange account to be an override")
+	}
+	if !HasCredentialsOverride(DeployCredentialsToContext(ctx, &Credentials{Key: "key", Secret: "secret"})) {
+		t.Fatal("expected credentials to be an override")
+	}
+	if !HasCredentialsOverride(DeploySubAccountOverrideToContext(ctx, "sub")) {
+		t.Fatal("expected sub account to be an override")
+	}
+}
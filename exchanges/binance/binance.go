@@ -74,6 +74,10 @@ const (
 	withdrawHistory  = "/sapi/v1/capital/withdraw/history"
 	depositAddress   = "/sapi/v1/capital/deposit/address"
 
+	// Transfer endpoints
+	universalTransfer           = "/sapi/v1/asset/transfer"
+	subAccountUniversalTransfer = "/sapi/v1/sub-account/universalTransfer"
+
 	defaultRecvWindow     = 5 * time.Second
 	binanceSAPITimeLayout = "2006-01-02 15:04:05"
 )
@@ -542,10 +546,19 @@ func (b *Binance) NewOrderTest(ctx context.Context, o *NewOrderRequest) error {
 }
 
 func (b *Binance) newOrder(ctx context.Context, api string, o *NewOrderRequest, resp *NewOrderResponse) error {
+	params, err := b.newOrderParams(o)
+	if err != nil {
+		return err
+	}
+	return b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, api, params, spotOrderRate, resp)
+}
+
+// newOrderParams returns the request parameters for a new order
+func (b *Binance) newOrderParams(o *NewOrderRequest) (url.Values, error) {
 	params := url.Values{}
 	symbol, err := b.FormatSymbol(o.Symbol, asset.Spot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	params.Set("symbol", symbol)
 	params.Set("side", o.Side)
@@ -577,16 +590,24 @@ func (b *Binance) newOrder(ctx context.Context, api string, o *NewOrderRequest,
 	if o.NewOrderRespType != "" {
 		params.Set("newOrderRespType", o.NewOrderRespType)
 	}
-	return b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, api, params, spotOrderRate, resp)
+	return params, nil
 }
 
 // CancelExistingOrder sends a cancel order to Binance
 func (b *Binance) CancelExistingOrder(ctx context.Context, symbol currency.Pair, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
 	var resp CancelOrderResponse
+	params, err := b.cancelOrderParams(symbol, orderID, origClientOrderID)
+	if err != nil {
+		return resp, err
+	}
+	return resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodDelete, orderEndpoint, params, spotOrderRate, &resp)
+}
 
+// cancelOrderParams returns the request parameters to cancel an order
+func (b *Binance) cancelOrderParams(symbol currency.Pair, orderID int64, origClientOrderID string) (url.Values, error) {
 	symbolValue, err := b.FormatSymbol(symbol, asset.Spot)
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 	params := url.Values{}
 	params.Set("symbol", symbolValue)
@@ -598,7 +619,7 @@ func (b *Binance) CancelExistingOrder(ctx context.Context, symbol currency.Pair,
 	if origClientOrderID != "" {
 		params.Set("origClientOrderId", origClientOrderID)
 	}
-	return resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodDelete, orderEndpoint, params, spotOrderRate, &resp)
+	return params, nil
 }
 
 // OpenOrders Current open orders. Get all open orders on a symbol.
@@ -975,6 +996,57 @@ func (b *Binance) WithdrawCrypto(ctx context.Context, cryptoAsset, withdrawOrder
 	return resp.ID, nil
 }
 
+// UniversalTransfer transfers an asset between the wallets of an account.
+// The transfer type joins the source and destination wallets e.g.
+// MAIN_UMFUTURE
+func (b *Binance) UniversalTransfer(ctx context.Context, transferType, cryptoAsset string, amount float64) (int64, error) {
+	if transferType == "" || cryptoAsset == "" || amount <= 0 {
+		return 0, errors.New("transfer type, asset and amount must be set")
+	}
+	params := url.Values{}
+	params.Set("type", transferType)
+	params.Set("asset", cryptoAsset)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	var resp TransferResponse
+	return resp.TransferID, b.SendAuthHTTPRequest(ctx,
+		exchange.RestSpotSupplementary,
+		http.MethodPost,
+		universalTransfer,
+		params,
+		spotDefaultRate,
+		&resp)
+}
+
+// SubAccountUniversalTransfer transfers an asset between the wallets of the
+// master account and its sub-accounts, which are identified by email. An
+// empty email refers to the master account. Only available to master accounts
+func (b *Binance) SubAccountUniversalTransfer(ctx context.Context, fromEmail, toEmail, fromAccountType, toAccountType, cryptoAsset string, amount float64) (int64, error) {
+	if fromAccountType == "" || toAccountType == "" || cryptoAsset == "" || amount <= 0 {
+		return 0, errors.New("account types, asset and amount must be set")
+	}
+	params := url.Values{}
+	if fromEmail != "" {
+		params.Set("fromEmail", fromEmail)
+	}
+	if toEmail != "" {
+		params.Set("toEmail", toEmail)
+	}
+	params.Set("fromAccountType", fromAccountType)
+	params.Set("toAccountType", toAccountType)
+	params.Set("asset", cryptoAsset)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	var resp TransferResponse
+	return resp.TransferID, b.SendAuthHTTPRequest(ctx,
+		exchange.RestSpotSupplementary,
+		http.MethodPost,
+		subAccountUniversalTransfer,
+		params,
+		spotDefaultRate,
+		&resp)
+}
+
 // DepositHistory returns the deposit history based on the supplied params
 // status `param` used as string to prevent default value 0 (for int) interpreting as EmailSent status
 func (b *Binance) DepositHistory(ctx context.Context, c currency.Code, status string, startTime, endTime time.Time, offset, limit int) ([]DepositHistory, error) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"sync"
 	"testing"
@@ -2055,6 +2056,48 @@ func TestWithdraw(t *testing.T) {
 	}
 }
 
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	_, err := b.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: b.Name,
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.Futures,
+	})
+	if !errors.Is(err, withdraw.ErrTransferWalletUnsupported) {
+		t.Errorf("received: '%v' but expected: '%v'", err, withdraw.ErrTransferWalletUnsupported)
+	}
+	for a, expected := range map[asset.Item][2]string{
+		asset.Spot:                {"MAIN", "SPOT"},
+		asset.Margin:              {"MARGIN", "MARGIN"},
+		asset.USDTMarginedFutures: {"UMFUTURE", "USDT_FUTURE"},
+		asset.CoinMarginedFutures: {"CMFUTURE", "COIN_FUTURE"},
+	} {
+		wallet, err := universalTransferWallet(a)
+		if err != nil || wallet != expected[0] {
+			t.Errorf("received: '%v' '%v' but expected: '%v'", wallet, err, expected[0])
+		}
+		wallet, err = subAccountTransferWallet(a)
+		if err != nil || wallet != expected[1] {
+			t.Errorf("received: '%v' '%v' but expected: '%v'", wallet, err, expected[1])
+		}
+	}
+	if mockTests || !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping live transfer, API keys not set or canManipulateRealOrders false")
+	}
+	_, err = b.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: b.Name,
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.USDTMarginedFutures,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestDepositHistory(t *testing.T) {
 	t.Parallel()
 	if areTestAPIKeysSet() && !canManipulateRealOrders && !mockTests {
@@ -2845,3 +2888,16 @@ func TestFetchSpotExchangeLimits(t *testing.T) {
 		t.Error("expected a response")
 	}
 }
+
+func TestWsAPISignaturePayload(t *testing.T) {
+	t.Parallel()
+	params := url.Values{}
+	params.Set("timestamp", "1660801839480")
+	params.Set("symbol", "BTCUSDT")
+	params.Set("newClientOrderId", "a b")
+	params.Set("apiKey", "key")
+	expected := "apiKey=key&newClientOrderId=a b&symbol=BTCUSDT&timestamp=1660801839480"
+	if payload := wsAPISignaturePayload(params); payload != expected {
+		t.Errorf("received: '%v' but expected: '%v'", payload, expected)
+	}
+}
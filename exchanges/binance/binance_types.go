@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -704,6 +705,11 @@ type WithdrawResponse struct {
 	ID string `json:"id"`
 }
 
+// TransferResponse contains the ID of an internal transfer
+type TransferResponse struct {
+	TransferID int64 `json:"tranId"`
+}
+
 // WithdrawStatusResponse defines a withdrawal status response
 type WithdrawStatusResponse struct {
 	Address         string  `json:"address"`
@@ -899,3 +905,35 @@ type update struct {
 type job struct {
 	Pair currency.Pair
 }
+
+// WsAPIRequest defines a websocket API request
+type WsAPIRequest struct {
+	ID     int64                  `json:"id"`
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+}
+
+// WsAPIResponse defines a websocket API response
+type WsAPIResponse struct {
+	ID         int64            `json:"id"`
+	Status     int64            `json:"status"`
+	Result     json.RawMessage  `json:"result"`
+	Error      *WsAPIError      `json:"error"`
+	RateLimits []WsAPIRateLimit `json:"rateLimits"`
+}
+
+// WsAPIError defines a websocket API error
+type WsAPIError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// WsAPIRateLimit defines the rate limit usage returned with a websocket API
+// response
+type WsAPIRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+	Count         int64  `json:"count"`
+}
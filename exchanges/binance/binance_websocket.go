@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -23,11 +27,25 @@ import (
 
 const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443/stream"
+	binanceWebsocketAPIURL     = "wss://ws-api.binance.com:443/ws-api/v3"
 	pingDelay                  = time.Minute * 9
+	// wsMaxStreamsPerConnection defines the max streams a single combined
+	// stream connection can subscribe to
+	wsMaxStreamsPerConnection = 1024
 )
 
 var listenKey string
 
+var errWsAPIRequestFailed = errors.New("websocket API request failed")
+
+// wsAPIIntegerParams are websocket API request parameters which are sent as
+// numbers
+var wsAPIIntegerParams = map[string]bool{
+	"orderId":    true,
+	"recvWindow": true,
+	"timestamp":  true,
+}
+
 var (
 	// maxWSUpdateBuffer defines max websocket updates to apply when an
 	// orderbook is initially fetched
@@ -40,17 +58,16 @@ var (
 	maxWSOrderbookWorkers = 10
 )
 
-// WsConnect initiates a websocket connection
+// WsConnect initiates a websocket connection. Market data streams are
+// subscribed to on pooled connections, so the primary connection is only
+// dialled for the authenticated user data stream
 func (b *Binance) WsConnect() error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
 		return errors.New(stream.WebsocketNotEnabled)
 	}
 
-	var dialer websocket.Dialer
-	dialer.HandshakeTimeout = b.Config.HTTPTimeout
-	dialer.Proxy = http.ProxyFromEnvironment
-	var err error
 	if b.Websocket.CanUseAuthenticatedEndpoints() {
+		var err error
 		listenKey, err = b.GetWsAuthStreamKey(context.TODO())
 		if err != nil {
 			b.Websocket.SetCanUseAuthenticatedEndpoints(false)
@@ -61,35 +78,55 @@ func (b *Binance) WsConnect() error {
 		} else {
 			// cleans on failed connection
 			clean := strings.Split(b.Websocket.GetWebsocketURL(), "?streams=")
-			authPayload := clean[0] + "?streams=" + listenKey
-			err = b.Websocket.SetWebsocketURL(authPayload, false, false)
+			b.Websocket.Conn.SetURL(clean[0] + "?streams=" + listenKey)
+			err = b.wsDial(b.Websocket.Conn)
 			if err != nil {
 				return err
 			}
+			go b.KeepAuthKeyAlive()
+		}
+	}
+
+	if b.Websocket.CanUseAuthenticatedEndpoints() && b.Websocket.IsOrderEntryEnabled() {
+		err := b.Websocket.ConnectAuth()
+		if err != nil {
+			// Order entry falls back to REST until the connection is redialled
+			log.Errorf(log.ExchangeSys, "%v %v", b.Name, err)
 		}
 	}
 
-	err = b.Websocket.Conn.Dial(&dialer, http.Header{})
+	b.setupOrderbookManager()
+	return nil
+}
+
+// wsConnectShard dials a pooled market data connection
+func (b *Binance) wsConnectShard(conn stream.Connection) error {
+	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
+		return errors.New(stream.WebsocketNotEnabled)
+	}
+	return b.wsDial(conn)
+}
+
+// wsDial dials a connection and starts reading from it
+func (b *Binance) wsDial(conn stream.Connection) error {
+	var dialer websocket.Dialer
+	dialer.HandshakeTimeout = b.Config.HTTPTimeout
+	dialer.Proxy = http.ProxyFromEnvironment
+	err := conn.Dial(&dialer, http.Header{})
 	if err != nil {
 		return fmt.Errorf("%v - Unable to connect to Websocket. Error: %s",
 			b.Name,
 			err)
 	}
 
-	if b.Websocket.CanUseAuthenticatedEndpoints() {
-		go b.KeepAuthKeyAlive()
-	}
-
-	b.Websocket.Conn.SetupPingHandler(stream.PingHandler{
+	conn.SetupPingHandler(stream.PingHandler{
 		UseGorillaHandler: true,
 		MessageType:       websocket.PongMessage,
 		Delay:             pingDelay,
 	})
 
 	b.Websocket.Wg.Add(1)
-	go b.wsReadData()
-
-	b.setupOrderbookManager()
+	go b.wsReadData(conn)
 	return nil
 }
 
@@ -141,11 +178,11 @@ func (b *Binance) KeepAuthKeyAlive() {
 }
 
 // wsReadData receives and passes on websocket messages for processing
-func (b *Binance) wsReadData() {
+func (b *Binance) wsReadData(conn stream.Connection) {
 	defer b.Websocket.Wg.Done()
 
 	for {
-		resp := b.Websocket.Conn.ReadMessage()
+		resp := conn.ReadMessage()
 		if resp.Raw == nil {
 			return
 		}
@@ -576,13 +613,18 @@ func (b *Binance) GenerateSubscriptions() ([]stream.ChannelSubscription, error)
 
 // Subscribe subscribes to a set of channels
 func (b *Binance) Subscribe(channelsToSubscribe []stream.ChannelSubscription) error {
+	return b.subscribeConn(b.Websocket.Conn, channelsToSubscribe)
+}
+
+// subscribeConn subscribes to a set of channels on a connection
+func (b *Binance) subscribeConn(conn stream.Connection, channelsToSubscribe []stream.ChannelSubscription) error {
 	payload := WsPayload{
 		Method: "SUBSCRIBE",
 	}
 	for i := range channelsToSubscribe {
 		payload.Params = append(payload.Params, channelsToSubscribe[i].Channel)
 		if i%50 == 0 && i != 0 {
-			err := b.Websocket.Conn.SendJSONMessage(payload)
+			err := conn.SendJSONMessage(payload)
 			if err != nil {
 				return err
 			}
@@ -590,7 +632,7 @@ func (b *Binance) Subscribe(channelsToSubscribe []stream.ChannelSubscription) er
 		}
 	}
 	if len(payload.Params) > 0 {
-		err := b.Websocket.Conn.SendJSONMessage(payload)
+		err := conn.SendJSONMessage(payload)
 		if err != nil {
 			return err
 		}
@@ -601,13 +643,18 @@ func (b *Binance) Subscribe(channelsToSubscribe []stream.ChannelSubscription) er
 
 // Unsubscribe unsubscribes from a set of channels
 func (b *Binance) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription) error {
+	return b.unsubscribeConn(b.Websocket.Conn, channelsToUnsubscribe)
+}
+
+// unsubscribeConn unsubscribes from a set of channels on a connection
+func (b *Binance) unsubscribeConn(conn stream.Connection, channelsToUnsubscribe []stream.ChannelSubscription) error {
 	payload := WsPayload{
 		Method: "UNSUBSCRIBE",
 	}
 	for i := range channelsToUnsubscribe {
 		payload.Params = append(payload.Params, channelsToUnsubscribe[i].Channel)
 		if i%50 == 0 && i != 0 {
-			err := b.Websocket.Conn.SendJSONMessage(payload)
+			err := conn.SendJSONMessage(payload)
 			if err != nil {
 				return err
 			}
@@ -615,7 +662,7 @@ func (b *Binance) Unsubscribe(channelsToUnsubscribe []stream.ChannelSubscription
 		}
 	}
 	if len(payload.Params) > 0 {
-		err := b.Websocket.Conn.SendJSONMessage(payload)
+		err := conn.SendJSONMessage(payload)
 		if err != nil {
 			return err
 		}
@@ -1058,3 +1105,171 @@ func (o *orderbookManager) stopNeedsFetchingBook(pair currency.Pair) error {
 	state.needsFetchingBook = false
 	return nil
 }
+
+// wsConnectAPI dials the websocket API connection used for order entry, it is
+// redialled by the websocket when it disconnects
+func (b *Binance) wsConnectAPI(conn stream.Connection) error {
+	var dialer websocket.Dialer
+	dialer.HandshakeTimeout = b.Config.HTTPTimeout
+	dialer.Proxy = http.ProxyFromEnvironment
+	err := conn.Dial(&dialer, http.Header{})
+	if err != nil {
+		return fmt.Errorf("%v - Unable to connect to Websocket API. Error: %s",
+			b.Name,
+			err)
+	}
+
+	conn.SetupPingHandler(stream.PingHandler{
+		UseGorillaHandler: true,
+		MessageType:       websocket.PongMessage,
+		Delay:             pingDelay,
+	})
+
+	b.Websocket.Wg.Add(1)
+	go b.wsReadAPIData(conn)
+	return nil
+}
+
+// wsReadAPIData receives websocket API responses and matches them to their
+// requests
+func (b *Binance) wsReadAPIData(conn stream.Connection) {
+	defer b.Websocket.Wg.Done()
+
+	for {
+		resp := conn.ReadMessage()
+		if resp.Raw == nil {
+			return
+		}
+		var id struct {
+			ID int64 `json:"id"`
+		}
+		if err := json.Unmarshal(resp.Raw, &id); err != nil {
+			b.Websocket.DataHandler <- err
+			continue
+		}
+		if !b.Websocket.Match.IncomingWithData(id.ID, resp.Raw) {
+			b.Websocket.DataHandler <- stream.UnhandledMessageWarning{
+				Message: b.Name + stream.UnhandledMessage + string(resp.Raw),
+			}
+		}
+	}
+}
+
+// wsAPIRequest signs and sends a websocket API request, waiting for its
+// response. The request weight used, as reported by the response, is shared
+// with the REST rate limiter
+func (b *Binance) wsAPIRequest(ctx context.Context, method string, params url.Values, f request.EndpointLimit, result interface{}) error {
+	creds, err := b.GetCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = b.Requester.InitiateRateLimit(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	params.Set("apiKey", creds.Key)
+	if params.Get("recvWindow") == "" {
+		params.Set("recvWindow", strconv.FormatInt(defaultRecvWindow.Milliseconds(), 10))
+	}
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	hmacSigned, err := crypto.GetHMAC(crypto.HashSHA256,
+		[]byte(wsAPISignaturePayload(params)),
+		[]byte(creds.Secret))
+	if err != nil {
+		return err
+	}
+
+	req := WsAPIRequest{
+		ID:     b.Websocket.AuthConn.GenerateMessageID(false),
+		Method: method,
+		Params: make(map[string]interface{}, len(params)+1),
+	}
+	for k := range params {
+		req.Params[k] = params.Get(k)
+		if wsAPIIntegerParams[k] {
+			req.Params[k], err = strconv.ParseInt(params.Get(k), 10, 64)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	req.Params["signature"] = crypto.HexEncodeToString(hmacSigned)
+
+	respRaw, err := b.Websocket.AuthConn.SendMessageReturnResponse(req.ID, req)
+	if err != nil {
+		return err
+	}
+	var resp WsAPIResponse
+	err = json.Unmarshal(respRaw, &resp)
+	if err != nil {
+		return err
+	}
+	b.updateWsAPIUsage(resp.RateLimits)
+	if resp.Error != nil {
+		return fmt.Errorf("%s websocket API %s status %d: %w: %s",
+			b.Name,
+			method,
+			resp.Status,
+			errWsAPIRequestFailed,
+			resp.Error.Message)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// wsAPISignaturePayload returns the request parameters sorted by key as an
+// unescaped query string, which is signed for websocket API requests
+func wsAPISignaturePayload(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for i := range keys {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(keys[i])
+		sb.WriteByte('=')
+		sb.WriteString(params.Get(keys[i]))
+	}
+	return sb.String()
+}
+
+// updateWsAPIUsage corrects the request weight rate limiter with the usage
+// reported by a websocket API response
+func (b *Binance) updateWsAPIUsage(limits []WsAPIRateLimit) {
+	for i := range limits {
+		if limits[i].RateLimitType == "REQUEST_WEIGHT" &&
+			limits[i].Interval == "MINUTE" &&
+			limits[i].IntervalNum == 1 {
+			b.Requester.UpdateRateLimitUsage(spotDefaultRate, int(limits[i].Count))
+		}
+	}
+}
+
+// WsSubmitOrder submits a spot order over the websocket API
+func (b *Binance) WsSubmitOrder(ctx context.Context, o *NewOrderRequest) (NewOrderResponse, error) {
+	var resp NewOrderResponse
+	params, err := b.newOrderParams(o)
+	if err != nil {
+		return resp, err
+	}
+	// Request the full response to include fills
+	if params.Get("newOrderRespType") == "" {
+		params.Set("newOrderRespType", "FULL")
+	}
+	return resp, b.wsAPIRequest(ctx, "order.place", params, spotOrderRate, &resp)
+}
+
+// WsCancelOrder cancels a spot order over the websocket API
+func (b *Binance) WsCancelOrder(ctx context.Context, symbol currency.Pair, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
+	var resp CancelOrderResponse
+	params, err := b.cancelOrderParams(symbol, orderID, origClientOrderID)
+	if err != nil {
+		return resp, err
+	}
+	return resp, b.wsAPIRequest(ctx, "order.cancel", params, spotOrderRate, &resp)
+}
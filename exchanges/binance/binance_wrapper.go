@@ -155,6 +155,8 @@ func (b *Binance) SetDefaults() {
 				GetOrders:              true,
 				Subscribe:              true,
 				Unsubscribe:            true,
+				SubmitOrder:            true,
+				CancelOrder:            true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.NoFiatWithdrawals,
@@ -226,6 +228,10 @@ func (b *Binance) Setup(exch *config.Exchange) error {
 	if err != nil {
 		return err
 	}
+	err = b.SetupAdaptiveRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if err != nil {
+		return err
+	}
 	ePoint, err := b.API.Endpoints.GetURL(exchange.WebsocketSpot)
 	if err != nil {
 		return err
@@ -243,17 +249,33 @@ func (b *Binance) Setup(exch *config.Exchange) error {
 			SortBuffer:            true,
 			SortBufferByUpdateIDs: true,
 		},
-		TradeFeed: b.Features.Enabled.TradeFeed,
+		TradeFeed:                     b.Features.Enabled.TradeFeed,
+		MaxSubscriptionsPerConnection: wsMaxStreamsPerConnection,
+		ShardConnector:                b.wsConnectShard,
+		ShardSubscriber:               b.subscribeConn,
+		ShardUnsubscriber:             b.unsubscribeConn,
+		AuthConnector:                 b.wsConnectAPI,
+		OrderEntrySignedPerMessage:    true,
 	})
 	if err != nil {
 		return err
 	}
 
-	return b.Websocket.SetupNewConnection(stream.ConnectionSetup{
+	err = b.Websocket.SetupNewConnection(stream.ConnectionSetup{
 		ResponseCheckTimeout: exch.WebsocketResponseCheckTimeout,
 		ResponseMaxLimit:     exch.WebsocketResponseMaxLimit,
 		RateLimit:            wsRateLimitMilliseconds,
 	})
+	if err != nil {
+		return err
+	}
+
+	return b.Websocket.SetupNewConnection(stream.ConnectionSetup{
+		ResponseCheckTimeout: exch.WebsocketResponseCheckTimeout,
+		ResponseMaxLimit:     exch.WebsocketResponseMaxLimit,
+		URL:                  binanceWebsocketAPIURL,
+		Authenticated:        true,
+	})
 }
 
 // Start starts the Binance go routine
@@ -940,7 +962,16 @@ func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 			TimeInForce:      timeInForce,
 			NewClientOrderID: s.ClientOrderID,
 		}
-		response, err := b.NewOrder(ctx, &orderRequest)
+		var response NewOrderResponse
+		err := b.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
+			var wsErr error
+			response, wsErr = b.WsSubmitOrder(ctx, &orderRequest)
+			return wsErr
+		}, func() error {
+			var restErr error
+			response, restErr = b.NewOrder(ctx, &orderRequest)
+			return restErr
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -1087,10 +1118,13 @@ func (b *Binance) CancelOrder(ctx context.Context, o *order.Cancel) error {
 		if err != nil {
 			return err
 		}
-		_, err = b.CancelExistingOrder(ctx,
-			o.Pair,
-			orderIDInt,
-			o.AccountID)
+		err = b.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
+			_, wsErr := b.WsCancelOrder(ctx, o.Pair, orderIDInt, o.AccountID)
+			return wsErr
+		}, func() error {
+			_, restErr := b.CancelExistingOrder(ctx, o.Pair, orderIDInt, o.AccountID)
+			return restErr
+		})
 		if err != nil {
 			return err
 		}
@@ -1333,6 +1367,77 @@ func (b *Binance) WithdrawFiatFundsToInternationalBank(_ context.Context, _ *wit
 	return nil, common.ErrFunctionNotSupported
 }
 
+// Transfer moves funds between the spot, margin and futures wallets of an
+// account, or between the master account and its sub-accounts which are
+// identified by email
+func (b *Binance) Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	var id int64
+	if r.FromSubAccount == "" && r.ToSubAccount == "" {
+		from, err := universalTransferWallet(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := universalTransferWallet(r.To)
+		if err != nil {
+			return nil, err
+		}
+		id, err = b.UniversalTransfer(ctx, from+"_"+to, r.Currency.String(), r.Amount)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		from, err := subAccountTransferWallet(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := subAccountTransferWallet(r.To)
+		if err != nil {
+			return nil, err
+		}
+		id, err = b.SubAccountUniversalTransfer(ctx, r.FromSubAccount, r.ToSubAccount, from, to, r.Currency.String(), r.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &withdraw.ExchangeResponse{
+		ID: strconv.FormatInt(id, 10),
+	}, nil
+}
+
+// universalTransferWallet returns the universal transfer wallet of an asset
+func universalTransferWallet(a asset.Item) (string, error) {
+	switch a {
+	case asset.Spot:
+		return "MAIN", nil
+	case asset.Margin:
+		return "MARGIN", nil
+	case asset.USDTMarginedFutures:
+		return "UMFUTURE", nil
+	case asset.CoinMarginedFutures:
+		return "CMFUTURE", nil
+	}
+	return "", fmt.Errorf("%w %v", withdraw.ErrTransferWalletUnsupported, a)
+}
+
+// subAccountTransferWallet returns the sub-account transfer wallet of an
+// asset
+func subAccountTransferWallet(a asset.Item) (string, error) {
+	switch a {
+	case asset.Spot:
+		return "SPOT", nil
+	case asset.Margin:
+		return "MARGIN", nil
+	case asset.USDTMarginedFutures:
+		return "USDT_FUTURE", nil
+	case asset.CoinMarginedFutures:
+		return "COIN_FUTURE", nil
+	}
+	return "", fmt.Errorf("%w %v", withdraw.ErrTransferWalletUnsupported, a)
+}
+
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (b *Binance) GetFeeByType(ctx context.Context, feeBuilder *exchange.FeeBuilder) (float64, error) {
 	if feeBuilder == nil {
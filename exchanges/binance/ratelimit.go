@@ -29,6 +29,20 @@ const (
 	uFuturesOrderRequestRate = 1200
 )
 
+// Binance rate limit budgets
+const (
+	spotBudget           = "spot"
+	spotOrdersBudget     = "spotOrders"
+	uFuturesBudget       = "uFutures"
+	uFuturesOrdersBudget = "uFuturesOrders"
+	cFuturesBudget       = "cFutures"
+	cFuturesOrdersBudget = "cFuturesOrders"
+
+	// usedWeightHeader reports the request weight used within the current
+	// minute
+	usedWeightHeader = "X-Mbx-Used-Weight-1m"
+)
+
 // Binance Spot rate limits
 const (
 	spotDefaultRate request.EndpointLimit = iota
@@ -106,149 +120,193 @@ type RateLimit struct {
 
 // Limit executes rate limiting functionality for Binance
 func (r *RateLimit) Limit(ctx context.Context, f request.EndpointLimit) error {
+	budget, tokens := endpointWeight(f)
 	var limiter *rate.Limiter
-	var tokens int
+	switch budget {
+	case spotOrdersBudget:
+		limiter = r.SpotOrdersRate
+	case uFuturesBudget:
+		limiter = r.UFuturesRate
+	case uFuturesOrdersBudget:
+		limiter = r.UFuturesOrdersRate
+	case cFuturesBudget:
+		limiter = r.CFuturesRate
+	case cFuturesOrdersBudget:
+		limiter = r.CFuturesOrdersRate
+	default:
+		limiter = r.SpotRate
+	}
+
+	var finalDelay time.Duration
+	var reserves = make([]*rate.Reservation, tokens)
+	for i := 0; i < tokens; i++ {
+		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
+		// which would otherwise allow the rate limit to be exceeded over short periods
+		reserves[i] = limiter.Reserve()
+		finalDelay = reserves[i].Delay()
+	}
+
+	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
+		// Cancel all potential reservations to free up rate limiter if deadline
+		// is exceeded.
+		for x := range reserves {
+			reserves[x].Cancel()
+		}
+		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
+			finalDelay,
+			context.DeadlineExceeded)
+	}
+
+	time.Sleep(finalDelay)
+	return nil
+}
+
+// SetRateLimit returns the rate limit for the exchange
+func SetRateLimit() *RateLimit {
+	return &RateLimit{
+		SpotRate:           request.NewRateLimit(spotInterval, spotRequestRate),
+		SpotOrdersRate:     request.NewRateLimit(spotOrderInterval, spotOrderRequestRate),
+		UFuturesRate:       request.NewRateLimit(uFuturesInterval, uFuturesRequestRate),
+		UFuturesOrdersRate: request.NewRateLimit(uFuturesOrderInterval, uFuturesOrderRequestRate),
+		CFuturesRate:       request.NewRateLimit(cFuturesInterval, cFuturesRequestRate),
+		CFuturesOrdersRate: request.NewRateLimit(cFuturesOrderInterval, cFuturesOrderRequestRate),
+	}
+}
+
+// endpointWeight returns the budget an endpoint spends and its weight
+func endpointWeight(f request.EndpointLimit) (string, int) {
 	switch f {
 	case spotDefaultRate:
-		limiter, tokens = r.SpotRate, 1
+		return spotBudget, 1
 	case spotOrderbookTickerAllRate,
 		spotSymbolPriceAllRate:
-		limiter, tokens = r.SpotRate, 2
+		return spotBudget, 2
 	case spotHistoricalTradesRate,
 		spotOrderbookDepth500Rate:
-		limiter, tokens = r.SpotRate, 5
+		return spotBudget, 5
 	case spotOrderbookDepth1000Rate,
 		spotAccountInformationRate,
 		spotExchangeInfo:
-		limiter, tokens = r.SpotRate, 10
+		return spotBudget, 10
 	case spotPriceChangeAllRate:
-		limiter, tokens = r.SpotRate, 40
+		return spotBudget, 40
 	case spotOrderbookDepth5000Rate:
-		limiter, tokens = r.SpotRate, 50
+		return spotBudget, 50
 	case spotOrderRate:
-		limiter, tokens = r.SpotOrdersRate, 1
+		return spotOrdersBudget, 1
 	case spotOrderQueryRate:
-		limiter, tokens = r.SpotOrdersRate, 2
+		return spotOrdersBudget, 2
 	case spotOpenOrdersSpecificRate:
-		limiter, tokens = r.SpotOrdersRate, 3
+		return spotOrdersBudget, 3
 	case spotAllOrdersRate:
-		limiter, tokens = r.SpotOrdersRate, 10
+		return spotOrdersBudget, 10
 	case spotOpenOrdersAllRate:
-		limiter, tokens = r.SpotOrdersRate, 40
+		return spotOrdersBudget, 40
 	case uFuturesDefaultRate,
 		uFuturesKline100Rate:
-		limiter, tokens = r.UFuturesRate, 1
+		return uFuturesBudget, 1
 	case uFuturesOrderbook50Rate,
 		uFuturesKline500Rate,
 		uFuturesOrderbookTickerAllRate:
-		limiter, tokens = r.UFuturesRate, 2
+		return uFuturesBudget, 2
 	case uFuturesOrderbook100Rate,
 		uFuturesKline1000Rate,
 		uFuturesAccountInformationRate:
-		limiter, tokens = r.UFuturesRate, 5
+		return uFuturesBudget, 5
 	case uFuturesOrderbook500Rate,
 		uFuturesKlineMaxRate:
-		limiter, tokens = r.UFuturesRate, 10
+		return uFuturesBudget, 10
 	case uFuturesOrderbook1000Rate,
 		uFuturesHistoricalTradesRate:
-		limiter, tokens = r.UFuturesRate, 20
+		return uFuturesBudget, 20
 	case uFuturesTickerPriceHistoryRate:
-		limiter, tokens = r.UFuturesRate, 40
+		return uFuturesBudget, 40
 	case uFuturesOrdersDefaultRate:
-		limiter, tokens = r.UFuturesOrdersRate, 1
+		return uFuturesOrdersBudget, 1
 	case uFuturesBatchOrdersRate,
 		uFuturesGetAllOrdersRate:
-		limiter, tokens = r.UFuturesOrdersRate, 5
+		return uFuturesOrdersBudget, 5
 	case uFuturesCountdownCancelRate:
-		limiter, tokens = r.UFuturesOrdersRate, 10
+		return uFuturesOrdersBudget, 10
 	case uFuturesCurrencyForceOrdersRate,
 		uFuturesSymbolOrdersRate:
-		limiter, tokens = r.UFuturesOrdersRate, 20
+		return uFuturesOrdersBudget, 20
 	case uFuturesIncomeHistoryRate:
-		limiter, tokens = r.UFuturesOrdersRate, 30
+		return uFuturesOrdersBudget, 30
 	case uFuturesPairOrdersRate,
 		uFuturesGetAllOpenOrdersRate:
-		limiter, tokens = r.UFuturesOrdersRate, 40
+		return uFuturesOrdersBudget, 40
 	case uFuturesAllForceOrdersRate:
-		limiter, tokens = r.UFuturesOrdersRate, 50
+		return uFuturesOrdersBudget, 50
 	case cFuturesKline100Rate:
-		limiter, tokens = r.CFuturesRate, 1
+		return cFuturesBudget, 1
 	case cFuturesKline500Rate,
 		cFuturesOrderbookTickerAllRate:
-		limiter, tokens = r.CFuturesRate, 2
+		return cFuturesBudget, 2
 	case cFuturesKline1000Rate,
 		cFuturesAccountInformationRate:
-		limiter, tokens = r.CFuturesRate, 5
+		return cFuturesBudget, 5
 	case cFuturesKlineMaxRate,
 		cFuturesIndexMarkPriceRate:
-		limiter, tokens = r.CFuturesRate, 10
+		return cFuturesBudget, 10
 	case cFuturesHistoricalTradesRate,
 		cFuturesCurrencyForceOrdersRate:
-		limiter, tokens = r.CFuturesRate, 20
+		return cFuturesBudget, 20
 	case cFuturesTickerPriceHistoryRate:
-		limiter, tokens = r.CFuturesRate, 40
+		return cFuturesBudget, 40
 	case cFuturesAllForceOrdersRate:
-		limiter, tokens = r.CFuturesRate, 50
+		return cFuturesBudget, 50
 	case cFuturesOrdersDefaultRate:
-		limiter, tokens = r.CFuturesOrdersRate, 1
+		return cFuturesOrdersBudget, 1
 	case cFuturesBatchOrdersRate,
 		cFuturesGetAllOpenOrdersRate:
-		limiter, tokens = r.CFuturesOrdersRate, 5
+		return cFuturesOrdersBudget, 5
 	case cFuturesCancelAllOrdersRate:
-		limiter, tokens = r.CFuturesOrdersRate, 10
+		return cFuturesOrdersBudget, 10
 	case cFuturesIncomeHistoryRate,
 		cFuturesSymbolOrdersRate:
-		limiter, tokens = r.CFuturesOrdersRate, 20
+		return cFuturesOrdersBudget, 20
 	case cFuturesPairOrdersRate:
-		limiter, tokens = r.CFuturesOrdersRate, 40
+		return cFuturesOrdersBudget, 40
 	case cFuturesOrderbook50Rate:
-		limiter, tokens = r.CFuturesRate, 2
+		return cFuturesBudget, 2
 	case cFuturesOrderbook100Rate:
-		limiter, tokens = r.CFuturesRate, 5
+		return cFuturesBudget, 5
 	case cFuturesOrderbook500Rate:
-		limiter, tokens = r.CFuturesRate, 10
+		return cFuturesBudget, 10
 	case cFuturesOrderbook1000Rate:
-		limiter, tokens = r.CFuturesRate, 20
+		return cFuturesBudget, 20
 	case cFuturesDefaultRate:
-		limiter, tokens = r.CFuturesRate, 1
+		return cFuturesBudget, 1
 	default:
-		limiter, tokens = r.SpotRate, 1
+		return spotBudget, 1
 	}
+}
 
-	var finalDelay time.Duration
-	var reserves = make([]*rate.Reservation, tokens)
-	for i := 0; i < tokens; i++ {
-		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
-		// which would otherwise allow the rate limit to be exceeded over short periods
-		reserves[i] = limiter.Reserve()
-		finalDelay = reserves[i].Delay()
+// weightBudgets returns the request weight budgets used when adaptive rate
+// limiting is enabled. Order rate limits are not reported as weight and are
+// handled by the static rate limiter
+func weightBudgets() []request.WeightBudget {
+	usage := request.UsedWeightHeader(usedWeightHeader)
+	return []request.WeightBudget{
+		{Name: spotBudget, Capacity: spotRequestRate, Interval: spotInterval, Usage: usage},
+		{Name: uFuturesBudget, Capacity: uFuturesRequestRate, Interval: uFuturesInterval, Usage: usage},
+		{Name: cFuturesBudget, Capacity: cFuturesRequestRate, Interval: cFuturesInterval, Usage: usage},
 	}
-
-	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
-		// Cancel all potential reservations to free up rate limiter if deadline
-		// is exceeded.
-		for x := range reserves {
-			reserves[x].Cancel()
-		}
-		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
-			finalDelay,
-			context.DeadlineExceeded)
-	}
-
-	time.Sleep(finalDelay)
-	return nil
 }
 
-// SetRateLimit returns the rate limit for the exchange
-func SetRateLimit() *RateLimit {
-	return &RateLimit{
-		SpotRate:           request.NewRateLimit(spotInterval, spotRequestRate),
-		SpotOrdersRate:     request.NewRateLimit(spotOrderInterval, spotOrderRequestRate),
-		UFuturesRate:       request.NewRateLimit(uFuturesInterval, uFuturesRequestRate),
-		UFuturesOrdersRate: request.NewRateLimit(uFuturesOrderInterval, uFuturesOrderRequestRate),
-		CFuturesRate:       request.NewRateLimit(cFuturesInterval, cFuturesRequestRate),
-		CFuturesOrdersRate: request.NewRateLimit(cFuturesOrderInterval, cFuturesOrderRequestRate),
+// endpointWeights returns the weight of each endpoint which spends a request
+// weight budget
+func endpointWeights() map[request.EndpointLimit]request.EndpointWeight {
+	weights := make(map[request.EndpointLimit]request.EndpointWeight)
+	for f := spotDefaultRate; f <= cFuturesOrdersDefaultRate; f++ {
+		budget, weight := endpointWeight(f)
+		switch budget {
+		case spotBudget, uFuturesBudget, cFuturesBudget:
+			weights[f] = request.EndpointWeight{Budget: budget, Weight: weight}
+		}
 	}
+	return weights
 }
 
 func bestPriceLimit(symbol string) request.EndpointLimit {
@@ -3,6 +3,7 @@ package binance
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -76,3 +77,52 @@ func TestRateLimit_LimitStatic(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointWeights(t *testing.T) {
+	t.Parallel()
+	l, err := request.NewWeightedRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	weights := endpointWeights()
+	if w := weights[spotOrderbookDepth5000Rate]; w.Budget != spotBudget || w.Weight != 50 {
+		t.Errorf("received: '%+v' but expected: '%+v'", w, request.EndpointWeight{Budget: spotBudget, Weight: 50})
+	}
+	if w := weights[cFuturesAllForceOrdersRate]; w.Budget != cFuturesBudget || w.Weight != 50 {
+		t.Errorf("received: '%+v' but expected: '%+v'", w, request.EndpointWeight{Budget: cFuturesBudget, Weight: 50})
+	}
+	if _, ok := weights[spotOrderRate]; ok {
+		t.Error("expected order rate limits to use the static rate limiter")
+	}
+	for _, f := range []request.EndpointLimit{spotDefaultRate, spotOrderRate} {
+		if err = l.Limit(context.Background(), f); !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+}
+
+func TestUpdateWsAPIUsage(t *testing.T) {
+	t.Parallel()
+	l, err := request.NewWeightedRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var bi Binance
+	bi.Requester, err = request.New("test", &http.Client{}, request.WithLimiter(l))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	bi.updateWsAPIUsage([]WsAPIRateLimit{
+		{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 10, Limit: 50, Count: 50},
+		{RateLimitType: "REQUEST_WEIGHT", Interval: "MINUTE", IntervalNum: 1, Limit: spotRequestRate, Count: spotRequestRate},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = l.Limit(ctx, spotDefaultRate)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+	if err = l.Limit(context.Background(), uFuturesDefaultRate); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
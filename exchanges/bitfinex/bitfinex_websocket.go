@@ -1474,15 +1474,7 @@ func (b *Bitfinex) WsUpdateOrderbook(p currency.Pair, assetType asset.Item, book
 	if checkme.Sequence+1 == sequenceNo {
 		// Sequence numbers get dropped, if checksum is not in line with
 		// sequence, do not check.
-		ob, err := b.Websocket.Orderbook.GetOrderbook(p, assetType)
-		if err != nil {
-			return fmt.Errorf("cannot calculate websocket checksum: book not found for %s %s %w",
-				p,
-				assetType,
-				err)
-		}
-
-		err = validateCRC32(ob, checkme.Token)
+		err := b.Websocket.Orderbook.VerifyChecksum(p, assetType, uint32(checkme.Token))
 		if err != nil {
 			return err
 		}
@@ -1863,6 +1855,12 @@ func makeRequestInterface(channelName string, data interface{}) []interface{} {
 	return []interface{}{0, channelName, nil, data}
 }
 
+// validateOrderbookChecksum validates an orderbook against a checksum, called
+// by the websocket orderbook buffer
+func validateOrderbookChecksum(book *orderbook.Base, token uint32) error {
+	return validateCRC32(book, int(token))
+}
+
 func validateCRC32(book *orderbook.Base, token int) error {
 	// Order ID's need to be sub-sorted in ascending order, this needs to be
 	// done on the main book to ensure that we do not cut price levels out below
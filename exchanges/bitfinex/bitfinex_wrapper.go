@@ -217,7 +217,10 @@ func (b *Bitfinex) Setup(exch *config.Exchange) error {
 		Features:              &b.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferConfig: buffer.Config{
 			UpdateEntriesByID: true,
+			Checksum:          validateOrderbookChecksum,
+			SeparateChecksum:  true,
 		},
+		OrderEntryByDefault: true,
 	})
 	if err != nil {
 		return err
@@ -647,39 +650,42 @@ func (b *Bitfinex) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Sub
 
 	var orderID string
 	status := order.New
-	if b.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		orderID, err = b.WsNewOrder(&WsNewOrderRequest{
+	err = b.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
+		var wsErr error
+		orderID, wsErr = b.WsNewOrder(&WsNewOrderRequest{
 			CustomID: b.Websocket.AuthConn.GenerateMessageID(false),
 			Type:     o.Type.String(),
 			Symbol:   fpair.String(),
 			Amount:   o.Amount,
 			Price:    o.Price,
 		})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		var response Order
-		b.appendOptionalDelimiter(&fpair)
+		return wsErr
+	}, func() error {
+		restPair := fpair
+		b.appendOptionalDelimiter(&restPair)
 		orderType := o.Type.Lower()
 		if o.AssetType == asset.Spot {
 			orderType = "exchange " + orderType
 		}
-		response, err = b.NewOrder(ctx,
-			fpair.String(),
+		response, restErr := b.NewOrder(ctx,
+			restPair.String(),
 			orderType,
 			o.Amount,
 			o.Price,
 			o.Side == order.Buy,
 			false)
-		if err != nil {
-			return nil, err
+		if restErr != nil {
+			return restErr
 		}
 		orderID = strconv.FormatInt(response.ID, 10)
 
 		if response.RemainingAmount == 0 {
 			status = order.Filled
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	resp, err := o.DeriveSubmitResponse(orderID)
 	if err != nil {
@@ -730,12 +736,12 @@ func (b *Bitfinex) CancelOrder(ctx context.Context, o *order.Cancel) error {
 	if err != nil {
 		return err
 	}
-	if b.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		err = b.WsCancelOrder(orderIDInt)
-	} else {
-		_, err = b.CancelExistingOrder(ctx, orderIDInt)
-	}
-	return err
+	return b.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
+		return b.WsCancelOrder(orderIDInt)
+	}, func() error {
+		_, err := b.CancelExistingOrder(ctx, orderIDInt)
+		return err
+	})
 }
 
 // CancelBatchOrders cancels an orders by their corresponding ID numbers
@@ -745,12 +751,10 @@ func (b *Bitfinex) CancelBatchOrders(ctx context.Context, o []order.Cancel) (ord
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitfinex) CancelAllOrders(ctx context.Context, _ *order.Cancel) (order.CancelAllResponse, error) {
-	var err error
-	if b.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		err = b.WsCancelAllOrders()
-	} else {
-		_, err = b.CancelAllExistingOrders(ctx)
-	}
+	err := b.Websocket.OrderEntry(ctx, stream.CancelAllOrdersAction, b.WsCancelAllOrders, func() error {
+		_, err := b.CancelAllExistingOrders(ctx)
+		return err
+	})
 	return order.CancelAllResponse{}, err
 }
 
@@ -390,6 +390,20 @@ func TestCancelAllOrdersAfterTime(t *testing.T) {
 	}
 }
 
+func TestCancelAllOrdersAfter(t *testing.T) {
+	t.Parallel()
+	if areTestAPIKeysSet() && !canManipulateRealOrders {
+		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
+	}
+	err := b.CancelAllOrdersAfter(context.Background(), time.Minute)
+	if !areTestAPIKeysSet() && err == nil {
+		t.Error("Expecting an error when no keys are set")
+	}
+	if areTestAPIKeysSet() && err != nil {
+		t.Errorf("Could not set cancel all orders after: %v", err)
+	}
+}
+
 func TestClosePosition(t *testing.T) {
 	t.Parallel()
 	_, err := b.ClosePosition(context.Background(), OrderClosePositionParams{})
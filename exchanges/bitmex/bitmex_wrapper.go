@@ -738,6 +738,15 @@ func (b *Bitmex) CancelAllOrders(ctx context.Context, _ *order.Cancel) (order.Ca
 	return cancelAllOrdersResponse, nil
 }
 
+// CancelAllOrdersAfter cancels all orders when not refreshed within the
+// timeout. A zero timeout disables the timer
+func (b *Bitmex) CancelAllOrdersAfter(ctx context.Context, timeout time.Duration) error {
+	_, err := b.CancelAllOrdersAfterTime(ctx, OrderCancelAllAfterParams{
+		Timeout: float64(timeout.Milliseconds()),
+	})
+	return err
+}
+
 // GetOrderInfo returns order information based on order ID
 func (b *Bitmex) GetOrderInfo(ctx context.Context, orderID string, pair currency.Pair, assetType asset.Item) (order.Detail, error) {
 	var orderDetail order.Detail
@@ -619,6 +619,18 @@ func TestCancelAllAfter(t *testing.T) {
 	}
 }
 
+func TestCancelAllOrdersAfter(t *testing.T) {
+	t.Parallel()
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping test, either api keys are unset or canManipulateRealOrders is false")
+	}
+
+	err := b.CancelAllOrdersAfter(context.Background(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() || !canManipulateRealOrders {
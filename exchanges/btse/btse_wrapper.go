@@ -111,6 +111,7 @@ func (b *BTSE) SetDefaults() {
 				FiatDepositFee:      true,
 				FiatWithdrawalFee:   true,
 				CryptoWithdrawalFee: true,
+				DeadMansSwitch:      true,
 			},
 			WebsocketCapabilities: protocol.Features{
 				OrderbookFetching: true,
@@ -613,6 +614,12 @@ func (b *BTSE) CancelAllOrders(ctx context.Context, orderCancellation *order.Can
 	return resp, nil
 }
 
+// CancelAllOrdersAfter cancels all orders when not refreshed within the
+// timeout. A zero timeout disables the timer
+func (b *BTSE) CancelAllOrdersAfter(ctx context.Context, timeout time.Duration) error {
+	return b.CancelAllAfter(ctx, int(timeout.Milliseconds()))
+}
+
 func orderIntToType(i int) order.Type {
 	if i == 77 {
 		return order.Market
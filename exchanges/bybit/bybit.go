@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -57,6 +58,8 @@ const (
 	// Account asset endpoint
 	bybitGetDepositAddress = "/asset/v1/private/deposit/address"
 	bybitWithdrawFund      = "/asset/v1/private/withdraw"
+	bybitInternalTransfer  = "/asset/v1/private/transfer"
+	bybitSubMemberTransfer = "/asset/v1/private/sub-member/transfer"
 )
 
 // GetAllSpotPairs gets all pairs on the exchange
@@ -788,6 +791,71 @@ func (by *Bybit) WithdrawFund(ctx context.Context, coin, chain, address, tag, am
 	return resp.Data.ID, by.SendAuthHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, bybitWithdrawFund, nil, params, &resp, privateSpotRate)
 }
 
+// InternalTransfer transfers a coin between the wallets of an account. Account
+// types are SPOT, CONTRACT, INVESTMENT and OPTION
+func (by *Bybit) InternalTransfer(ctx context.Context, coin, fromAccountType, toAccountType string, amount float64) (string, error) {
+	if coin == "" {
+		return "", errInvalidCoin
+	}
+	if fromAccountType == "" || toAccountType == "" {
+		return "", errInvalidAccountType
+	}
+	if amount <= 0 {
+		return "", errInvalidQuantity
+	}
+	transferID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	resp := struct {
+		Result struct {
+			TransferID string `json:"transfer_id"`
+		} `json:"result"`
+		Error
+	}{}
+	params := make(map[string]interface{})
+	params["transfer_id"] = transferID.String()
+	params["coin"] = strings.ToUpper(coin)
+	params["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	params["from_account_type"] = fromAccountType
+	params["to_account_type"] = toAccountType
+	return resp.Result.TransferID, by.SendAuthHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, bybitInternalTransfer, nil, params, &resp, privateSpotRate)
+}
+
+// SubMemberTransfer transfers a coin between the master account and a
+// sub-account. Deposit transfers to the sub-account and withdraws otherwise
+func (by *Bybit) SubMemberTransfer(ctx context.Context, coin string, subUserID int64, amount float64, deposit bool) (string, error) {
+	if coin == "" {
+		return "", errInvalidCoin
+	}
+	if subUserID <= 0 {
+		return "", errInvalidSubUserID
+	}
+	if amount <= 0 {
+		return "", errInvalidQuantity
+	}
+	transferID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	resp := struct {
+		Result struct {
+			TransferID string `json:"transfer_id"`
+		} `json:"result"`
+		Error
+	}{}
+	params := make(map[string]interface{})
+	params["transfer_id"] = transferID.String()
+	params["coin"] = strings.ToUpper(coin)
+	params["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	params["sub_user_id"] = subUserID
+	params["type"] = "OUT"
+	if deposit {
+		params["type"] = "IN"
+	}
+	return resp.Result.TransferID, by.SendAuthHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, bybitSubMemberTransfer, nil, params, &resp, privateSpotRate)
+}
+
 // SendHTTPRequest sends an unauthenticated request
 func (by *Bybit) SendHTTPRequest(ctx context.Context, ePath exchange.URL, path string, f request.EndpointLimit, result UnmarshalTo) error {
 	endpointPath, err := by.API.Endpoints.GetURL(ePath)
@@ -328,6 +328,41 @@ func TestWithdrawFund(t *testing.T) {
 	}
 }
 
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		request  withdraw.TransferRequest
+		expected error
+	}{
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Margin}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.USDTMarginedFutures, To: asset.CoinMarginedFutures}, withdraw.ErrTransferSameWallet},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Spot, FromSubAccount: "1", ToSubAccount: "2"}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.USDTMarginedFutures, ToSubAccount: "1"}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Spot, ToSubAccount: "sub"}, errInvalidSubUserID},
+	} {
+		tt.request.Exchange = b.Name
+		tt.request.Currency = currency.USDT
+		tt.request.Amount = 1
+		_, err := b.Transfer(context.Background(), &tt.request)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("received: '%v' but expected: '%v'", err, tt.expected)
+		}
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("skipping test: api keys not set or canManipulateRealOrders false")
+	}
+	_, err := b.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: b.Name,
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.USDTMarginedFutures,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 // test cases for WS SPOT
 
 func TestWsSubscription(t *testing.T) {
@@ -33,6 +33,8 @@ var (
 	errInvalidOrderFilter        = errors.New("orderFilter can't be empty or missing")
 	errInvalidCategory           = errors.New("invalid category")
 	errInvalidCoin               = errors.New("coin can't be empty")
+	errInvalidAccountType        = errors.New("account type can't be empty")
+	errInvalidSubUserID          = errors.New("sub user ID must be greater than zero")
 
 	errStopOrderOrOrderLinkIDMissing = errors.New("atleast one should be present among stopOrderID and orderLinkID")
 	errOrderOrOrderLinkIDMissing     = errors.New("atleast one should be present among orderID and orderLinkID")
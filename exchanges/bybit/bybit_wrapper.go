@@ -192,6 +192,11 @@ func (by *Bybit) Setup(exch *config.Exchange) error {
 		return err
 	}
 
+	err = by.SetupAdaptiveRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if err != nil {
+		return err
+	}
+
 	wsRunningEndpoint, err := by.API.Endpoints.GetURL(exchange.WebsocketSpot)
 	if err != nil {
 		return err
@@ -1419,6 +1424,65 @@ func (by *Bybit) WithdrawFiatFundsToInternationalBank(ctx context.Context, withd
 	return nil, common.ErrFunctionNotSupported
 }
 
+// Transfer moves funds between the spot and derivatives wallets of an
+// account, or between the same wallet of the master account and a
+// sub-account identified by its user ID
+func (by *Bybit) Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if r.FromSubAccount == "" && r.ToSubAccount == "" {
+		from, err := transferAccountType(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := transferAccountType(r.To)
+		if err != nil {
+			return nil, err
+		}
+		if from == to {
+			return nil, fmt.Errorf("%w, %v and %v share the %s account", withdraw.ErrTransferSameWallet, r.From, r.To, from)
+		}
+		id, err := by.InternalTransfer(ctx, r.Currency.String(), from, to, r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{ID: id}, nil
+	}
+	if r.FromSubAccount != "" && r.ToSubAccount != "" {
+		return nil, fmt.Errorf("%w between sub-accounts", withdraw.ErrTransferWalletUnsupported)
+	}
+	if r.From != r.To {
+		return nil, fmt.Errorf("%w %v to %v with a sub-account", withdraw.ErrTransferWalletUnsupported, r.From, r.To)
+	}
+	subAccount := r.ToSubAccount
+	if subAccount == "" {
+		subAccount = r.FromSubAccount
+	}
+	subUserID, err := strconv.ParseInt(subAccount, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidSubUserID, subAccount)
+	}
+	id, err := by.SubMemberTransfer(ctx, r.Currency.String(), subUserID, r.Amount, r.ToSubAccount != "")
+	if err != nil {
+		return nil, err
+	}
+	return &withdraw.ExchangeResponse{ID: id}, nil
+}
+
+// transferAccountType returns the transfer account type of an asset
+func transferAccountType(a asset.Item) (string, error) {
+	switch a {
+	case asset.Spot:
+		return "SPOT", nil
+	case asset.CoinMarginedFutures, asset.USDTMarginedFutures, asset.Futures:
+		return "CONTRACT", nil
+	case asset.USDCMarginedFutures:
+		return "OPTION", nil
+	}
+	return "", fmt.Errorf("%w %v", withdraw.ErrTransferWalletUnsupported, a)
+}
+
 // GetActiveOrders retrieves any orders that are active/open
 func (by *Bybit) GetActiveOrders(ctx context.Context, req *order.GetOrdersRequest) (order.FilteredOrders, error) {
 	err := req.Validate()
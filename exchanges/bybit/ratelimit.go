@@ -148,6 +148,55 @@ const (
 	usdcGetPredictedFundingRate
 )
 
+// Bybit rate limit budgets, each shared by the endpoints of a rate limit group
+const (
+	spotBudget                    = "spot"
+	privateSpotBudget             = "privateSpot"
+	cFuturesBudget                = "cFutures"
+	cFuturesOrderBudget           = "cFuturesOrder"
+	cFuturesOrderListBudget       = "cFuturesOrderList"
+	cFuturesExecutionBudget       = "cFuturesExecution"
+	cFuturesPositionBudget        = "cFuturesPosition"
+	cFuturesPositionListBudget    = "cFuturesPositionList"
+	cFuturesFundingBudget         = "cFuturesFunding"
+	cFuturesWalletBudget          = "cFuturesWallet"
+	cFuturesAccountBudget         = "cFuturesAccount"
+	uFuturesBudget                = "uFutures"
+	uFuturesOrderBudget           = "uFuturesOrder"
+	uFuturesPositionBudget        = "uFuturesPosition"
+	uFuturesPositionListBudget    = "uFuturesPositionList"
+	uFuturesOrderListBudget       = "uFuturesOrderList"
+	uFuturesFundingBudget         = "uFuturesFunding"
+	futuresBudget                 = "futures"
+	futuresOrderBudget            = "futuresOrder"
+	futuresOrderListBudget        = "futuresOrderList"
+	futuresExecutionBudget        = "futuresExecution"
+	futuresPositionBudget         = "futuresPosition"
+	futuresPositionListBudget     = "futuresPositionList"
+	usdcPublicBudget              = "usdcPublic"
+	usdcCancelAllOrderBudget      = "usdcCancelAllOrder"
+	usdcPlaceOrderBudget          = "usdcPlaceOrder"
+	usdcModifyOrderBudget         = "usdcModifyOrder"
+	usdcCancelOrderBudget         = "usdcCancelOrder"
+	usdcGetOrderBudget            = "usdcGetOrder"
+	usdcGetOrderHistoryBudget     = "usdcGetOrderHistory"
+	usdcGetTradeHistoryBudget     = "usdcGetTradeHistory"
+	usdcGetTransactionBudget      = "usdcGetTransaction"
+	usdcGetWalletBudget           = "usdcGetWallet"
+	usdcGetAssetBudget            = "usdcGetAsset"
+	usdcGetMarginBudget           = "usdcGetMargin"
+	usdcGetPositionBudget         = "usdcGetPosition"
+	usdcSetLeverageBudget         = "usdcSetLeverage"
+	usdcGetSettlementBudget       = "usdcGetSettlement"
+	usdcSetRiskBudget             = "usdcSetRisk"
+	usdcGetPredictedFundingBudget = "usdcGetPredictedFunding"
+
+	// limitHeader and limitStatusHeader report the request limit of an
+	// endpoint's rate limit group and the requests remaining within it
+	limitHeader       = "X-Bapi-Limit"
+	limitStatusHeader = "X-Bapi-Limit-Status"
+)
+
 // RateLimit implements the request.Limiter interface
 type RateLimit struct {
 	SpotRate                    *rate.Limiter
@@ -193,166 +242,241 @@ type RateLimit struct {
 	USDCGetPredictedFundingRate *rate.Limiter
 }
 
-// Limit executes rate limiting functionality for Binance
+// Limit executes rate limiting functionality for Bybit
 func (r *RateLimit) Limit(ctx context.Context, f request.EndpointLimit) error {
-	var limiter *rate.Limiter
-	var tokens int
+	budget, tokens := endpointWeight(f)
+	limiter := r.budgetLimiter(budget)
+
+	var finalDelay time.Duration
+	var reserves = make([]*rate.Reservation, tokens)
+	for i := 0; i < tokens; i++ {
+		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
+		// which would otherwise allow the rate limit to be exceeded over short periods
+		reserves[i] = limiter.Reserve()
+		finalDelay = limiter.Reserve().Delay()
+	}
+
+	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
+		// Cancel all potential reservations to free up rate limiter if deadline
+		// is exceeded.
+		for x := range reserves {
+			reserves[x].Cancel()
+		}
+		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
+			finalDelay,
+			context.DeadlineExceeded)
+	}
+
+	time.Sleep(finalDelay)
+	return nil
+}
+
+// budgetLimiter returns the rate limiter of a budget
+func (r *RateLimit) budgetLimiter(budget string) *rate.Limiter {
+	switch budget {
+	case privateSpotBudget:
+		return r.PrivateSpotRate
+	case cFuturesBudget:
+		return r.CMFuturesDefaultRate
+	case cFuturesOrderBudget:
+		return r.CMFuturesOrderRate
+	case cFuturesOrderListBudget:
+		return r.CMFuturesOrderListRate
+	case cFuturesExecutionBudget:
+		return r.CMFuturesExecutionRate
+	case cFuturesPositionBudget:
+		return r.CMFuturesPositionRate
+	case cFuturesPositionListBudget:
+		return r.CMFuturesPositionListRate
+	case cFuturesFundingBudget:
+		return r.CMFuturesFundingRate
+	case cFuturesWalletBudget:
+		return r.CMFuturesWalletRate
+	case cFuturesAccountBudget:
+		return r.CMFuturesAccountRate
+	case uFuturesBudget:
+		return r.UFuturesDefaultRate
+	case uFuturesOrderBudget:
+		return r.UFuturesOrderRate
+	case uFuturesPositionBudget:
+		return r.UFuturesPositionRate
+	case uFuturesPositionListBudget:
+		return r.UFuturesPositionListRate
+	case uFuturesOrderListBudget:
+		return r.UFuturesOrderListRate
+	case uFuturesFundingBudget:
+		return r.UFuturesFundingRate
+	case futuresBudget:
+		return r.FuturesDefaultRate
+	case futuresOrderBudget:
+		return r.FuturesOrderRate
+	case futuresOrderListBudget:
+		return r.FuturesOrderListRate
+	case futuresExecutionBudget:
+		return r.FuturesExecutionRate
+	case futuresPositionBudget:
+		return r.FuturesPositionRate
+	case futuresPositionListBudget:
+		return r.FuturesPositionListRate
+	case usdcPublicBudget:
+		return r.USDCPublic
+	case usdcCancelAllOrderBudget:
+		return r.USDCCancelAllOrderRate
+	case usdcPlaceOrderBudget:
+		return r.USDCPlaceOrderRate
+	case usdcModifyOrderBudget:
+		return r.USDCModifyOrderRate
+	case usdcCancelOrderBudget:
+		return r.USDCCancelOrderRate
+	case usdcGetOrderBudget:
+		return r.USDCGetOrderRate
+	case usdcGetOrderHistoryBudget:
+		return r.USDCGetOrderHistoryRate
+	case usdcGetTradeHistoryBudget:
+		return r.USDCGetTradeHistoryRate
+	case usdcGetTransactionBudget:
+		return r.USDCGetTransactionRate
+	case usdcGetWalletBudget:
+		return r.USDCGetWalletRate
+	case usdcGetAssetBudget:
+		return r.USDCGetAssetRate
+	case usdcGetMarginBudget:
+		return r.USDCGetMarginRate
+	case usdcGetPositionBudget:
+		return r.USDCGetPositionRate
+	case usdcSetLeverageBudget:
+		return r.USDCSetLeverageRate
+	case usdcGetSettlementBudget:
+		return r.USDCGetSettlementRate
+	case usdcSetRiskBudget:
+		return r.USDCSetRiskRate
+	case usdcGetPredictedFundingBudget:
+		return r.USDCGetPredictedFundingRate
+	default:
+		return r.SpotRate
+	}
+}
+
+// endpointWeight returns the budget an endpoint spends and its weight
+func endpointWeight(f request.EndpointLimit) (budget string, weight int) {
 	switch f {
 	case publicSpotRate:
-		limiter, tokens = r.SpotRate, 1
+		return spotBudget, 1
 	case privateSpotRate:
-		limiter, tokens = r.PrivateSpotRate, 1
+		return privateSpotBudget, 1
 
 	case cFuturesDefaultRate:
-		limiter, tokens = r.CMFuturesDefaultRate, 1
+		return cFuturesBudget, 1
 
 	case cFuturesCancelActiveOrderRate, cFuturesCreateConditionalOrderRate, cFuturesCancelConditionalOrderRate, cFuturesReplaceActiveOrderRate,
 		cFuturesReplaceConditionalOrderRate, cFuturesCreateOrderRate:
-		limiter, tokens = r.CMFuturesOrderRate, 1
+		return cFuturesOrderBudget, 1
 	case cFuturesCancelAllActiveOrderRate, cFuturesCancelAllConditionalOrderRate:
-		limiter, tokens = r.CMFuturesOrderRate, 10
+		return cFuturesOrderBudget, 10
 
 	case cFuturesGetActiveOrderRate, cFuturesGetConditionalOrderRate, cFuturesGetRealtimeOrderRate:
-		limiter, tokens = r.CMFuturesOrderListRate, 1
+		return cFuturesOrderListBudget, 1
 
 	case cFuturesTradeRate:
-		limiter, tokens = r.CMFuturesExecutionRate, 1
+		return cFuturesExecutionBudget, 1
 
 	case cFuturesSetLeverageRate, cFuturesUpdateMarginRate, cFuturesSetTradingRate, cFuturesSwitchPositionRate, cFuturesGetTradingFeeRate:
-		limiter, tokens = r.CMFuturesPositionRate, 1
+		return cFuturesPositionBudget, 1
 
 	case cFuturesPositionRate, cFuturesWalletBalanceRate:
-		limiter, tokens = r.CMFuturesPositionListRate, 1
+		return cFuturesPositionListBudget, 1
 
 	case cFuturesLastFundingFeeRate, cFuturesPredictFundingRate:
-		limiter, tokens = r.CMFuturesFundingRate, 1
+		return cFuturesFundingBudget, 1
 
 	case cFuturesWalletFundRecordRate, cFuturesWalletWithdrawalRate:
-		limiter, tokens = r.CMFuturesWalletRate, 1
+		return cFuturesWalletBudget, 1
 
 	case cFuturesAPIKeyInfoRate:
-		limiter, tokens = r.CMFuturesAccountRate, 1
+		return cFuturesAccountBudget, 1
 
 	case uFuturesDefaultRate:
-		limiter, tokens = r.UFuturesDefaultRate, 1
+		return uFuturesBudget, 1
 
 	case uFuturesCreateOrderRate, uFuturesCancelOrderRate, uFuturesCreateConditionalOrderRate, uFuturesCancelConditionalOrderRate:
-		limiter, tokens = r.UFuturesOrderRate, 1
+		return uFuturesOrderBudget, 1
 
 	case uFuturesCancelAllOrderRate, uFuturesCancelAllConditionalOrderRate:
-		limiter, tokens = r.UFuturesOrderRate, 10
+		return uFuturesOrderBudget, 10
 
 	case uFuturesSetLeverageRate, uFuturesSwitchMargin, uFuturesSwitchPosition, uFuturesSetMarginRate, uFuturesSetTradingStopRate, uFuturesUpdateMarginRate:
-		limiter, tokens = r.UFuturesPositionRate, 1
+		return uFuturesPositionBudget, 1
 
 	case uFuturesPositionRate, uFuturesGetClosedTradesRate, uFuturesGetTradesRate:
-		limiter, tokens = r.UFuturesPositionListRate, 1
+		return uFuturesPositionListBudget, 1
 
 	case uFuturesGetActiveOrderRate, uFuturesGetActiveRealtimeOrderRate, uFuturesGetConditionalOrderRate, uFuturesGetConditionalRealtimeOrderRate:
-		limiter, tokens = r.UFuturesOrderListRate, 1
+		return uFuturesOrderListBudget, 1
 
 	case uFuturesGetMyLastFundingFeeRate, uFuturesPredictFundingRate:
-		limiter, tokens = r.UFuturesFundingRate, 1
+		return uFuturesFundingBudget, 1
 
 	case futuresDefaultRate:
-		limiter, tokens = r.FuturesDefaultRate, 1
+		return futuresBudget, 1
 
 	case futuresCancelOrderRate, futuresCreateOrderRate, futuresReplaceOrderRate, futuresReplaceConditionalOrderRate, futuresCancelConditionalOrderRate,
 		futuresCreateConditionalOrderRate:
-		limiter, tokens = r.FuturesOrderRate, 1
+		return futuresOrderBudget, 1
 
 	case futuresCancelAllOrderRate, futuresCancelAllConditionalOrderRate:
-		limiter, tokens = r.FuturesOrderRate, 10
+		return futuresOrderBudget, 10
 
 	case futuresGetActiveOrderRate, futuresGetConditionalOrderRate, futuresGetActiveRealtimeOrderRate, futuresGetConditionalRealtimeOrderRate:
-		limiter, tokens = r.FuturesOrderListRate, 1
+		return futuresOrderListBudget, 1
 
 	case futuresGetTradeRate:
-		limiter, tokens = r.FuturesExecutionRate, 1
+		return futuresExecutionBudget, 1
 
 	case futuresSetLeverageRate, futuresUpdateMarginRate, futuresSetTradingStopRate, futuresSwitchPositionModeRate, futuresSwitchMarginRate, futuresSwitchPositionRate:
-		limiter, tokens = r.FuturesPositionRate, 1
+		return futuresPositionBudget, 1
 
 	case futuresPositionRate:
-		limiter, tokens = r.FuturesPositionListRate, 1
+		return futuresPositionListBudget, 1
 
 	case usdcPublicRate:
-		limiter, tokens = r.USDCPublic, 1
+		return usdcPublicBudget, 1
 
 	case usdcCancelAllOrderRate:
-		limiter, tokens = r.USDCCancelAllOrderRate, 1
-
+		return usdcCancelAllOrderBudget, 1
 	case usdcPlaceOrderRate:
-		limiter, tokens = r.USDCPlaceOrderRate, 1
-
+		return usdcPlaceOrderBudget, 1
 	case usdcModifyOrderRate:
-		limiter, tokens = r.USDCModifyOrderRate, 1
-
+		return usdcModifyOrderBudget, 1
 	case usdcCancelOrderRate:
-		limiter, tokens = r.USDCCancelOrderRate, 1
-
+		return usdcCancelOrderBudget, 1
 	case usdcGetOrderRate:
-		limiter, tokens = r.USDCGetOrderRate, 1
-
+		return usdcGetOrderBudget, 1
 	case usdcGetOrderHistoryRate:
-		limiter, tokens = r.USDCGetOrderHistoryRate, 1
-
+		return usdcGetOrderHistoryBudget, 1
 	case usdcGetTradeHistoryRate:
-		limiter, tokens = r.USDCGetTradeHistoryRate, 1
-
+		return usdcGetTradeHistoryBudget, 1
 	case usdcGetTransactionRate:
-		limiter, tokens = r.USDCGetTransactionRate, 1
-
+		return usdcGetTransactionBudget, 1
 	case usdcGetWalletRate:
-		limiter, tokens = r.USDCGetWalletRate, 1
-
+		return usdcGetWalletBudget, 1
 	case usdcGetAssetRate:
-		limiter, tokens = r.USDCGetAssetRate, 1
-
+		return usdcGetAssetBudget, 1
 	case usdcGetMarginRate:
-		limiter, tokens = r.USDCGetMarginRate, 1
-
+		return usdcGetMarginBudget, 1
 	case usdcGetPositionRate:
-		limiter, tokens = r.USDCGetPositionRate, 1
-
+		return usdcGetPositionBudget, 1
 	case usdcSetLeverageRate:
-		limiter, tokens = r.USDCSetLeverageRate, 1
-
+		return usdcSetLeverageBudget, 1
 	case usdcGetSettlementRate:
-		limiter, tokens = r.USDCGetSettlementRate, 1
-
+		return usdcGetSettlementBudget, 1
 	case usdcSetRiskRate:
-		limiter, tokens = r.USDCSetRiskRate, 1
-
+		return usdcSetRiskBudget, 1
 	case usdcGetPredictedFundingRate:
-		limiter, tokens = r.USDCGetPredictedFundingRate, 1
+		return usdcGetPredictedFundingBudget, 1
 
 	default:
-		limiter, tokens = r.SpotRate, 1
-	}
-
-	var finalDelay time.Duration
-	var reserves = make([]*rate.Reservation, tokens)
-	for i := 0; i < tokens; i++ {
-		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
-		// which would otherwise allow the rate limit to be exceeded over short periods
-		reserves[i] = limiter.Reserve()
-		finalDelay = limiter.Reserve().Delay()
-	}
-
-	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
-		// Cancel all potential reservations to free up rate limiter if deadline
-		// is exceeded.
-		for x := range reserves {
-			reserves[x].Cancel()
-		}
-		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
-			finalDelay,
-			context.DeadlineExceeded)
+		return spotBudget, 1
 	}
-
-	time.Sleep(finalDelay)
-	return nil
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -401,3 +525,64 @@ func SetRateLimit() *RateLimit {
 		USDCGetPredictedFundingRate: request.NewRateLimit(usdcPerpetualInterval, usdcPerpetualPrivateRate),
 	}
 }
+
+// weightBudgets returns the request budgets used when adaptive rate limiting
+// is enabled. Private endpoints report the requests remaining in their rate
+// limit group, public endpoints are handled by the static rate limiter
+func weightBudgets() []request.WeightBudget {
+	usage := request.RemainingWeightHeaders(limitHeader, limitStatusHeader)
+	return []request.WeightBudget{
+		{Name: privateSpotBudget, Capacity: spotPrivateRequestRate, Interval: spotInterval, Usage: usage},
+		{Name: cFuturesBudget, Capacity: futuresDefaultRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesOrderBudget, Capacity: futuresOrderRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesOrderListBudget, Capacity: futuresOrderListRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesExecutionBudget, Capacity: futuresExecutionRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesPositionBudget, Capacity: futuresPositionRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesPositionListBudget, Capacity: futuresPositionListRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesFundingBudget, Capacity: futuresFundingRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesWalletBudget, Capacity: futuresWalletRate, Interval: futuresInterval, Usage: usage},
+		{Name: cFuturesAccountBudget, Capacity: futuresAccountRate, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesBudget, Capacity: futuresDefaultRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesOrderBudget, Capacity: futuresOrderRate, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesPositionBudget, Capacity: futuresPositionRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesPositionListBudget, Capacity: futuresPositionListRate, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesOrderListBudget, Capacity: futuresOrderListRate, Interval: futuresInterval, Usage: usage},
+		{Name: uFuturesFundingBudget, Capacity: futuresFundingRate, Interval: futuresInterval, Usage: usage},
+		{Name: futuresBudget, Capacity: futuresDefaultRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: futuresOrderBudget, Capacity: futuresOrderRate, Interval: futuresInterval, Usage: usage},
+		{Name: futuresOrderListBudget, Capacity: futuresOrderListRate, Interval: futuresInterval, Usage: usage},
+		{Name: futuresExecutionBudget, Capacity: futuresExecutionRate, Interval: futuresInterval, Usage: usage},
+		{Name: futuresPositionBudget, Capacity: futuresPositionRateCount, Interval: futuresInterval, Usage: usage},
+		{Name: futuresPositionListBudget, Capacity: futuresPositionListRate, Interval: futuresInterval, Usage: usage},
+		{Name: usdcCancelAllOrderBudget, Capacity: usdcPerpetualCancelAllRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcPlaceOrderBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcModifyOrderBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcCancelOrderBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetOrderBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetOrderHistoryBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetTradeHistoryBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetTransactionBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetWalletBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetAssetBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetMarginBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetPositionBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcSetLeverageBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetSettlementBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcSetRiskBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+		{Name: usdcGetPredictedFundingBudget, Capacity: usdcPerpetualPrivateRate, Interval: usdcPerpetualInterval, Usage: usage},
+	}
+}
+
+// endpointWeights returns the weight of each endpoint which spends a request
+// budget
+func endpointWeights() map[request.EndpointLimit]request.EndpointWeight {
+	weights := make(map[request.EndpointLimit]request.EndpointWeight)
+	for f := publicSpotRate; f <= usdcGetPredictedFundingRate; f++ {
+		budget, weight := endpointWeight(f)
+		if budget == spotBudget || budget == usdcPublicBudget {
+			continue
+		}
+		weights[f] = request.EndpointWeight{Budget: budget, Weight: weight}
+	}
+	return weights
+}
@@ -0,0 +1,66 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestBudgetLimiter(t *testing.T) {
+	t.Parallel()
+	r := SetRateLimit()
+	for f := publicSpotRate; f <= usdcGetPredictedFundingRate; f++ {
+		budget, _ := endpointWeight(f)
+		if r.budgetLimiter(budget) == nil {
+			t.Fatalf("endpoint %d budget %s has no rate limiter", f, budget)
+		}
+	}
+}
+
+func TestEndpointWeights(t *testing.T) {
+	t.Parallel()
+	_, err := request.NewWeightedRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	weights := endpointWeights()
+	if w := weights[cFuturesCancelAllActiveOrderRate]; w.Budget != cFuturesOrderBudget || w.Weight != 10 {
+		t.Errorf("received: '%+v' but expected: '%+v'", w, request.EndpointWeight{Budget: cFuturesOrderBudget, Weight: 10})
+	}
+	if w := weights[usdcPlaceOrderRate]; w.Budget != usdcPlaceOrderBudget || w.Weight != 1 {
+		t.Errorf("received: '%+v' but expected: '%+v'", w, request.EndpointWeight{Budget: usdcPlaceOrderBudget, Weight: 1})
+	}
+	for _, f := range []request.EndpointLimit{publicSpotRate, usdcPublicRate} {
+		if _, ok := weights[f]; ok {
+			t.Errorf("expected public endpoint %d to use the static rate limiter", f)
+		}
+	}
+}
+
+func TestObserveLimitStatus(t *testing.T) {
+	t.Parallel()
+	l, err := request.NewWeightedRateLimit(weightBudgets(), endpointWeights(), SetRateLimit())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set(limitHeader, "100")
+	resp.Header.Set(limitStatusHeader, "0")
+	l.ObserveResponse(uFuturesCreateOrderRate, resp)
+
+	// The order rate limit group is exhausted, other groups are unaffected
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = l.Limit(ctx, uFuturesCancelOrderRate)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+	err = l.Limit(context.Background(), uFuturesPositionRate)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
@@ -167,6 +167,7 @@ func (c *COINUT) Setup(exch *config.Exchange) error {
 			SortBuffer:            true,
 			SortBufferByUpdateIDs: true,
 		},
+		OrderEntryByDefault: true,
 	})
 	if err != nil {
 		return err
@@ -598,7 +599,7 @@ func (c *COINUT) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Submi
 
 	var orderID string
 	status := order.New
-	if c.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+	err = c.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
 		var response *order.Detail
 		response, err = c.wsSubmitOrder(&WsSubmitOrderParameters{
 			Currency: o.Pair,
@@ -607,31 +608,32 @@ func (c *COINUT) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Submi
 			Price:    o.Price,
 		})
 		if err != nil {
-			return nil, err
+			return err
 		}
 		orderID = response.OrderID
-	} else {
+		return nil
+	}, func() error {
 		err = c.loadInstrumentsIfNotLoaded()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		var fPair currency.Pair
 		fPair, err = c.FormatExchangeCurrency(o.Pair, asset.Spot)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		currencyID := c.instrumentMap.LookupID(fPair.String())
 		if currencyID == 0 {
-			return nil, errLookupInstrumentID
+			return errLookupInstrumentID
 		}
 
 		var APIResponse interface{}
 		var clientIDInt uint64
 		clientIDInt, err = strconv.ParseUint(o.ClientID, 10, 32)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		APIResponse, err = c.NewOrder(ctx,
 			currencyID,
@@ -640,33 +642,37 @@ func (c *COINUT) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Submi
 			o.Side == order.Buy,
 			uint32(clientIDInt))
 		if err != nil {
-			return nil, err
+			return err
 		}
 		responseMap, ok := APIResponse.(map[string]interface{})
 		if !ok {
-			return nil, errors.New("unable to type assert responseMap")
+			return errors.New("unable to type assert responseMap")
 		}
 		orderType, ok := responseMap["reply"].(string)
 		if !ok {
-			return nil, errors.New("unable to type assert orderType")
+			return errors.New("unable to type assert orderType")
 		}
 		switch orderType {
 		case "order_rejected":
-			return nil, fmt.Errorf("clientOrderID: %v was rejected: %v", o.ClientID, responseMap["reasons"])
+			return fmt.Errorf("clientOrderID: %v was rejected: %v", o.ClientID, responseMap["reasons"])
 		case "order_filled":
 			orderIDResp, ok := responseMap["order_id"].(float64)
 			if !ok {
-				return nil, errors.New("unable to type assert orderID")
+				return errors.New("unable to type assert orderID")
 			}
 			orderID = strconv.FormatFloat(orderIDResp, 'f', -1, 64)
 			status = order.Filled
 		case "order_accepted":
 			orderIDResp, ok := responseMap["order_id"].(float64)
 			if !ok {
-				return nil, errors.New("unable to type assert orderID")
+				return errors.New("unable to type assert orderID")
 			}
 			orderID = strconv.FormatFloat(orderIDResp, 'f', -1, 64)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	resp, err := o.DeriveSubmitResponse(orderID)
 	if err != nil {
@@ -704,7 +710,7 @@ func (c *COINUT) CancelOrder(ctx context.Context, o *order.Cancel) error {
 
 	currencyID := c.instrumentMap.LookupID(fpair.String())
 
-	if c.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+	return c.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
 		var resp *CancelOrdersResponse
 		resp, err = c.wsCancelOrder(&WsCancelOrderParameters{
 			Currency: o.Pair,
@@ -716,7 +722,8 @@ func (c *COINUT) CancelOrder(ctx context.Context, o *order.Cancel) error {
 		if len(resp.Status) >= 1 && resp.Status[0] != "OK" {
 			return errors.New(c.Name + " - Failed to cancel order " + o.OrderID)
 		}
-	} else {
+		return nil
+	}, func() error {
 		if currencyID == 0 {
 			return errLookupInstrumentID
 		}
@@ -724,9 +731,8 @@ func (c *COINUT) CancelOrder(ctx context.Context, o *order.Cancel) error {
 		if err != nil {
 			return err
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 // CancelBatchOrders cancels an orders by their corresponding ID numbers
@@ -746,17 +752,17 @@ func (c *COINUT) CancelAllOrders(ctx context.Context, details *order.Cancel) (or
 		return cancelAllOrdersResponse, err
 	}
 	cancelAllOrdersResponse.Status = make(map[string]string)
-	if c.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+	err = c.Websocket.OrderEntry(ctx, stream.CancelAllOrdersAction, func() error {
 		openOrders, err := c.wsGetOpenOrders(details.Pair.String())
 		if err != nil {
-			return cancelAllOrdersResponse, err
+			return err
 		}
 		var ordersToCancel []WsCancelOrderParameters
 		for i := range openOrders.Orders {
 			var fpair currency.Pair
 			fpair, err = c.FormatExchangeCurrency(details.Pair, asset.Spot)
 			if err != nil {
-				return cancelAllOrdersResponse, err
+				return err
 			}
 			if openOrders.Orders[i].InstrumentID == c.instrumentMap.LookupID(fpair.String()) {
 				ordersToCancel = append(ordersToCancel, WsCancelOrderParameters{
@@ -767,25 +773,26 @@ func (c *COINUT) CancelAllOrders(ctx context.Context, details *order.Cancel) (or
 		}
 		resp, err := c.wsCancelOrders(ordersToCancel)
 		if err != nil {
-			return cancelAllOrdersResponse, err
+			return err
 		}
 		for i := range resp.Results {
 			if openOrders.Orders[i].Status[0] != "OK" {
 				cancelAllOrdersResponse.Status[strconv.FormatInt(openOrders.Orders[i].OrderID, 10)] = strings.Join(openOrders.Orders[i].Status, ",")
 			}
 		}
-	} else {
+		return nil
+	}, func() error {
 		var allTheOrders []OrderResponse
 		ids := c.instrumentMap.GetInstrumentIDs()
 		for x := range ids {
 			fpair, err := c.FormatExchangeCurrency(details.Pair, asset.Spot)
 			if err != nil {
-				return cancelAllOrdersResponse, err
+				return err
 			}
 			if ids[x] == c.instrumentMap.LookupID(fpair.String()) {
 				openOrders, err := c.GetOpenOrders(ctx, ids[x])
 				if err != nil {
-					return cancelAllOrdersResponse, err
+					return err
 				}
 				allTheOrders = append(allTheOrders, openOrders.Orders...)
 			}
@@ -803,7 +810,7 @@ func (c *COINUT) CancelAllOrders(ctx context.Context, details *order.Cancel) (or
 		if len(allTheOrdersToCancel) > 0 {
 			resp, err := c.CancelOrders(ctx, allTheOrdersToCancel)
 			if err != nil {
-				return cancelAllOrdersResponse, err
+				return err
 			}
 
 			for i := range resp.Results {
@@ -812,6 +819,10 @@ func (c *COINUT) CancelAllOrders(ctx context.Context, details *order.Cancel) (or
 				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return cancelAllOrdersResponse, err
 	}
 
 	return cancelAllOrdersResponse, nil
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
@@ -21,6 +23,9 @@ var (
 	// completely empty but an attempt at retrieving credentials was made to
 	// undertake an authenticated HTTP request.
 	ErrCredentialsAreEmpty = errors.New("credentials are empty")
+	// ErrAccountNotFound defines an error for when a named exchange account
+	// selected via context has not been loaded
+	ErrAccountNotFound = errors.New("exchange account not found")
 
 	errRequiresAPIKey            = errors.New("requires API key but default/empty one set")
 	errRequiresAPISecret         = errors.New("requires API secret but default/empty one set")
@@ -28,6 +33,7 @@ var (
 	errRequiresAPIClientID       = errors.New("requires API Client ID but default/empty one set")
 	errBase64DecodeFailure       = errors.New("base64 decode has failed")
 	errContextCredentialsFailure = errors.New("context credentials type assertion failure")
+	errAccountNameEmpty          = errors.New("exchange account name is empty")
 )
 
 // SetKey sets new key for the default credentials
@@ -142,13 +148,33 @@ func (b *Base) GetCredentials(ctx context.Context) (*account.Credentials, error)
 		return creds, nil
 	}
 
+	subAccountOverride, ok := ctx.Value(account.ContextSubAccountFlag).(string)
+	if name := account.GetAccountFromContext(ctx); name != "" {
+		b.API.credMu.RLock()
+		stored, found := b.API.accounts[name]
+		var creds account.Credentials
+		if found {
+			creds = *stored
+		}
+		b.API.credMu.RUnlock()
+		if !found {
+			return &account.Credentials{}, fmt.Errorf("%s %w: %s", b.Name, ErrAccountNotFound, name)
+		}
+		if err := b.CheckCredentials(&creds, false); err != nil {
+			return &account.Credentials{}, fmt.Errorf("%s account credentials issue: %w", name, err)
+		}
+		if ok {
+			creds.SubAccount = subAccountOverride
+		}
+		return &creds, nil
+	}
+
 	err := b.CheckCredentials(b.API.credentials, false)
 	if err != nil {
 		// NOTE: Return empty credentials on error to limit panic on websocket
 		// handling.
 		return &account.Credentials{}, err
 	}
-	subAccountOverride, ok := ctx.Value(account.ContextSubAccountFlag).(string)
 	b.API.credMu.RLock()
 	defer b.API.credMu.RUnlock()
 	creds := *b.API.credentials
@@ -223,6 +249,47 @@ func (b *Base) SetCredentials(apiKey, apiSecret, clientID, subaccount, pemKey, o
 	}
 }
 
+// SetAccountCredentials sets the credentials of a named exchange account, which
+// are used in place of the default credentials when the account name is set
+// to context via account.DeployAccountToContext
+func (b *Base) SetAccountCredentials(name string, creds *account.Credentials) error {
+	name = account.NormaliseAccountName(name)
+	if name == "" {
+		return errAccountNameEmpty
+	}
+	if creds == nil {
+		return fmt.Errorf("%w account credentials", common.ErrNilPointer)
+	}
+	cpy := *creds
+	b.API.credMu.Lock()
+	defer b.API.credMu.Unlock()
+	if b.API.CredentialsValidator.RequiresBase64DecodeSecret {
+		result, err := crypto.Base64Decode(cpy.Secret)
+		if err != nil {
+			return fmt.Errorf("%s account %s API secret %w: %s", b.Name, name, errBase64DecodeFailure, err)
+		}
+		cpy.Secret = string(result)
+	}
+	if b.API.accounts == nil {
+		b.API.accounts = make(map[string]*account.Credentials)
+	}
+	b.API.accounts[name] = &cpy
+	return nil
+}
+
+// GetAccountNames returns the sorted names of the exchange accounts loaded in
+// addition to the default credentials
+func (b *Base) GetAccountNames() []string {
+	b.API.credMu.RLock()
+	defer b.API.credMu.RUnlock()
+	names := make([]string, 0, len(b.API.accounts))
+	for name := range b.API.accounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // SetAPICredentialDefaults sets the API Credential validator defaults
 func (b *Base) SetAPICredentialDefaults() {
 	b.API.credMu.Lock()
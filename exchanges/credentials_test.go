@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 )
@@ -106,6 +107,87 @@ func TestGetCredentials(t *testing.T) {
 	}
 }
 
+func TestSetAccountCredentials(t *testing.T) {
+	t.Parallel()
+	b := Base{Name: "test"}
+	err := b.SetAccountCredentials("", &account.Credentials{})
+	if !errors.Is(err, errAccountNameEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errAccountNameEmpty)
+	}
+	err = b.SetAccountCredentials("strategy", nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	b.API.CredentialsValidator.RequiresBase64DecodeSecret = true
+	err = b.SetAccountCredentials("strategy", &account.Credentials{Secret: "%%"})
+	if !errors.Is(err, errBase64DecodeFailure) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errBase64DecodeFailure)
+	}
+	err = b.SetAccountCredentials("strategy", &account.Credentials{Key: "key", Secret: "c2VjcmV0"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if b.API.accounts["strategy"].Secret != "secret" {
+		t.Fatalf("received: '%v' but expected: '%v'", b.API.accounts["strategy"].Secret, "secret")
+	}
+	err = b.SetAccountCredentials("Another", &account.Credentials{Key: "key", Secret: "c2VjcmV0"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if names := b.GetAccountNames(); len(names) != 2 || names[0] != "another" || names[1] != "strategy" {
+		t.Fatalf("unexpected account names %v", names)
+	}
+}
+
+func TestGetAccountCredentials(t *testing.T) {
+	t.Parallel()
+	b := Base{Name: "test", LoadedByConfig: true}
+	b.API.AuthenticatedSupport = true
+	b.SetCredentials("default", "secret", "", "", "", "")
+	ctx := account.DeployAccountToContext(context.Background(), "strategy")
+	_, err := b.GetCredentials(ctx)
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrAccountNotFound)
+	}
+	err = b.SetAccountCredentials("strategy", &account.Credentials{Key: "strategykey", Secret: "secret", SubAccount: "sub"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	creds, err := b.GetCredentials(ctx)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if creds.Key != "strategykey" || creds.SubAccount != "sub" {
+		t.Fatalf("unexpected credentials %v", creds)
+	}
+	creds, err = b.GetCredentials(account.DeployAccountToContext(context.Background(), "STRATEGY"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if creds.Key != "strategykey" {
+		t.Fatalf("unexpected credentials %v", creds)
+	}
+	creds, err = b.GetCredentials(account.DeploySubAccountOverrideToContext(ctx, "override"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if creds.Key != "strategykey" || creds.SubAccount != "override" {
+		t.Fatalf("unexpected credentials %v", creds)
+	}
+	creds, err = b.GetCredentials(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if creds.Key != "default" {
+		t.Fatalf("unexpected credentials %v", creds)
+	}
+	b.API.AuthenticatedSupport = false
+	_, err = b.GetCredentials(ctx)
+	if !errors.Is(err, ErrAuthenticationSupportNotEnabled) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrAuthenticationSupportNotEnabled)
+	}
+}
+
 func TestAreCredentialsValid(t *testing.T) {
 	t.Parallel()
 	var b Base
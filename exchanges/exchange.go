@@ -26,6 +26,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 const (
@@ -58,6 +59,45 @@ func (b *Base) SetRequester(r *request.Requester) error {
 	return nil
 }
 
+// SetupAdaptiveRateLimit replaces the requester's rate limiter with a weighted
+// rate limiter when enabled in the exchange config. Endpoints without a weight
+// are limited by fallback
+func (b *Base) SetupAdaptiveRateLimit(budgets []request.WeightBudget, endpoints map[request.EndpointLimit]request.EndpointWeight, fallback request.Limiter) error {
+	if b.Config == nil || b.Config.RateLimit == nil || !b.Config.RateLimit.Adaptive {
+		return nil
+	}
+	limiter, err := request.NewWeightedRateLimit(budgets, endpoints, fallback)
+	if err != nil {
+		return err
+	}
+	if b.Config.RateLimit.Threshold != 0 {
+		err = limiter.SetThreshold(b.Config.RateLimit.Threshold)
+		if err != nil {
+			return err
+		}
+	}
+	for name, capacity := range b.Config.RateLimit.Capacities {
+		err = limiter.SetCapacity(name, capacity)
+		if err != nil {
+			return err
+		}
+	}
+	return b.Requester.SetLimiter(limiter)
+}
+
+// SetupCircuitBreaker applies the exchange config's circuit breaker settings
+// to the requester. Circuit breakers are only used when enabled in config
+func (b *Base) SetupCircuitBreaker() error {
+	if b.Config == nil || b.Config.CircuitBreaker == nil || !b.Config.CircuitBreaker.Enabled {
+		return b.Requester.DisableCircuitBreaker()
+	}
+	return b.Requester.SetCircuitBreaker(request.BreakerConfig{
+		FailureThreshold: b.Config.CircuitBreaker.FailureThreshold,
+		OpenDuration:     b.Config.CircuitBreaker.OpenDuration,
+		SuccessThreshold: b.Config.CircuitBreaker.SuccessThreshold,
+	})
+}
+
 // SetClientProxyAddress sets a proxy address for REST and websocket requests
 func (b *Base) SetClientProxyAddress(addr string) error {
 	if addr == "" {
@@ -542,6 +582,20 @@ func (b *Base) SetupDefaults(exch *config.Exchange) error {
 			exch.API.Credentials.PEMKey,
 			exch.API.Credentials.OTPSecret,
 		)
+		for i := range exch.API.Accounts {
+			creds := exch.API.Accounts[i].Credentials
+			err = b.SetAccountCredentials(exch.API.Accounts[i].Name, &account.Credentials{
+				Key:             creds.Key,
+				Secret:          creds.Secret,
+				ClientID:        creds.ClientID,
+				SubAccount:      creds.Subaccount,
+				PEMKey:          creds.PEMKey,
+				OneTimePassword: creds.OTPSecret,
+			})
+			if err != nil {
+				log.Warnf(log.ExchangeSys, "%s unable to load account: %v", b.Name, err)
+			}
+		}
 	}
 
 	if exch.HTTPTimeout <= time.Duration(0) {
@@ -553,6 +607,11 @@ func (b *Base) SetupDefaults(exch *config.Exchange) error {
 		return err
 	}
 
+	err = b.SetupCircuitBreaker()
+	if err != nil {
+		return err
+	}
+
 	if exch.CurrencyPairs == nil {
 		exch.CurrencyPairs = new(currency.PairsManager)
 	}
@@ -1411,6 +1470,19 @@ func (a *AssetWebsocketSupport) IsAssetWebsocketSupported(aType asset.Item) bool
 	return a.unsupported == nil || !a.unsupported[aType]
 }
 
+// CancelAllOrdersAfter sets an exchange side dead man's switch which cancels
+// all orders when not refreshed within the timeout. A zero timeout disables
+// it. This is overridable
+func (b *Base) CancelAllOrdersAfter(_ context.Context, _ time.Duration) error {
+	return common.ErrFunctionNotSupported
+}
+
+// Transfer moves funds between the asset wallets and sub-accounts of an
+// exchange. This is overridable
+func (b *Base) Transfer(_ context.Context, _ *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // UpdateCurrencyStates updates currency states
 func (b *Base) UpdateCurrencyStates(ctx context.Context, a asset.Item) error {
 	return common.ErrNotYetImplemented
@@ -177,6 +177,89 @@ func TestSetDefaultEndpoints(t *testing.T) {
 	}
 }
 
+func TestSetupAdaptiveRateLimit(t *testing.T) {
+	t.Parallel()
+	requester, err := request.New("rawr", common.NewHTTPClientWithTimeout(time.Second*15))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := Base{Name: "rawr", Requester: requester, Config: &config.Exchange{}}
+	budgets := []request.WeightBudget{{Name: "spot", Capacity: 1200, Interval: time.Minute}}
+	endpoints := map[request.EndpointLimit]request.EndpointWeight{request.Unset: {Budget: "spot", Weight: 1}}
+
+	// Not enabled
+	err = b.SetupAdaptiveRateLimit(budgets, endpoints, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	b.Config.RateLimit = &config.RateLimit{Adaptive: true, Threshold: 2}
+	err = b.SetupAdaptiveRateLimit(budgets, endpoints, nil)
+	if err == nil {
+		t.Fatal("expected error for invalid threshold")
+	}
+
+	b.Config.RateLimit.Threshold = 0.5
+	b.Config.RateLimit.Capacities = map[string]int{"futures": 10}
+	err = b.SetupAdaptiveRateLimit(budgets, endpoints, nil)
+	if err == nil {
+		t.Fatal("expected error for unknown budget")
+	}
+
+	b.Config.RateLimit.Capacities = map[string]int{"spot": 600}
+	err = b.SetupAdaptiveRateLimit(budgets, endpoints, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = b.Requester.InitiateRateLimit(context.Background(), request.Unset)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestSetupCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	requester, err := request.New("rawr", common.NewHTTPClientWithTimeout(time.Second*15))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := Base{Name: "rawr", Requester: requester, Config: &config.Exchange{}}
+	err = b.SetupCircuitBreaker()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if requester.EndpointHealth() != nil {
+		t.Fatal("expected circuit breaker to be disabled by default")
+	}
+
+	b.Config.CircuitBreaker = &config.CircuitBreaker{FailureThreshold: -1}
+	err = b.SetupCircuitBreaker()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	b.Config.CircuitBreaker = &config.CircuitBreaker{Enabled: true, FailureThreshold: -1}
+	err = b.SetupCircuitBreaker()
+	if err == nil {
+		t.Fatal("expected error for invalid failure threshold")
+	}
+
+	b.Config.CircuitBreaker = &config.CircuitBreaker{Enabled: true, FailureThreshold: 3, OpenDuration: time.Minute}
+	err = b.SetupCircuitBreaker()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if requester.EndpointHealth() == nil {
+		t.Fatal("expected circuit breaker to be enabled")
+	}
+
+	b.Config.CircuitBreaker.Enabled = false
+	err = b.SetupCircuitBreaker()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
 func TestSetClientProxyAddress(t *testing.T) {
 	t.Parallel()
 
@@ -1197,6 +1280,9 @@ func TestSetupDefaults(t *testing.T) {
 		HTTPTimeout: time.Duration(-1),
 		API: config.APIConfig{
 			AuthenticatedSupport: true,
+			Accounts: []config.APIAccountConfig{
+				{Name: "strategy", Credentials: config.APICredentialsConfig{Key: "key", Subaccount: "sub"}},
+			},
 		},
 	}
 
@@ -1207,6 +1293,9 @@ func TestSetupDefaults(t *testing.T) {
 	if cfg.HTTPTimeout.String() != "15s" {
 		t.Error("HTTP timeout should be set to 15s")
 	}
+	if names := b.GetAccountNames(); len(names) != 1 || names[0] != "strategy" {
+		t.Errorf("unexpected account names %v", names)
+	}
 
 	// Test custom HTTP timeout is set
 	cfg.HTTPTimeout = time.Second * 30
@@ -2448,6 +2537,22 @@ func TestUpdateCurrencyStates(t *testing.T) {
 	}
 }
 
+func TestCancelAllOrdersAfter(t *testing.T) {
+	t.Parallel()
+	var b Base
+	if err := b.CancelAllOrdersAfter(context.Background(), time.Minute); !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrFunctionNotSupported)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	var b Base
+	if _, err := b.Transfer(context.Background(), nil); !errors.Is(err, common.ErrFunctionNotSupported) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrFunctionNotSupported)
+	}
+}
+
 func TestUpdateOrderExecutionLimits(t *testing.T) {
 	t.Parallel()
 	var b Base
@@ -188,7 +188,10 @@ type API struct {
 	Endpoints *Endpoints
 
 	credentials *account.Credentials
-	credMu      sync.RWMutex
+	// accounts holds the credentials of named exchange accounts which are
+	// selected via context
+	accounts map[string]*account.Credentials
+	credMu   sync.RWMutex
 
 	CredentialsValidator CredentialsValidator
 }
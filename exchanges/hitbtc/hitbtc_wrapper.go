@@ -183,6 +183,7 @@ func (h *HitBTC) Setup(exch *config.Exchange) error {
 			SortBuffer:            true,
 			SortBufferByUpdateIDs: true,
 		},
+		OrderEntryByDefault: true,
 	})
 	if err != nil {
 		return err
@@ -567,37 +568,39 @@ func (h *HitBTC) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Submi
 
 	var orderID string
 	status := order.New
-	if h.Websocket.IsConnected() && h.Websocket.CanUseAuthenticatedEndpoints() {
-		var response *WsSubmitOrderSuccessResponse
-		response, err = h.wsPlaceOrder(o.Pair, o.Side.String(), o.Amount, o.Price)
-		if err != nil {
-			return nil, err
+	err = h.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
+		response, wsErr := h.wsPlaceOrder(o.Pair, o.Side.String(), o.Amount, o.Price)
+		if wsErr != nil {
+			return wsErr
 		}
 		orderID = strconv.FormatInt(response.ID, 10)
 		if response.Result.CumQuantity == o.Amount {
 			status = order.Filled
 		}
-	} else {
-		var fPair currency.Pair
-		fPair, err = h.FormatExchangeCurrency(o.Pair, o.AssetType)
-		if err != nil {
-			return nil, err
+		return nil
+	}, func() error {
+		fPair, restErr := h.FormatExchangeCurrency(o.Pair, o.AssetType)
+		if restErr != nil {
+			return restErr
 		}
 
-		var response OrderResponse
-		response, err = h.PlaceOrder(ctx,
+		response, restErr := h.PlaceOrder(ctx,
 			fPair.String(),
 			o.Price,
 			o.Amount,
 			o.Type.Lower(),
 			o.Side.Lower())
-		if err != nil {
-			return nil, err
+		if restErr != nil {
+			return restErr
 		}
 		orderID = strconv.FormatInt(response.OrderNumber, 10)
 		if o.Type == order.Market {
 			status = order.Filled
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	resp, err := o.DeriveSubmitResponse(orderID)
 	if err != nil {
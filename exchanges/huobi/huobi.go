@@ -56,6 +56,9 @@ const (
 	huobiGetOrdersMatch              = "/orders/matchresults"
 	huobiMarginTransferIn            = "/dw/transfer-in/margin"
 	huobiMarginTransferOut           = "/dw/transfer-out/margin"
+	huobiSpotFuturesTransfer         = "/futures/transfer"
+	huobiAccountTransfer             = "/account/transfer"
+	huobiSubUserTransfer             = "/subuser/transfer"
 	huobiMarginOrders                = "/margin/orders"
 	huobiMarginRepay                 = "/margin/orders/%s/repay"
 	huobiMarginLoanOrders            = "/margin/loan-orders"
@@ -630,6 +633,78 @@ func (h *HUOBI) MarginTransfer(ctx context.Context, symbol currency.Pair, curren
 	return resp.TransferID, err
 }
 
+// SpotFuturesTransfer transfers a currency between the spot and futures
+// accounts
+func (h *HUOBI) SpotFuturesTransfer(ctx context.Context, c currency.Code, amount float64, toFutures bool) (int64, error) {
+	data := struct {
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount"`
+		Type     string  `json:"type"`
+	}{
+		Currency: c.Lower().String(),
+		Amount:   amount,
+		Type:     "futures-to-pro",
+	}
+	if toFutures {
+		data.Type = "pro-to-futures"
+	}
+	resp := struct {
+		TransferID int64 `json:"data"`
+	}{}
+	err := h.SendAuthenticatedHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, huobiSpotFuturesTransfer, nil, data, &resp, false)
+	return resp.TransferID, err
+}
+
+// SpotSwapTransfer transfers a currency between the spot and coin margined
+// swap accounts. The margin account is the contract code e.g. BTC-USD
+func (h *HUOBI) SpotSwapTransfer(ctx context.Context, c currency.Code, marginAccount string, amount float64, toSwap bool) (int64, error) {
+	data := struct {
+		From          string  `json:"from"`
+		To            string  `json:"to"`
+		Currency      string  `json:"currency"`
+		Amount        float64 `json:"amount"`
+		MarginAccount string  `json:"margin-account"`
+	}{
+		From:          "swap",
+		To:            "spot",
+		Currency:      c.Lower().String(),
+		Amount:        amount,
+		MarginAccount: marginAccount,
+	}
+	if toSwap {
+		data.From, data.To = data.To, data.From
+	}
+	resp := struct {
+		TransferID int64 `json:"data"`
+	}{}
+	err := h.SendAuthenticatedHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, huobiAccountTransfer, nil, data, &resp, true)
+	return resp.TransferID, err
+}
+
+// SubUserTransfer transfers a currency between the spot accounts of the
+// master account and a sub-user
+func (h *HUOBI) SubUserTransfer(ctx context.Context, subUID int64, c currency.Code, amount float64, toSubUser bool) (int64, error) {
+	data := struct {
+		SubUID   int64   `json:"sub-uid"`
+		Currency string  `json:"currency"`
+		Amount   float64 `json:"amount"`
+		Type     string  `json:"type"`
+	}{
+		SubUID:   subUID,
+		Currency: c.Lower().String(),
+		Amount:   amount,
+		Type:     "master-transfer-in",
+	}
+	if toSubUser {
+		data.Type = "master-transfer-out"
+	}
+	resp := struct {
+		TransferID int64 `json:"data"`
+	}{}
+	err := h.SendAuthenticatedHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, huobiSubUserTransfer, nil, data, &resp, false)
+	return resp.TransferID, err
+}
+
 // MarginOrder submits a margin order application
 func (h *HUOBI) MarginOrder(ctx context.Context, symbol currency.Pair, currency string, amount float64) (int64, error) {
 	symbolValue, err := h.FormatSymbol(symbol, asset.Spot)
@@ -2150,6 +2150,40 @@ func TestWithdraw(t *testing.T) {
 	}
 }
 
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		request  withdraw.TransferRequest
+		expected error
+	}{
+		{withdraw.TransferRequest{From: asset.Futures, To: asset.CoinMarginedFutures}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Spot, FromSubAccount: "1", ToSubAccount: "2"}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Futures, ToSubAccount: "1"}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Spot, ToSubAccount: "sub"}, strconv.ErrSyntax},
+	} {
+		tt.request.Exchange = h.Name
+		tt.request.Currency = currency.USDT
+		tt.request.Amount = 1
+		_, err := h.Transfer(context.Background(), &tt.request)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("received: '%v' but expected: '%v'", err, tt.expected)
+		}
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders false, skipping test")
+	}
+	_, err := h.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: h.Name,
+		Currency: currency.BTC,
+		Amount:   0.001,
+		From:     asset.Spot,
+		To:       asset.CoinMarginedFutures,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestWithdrawFiat(t *testing.T) {
 	if areTestAPIKeysSet() && !canManipulateRealOrders {
 		t.Skip("API keys set, canManipulateRealOrders false, skipping test")
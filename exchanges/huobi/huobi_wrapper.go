@@ -1327,6 +1327,81 @@ func (h *HUOBI) WithdrawFiatFundsToInternationalBank(_ context.Context, _ *withd
 	return nil, common.ErrFunctionNotSupported
 }
 
+// Transfer moves funds between the spot account and the futures or coin
+// margined swap accounts, or between the same account of the master account
+// and a sub-user identified by its user ID
+func (h *HUOBI) Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if r.FromSubAccount == "" && r.ToSubAccount == "" {
+		return h.transferAccounts(ctx, r)
+	}
+	if r.FromSubAccount != "" && r.ToSubAccount != "" {
+		return nil, fmt.Errorf("%w between sub-users", withdraw.ErrTransferWalletUnsupported)
+	}
+	if r.From != r.To {
+		return nil, fmt.Errorf("%w %v to %v with a sub-user", withdraw.ErrTransferWalletUnsupported, r.From, r.To)
+	}
+	subUID, toSubUser := r.ToSubAccount, r.ToSubAccount != ""
+	if !toSubUser {
+		subUID = r.FromSubAccount
+	}
+	transferType := "sub_to_master"
+	if toSubUser {
+		transferType = "master_to_sub"
+	}
+	switch r.From {
+	case asset.Spot:
+		uid, err := strconv.ParseInt(subUID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sub-user ID %s: %w", subUID, err)
+		}
+		id, err := h.SubUserTransfer(ctx, uid, r.Currency, r.Amount, toSubUser)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{ID: strconv.FormatInt(id, 10)}, nil
+	case asset.Futures:
+		resp, err := h.FTransfer(ctx, subUID, r.Currency.Upper().String(), transferType, r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{ID: resp.Data.OrderID}, nil
+	case asset.CoinMarginedFutures:
+		resp, err := h.AccountTransferData(ctx, currency.NewPair(r.Currency, currency.USD), subUID, transferType, r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{ID: resp.Data.OrderID}, nil
+	}
+	return nil, fmt.Errorf("%w %v", withdraw.ErrTransferWalletUnsupported, r.From)
+}
+
+// transferAccounts moves funds between the spot account and the futures or
+// coin margined swap accounts
+func (h *HUOBI) transferAccounts(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	var (
+		id  int64
+		err error
+	)
+	switch {
+	case r.From == asset.Spot && r.To == asset.Futures,
+		r.From == asset.Futures && r.To == asset.Spot:
+		id, err = h.SpotFuturesTransfer(ctx, r.Currency, r.Amount, r.To == asset.Futures)
+	case r.From == asset.Spot && r.To == asset.CoinMarginedFutures,
+		r.From == asset.CoinMarginedFutures && r.To == asset.Spot:
+		marginAccount := r.Currency.Upper().String() + currency.DashDelimiter + currency.USD.String()
+		id, err = h.SpotSwapTransfer(ctx, r.Currency, marginAccount, r.Amount, r.To == asset.CoinMarginedFutures)
+	default:
+		return nil, fmt.Errorf("%w %v to %v", withdraw.ErrTransferWalletUnsupported, r.From, r.To)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &withdraw.ExchangeResponse{ID: strconv.FormatInt(id, 10)}, nil
+}
+
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (h *HUOBI) GetFeeByType(ctx context.Context, feeBuilder *exchange.FeeBuilder) (float64, error) {
 	if feeBuilder == nil {
@@ -73,6 +73,7 @@ type IBotExchange interface {
 	CheckOrderExecutionLimits(a asset.Item, cp currency.Pair, price, amount float64, orderType order.Type) error
 	UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) error
 	GetCredentials(ctx context.Context) (*account.Credentials, error)
+	GetAccountNames() []string
 	ValidateCredentials(ctx context.Context, a asset.Item) error
 
 	FunctionalityChecker
@@ -80,6 +81,7 @@ type IBotExchange interface {
 	OrderManagement
 	CurrencyStateManagement
 	FuturesManagement
+	TransferManagement
 }
 
 // OrderManagement defines functionality for order management
@@ -89,11 +91,18 @@ type OrderManagement interface {
 	CancelOrder(ctx context.Context, o *order.Cancel) error
 	CancelBatchOrders(ctx context.Context, o []order.Cancel) (order.CancelBatchResponse, error)
 	CancelAllOrders(ctx context.Context, orders *order.Cancel) (order.CancelAllResponse, error)
+	CancelAllOrdersAfter(ctx context.Context, timeout time.Duration) error
 	GetOrderInfo(ctx context.Context, orderID string, pair currency.Pair, assetType asset.Item) (order.Detail, error)
 	GetActiveOrders(ctx context.Context, getOrdersRequest *order.GetOrdersRequest) (order.FilteredOrders, error)
 	GetOrderHistory(ctx context.Context, getOrdersRequest *order.GetOrdersRequest) (order.FilteredOrders, error)
 }
 
+// TransferManagement defines functionality for internal transfers between the
+// asset wallets and sub-accounts of an exchange
+type TransferManagement interface {
+	Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error)
+}
+
 // CurrencyStateManagement defines functionality for currency state management
 type CurrencyStateManagement interface {
 	GetCurrencyStateSnapshot() ([]currencystate.Snapshot, error)
@@ -0,0 +1,1031 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	errInvalidMultiplier   = errors.New("invalid multiplier")
+	errInvalidAcceleration = errors.New("invalid acceleration factor")
+	errInvalidPivotMethod  = errors.New("invalid pivot point method")
+	errCandleOutOfSequence = errors.New("candle time is before the last processed candle")
+	errNilIndicatorStream  = errors.New("nil indicator stream")
+)
+
+// PivotMethod defines the calculation used to derive pivot points
+type PivotMethod string
+
+// Supported pivot point methods
+const (
+	PivotClassic   PivotMethod = "classic"
+	PivotFibonacci PivotMethod = "fibonacci"
+	PivotWoodie    PivotMethod = "woodie"
+	PivotCamarilla PivotMethod = "camarilla"
+)
+
+// indicatorState is a candle by candle calculation of an indicator. Each call
+// to step commits the candle to the state and returns the indicator values for
+// that candle in the order of the names returned by outputs. Values that cannot
+// be derived yet due to the warm up period are returned as zero, matching the
+// batch indicators.
+type indicatorState interface {
+	step(c *Candle) []float64
+	outputs() []string
+	clone() indicatorState
+}
+
+// IndicatorStream incrementally calculates an indicator as candles arrive.
+// Candles with a time after the last processed candle are appended to the
+// series; a candle with the same time as the last processed candle amends it,
+// which allows an in-progress candle to be updated without recomputing the
+// entire series.
+type IndicatorStream struct {
+	m         sync.Mutex
+	committed indicatorState
+	working   indicatorState
+	last      time.Time
+	started   bool
+}
+
+func newIndicatorStream(state indicatorState) *IndicatorStream {
+	return &IndicatorStream{committed: state, working: state.clone()}
+}
+
+// Update adds or amends a candle and returns the indicator values for it
+func (s *IndicatorStream) Update(c Candle) ([]float64, error) {
+	if s == nil {
+		return nil, errNilIndicatorStream
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	switch {
+	case !s.started:
+		s.started = true
+	case c.Time.Equal(s.last):
+		// Discard the previous version of this candle and apply the amended
+		// version against the committed state.
+		s.working = s.committed.clone()
+	case c.Time.Before(s.last):
+		return nil, fmt.Errorf("%w: %v last: %v", errCandleOutOfSequence, c.Time, s.last)
+	default:
+		s.committed = s.working
+		s.working = s.committed.clone()
+	}
+	s.last = c.Time
+	return s.working.step(&c), nil
+}
+
+// Outputs returns the names of the values returned by Update in order
+func (s *IndicatorStream) Outputs() []string {
+	if s == nil {
+		return nil
+	}
+	return s.committed.outputs()
+}
+
+// NewStochasticStream returns an incremental stochastic oscillator
+func NewStochasticStream(kPeriod, smoothK, dPeriod int64) (*IndicatorStream, error) {
+	state, err := newStochasticState(kPeriod, smoothK, dPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewStochasticRSIStream returns an incremental stochastic RSI on the close
+// price
+func NewStochasticRSIStream(rsiPeriod, stochPeriod, smoothK, dPeriod int64) (*IndicatorStream, error) {
+	state, err := newStochasticRSIState(rsiPeriod, stochPeriod, smoothK, dPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewDirectionalMovementStream returns an incremental directional movement
+// index (+DI, -DI and ADX)
+func NewDirectionalMovementStream(period int64) (*IndicatorStream, error) {
+	state, err := newDirectionalMovementState(period)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewIchimokuStream returns an incremental ichimoku cloud. The lagging span is
+// not included as it is the close price plotted back by the displacement.
+func NewIchimokuStream(conversion, base, spanB, displacement int64) (*IndicatorStream, error) {
+	state, err := newIchimokuState(conversion, base, spanB, displacement)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewKeltnerStream returns incremental keltner channels
+func NewKeltnerStream(emaPeriod, atrPeriod int64, multiplier float64) (*IndicatorStream, error) {
+	state, err := newKeltnerState(emaPeriod, atrPeriod, multiplier)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewDonchianStream returns incremental donchian channels
+func NewDonchianStream(period int64) (*IndicatorStream, error) {
+	state, err := newDonchianState(period)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewSupertrendStream returns an incremental supertrend
+func NewSupertrendStream(period int64, multiplier float64) (*IndicatorStream, error) {
+	state, err := newSupertrendState(period, multiplier)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewVWAPBandsStream returns an incremental volume weighted average price with
+// standard deviation bands
+func NewVWAPBandsStream(multiplier float64) (*IndicatorStream, error) {
+	state, err := newVWAPBandsState(multiplier)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewCommodityChannelIndexStream returns an incremental commodity channel index
+func NewCommodityChannelIndexStream(period int64) (*IndicatorStream, error) {
+	state, err := newCommodityChannelIndexState(period)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewWilliamsPercentRangeStream returns an incremental williams %R
+func NewWilliamsPercentRangeStream(period int64) (*IndicatorStream, error) {
+	state, err := newWilliamsPercentRangeState(period)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewParabolicSARStream returns an incremental parabolic stop and reverse
+func NewParabolicSARStream(step, maximum float64) (*IndicatorStream, error) {
+	state, err := newParabolicSARState(step, maximum)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// NewHeikinAshiStream returns incremental heikin-ashi candles
+func NewHeikinAshiStream() *IndicatorStream {
+	return newIndicatorStream(&heikinAshiState{})
+}
+
+// NewPivotPointsStream returns incremental pivot points derived from the
+// previous candle
+func NewPivotPointsStream(method PivotMethod) (*IndicatorStream, error) {
+	state, err := newPivotPointsState(method)
+	if err != nil {
+		return nil, err
+	}
+	return newIndicatorStream(state), nil
+}
+
+// rollingWindow holds the most recent values up to its size
+type rollingWindow struct {
+	values []float64
+	size   int
+}
+
+func newRollingWindow(size int) rollingWindow {
+	return rollingWindow{values: make([]float64, 0, size+1), size: size}
+}
+
+func (w *rollingWindow) push(v float64) {
+	if len(w.values) == w.size {
+		copy(w.values, w.values[1:])
+		w.values[len(w.values)-1] = v
+		return
+	}
+	w.values = append(w.values, v)
+}
+
+func (w *rollingWindow) full() bool {
+	return len(w.values) == w.size
+}
+
+func (w *rollingWindow) mean() float64 {
+	var sum float64
+	for x := range w.values {
+		sum += w.values[x]
+	}
+	return sum / float64(len(w.values))
+}
+
+// highest returns the highest of the last n values
+func (w *rollingWindow) highest(n int) float64 {
+	last := w.values[len(w.values)-n:]
+	v := last[0]
+	for x := 1; x < len(last); x++ {
+		if last[x] > v {
+			v = last[x]
+		}
+	}
+	return v
+}
+
+// lowest returns the lowest of the last n values
+func (w *rollingWindow) lowest(n int) float64 {
+	last := w.values[len(w.values)-n:]
+	v := last[0]
+	for x := 1; x < len(last); x++ {
+		if last[x] < v {
+			v = last[x]
+		}
+	}
+	return v
+}
+
+func (w rollingWindow) clone() rollingWindow {
+	values := make([]float64, len(w.values), w.size+1)
+	copy(values, w.values)
+	w.values = values
+	return w
+}
+
+// wilderAverage is Wilder's smoothing, seeded with the simple average of the
+// first period values
+type wilderAverage struct {
+	period int
+	count  int
+	sum    float64
+	value  float64
+}
+
+func (a *wilderAverage) push(v float64) bool {
+	if a.count < a.period {
+		a.count++
+		a.sum += v
+		if a.count < a.period {
+			return false
+		}
+		a.value = a.sum / float64(a.period)
+		return true
+	}
+	a.value = (a.value*float64(a.period-1) + v) / float64(a.period)
+	return true
+}
+
+// exponentialAverage is an exponential moving average seeded with the simple
+// average of the first period values
+type exponentialAverage struct {
+	period int
+	count  int
+	sum    float64
+	value  float64
+}
+
+func (a *exponentialAverage) push(v float64) bool {
+	if a.count < a.period {
+		a.count++
+		a.sum += v
+		if a.count < a.period {
+			return false
+		}
+		a.value = a.sum / float64(a.period)
+		return true
+	}
+	k := 2 / float64(a.period+1)
+	a.value = (v-a.value)*k + a.value
+	return true
+}
+
+// averageTrueRange is an incremental average true range using Wilder's
+// smoothing
+type averageTrueRange struct {
+	average   wilderAverage
+	prevClose float64
+	hasPrev   bool
+}
+
+func (a *averageTrueRange) push(c *Candle) bool {
+	tr := c.High - c.Low
+	if a.hasPrev {
+		tr = math.Max(tr, math.Max(math.Abs(c.High-a.prevClose), math.Abs(c.Low-a.prevClose)))
+	}
+	a.prevClose = c.Close
+	a.hasPrev = true
+	return a.average.push(tr)
+}
+
+// relativeStrength is an incremental relative strength index using Wilder's
+// smoothing
+type relativeStrength struct {
+	gains, losses wilderAverage
+	prev          float64
+	hasPrev       bool
+}
+
+func (r *relativeStrength) push(v float64) (float64, bool) {
+	if !r.hasPrev {
+		r.prev = v
+		r.hasPrev = true
+		return 0, false
+	}
+	change := v - r.prev
+	r.prev = v
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+	gainOK := r.gains.push(gain)
+	lossOK := r.losses.push(loss)
+	if !gainOK || !lossOK {
+		return 0, false
+	}
+	if r.losses.value == 0 {
+		if r.gains.value == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+r.gains.value/r.losses.value), true
+}
+
+// percentOfRange returns where v sits between low and high as a percentage
+func percentOfRange(v, low, high float64) float64 {
+	if high == low {
+		return 0
+	}
+	return 100 * (v - low) / (high - low)
+}
+
+// smoothedOscillator smooths a raw oscillator value into %K and derives %D
+type smoothedOscillator struct {
+	k, d rollingWindow
+}
+
+func newSmoothedOscillator(smoothK, dPeriod int) smoothedOscillator {
+	return smoothedOscillator{k: newRollingWindow(smoothK), d: newRollingWindow(dPeriod)}
+}
+
+func (s *smoothedOscillator) push(v float64) []float64 {
+	s.k.push(v)
+	if !s.k.full() {
+		return []float64{0, 0}
+	}
+	k := s.k.mean()
+	s.d.push(k)
+	if !s.d.full() {
+		return []float64{k, 0}
+	}
+	return []float64{k, s.d.mean()}
+}
+
+func (s smoothedOscillator) clone() smoothedOscillator {
+	s.k = s.k.clone()
+	s.d = s.d.clone()
+	return s
+}
+
+type stochasticState struct {
+	highs, lows rollingWindow
+	oscillator  smoothedOscillator
+}
+
+func newStochasticState(kPeriod, smoothK, dPeriod int64) (*stochasticState, error) {
+	if kPeriod <= 0 {
+		return nil, fmt.Errorf("stochastic %w k period", errInvalidPeriod)
+	}
+	if smoothK <= 0 {
+		return nil, fmt.Errorf("stochastic %w k smoothing period", errInvalidPeriod)
+	}
+	if dPeriod <= 0 {
+		return nil, fmt.Errorf("stochastic %w d period", errInvalidPeriod)
+	}
+	return &stochasticState{
+		highs:      newRollingWindow(int(kPeriod)),
+		lows:       newRollingWindow(int(kPeriod)),
+		oscillator: newSmoothedOscillator(int(smoothK), int(dPeriod)),
+	}, nil
+}
+
+func (s *stochasticState) step(c *Candle) []float64 {
+	s.highs.push(c.High)
+	s.lows.push(c.Low)
+	if !s.highs.full() {
+		return []float64{0, 0}
+	}
+	return s.oscillator.push(percentOfRange(c.Close, s.lows.lowest(s.lows.size), s.highs.highest(s.highs.size)))
+}
+
+func (s *stochasticState) outputs() []string {
+	return []string{"K", "D"}
+}
+
+func (s *stochasticState) clone() indicatorState {
+	return &stochasticState{
+		highs:      s.highs.clone(),
+		lows:       s.lows.clone(),
+		oscillator: s.oscillator.clone(),
+	}
+}
+
+type stochasticRSIState struct {
+	rsi        relativeStrength
+	values     rollingWindow
+	oscillator smoothedOscillator
+}
+
+func newStochasticRSIState(rsiPeriod, stochPeriod, smoothK, dPeriod int64) (*stochasticRSIState, error) {
+	if rsiPeriod <= 1 {
+		return nil, fmt.Errorf("stochastic rsi %w rsi period cannot be equal or below 1", errInvalidPeriod)
+	}
+	if stochPeriod <= 0 {
+		return nil, fmt.Errorf("stochastic rsi %w stochastic period", errInvalidPeriod)
+	}
+	if smoothK <= 0 {
+		return nil, fmt.Errorf("stochastic rsi %w k smoothing period", errInvalidPeriod)
+	}
+	if dPeriod <= 0 {
+		return nil, fmt.Errorf("stochastic rsi %w d period", errInvalidPeriod)
+	}
+	return &stochasticRSIState{
+		rsi: relativeStrength{
+			gains:  wilderAverage{period: int(rsiPeriod)},
+			losses: wilderAverage{period: int(rsiPeriod)},
+		},
+		values:     newRollingWindow(int(stochPeriod)),
+		oscillator: newSmoothedOscillator(int(smoothK), int(dPeriod)),
+	}, nil
+}
+
+func (s *stochasticRSIState) step(c *Candle) []float64 {
+	rsi, ok := s.rsi.push(c.Close)
+	if !ok {
+		return []float64{0, 0}
+	}
+	s.values.push(rsi)
+	if !s.values.full() {
+		return []float64{0, 0}
+	}
+	return s.oscillator.push(percentOfRange(rsi, s.values.lowest(s.values.size), s.values.highest(s.values.size)))
+}
+
+func (s *stochasticRSIState) outputs() []string {
+	return []string{"K", "D"}
+}
+
+func (s *stochasticRSIState) clone() indicatorState {
+	return &stochasticRSIState{
+		rsi:        s.rsi,
+		values:     s.values.clone(),
+		oscillator: s.oscillator.clone(),
+	}
+}
+
+type directionalMovementState struct {
+	trueRange, plusDM, minusDM, adx wilderAverage
+	prev                            Candle
+	hasPrev                         bool
+}
+
+func newDirectionalMovementState(period int64) (*directionalMovementState, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("directional movement %w", errInvalidPeriod)
+	}
+	return &directionalMovementState{
+		trueRange: wilderAverage{period: int(period)},
+		plusDM:    wilderAverage{period: int(period)},
+		minusDM:   wilderAverage{period: int(period)},
+		adx:       wilderAverage{period: int(period)},
+	}, nil
+}
+
+func (s *directionalMovementState) step(c *Candle) []float64 {
+	if !s.hasPrev {
+		s.prev = *c
+		s.hasPrev = true
+		return []float64{0, 0, 0}
+	}
+	upMove := c.High - s.prev.High
+	downMove := s.prev.Low - c.Low
+	var plus, minus float64
+	if upMove > downMove && upMove > 0 {
+		plus = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minus = downMove
+	}
+	tr := math.Max(c.High-c.Low, math.Max(math.Abs(c.High-s.prev.Close), math.Abs(c.Low-s.prev.Close)))
+	s.prev = *c
+
+	s.trueRange.push(tr)
+	s.plusDM.push(plus)
+	if !s.minusDM.push(minus) || s.trueRange.value == 0 {
+		return []float64{0, 0, 0}
+	}
+	plusDI := 100 * s.plusDM.value / s.trueRange.value
+	minusDI := 100 * s.minusDM.value / s.trueRange.value
+	var dx float64
+	if sum := plusDI + minusDI; sum != 0 {
+		dx = 100 * math.Abs(plusDI-minusDI) / sum
+	}
+	if !s.adx.push(dx) {
+		return []float64{plusDI, minusDI, 0}
+	}
+	return []float64{plusDI, minusDI, s.adx.value}
+}
+
+func (s *directionalMovementState) outputs() []string {
+	return []string{"PLUS_DI", "MINUS_DI", "ADX"}
+}
+
+func (s *directionalMovementState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type ichimokuState struct {
+	conversion, base, spanB int
+	highs, lows             rollingWindow
+	spanAHistory            rollingWindow
+	spanBHistory            rollingWindow
+}
+
+func newIchimokuState(conversion, base, spanB, displacement int64) (*ichimokuState, error) {
+	if conversion <= 0 {
+		return nil, fmt.Errorf("ichimoku %w conversion period", errInvalidPeriod)
+	}
+	if base <= 0 {
+		return nil, fmt.Errorf("ichimoku %w base period", errInvalidPeriod)
+	}
+	if spanB <= 0 {
+		return nil, fmt.Errorf("ichimoku %w leading span b period", errInvalidPeriod)
+	}
+	if displacement < 0 {
+		return nil, fmt.Errorf("ichimoku %w displacement", errInvalidPeriod)
+	}
+	longest := conversion
+	if base > longest {
+		longest = base
+	}
+	if spanB > longest {
+		longest = spanB
+	}
+	return &ichimokuState{
+		conversion:   int(conversion),
+		base:         int(base),
+		spanB:        int(spanB),
+		highs:        newRollingWindow(int(longest)),
+		lows:         newRollingWindow(int(longest)),
+		spanAHistory: newRollingWindow(int(displacement) + 1),
+		spanBHistory: newRollingWindow(int(displacement) + 1),
+	}, nil
+}
+
+func (s *ichimokuState) midpoint(period int) float64 {
+	if len(s.highs.values) < period {
+		return 0
+	}
+	return (s.highs.highest(period) + s.lows.lowest(period)) / 2
+}
+
+func (s *ichimokuState) step(c *Candle) []float64 {
+	s.highs.push(c.High)
+	s.lows.push(c.Low)
+	conversion := s.midpoint(s.conversion)
+	base := s.midpoint(s.base)
+	var spanA float64
+	if conversion != 0 && base != 0 {
+		spanA = (conversion + base) / 2
+	}
+	s.spanAHistory.push(spanA)
+	s.spanBHistory.push(s.midpoint(s.spanB))
+	if !s.spanAHistory.full() {
+		return []float64{conversion, base, 0, 0}
+	}
+	// Leading spans are projected forward by the displacement, so the value
+	// applicable to this candle was calculated displacement candles ago.
+	return []float64{conversion, base, s.spanAHistory.values[0], s.spanBHistory.values[0]}
+}
+
+func (s *ichimokuState) outputs() []string {
+	return []string{"CONVERSION", "BASE", "LEADING_SPAN_A", "LEADING_SPAN_B"}
+}
+
+func (s *ichimokuState) clone() indicatorState {
+	cpy := *s
+	cpy.highs = s.highs.clone()
+	cpy.lows = s.lows.clone()
+	cpy.spanAHistory = s.spanAHistory.clone()
+	cpy.spanBHistory = s.spanBHistory.clone()
+	return &cpy
+}
+
+type keltnerState struct {
+	middle     exponentialAverage
+	atr        averageTrueRange
+	multiplier float64
+}
+
+func newKeltnerState(emaPeriod, atrPeriod int64, multiplier float64) (*keltnerState, error) {
+	if emaPeriod <= 0 {
+		return nil, fmt.Errorf("keltner channels %w ema period", errInvalidPeriod)
+	}
+	if atrPeriod <= 0 {
+		return nil, fmt.Errorf("keltner channels %w atr period", errInvalidPeriod)
+	}
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("keltner channels %w", errInvalidMultiplier)
+	}
+	return &keltnerState{
+		middle:     exponentialAverage{period: int(emaPeriod)},
+		atr:        averageTrueRange{average: wilderAverage{period: int(atrPeriod)}},
+		multiplier: multiplier,
+	}, nil
+}
+
+func (s *keltnerState) step(c *Candle) []float64 {
+	middleOK := s.middle.push(c.Close)
+	atrOK := s.atr.push(c)
+	if !middleOK || !atrOK {
+		return []float64{0, 0, 0}
+	}
+	width := s.atr.average.value * s.multiplier
+	return []float64{s.middle.value + width, s.middle.value, s.middle.value - width}
+}
+
+func (s *keltnerState) outputs() []string {
+	return []string{"UPPER", "MIDDLE", "LOWER"}
+}
+
+func (s *keltnerState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type donchianState struct {
+	highs, lows rollingWindow
+}
+
+func newDonchianState(period int64) (*donchianState, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("donchian channels %w", errInvalidPeriod)
+	}
+	return &donchianState{
+		highs: newRollingWindow(int(period)),
+		lows:  newRollingWindow(int(period)),
+	}, nil
+}
+
+func (s *donchianState) step(c *Candle) []float64 {
+	s.highs.push(c.High)
+	s.lows.push(c.Low)
+	if !s.highs.full() {
+		return []float64{0, 0, 0}
+	}
+	upper, lower := s.highs.highest(s.highs.size), s.lows.lowest(s.lows.size)
+	return []float64{upper, (upper + lower) / 2, lower}
+}
+
+func (s *donchianState) outputs() []string {
+	return []string{"UPPER", "MIDDLE", "LOWER"}
+}
+
+func (s *donchianState) clone() indicatorState {
+	return &donchianState{highs: s.highs.clone(), lows: s.lows.clone()}
+}
+
+type supertrendState struct {
+	atr                    averageTrueRange
+	multiplier             float64
+	upper, lower, value    float64
+	prevClose              float64
+	uptrend, started, seen bool
+}
+
+func newSupertrendState(period int64, multiplier float64) (*supertrendState, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("supertrend %w", errInvalidPeriod)
+	}
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("supertrend %w", errInvalidMultiplier)
+	}
+	return &supertrendState{
+		atr:        averageTrueRange{average: wilderAverage{period: int(period)}},
+		multiplier: multiplier,
+	}, nil
+}
+
+func (s *supertrendState) step(c *Candle) []float64 {
+	prevClose, seen := s.prevClose, s.seen
+	s.prevClose, s.seen = c.Close, true
+	if !s.atr.push(c) {
+		return []float64{0, 0}
+	}
+	mid := (c.High + c.Low) / 2
+	width := s.multiplier * s.atr.average.value
+	basicUpper, basicLower := mid+width, mid-width
+	if !s.started {
+		s.started = true
+		s.upper, s.lower = basicUpper, basicLower
+		s.uptrend = c.Close > mid
+	} else {
+		if basicUpper < s.upper || (seen && prevClose > s.upper) {
+			s.upper = basicUpper
+		}
+		if basicLower > s.lower || (seen && prevClose < s.lower) {
+			s.lower = basicLower
+		}
+		if s.uptrend && c.Close < s.lower {
+			s.uptrend = false
+		} else if !s.uptrend && c.Close > s.upper {
+			s.uptrend = true
+		}
+	}
+	if s.uptrend {
+		s.value = s.lower
+		return []float64{s.value, 1}
+	}
+	s.value = s.upper
+	return []float64{s.value, -1}
+}
+
+func (s *supertrendState) outputs() []string {
+	return []string{"SUPERTREND", "DIRECTION"}
+}
+
+func (s *supertrendState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type vwapBandsState struct {
+	multiplier                       float64
+	cumVolume, cumTotal, cumSquTotal float64
+}
+
+func newVWAPBandsState(multiplier float64) (*vwapBandsState, error) {
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("vwap bands %w", errInvalidMultiplier)
+	}
+	return &vwapBandsState{multiplier: multiplier}, nil
+}
+
+func (s *vwapBandsState) step(c *Candle) []float64 {
+	typical := c.GetTypicalPrice()
+	s.cumVolume += c.Volume
+	s.cumTotal += typical * c.Volume
+	s.cumSquTotal += typical * typical * c.Volume
+	if s.cumVolume == 0 {
+		return []float64{0, 0, 0}
+	}
+	vwap := s.cumTotal / s.cumVolume
+	width := s.multiplier * math.Sqrt(math.Max(s.cumSquTotal/s.cumVolume-vwap*vwap, 0))
+	return []float64{vwap + width, vwap, vwap - width}
+}
+
+func (s *vwapBandsState) outputs() []string {
+	return []string{"UPPER", "VWAP", "LOWER"}
+}
+
+func (s *vwapBandsState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type commodityChannelIndexState struct {
+	typical rollingWindow
+}
+
+func newCommodityChannelIndexState(period int64) (*commodityChannelIndexState, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("commodity channel index %w", errInvalidPeriod)
+	}
+	return &commodityChannelIndexState{typical: newRollingWindow(int(period))}, nil
+}
+
+func (s *commodityChannelIndexState) step(c *Candle) []float64 {
+	typical := c.GetTypicalPrice()
+	s.typical.push(typical)
+	if !s.typical.full() {
+		return []float64{0}
+	}
+	mean := s.typical.mean()
+	var deviation float64
+	for x := range s.typical.values {
+		deviation += math.Abs(s.typical.values[x] - mean)
+	}
+	deviation /= float64(len(s.typical.values))
+	if deviation == 0 {
+		return []float64{0}
+	}
+	return []float64{(typical - mean) / (0.015 * deviation)}
+}
+
+func (s *commodityChannelIndexState) outputs() []string {
+	return []string{"CCI"}
+}
+
+func (s *commodityChannelIndexState) clone() indicatorState {
+	return &commodityChannelIndexState{typical: s.typical.clone()}
+}
+
+type williamsPercentRangeState struct {
+	highs, lows rollingWindow
+}
+
+func newWilliamsPercentRangeState(period int64) (*williamsPercentRangeState, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("williams %%R %w", errInvalidPeriod)
+	}
+	return &williamsPercentRangeState{
+		highs: newRollingWindow(int(period)),
+		lows:  newRollingWindow(int(period)),
+	}, nil
+}
+
+func (s *williamsPercentRangeState) step(c *Candle) []float64 {
+	s.highs.push(c.High)
+	s.lows.push(c.Low)
+	if !s.highs.full() {
+		return []float64{0}
+	}
+	return []float64{percentOfRange(c.Close, s.lows.lowest(s.lows.size), s.highs.highest(s.highs.size)) - 100}
+}
+
+func (s *williamsPercentRangeState) outputs() []string {
+	return []string{"WILLR"}
+}
+
+func (s *williamsPercentRangeState) clone() indicatorState {
+	return &williamsPercentRangeState{highs: s.highs.clone(), lows: s.lows.clone()}
+}
+
+type parabolicSARState struct {
+	acceleration, maximum float64
+	sar, extreme, factor  float64
+	prev, prevPrev        Candle
+	count                 int
+	long                  bool
+}
+
+func newParabolicSARState(step, maximum float64) (*parabolicSARState, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("parabolic sar %w step", errInvalidAcceleration)
+	}
+	if maximum < step {
+		return nil, fmt.Errorf("parabolic sar %w maximum cannot be below step", errInvalidAcceleration)
+	}
+	return &parabolicSARState{acceleration: step, maximum: maximum}, nil
+}
+
+func (s *parabolicSARState) step(c *Candle) []float64 {
+	defer func() {
+		s.prevPrev, s.prev = s.prev, *c
+		s.count++
+	}()
+	switch s.count {
+	case 0:
+		return []float64{0}
+	case 1:
+		// The initial trend is derived from the first two candles
+		s.long = c.Close >= s.prev.Close
+		s.factor = s.acceleration
+		if s.long {
+			s.sar = math.Min(s.prev.Low, c.Low)
+			s.extreme = math.Max(s.prev.High, c.High)
+		} else {
+			s.sar = math.Max(s.prev.High, c.High)
+			s.extreme = math.Min(s.prev.Low, c.Low)
+		}
+		return []float64{s.sar}
+	}
+
+	sar := s.sar + s.factor*(s.extreme-s.sar)
+	if s.long {
+		sar = math.Min(sar, math.Min(s.prev.Low, s.prevPrev.Low))
+		if c.Low < sar {
+			s.long = false
+			sar = s.extreme
+			s.extreme = c.Low
+			s.factor = s.acceleration
+		} else if c.High > s.extreme {
+			s.extreme = c.High
+			s.factor = math.Min(s.factor+s.acceleration, s.maximum)
+		}
+	} else {
+		sar = math.Max(sar, math.Max(s.prev.High, s.prevPrev.High))
+		if c.High > sar {
+			s.long = true
+			sar = s.extreme
+			s.extreme = c.High
+			s.factor = s.acceleration
+		} else if c.Low < s.extreme {
+			s.extreme = c.Low
+			s.factor = math.Min(s.factor+s.acceleration, s.maximum)
+		}
+	}
+	s.sar = sar
+	return []float64{sar}
+}
+
+func (s *parabolicSARState) outputs() []string {
+	return []string{"SAR"}
+}
+
+func (s *parabolicSARState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type heikinAshiState struct {
+	open, close float64
+	started     bool
+}
+
+func (s *heikinAshiState) step(c *Candle) []float64 {
+	haClose := (c.Open + c.High + c.Low + c.Close) / 4
+	haOpen := (c.Open + c.Close) / 2
+	if s.started {
+		haOpen = (s.open + s.close) / 2
+	}
+	s.open, s.close, s.started = haOpen, haClose, true
+	return []float64{
+		haOpen,
+		math.Max(c.High, math.Max(haOpen, haClose)),
+		math.Min(c.Low, math.Min(haOpen, haClose)),
+		haClose,
+	}
+}
+
+func (s *heikinAshiState) outputs() []string {
+	return []string{"OPEN", "HIGH", "LOW", "CLOSE"}
+}
+
+func (s *heikinAshiState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
+
+type pivotPointsState struct {
+	method  PivotMethod
+	prev    Candle
+	hasPrev bool
+}
+
+func newPivotPointsState(method PivotMethod) (*pivotPointsState, error) {
+	switch method {
+	case PivotClassic, PivotFibonacci, PivotWoodie, PivotCamarilla:
+	case "":
+		method = PivotClassic
+	default:
+		return nil, fmt.Errorf("%w '%s'", errInvalidPivotMethod, method)
+	}
+	return &pivotPointsState{method: method}, nil
+}
+
+func (s *pivotPointsState) step(c *Candle) []float64 {
+	prev, hasPrev := s.prev, s.hasPrev
+	s.prev, s.hasPrev = *c, true
+	if !hasPrev {
+		return make([]float64, 7)
+	}
+	h, l, cl := prev.High, prev.Low, prev.Close
+	r := h - l
+	switch s.method {
+	case PivotFibonacci:
+		p := (h + l + cl) / 3
+		return []float64{p, p + 0.382*r, p + 0.618*r, p + r, p - 0.382*r, p - 0.618*r, p - r}
+	case PivotCamarilla:
+		p := (h + l + cl) / 3
+		return []float64{p, cl + r*1.1/12, cl + r*1.1/6, cl + r*1.1/4, cl - r*1.1/12, cl - r*1.1/6, cl - r*1.1/4}
+	case PivotWoodie:
+		p := (h + l + 2*cl) / 4
+		return []float64{p, 2*p - l, p + r, h + 2*(p-l), 2*p - h, p - r, l - 2*(h-p)}
+	default:
+		p := (h + l + cl) / 3
+		return []float64{p, 2*p - l, p + r, h + 2*(p-l), 2*p - h, p - r, l - 2*(h-p)}
+	}
+}
+
+func (s *pivotPointsState) outputs() []string {
+	return []string{"PIVOT", "R1", "R2", "R3", "S1", "S2", "S3"}
+}
+
+func (s *pivotPointsState) clone() indicatorState {
+	cpy := *s
+	return &cpy
+}
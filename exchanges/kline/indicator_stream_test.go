@@ -0,0 +1,251 @@
+package kline
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIndicatorStreamMatchesBatch(t *testing.T) {
+	t.Parallel()
+	candles := getTestCandles(80)
+	wrap := Item{Candles: candles}
+
+	stoch, err := wrap.GetStochastic(14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	stochRSI, err := wrap.GetStochasticRSIOnClose(14, 14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	dmi, err := wrap.GetDirectionalMovementIndex(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cloud, err := wrap.GetIchimokuCloud(9, 26, 52, 26)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	keltner, err := wrap.GetKeltnerChannels(20, 10, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	donchian, err := wrap.GetDonchianChannels(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	supertrend, err := wrap.GetSupertrend(10, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	vwap, err := wrap.GetVWAPBands(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cci, err := wrap.GetCommodityChannelIndex(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	willr, err := wrap.GetWilliamsPercentRange(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	sar, err := wrap.GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ha, err := wrap.GetHeikinAshi()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	pivots, err := wrap.GetPivotPoints(PivotFibonacci)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	haCloses := make([]float64, len(ha.Candles))
+	for x := range ha.Candles {
+		haCloses[x] = ha.Candles[x].Close
+	}
+
+	stochStream, err := NewStochasticStream(14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	stochRSIStream, err := NewStochasticRSIStream(14, 14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	dmiStream, err := NewDirectionalMovementStream(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cloudStream, err := NewIchimokuStream(9, 26, 52, 26)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	keltnerStream, err := NewKeltnerStream(20, 10, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	donchianStream, err := NewDonchianStream(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	supertrendStream, err := NewSupertrendStream(10, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	vwapStream, err := NewVWAPBandsStream(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	cciStream, err := NewCommodityChannelIndexStream(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	willrStream, err := NewWilliamsPercentRangeStream(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	sarStream, err := NewParabolicSARStream(0.02, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	pivotStream, err := NewPivotPointsStream(PivotFibonacci)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	tests := []struct {
+		name     string
+		stream   *IndicatorStream
+		expected [][]float64
+	}{
+		{"stochastic", stochStream, [][]float64{stoch.K, stoch.D}},
+		{"stochastic rsi", stochRSIStream, [][]float64{stochRSI.K, stochRSI.D}},
+		{"directional movement", dmiStream, [][]float64{dmi.PlusDI, dmi.MinusDI, dmi.ADX}},
+		{"ichimoku", cloudStream, [][]float64{cloud.Conversion, cloud.Base, cloud.LeadingSpanA, cloud.LeadingSpanB}},
+		{"keltner", keltnerStream, [][]float64{keltner.Upper, keltner.Middle, keltner.Lower}},
+		{"donchian", donchianStream, [][]float64{donchian.Upper, donchian.Middle, donchian.Lower}},
+		{"supertrend", supertrendStream, [][]float64{supertrend.Values, supertrend.Direction}},
+		{"vwap bands", vwapStream, [][]float64{vwap.Upper, vwap.Middle, vwap.Lower}},
+		{"cci", cciStream, [][]float64{cci}},
+		{"williams %R", willrStream, [][]float64{willr}},
+		{"parabolic sar", sarStream, [][]float64{sar}},
+		{"heikin ashi", NewHeikinAshiStream(), [][]float64{nil, nil, nil, haCloses}},
+		{"pivot points", pivotStream, [][]float64{pivots.Pivot, pivots.Resistance1, pivots.Resistance2, pivots.Resistance3, pivots.Support1, pivots.Support2, pivots.Support3}},
+	}
+	for _, tt := range tests {
+		if len(tt.stream.Outputs()) != len(tt.expected) {
+			t.Fatalf("%s received: '%v' outputs but expected: '%v'", tt.name, len(tt.stream.Outputs()), len(tt.expected))
+		}
+		for x := range candles {
+			// Feed a partial version of each candle first to ensure amending
+			// the in-progress candle does not leak into the committed state.
+			partial := candles[x]
+			partial.High += 50
+			partial.Low -= 50
+			partial.Close = partial.Open
+			partial.Volume /= 2
+			if _, err = tt.stream.Update(partial); !errors.Is(err, nil) {
+				t.Fatalf("%s received: '%v' but expected: '%v'", tt.name, err, nil)
+			}
+			values, err := tt.stream.Update(candles[x])
+			if !errors.Is(err, nil) {
+				t.Fatalf("%s received: '%v' but expected: '%v'", tt.name, err, nil)
+			}
+			for y := range tt.expected {
+				if tt.expected[y] == nil {
+					continue
+				}
+				if math.Abs(values[y]-tt.expected[y][x]) > 1e-9 {
+					t.Fatalf("%s output %s at %d received: '%v' but expected: '%v'",
+						tt.name, tt.stream.Outputs()[y], x, values[y], tt.expected[y][x])
+				}
+			}
+		}
+	}
+}
+
+func TestIndicatorStreamUpdate(t *testing.T) {
+	t.Parallel()
+	var s *IndicatorStream
+	_, err := s.Update(Candle{})
+	if !errors.Is(err, errNilIndicatorStream) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilIndicatorStream)
+	}
+	if s.Outputs() != nil {
+		t.Fatal("expected nil outputs")
+	}
+
+	s, err = NewDonchianStream(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	tn := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.Update(Candle{Time: tn, High: 10, Low: 5})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.Update(Candle{Time: tn.Add(-time.Minute)})
+	if !errors.Is(err, errCandleOutOfSequence) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleOutOfSequence)
+	}
+	values, err := s.Update(Candle{Time: tn.Add(time.Minute), High: 20, Low: 8})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if values[0] != 20 || values[2] != 5 {
+		t.Fatalf("received: '%v' but expected: '[20 12.5 5]'", values)
+	}
+	values, err = s.Update(Candle{Time: tn.Add(time.Minute), High: 9, Low: 8})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if values[0] != 10 || values[2] != 5 {
+		t.Fatalf("received: '%v' but expected: '[10 7.5 5]'", values)
+	}
+}
+
+func TestNewIndicatorStreamErrors(t *testing.T) {
+	t.Parallel()
+	if _, err := NewStochasticStream(0, 1, 1); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewStochasticRSIStream(14, 0, 1, 1); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewDirectionalMovementStream(0); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewIchimokuStream(0, 26, 52, 26); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewKeltnerStream(0, 10, 2); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewDonchianStream(0); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewSupertrendStream(0, 3); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewVWAPBandsStream(-1); !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+	if _, err := NewCommodityChannelIndexStream(0); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewWilliamsPercentRangeStream(0); !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	if _, err := NewParabolicSARStream(0, 0.2); !errors.Is(err, errInvalidAcceleration) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAcceleration)
+	}
+	if _, err := NewPivotPointsStream("meow"); !errors.Is(err, errInvalidPivotMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPivotMethod)
+	}
+}
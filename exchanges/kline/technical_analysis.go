@@ -352,3 +352,491 @@ func (o *OHLC) GetRelativeStrengthIndex(option []float64, period int64) ([]float
 	}
 	return indicators.RSI(option, int(period)), nil
 }
+
+// candles converts the OHLC data to candles for the incremental indicators,
+// ensuring the required price sets are populated and aligned.
+func (o *OHLC) candles(requireVolume bool) ([]Candle, error) {
+	if len(o.High) == 0 {
+		return nil, fmt.Errorf("high %w", errNoData)
+	}
+	if len(o.Low) == 0 {
+		return nil, fmt.Errorf("low %w", errNoData)
+	}
+	if len(o.Close) == 0 {
+		return nil, fmt.Errorf("close %w", errNoData)
+	}
+	if requireVolume && len(o.Volume) == 0 {
+		return nil, fmt.Errorf("volume %w", errNoData)
+	}
+	if len(o.High) != len(o.Close) || len(o.Low) != len(o.Close) ||
+		(len(o.Open) != 0 && len(o.Open) != len(o.Close)) ||
+		(len(o.Volume) != 0 && len(o.Volume) != len(o.Close)) {
+		return nil, errInvalidDataSetLengths
+	}
+	candles := make([]Candle, len(o.Close))
+	for x := range candles {
+		candles[x].High = o.High[x]
+		candles[x].Low = o.Low[x]
+		candles[x].Close = o.Close[x]
+		if len(o.Open) != 0 {
+			candles[x].Open = o.Open[x]
+		}
+		if len(o.Volume) != 0 {
+			candles[x].Volume = o.Volume[x]
+		}
+	}
+	return candles, nil
+}
+
+// runIndicator applies the indicator across the candles and returns each
+// indicator output as its own series.
+func runIndicator(state indicatorState, candles []Candle) [][]float64 {
+	series := make([][]float64, len(state.outputs()))
+	for x := range series {
+		series[x] = make([]float64, len(candles))
+	}
+	for x := range candles {
+		values := state.step(&candles[x])
+		for y := range values {
+			series[y][x] = values[y]
+		}
+	}
+	return series
+}
+
+// Stochastic defines the %K and %D lines of a stochastic oscillator
+type Stochastic struct {
+	K []float64
+	D []float64
+}
+
+// GetStochastic returns the stochastic oscillator for the given %K period,
+// %K smoothing period and %D period.
+func (k *Item) GetStochastic(kPeriod, smoothK, dPeriod int64) (*Stochastic, error) {
+	return k.GetOHLC().GetStochastic(kPeriod, smoothK, dPeriod)
+}
+
+// GetStochastic returns the stochastic oscillator for the given %K period,
+// %K smoothing period and %D period.
+func (o *OHLC) GetStochastic(kPeriod, smoothK, dPeriod int64) (*Stochastic, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get stochastic %w", errNilOHLC)
+	}
+	state, err := newStochasticState(kPeriod, smoothK, dPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get stochastic %w", err)
+	}
+	if int(kPeriod) > len(candles) {
+		return nil, fmt.Errorf("get stochastic %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	series := runIndicator(state, candles)
+	return &Stochastic{K: series[0], D: series[1]}, nil
+}
+
+// GetStochasticRSIOnClose returns the stochastic RSI on the close price set
+// for the given RSI period, stochastic period, %K smoothing period and %D
+// period.
+func (k *Item) GetStochasticRSIOnClose(rsiPeriod, stochPeriod, smoothK, dPeriod int64) (*Stochastic, error) {
+	ohlc := k.GetOHLC()
+	return ohlc.GetStochasticRSI(ohlc.Close, rsiPeriod, stochPeriod, smoothK, dPeriod)
+}
+
+// GetStochasticRSI returns the stochastic RSI on the supplied price set for the
+// given RSI period, stochastic period, %K smoothing period and %D period.
+func (o *OHLC) GetStochasticRSI(option []float64, rsiPeriod, stochPeriod, smoothK, dPeriod int64) (*Stochastic, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get stochastic rsi %w", errNilOHLC)
+	}
+	state, err := newStochasticRSIState(rsiPeriod, stochPeriod, smoothK, dPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	if len(option) == 0 {
+		return nil, fmt.Errorf("get stochastic rsi %w", errNoData)
+	}
+	if int(rsiPeriod+stochPeriod) > len(option) {
+		return nil, fmt.Errorf("get stochastic rsi %w combined rsi and stochastic periods exceed data length, please reduce",
+			errInvalidPeriod)
+	}
+	candles := make([]Candle, len(option))
+	for x := range option {
+		candles[x].Close = option[x]
+	}
+	series := runIndicator(state, candles)
+	return &Stochastic{K: series[0], D: series[1]}, nil
+}
+
+// DirectionalMovement defines the directional indicators and the average
+// directional index
+type DirectionalMovement struct {
+	PlusDI  []float64
+	MinusDI []float64
+	ADX     []float64
+}
+
+// GetDirectionalMovementIndex returns the directional movement index (+DI, -DI
+// and ADX) for the given period.
+func (k *Item) GetDirectionalMovementIndex(period int64) (*DirectionalMovement, error) {
+	return k.GetOHLC().GetDirectionalMovementIndex(period)
+}
+
+// GetDirectionalMovementIndex returns the directional movement index (+DI, -DI
+// and ADX) for the given period.
+func (o *OHLC) GetDirectionalMovementIndex(period int64) (*DirectionalMovement, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get directional movement index %w", errNilOHLC)
+	}
+	state, err := newDirectionalMovementState(period)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get directional movement index %w", err)
+	}
+	if int(period) >= len(candles) {
+		return nil, fmt.Errorf("get directional movement index %w '%v' should not exceed or equal data length '%v'",
+			errInvalidPeriod, period, len(candles))
+	}
+	series := runIndicator(state, candles)
+	return &DirectionalMovement{PlusDI: series[0], MinusDI: series[1], ADX: series[2]}, nil
+}
+
+// Ichimoku defines the lines of an ichimoku cloud. Leading spans are aligned
+// to the candle they are projected onto and the lagging span is aligned to the
+// candle it is plotted against, with the final displacement values unset.
+type Ichimoku struct {
+	Conversion   []float64
+	Base         []float64
+	LeadingSpanA []float64
+	LeadingSpanB []float64
+	LaggingSpan  []float64
+}
+
+// GetIchimokuCloud returns the ichimoku cloud for the given conversion, base,
+// leading span B periods and displacement.
+func (k *Item) GetIchimokuCloud(conversion, base, spanB, displacement int64) (*Ichimoku, error) {
+	return k.GetOHLC().GetIchimokuCloud(conversion, base, spanB, displacement)
+}
+
+// GetIchimokuCloud returns the ichimoku cloud for the given conversion, base,
+// leading span B periods and displacement.
+func (o *OHLC) GetIchimokuCloud(conversion, base, spanB, displacement int64) (*Ichimoku, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get ichimoku cloud %w", errNilOHLC)
+	}
+	state, err := newIchimokuState(conversion, base, spanB, displacement)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get ichimoku cloud %w", err)
+	}
+	if int(spanB) > len(candles) {
+		return nil, fmt.Errorf("get ichimoku cloud %w leading span b period exceeds data length, please reduce",
+			errInvalidPeriod)
+	}
+	series := runIndicator(state, candles)
+	lagging := make([]float64, len(candles))
+	for x := int(displacement); x < len(candles); x++ {
+		lagging[x-int(displacement)] = candles[x].Close
+	}
+	return &Ichimoku{
+		Conversion:   series[0],
+		Base:         series[1],
+		LeadingSpanA: series[2],
+		LeadingSpanB: series[3],
+		LaggingSpan:  lagging,
+	}, nil
+}
+
+// Channel defines a return type for price channels and bands
+type Channel struct {
+	Upper  []float64
+	Middle []float64
+	Lower  []float64
+}
+
+// GetKeltnerChannels returns the keltner channels using the EMA period for the
+// middle line and the ATR period and multiplier for the channel width.
+func (k *Item) GetKeltnerChannels(emaPeriod, atrPeriod int64, multiplier float64) (*Channel, error) {
+	return k.GetOHLC().GetKeltnerChannels(emaPeriod, atrPeriod, multiplier)
+}
+
+// GetKeltnerChannels returns the keltner channels using the EMA period for the
+// middle line and the ATR period and multiplier for the channel width.
+func (o *OHLC) GetKeltnerChannels(emaPeriod, atrPeriod int64, multiplier float64) (*Channel, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get keltner channels %w", errNilOHLC)
+	}
+	state, err := newKeltnerState(emaPeriod, atrPeriod, multiplier)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get keltner channels %w", err)
+	}
+	if int(emaPeriod) > len(candles) || int(atrPeriod) > len(candles) {
+		return nil, fmt.Errorf("get keltner channels %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	series := runIndicator(state, candles)
+	return &Channel{Upper: series[0], Middle: series[1], Lower: series[2]}, nil
+}
+
+// GetDonchianChannels returns the donchian channels for the given period.
+func (k *Item) GetDonchianChannels(period int64) (*Channel, error) {
+	return k.GetOHLC().GetDonchianChannels(period)
+}
+
+// GetDonchianChannels returns the donchian channels for the given period.
+func (o *OHLC) GetDonchianChannels(period int64) (*Channel, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get donchian channels %w", errNilOHLC)
+	}
+	state, err := newDonchianState(period)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get donchian channels %w", err)
+	}
+	if int(period) > len(candles) {
+		return nil, fmt.Errorf("get donchian channels %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	series := runIndicator(state, candles)
+	return &Channel{Upper: series[0], Middle: series[1], Lower: series[2]}, nil
+}
+
+// Supertrend defines the supertrend line and its direction, 1 for an uptrend
+// and -1 for a downtrend.
+type Supertrend struct {
+	Values    []float64
+	Direction []float64
+}
+
+// GetSupertrend returns the supertrend for the given ATR period and
+// multiplier.
+func (k *Item) GetSupertrend(period int64, multiplier float64) (*Supertrend, error) {
+	return k.GetOHLC().GetSupertrend(period, multiplier)
+}
+
+// GetSupertrend returns the supertrend for the given ATR period and
+// multiplier.
+func (o *OHLC) GetSupertrend(period int64, multiplier float64) (*Supertrend, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get supertrend %w", errNilOHLC)
+	}
+	state, err := newSupertrendState(period, multiplier)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get supertrend %w", err)
+	}
+	if int(period) > len(candles) {
+		return nil, fmt.Errorf("get supertrend %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	series := runIndicator(state, candles)
+	return &Supertrend{Values: series[0], Direction: series[1]}, nil
+}
+
+// GetVWAPBands returns the cumulative volume weighted average price as the
+// middle line with bands set at the multiplier of the volume weighted standard
+// deviation.
+func (k *Item) GetVWAPBands(multiplier float64) (*Channel, error) {
+	return k.GetOHLC().GetVWAPBands(multiplier)
+}
+
+// GetVWAPBands returns the cumulative volume weighted average price as the
+// middle line with bands set at the multiplier of the volume weighted standard
+// deviation.
+func (o *OHLC) GetVWAPBands(multiplier float64) (*Channel, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get vwap bands %w", errNilOHLC)
+	}
+	state, err := newVWAPBandsState(multiplier)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(true)
+	if err != nil {
+		return nil, fmt.Errorf("get vwap bands %w", err)
+	}
+	series := runIndicator(state, candles)
+	return &Channel{Upper: series[0], Middle: series[1], Lower: series[2]}, nil
+}
+
+// GetCommodityChannelIndex returns the commodity channel index for the given
+// period.
+func (k *Item) GetCommodityChannelIndex(period int64) ([]float64, error) {
+	return k.GetOHLC().GetCommodityChannelIndex(period)
+}
+
+// GetCommodityChannelIndex returns the commodity channel index for the given
+// period.
+func (o *OHLC) GetCommodityChannelIndex(period int64) ([]float64, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get commodity channel index %w", errNilOHLC)
+	}
+	state, err := newCommodityChannelIndexState(period)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get commodity channel index %w", err)
+	}
+	if int(period) > len(candles) {
+		return nil, fmt.Errorf("get commodity channel index %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	return runIndicator(state, candles)[0], nil
+}
+
+// GetWilliamsPercentRange returns the williams %R for the given period.
+func (k *Item) GetWilliamsPercentRange(period int64) ([]float64, error) {
+	return k.GetOHLC().GetWilliamsPercentRange(period)
+}
+
+// GetWilliamsPercentRange returns the williams %R for the given period.
+func (o *OHLC) GetWilliamsPercentRange(period int64) ([]float64, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get williams %%R %w", errNilOHLC)
+	}
+	state, err := newWilliamsPercentRangeState(period)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get williams %%R %w", err)
+	}
+	if int(period) > len(candles) {
+		return nil, fmt.Errorf("get williams %%R %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	return runIndicator(state, candles)[0], nil
+}
+
+// GetParabolicSAR returns the parabolic stop and reverse for the given
+// acceleration step and maximum acceleration.
+func (k *Item) GetParabolicSAR(step, maximum float64) ([]float64, error) {
+	return k.GetOHLC().GetParabolicSAR(step, maximum)
+}
+
+// GetParabolicSAR returns the parabolic stop and reverse for the given
+// acceleration step and maximum acceleration.
+func (o *OHLC) GetParabolicSAR(step, maximum float64) ([]float64, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get parabolic sar %w", errNilOHLC)
+	}
+	state, err := newParabolicSARState(step, maximum)
+	if err != nil {
+		return nil, fmt.Errorf("get %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get parabolic sar %w", err)
+	}
+	if len(candles) < 2 {
+		return nil, fmt.Errorf("get parabolic sar %w, requires atleast 2 data points", errNotEnoughData)
+	}
+	return runIndicator(state, candles)[0], nil
+}
+
+// GetHeikinAshi returns a copy of the item with its candles converted to
+// heikin-ashi candles.
+func (k *Item) GetHeikinAshi() (*Item, error) {
+	if len(k.Candles) == 0 {
+		return nil, fmt.Errorf("get heikin ashi %w", errNoData)
+	}
+	ha := *k
+	ha.Candles = make([]Candle, len(k.Candles))
+	var state heikinAshiState
+	for x := range k.Candles {
+		values := state.step(&k.Candles[x])
+		ha.Candles[x] = Candle{
+			Time:   k.Candles[x].Time,
+			Open:   values[0],
+			High:   values[1],
+			Low:    values[2],
+			Close:  values[3],
+			Volume: k.Candles[x].Volume,
+		}
+	}
+	return &ha, nil
+}
+
+// GetHeikinAshi returns the OHLC data converted to heikin-ashi prices.
+func (o *OHLC) GetHeikinAshi() (*OHLC, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get heikin ashi %w", errNilOHLC)
+	}
+	if len(o.Open) == 0 {
+		return nil, fmt.Errorf("get heikin ashi open %w", errNoData)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get heikin ashi %w", err)
+	}
+	series := runIndicator(&heikinAshiState{}, candles)
+	return &OHLC{
+		Open:   series[0],
+		High:   series[1],
+		Low:    series[2],
+		Close:  series[3],
+		Volume: append([]float64(nil), o.Volume...),
+	}, nil
+}
+
+// PivotPoints defines the pivot, resistance and support levels derived from
+// the previous candle
+type PivotPoints struct {
+	Pivot       []float64
+	Resistance1 []float64
+	Resistance2 []float64
+	Resistance3 []float64
+	Support1    []float64
+	Support2    []float64
+	Support3    []float64
+}
+
+// GetPivotPoints returns the pivot points for each candle derived from the
+// previous candle using the supplied method. To derive daily pivots for
+// intraday candles, the previous candle must be a daily candle so the item
+// should be converted to a daily interval first.
+func (k *Item) GetPivotPoints(method PivotMethod) (*PivotPoints, error) {
+	return k.GetOHLC().GetPivotPoints(method)
+}
+
+// GetPivotPoints returns the pivot points for each candle derived from the
+// previous candle using the supplied method.
+func (o *OHLC) GetPivotPoints(method PivotMethod) (*PivotPoints, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get pivot points %w", errNilOHLC)
+	}
+	state, err := newPivotPointsState(method)
+	if err != nil {
+		return nil, fmt.Errorf("get pivot points %w", err)
+	}
+	candles, err := o.candles(false)
+	if err != nil {
+		return nil, fmt.Errorf("get pivot points %w", err)
+	}
+	series := runIndicator(state, candles)
+	return &PivotPoints{
+		Pivot:       series[0],
+		Resistance1: series[1],
+		Resistance2: series[2],
+		Resistance3: series[3],
+		Support1:    series[4],
+		Support2:    series[5],
+		Support3:    series[6],
+	}, nil
+}
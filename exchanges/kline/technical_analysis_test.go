@@ -2,7 +2,9 @@ package kline
 
 import (
 	"errors"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestGetOHLC(t *testing.T) {
@@ -427,3 +429,526 @@ func TestGetRelativeStrengthIndex(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
 	}
 }
+
+// getTestCandles returns a deterministic trending and oscillating candle set
+func getTestCandles(count int) []Candle {
+	candles := make([]Candle, count)
+	tn := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for x := range candles {
+		base := 100 + float64(x) + 10*math.Sin(float64(x)/3)
+		candles[x] = Candle{
+			Time:   tn.Add(time.Duration(x) * time.Hour),
+			Open:   base - 1,
+			High:   base + 3,
+			Low:    base - 3,
+			Close:  base + 1,
+			Volume: 10 + float64(x%5),
+		}
+	}
+	return candles
+}
+
+func TestGetStochastic(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetStochastic(0, 0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetStochastic(0, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	_, err = ohlc.GetStochastic(14, 3, 3)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	ohlc.High = []float64{1, 2}
+	ohlc.Low = []float64{1, 2}
+	ohlc.Close = []float64{1}
+	_, err = ohlc.GetStochastic(14, 3, 3)
+	if !errors.Is(err, errInvalidDataSetLengths) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidDataSetLengths)
+	}
+
+	ohlc.Close = []float64{1, 2}
+	_, err = ohlc.GetStochastic(14, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{10, 12, 14, 16},
+		Low:   []float64{8, 10, 12, 14},
+		Close: []float64{9, 11, 13, 14},
+	}
+	stoch, err := ohlc.GetStochastic(2, 1, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if stoch.K[0] != 0 || stoch.K[1] != 75 || stoch.K[3] != 50 {
+		t.Fatalf("received: '%v' but expected: '[0 75 75 50]'", stoch.K)
+	}
+	if stoch.D[1] != 0 || stoch.D[2] != 75 || stoch.D[3] != 62.5 {
+		t.Fatalf("received: '%v' but expected: '[0 0 75 62.5]'", stoch.D)
+	}
+
+	wrap := Item{Candles: getTestCandles(30)}
+	_, err = wrap.GetStochastic(14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestGetStochasticRSI(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetStochasticRSI(nil, 0, 0, 0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetStochasticRSI(nil, 1, 14, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	_, err = ohlc.GetStochasticRSI(nil, 14, 14, 3, 3)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	_, err = ohlc.GetStochasticRSI([]float64{1, 2, 3}, 14, 14, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	wrap := Item{Candles: getTestCandles(60)}
+	stoch, err := wrap.GetStochasticRSIOnClose(14, 14, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for x := range stoch.K {
+		if stoch.K[x] < 0 || stoch.K[x] > 100 {
+			t.Fatalf("received: '%v' but expected value between 0 and 100", stoch.K[x])
+		}
+	}
+}
+
+func TestGetDirectionalMovementIndex(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetDirectionalMovementIndex(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetDirectionalMovementIndex(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	_, err = ohlc.GetDirectionalMovementIndex(14)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap := Item{Candles: getTestCandles(14)}
+	_, err = wrap.GetDirectionalMovementIndex(14)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	// A persistent uptrend should only register positive directional movement
+	ohlc = &OHLC{
+		High:  []float64{2, 3, 4, 5, 6, 7},
+		Low:   []float64{1, 2, 3, 4, 5, 6},
+		Close: []float64{2, 3, 4, 5, 6, 7},
+	}
+	dmi, err := ohlc.GetDirectionalMovementIndex(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if dmi.PlusDI[5] != 100 || dmi.MinusDI[5] != 0 || dmi.ADX[5] != 100 {
+		t.Fatalf("received: '%v %v %v' but expected: '100 0 100'", dmi.PlusDI[5], dmi.MinusDI[5], dmi.ADX[5])
+	}
+}
+
+func TestGetIchimokuCloud(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetIchimokuCloud(0, 0, 0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetIchimokuCloud(9, 26, 52, -1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	_, err = ohlc.GetIchimokuCloud(9, 26, 52, 26)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap := Item{Candles: getTestCandles(51)}
+	_, err = wrap.GetIchimokuCloud(9, 26, 52, 26)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{2, 4, 6, 8},
+		Low:   []float64{1, 3, 5, 7},
+		Close: []float64{1, 2, 3, 4},
+	}
+	cloud, err := ohlc.GetIchimokuCloud(1, 2, 3, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cloud.Conversion[3] != 7.5 || cloud.Base[3] != 6.5 {
+		t.Fatalf("received: '%v %v' but expected: '7.5 6.5'", cloud.Conversion[3], cloud.Base[3])
+	}
+	if cloud.LeadingSpanA[3] != 5 || cloud.LeadingSpanB[3] != 3.5 {
+		t.Fatalf("received: '%v %v' but expected: '5 3.5'", cloud.LeadingSpanA[3], cloud.LeadingSpanB[3])
+	}
+	if cloud.LaggingSpan[0] != 2 || cloud.LaggingSpan[3] != 0 {
+		t.Fatalf("received: '%v' but expected: '[2 3 4 0]'", cloud.LaggingSpan)
+	}
+}
+
+func TestGetKeltnerChannels(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetKeltnerChannels(0, 0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetKeltnerChannels(20, 0, 2)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	_, err = ohlc.GetKeltnerChannels(20, 10, 0)
+	if !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+
+	_, err = ohlc.GetKeltnerChannels(20, 10, 2)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap := Item{Candles: getTestCandles(30)}
+	channel, err := wrap.GetKeltnerChannels(20, 10, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if channel.Upper[29] <= channel.Middle[29] || channel.Lower[29] >= channel.Middle[29] {
+		t.Fatal("unexpected channel values")
+	}
+}
+
+func TestGetDonchianChannels(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetDonchianChannels(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetDonchianChannels(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{5, 3, 9},
+		Low:   []float64{1, 2, 4},
+		Close: []float64{3, 3, 5},
+	}
+	channel, err := ohlc.GetDonchianChannels(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if channel.Upper[1] != 5 || channel.Lower[1] != 1 || channel.Middle[1] != 3 {
+		t.Fatal("unexpected channel values")
+	}
+	if channel.Upper[2] != 9 || channel.Lower[2] != 2 || channel.Middle[2] != 5.5 {
+		t.Fatal("unexpected channel values")
+	}
+}
+
+func TestGetSupertrend(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetSupertrend(0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetSupertrend(10, 0)
+	if !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+
+	_, err = ohlc.GetSupertrend(10, 3)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap := Item{Candles: getTestCandles(50)}
+	st, err := wrap.GetSupertrend(10, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for x := 9; x < len(st.Direction); x++ {
+		if st.Direction[x] != 1 && st.Direction[x] != -1 {
+			t.Fatalf("received: '%v' but expected: '1 or -1'", st.Direction[x])
+		}
+	}
+}
+
+func TestGetVWAPBands(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetVWAPBands(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetVWAPBands(0)
+	if !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+
+	ohlc = &OHLC{High: []float64{1}, Low: []float64{1}, Close: []float64{1}}
+	_, err = ohlc.GetVWAPBands(2)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap := Item{Candles: getTestCandles(20)}
+	bands, err := wrap.GetVWAPBands(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	vwaps, err := wrap.GetVWAPs()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for x := range vwaps {
+		if math.Abs(vwaps[x]-bands.Middle[x]) > 1e-9 {
+			t.Fatalf("received: '%v' but expected: '%v'", bands.Middle[x], vwaps[x])
+		}
+	}
+}
+
+func TestGetCommodityChannelIndex(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetCommodityChannelIndex(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetCommodityChannelIndex(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{3, 6},
+		Low:   []float64{3, 6},
+		Close: []float64{3, 6},
+	}
+	cci, err := ohlc.GetCommodityChannelIndex(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if expected := 1.5 / (0.015 * 1.5); math.Abs(cci[1]-expected) > 1e-9 {
+		t.Fatalf("received: '%v' but expected: '%v'", cci[1], expected)
+	}
+}
+
+func TestGetWilliamsPercentRange(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetWilliamsPercentRange(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetWilliamsPercentRange(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{10, 12},
+		Low:   []float64{8, 9},
+		Close: []float64{9, 11},
+	}
+	willr, err := ohlc.GetWilliamsPercentRange(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if willr[1] != -25 {
+		t.Fatalf("received: '%v' but expected: '%v'", willr[1], -25)
+	}
+}
+
+func TestGetParabolicSAR(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetParabolicSAR(0, 0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetParabolicSAR(0, 0.2)
+	if !errors.Is(err, errInvalidAcceleration) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAcceleration)
+	}
+
+	_, err = ohlc.GetParabolicSAR(0.3, 0.2)
+	if !errors.Is(err, errInvalidAcceleration) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAcceleration)
+	}
+
+	ohlc = &OHLC{High: []float64{1}, Low: []float64{1}, Close: []float64{1}}
+	_, err = ohlc.GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{10, 11, 12, 13, 8},
+		Low:   []float64{9, 10, 11, 12, 7},
+		Close: []float64{9.5, 10.5, 11.5, 12.5, 7.5},
+	}
+	sar, err := ohlc.GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if sar[1] != 9 {
+		t.Fatalf("received: '%v' but expected: '%v'", sar[1], 9)
+	}
+	// The final candle breaks below the SAR and reverses to the extreme high
+	if sar[4] != 13 {
+		t.Fatalf("received: '%v' but expected: '%v'", sar[4], 13)
+	}
+}
+
+func TestGetHeikinAshi(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetHeikinAshi()
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetHeikinAshi()
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	ohlc = &OHLC{
+		Open:  []float64{10, 12},
+		High:  []float64{14, 16},
+		Low:   []float64{8, 10},
+		Close: []float64{12, 14},
+	}
+	ha, err := ohlc.GetHeikinAshi()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if ha.Open[0] != 11 || ha.Close[0] != 11 || ha.Open[1] != 11 || ha.Close[1] != 13 {
+		t.Fatalf("received: '%v %v' unexpected heikin ashi values", ha.Open, ha.Close)
+	}
+
+	wrap := Item{}
+	_, err = wrap.GetHeikinAshi()
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+
+	wrap.Candles = getTestCandles(5)
+	haItem, err := wrap.GetHeikinAshi()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !haItem.Candles[4].Time.Equal(wrap.Candles[4].Time) || haItem.Candles[4].Volume != wrap.Candles[4].Volume {
+		t.Fatal("expected time and volume to be retained")
+	}
+}
+
+func TestGetPivotPoints(t *testing.T) {
+	t.Parallel()
+
+	var ohlc *OHLC
+	_, err := ohlc.GetPivotPoints(PivotClassic)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+
+	ohlc = &OHLC{}
+	_, err = ohlc.GetPivotPoints("meow")
+	if !errors.Is(err, errInvalidPivotMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPivotMethod)
+	}
+
+	ohlc = &OHLC{
+		High:  []float64{12, 1},
+		Low:   []float64{6, 1},
+		Close: []float64{9, 1},
+	}
+	for _, tc := range []struct {
+		method   PivotMethod
+		pivot    float64
+		support1 float64
+	}{
+		{"", 9, 6},
+		{PivotClassic, 9, 6},
+		{PivotFibonacci, 9, 9 - 0.382*6},
+		{PivotWoodie, 9, 6},
+		{PivotCamarilla, 9, 9 - 6*1.1/12},
+	} {
+		pivots, err := ohlc.GetPivotPoints(tc.method)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if pivots.Pivot[0] != 0 || pivots.Pivot[1] != tc.pivot || math.Abs(pivots.Support1[1]-tc.support1) > 1e-9 {
+			t.Fatalf("%s received: '%v %v' but expected: '%v %v'", tc.method, pivots.Pivot[1], pivots.Support1[1], tc.pivot, tc.support1)
+		}
+	}
+}
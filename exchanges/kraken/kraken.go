@@ -34,6 +34,11 @@ const (
 type Kraken struct {
 	exchange.Base
 	wsRequestMtx sync.Mutex
+
+	// checksumDecimals stores the price and amount decimal places of the
+	// latest orderbook update of each pair for checksum validation
+	checksumDecimals    map[string]checksumDecimals
+	checksumDecimalsMtx sync.Mutex
 }
 
 // GetCurrentServerTime returns current server time
@@ -580,6 +585,27 @@ func (k *Kraken) Withdraw(ctx context.Context, asset, key string, amount float64
 	return response.ReferenceID, GetError(response.Error)
 }
 
+// WalletTransfer transfers an asset from the spot wallet to the futures wallet
+func (k *Kraken) WalletTransfer(ctx context.Context, asset string, amount float64) (string, error) {
+	var response struct {
+		Error  []string `json:"error"`
+		Result struct {
+			ReferenceID string `json:"refid"`
+		} `json:"result"`
+	}
+	params := url.Values{}
+	params.Set("asset", asset)
+	params.Set("from", "Spot Wallet")
+	params.Set("to", "Futures Wallet")
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	if err := k.SendAuthenticatedHTTPRequest(ctx, exchange.RestSpot, krakenWalletTransfer, params, &response); err != nil {
+		return response.Result.ReferenceID, err
+	}
+
+	return response.Result.ReferenceID, GetError(response.Error)
+}
+
 // GetDepositMethods gets withdrawal fees
 func (k *Kraken) GetDepositMethods(ctx context.Context, currency string) ([]DepositMethods, error) {
 	var response struct {
@@ -1039,7 +1065,7 @@ func (k *Kraken) SendAuthenticatedHTTPRequest(ctx context.Context, ep exchange.U
 	path := fmt.Sprintf("/%s/private/%s", krakenAPIVersion, method)
 
 	interim := json.RawMessage{}
-	err = k.SendPayload(ctx, request.Unset, func() (*request.Item, error) {
+	err = k.SendPayload(ctx, privateRateLimit(method), func() (*request.Item, error) {
 		nonce := k.Requester.GetNonce(true).String()
 		params.Set("nonce", nonce)
 		encoded := params.Encode()
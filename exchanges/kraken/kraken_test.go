@@ -1085,6 +1085,38 @@ func TestWithdraw(t *testing.T) {
 }
 
 // TestWithdrawFiat wrapper test
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		request  withdraw.TransferRequest
+		expected error
+	}{
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Margin}, withdraw.ErrTransferWalletUnsupported},
+		{withdraw.TransferRequest{From: asset.Spot, To: asset.Spot, ToSubAccount: "sub"}, withdraw.ErrTransferWalletUnsupported},
+	} {
+		tt.request.Exchange = k.Name
+		tt.request.Currency = currency.USD
+		tt.request.Amount = 1
+		_, err := k.Transfer(context.Background(), &tt.request)
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("received: '%v' but expected: '%v'", err, tt.expected)
+		}
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.Skip("API keys not set or canManipulateRealOrders false, skipping test")
+	}
+	_, err := k.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: k.Name,
+		Currency: currency.USD,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.Futures,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestWithdrawFiat(t *testing.T) {
 	t.Parallel()
 	if areTestAPIKeysSet() && !canManipulateRealOrders {
@@ -37,6 +37,7 @@ const (
 	krakenDepositAddresses = "DepositAddresses"
 	krakenWithdrawStatus   = "WithdrawStatus"
 	krakenWithdrawCancel   = "WithdrawCancel"
+	krakenWalletTransfer   = "WalletTransfer"
 	krakenWebsocketToken   = "GetWebSocketsToken"
 
 	// Futures
@@ -539,6 +540,13 @@ type WebsocketErrorResponse struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// checksumDecimals holds the price and amount decimal places used to format
+// orderbook levels for checksum calculation
+type checksumDecimals struct {
+	price  int
+	amount int
+}
+
 // WebsocketChannelData Holds relevant data for channels to identify what we're
 // doing
 type WebsocketChannelData struct {
@@ -1072,25 +1072,34 @@ func (k *Kraken) wsProcessOrderBookUpdate(channelData *WebsocketChannelData, ask
 		}
 	}
 	update.UpdateTime = highestLastUpdate
-	err := k.Websocket.Orderbook.Update(&update)
-	if err != nil {
-		return err
-	}
-
-	book, err := k.Websocket.Orderbook.GetOrderbook(channelData.Pair, asset.Spot)
-	if err != nil {
-		return fmt.Errorf("cannot calculate websocket checksum: book not found for %s %s %w",
-			channelData.Pair,
-			asset.Spot,
-			err)
-	}
 
 	token, err := strconv.ParseInt(checksum, 10, 64)
 	if err != nil {
 		return err
 	}
+	update.Checksum = uint32(token)
+	k.setChecksumDecimals(channelData.Pair, priceDP, amtDP)
+	return k.Websocket.Orderbook.Update(&update)
+}
+
+// setChecksumDecimals stores the decimal places of the latest orderbook update
+// of a pair
+func (k *Kraken) setChecksumDecimals(p currency.Pair, priceDP, amtDP int) {
+	k.checksumDecimalsMtx.Lock()
+	defer k.checksumDecimalsMtx.Unlock()
+	if k.checksumDecimals == nil {
+		k.checksumDecimals = make(map[string]checksumDecimals)
+	}
+	k.checksumDecimals[p.String()] = checksumDecimals{price: priceDP, amount: amtDP}
+}
 
-	return validateCRC32(book, uint32(token), priceDP, amtDP)
+// validateOrderbookChecksum validates an updated orderbook against the
+// checksum of the update, called by the websocket orderbook buffer
+func (k *Kraken) validateOrderbookChecksum(b *orderbook.Base, token uint32) error {
+	k.checksumDecimalsMtx.Lock()
+	dp := k.checksumDecimals[b.Pair.String()]
+	k.checksumDecimalsMtx.Unlock()
+	return validateCRC32(b, token, dp.price, dp.amount)
 }
 
 func validateCRC32(b *orderbook.Base, token uint32, decPrice, decAmount int) error {
@@ -209,6 +209,11 @@ func (k *Kraken) Setup(exch *config.Exchange) error {
 		return err
 	}
 
+	err = k.SetupAdaptiveRateLimit(weightBudgets(), endpointWeights(), request.NewBasicRateLimit(krakenRateInterval, krakenRequestRate))
+	if err != nil {
+		return err
+	}
+
 	err = k.SeedAssets(context.TODO())
 	if err != nil {
 		return err
@@ -227,7 +232,11 @@ func (k *Kraken) Setup(exch *config.Exchange) error {
 		Unsubscriber:          k.Unsubscribe,
 		GenerateSubscriptions: k.GenerateDefaultSubscriptions,
 		Features:              &k.Features.Supports.WebsocketCapabilities,
-		OrderbookBufferConfig: buffer.Config{SortBuffer: true},
+		OrderbookBufferConfig: buffer.Config{
+			SortBuffer: true,
+			Checksum:   k.validateOrderbookChecksum,
+		},
+		OrderEntryByDefault: true,
 	})
 	if err != nil {
 		return err
@@ -732,20 +741,22 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 		if s.ImmediateOrCancel {
 			timeInForce = KrakenRequestParamsTimeIOC
 		}
-		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-			s.Pair.Delimiter = "/" // required pair format: ISO 4217-A3
+		err = k.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
+			wsPair := s.Pair
+			wsPair.Delimiter = "/" // required pair format: ISO 4217-A3
 			orderID, err = k.wsAddOrder(&WsAddOrderRequest{
 				OrderType:   s.Type.Lower(),
 				OrderSide:   s.Side.Lower(),
-				Pair:        s.Pair.String(),
+				Pair:        wsPair.String(),
 				Price:       s.Price,
 				Volume:      s.Amount,
 				TimeInForce: timeInForce,
 			})
 			if err != nil {
-				return nil, err
+				return err
 			}
-		} else {
+			return nil
+		}, func() error {
 			var response AddOrderResponse
 			response, err = k.AddOrder(ctx,
 				s.Pair,
@@ -759,11 +770,15 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 					TimeInForce: timeInForce,
 				})
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if len(response.TransactionIds) > 0 {
 				orderID = strings.Join(response.TransactionIds, ", ")
 			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 		if s.Type == order.Market {
 			status = order.Filled
@@ -815,11 +830,12 @@ func (k *Kraken) CancelOrder(ctx context.Context, o *order.Cancel) error {
 	}
 	switch o.AssetType {
 	case asset.Spot:
-		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+		return k.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
 			return k.wsCancelOrders([]string{o.OrderID})
-		}
-		_, err := k.CancelExistingOrder(ctx, o.OrderID)
-		return err
+		}, func() error {
+			_, err := k.CancelExistingOrder(ctx, o.OrderID)
+			return err
+		})
 	case asset.Futures:
 		_, err := k.FuturesCancelOrder(ctx, o.OrderID, "")
 		if err != nil {
@@ -857,31 +873,28 @@ func (k *Kraken) CancelAllOrders(ctx context.Context, req *order.Cancel) (order.
 	}
 	switch req.AssetType {
 	case asset.Spot:
-		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+		err := k.Websocket.OrderEntry(ctx, stream.CancelAllOrdersAction, func() error {
 			resp, err := k.wsCancelAllOrders()
 			if err != nil {
-				return cancelAllOrdersResponse, err
+				return err
 			}
-
 			cancelAllOrdersResponse.Count = resp.Count
-			return cancelAllOrdersResponse, err
-		}
-
-		var emptyOrderOptions OrderInfoOptions
-		openOrders, err := k.GetOpenOrders(ctx, emptyOrderOptions)
-		if err != nil {
-			return cancelAllOrdersResponse, err
-		}
-		for orderID := range openOrders.Open {
-			var err error
-			if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-				err = k.wsCancelOrders([]string{orderID})
-			} else {
-				_, err = k.CancelExistingOrder(ctx, orderID)
-			}
+			return nil
+		}, func() error {
+			var emptyOrderOptions OrderInfoOptions
+			openOrders, err := k.GetOpenOrders(ctx, emptyOrderOptions)
 			if err != nil {
-				cancelAllOrdersResponse.Status[orderID] = err.Error()
+				return err
+			}
+			for orderID := range openOrders.Open {
+				if _, err = k.CancelExistingOrder(ctx, orderID); err != nil {
+					cancelAllOrdersResponse.Status[orderID] = err.Error()
+				}
 			}
+			return nil
+		})
+		if err != nil {
+			return cancelAllOrdersResponse, err
 		}
 	case asset.Futures:
 		cancelData, err := k.FuturesCancelAllOrders(ctx, req.Pair)
@@ -1063,6 +1076,32 @@ func (k *Kraken) WithdrawCryptocurrencyFunds(ctx context.Context, withdrawReques
 	}, nil
 }
 
+// Transfer moves funds between the spot and futures wallets. Sub-account
+// transfers are not supported
+func (k *Kraken) Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if r.FromSubAccount != "" || r.ToSubAccount != "" {
+		return nil, fmt.Errorf("%w with a sub-account", withdraw.ErrTransferWalletUnsupported)
+	}
+	switch {
+	case r.From == asset.Spot && r.To == asset.Futures:
+		id, err := k.WalletTransfer(ctx, r.Currency.String(), r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{ID: id}, nil
+	case r.From == asset.Futures && r.To == asset.Spot:
+		resp, err := k.FuturesWithdrawToSpotWallet(ctx, r.Currency.String(), r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		return &withdraw.ExchangeResponse{Status: resp.Result}, nil
+	}
+	return nil, fmt.Errorf("%w %v to %v", withdraw.ErrTransferWalletUnsupported, r.From, r.To)
+}
+
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (k *Kraken) WithdrawFiatFunds(ctx context.Context, withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error) {
@@ -0,0 +1,58 @@
+package kraken
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+const (
+	// counterBudget is the private API call counter. Kraken does not report
+	// the counter, so it is modelled from the starter tier's maximum of 15
+	// and its decay of 0.33 per second
+	counterBudget   = "counter"
+	counterLimit    = 15
+	counterInterval = 45 * time.Second
+)
+
+// Kraken private endpoint rate limits, public endpoints use request.Unset
+const (
+	// krakenPrivateRate increases the API call counter by one
+	krakenPrivateRate request.EndpointLimit = iota + 1
+	// krakenHistoryRate increases the API call counter by two for ledger and
+	// trade history queries
+	krakenHistoryRate
+	// krakenOrderRate is used for order placement and cancellation, which are
+	// limited by the trading engine rather than the API call counter
+	krakenOrderRate
+)
+
+// privateRateLimit returns the rate limit of a private endpoint method
+func privateRateLimit(method string) request.EndpointLimit {
+	switch method {
+	case krakenLedgers, krakenQueryLedgers, krakenTradeHistory, krakenQueryTrades:
+		return krakenHistoryRate
+	case krakenOrderPlace, krakenOrderCancel:
+		return krakenOrderRate
+	default:
+		return krakenPrivateRate
+	}
+}
+
+// weightBudgets returns the API call counter budget used when adaptive rate
+// limiting is enabled
+func weightBudgets() []request.WeightBudget {
+	return []request.WeightBudget{
+		{Name: counterBudget, Capacity: counterLimit, Interval: counterInterval},
+	}
+}
+
+// endpointWeights returns the weight of each endpoint which increases the API
+// call counter. Public endpoints and orders are handled by the static rate
+// limiter
+func endpointWeights() map[request.EndpointLimit]request.EndpointWeight {
+	return map[request.EndpointLimit]request.EndpointWeight{
+		krakenPrivateRate: {Budget: counterBudget, Weight: 1},
+		krakenHistoryRate: {Budget: counterBudget, Weight: 2},
+	}
+}
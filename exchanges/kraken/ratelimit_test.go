@@ -0,0 +1,51 @@
+package kraken
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestPrivateRateLimit(t *testing.T) {
+	t.Parallel()
+	for method, expected := range map[string]request.EndpointLimit{
+		krakenBalance:      krakenPrivateRate,
+		krakenLedgers:      krakenHistoryRate,
+		krakenQueryTrades:  krakenHistoryRate,
+		krakenOrderPlace:   krakenOrderRate,
+		krakenOrderCancel:  krakenOrderRate,
+		krakenOpenOrders:   krakenPrivateRate,
+		krakenTradeHistory: krakenHistoryRate,
+	} {
+		if received := privateRateLimit(method); received != expected {
+			t.Errorf("%s received: '%v' but expected: '%v'", method, received, expected)
+		}
+	}
+}
+
+func TestAPICallCounter(t *testing.T) {
+	t.Parallel()
+	l, err := request.NewWeightedRateLimit(weightBudgets(), endpointWeights(), request.NewBasicRateLimit(krakenRateInterval, krakenRequestRate))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// History queries spend two from the counter, reaching its threshold
+	for i := 0; i < 6; i++ {
+		if err = l.Limit(context.Background(), krakenHistoryRate); !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = l.Limit(ctx, krakenPrivateRate)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+	// Orders are not limited by the counter
+	if err = l.Limit(context.Background(), krakenOrderRate); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
@@ -34,9 +34,30 @@ type VCRMock struct {
 	Routes map[string]map[string][]HTTPResponse `json:"routes"`
 }
 
+var (
+	errMethodNotRecorded    = errors.New("method not present in mock file")
+	errUnhandledContentType = errors.New("unhandled content type")
+	errUnhandledMethod      = errors.New("unhandled HTTP method")
+	errPayloadHeaderMissing = errors.New("cannot find payload header in request")
+)
+
 // NewVCRServer starts a new VCR server for replaying HTTP requests for testing
 // purposes and returns the server connection details
 func NewVCRServer(path string) (string, *http.Client, error) {
+	return newVCRServer(path, true)
+}
+
+// NewPermissiveVCRServer starts a new VCR server which responds to requests
+// without a recorded response with http.StatusNotFound instead of exiting.
+// This allows functionality a recording does not cover to be probed without
+// stopping the caller
+func NewPermissiveVCRServer(path string) (string, *http.Client, error) {
+	return newVCRServer(path, false)
+}
+
+// newVCRServer starts a new VCR server. Strict servers exit when a request
+// cannot be matched with a recorded response
+func newVCRServer(path string, strict bool) (string, *http.Client, error) {
 	if path == "" {
 		return "", nil, errors.New("no path to json mock file found")
 	}
@@ -82,7 +103,7 @@ func NewVCRServer(path string) (string, *http.Client, error) {
 	// methods
 	if len(mockFile.Routes) != 0 {
 		for pattern, mockResponses := range mockFile.Routes {
-			RegisterHandler(pattern, mockResponses, newMux)
+			registerHandler(pattern, mockResponses, newMux, strict)
 		}
 	} else {
 		newMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -101,124 +122,107 @@ func NewVCRServer(path string) (string, *http.Client, error) {
 // RegisterHandler registers a generalised mock response logic for specific
 // routes
 func RegisterHandler(pattern string, mock map[string][]HTTPResponse, mux *http.ServeMux) {
+	registerHandler(pattern, mock, mux, true)
+}
+
+// registerHandler registers a generalised mock response logic for specific
+// routes. Unmatched requests exit when strict, otherwise they are responded to
+// with http.StatusNotFound
+func registerHandler(pattern string, mock map[string][]HTTPResponse, mux *http.ServeMux, strict bool) {
 	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
-		httpResponses, ok := mock[r.Method]
-		if !ok {
-			log.Fatalf("Mock Test Failure - Method %s not present in mock file",
-				r.Method)
+		payload, err := matchRequest(r, mock)
+		if err != nil {
+			if strict {
+				log.Fatalf("Mock Test Failure - %v", err)
+			}
+			MessageWriteJSON(w, http.StatusNotFound, err.Error())
+			return
 		}
+		MessageWriteJSON(w, http.StatusOK, payload)
+	})
+}
 
-		switch r.Method {
-		case http.MethodGet:
-			vals, err := url.ParseRequestURI(r.RequestURI)
+// matchRequest matches a request with its recorded response and returns the
+// response payload
+func matchRequest(r *http.Request, mock map[string][]HTTPResponse) (json.RawMessage, error) {
+	httpResponses, ok := mock[r.Method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errMethodNotRecorded, r.Method)
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		vals, err := url.ParseRequestURI(r.RequestURI)
+		if err != nil {
+			return nil, fmt.Errorf("parse request URI error: %w", err)
+		}
+		payload, err := MatchAndGetResponse(httpResponses, vals.Query(), true)
+		if err != nil {
+			return nil, fmt.Errorf("MatchAndGetResponse error %w for %s", err, r.RequestURI)
+		}
+		return payload, nil
+
+	case http.MethodPost, http.MethodPut:
+		var reqVals url.Values
+		var isQueryData bool
+		switch r.Header.Get(contentType) {
+		case applicationURLEncoded:
+			readBody, err := io.ReadAll(r.Body)
 			if err != nil {
-				log.Fatal("Mock Test Failure - Parse request URI error", err)
+				return nil, err
 			}
-
-			payload, err := MatchAndGetResponse(httpResponses, vals.Query(), true)
+			reqVals, err = url.ParseQuery(string(readBody))
 			if err != nil {
-				log.Fatalf("Mock Test Failure - MatchAndGetResponse error %s for %s",
-					err, r.RequestURI)
+				return nil, fmt.Errorf("parse query error: %w", err)
 			}
 
-			MessageWriteJSON(w, http.StatusOK, payload)
-			return
+		case "":
+			reqVals = r.URL.Query()
+			isQueryData = true
 
-		case http.MethodPost, http.MethodPut:
-			switch r.Header.Get(contentType) {
-			case applicationURLEncoded:
-				readBody, err := io.ReadAll(r.Body)
-				if err != nil {
-					log.Fatal("Mock Test Failure - ReadAll error", err)
-				}
-
-				vals, err := url.ParseQuery(string(readBody))
-				if err != nil {
-					log.Fatal("Mock Test Failure - parse query error", err)
-				}
-
-				payload, err := MatchAndGetResponse(httpResponses, vals, false)
-				if err != nil {
-					log.Fatal("Mock Test Failure - MatchAndGetResponse error ", err)
-				}
-
-				MessageWriteJSON(w, http.StatusOK, payload)
-				return
-
-			case "":
-				payload, err := MatchAndGetResponse(httpResponses, r.URL.Query(), true)
-				if err != nil {
-					log.Fatal("Mock Test Failure - MatchAndGetResponse error ", err)
-				}
-
-				MessageWriteJSON(w, http.StatusOK, payload)
-				return
-
-			case applicationJSON:
-				readBody, err := io.ReadAll(r.Body)
-				if err != nil {
-					log.Fatalf("Mock Test Failure - %v", err)
-				}
-
-				reqVals, err := DeriveURLValsFromJSONMap(readBody)
-				if err != nil {
-					log.Fatalf("Mock Test Failure - %v", err)
-				}
-
-				payload, err := MatchAndGetResponse(httpResponses, reqVals, false)
-				if err != nil {
-					log.Fatal("Mock Test Failure - MatchAndGetResponse error ", err)
-				}
-
-				MessageWriteJSON(w, http.StatusOK, payload)
-				return
-
-			case textPlain:
-				headerData, ok := r.Header["X-Gemini-Payload"]
-				if !ok {
-					log.Fatal("Mock Test Failure - Cannot find header in request")
-				}
-
-				base64data := strings.Join(headerData, "")
-
-				jsonThings, err := crypto.Base64Decode(base64data)
-				if err != nil {
-					log.Fatal("Mock Test Failure - ", err)
-				}
-
-				reqVals, err := DeriveURLValsFromJSONMap(jsonThings)
-				if err != nil {
-					log.Fatalf("Mock Test Failure - %v", err)
-				}
-
-				payload, err := MatchAndGetResponse(httpResponses, reqVals, false)
-				if err != nil {
-					log.Fatal("Mock Test Failure - MatchAndGetResponse error ", err)
-				}
-
-				MessageWriteJSON(w, http.StatusOK, payload)
-				return
-
-			default:
-				log.Fatalf("Mock Test Failure - Unhandled content type %v",
-					r.Header.Get(contentType))
+		case applicationJSON:
+			readBody, err := io.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
 			}
-
-		case http.MethodDelete:
-			payload, err := MatchAndGetResponse(httpResponses, r.URL.Query(), true)
+			reqVals, err = DeriveURLValsFromJSONMap(readBody)
 			if err != nil {
-				log.Println(r.URL.Query())
-				log.Fatal("Mock Test Failure - MatchAndGetResponse error ", err)
+				return nil, err
 			}
 
-			MessageWriteJSON(w, http.StatusOK, payload)
-			return
+		case textPlain:
+			headerData, ok := r.Header["X-Gemini-Payload"]
+			if !ok {
+				return nil, errPayloadHeaderMissing
+			}
+			jsonThings, err := crypto.Base64Decode(strings.Join(headerData, ""))
+			if err != nil {
+				return nil, err
+			}
+			reqVals, err = DeriveURLValsFromJSONMap(jsonThings)
+			if err != nil {
+				return nil, err
+			}
 
 		default:
-			log.Fatal("Mock Test Failure - Unhandled HTTP method:",
-				r.Header.Get(contentType))
+			return nil, fmt.Errorf("%w %v", errUnhandledContentType, r.Header.Get(contentType))
 		}
-	})
+		payload, err := MatchAndGetResponse(httpResponses, reqVals, isQueryData)
+		if err != nil {
+			return nil, fmt.Errorf("MatchAndGetResponse error %w for %s", err, r.RequestURI)
+		}
+		return payload, nil
+
+	case http.MethodDelete:
+		payload, err := MatchAndGetResponse(httpResponses, r.URL.Query(), true)
+		if err != nil {
+			return nil, fmt.Errorf("MatchAndGetResponse error %w for %s", err, r.RequestURI)
+		}
+		return payload, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnhandledMethod, r.Method)
+	}
 }
 
 // MessageWriteJSON writes JSON to a connection
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -121,3 +123,48 @@ func TestNewVCRServer(t *testing.T) {
 		t.Fatal("Remove error", err)
 	}
 }
+
+func TestNewPermissiveVCRServer(t *testing.T) {
+	t.Parallel()
+	mockFile := VCRMock{Routes: map[string]map[string][]HTTPResponse{
+		"/test": {
+			http.MethodGet: {{Data: json.RawMessage(`{"price":8000}`), QueryString: queryString}},
+		},
+	}}
+	payload, err := json.Marshal(mockFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "permissive.json")
+	if err = os.WriteFile(path, payload, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deets, client, err := NewPermissiveVCRServer(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	for _, tc := range []struct {
+		method, query string
+		status        int
+	}{
+		{http.MethodGet, queryString, http.StatusOK},
+		{http.MethodGet, "currency=ltc", http.StatusNotFound},
+		{http.MethodPost, queryString, http.StatusNotFound},
+	} {
+		req, err := http.NewRequestWithContext(context.Background(), tc.method, deets+"/test?"+tc.query, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = resp.Body.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s %s received: '%v' but expected: '%v'", tc.method, tc.query, resp.StatusCode, tc.status)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+)
+
+// DefaultWebsocketDirectory defines the main websocket mock directory
+const DefaultWebsocketDirectory = "../../testdata/ws_mock/"
+
+// Websocket frame directions
+const (
+	Inbound  = "inbound"
+	Outbound = "outbound"
+)
+
+// redacted replaces the values of excluded keys in recorded frames
+const redacted = "redacted"
+
+var (
+	errExchangeNameUnset = errors.New("exchange name not supplied")
+	errSessionNameUnset  = errors.New("session name not supplied")
+	errNothingRecorded   = errors.New("no websocket connections recorded")
+)
+
+// defaultExcludedWebsocketKeys defines frame keys which hold credentials and
+// are redacted when recorded
+var defaultExcludedWebsocketKeys = []string{"apiKey",
+	"api_key",
+	"passphrase",
+	"secret",
+	"sign",
+	"signature",
+	"token",
+	"listenKey"}
+
+// WebsocketSession defines the recorded websocket connections of a session
+// which are replayed by the websocket mock server
+type WebsocketSession struct {
+	Connections []WebsocketConnectionRecord `json:"connections"`
+}
+
+// WebsocketConnectionRecord defines the frames sent and received over a single
+// dialled connection
+type WebsocketConnectionRecord struct {
+	Path   string           `json:"path"`
+	Frames []WebsocketFrame `json:"frames"`
+}
+
+// WebsocketFrame defines a recorded websocket frame. Delay is the number of
+// milliseconds since the previous frame on the connection
+type WebsocketFrame struct {
+	Direction string          `json:"direction"`
+	Delay     int64           `json:"delay"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Text      string          `json:"text,omitempty"`
+}
+
+// WebsocketRecorder records the frames of an exchange's websocket connections
+// to a session file for replay by the websocket mock server
+type WebsocketRecorder struct {
+	path     string
+	excluded []string
+	m        sync.Mutex
+	session  WebsocketSession
+	last     []time.Time
+}
+
+// NewWebsocketRecorder returns a recorder which saves to the named session
+// file under the exchange's websocket mock directory
+func NewWebsocketRecorder(exchangeName, session string) (*WebsocketRecorder, error) {
+	if exchangeName == "" {
+		return nil, errExchangeNameUnset
+	}
+	if session == "" {
+		return nil, errSessionNameUnset
+	}
+	exchangeName = strings.ToLower(exchangeName)
+	return &WebsocketRecorder{
+		path:     filepath.Join(DefaultWebsocketDirectory, exchangeName, session+".json"),
+		excluded: defaultExcludedWebsocketKeys,
+	}, nil
+}
+
+// RecordDial starts recording a newly dialled connection
+func (r *WebsocketRecorder) RecordDial(connectionURL string) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.session.Connections = append(r.session.Connections, WebsocketConnectionRecord{Path: websocketPath(connectionURL)})
+	r.last = append(r.last, time.Now())
+	return len(r.session.Connections) - 1
+}
+
+// RecordFrame records a frame sent or received over a dialled connection,
+// redacting any credentials
+func (r *WebsocketRecorder) RecordFrame(recording int, outbound bool, data []byte) {
+	frame := newWebsocketFrame(data, r.excluded)
+	frame.Direction = Inbound
+	if outbound {
+		frame.Direction = Outbound
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if recording < 0 || recording >= len(r.session.Connections) {
+		return
+	}
+	now := time.Now()
+	frame.Delay = now.Sub(r.last[recording]).Milliseconds()
+	r.last[recording] = now
+	r.session.Connections[recording].Frames = append(r.session.Connections[recording].Frames, frame)
+}
+
+// Save writes the recorded session to file, replacing any previous recording
+func (r *WebsocketRecorder) Save() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if len(r.session.Connections) == 0 {
+		return errNothingRecorded
+	}
+	payload, err := json.MarshalIndent(r.session, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(r.path, payload)
+}
+
+// newWebsocketFrame returns a frame holding JSON data, or text when the data
+// is not valid JSON
+func newWebsocketFrame(data []byte, excluded []string) WebsocketFrame {
+	if !json.Valid(data) {
+		return WebsocketFrame{Text: string(data)}
+	}
+	if redactedData, ok := redactWebsocketFrame(data, excluded); ok {
+		data = redactedData
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return WebsocketFrame{Text: string(data)}
+	}
+	return WebsocketFrame{Data: compacted.Bytes()}
+}
+
+// redactWebsocketFrame replaces the values of excluded keys in JSON data and
+// returns if any were replaced. Frames without excluded keys are left as
+// received
+func redactWebsocketFrame(data []byte, excluded []string) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+	if !redactValue(v, excluded) {
+		return nil, false
+	}
+	redactedData, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return redactedData, true
+}
+
+// redactValue recursively replaces the values of excluded keys
+func redactValue(v interface{}, excluded []string) bool {
+	var found bool
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range val {
+			if IsExcluded(k, excluded) {
+				if _, ok := inner.(string); ok {
+					val[k] = redacted
+					found = true
+					continue
+				}
+			}
+			if redactValue(inner, excluded) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for i := range val {
+			if redactValue(val[i], excluded) {
+				found = true
+			}
+		}
+	}
+	return found
+}
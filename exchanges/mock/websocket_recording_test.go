@@ -0,0 +1,96 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewWebsocketRecorder(t *testing.T) {
+	t.Parallel()
+	_, err := NewWebsocketRecorder("", "session")
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	_, err = NewWebsocketRecorder("Binance", "")
+	if !errors.Is(err, errSessionNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errSessionNameUnset)
+	}
+	r, err := NewWebsocketRecorder("Binance", "subscriptions")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	expected := filepath.Join(DefaultWebsocketDirectory, "binance", "subscriptions.json")
+	if r.path != expected {
+		t.Errorf("received: '%v' but expected: '%v'", r.path, expected)
+	}
+	if err = r.Save(); !errors.Is(err, errNothingRecorded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNothingRecorded)
+	}
+}
+
+func TestWebsocketRecorder(t *testing.T) {
+	t.Parallel()
+	r, err := NewWebsocketRecorder("test", "session")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r.path = filepath.Join(t.TempDir(), "session.json")
+
+	first := r.RecordDial("wss://stream.test.com/ws?listenKey=secret")
+	second := r.RecordDial("wss://stream.test.com/private")
+	r.RecordFrame(first, true, []byte(`{"op":"login","args":[{"apiKey":"key","sign":"secret","timestamp":"1"}]}`))
+	r.RecordFrame(second, true, []byte("ping"))
+	r.RecordFrame(first, false, []byte(`{"event":"login",  "tradeID":123456789012345678901}`))
+	r.RecordFrame(5, false, []byte(`{"event":"ignored"}`))
+
+	err = r.Save()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	contents, err := os.ReadFile(r.path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var session WebsocketSession
+	err = json.Unmarshal(contents, &session)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(session.Connections) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(session.Connections), 2)
+	}
+	conn := session.Connections[first]
+	if conn.Path != "/ws" || len(conn.Frames) != 2 {
+		t.Fatalf("unexpected connection recording: %+v", conn)
+	}
+	if conn.Frames[0].Direction != Outbound || conn.Frames[1].Direction != Inbound {
+		t.Errorf("unexpected frame directions: %+v", conn.Frames)
+	}
+	compact := func(data json.RawMessage) string {
+		t.Helper()
+		var compacted bytes.Buffer
+		if compactErr := json.Compact(&compacted, data); compactErr != nil {
+			t.Fatal(compactErr)
+		}
+		return compacted.String()
+	}
+	login := compact(conn.Frames[0].Data)
+	if strings.Contains(login, "secret") || strings.Contains(login, `"key"`) {
+		t.Errorf("credentials were not redacted: %s", login)
+	}
+	if !strings.Contains(login, `"timestamp":"1"`) {
+		t.Errorf("unexpected redaction: %s", login)
+	}
+	expected := `{"event":"login","tradeID":123456789012345678901}`
+	if received := compact(conn.Frames[1].Data); received != expected {
+		t.Errorf("received: '%s' but expected: '%s'", received, expected)
+	}
+	if frame := session.Connections[second].Frames[0]; frame.Text != "ping" || frame.Data != nil {
+		t.Errorf("unexpected text frame: %+v", frame)
+	}
+}
@@ -0,0 +1,350 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	errNoRecordedConnections = errors.New("no recorded websocket connections")
+	errInvalidTimeScale      = errors.New("time scale cannot be negative")
+)
+
+// requestIDKeys defines top level keys which hold request IDs. These differ
+// between sessions, so are ignored when matching requests and are replaced
+// in the responses replayed
+var requestIDKeys = []string{"id", "reqid", "req_id", "requestId", "request_id"}
+
+// websocketDeltaKeys defines keys which differ between sessions at any depth
+// and are ignored when matching requests
+var websocketDeltaKeys = []string{"nonce", "signature", "timestamp", "tonce"}
+
+// WebsocketServer replays recorded websocket sessions. Each dialled
+// connection is served the next unused recording for its path. Inbound frames
+// are replayed with their recorded timing, and replay waits at each outbound
+// frame until the client sends a matching request
+type WebsocketServer struct {
+	// URL is the websocket URL of the server
+	URL string
+
+	server    *httptest.Server
+	upgrader  websocket.Upgrader
+	timeScale float64
+
+	m         sync.Mutex
+	session   WebsocketSession
+	used      []bool
+	conns     map[*websocket.Conn]struct{}
+	unmatched []string
+}
+
+// NewWebsocketServer starts a websocket server replaying the session file.
+// Recorded delays are multiplied by the time scale, so 1 replays with the
+// recorded timing and 0 replays without delay
+func NewWebsocketServer(path string, timeScale float64) (*WebsocketServer, error) {
+	if timeScale < 0 {
+		return nil, errInvalidTimeScale
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var session WebsocketSession
+	err = json.Unmarshal(contents, &session)
+	if err != nil {
+		return nil, err
+	}
+	if len(session.Connections) == 0 {
+		return nil, fmt.Errorf("%w in %s", errNoRecordedConnections, path)
+	}
+	s := &WebsocketServer{
+		timeScale: timeScale,
+		session:   session,
+		used:      make([]bool, len(session.Connections)),
+		conns:     make(map[*websocket.Conn]struct{}),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	s.URL = "ws" + strings.TrimPrefix(s.server.URL, "http")
+	return s, nil
+}
+
+// Close closes all connections and shuts down the server
+func (s *WebsocketServer) Close() {
+	s.m.Lock()
+	for c := range s.conns {
+		if err := c.Close(); err != nil {
+			log.Println("Mock Test Failure - Closing websocket connection", err)
+		}
+	}
+	s.m.Unlock()
+	s.server.Close()
+}
+
+// Unmatched returns the client requests which did not match a recorded
+// request
+func (s *WebsocketServer) Unmatched() []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append(s.unmatched[:0:0], s.unmatched...)
+}
+
+// serve replays the next unused recording for the requested path
+func (s *WebsocketServer) serve(w http.ResponseWriter, r *http.Request) {
+	record, ok := s.nextConnection(r.URL.Path)
+	if !ok {
+		http.Error(w, "no recorded websocket connection for "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+	c, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("Mock Test Failure - Websocket upgrade error", err)
+		return
+	}
+	s.m.Lock()
+	s.conns[c] = struct{}{}
+	s.m.Unlock()
+	defer func() {
+		s.m.Lock()
+		delete(s.conns, c)
+		s.m.Unlock()
+		c.Close()
+	}()
+
+	requests := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(requests)
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			select {
+			case requests <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var pending [][]byte
+	ids := make(map[string]json.RawMessage)
+	for i := range record.Frames {
+		if record.Frames[i].Direction == Outbound {
+			for {
+				if j := matchPendingRequest(&record.Frames[i], pending); j >= 0 {
+					mapRequestIDs(record.Frames[i].Data, pending[j], ids)
+					pending = append(pending[:j], pending[j+1:]...)
+					break
+				}
+				msg, ok := <-requests
+				if !ok {
+					s.addUnmatched(pending)
+					return
+				}
+				pending = append(pending, msg)
+			}
+			continue
+		}
+
+		timer := time.NewTimer(time.Duration(float64(record.Frames[i].Delay)*s.timeScale) * time.Millisecond)
+	wait:
+		for {
+			select {
+			case msg, ok := <-requests:
+				if !ok {
+					timer.Stop()
+					s.addUnmatched(pending)
+					return
+				}
+				pending = append(pending, msg)
+			case <-timer.C:
+				break wait
+			}
+		}
+
+		payload := []byte(record.Frames[i].Text)
+		if record.Frames[i].Data != nil {
+			payload = replaceRequestIDs(record.Frames[i].Data, ids)
+		}
+		if err = c.WriteMessage(websocket.TextMessage, payload); err != nil {
+			return
+		}
+	}
+
+	// Hold the connection open once replayed until the client disconnects
+	for msg := range requests {
+		pending = append(pending, msg)
+	}
+	s.addUnmatched(pending)
+}
+
+// nextConnection returns the next unused recorded connection for the path
+func (s *WebsocketServer) nextConnection(path string) (*WebsocketConnectionRecord, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i := range s.session.Connections {
+		if !s.used[i] && s.session.Connections[i].Path == path {
+			s.used[i] = true
+			return &s.session.Connections[i], true
+		}
+	}
+	return nil, false
+}
+
+// addUnmatched stores client requests which did not match a recorded request
+func (s *WebsocketServer) addUnmatched(requests [][]byte) {
+	s.m.Lock()
+	for i := range requests {
+		s.unmatched = append(s.unmatched, string(requests[i]))
+	}
+	s.m.Unlock()
+}
+
+// matchPendingRequest returns the index of the first pending request which
+// matches the recorded outbound frame, or -1 if none match
+func matchPendingRequest(frame *WebsocketFrame, pending [][]byte) int {
+	for i := range pending {
+		if MatchWebsocketRequest(frame, pending[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MatchWebsocketRequest matches a client request with a recorded outbound
+// frame. JSON object requests are matched with MatchURLVals, ignoring request
+// IDs, credentials and values which differ between sessions
+func MatchWebsocketRequest(frame *WebsocketFrame, request []byte) bool {
+	if frame.Data == nil {
+		return frame.Text == string(request)
+	}
+	recorded, err := normaliseWebsocketRequest(frame.Data)
+	if err != nil {
+		return false
+	}
+	live, err := normaliseWebsocketRequest(request)
+	if err != nil {
+		return false
+	}
+	recordedVals, err := DeriveURLValsFromJSONMap(recorded)
+	if err != nil {
+		// Requests which are not JSON objects must match exactly
+		return bytes.Equal(recorded, live)
+	}
+	liveVals, err := DeriveURLValsFromJSONMap(live)
+	if err != nil {
+		return false
+	}
+	return MatchURLVals(recordedVals, liveVals)
+}
+
+// normaliseWebsocketRequest removes request IDs and values which differ
+// between sessions from a JSON request and redacts its credentials
+func normaliseWebsocketRequest(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		for i := range requestIDKeys {
+			delete(m, requestIDKeys[i])
+		}
+	}
+	removeDeltaValues(v)
+	redactValue(v, defaultExcludedWebsocketKeys)
+	return json.Marshal(v)
+}
+
+// removeDeltaValues recursively removes values which differ between sessions
+func removeDeltaValues(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, inner := range val {
+			if IsExcluded(k, websocketDeltaKeys) {
+				delete(val, k)
+				continue
+			}
+			removeDeltaValues(inner)
+		}
+	case []interface{}:
+		for i := range val {
+			removeDeltaValues(val[i])
+		}
+	}
+}
+
+// mapRequestIDs maps the request IDs of a recorded request to those of the
+// matching client request
+func mapRequestIDs(recorded, request []byte, ids map[string]json.RawMessage) {
+	var recordedFields, requestFields map[string]json.RawMessage
+	if json.Unmarshal(recorded, &recordedFields) != nil ||
+		json.Unmarshal(request, &requestFields) != nil {
+		return
+	}
+	for i := range requestIDKeys {
+		recordedID, ok := recordedFields[requestIDKeys[i]]
+		if !ok {
+			continue
+		}
+		if requestID, ok := requestFields[requestIDKeys[i]]; ok {
+			ids[string(recordedID)] = requestID
+		}
+	}
+}
+
+// replaceRequestIDs replaces recorded request IDs in a response with those of
+// the matching client requests
+func replaceRequestIDs(data []byte, ids map[string]json.RawMessage) []byte {
+	if len(ids) == 0 {
+		return data
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return data
+	}
+	var replaced bool
+	for i := range requestIDKeys {
+		recordedID, ok := fields[requestIDKeys[i]]
+		if !ok {
+			continue
+		}
+		if requestID, ok := ids[string(recordedID)]; ok {
+			fields[requestIDKeys[i]] = requestID
+			replaced = true
+		}
+	}
+	if !replaced {
+		return data
+	}
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+	return payload
+}
+
+// websocketPath returns the path of a websocket URL
+func websocketPath(connectionURL string) string {
+	u, err := url.Parse(connectionURL)
+	if err != nil {
+		return connectionURL
+	}
+	return u.Path
+}
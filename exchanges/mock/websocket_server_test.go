@@ -0,0 +1,145 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func newTestWebsocketSession(t *testing.T, session *WebsocketSession) string {
+	t.Helper()
+	payload, err := json.Marshal(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err = os.WriteFile(path, payload, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewWebsocketServer(t *testing.T) {
+	t.Parallel()
+	_, err := NewWebsocketServer("session.json", -1)
+	if !errors.Is(err, errInvalidTimeScale) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimeScale)
+	}
+	_, err = NewWebsocketServer(filepath.Join(t.TempDir(), "missing.json"), 0)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, os.ErrNotExist)
+	}
+	_, err = NewWebsocketServer(newTestWebsocketSession(t, &WebsocketSession{}), 0)
+	if !errors.Is(err, errNoRecordedConnections) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoRecordedConnections)
+	}
+}
+
+func TestWebsocketServer(t *testing.T) {
+	t.Parallel()
+	path := newTestWebsocketSession(t, &WebsocketSession{
+		Connections: []WebsocketConnectionRecord{{
+			Path: "/ws",
+			Frames: []WebsocketFrame{
+				{Direction: Inbound, Data: json.RawMessage(`{"event":"connected"}`)},
+				{Direction: Outbound, Data: json.RawMessage(`{"method":"subscribe","params":["btcusdt@trade"],"id":1}`)},
+				{Direction: Outbound, Data: json.RawMessage(`{"method":"subscribe","params":["ethusdt@trade"],"id":2}`)},
+				{Direction: Inbound, Delay: 20, Data: json.RawMessage(`{"result":null,"id":1}`)},
+				{Direction: Inbound, Data: json.RawMessage(`{"result":null,"id":2}`)},
+				{Direction: Inbound, Text: "pong"},
+			},
+		}},
+	})
+	s, err := NewWebsocketServer(path, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer s.Close()
+
+	_, _, err = websocket.DefaultDialer.Dial(s.URL+"/unrecorded", nil)
+	if !errors.Is(err, websocket.ErrBadHandshake) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, websocket.ErrBadHandshake)
+	}
+
+	c, _, err := websocket.DefaultDialer.Dial(s.URL+"/ws?streams=test", nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer c.Close()
+	read := func(expected string) {
+		t.Helper()
+		_, msg, readErr := c.ReadMessage()
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		if string(msg) != expected {
+			t.Fatalf("received: '%s' but expected: '%s'", msg, expected)
+		}
+	}
+	read(`{"event":"connected"}`)
+
+	// Requests are matched out of order and request IDs are replaced
+	for _, req := range []string{
+		`{"method":"subscribe","params":["ethusdt@trade"],"id":98}`,
+		`{"method":"unknown"}`,
+		`{"id":99,"params":["btcusdt@trade"],"method":"subscribe"}`,
+	} {
+		if err = c.WriteMessage(websocket.TextMessage, []byte(req)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now()
+	read(`{"id":99,"result":null}`)
+	if time.Since(start) < time.Millisecond*20 {
+		t.Error("expected recorded delay to be replayed")
+	}
+	read(`{"id":98,"result":null}`)
+	read("pong")
+
+	// Each recorded connection is only replayed once
+	_, _, err = websocket.DefaultDialer.Dial(s.URL+"/ws", nil)
+	if !errors.Is(err, websocket.ErrBadHandshake) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, websocket.ErrBadHandshake)
+	}
+
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(s.Unmatched()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if unmatched := s.Unmatched(); len(unmatched) != 1 || unmatched[0] != `{"method":"unknown"}` {
+		t.Errorf("received: '%v' but expected: '%v'", unmatched, []string{`{"method":"unknown"}`})
+	}
+}
+
+func TestMatchWebsocketRequest(t *testing.T) {
+	t.Parallel()
+	frame := &WebsocketFrame{Data: json.RawMessage(`{"op":"login","args":[{"apiKey":"redacted","timestamp":"1","sign":"redacted"}]}`)}
+	if !MatchWebsocketRequest(frame, []byte(`{"op":"login","args":[{"apiKey":"key","timestamp":"2","sign":"abc"}]}`)) {
+		t.Error("expected credentials and timestamps to be ignored")
+	}
+	if MatchWebsocketRequest(frame, []byte(`{"op":"logout","args":[{"apiKey":"key"}]}`)) {
+		t.Error("expected requests not to match")
+	}
+	frame = &WebsocketFrame{Data: json.RawMessage(`[0,"oc",null,{"id":1}]`)}
+	if !MatchWebsocketRequest(frame, []byte(`[0, "oc", null, {"id": 1}]`)) {
+		t.Error("expected array requests to match")
+	}
+	if MatchWebsocketRequest(frame, []byte(`[0,"oc",null,{"id":2}]`)) {
+		t.Error("expected array requests not to match")
+	}
+	if MatchWebsocketRequest(frame, []byte("ping")) {
+		t.Error("expected invalid JSON not to match")
+	}
+	frame = &WebsocketFrame{Text: "ping"}
+	if !MatchWebsocketRequest(frame, []byte("ping")) {
+		t.Error("expected text requests to match")
+	}
+}
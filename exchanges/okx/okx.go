@@ -1419,7 +1419,9 @@ func (ok *Okx) FundingTransfer(ctx context.Context, arg *FundingTransferRequestI
 	if arg.To == "" {
 		return nil, errors.New("missing funding destination field \"To\", only '6' and '18' are supported")
 	}
-	if arg.From == arg.To {
+	// Transfers between the master account and sub-accounts can use the
+	// same account type
+	if arg.From == arg.To && arg.Type == 0 {
 		return nil, errors.New("parameter 'from' can not equal to parameter 'to'")
 	}
 	return resp, ok.SendHTTPRequest(ctx, exchange.RestSpot, fundsTransferEPL, http.MethodPost, assetTransfer, arg, &resp, true)
@@ -1076,6 +1076,44 @@ func TestFundingTransfer(t *testing.T) {
 	}
 }
 
+func TestTransfer(t *testing.T) {
+	t.Parallel()
+	_, err := ok.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: ok.Name,
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.USDTMarginedFutures,
+	})
+	if !errors.Is(err, withdraw.ErrTransferWalletUnsupported) {
+		t.Errorf("received: '%v' but expected: '%v'", err, withdraw.ErrTransferWalletUnsupported)
+	}
+	_, err = ok.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange: ok.Name,
+		Currency: currency.USDT,
+		Amount:   1,
+		From:     asset.Spot,
+		To:       asset.PerpetualSwap,
+	})
+	if !errors.Is(err, withdraw.ErrTransferSameWallet) {
+		t.Errorf("received: '%v' but expected: '%v'", err, withdraw.ErrTransferSameWallet)
+	}
+	if !areTestAPIKeysSet() || !canManipulateRealOrders {
+		t.SkipNow()
+	}
+	_, err = ok.Transfer(context.Background(), &withdraw.TransferRequest{
+		Exchange:     ok.Name,
+		Currency:     currency.USDT,
+		Amount:       1,
+		From:         asset.Spot,
+		To:           asset.Spot,
+		ToSubAccount: "sub",
+	})
+	if err != nil {
+		t.Error("Okx Transfer() error", err)
+	}
+}
+
 func TestGetFundsTransferState(t *testing.T) {
 	t.Parallel()
 	if !areTestAPIKeysSet() {
@@ -2866,6 +2866,8 @@ type WsOrderBookData struct {
 	Bids      [][4]string      `json:"bids"`
 	Timestamp okxUnixMilliTime `json:"ts"`
 	Checksum  int32            `json:"checksum,omitempty"`
+	SeqID     int64            `json:"seqId"`
+	PrevSeqID int64            `json:"prevSeqId"`
 }
 
 // WsOptionSummary represents option summary
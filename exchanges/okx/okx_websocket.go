@@ -820,6 +820,7 @@ func (ok *Okx) WsProcessSnapshotOrderBook(data WsOrderBookData, pair currency.Pa
 		Asks:            asks,
 		Bids:            bids,
 		LastUpdated:     data.Timestamp.Time(),
+		LastUpdateID:    data.SeqID,
 		Pair:            pair,
 		Exchange:        ok.Name,
 		VerifyOrderbook: ok.CanVerifyOrderbook,
@@ -846,8 +847,10 @@ func (ok *Okx) WsProcessSnapshotOrderBook(data WsOrderBookData, pair currency.Pa
 // orderbook
 func (ok *Okx) WsProcessUpdateOrderbook(data WsOrderBookData, pair currency.Pair, a asset.Item) error {
 	update := &orderbook.Update{
-		Asset: a,
-		Pair:  pair,
+		Asset:        a,
+		Pair:         pair,
+		UpdateID:     data.SeqID,
+		PrevUpdateID: data.PrevSeqID,
 	}
 	var err error
 	update.Asks, err = ok.AppendWsOrderbookItems(data.Asks)
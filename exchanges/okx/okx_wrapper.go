@@ -33,6 +33,8 @@ import (
 
 const (
 	okxWebsocketResponseMaxLimit = time.Second * 3
+	// okxTradingAccount is the account type of the unified trading account
+	okxTradingAccount = 18
 )
 
 // GetDefaultConfig returns a default exchange config
@@ -206,6 +208,7 @@ func (ok *Okx) Setup(exch *config.Exchange) error {
 		OrderbookBufferConfig: buffer.Config{
 			Checksum: ok.CalculateUpdateOrderbookChecksum,
 		},
+		OrderEntryByDefault: true,
 	})
 
 	if err != nil {
@@ -782,17 +785,15 @@ func (ok *Okx) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitR
 			orderRequest.PositionSide = positionSideShort
 		}
 	}
-	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		placeOrderResponse, err = ok.WsPlaceOrder(orderRequest)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		placeOrderResponse, err = ok.PlaceOrder(ctx, orderRequest, s.AssetType)
-		if err != nil {
-			return nil, err
-		}
-	}
+	err = ok.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
+		var wsErr error
+		placeOrderResponse, wsErr = ok.WsPlaceOrder(orderRequest)
+		return wsErr
+	}, func() error {
+		var restErr error
+		placeOrderResponse, restErr = ok.PlaceOrder(ctx, orderRequest, s.AssetType)
+		return restErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -825,11 +826,13 @@ func (ok *Okx) ModifyOrder(ctx context.Context, action *order.Modify) (*order.Mo
 		OrderID:               action.OrderID,
 		ClientSuppliedOrderID: action.ClientOrderID,
 	}
-	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		_, err = ok.WsAmendOrder(&amendRequest)
-	} else {
-		_, err = ok.AmendOrder(ctx, &amendRequest)
-	}
+	err = ok.Websocket.OrderEntry(ctx, stream.ModifyOrderAction, func() error {
+		_, wsErr := ok.WsAmendOrder(&amendRequest)
+		return wsErr
+	}, func() error {
+		_, restErr := ok.AmendOrder(ctx, &amendRequest)
+		return restErr
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -857,12 +860,13 @@ func (ok *Okx) CancelOrder(ctx context.Context, ord *order.Cancel) error {
 		OrderID:               ord.OrderID,
 		ClientSupplierOrderID: ord.ClientOrderID,
 	}
-	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		_, err = ok.WsCancelOrder(req)
-	} else {
-		_, err = ok.CancelSingleOrder(ctx, req)
-	}
-	return err
+	return ok.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
+		_, wsErr := ok.WsCancelOrder(req)
+		return wsErr
+	}, func() error {
+		_, restErr := ok.CancelSingleOrder(ctx, req)
+		return restErr
+	})
 }
 
 // CancelBatchOrders cancels orders by their corresponding ID numbers
@@ -905,11 +909,15 @@ func (ok *Okx) CancelBatchOrders(ctx context.Context, orders []order.Cancel) (or
 		cancelOrderParams = append(cancelOrderParams, req)
 	}
 	var canceledOrders []OrderData
-	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		canceledOrders, err = ok.WsCancelMultipleOrder(cancelOrderParams)
-	} else {
-		canceledOrders, err = ok.CancelMultipleOrders(ctx, cancelOrderParams)
-	}
+	err = ok.Websocket.OrderEntry(ctx, stream.CancelBatchOrdersAction, func() error {
+		var wsErr error
+		canceledOrders, wsErr = ok.WsCancelMultipleOrder(cancelOrderParams)
+		return wsErr
+	}, func() error {
+		var restErr error
+		canceledOrders, restErr = ok.CancelMultipleOrders(ctx, cancelOrderParams)
+		return restErr
+	})
 	if err != nil {
 		return cancelBatchResponse, err
 	}
@@ -992,20 +1000,20 @@ ordersLoop:
 	loop := int(math.Ceil(float64(len(remaining)) / 20.0))
 	for b := 0; b < loop; b++ {
 		var response []OrderData
-		if len(remaining) > 20 {
-			if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-				response, err = ok.WsCancelMultipleOrder(remaining[:20])
-			} else {
-				response, err = ok.CancelMultipleOrders(ctx, remaining[:20])
-			}
-			remaining = remaining[20:]
-		} else {
-			if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-				response, err = ok.WsCancelMultipleOrder(remaining)
-			} else {
-				response, err = ok.CancelMultipleOrders(ctx, remaining)
-			}
+		batch := remaining
+		if len(batch) > 20 {
+			batch = remaining[:20]
 		}
+		remaining = remaining[len(batch):]
+		err = ok.Websocket.OrderEntry(ctx, stream.CancelAllOrdersAction, func() error {
+			var wsErr error
+			response, wsErr = ok.WsCancelMultipleOrder(batch)
+			return wsErr
+		}, func() error {
+			var restErr error
+			response, restErr = ok.CancelMultipleOrders(ctx, batch)
+			return restErr
+		})
 		if err != nil {
 			if len(cancelAllResponse.Status) == 0 {
 				return cancelAllResponse, err
@@ -1140,6 +1148,58 @@ func (ok *Okx) WithdrawFiatFundsToInternationalBank(ctx context.Context, withdra
 	return nil, common.ErrFunctionNotSupported
 }
 
+// Transfer moves funds in the trading account between the master account and
+// its sub-accounts. Asset types share the unified trading account so
+// transfers between asset types of the same account are not supported
+func (ok *Okx) Transfer(ctx context.Context, r *withdraw.TransferRequest) (*withdraw.ExchangeResponse, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if !ok.SupportsAsset(r.From) || !ok.SupportsAsset(r.To) {
+		return nil, fmt.Errorf("%w %v to %v", withdraw.ErrTransferWalletUnsupported, r.From, r.To)
+	}
+	if strings.EqualFold(r.FromSubAccount, r.ToSubAccount) {
+		return nil, fmt.Errorf("%w, %v and %v share the trading account", withdraw.ErrTransferSameWallet, r.From, r.To)
+	}
+	if r.FromSubAccount != "" && r.ToSubAccount != "" {
+		resp, err := ok.MasterAccountsManageTransfersBetweenSubaccounts(ctx, SubAccountAssetTransferParams{
+			Currency:       r.Currency.String(),
+			Amount:         r.Amount,
+			From:           okxTradingAccount,
+			To:             okxTradingAccount,
+			FromSubAccount: r.FromSubAccount,
+			ToSubAccount:   r.ToSubAccount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) != 1 {
+			return nil, errNoValidResponseFromServer
+		}
+		return &withdraw.ExchangeResponse{ID: resp[0].TransferID}, nil
+	}
+	arg := &FundingTransferRequestInput{
+		Currency:   r.Currency.String(),
+		Amount:     r.Amount,
+		From:       strconv.Itoa(okxTradingAccount),
+		To:         strconv.Itoa(okxTradingAccount),
+		Type:       1, // master account to sub-account
+		SubAccount: r.ToSubAccount,
+	}
+	if r.FromSubAccount != "" {
+		arg.Type = 2 // sub-account to master account
+		arg.SubAccount = r.FromSubAccount
+	}
+	resp, err := ok.FundingTransfer(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 1 {
+		return nil, errNoValidResponseFromServer
+	}
+	return &withdraw.ExchangeResponse{ID: resp[0].TransferID}, nil
+}
+
 // GetActiveOrders retrieves any orders that are active/open
 func (ok *Okx) GetActiveOrders(ctx context.Context, req *order.GetOrdersRequest) (order.FilteredOrders, error) {
 	err := req.Validate()
@@ -1420,6 +1420,7 @@ func TestMatchFilter(t *testing.T) {
 		15: {Exchange: "Binance", Type: Limit, Status: New},
 		16: {Exchange: "Binance", Type: AnyType},
 		17: {AccountID: "8888"},
+		18: {Account: "strategy"},
 	}
 
 	orders := map[int]Detail{
@@ -1440,6 +1441,7 @@ func TestMatchFilter(t *testing.T) {
 		14: {Pair: currency.NewPair(currency.BTC, currency.USD)},
 		15: {Exchange: "Binance", Type: Limit, Status: New},
 		16: {AccountID: "8888"},
+		17: {Account: "strategy"},
 	}
 	// empty filter tests
 	emptyFilter := filters[0]
@@ -1492,6 +1494,8 @@ func TestMatchFilter(t *testing.T) {
 		35: {filters[16], orders[15], true},
 		36: {filters[17], orders[16], true},
 		37: {filters[17], orders[15], false},
+		38: {filters[18], orders[17], true},
+		39: {filters[18], orders[16], false},
 	}
 	// specific tests
 	for num, tt := range tests {
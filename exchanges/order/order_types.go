@@ -180,6 +180,9 @@ type Detail struct {
 	LastUpdated          time.Time
 	Pair                 currency.Pair
 	Trades               []TradeHistory
+	// Account is the name of the exchange account loaded by config the order
+	// was placed with, empty for the default credentials
+	Account string
 }
 
 // Filter contains all properties an order can be filtered for
@@ -190,6 +193,7 @@ type Filter struct {
 	OrderID         string
 	ClientOrderID   string
 	AccountID       string
+	Account         string
 	ClientID        string
 	WalletAddress   string
 	Type            Type
@@ -159,6 +159,10 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
 		d.AccountID = m.AccountID
 		updated = true
 	}
+	if m.Account != "" && m.Account != d.Account {
+		d.Account = m.Account
+		updated = true
+	}
 	if m.PostOnly != d.PostOnly {
 		d.PostOnly = m.PostOnly
 		updated = true
@@ -355,6 +359,8 @@ func (d *Detail) MatchFilter(f *Filter) bool {
 		return false
 	case f.AccountID != "" && d.AccountID != f.AccountID:
 		return false
+	case f.Account != "" && d.Account != f.Account:
+		return false
 	case f.WalletAddress != "" && d.WalletAddress != f.WalletAddress:
 		return false
 	default:
@@ -0,0 +1,350 @@
+package consolidated
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// New returns an aggregator for the supplied venues
+func New(cfg *Config) (*Aggregator, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w consolidated config", common.ErrNilPointer)
+	}
+	if cfg.Base.IsEmpty() {
+		return nil, errBaseCurrencyUnset
+	}
+	if cfg.Quote.IsEmpty() {
+		return nil, errQuoteCurrencyUnset
+	}
+	if len(cfg.Venues) == 0 {
+		return nil, errNoVenues
+	}
+	for x := range cfg.Venues {
+		if cfg.Venues[x].Exchange == "" {
+			return nil, errExchangeNameUnset
+		}
+		if !cfg.Venues[x].Pair.Base.Equal(cfg.Base) {
+			return nil, fmt.Errorf("%s %s %w", cfg.Venues[x].Exchange, cfg.Venues[x].Pair, errBaseMismatch)
+		}
+		if cfg.Venues[x].Fee < 0 || cfg.Venues[x].Fee >= 1 {
+			return nil, fmt.Errorf("%s %w: %v", cfg.Venues[x].Exchange, errInvalidFee, cfg.Venues[x].Fee)
+		}
+	}
+	converter := cfg.Converter
+	if converter == nil {
+		converter = DefaultConverter
+	}
+	venues := make([]Venue, len(cfg.Venues))
+	copy(venues, cfg.Venues)
+	return &Aggregator{
+		base:        cfg.Base,
+		quote:       cfg.Quote,
+		venues:      venues,
+		feeAdjusted: cfg.FeeAdjusted,
+		converter:   converter,
+	}, nil
+}
+
+// DefaultConverter converts between identical currencies, USD pegged stable
+// coins at par with USD and fiat currencies via the foreign exchange rates
+// loaded by the currency package
+func DefaultConverter(from, to currency.Code) (float64, error) {
+	if from.Equal(to) {
+		return 1, nil
+	}
+	f, t := from, to
+	if usdEquivalents.Contains(f) {
+		f = currency.USD
+	}
+	if usdEquivalents.Contains(t) {
+		t = currency.USD
+	}
+	if f.Equal(t) {
+		return 1, nil
+	}
+	if !f.IsFiatCurrency() || !t.IsFiatCurrency() {
+		return 0, fmt.Errorf("%w from %s to %s", errNoConversion, from, to)
+	}
+	return currency.ConvertFiat(1, f, t)
+}
+
+// GetVenues returns the venues which contribute to the consolidated book
+func (a *Aggregator) GetVenues() []Venue {
+	venues := make([]Venue, len(a.venues))
+	copy(venues, a.venues)
+	return venues
+}
+
+// Consolidate merges the current depth of each venue into a consolidated book.
+// A venue which cannot be retrieved or converted is excluded and its error is
+// reported in the book venue status.
+func (a *Aggregator) Consolidate() (*Book, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%w consolidated aggregator", common.ErrNilPointer)
+	}
+	book := &Book{
+		Base:        a.base,
+		Quote:       a.quote,
+		FeeAdjusted: a.feeAdjusted,
+		Venues:      make([]VenueStatus, len(a.venues)),
+	}
+	for x := range a.venues {
+		v := &a.venues[x]
+		status := &book.Venues[x]
+		status.Exchange = v.Exchange
+		status.Pair = v.Pair
+		status.Asset = v.Asset
+		status.Fee = v.Fee
+
+		rate, err := a.converter(v.Pair.Quote, a.quote)
+		if err != nil {
+			status.Error = err
+			continue
+		}
+		if rate <= 0 {
+			status.Error = fmt.Errorf("%w from %s to %s", errInvalidRate, v.Pair.Quote, a.quote)
+			continue
+		}
+		status.Rate = rate
+
+		depth, err := orderbook.GetDepth(v.Exchange, v.Pair, v.Asset)
+		if err != nil {
+			status.Error = err
+			continue
+		}
+		base, err := depth.Retrieve()
+		if err != nil {
+			status.Error = err
+			continue
+		}
+		status.Bids = len(base.Bids)
+		status.Asks = len(base.Asks)
+		status.LastUpdated = base.LastUpdated
+		if base.LastUpdated.After(book.LastUpdated) {
+			book.LastUpdated = base.LastUpdated
+		}
+
+		bidRate, askRate := rate, rate
+		if a.feeAdjusted {
+			bidRate *= 1 - v.Fee
+			askRate *= 1 + v.Fee
+		}
+		for y := range base.Bids {
+			book.Bids = append(book.Bids, Level{
+				Exchange:   v.Exchange,
+				Pair:       v.Pair,
+				Price:      base.Bids[y].Price * bidRate,
+				VenuePrice: base.Bids[y].Price,
+				Amount:     base.Bids[y].Amount,
+			})
+		}
+		for y := range base.Asks {
+			book.Asks = append(book.Asks, Level{
+				Exchange:   v.Exchange,
+				Pair:       v.Pair,
+				Price:      base.Asks[y].Price * askRate,
+				VenuePrice: base.Asks[y].Price,
+				Amount:     base.Asks[y].Amount,
+			})
+		}
+	}
+	// Stable sort retains the venue order for levels at the same price
+	sort.SliceStable(book.Bids, func(i, j int) bool { return book.Bids[i].Price > book.Bids[j].Price })
+	sort.SliceStable(book.Asks, func(i, j int) bool { return book.Asks[i].Price < book.Asks[j].Price })
+	return book, nil
+}
+
+// Updates returns a channel which receives when the orderbook of any venue
+// changes. Bursts of changes are coalesced into a single notification. The
+// channel is closed when the context is done. Venues without a deployed
+// orderbook are not monitored.
+func (a *Aggregator) Updates(ctx context.Context) (<-chan struct{}, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%w consolidated aggregator", common.ErrNilPointer)
+	}
+	if ctx == nil {
+		return nil, fmt.Errorf("%w context", common.ErrNilPointer)
+	}
+	var depths []*orderbook.Depth
+	for x := range a.venues {
+		depth, err := orderbook.GetDepth(a.venues[x].Exchange, a.venues[x].Pair, a.venues[x].Asset)
+		if err != nil {
+			continue
+		}
+		depths = append(depths, depth)
+	}
+	if len(depths) == 0 {
+		return nil, errNoLiquidity
+	}
+	updates := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	wg.Add(len(depths))
+	for x := range depths {
+		go func(d *orderbook.Depth) {
+			defer wg.Done()
+			for {
+				// Wait returns true when kicked by the context
+				if kicked := <-d.Wait(ctx.Done()); kicked {
+					return
+				}
+				select {
+				case updates <- struct{}{}:
+				default:
+				}
+			}
+		}(depths[x])
+	}
+	go func() {
+		wg.Wait()
+		close(updates)
+	}()
+	return updates, nil
+}
+
+// BestBid returns the highest consolidated bid
+func (b *Book) BestBid() (Level, error) {
+	if b == nil {
+		return Level{}, errNilBook
+	}
+	if len(b.Bids) == 0 {
+		return Level{}, fmt.Errorf("bids %w", errNoLiquidity)
+	}
+	return b.Bids[0], nil
+}
+
+// BestAsk returns the lowest consolidated ask
+func (b *Book) BestAsk() (Level, error) {
+	if b == nil {
+		return Level{}, errNilBook
+	}
+	if len(b.Asks) == 0 {
+		return Level{}, fmt.Errorf("asks %w", errNoLiquidity)
+	}
+	return b.Asks[0], nil
+}
+
+// GetMidPrice returns the average between the best consolidated bid and ask
+func (b *Book) GetMidPrice() (float64, error) {
+	bid, err := b.BestBid()
+	if err != nil {
+		return 0, err
+	}
+	ask, err := b.BestAsk()
+	if err != nil {
+		return 0, err
+	}
+	return (bid.Price + ask.Price) / 2, nil
+}
+
+// GetSpreadAmount returns the difference between the best consolidated ask and
+// bid. This is negative when the venues are crossed.
+func (b *Book) GetSpreadAmount() (float64, error) {
+	bid, err := b.BestBid()
+	if err != nil {
+		return 0, err
+	}
+	ask, err := b.BestAsk()
+	if err != nil {
+		return 0, err
+	}
+	return ask.Price - bid.Price, nil
+}
+
+// GetLevels returns up to the supplied number of levels on each side of the
+// book, a depth of zero returns all levels
+func (b *Book) GetLevels(depth int) (bids, asks []Level) {
+	if b == nil {
+		return nil, nil
+	}
+	bids, asks = b.Bids, b.Asks
+	if depth > 0 {
+		if len(bids) > depth {
+			bids = bids[:depth]
+		}
+		if len(asks) > depth {
+			asks = asks[:depth]
+		}
+	}
+	return append([]Level(nil), bids...), append([]Level(nil), asks...)
+}
+
+// TotalBidAmounts returns the total base amount and quote value of the bids
+func (b *Book) TotalBidAmounts() (liquidity, value float64) {
+	if b == nil {
+		return 0, 0
+	}
+	return total(b.Bids)
+}
+
+// TotalAskAmounts returns the total base amount and quote value of the asks
+func (b *Book) TotalAskAmounts() (liquidity, value float64) {
+	if b == nil {
+		return 0, 0
+	}
+	return total(b.Asks)
+}
+
+// GetVenueLiquidity returns the total base amount on each side of the book for
+// the supplied exchange
+func (b *Book) GetVenueLiquidity(exchange string) (bidAmount, askAmount float64) {
+	if b == nil {
+		return 0, 0
+	}
+	for x := range b.Bids {
+		if b.Bids[x].Exchange == exchange {
+			bidAmount += b.Bids[x].Amount
+		}
+	}
+	for x := range b.Asks {
+		if b.Asks[x].Exchange == exchange {
+			askAmount += b.Asks[x].Amount
+		}
+	}
+	return bidAmount, askAmount
+}
+
+// Depth returns the consolidated book as an orderbook depth so that the
+// Movement calculations e.g. LiftTheAsksByNominalSlippage can be performed
+// across all venues. Prices are normalised and fee adjusted as per the book.
+// The depth is not updated by subsequent venue changes.
+func (b *Book) Depth() (*orderbook.Depth, error) {
+	if b == nil {
+		return nil, errNilBook
+	}
+	if len(b.Bids) == 0 && len(b.Asks) == 0 {
+		return nil, errNoLiquidity
+	}
+	depth := orderbook.NewDepth(uuid.Nil)
+	depth.AssignOptions(&orderbook.Base{
+		Exchange:         "Consolidated",
+		Pair:             currency.NewPair(b.Base, b.Quote),
+		PriceDuplication: true,
+	})
+	depth.LoadSnapshot(toItems(b.Bids), toItems(b.Asks), 0, b.LastUpdated, false)
+	return depth, nil
+}
+
+// total returns the base amount and quote value of the supplied levels
+func total(levels []Level) (liquidity, value float64) {
+	for x := range levels {
+		liquidity += levels[x].Amount
+		value += levels[x].Amount * levels[x].Price
+	}
+	return liquidity, value
+}
+
+// toItems converts consolidated levels to orderbook items
+func toItems(levels []Level) orderbook.Items {
+	items := make(orderbook.Items, len(levels))
+	for x := range levels {
+		items[x] = orderbook.Item{Price: levels[x].Price, Amount: levels[x].Amount}
+	}
+	return items
+}
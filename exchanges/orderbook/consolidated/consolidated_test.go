@@ -0,0 +1,287 @@
+package consolidated
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+var (
+	usdPair  = currency.NewPair(currency.BTC, currency.USD)
+	usdtPair = currency.NewPair(currency.BTC, currency.USDT)
+)
+
+// deployBook loads an orderbook for an exchange into the orderbook service
+func deployBook(t *testing.T, exch string, p currency.Pair, bids, asks orderbook.Items) {
+	t.Helper()
+	b := &orderbook.Base{
+		Exchange:    exch,
+		Pair:        p,
+		Asset:       asset.Spot,
+		Bids:        bids,
+		Asks:        asks,
+		LastUpdated: time.Now(),
+	}
+	if err := b.Process(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, err := New(nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	_, err = New(&Config{})
+	if !errors.Is(err, errBaseCurrencyUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errBaseCurrencyUnset)
+	}
+	_, err = New(&Config{Base: currency.BTC})
+	if !errors.Is(err, errQuoteCurrencyUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errQuoteCurrencyUnset)
+	}
+	_, err = New(&Config{Base: currency.BTC, Quote: currency.USD})
+	if !errors.Is(err, errNoVenues) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoVenues)
+	}
+	_, err = New(&Config{Base: currency.BTC, Quote: currency.USD, Venues: []Venue{{Pair: usdPair}}})
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	_, err = New(&Config{Base: currency.BTC, Quote: currency.USD, Venues: []Venue{{Exchange: "a", Pair: currency.NewPair(currency.ETH, currency.USD)}}})
+	if !errors.Is(err, errBaseMismatch) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errBaseMismatch)
+	}
+	_, err = New(&Config{Base: currency.BTC, Quote: currency.USD, Venues: []Venue{{Exchange: "a", Pair: usdPair, Fee: 1}}})
+	if !errors.Is(err, errInvalidFee) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidFee)
+	}
+	a, err := New(&Config{Base: currency.BTC, Quote: currency.USD, Venues: []Venue{{Exchange: "a", Pair: usdPair}}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if a.converter == nil {
+		t.Fatal("default converter should be set")
+	}
+	if len(a.GetVenues()) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(a.GetVenues()), 1)
+	}
+}
+
+func TestDefaultConverter(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		from, to currency.Code
+		rate     float64
+		err      error
+	}{
+		{from: currency.BTC, to: currency.BTC, rate: 1},
+		{from: currency.USDT, to: currency.USD, rate: 1},
+		{from: currency.USD, to: currency.USDC, rate: 1},
+		{from: currency.USDT, to: currency.BUSD, rate: 1},
+		{from: currency.ETH, to: currency.USD, err: errNoConversion},
+	} {
+		rate, err := DefaultConverter(tt.from, tt.to)
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("%s-%s received: '%v' but expected: '%v'", tt.from, tt.to, err, tt.err)
+		}
+		if rate != tt.rate {
+			t.Fatalf("%s-%s received: '%v' but expected: '%v'", tt.from, tt.to, rate, tt.rate)
+		}
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	t.Parallel()
+	var a *Aggregator
+	_, err := a.Consolidate()
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+
+	deployBook(t, "ConsolidateA", usdPair,
+		orderbook.Items{{Price: 100, Amount: 1}, {Price: 98, Amount: 1}},
+		orderbook.Items{{Price: 102, Amount: 1}, {Price: 104, Amount: 1}})
+	deployBook(t, "ConsolidateB", usdtPair,
+		orderbook.Items{{Price: 99, Amount: 2}},
+		orderbook.Items{{Price: 101, Amount: 2}, {Price: 103, Amount: 2}})
+
+	a, err = New(&Config{
+		Base:  currency.BTC,
+		Quote: currency.USD,
+		Venues: []Venue{
+			{Exchange: "ConsolidateA", Pair: usdPair, Asset: asset.Spot, Fee: 0.01},
+			{Exchange: "ConsolidateB", Pair: usdtPair, Asset: asset.Spot, Fee: 0.02},
+			{Exchange: "ConsolidateMissing", Pair: usdPair, Asset: asset.Spot},
+		},
+		Converter: func(from, to currency.Code) (float64, error) {
+			if from.Equal(currency.USDT) {
+				return 0.5, nil
+			}
+			return DefaultConverter(from, to)
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	book, err := a.Consolidate()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(book.Bids) != 3 || len(book.Asks) != 4 {
+		t.Fatalf("unexpected book depth bids %d asks %d", len(book.Bids), len(book.Asks))
+	}
+	// ConsolidateB prices are halved by the conversion rate
+	bid, err := book.BestBid()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if bid.Exchange != "ConsolidateA" || bid.Price != 100 {
+		t.Fatalf("unexpected best bid %+v", bid)
+	}
+	ask, err := book.BestAsk()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if ask.Exchange != "ConsolidateB" || ask.Price != 50.5 || ask.VenuePrice != 101 {
+		t.Fatalf("unexpected best ask %+v", ask)
+	}
+	if book.Venues[2].Error == nil {
+		t.Fatal("missing venue should report an error")
+	}
+	if book.Venues[1].Rate != 0.5 {
+		t.Fatalf("received: '%v' but expected: '%v'", book.Venues[1].Rate, 0.5)
+	}
+	bidAmount, askAmount := book.GetVenueLiquidity("ConsolidateB")
+	if bidAmount != 2 || askAmount != 4 {
+		t.Fatalf("unexpected venue liquidity %v %v", bidAmount, askAmount)
+	}
+
+	a.feeAdjusted = true
+	book, err = a.Consolidate()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if book.Bids[0].Price != 99 {
+		t.Fatalf("received: '%v' but expected: '%v'", book.Bids[0].Price, 99)
+	}
+	if book.Asks[0].Price != 51.51 {
+		t.Fatalf("received: '%v' but expected: '%v'", book.Asks[0].Price, 51.51)
+	}
+}
+
+func TestBookQueries(t *testing.T) {
+	t.Parallel()
+	var b *Book
+	_, err := b.BestBid()
+	if !errors.Is(err, errNilBook) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilBook)
+	}
+	_, err = b.Depth()
+	if !errors.Is(err, errNilBook) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilBook)
+	}
+	b = &Book{Base: currency.BTC, Quote: currency.USD}
+	_, err = b.GetMidPrice()
+	if !errors.Is(err, errNoLiquidity) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoLiquidity)
+	}
+	_, err = b.Depth()
+	if !errors.Is(err, errNoLiquidity) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoLiquidity)
+	}
+
+	b.Bids = []Level{{Exchange: "a", Price: 100, Amount: 1}, {Exchange: "b", Price: 99, Amount: 1}}
+	b.Asks = []Level{{Exchange: "b", Price: 101, Amount: 1}, {Exchange: "a", Price: 102, Amount: 1}}
+	mid, err := b.GetMidPrice()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if mid != 100.5 {
+		t.Fatalf("received: '%v' but expected: '%v'", mid, 100.5)
+	}
+	spread, err := b.GetSpreadAmount()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if spread != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", spread, 1)
+	}
+	bids, asks := b.GetLevels(1)
+	if len(bids) != 1 || len(asks) != 1 {
+		t.Fatalf("unexpected levels %v %v", bids, asks)
+	}
+	bids[0].Price = 0
+	if b.Bids[0].Price != 100 {
+		t.Fatal("levels should be copied")
+	}
+	liquidity, value := b.TotalAskAmounts()
+	if liquidity != 2 || value != 203 {
+		t.Fatalf("unexpected ask amounts %v %v", liquidity, value)
+	}
+	liquidity, value = b.TotalBidAmounts()
+	if liquidity != 2 || value != 199 {
+		t.Fatalf("unexpected bid amounts %v %v", liquidity, value)
+	}
+
+	depth, err := b.Depth()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	move, err := depth.LiftTheAsks(203, 101, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if move.Purchased != 2 || !move.FullBookSideConsumed {
+		t.Fatalf("unexpected movement %+v", move)
+	}
+}
+
+func TestUpdates(t *testing.T) {
+	t.Parallel()
+	var a *Aggregator
+	_, err := a.Updates(context.Background())
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	a = &Aggregator{venues: []Venue{{Exchange: "UpdatesMissing", Pair: usdPair, Asset: asset.Spot}}}
+	_, err = a.Updates(context.Background())
+	if !errors.Is(err, errNoLiquidity) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoLiquidity)
+	}
+
+	deployBook(t, "UpdatesA", usdPair, orderbook.Items{{Price: 100, Amount: 1}}, orderbook.Items{{Price: 101, Amount: 1}})
+	a.venues = append(a.venues, Venue{Exchange: "UpdatesA", Pair: usdPair, Asset: asset.Spot})
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := a.Updates(ctx)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	depth, err := orderbook.GetDepth("UpdatesA", usdPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// The monitoring routine may not be waiting yet, alert until notified
+	timeout := time.After(5 * time.Second)
+	for notified := false; !notified; {
+		depth.UpdateBidAskByPrice(&orderbook.Update{Bids: orderbook.Items{{Price: 100, Amount: 2}}})
+		select {
+		case <-updates:
+			notified = true
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("expected update notification")
+		}
+	}
+	cancel()
+	for range updates {
+	}
+}
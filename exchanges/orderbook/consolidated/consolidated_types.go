@@ -0,0 +1,112 @@
+package consolidated
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	errBaseCurrencyUnset  = errors.New("consolidated base currency unset")
+	errQuoteCurrencyUnset = errors.New("consolidated quote currency unset")
+	errNoVenues           = errors.New("no venues supplied")
+	errExchangeNameUnset  = errors.New("venue exchange name unset")
+	errBaseMismatch       = errors.New("venue base currency does not match consolidated base currency")
+	errInvalidFee         = errors.New("venue fee must be between 0 and 1")
+	errNoConversion       = errors.New("no conversion available")
+	errInvalidRate        = errors.New("conversion rate must be greater than zero")
+	errNoLiquidity        = errors.New("no consolidated liquidity")
+	errNilBook            = errors.New("consolidated book is nil")
+)
+
+// usdEquivalents defines stable coins which are quoted at par with USD by the
+// default converter
+var usdEquivalents = currency.Currencies{
+	currency.USDT,
+	currency.USDC,
+	currency.BUSD,
+	currency.TUSD,
+	currency.USDP,
+	currency.PAX,
+	currency.GUSD,
+	currency.DAI,
+}
+
+// Converter returns the rate which converts a price denominated in the from
+// currency into a price denominated in the to currency
+type Converter func(from, to currency.Code) (float64, error)
+
+// Venue defines an exchange orderbook which contributes to the consolidated
+// book
+type Venue struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	// Fee is the taker fee rate of the venue e.g. 0.001 for 0.1%, which is
+	// applied to prices when fee adjustment is enabled.
+	Fee float64
+}
+
+// Config defines the consolidated book settings
+type Config struct {
+	Base  currency.Code
+	Quote currency.Code
+	// Venues are the exchange orderbooks to merge. Each venue pair must share
+	// the consolidated base currency, the quote currency is converted.
+	Venues []Venue
+	// FeeAdjusted applies each venue taker fee to its prices, so bids are
+	// what would be received and asks what would be paid after fees.
+	FeeAdjusted bool
+	// Converter overrides the default quote currency conversion
+	Converter Converter
+}
+
+// Aggregator merges exchange orderbooks for the same base currency into a
+// consolidated book
+type Aggregator struct {
+	base        currency.Code
+	quote       currency.Code
+	venues      []Venue
+	feeAdjusted bool
+	converter   Converter
+}
+
+// Level defines a consolidated price level attributed to a venue
+type Level struct {
+	Exchange string
+	Pair     currency.Pair
+	// Price is normalised to the consolidated quote currency and fee adjusted
+	// when enabled
+	Price float64
+	// VenuePrice is the price as quoted by the exchange
+	VenuePrice float64
+	Amount     float64
+}
+
+// VenueStatus defines the state of a venue when the book was consolidated
+type VenueStatus struct {
+	Exchange    string
+	Pair        currency.Pair
+	Asset       asset.Item
+	Rate        float64
+	Fee         float64
+	Bids        int
+	Asks        int
+	LastUpdated time.Time
+	// Error is set when the venue could not be included in the book
+	Error error
+}
+
+// Book defines a consolidated orderbook. Bids are sorted from highest to
+// lowest price and asks from lowest to highest price.
+type Book struct {
+	Base        currency.Code
+	Quote       currency.Code
+	FeeAdjusted bool
+	Bids        []Level
+	Asks        []Level
+	Venues      []VenueStatus
+	LastUpdated time.Time
+}
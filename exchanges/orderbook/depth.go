@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/alert"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -272,6 +274,23 @@ func (d *Depth) LastUpdateID() (int64, error) {
 	return d.lastUpdateID, nil
 }
 
+// LastUpdated returns the time the depth was last updated
+func (d *Depth) LastUpdated() (time.Time, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.validationError != nil {
+		return time.Time{}, d.validationError
+	}
+	return d.lastUpdated, nil
+}
+
+// GetAssetAndPair returns the asset type and currency pair of the depth
+func (d *Depth) GetAssetAndPair() (asset.Item, currency.Pair) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return d.asset, d.pair
+}
+
 // IsFundingRate returns if the depth is a funding rate
 func (d *Depth) IsFundingRate() bool {
 	d.m.Lock()
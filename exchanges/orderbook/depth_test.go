@@ -591,6 +591,43 @@ func TestLastUpdateID(t *testing.T) {
 	}
 }
 
+func TestLastUpdated(t *testing.T) {
+	t.Parallel()
+	d := Depth{}
+	err := d.Invalidate(nil)
+	if !errors.Is(err, ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrOrderbookInvalid)
+	}
+	_, err = d.LastUpdated()
+	if !errors.Is(err, ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrOrderbookInvalid)
+	}
+
+	d.validationError = nil
+	tn := time.Now()
+	d.lastUpdated = tn
+	lastUpdated, err := d.LastUpdated()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !lastUpdated.Equal(tn) {
+		t.Fatalf("received: '%v' but expected: '%v'", lastUpdated, tn)
+	}
+}
+
+func TestGetAssetAndPair(t *testing.T) {
+	t.Parallel()
+	d := Depth{}
+	d.AssignOptions(&Base{Asset: asset.Spot, Pair: currency.NewPair(currency.BTC, currency.USDT)})
+	a, p := d.GetAssetAndPair()
+	if a != asset.Spot {
+		t.Fatalf("received: '%v' but expected: '%v'", a, asset.Spot)
+	}
+	if !p.Equal(currency.NewPair(currency.BTC, currency.USDT)) {
+		t.Fatalf("received: '%v' but expected: '%v'", p, "BTCUSDT")
+	}
+}
+
 func TestIsFundingRate(t *testing.T) {
 	t.Parallel()
 	d := Depth{}
@@ -134,9 +134,12 @@ const (
 
 // Update and things and stuff
 type Update struct {
-	UpdateID   int64 // Used when no time is provided
-	UpdateTime time.Time
-	Asset      asset.Item
+	UpdateID int64 // Used when no time is provided
+	// PrevUpdateID when set must match the update ID of the last update
+	// applied, otherwise the book has missed an update and is invalidated.
+	PrevUpdateID int64
+	UpdateTime   time.Time
+	Asset        asset.Item
 	Action
 	Bids []Item
 	Asks []Item
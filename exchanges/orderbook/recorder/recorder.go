@@ -0,0 +1,340 @@
+// Package recorder writes websocket orderbook snapshots and applied updates to
+// compressed rotating files so that books can be reconstructed and replayed
+// after the fact.
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// New returns a new recorder writing to the configured directory
+func New(cfg *Config) (*Recorder, error) {
+	if cfg == nil || cfg.Directory == "" {
+		return nil, errDirectoryUnset
+	}
+	r := &Recorder{
+		directory:        cfg.Directory,
+		rotationInterval: cfg.RotationInterval,
+		maxFileSize:      cfg.MaxFileSize,
+		series:           make(map[seriesKey]*series),
+		now:              time.Now,
+	}
+	if r.rotationInterval <= 0 {
+		r.rotationInterval = DefaultRotationInterval
+	}
+	if r.maxFileSize <= 0 {
+		r.maxFileSize = DefaultMaxFileSize
+	}
+	if len(cfg.Exchanges) != 0 {
+		r.exchanges = make(map[string]struct{}, len(cfg.Exchanges))
+		for x := range cfg.Exchanges {
+			r.exchanges[strings.ToLower(cfg.Exchanges[x])] = struct{}{}
+		}
+	}
+	return r, nil
+}
+
+// SeriesPath returns the directory recorded files are stored in for an
+// exchange, pair and asset
+func SeriesPath(directory, exchange string, p currency.Pair, a asset.Item) string {
+	return filepath.Join(directory,
+		strings.ToLower(exchange),
+		a.String(),
+		p.Format(currency.PairFormat{Delimiter: currency.DashDelimiter, Uppercase: true}).String())
+}
+
+// RecordSnapshot records a full orderbook snapshot, rotating the current file
+// when required
+func (r *Recorder) RecordSnapshot(book *orderbook.Base) error {
+	if book == nil {
+		return errNilBook
+	}
+	if book.Exchange == "" {
+		return errExchangeNameUnset
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.closed {
+		return errRecorderClosed
+	}
+	if !r.isRecorded(book.Exchange) {
+		return nil
+	}
+	s, err := r.getSeries(book.Exchange, book.Pair, book.Asset)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	if s.file == nil || r.requiresRotation(s, now) {
+		if err = r.rotate(s, now); err != nil {
+			return err
+		}
+	}
+	return r.write(s, snapshotToRecord(book, now))
+}
+
+// RecordUpdate records an orderbook update which has been applied to the
+// exchange depth. When a new file is required the current depth is written
+// as a snapshot instead so every file can be replayed on its own.
+func (r *Recorder) RecordUpdate(exchange string, update *orderbook.Update) error {
+	if update == nil {
+		return errNilUpdate
+	}
+	if exchange == "" {
+		return errExchangeNameUnset
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.closed {
+		return errRecorderClosed
+	}
+	if !r.isRecorded(exchange) {
+		return nil
+	}
+	s, err := r.getSeries(exchange, update.Pair, update.Asset)
+	if err != nil {
+		return err
+	}
+	now := r.now()
+	if s.file == nil || r.requiresRotation(s, now) {
+		book, retrieveErr := retrieveBook(exchange, update.Pair, update.Asset)
+		if retrieveErr == nil {
+			if err = r.rotate(s, now); err != nil {
+				return err
+			}
+			// The retrieved depth already has this update applied
+			return r.write(s, snapshotToRecord(book, now))
+		}
+		if s.file == nil {
+			return fmt.Errorf("%s %s %s %w: %v", exchange, update.Pair, update.Asset, errNoSnapshot, retrieveErr)
+		}
+		// Continue writing to the current file until a valid book is
+		// available to start the next
+	}
+	return r.write(s, &Record{
+		Timestamp:  now,
+		Type:       UpdateRecord,
+		Bids:       update.Bids,
+		Asks:       update.Asks,
+		UpdateID:   update.UpdateID,
+		UpdateTime: update.UpdateTime,
+		Action:     update.Action,
+		Checksum:   update.Checksum,
+		MaxDepth:   update.MaxDepth,
+	})
+}
+
+// Flush flushes all buffered records to disk and updates each series index
+func (r *Recorder) Flush() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	var errs common.Errors
+	for _, s := range r.series {
+		if err := s.flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Close flushes and closes all open files, no further records are accepted
+func (r *Recorder) Close() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.closed {
+		return errRecorderClosed
+	}
+	r.closed = true
+	var errs common.Errors
+	for _, s := range r.series {
+		if err := s.close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// isRecorded checks whether the exchange is allowed to be recorded
+func (r *Recorder) isRecorded(exchange string) bool {
+	if r.exchanges == nil {
+		return true
+	}
+	_, ok := r.exchanges[strings.ToLower(exchange)]
+	return ok
+}
+
+// getSeries returns the series for the supplied params, loading any existing
+// index from disk. Must be called with the lock held.
+func (r *Recorder) getSeries(exchange string, p currency.Pair, a asset.Item) (*series, error) {
+	key := seriesKey{
+		Exchange: strings.ToLower(exchange),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+	}
+	s, ok := r.series[key]
+	if ok {
+		return s, nil
+	}
+	path := SeriesPath(r.directory, exchange, p, a)
+	idx, err := LoadIndex(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		idx = &Index{
+			Exchange: exchange,
+			Pair:     p.Format(currency.PairFormat{Delimiter: currency.DashDelimiter, Uppercase: true}),
+			Asset:    a,
+		}
+	}
+	s = &series{path: path, index: *idx}
+	r.series[key] = s
+	return s, nil
+}
+
+// requiresRotation checks whether the open file has exceeded its limits
+func (r *Recorder) requiresRotation(s *series, now time.Time) bool {
+	return s.written >= r.maxFileSize || now.Sub(s.opened) >= r.rotationInterval
+}
+
+// rotate closes any open file and starts a new one
+func (r *Recorder) rotate(s *series, now time.Time) error {
+	if err := s.close(); err != nil {
+		return err
+	}
+	name := strconv.FormatInt(now.UnixNano(), 10) + fileExtension
+	f, err := file.Writer(filepath.Join(s.path, name))
+	if err != nil {
+		return err
+	}
+	s.file = f
+	s.gz = gzip.NewWriter(f)
+	s.buf = bufio.NewWriter(s.gz)
+	s.opened = now
+	s.written = 0
+	s.index.Files = append(s.index.Files, IndexEntry{File: name, Start: now, End: now})
+	return s.writeIndex()
+}
+
+// write appends a record to the open file
+func (r *Recorder) write(s *series, rec *Record) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+	n, err := s.buf.Write(payload)
+	if err != nil {
+		return err
+	}
+	s.written += int64(n)
+	entry := &s.index.Files[len(s.index.Files)-1]
+	entry.End = rec.Timestamp
+	entry.Records++
+	return nil
+}
+
+// flush writes buffered data through to disk
+func (s *series) flush() error {
+	if s.file == nil {
+		return nil
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if err := s.gz.Flush(); err != nil {
+		return err
+	}
+	return s.writeIndex()
+}
+
+// close flushes and closes the open file
+func (s *series) close() error {
+	if s.file == nil {
+		return nil
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if err := s.gz.Close(); err != nil {
+		return err
+	}
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file, s.gz, s.buf = nil, nil, nil
+	return s.writeIndex()
+}
+
+// writeIndex writes the series index to a temporary file before moving it
+// into place so readers never observe a partially written index
+func (s *series) writeIndex() error {
+	payload, err := json.MarshalIndent(s.index, "", " ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.path, IndexFile+".tmp")
+	if err = file.Write(tmp, payload); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.path, IndexFile))
+}
+
+// LoadIndex loads the index for a series path
+func LoadIndex(path string) (*Index, error) {
+	payload, err := os.ReadFile(filepath.Join(path, IndexFile))
+	if err != nil {
+		return nil, err
+	}
+	var idx Index
+	if err = json.Unmarshal(payload, &idx); err != nil {
+		return nil, fmt.Errorf("cannot decode index %s: %w", path, err)
+	}
+	return &idx, nil
+}
+
+// retrieveBook returns the current state of a deployed depth
+func retrieveBook(exchange string, p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	depth, err := orderbook.GetDepth(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	return depth.Retrieve()
+}
+
+// snapshotToRecord converts a book to a snapshot record
+func snapshotToRecord(book *orderbook.Base, now time.Time) *Record {
+	return &Record{
+		Timestamp:        now,
+		Type:             SnapshotRecord,
+		Bids:             book.Bids,
+		Asks:             book.Asks,
+		UpdateID:         book.LastUpdateID,
+		UpdateTime:       book.LastUpdated,
+		PriceDuplication: book.PriceDuplication,
+		IsFundingRate:    book.IsFundingRate,
+		IDAlignment:      book.IDAlignment,
+	}
+}
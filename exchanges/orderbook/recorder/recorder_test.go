@@ -0,0 +1,256 @@
+package recorder
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+const testExchange = "RecorderTest"
+
+var (
+	testPair  = currency.NewPair(currency.BTC, currency.USDT)
+	testStart = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestMain(m *testing.M) {
+	err := dispatch.Start(dispatch.DefaultMaxWorkers, dispatch.DefaultJobsLimit*10)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+// testClock returns a controllable clock for the recorder
+func testClock(start time.Time) (now func() time.Time, advance func(time.Duration)) {
+	current := start
+	return func() time.Time { return current },
+		func(d time.Duration) { current = current.Add(d) }
+}
+
+func testBook() *orderbook.Base {
+	return &orderbook.Base{
+		Exchange: testExchange,
+		Pair:     testPair,
+		Asset:    asset.Spot,
+		Bids:     orderbook.Items{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+		Asks:     orderbook.Items{{Price: 101, Amount: 1}, {Price: 102, Amount: 2}},
+	}
+}
+
+func testUpdate(bidPrice, bidAmount float64) *orderbook.Update {
+	return &orderbook.Update{
+		Pair:  testPair,
+		Asset: asset.Spot,
+		Bids:  orderbook.Items{{Price: bidPrice, Amount: bidAmount}},
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, err := New(nil)
+	if !errors.Is(err, errDirectoryUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errDirectoryUnset)
+	}
+	r, err := New(&Config{Directory: t.TempDir(), Exchanges: []string{"BiNaNcE"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if r.rotationInterval != DefaultRotationInterval {
+		t.Errorf("received: '%v' but expected: '%v'", r.rotationInterval, DefaultRotationInterval)
+	}
+	if r.maxFileSize != DefaultMaxFileSize {
+		t.Errorf("received: '%v' but expected: '%v'", r.maxFileSize, DefaultMaxFileSize)
+	}
+	if !r.isRecorded("binance") || r.isRecorded("bitstamp") {
+		t.Error("unexpected exchange filtering")
+	}
+}
+
+func TestRecordSnapshot(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	r, err := New(&Config{Directory: dir, RotationInterval: time.Minute})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var advance func(time.Duration)
+	r.now, advance = testClock(testStart)
+
+	err = r.RecordSnapshot(nil)
+	if !errors.Is(err, errNilBook) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilBook)
+	}
+	err = r.RecordSnapshot(&orderbook.Base{})
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	advance(time.Second)
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	advance(time.Minute)
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, errRecorderClosed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRecorderClosed)
+	}
+
+	idx, err := LoadIndex(SeriesPath(dir, testExchange, testPair, asset.Spot))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(idx.Files) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(idx.Files), 2)
+	}
+	if idx.Files[0].Records != 2 || idx.Files[1].Records != 1 {
+		t.Errorf("unexpected record counts %d %d", idx.Files[0].Records, idx.Files[1].Records)
+	}
+	if !idx.Files[0].End.Equal(testStart.Add(time.Second)) {
+		t.Errorf("received: '%v' but expected: '%v'", idx.Files[0].End, testStart.Add(time.Second))
+	}
+	for x := range idx.Files {
+		if _, err = os.Stat(filepath.Join(SeriesPath(dir, testExchange, testPair, asset.Spot), idx.Files[x].File)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRecordSnapshotFiltered(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	r, err := New(&Config{Directory: dir, Exchanges: []string{"someOtherExchange"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = LoadIndex(SeriesPath(dir, testExchange, testPair, asset.Spot))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, os.ErrNotExist)
+	}
+}
+
+func TestRecordUpdate(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	r, err := New(&Config{Directory: dir, MaxFileSize: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r.now, _ = testClock(testStart)
+
+	err = r.RecordUpdate(testExchange, nil)
+	if !errors.Is(err, errNilUpdate) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilUpdate)
+	}
+	err = r.RecordUpdate("", testUpdate(99, 2))
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	err = r.RecordUpdate(testExchange, testUpdate(99, 2))
+	if !errors.Is(err, errNoSnapshot) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoSnapshot)
+	}
+
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// The max file size has been exceeded but no live depth exists to start
+	// a new file, so the update is appended to the current file.
+	err = r.RecordUpdate(testExchange, testUpdate(99, 2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Close()
+	if !errors.Is(err, errRecorderClosed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRecorderClosed)
+	}
+	idx, err := LoadIndex(SeriesPath(dir, testExchange, testPair, asset.Spot))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(idx.Files) != 1 || idx.Files[0].Records != 2 {
+		t.Fatalf("unexpected index %+v", idx.Files)
+	}
+}
+
+func TestRecordUpdateRotatesFromDepth(t *testing.T) {
+	t.Parallel()
+	const exch = "RecorderDepthTest"
+	book := testBook()
+	book.Exchange = exch
+	err := book.Process()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	dir := t.TempDir()
+	r, err := New(&Config{Directory: dir, MaxFileSize: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var advance func(time.Duration)
+	r.now, advance = testClock(testStart)
+
+	// No file is open so the live depth is used as the first snapshot
+	err = r.RecordUpdate(exch, testUpdate(99, 2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	advance(time.Second)
+	err = r.RecordUpdate(exch, testUpdate(99, 2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Flush()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	idx, err := LoadIndex(SeriesPath(dir, exch, testPair, asset.Spot))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(idx.Files) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(idx.Files), 2)
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestSeriesPath(t *testing.T) {
+	t.Parallel()
+	path := SeriesPath("root", "Binance", testPair, asset.Spot)
+	if expected := filepath.Join("root", "binance", "spot", "BTC-USDT"); path != expected {
+		t.Fatalf("received: '%v' but expected: '%v'", path, expected)
+	}
+}
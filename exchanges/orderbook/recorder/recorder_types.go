@@ -0,0 +1,146 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+const (
+	// DefaultRotationInterval defines the default maximum duration a single
+	// file will be written to before it is rotated
+	DefaultRotationInterval = time.Hour
+	// DefaultMaxFileSize defines the default maximum amount of uncompressed
+	// bytes written to a single file before it is rotated
+	DefaultMaxFileSize = 64 << 20
+	// IndexFile is the name of the per series index file
+	IndexFile = "index.json"
+	// fileExtension is the extension used for recorded files
+	fileExtension = ".jsonl.gz"
+)
+
+var (
+	errDirectoryUnset      = errors.New("recorder directory unset")
+	errRecorderClosed      = errors.New("recorder closed")
+	errNilBook             = errors.New("orderbook is nil")
+	errNilUpdate           = errors.New("orderbook update is nil")
+	errExchangeNameUnset   = errors.New("exchange name unset")
+	errNoSnapshot          = errors.New("no snapshot recorded prior to update")
+	errNoRecordedData      = errors.New("no recorded data")
+	errNoDataBeforeTime    = errors.New("no recorded data at or before time")
+	errInvalidReplaySpeed  = errors.New("replay speed cannot be negative")
+	errInvalidTimeRange    = errors.New("end time cannot be before start time")
+	errUnknownRecordType   = errors.New("unknown record type")
+	errFileNotInSeriesPath = errors.New("recorded file not within series path")
+)
+
+// Config defines the recorder settings
+type Config struct {
+	// Directory is the root directory files are written to. Each exchange,
+	// asset and pair are stored in their own sub directory.
+	Directory string
+	// RotationInterval is the maximum duration a file is written to before a
+	// new file is started
+	RotationInterval time.Duration
+	// MaxFileSize is the maximum amount of uncompressed bytes written to a
+	// file before a new file is started
+	MaxFileSize int64
+	// Exchanges restricts recording to the named exchanges, all exchanges are
+	// recorded when empty
+	Exchanges []string
+}
+
+// Recorder writes orderbook snapshots and updates to compressed rotating
+// files
+type Recorder struct {
+	directory        string
+	rotationInterval time.Duration
+	maxFileSize      int64
+	exchanges        map[string]struct{}
+	series           map[seriesKey]*series
+	closed           bool
+	now              func() time.Time
+	m                sync.Mutex
+}
+
+// RecordType defines the type of record stored
+type RecordType string
+
+// Record types
+const (
+	SnapshotRecord RecordType = "snapshot"
+	UpdateRecord   RecordType = "update"
+)
+
+// Record defines a single line written to a recorded file
+type Record struct {
+	// Timestamp is the local time the record was written
+	Timestamp time.Time        `json:"timestamp"`
+	Type      RecordType       `json:"type"`
+	Bids      orderbook.Items  `json:"bids,omitempty"`
+	Asks      orderbook.Items  `json:"asks,omitempty"`
+	UpdateID  int64            `json:"updateID,omitempty"`
+	Action    orderbook.Action `json:"action,omitempty"`
+	Checksum  uint32           `json:"checksum,omitempty"`
+	MaxDepth  int              `json:"maxDepth,omitempty"`
+	// UpdateTime is the exchange provided time of the snapshot or update
+	UpdateTime       time.Time `json:"updateTime"`
+	PriceDuplication bool      `json:"priceDuplication,omitempty"`
+	IsFundingRate    bool      `json:"isFundingRate,omitempty"`
+	IDAlignment      bool      `json:"idAlignment,omitempty"`
+}
+
+// IndexEntry describes a single recorded file
+type IndexEntry struct {
+	File    string    `json:"file"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Records int64     `json:"records"`
+}
+
+// Index is written alongside the recorded files for a series and lists files
+// in ascending time order
+type Index struct {
+	Exchange string        `json:"exchange"`
+	Pair     currency.Pair `json:"pair"`
+	Asset    asset.Item    `json:"asset"`
+	Files    []IndexEntry  `json:"files"`
+}
+
+// seriesKey is used to look up a recorded series
+type seriesKey struct {
+	Exchange string
+	Base     *currency.Item
+	Quote    *currency.Item
+	Asset    asset.Item
+}
+
+// series holds the open file for a single exchange, pair and asset
+type series struct {
+	path    string
+	index   Index
+	file    *os.File
+	gz      *gzip.Writer
+	buf     *bufio.Writer
+	opened  time.Time
+	written int64
+}
+
+// Replayer reconstructs recorded orderbook depth for a single series
+type Replayer struct {
+	path  string
+	index Index
+	depth *orderbook.Depth
+	mux   *dispatch.Mux
+	id    uuid.UUID
+	m     sync.Mutex
+}
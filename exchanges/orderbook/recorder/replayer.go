@@ -0,0 +1,258 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// NewReplayer returns a replayer for a recorded exchange, pair and asset
+func NewReplayer(directory, exchange string, p currency.Pair, a asset.Item) (*Replayer, error) {
+	if directory == "" {
+		return nil, errDirectoryUnset
+	}
+	if exchange == "" {
+		return nil, errExchangeNameUnset
+	}
+	path := SeriesPath(directory, exchange, p, a)
+	idx, err := LoadIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(idx.Files) == 0 {
+		return nil, fmt.Errorf("%s %s %s %w", exchange, p, a, errNoRecordedData)
+	}
+	mux := dispatch.GetNewMux(nil)
+	id, err := mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+	depth := orderbook.NewDepth(id)
+	depth.AssignOptions(&orderbook.Base{Exchange: idx.Exchange, Pair: p, Asset: a})
+	return &Replayer{
+		path:  path,
+		index: *idx,
+		depth: depth,
+		mux:   mux,
+		id:    id,
+	}, nil
+}
+
+// GetIndex returns a copy of the recorded index
+func (r *Replayer) GetIndex() Index {
+	idx := r.index
+	idx.Files = make([]IndexEntry, len(r.index.Files))
+	copy(idx.Files, r.index.Files)
+	return idx
+}
+
+// GetDepth returns the depth which is rebuilt when reconstructing or
+// replaying recorded data
+func (r *Replayer) GetDepth() *orderbook.Depth {
+	return r.depth
+}
+
+// Subscribe returns a pipe which receives the replayed depth each time it is
+// changed by Replay
+func (r *Replayer) Subscribe() (dispatch.Pipe, error) {
+	return r.mux.Subscribe(r.id)
+}
+
+// DepthAt reconstructs the orderbook as it was at the supplied time
+func (r *Replayer) DepthAt(t time.Time) (*orderbook.Base, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	start, err := r.findFile(t)
+	if err != nil {
+		return nil, err
+	}
+	err = r.readFile(r.index.Files[start].File, func(rec *Record) (bool, error) {
+		if rec.Timestamp.After(t) {
+			return false, nil
+		}
+		return true, r.apply(rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.depth.Retrieve()
+}
+
+// Replay reconstructs the orderbook at the start time then applies each
+// recorded change up to the end time, publishing the depth via dispatch after
+// every change. A speed of 1 replays in real time, 10 replays ten times faster
+// and 0 replays as fast as possible.
+func (r *Replayer) Replay(ctx context.Context, start, end time.Time, speed float64) error {
+	if speed < 0 {
+		return errInvalidReplaySpeed
+	}
+	if end.Before(start) {
+		return errInvalidTimeRange
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	first, err := r.findFile(start)
+	if err != nil {
+		return err
+	}
+	var published bool
+	var last time.Time
+	for x := first; x < len(r.index.Files); x++ {
+		if r.index.Files[x].Start.After(end) {
+			break
+		}
+		if x > first && !published {
+			// Each file begins with a snapshot, so any state carried from the
+			// prior file is superseded and the start state is now complete.
+			r.depth.Publish()
+			published, last = true, start
+		}
+		err = r.readFile(r.index.Files[x].File, func(rec *Record) (bool, error) {
+			if !rec.Timestamp.After(start) {
+				return true, r.apply(rec)
+			}
+			if rec.Timestamp.After(end) {
+				return false, nil
+			}
+			if !published {
+				r.depth.Publish()
+				published, last = true, start
+			}
+			if speed > 0 {
+				wait := time.NewTimer(time.Duration(float64(rec.Timestamp.Sub(last)) / speed))
+				select {
+				case <-ctx.Done():
+					wait.Stop()
+					return false, ctx.Err()
+				case <-wait.C:
+				}
+			} else if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			last = rec.Timestamp
+			if err := r.apply(rec); err != nil {
+				return false, err
+			}
+			r.depth.Publish()
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if !published {
+		r.depth.Publish()
+	}
+	return nil
+}
+
+// findFile returns the index of the last file started at or before the
+// supplied time
+func (r *Replayer) findFile(t time.Time) (int, error) {
+	for x := len(r.index.Files) - 1; x >= 0; x-- {
+		if !r.index.Files[x].Start.After(t) {
+			return x, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %s", errNoDataBeforeTime, t)
+}
+
+// readFile decodes each record in a recorded file and passes it to fn until fn
+// returns false. A truncated file, such as one still being written, is read
+// up to the last complete record.
+func (r *Replayer) readFile(name string, fn func(*Record) (bool, error)) error {
+	if name != filepath.Base(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("%w: %s", errFileNotInSeriesPath, name)
+	}
+	f, err := os.Open(filepath.Join(r.path, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+	defer gz.Close()
+	dec := json.NewDecoder(gz)
+	for {
+		var rec Record
+		if err = dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		var next bool
+		next, err = fn(&rec)
+		if err != nil || !next {
+			return err
+		}
+	}
+}
+
+// apply applies a record to the replay depth
+func (r *Replayer) apply(rec *Record) error {
+	switch rec.Type {
+	case SnapshotRecord:
+		r.depth.AssignOptions(&orderbook.Base{
+			Exchange:         r.index.Exchange,
+			Pair:             r.index.Pair,
+			Asset:            r.index.Asset,
+			PriceDuplication: rec.PriceDuplication,
+			IsFundingRate:    rec.IsFundingRate,
+			IDAlignment:      rec.IDAlignment,
+		})
+		r.depth.LoadSnapshot(rec.Bids, rec.Asks, rec.UpdateID, rec.UpdateTime, false)
+		return nil
+	case UpdateRecord:
+		update := &orderbook.Update{
+			UpdateID:   rec.UpdateID,
+			UpdateTime: rec.UpdateTime,
+			Asset:      r.index.Asset,
+			Action:     rec.Action,
+			Bids:       rec.Bids,
+			Asks:       rec.Asks,
+			Pair:       r.index.Pair,
+			Checksum:   rec.Checksum,
+			MaxDepth:   rec.MaxDepth,
+		}
+		// Mirrors the websocket buffer processing, updates without an action
+		// are applied by price level.
+		switch update.Action {
+		case 0:
+			r.depth.UpdateBidAskByPrice(update)
+			return nil
+		case orderbook.Amend:
+			return r.depth.UpdateBidAskByID(update)
+		case orderbook.Delete:
+			// Edge case for Bitfinex as their streaming endpoint duplicates
+			// deletes
+			bypassErr := r.depth.GetName() == "Bitfinex" && r.depth.IsFundingRate()
+			return r.depth.DeleteBidAskByID(update, bypassErr)
+		case orderbook.Insert:
+			return r.depth.InsertBidAskByID(update)
+		case orderbook.UpdateInsert:
+			return r.depth.UpdateInsertByID(update)
+		default:
+			return fmt.Errorf("%w [%d]", orderbook.ErrInvalidAction, update.Action)
+		}
+	default:
+		return fmt.Errorf("%w %q", errUnknownRecordType, rec.Type)
+	}
+}
@@ -0,0 +1,191 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// recordTestSeries writes a snapshot at the start time followed by an update
+// every second, rotating after three seconds
+func recordTestSeries(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	r, err := New(&Config{Directory: dir, RotationInterval: 3 * time.Second})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var advance func(time.Duration)
+	r.now, advance = testClock(testStart)
+	err = r.RecordSnapshot(testBook())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for x := 1; x <= 2; x++ {
+		advance(time.Second)
+		err = r.RecordUpdate(testExchange, testUpdate(99, float64(x+1)))
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	// Rotated, a snapshot is recorded to start the next file as there is no
+	// live depth deployed for this exchange
+	advance(time.Second)
+	book := testBook()
+	book.Bids = orderbook.Items{{Price: 97, Amount: 5}}
+	err = r.RecordSnapshot(book)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	advance(time.Second)
+	err = r.RecordUpdate(testExchange, testUpdate(96, 1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return dir
+}
+
+func TestNewReplayer(t *testing.T) {
+	t.Parallel()
+	_, err := NewReplayer("", testExchange, testPair, asset.Spot)
+	if !errors.Is(err, errDirectoryUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errDirectoryUnset)
+	}
+	_, err = NewReplayer("dir", "", testPair, asset.Spot)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	_, err = NewReplayer(t.TempDir(), testExchange, testPair, asset.Spot)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, os.ErrNotExist)
+	}
+	r, err := NewReplayer(recordTestSeries(t), testExchange, testPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if idx := r.GetIndex(); len(idx.Files) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(idx.Files), 2)
+	}
+	if r.GetDepth() == nil {
+		t.Fatal("depth should be set")
+	}
+}
+
+func TestDepthAt(t *testing.T) {
+	t.Parallel()
+	r, err := NewReplayer(recordTestSeries(t), testExchange, testPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = r.DepthAt(testStart.Add(-time.Second))
+	if !errors.Is(err, errNoDataBeforeTime) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoDataBeforeTime)
+	}
+
+	for _, tt := range []struct {
+		at       time.Duration
+		bestBid  orderbook.Item
+		bidDepth int
+	}{
+		{at: 0, bestBid: orderbook.Item{Price: 99, Amount: 1}, bidDepth: 2},
+		{at: 1500 * time.Millisecond, bestBid: orderbook.Item{Price: 99, Amount: 2}, bidDepth: 2},
+		{at: 2 * time.Second, bestBid: orderbook.Item{Price: 99, Amount: 3}, bidDepth: 2},
+		{at: 3 * time.Second, bestBid: orderbook.Item{Price: 97, Amount: 5}, bidDepth: 1},
+		{at: time.Hour, bestBid: orderbook.Item{Price: 97, Amount: 5}, bidDepth: 2},
+	} {
+		book, err := r.DepthAt(testStart.Add(tt.at))
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if len(book.Bids) != tt.bidDepth {
+			t.Fatalf("%v received: '%v' but expected: '%v'", tt.at, len(book.Bids), tt.bidDepth)
+		}
+		if book.Bids[0] != tt.bestBid {
+			t.Fatalf("%v received: '%v' but expected: '%v'", tt.at, book.Bids[0], tt.bestBid)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	r, err := NewReplayer(recordTestSeries(t), testExchange, testPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = r.Replay(context.Background(), testStart, testStart, -1)
+	if !errors.Is(err, errInvalidReplaySpeed) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidReplaySpeed)
+	}
+	err = r.Replay(context.Background(), testStart, testStart.Add(-1), 0)
+	if !errors.Is(err, errInvalidTimeRange) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimeRange)
+	}
+
+	pipe, err := r.Subscribe()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer func() {
+		if err := pipe.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	err = r.Replay(context.Background(), testStart.Add(time.Second), testStart.Add(time.Hour), 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// Dispatch drops publications for slow receivers so only the first is
+	// guaranteed when replaying as fast as possible
+	select {
+	case <-pipe.C:
+	case <-time.After(time.Second):
+		t.Fatal("expected replayed depth publication")
+	}
+	book, err := r.GetDepth().Retrieve()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(book.Bids) != 2 || book.Bids[1] != (orderbook.Item{Price: 96, Amount: 1}) {
+		t.Fatalf("unexpected replayed bids %+v", book.Bids)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = r.Replay(ctx, testStart, testStart.Add(time.Hour), 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.Canceled)
+	}
+}
+
+func TestReadFileOutsideSeriesPath(t *testing.T) {
+	t.Parallel()
+	r := &Replayer{path: t.TempDir()}
+	err := r.readFile("../index.json", nil)
+	if !errors.Is(err, errFileNotInSeriesPath) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errFileNotInSeriesPath)
+	}
+}
+
+func TestApplyUnknownRecord(t *testing.T) {
+	t.Parallel()
+	r := &Replayer{depth: orderbook.NewDepth([16]byte{1}), index: Index{Pair: currency.NewPair(currency.BTC, currency.USD)}}
+	err := r.apply(&Record{Type: "meow"})
+	if !errors.Is(err, errUnknownRecordType) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnknownRecordType)
+	}
+	err = r.apply(&Record{Type: UpdateRecord, Action: 99})
+	if !errors.Is(err, orderbook.ErrInvalidAction) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrInvalidAction)
+	}
+}
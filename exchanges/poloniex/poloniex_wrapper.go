@@ -639,6 +639,7 @@ func (p *Poloniex) CancelAllOrders(ctx context.Context, _ *order.Cancel) (order.
 func (p *Poloniex) GetOrderInfo(ctx context.Context, orderID string, pair currency.Pair, assetType asset.Item) (order.Detail, error) {
 	orderInfo := order.Detail{
 		Exchange: p.Name,
+		OrderID:  orderID,
 		Pair:     pair,
 	}
 
@@ -0,0 +1,435 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default circuit breaker settings
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenDuration     = 30 * time.Second
+	DefaultBreakerSuccessThreshold = 1
+	// healthDecay is the weight given to the latest attempt when updating an
+	// endpoint's health score
+	healthDecay = 0.1
+)
+
+// ErrCircuitOpen defines an error when requests are rejected because an
+// exchange endpoint's circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+var errInvalidBreakerConfig = errors.New("invalid circuit breaker config")
+
+// BreakerState defines the state of a circuit breaker, ordered by severity
+type BreakerState uint8
+
+// Circuit breaker states
+const (
+	// BreakerClosed allows all requests
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen allows a single probe request at a time, closing the
+	// breaker once enough probes succeed
+	BreakerHalfOpen
+	// BreakerOpen rejects all requests until the open duration has passed
+	BreakerOpen
+)
+
+// String implements the stringer interface
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig defines when a circuit breaker opens and closes. Zero values
+// are replaced by their defaults
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed attempts which
+	// open the breaker
+	FailureThreshold int
+	// OpenDuration is how long an open breaker rejects requests before a
+	// probe request is allowed
+	OpenDuration time.Duration
+	// SuccessThreshold is the number of consecutive successful probes which
+	// close a half-open breaker
+	SuccessThreshold int
+}
+
+// CircuitOpenError is returned when a request is rejected by an open circuit
+// breaker
+type CircuitOpenError struct {
+	Exchange string
+	Endpoint string
+	// RetryAt is when the breaker will next allow a probe request
+	RetryAt time.Time
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s %s %v, retry at %s", e.Exchange, e.Endpoint, ErrCircuitOpen, e.RetryAt.Format(time.RFC3339))
+}
+
+// Unwrap allows errors.Is to match ErrCircuitOpen
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
+
+// EndpointHealth defines the circuit breaker state and health of an exchange
+// endpoint
+type EndpointHealth struct {
+	Endpoint string
+	State    BreakerState
+	// Score is a decaying success rate between 0 and 1, reduced to 0 while
+	// the breaker is open and capped at 0.5 while half-open
+	Score               float64
+	ConsecutiveFailures int
+	Requests            int64
+	Failures            int64
+	OpenedAt            time.Time
+	LastError           string
+}
+
+// circuitBreakers holds a circuit breaker for each endpoint host of an
+// exchange
+type circuitBreakers struct {
+	cfg       BreakerConfig
+	endpoints map[string]*breaker
+	// hosts holds the endpoint host last requested for each rate limited
+	// endpoint, so its breaker can be checked before rate limiting
+	hosts map[EndpointLimit]string
+	m     sync.Mutex
+}
+
+type breaker struct {
+	state     BreakerState
+	failures  int
+	successes int
+	probing   bool
+	openedAt  time.Time
+	score     float64
+	requests  int64
+	failed    int64
+	lastError string
+}
+
+type breakerOutcome uint8
+
+const (
+	breakerSuccess breakerOutcome = iota
+	breakerFailure
+	breakerIgnore
+)
+
+// DefaultBreakerConfig returns the default circuit breaker config
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: DefaultBreakerFailureThreshold,
+		OpenDuration:     DefaultBreakerOpenDuration,
+		SuccessThreshold: DefaultBreakerSuccessThreshold,
+	}
+}
+
+func newCircuitBreakers(cfg BreakerConfig) (*circuitBreakers, error) {
+	if cfg.FailureThreshold < 0 || cfg.OpenDuration < 0 || cfg.SuccessThreshold < 0 {
+		return nil, fmt.Errorf("%w: values cannot be negative", errInvalidBreakerConfig)
+	}
+	if cfg.FailureThreshold == 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.OpenDuration == 0 {
+		cfg.OpenDuration = DefaultBreakerOpenDuration
+	}
+	if cfg.SuccessThreshold == 0 {
+		cfg.SuccessThreshold = DefaultBreakerSuccessThreshold
+	}
+	return &circuitBreakers{
+		cfg:       cfg,
+		endpoints: make(map[string]*breaker),
+		hosts:     make(map[EndpointLimit]string),
+	}, nil
+}
+
+// SetCircuitBreaker replaces the requester's circuit breakers, resetting the
+// state of all endpoints
+func (r *Requester) SetCircuitBreaker(cfg BreakerConfig) error {
+	if r == nil {
+		return ErrRequestSystemIsNil
+	}
+	c, err := newCircuitBreakers(cfg)
+	if err != nil {
+		return err
+	}
+	r.breakerMtx.Lock()
+	r.breakers = c
+	r.breakerMtx.Unlock()
+	return nil
+}
+
+// DisableCircuitBreaker stops requests being rejected by the requester's
+// circuit breakers
+func (r *Requester) DisableCircuitBreaker() error {
+	if r == nil {
+		return ErrRequestSystemIsNil
+	}
+	r.breakerMtx.Lock()
+	r.breakers = nil
+	r.breakerMtx.Unlock()
+	return nil
+}
+
+// CircuitState returns the most severe circuit breaker state across the
+// requester's endpoints
+func (r *Requester) CircuitState() BreakerState {
+	state := BreakerClosed
+	for _, h := range r.EndpointHealth() {
+		if h.State > state {
+			state = h.State
+		}
+	}
+	return state
+}
+
+// CheckCircuit returns a CircuitOpenError if the breaker of any of the
+// supplied endpoint hosts is rejecting requests, allowing callers to fail fast
+// before building requests to those endpoints. Breakers of other endpoints
+// are not checked, so a degraded endpoint does not block unrelated requests
+func (r *Requester) CheckCircuit(endpoints ...string) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	c := r.getBreakers()
+	health := c.health()
+	for i := range health {
+		if health[i].State != BreakerOpen {
+			continue
+		}
+		for j := range endpoints {
+			if health[i].Endpoint == endpoints[j] {
+				return &CircuitOpenError{
+					Exchange: r.name,
+					Endpoint: health[i].Endpoint,
+					RetryAt:  health[i].OpenedAt.Add(c.cfg.OpenDuration),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// HealthScore returns the lowest health score across the requester's
+// endpoints, 1 if no requests have been sent
+func (r *Requester) HealthScore() float64 {
+	score := 1.0
+	for _, h := range r.EndpointHealth() {
+		if h.Score < score {
+			score = h.Score
+		}
+	}
+	return score
+}
+
+// EndpointHealth returns the circuit breaker state and health of each
+// endpoint the requester has sent requests to, sorted by endpoint
+func (r *Requester) EndpointHealth() []EndpointHealth {
+	return r.getBreakers().health()
+}
+
+// getBreakers returns the requester's circuit breakers, nil if disabled
+func (r *Requester) getBreakers() *circuitBreakers {
+	if r == nil {
+		return nil
+	}
+	r.breakerMtx.RLock()
+	defer r.breakerMtx.RUnlock()
+	return r.breakers
+}
+
+// health returns the state and health of each endpoint. Open breakers whose
+// open duration has passed are reported as half-open, as the next request
+// will be allowed through as a probe
+func (c *circuitBreakers) health() []EndpointHealth {
+	if c == nil {
+		return nil
+	}
+	now := time.Now()
+	c.m.Lock()
+	defer c.m.Unlock()
+	health := make([]EndpointHealth, 0, len(c.endpoints))
+	for endpoint, b := range c.endpoints {
+		h := EndpointHealth{
+			Endpoint:            endpoint,
+			State:               b.state,
+			Score:               b.score,
+			ConsecutiveFailures: b.failures,
+			Requests:            b.requests,
+			Failures:            b.failed,
+			OpenedAt:            b.openedAt,
+			LastError:           b.lastError,
+		}
+		if h.State == BreakerOpen && !now.Before(b.openedAt.Add(c.cfg.OpenDuration)) {
+			h.State = BreakerHalfOpen
+		}
+		switch h.State {
+		case BreakerOpen:
+			h.Score = 0
+		case BreakerHalfOpen:
+			if h.Score > 0.5 {
+				h.Score = 0.5
+			}
+		}
+		health = append(health, h)
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Endpoint < health[j].Endpoint })
+	return health
+}
+
+// check returns a CircuitOpenError if the breaker of the endpoint host last
+// requested for the rate limited endpoint is rejecting requests, without
+// changing its state. This allows requests to be rejected before spending
+// rate limit tokens or weight on them
+func (c *circuitBreakers) check(exchange string, limit EndpointLimit, now time.Time) error {
+	if c == nil {
+		return nil
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	endpoint, ok := c.hosts[limit]
+	if !ok {
+		return nil
+	}
+	b, ok := c.endpoints[endpoint]
+	if !ok {
+		return nil
+	}
+	switch b.state {
+	case BreakerOpen:
+		if retryAt := b.openedAt.Add(c.cfg.OpenDuration); now.Before(retryAt) {
+			return &CircuitOpenError{Exchange: exchange, Endpoint: endpoint, RetryAt: retryAt}
+		}
+	case BreakerHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{Exchange: exchange, Endpoint: endpoint, RetryAt: now}
+		}
+	}
+	return nil
+}
+
+// allow returns a CircuitOpenError if the endpoint's breaker is rejecting
+// requests. An open breaker moves to half-open once its open duration has
+// passed, allowing a single probe request through at a time. Probe is set
+// when the allowed request is the probe, its outcome must be recorded as such
+func (c *circuitBreakers) allow(exchange, endpoint string, limit EndpointLimit, now time.Time) (probe bool, err error) {
+	if c == nil {
+		return false, nil
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.hosts[limit] = endpoint
+	b, ok := c.endpoints[endpoint]
+	if !ok {
+		return false, nil
+	}
+	retryAt := b.openedAt.Add(c.cfg.OpenDuration)
+	switch b.state {
+	case BreakerOpen:
+		if now.Before(retryAt) {
+			return false, &CircuitOpenError{Exchange: exchange, Endpoint: endpoint, RetryAt: retryAt}
+		}
+		b.state = BreakerHalfOpen
+		b.successes = 0
+		b.probing = true
+		return true, nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return false, &CircuitOpenError{Exchange: exchange, Endpoint: endpoint, RetryAt: now}
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the endpoint's breaker with the outcome of an attempt and
+// returns a CircuitOpenError if the attempt opened the breaker. Only the probe
+// request ends probing and moves a half-open breaker, attempts sent before the
+// breaker opened only update the endpoint's health
+func (c *circuitBreakers) record(ctx context.Context, exchange, endpoint string, probe bool, resp *http.Response, err error, now time.Time) error {
+	if c == nil {
+		return nil
+	}
+	outcome := classifyAttempt(ctx, resp, err)
+	c.m.Lock()
+	defer c.m.Unlock()
+	b, ok := c.endpoints[endpoint]
+	if !ok {
+		b = &breaker{score: 1}
+		c.endpoints[endpoint] = b
+	}
+	if probe {
+		b.probing = false
+	}
+	if outcome == breakerIgnore {
+		return nil
+	}
+	b.requests++
+	// Attempts sent before the breaker opened do not move a half-open breaker
+	stale := b.state == BreakerHalfOpen && !probe
+	if outcome == breakerSuccess {
+		b.score += healthDecay * (1 - b.score)
+		b.failures = 0
+		if b.state == BreakerHalfOpen && !stale {
+			b.successes++
+			if b.successes >= c.cfg.SuccessThreshold {
+				b.state = BreakerClosed
+				b.successes = 0
+			}
+		}
+		return nil
+	}
+
+	b.score -= healthDecay * b.score
+	b.failed++
+	b.failures++
+	if err != nil {
+		b.lastError = err.Error()
+	} else {
+		b.lastError = resp.Status
+	}
+	if stale || b.state == BreakerOpen || (b.state == BreakerClosed && b.failures < c.cfg.FailureThreshold) {
+		return nil
+	}
+	b.successes = 0
+	b.state = BreakerOpen
+	b.openedAt = now
+	return &CircuitOpenError{Exchange: exchange, Endpoint: endpoint, RetryAt: now.Add(c.cfg.OpenDuration)}
+}
+
+// classifyAttempt determines if an attempt counts against an endpoint's
+// health. Transport errors and server errors indicate the exchange is
+// unavailable, while client errors and rate limiting do not. Attempts ended by
+// the caller's context are ignored
+func classifyAttempt(ctx context.Context, resp *http.Response, err error) breakerOutcome {
+	if err != nil {
+		if ctx.Err() != nil {
+			return breakerIgnore
+		}
+		return breakerFailure
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return breakerFailure
+	}
+	return breakerSuccess
+}
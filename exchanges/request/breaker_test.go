@@ -0,0 +1,274 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewCircuitBreakers(t *testing.T) {
+	t.Parallel()
+	_, err := newCircuitBreakers(BreakerConfig{FailureThreshold: -1})
+	if !errors.Is(err, errInvalidBreakerConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidBreakerConfig)
+	}
+	c, err := newCircuitBreakers(BreakerConfig{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if c.cfg != DefaultBreakerConfig() {
+		t.Errorf("received: '%v' but expected: '%v'", c.cfg, DefaultBreakerConfig())
+	}
+
+	var r *Requester
+	err = r.SetCircuitBreaker(BreakerConfig{})
+	if !errors.Is(err, ErrRequestSystemIsNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrRequestSystemIsNil)
+	}
+	err = r.DisableCircuitBreaker()
+	if !errors.Is(err, ErrRequestSystemIsNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrRequestSystemIsNil)
+	}
+	if r.CircuitState() != BreakerClosed || r.HealthScore() != 1 || r.CheckCircuit("api") != nil {
+		t.Error("expected nil requester to report a closed breaker")
+	}
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	t.Parallel()
+	c, err := newCircuitBreakers(BreakerConfig{FailureThreshold: 2, OpenDuration: time.Minute, SuccessThreshold: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx := context.Background()
+	ok := &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}
+	down := &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}
+	now := time.Now()
+
+	// Client errors and rate limiting do not count against the endpoint
+	for _, code := range []int{http.StatusBadRequest, http.StatusTooManyRequests} {
+		if err = c.record(ctx, "test", "api", false, &http.Response{StatusCode: code}, nil, now); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	if err = c.record(ctx, "test", "api", false, down, nil, now); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = c.record(ctx, "test", "api", false, nil, errors.New("connection refused"), now)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	if openErr.Exchange != "test" || openErr.Endpoint != "api" || !openErr.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected circuit open error: %+v", openErr)
+	}
+
+	_, err = c.allow("test", "api", Unset, now.Add(time.Second))
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	if _, err = c.allow("test", "other", Unset, now); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// A single probe is allowed once the open duration has passed
+	later := now.Add(time.Minute)
+	probe, err := c.allow("test", "api", Unset, later)
+	if err != nil || !probe {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", err, probe, nil, true)
+	}
+	if _, err = c.allow("test", "api", Unset, later); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	// A failed probe reopens the breaker
+	if err = c.record(ctx, "test", "api", true, down, nil, later); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+
+	later = later.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if probe, err = c.allow("test", "api", Unset, later); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if c.endpoints["api"].state != BreakerHalfOpen {
+			t.Fatalf("received: '%v' but expected: '%v'", c.endpoints["api"].state, BreakerHalfOpen)
+		}
+		if err = c.record(ctx, "test", "api", probe, ok, nil, later); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	if c.endpoints["api"].state != BreakerClosed {
+		t.Fatalf("received: '%v' but expected: '%v'", c.endpoints["api"].state, BreakerClosed)
+	}
+
+	// Attempts cancelled by the caller are ignored
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err = c.record(cancelled, "test", "api", false, nil, context.Canceled, later); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	h := c.health()
+	if len(h) != 1 || h[0].Requests != 7 || h[0].Failures != 3 || h[0].ConsecutiveFailures != 0 || h[0].LastError != down.Status {
+		t.Errorf("unexpected endpoint health: %+v", h)
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	t.Parallel()
+	c, err := newCircuitBreakers(BreakerConfig{FailureThreshold: 1, OpenDuration: time.Minute, SuccessThreshold: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx := context.Background()
+	ok := &http.Response{StatusCode: http.StatusOK}
+	down := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	now := time.Now()
+	if err = c.record(ctx, "test", "api", false, down, nil, now); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	later := now.Add(time.Minute)
+	probe, err := c.allow("test", "api", Unset, later)
+	if err != nil || !probe {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", err, probe, nil, true)
+	}
+
+	// Requests sent before the breaker opened do not end the probe or move
+	// the half-open breaker
+	for _, resp := range []*http.Response{ok, down} {
+		if err = c.record(ctx, "test", "api", false, resp, nil, later); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if b := c.endpoints["api"]; b.state != BreakerHalfOpen || !b.probing {
+			t.Fatalf("received: '%v' probing '%v' but expected: '%v' probing '%v'", b.state, b.probing, BreakerHalfOpen, true)
+		}
+		if _, err = c.allow("test", "api", Unset, later); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+		}
+	}
+
+	if err = c.record(ctx, "test", "api", probe, ok, nil, later); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if b := c.endpoints["api"]; b.state != BreakerClosed || b.probing {
+		t.Fatalf("received: '%v' probing '%v' but expected: '%v' probing '%v'", b.state, b.probing, BreakerClosed, false)
+	}
+	probe, err = c.allow("test", "api", Unset, later)
+	if err != nil || probe {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", err, probe, nil, false)
+	}
+}
+
+func TestEndpointHealth(t *testing.T) {
+	t.Parallel()
+	c, err := newCircuitBreakers(BreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx := context.Background()
+	now := time.Now()
+	_ = c.record(ctx, "test", "b", false, &http.Response{StatusCode: http.StatusOK}, nil, now)
+	_ = c.record(ctx, "test", "a", false, &http.Response{StatusCode: http.StatusOK}, nil, now)
+	r := &Requester{name: "test", breakers: c}
+	if r.CircuitState() != BreakerClosed || r.HealthScore() != 1 || r.CheckCircuit("a", "b") != nil {
+		t.Fatal("expected healthy endpoints")
+	}
+
+	_ = c.record(ctx, "test", "b", false, &http.Response{StatusCode: http.StatusBadGateway}, nil, now)
+	h := r.EndpointHealth()
+	if len(h) != 2 || h[0].Endpoint != "a" || h[1].Endpoint != "b" {
+		t.Fatalf("unexpected endpoint health: %+v", h)
+	}
+	if h[1].State != BreakerOpen || h[1].Score != 0 || r.HealthScore() != 0 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", h[1].State, h[1].Score, BreakerOpen, 0)
+	}
+	if r.CircuitState() != BreakerOpen {
+		t.Errorf("received: '%v' but expected: '%v'", r.CircuitState(), BreakerOpen)
+	}
+	err = r.CheckCircuit("a", "b")
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Endpoint != "b" {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	// Only the breakers of the requested endpoints are checked
+	for _, endpoints := range [][]string{{"a"}, {"c"}, nil} {
+		if err = r.CheckCircuit(endpoints...); err != nil {
+			t.Errorf("%v received: '%v' but expected: '%v'", endpoints, err, nil)
+		}
+	}
+
+	// Once the open duration has passed the breaker is reported as half-open
+	c.endpoints["b"].openedAt = now.Add(-time.Hour)
+	h = r.EndpointHealth()
+	if h[1].State != BreakerHalfOpen || h[1].Score != 0.5 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", h[1].State, h[1].Score, BreakerHalfOpen, 0.5)
+	}
+	if r.CheckCircuit("b") != nil {
+		t.Error("expected half-open breaker to allow requests")
+	}
+
+	err = r.DisableCircuitBreaker()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if r.EndpointHealth() != nil {
+		t.Error("expected no endpoint health when disabled")
+	}
+}
+
+func TestCircuitBreakerRequester(t *testing.T) {
+	t.Parallel()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	l := &countLimiter{}
+	r, err := New("test", srv.Client(),
+		WithBackoff(func(int) time.Duration { return 0 }),
+		WithLimiter(l),
+		WithCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := func() (*Item, error) { return &Item{Method: http.MethodGet, Path: srv.URL}, nil }
+
+	// The breaker opens on the second attempt and further retries are stopped
+	err = r.SendPayload(context.Background(), Unset, item)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	if h := atomic.LoadInt32(&hits); h != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", h, 2)
+	}
+	// Requests are rejected without reaching the exchange or spending rate
+	// limit tokens
+	err = r.SendPayload(context.Background(), Unset, item)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrCircuitOpen)
+	}
+	if h := atomic.LoadInt32(&hits); h != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", h, 2)
+	}
+	if c := atomic.LoadInt32(&l.calls); c != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", c, 2)
+	}
+	if r.CircuitState() != BreakerOpen {
+		t.Errorf("received: '%v' but expected: '%v'", r.CircuitState(), BreakerOpen)
+	}
+}
+
+type countLimiter struct {
+	calls int32
+}
+
+func (l *countLimiter) Limit(context.Context, EndpointLimit) error {
+	atomic.AddInt32(&l.calls, 1)
+	return nil
+}
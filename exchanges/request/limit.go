@@ -14,6 +14,8 @@ import (
 var (
 	ErrRateLimiterAlreadyDisabled = errors.New("rate limiter already disabled")
 	ErrRateLimiterAlreadyEnabled  = errors.New("rate limiter already enabled")
+
+	errLimiterIsNil = errors.New("rate limiter is nil")
 )
 
 // Const here define individual functionality sub types for rate limiting
@@ -74,13 +76,46 @@ func (r *Requester) InitiateRateLimit(ctx context.Context, e EndpointLimit) erro
 		return nil
 	}
 
-	if r.limiter != nil {
-		return r.limiter.Limit(ctx, e)
+	if l := r.getLimiter(); l != nil {
+		return l.Limit(ctx, e)
 	}
 
 	return nil
 }
 
+// UpdateRateLimitUsage passes the usage of an endpoint's rate limit reported
+// outside of HTTP responses, such as by websocket requests sharing the same
+// limits, to the rate limiter when supported
+func (r *Requester) UpdateRateLimitUsage(e EndpointLimit, used int) {
+	if r == nil {
+		return
+	}
+	if u, ok := r.getLimiter().(UsageUpdater); ok {
+		u.UpdateUsage(e, used)
+	}
+}
+
+// SetLimiter replaces the rate limiter used by the requester
+func (r *Requester) SetLimiter(l Limiter) error {
+	if r == nil {
+		return ErrRequestSystemIsNil
+	}
+	if l == nil {
+		return errLimiterIsNil
+	}
+	r.limiterMtx.Lock()
+	r.limiter = l
+	r.limiterMtx.Unlock()
+	return nil
+}
+
+// getLimiter returns the rate limiter used by the requester
+func (r *Requester) getLimiter() Limiter {
+	r.limiterMtx.RLock()
+	defer r.limiterMtx.RUnlock()
+	return r.limiter
+}
+
 // DisableRateLimiter disables the rate limiting system for the exchange
 func (r *Requester) DisableRateLimiter() error {
 	if r == nil {
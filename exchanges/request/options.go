@@ -27,3 +27,13 @@ func WithReporter(rep Reporter) RequesterOption {
 		r.reporter = rep
 	}
 }
+
+// WithCircuitBreaker configures the circuit breakers for a Requester. Invalid
+// configs are ignored in favour of the default config
+func WithCircuitBreaker(cfg BreakerConfig) RequesterOption {
+	return func(r *Requester) {
+		if c, err := newCircuitBreakers(cfg); err == nil {
+			r.breakers = c
+		}
+	}
+}
@@ -10,8 +10,35 @@ type Reporter interface {
 	Latency(name, method, path string, t time.Duration)
 }
 
+// ErrorReporter is an optional Reporter extension which is notified of each
+// failed HTTP request attempt, including unsuccessful status codes.
+type ErrorReporter interface {
+	RequestError(name, method, path string, err error)
+}
+
+// RateLimitReporter is an optional Reporter extension which is notified of the
+// time spent waiting on the rate limiter before each HTTP request attempt.
+type RateLimitReporter interface {
+	RateLimitWait(name string, t time.Duration)
+}
+
 // SetupGlobalReporter sets a reporter interface to be used
 // for all exchange requests
 func SetupGlobalReporter(r Reporter) {
 	globalReporter = r
 }
+
+// reportError notifies the reporter of a failed request attempt when supported
+func (r *Requester) reportError(method, path string, err error) {
+	if rep, ok := r.reporter.(ErrorReporter); ok {
+		rep.RequestError(r.name, method, path, err)
+	}
+}
+
+// reportRateLimitWait notifies the reporter of the time spent waiting on the
+// rate limiter when supported
+func (r *Requester) reportRateLimitWait(t time.Duration) {
+	if rep, ok := r.reporter.(RateLimitReporter); ok {
+		rep.RateLimitWait(r.name, t)
+	}
+}
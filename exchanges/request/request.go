@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 )
 
 const contextVerboseFlag verbosity = "verbose"
@@ -78,7 +80,10 @@ func (r *Requester) SendPayload(ctx context.Context, ep EndpointLimit, newReques
 	}
 
 	atomic.AddInt32(&r.jobs, 1)
+	ctx, span := tracing.StartSpan(ctx, "request.SendPayload", tracing.String("exchange", r.name))
 	err := r.doRequest(ctx, ep, newRequest)
+	span.RecordError(err)
+	span.End()
 	atomic.AddInt32(&r.jobs, -1)
 	return err
 }
@@ -132,11 +137,23 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 		default:
 		}
 
+		// Reject requests to an unavailable endpoint before spending rate
+		// limit tokens or weight on them
+		breakers := r.getBreakers()
+		if err := breakers.check(r.name, endpoint, time.Now()); err != nil {
+			return err
+		}
+
 		// Initiate a rate limit reservation and sleep on requested endpoint
+		_, waitSpan := tracing.StartSpan(ctx, "request.RateLimitWait", tracing.String("exchange", r.name))
+		waitStart := time.Now()
 		err := r.InitiateRateLimit(ctx, endpoint)
+		waitSpan.RecordError(err)
+		waitSpan.End()
 		if err != nil {
 			return fmt.Errorf("failed to rate limit HTTP request: %w", err)
 		}
+		r.reportRateLimitWait(time.Since(waitStart))
 
 		p, err := newRequest()
 		if err != nil {
@@ -161,22 +178,55 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 			}
 		}
 
+		probe, err := breakers.allow(r.name, req.URL.Host, endpoint, time.Now())
+		if err != nil {
+			r.reportError(p.Method, p.Path, err)
+			return err
+		}
+
+		_, httpSpan := tracing.StartSpan(ctx, "request.HTTP",
+			tracing.String("exchange", r.name),
+			tracing.String("http.method", p.Method),
+			tracing.String("http.url", stripQuery(p.Path)),
+			tracing.Int("attempt", attempt))
 		start := time.Now()
 
 		resp, err := r._HTTPClient.do(req)
+		tripErr := breakers.record(ctx, r.name, req.URL.Host, probe, resp, err, time.Now())
+		if err != nil {
+			httpSpan.RecordError(err)
+		} else {
+			httpSpan.SetAttributes(tracing.Int("http.status_code", resp.StatusCode))
+			if o, ok := r.getLimiter().(ResponseObserver); ok {
+				o.ObserveResponse(endpoint, resp)
+			}
+		}
 
-		if r.reporter != nil && err == nil {
-			r.reporter.Latency(r.name, p.Method, p.Path, time.Since(start))
+		if r.reporter != nil {
+			if err != nil {
+				r.reportError(p.Method, p.Path, err)
+			} else {
+				r.reporter.Latency(r.name, p.Method, p.Path, time.Since(start))
+			}
 		}
 
-		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
+		retry, checkErr := r.retryPolicy(resp, err)
+		httpSpan.SetAttributes(tracing.Bool("retry", retry))
+		httpSpan.End()
+		if checkErr != nil {
 			return checkErr
 		} else if retry {
 			if err == nil {
+				r.reportError(p.Method, p.Path, fmt.Errorf("retrying request, status: %s", resp.Status))
 				// If the body isn't fully read, the connection cannot be re-used
 				r.drainBody(resp.Body)
 			}
 
+			if tripErr != nil {
+				// Stop retrying as the exchange is considered unavailable
+				return tripErr
+			}
+
 			if attempt > r.maxRetries {
 				if err != nil {
 					return fmt.Errorf("%w, err: %v", errFailedToRetryRequest, err)
@@ -237,10 +287,12 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 
 		if resp.StatusCode < http.StatusOK ||
 			resp.StatusCode > http.StatusAccepted {
-			return fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
+			err = fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
 				r.name,
 				resp.StatusCode,
 				string(contents))
+			r.reportError(p.Method, p.Path, err)
+			return err
 		}
 
 		if p.HTTPDebugging {
@@ -275,6 +327,15 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 	}
 }
 
+// stripQuery removes the query from a request path as it may contain
+// credentials or signatures
+func stripQuery(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
 func (r *Requester) drainBody(body io.ReadCloser) {
 	if _, err := io.Copy(io.Discard, io.LimitReader(body, drainBodyLimit)); err != nil {
 		log.Errorf(log.RequestSys,
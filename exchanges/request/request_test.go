@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +20,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/tracing"
 	"golang.org/x/time/rate"
 )
 
@@ -505,6 +507,166 @@ func TestDoRequest_NotRetryable(t *testing.T) {
 	}
 }
 
+type testReporter struct {
+	latencies int
+	errors    []string
+	waits     int
+}
+
+func (r *testReporter) Latency(_, _, _ string, _ time.Duration) {
+	r.latencies++
+}
+
+func (r *testReporter) RequestError(_, _, path string, _ error) {
+	r.errors = append(r.errors, path)
+}
+
+func (r *testReporter) RateLimitWait(_ string, _ time.Duration) {
+	r.waits++
+}
+
+func TestReporter(t *testing.T) {
+	t.Parallel()
+	rep := &testReporter{}
+	r, err := New("test",
+		new(http.Client),
+		WithReporter(rep),
+		WithLimiter(NewBasicRateLimit(time.Millisecond, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: testURL}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: testURL + "/error"}, nil
+	})
+	if err == nil {
+		t.Fatal("expected an unsuccessful status code error")
+	}
+	if rep.latencies != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", rep.latencies, 2)
+	}
+	if rep.waits != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", rep.waits, 2)
+	}
+	if len(rep.errors) != 1 || rep.errors[0] != testURL+"/error" {
+		t.Errorf("received: '%v' but expected: '%v'", rep.errors, testURL+"/error")
+	}
+}
+
+// TestTracing does not run in parallel as the tracer is package global
+func TestTracing(t *testing.T) {
+	type span struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+		Attributes   []struct {
+			Key   string `json:"key"`
+			Value struct {
+				StringValue string `json:"stringValue"`
+				IntValue    string `json:"intValue"`
+				BoolValue   bool   `json:"boolValue"`
+			} `json:"value"`
+		} `json:"attributes"`
+		Status struct {
+			Code int `json:"code"`
+		} `json:"status"`
+	}
+	var mtx sync.Mutex
+	var spans []span
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var export struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []span `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&export); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mtx.Lock()
+		for i := range export.ResourceSpans {
+			for j := range export.ResourceSpans[i].ScopeSpans {
+				spans = append(spans, export.ResourceSpans[i].ScopeSpans[j].Spans...)
+			}
+		}
+		mtx.Unlock()
+	}))
+	defer collector.Close()
+
+	err := tracing.Start(collector.URL, "test", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := New("tracing", new(http.Client), WithBackoff(func(int) time.Duration { return 0 }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.maxRetries = 1
+	err = r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: testURL + "/always-retry?signature=secret"}, nil
+	})
+	if !errors.Is(err, errFailedToRetryRequest) {
+		t.Fatalf("received: %v but expected: %v", err, errFailedToRetryRequest)
+	}
+	err = tracing.Stop()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload *span
+	var waits, attempts []span
+	for i := range spans {
+		switch spans[i].Name {
+		case "request.SendPayload":
+			payload = &spans[i]
+		case "request.RateLimitWait":
+			waits = append(waits, spans[i])
+		case "request.HTTP":
+			attempts = append(attempts, spans[i])
+		}
+	}
+	if payload == nil {
+		t.Fatal("expected payload span to be exported")
+	}
+	if payload.Status.Code != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", payload.Status.Code, 2)
+	}
+	if len(waits) != 2 || len(attempts) != 2 {
+		t.Fatalf("received: '%v' rate limit and '%v' HTTP spans but expected two of each", len(waits), len(attempts))
+	}
+	for i := range attempts {
+		if attempts[i].TraceID != payload.TraceID || attempts[i].ParentSpanID != payload.SpanID {
+			t.Error("expected HTTP span to be a child of the payload span")
+		}
+		var path, status string
+		var retry bool
+		for _, a := range attempts[i].Attributes {
+			switch a.Key {
+			case "http.url":
+				path = a.Value.StringValue
+			case "http.status_code":
+				status = a.Value.IntValue
+			case "retry":
+				retry = a.Value.BoolValue
+			}
+		}
+		if path != testURL+"/always-retry" {
+			t.Errorf("received: '%v' but expected: '%v'", path, testURL+"/always-retry")
+		}
+		if status != "429" || !retry {
+			t.Errorf("received status: '%v' retry: '%v' but expected status: '429' retry: 'true'", status, retry)
+		}
+	}
+}
+
 func TestGetNonce(t *testing.T) {
 	t.Parallel()
 	r, err := New("test",
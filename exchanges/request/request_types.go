@@ -3,6 +3,7 @@ package request
 import (
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
@@ -30,6 +31,7 @@ var (
 type Requester struct {
 	_HTTPClient        *client
 	limiter            Limiter
+	limiterMtx         sync.RWMutex
 	reporter           Reporter
 	name               string
 	userAgent          string
@@ -40,6 +42,8 @@ type Requester struct {
 	backoff            Backoff
 	retryPolicy        RetryPolicy
 	timedLock          *timedmutex.TimedMutex
+	breakers           *circuitBreakers
+	breakerMtx         sync.RWMutex
 }
 
 // Item is a temp item for requests
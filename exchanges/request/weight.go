@@ -0,0 +1,359 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultWeightThreshold is the fraction of a weight budget which can be spent
+// before requests are slowed to the rate the budget recovers
+const DefaultWeightThreshold = 0.8
+
+var (
+	errNoWeightBudgets      = errors.New("no weight budgets supplied")
+	errInvalidWeightBudget  = errors.New("invalid weight budget")
+	errWeightBudgetNotFound = errors.New("weight budget not found")
+	errEndpointNotWeighted  = errors.New("endpoint has no weight and no fallback limiter is set")
+	errInvalidThreshold     = errors.New("threshold must be greater than 0 and no greater than 1")
+)
+
+// UsageParser returns the weight used so far, as reported by exchange response
+// headers. A capacity of zero reports the usage of the whole budget. A
+// non-zero capacity reports the usage and limit of the requested endpoint
+// alone, which is tracked separately from the budget it shares with other
+// endpoints
+type UsageParser func(http.Header) (used, capacity int, ok bool)
+
+// ResponseObserver is an optional Limiter extension which adjusts its limits
+// from exchange responses
+type ResponseObserver interface {
+	ObserveResponse(EndpointLimit, *http.Response)
+}
+
+// UsageUpdater is an optional Limiter extension which corrects its limits from
+// usage reported outside of HTTP responses, such as websocket messages
+type UsageUpdater interface {
+	UpdateUsage(e EndpointLimit, used int)
+}
+
+// WeightBudget defines a weight budget shared by a set of endpoints. Spent
+// weight recovers linearly, with a fully spent budget recovering over interval
+type WeightBudget struct {
+	Name     string
+	Capacity int
+	Interval time.Duration
+	// Usage optionally reads the used weight from response headers
+	Usage UsageParser
+}
+
+// EndpointWeight defines the budget an endpoint spends and its weight
+type EndpointWeight struct {
+	Budget string
+	Weight int
+}
+
+// WeightedLimit is a Limiter which spends per endpoint weights from shared
+// budgets. Budget usage is corrected from exchange response headers, and
+// requests are slowed once a budget passes its threshold rather than being
+// rejected by the exchange. Endpoints without a weight are passed to the
+// fallback limiter. The same WeightedLimit may be used by REST and websocket
+// requests to share budgets between them
+type WeightedLimit struct {
+	budgets   map[string]*weightBudget
+	endpoints map[EndpointLimit]EndpointWeight
+	fallback  Limiter
+	// endpointBudgets hold per endpoint limits reported by the exchange,
+	// which are spent alongside the endpoint's shared budget
+	endpointBudgets map[EndpointLimit]*weightBudget
+	m               sync.RWMutex
+}
+
+type weightBudget struct {
+	capacity     float64
+	interval     time.Duration
+	threshold    float64
+	usage        UsageParser
+	used         float64
+	updated      time.Time
+	blockedUntil time.Time
+	m            sync.Mutex
+}
+
+// NewWeightedRateLimit returns a WeightedLimit for the supplied budgets and
+// endpoint weights. fallback may be nil if all endpoints are weighted
+func NewWeightedRateLimit(budgets []WeightBudget, endpoints map[EndpointLimit]EndpointWeight, fallback Limiter) (*WeightedLimit, error) {
+	if len(budgets) == 0 {
+		return nil, errNoWeightBudgets
+	}
+	w := &WeightedLimit{
+		budgets:         make(map[string]*weightBudget, len(budgets)),
+		endpoints:       endpoints,
+		fallback:        fallback,
+		endpointBudgets: make(map[EndpointLimit]*weightBudget),
+	}
+	for i := range budgets {
+		if budgets[i].Name == "" || budgets[i].Capacity <= 0 || budgets[i].Interval <= 0 {
+			return nil, fmt.Errorf("%w %q", errInvalidWeightBudget, budgets[i].Name)
+		}
+		w.budgets[budgets[i].Name] = &weightBudget{
+			capacity:  float64(budgets[i].Capacity),
+			interval:  budgets[i].Interval,
+			threshold: DefaultWeightThreshold,
+			usage:     budgets[i].Usage,
+		}
+	}
+	for e, ew := range endpoints {
+		if _, ok := w.budgets[ew.Budget]; !ok {
+			return nil, fmt.Errorf("endpoint %d %w: %q", e, errWeightBudgetNotFound, ew.Budget)
+		}
+	}
+	return w, nil
+}
+
+// SetThreshold sets the fraction of every budget which can be spent before
+// requests are slowed
+func (w *WeightedLimit) SetThreshold(threshold float64) error {
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("%w, received %v", errInvalidThreshold, threshold)
+	}
+	for _, b := range w.budgets {
+		b.m.Lock()
+		b.threshold = threshold
+		b.m.Unlock()
+	}
+	w.m.RLock()
+	defer w.m.RUnlock()
+	for _, b := range w.endpointBudgets {
+		b.m.Lock()
+		b.threshold = threshold
+		b.m.Unlock()
+	}
+	return nil
+}
+
+// SetCapacity overrides the capacity of a budget
+func (w *WeightedLimit) SetCapacity(budget string, capacity int) error {
+	b, ok := w.budgets[budget]
+	if !ok {
+		return fmt.Errorf("%w: %q", errWeightBudgetNotFound, budget)
+	}
+	if capacity <= 0 {
+		return fmt.Errorf("%w %q: capacity must be greater than zero", errInvalidWeightBudget, budget)
+	}
+	b.m.Lock()
+	b.capacity = float64(capacity)
+	b.m.Unlock()
+	return nil
+}
+
+// Limit waits until the endpoint's weight can be spent from its budget
+func (w *WeightedLimit) Limit(ctx context.Context, e EndpointLimit) error {
+	ew, ok := w.endpoints[e]
+	if !ok {
+		if w.fallback == nil {
+			return fmt.Errorf("%w: %d", errEndpointNotWeighted, e)
+		}
+		return w.fallback.Limit(ctx, e)
+	}
+	budgets := []*weightBudget{w.budgets[ew.Budget]}
+	w.m.RLock()
+	if eb, ok := w.endpointBudgets[e]; ok {
+		budgets = append(budgets, eb)
+	}
+	w.m.RUnlock()
+	for {
+		delay := spendBudgets(float64(ew.Weight), time.Now(), budgets...)
+		if delay <= 0 {
+			return nil
+		}
+		if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(delay)) {
+			return fmt.Errorf("rate limit delay of %s will exceed deadline: %w", delay, context.DeadlineExceeded)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ObserveResponse corrects the endpoint's budget from the response headers,
+// and blocks the budget for the duration of any Retry-After header when the
+// exchange rejects a request for exceeding its limits
+func (w *WeightedLimit) ObserveResponse(e EndpointLimit, resp *http.Response) {
+	ew, ok := w.endpoints[e]
+	if !ok || resp == nil {
+		return
+	}
+	b := w.budgets[ew.Budget]
+	now := time.Now()
+	if b.usage != nil {
+		if used, capacity, ok := b.usage(resp.Header); ok {
+			if capacity > 0 {
+				w.updateEndpointBudget(e, b, float64(used), float64(capacity), now)
+			} else {
+				b.update(float64(used), 0, now)
+			}
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+		b.block(RetryAfter(resp, now), now)
+	}
+}
+
+// UpdateUsage sets the used weight of the endpoint's budget, for responses
+// which report usage outside of HTTP headers such as websocket messages
+func (w *WeightedLimit) UpdateUsage(e EndpointLimit, used int) {
+	ew, ok := w.endpoints[e]
+	if !ok {
+		return
+	}
+	w.budgets[ew.Budget].update(float64(used), 0, time.Now())
+}
+
+// updateEndpointBudget sets the used weight and capacity of an endpoint's own
+// limit, creating it alongside the endpoint's shared budget when first reported
+func (w *WeightedLimit) updateEndpointBudget(e EndpointLimit, shared *weightBudget, used, capacity float64, now time.Time) {
+	w.m.Lock()
+	eb, ok := w.endpointBudgets[e]
+	if !ok {
+		shared.m.Lock()
+		eb = &weightBudget{
+			capacity:  capacity,
+			interval:  shared.interval,
+			threshold: shared.threshold,
+		}
+		shared.m.Unlock()
+		w.endpointBudgets[e] = eb
+	}
+	w.m.Unlock()
+	eb.update(used, capacity, now)
+}
+
+// spend spends weight if the budget remains within its threshold, otherwise it
+// returns how long to wait for enough of the budget to recover
+func (b *weightBudget) spend(weight float64, now time.Time) time.Duration {
+	return spendBudgets(weight, now, b)
+}
+
+// spendBudgets spends weight from every budget if all remain within their
+// thresholds, otherwise nothing is spent and it returns how long to wait for
+// enough of each budget to recover. Shared budgets must be supplied before
+// endpoint budgets to keep a consistent lock order
+func spendBudgets(weight float64, now time.Time, budgets ...*weightBudget) time.Duration {
+	for i := range budgets {
+		budgets[i].m.Lock()
+	}
+	defer func() {
+		for i := range budgets {
+			budgets[i].m.Unlock()
+		}
+	}()
+	var delay time.Duration
+	for i := range budgets {
+		if d := budgets[i].delay(weight, now); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+	for i := range budgets {
+		budgets[i].used += weight
+	}
+	return 0
+}
+
+// delay returns how long to wait before weight can be spent within the
+// budget's threshold. The caller must hold the lock
+func (b *weightBudget) delay(weight float64, now time.Time) time.Duration {
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	b.replenish(now)
+	limit := b.capacity * b.threshold
+	if weight > limit {
+		// Weights larger than the threshold wait for the budget to fully
+		// recover rather than never being sent
+		limit = weight
+	}
+	if excess := b.used + weight - limit; excess > 0 {
+		return time.Duration(math.Ceil(excess / b.capacity * float64(b.interval)))
+	}
+	return 0
+}
+
+// replenish reduces the used weight by the amount recovered since last
+// updated
+func (b *weightBudget) replenish(now time.Time) {
+	if !b.updated.IsZero() {
+		b.used -= b.capacity * float64(now.Sub(b.updated)) / float64(b.interval)
+		if b.used < 0 {
+			b.used = 0
+		}
+	}
+	b.updated = now
+}
+
+// update sets the used weight as reported by the exchange
+func (b *weightBudget) update(used, capacity float64, now time.Time) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if capacity > 0 {
+		b.capacity = capacity
+	}
+	b.used = used
+	b.updated = now
+}
+
+// block prevents spending until the exchange allows requests again. Without a
+// Retry-After duration the budget is treated as fully spent
+func (b *weightBudget) block(retryAfter time.Duration, now time.Time) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if retryAfter > 0 {
+		if until := now.Add(retryAfter); until.After(b.blockedUntil) {
+			b.blockedUntil = until
+		}
+	}
+	b.used = b.capacity
+	b.updated = now
+}
+
+// UsedWeightHeader returns a UsageParser which reads the used weight from a
+// header, such as Binance's X-MBX-USED-WEIGHT-1M
+func UsedWeightHeader(header string) UsageParser {
+	return func(h http.Header) (int, int, bool) {
+		used, err := strconv.Atoi(h.Get(header))
+		if err != nil {
+			return 0, 0, false
+		}
+		return used, 0, true
+	}
+}
+
+// RemainingWeightHeaders returns a UsageParser which derives the used weight
+// from a limit and remaining header, such as Bybit's X-Bapi-Limit and
+// X-Bapi-Limit-Status. The limit is reported as the capacity of the requested
+// endpoint
+func RemainingWeightHeaders(limitHeader, remainingHeader string) UsageParser {
+	return func(h http.Header) (int, int, bool) {
+		limit, err := strconv.Atoi(h.Get(limitHeader))
+		if err != nil || limit <= 0 {
+			return 0, 0, false
+		}
+		remaining, err := strconv.Atoi(h.Get(remainingHeader))
+		if err != nil || remaining < 0 || remaining > limit {
+			return 0, 0, false
+		}
+		return limit - remaining, limit, true
+	}
+}
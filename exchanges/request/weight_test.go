@@ -0,0 +1,272 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testWeighted EndpointLimit = iota + 100
+	testHeavy
+	testUnweighted
+)
+
+func newTestWeightedLimit(t *testing.T, fallback Limiter) *WeightedLimit {
+	t.Helper()
+	w, err := NewWeightedRateLimit([]WeightBudget{{
+		Name:     "spot",
+		Capacity: 100,
+		Interval: time.Second,
+		Usage:    UsedWeightHeader("X-Used-Weight"),
+	}}, map[EndpointLimit]EndpointWeight{
+		testWeighted: {Budget: "spot", Weight: 10},
+		testHeavy:    {Budget: "spot", Weight: 150},
+	}, fallback)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return w
+}
+
+func TestNewWeightedRateLimit(t *testing.T) {
+	t.Parallel()
+	_, err := NewWeightedRateLimit(nil, nil, nil)
+	if !errors.Is(err, errNoWeightBudgets) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoWeightBudgets)
+	}
+	_, err = NewWeightedRateLimit([]WeightBudget{{Name: "spot"}}, nil, nil)
+	if !errors.Is(err, errInvalidWeightBudget) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidWeightBudget)
+	}
+	_, err = NewWeightedRateLimit([]WeightBudget{{Name: "spot", Capacity: 1, Interval: time.Second}},
+		map[EndpointLimit]EndpointWeight{Unset: {Budget: "futures", Weight: 1}}, nil)
+	if !errors.Is(err, errWeightBudgetNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errWeightBudgetNotFound)
+	}
+
+	w := newTestWeightedLimit(t, nil)
+	err = w.SetThreshold(0)
+	if !errors.Is(err, errInvalidThreshold) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidThreshold)
+	}
+	err = w.SetThreshold(0.5)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.SetCapacity("futures", 1)
+	if !errors.Is(err, errWeightBudgetNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errWeightBudgetNotFound)
+	}
+	err = w.SetCapacity("spot", 0)
+	if !errors.Is(err, errInvalidWeightBudget) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidWeightBudget)
+	}
+	err = w.SetCapacity("spot", 200)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if b := w.budgets["spot"]; b.capacity != 200 || b.threshold != 0.5 {
+		t.Errorf("received capacity: '%v' threshold: '%v' but expected: '200' '0.5'", b.capacity, b.threshold)
+	}
+}
+
+func TestWeightBudgetSpend(t *testing.T) {
+	t.Parallel()
+	b := &weightBudget{capacity: 100, interval: time.Second, threshold: 0.8}
+	now := time.Now()
+	for i := 0; i < 8; i++ {
+		if delay := b.spend(10, now); delay != 0 {
+			t.Fatalf("received: '%v' but expected: '%v'", delay, 0)
+		}
+	}
+	// The threshold has been reached so the next request must wait for 10
+	// weight to recover
+	if delay := b.spend(10, now); delay != 100*time.Millisecond {
+		t.Fatalf("received: '%v' but expected: '%v'", delay, 100*time.Millisecond)
+	}
+	if delay := b.spend(10, now.Add(100*time.Millisecond)); delay != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", delay, 0)
+	}
+
+	// Weights larger than the threshold are sent once the budget has recovered
+	if delay := b.spend(150, now.Add(100*time.Millisecond)); delay != 800*time.Millisecond {
+		t.Fatalf("received: '%v' but expected: '%v'", delay, 800*time.Millisecond)
+	}
+	if delay := b.spend(150, now.Add(900*time.Millisecond)); delay != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", delay, 0)
+	}
+}
+
+func TestWeightedLimit(t *testing.T) {
+	t.Parallel()
+	w := newTestWeightedLimit(t, nil)
+	err := w.Limit(context.Background(), testUnweighted)
+	if !errors.Is(err, errEndpointNotWeighted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errEndpointNotWeighted)
+	}
+
+	w = newTestWeightedLimit(t, NewBasicRateLimit(0, 0))
+	err = w.Limit(context.Background(), testUnweighted)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.Limit(context.Background(), testWeighted)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// The exchange reports the budget is nearly spent, so the next request is
+	// delayed beyond the context deadline
+	w.UpdateUsage(testWeighted, 95)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = w.Limit(ctx, testWeighted)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWeightedLimitObserveResponse(t *testing.T) {
+	t.Parallel()
+	w := newTestWeightedLimit(t, nil)
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("X-Used-Weight", "42")
+	w.ObserveResponse(testWeighted, resp)
+	if used := w.budgets["spot"].used; used != 42 {
+		t.Fatalf("received: '%v' but expected: '%v'", used, 42)
+	}
+
+	resp = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "2")
+	w.ObserveResponse(testWeighted, resp)
+	b := w.budgets["spot"]
+	if b.used != b.capacity {
+		t.Errorf("received: '%v' but expected: '%v'", b.used, b.capacity)
+	}
+	if delay := b.spend(1, time.Now()); delay <= time.Second {
+		t.Errorf("received: '%v' but expected the budget to be blocked for the Retry-After duration", delay)
+	}
+}
+
+func TestWeightedLimitEndpointCapacity(t *testing.T) {
+	t.Parallel()
+	w, err := NewWeightedRateLimit([]WeightBudget{{
+		Name:     "private",
+		Capacity: 100,
+		Interval: time.Minute,
+		Usage:    RemainingWeightHeaders("X-Bapi-Limit", "X-Bapi-Limit-Status"),
+	}}, map[EndpointLimit]EndpointWeight{
+		testWeighted: {Budget: "private", Weight: 1},
+		testHeavy:    {Budget: "private", Weight: 1},
+	}, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("X-Bapi-Limit", "10")
+	resp.Header.Set("X-Bapi-Limit-Status", "2")
+	w.ObserveResponse(testWeighted, resp)
+
+	// The endpoint limit does not replace the shared budget
+	b := w.budgets["private"]
+	if b.capacity != 100 || b.used != 0 {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", b.capacity, b.used, 100, 0)
+	}
+	eb := w.endpointBudgets[testWeighted]
+	if eb == nil || eb.capacity != 10 || eb.used != 8 {
+		t.Fatalf("unexpected endpoint budget %+v", eb)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err = w.Limit(ctx, testWeighted)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+	if b.used != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", b.used, 0)
+	}
+	err = w.Limit(context.Background(), testHeavy)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if b.used <= 0 {
+		t.Fatal("expected shared budget to be spent")
+	}
+
+	err = w.SetThreshold(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.Limit(context.Background(), testWeighted)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestWeightedLimitRequester(t *testing.T) {
+	t.Parallel()
+	w := newTestWeightedLimit(t, nil)
+	r, err := New("test", new(http.Client), WithLimiter(w))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SendPayload(context.Background(), testWeighted, func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: testURL}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used := w.budgets["spot"].used; used < 9 || used > 10 {
+		t.Errorf("received: '%v' but expected: '%v'", used, 10)
+	}
+
+	// Usage reported by websocket requests sharing the budget
+	r.UpdateRateLimitUsage(testWeighted, 55)
+	if used := w.budgets["spot"].used; used != 55 {
+		t.Errorf("received: '%v' but expected: '%v'", used, 55)
+	}
+
+	var nilRequester *Requester
+	nilRequester.UpdateRateLimitUsage(testWeighted, 1)
+	err = nilRequester.SetLimiter(w)
+	if !errors.Is(err, ErrRequestSystemIsNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrRequestSystemIsNil)
+	}
+	err = r.SetLimiter(nil)
+	if !errors.Is(err, errLimiterIsNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errLimiterIsNil)
+	}
+	err = r.SetLimiter(NewBasicRateLimit(0, 0))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestWeightHeaders(t *testing.T) {
+	t.Parallel()
+	h := http.Header{}
+	if _, _, ok := UsedWeightHeader("X-Mbx-Used-Weight-1m")(h); ok {
+		t.Error("expected missing header to be ignored")
+	}
+	h.Set("X-MBX-USED-WEIGHT-1M", "1100")
+	used, capacity, ok := UsedWeightHeader("X-Mbx-Used-Weight-1m")(h)
+	if !ok || used != 1100 || capacity != 0 {
+		t.Errorf("received: '%v' '%v' '%v' but expected: '1100' '0' 'true'", used, capacity, ok)
+	}
+
+	parser := RemainingWeightHeaders("X-Bapi-Limit", "X-Bapi-Limit-Status")
+	h.Set("X-Bapi-Limit", "100")
+	h.Set("X-Bapi-Limit-Status", "101")
+	if _, _, ok = parser(h); ok {
+		t.Error("expected remaining weight greater than the limit to be ignored")
+	}
+	h.Set("X-Bapi-Limit-Status", "30")
+	used, capacity, ok = parser(h)
+	if !ok || used != 70 || capacity != 100 {
+		t.Errorf("received: '%v' '%v' '%v' but expected: '70' '100' 'true'", used, capacity, ok)
+	}
+}
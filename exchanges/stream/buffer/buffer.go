@@ -31,8 +31,19 @@ var (
 	errUpdateInsertFailure          = errors.New("orderbook update/insert update failure")
 	errRESTTimerLapse               = errors.New("rest sync timer lapse with active websocket connection")
 	errOrderbookFlushed             = errors.New("orderbook flushed")
+	errSequenceGap                  = errors.New("orderbook update sequence gap")
+	errCrossedBook                  = errors.New("orderbook crossed")
+	errChecksumNotSet               = errors.New("orderbook checksum calculation not set")
 )
 
+var globalRecorder Recorder
+
+// SetupGlobalRecorder sets a recorder interface to be used by all orderbook
+// buffers which are setup after this call. A nil recorder disables recording.
+func SetupGlobalRecorder(r Recorder) {
+	globalRecorder = r
+}
+
 // Setup sets private variables
 func (w *Orderbook) Setup(exchangeConfig *config.Exchange, c *Config, dataHandler chan<- interface{}) error {
 	if exchangeConfig == nil { // exchange config fields are checked in stream package
@@ -70,7 +81,11 @@ func (w *Orderbook) Setup(exchangeConfig *config.Exchange, c *Config, dataHandle
 	}
 	w.publishPeriod = orderbookPublishPeriod
 	w.updateIDProgression = c.UpdateIDProgression
+	w.updateIDSequential = c.UpdateIDSequential
+	w.crossedBookDetection = exchangeConfig.Orderbook.CrossedBookDetection
 	w.checksum = c.Checksum
+	w.separateChecksum = c.SeparateChecksum
+	w.recorder = globalRecorder
 	return nil
 }
 
@@ -242,24 +257,132 @@ func (w *Orderbook) processBufferUpdate(o *orderbookHolder, u *orderbook.Update)
 // processObUpdate processes updates either by its corresponding id or by
 // price level
 func (w *Orderbook) processObUpdate(o *orderbookHolder, u *orderbook.Update) error {
+	if err := w.checkSequence(o, u); err != nil {
+		return err
+	}
 	if w.updateEntriesByID {
-		return o.updateByIDAndAction(u)
+		if err := o.updateByIDAndAction(u); err != nil {
+			return err
+		}
+	} else {
+		o.updateByPrice(u)
+	}
+	if err := w.checkIntegrity(o, u); err != nil {
+		return err
+	}
+	o.updateID = u.UpdateID
+	w.recordUpdate(u)
+	return nil
+}
+
+// checkSequence invalidates the book when an update does not follow on from
+// the last applied update
+func (w *Orderbook) checkSequence(o *orderbookHolder, u *orderbook.Update) error {
+	if o.updateID == 0 {
+		// No sequence to compare against
+		return nil
+	}
+	if u.PrevUpdateID != 0 && u.PrevUpdateID != o.updateID {
+		return w.invalidate(o, u.Pair, u.Asset, fmt.Errorf("%w previous ID expected %d received %d",
+			errSequenceGap,
+			o.updateID,
+			u.PrevUpdateID))
+	}
+	if w.updateIDSequential && u.UpdateID != o.updateID+1 {
+		return w.invalidate(o, u.Pair, u.Asset, fmt.Errorf("%w ID expected %d received %d",
+			errSequenceGap,
+			o.updateID+1,
+			u.UpdateID))
+	}
+	return nil
+}
+
+// checkIntegrity validates the book after an update has been applied
+func (w *Orderbook) checkIntegrity(o *orderbookHolder, u *orderbook.Update) error {
+	if w.crossedBookDetection && !o.ob.IsFundingRate() {
+		bid, bidErr := o.ob.GetBestBid()
+		ask, askErr := o.ob.GetBestAsk()
+		// An empty side cannot be crossed
+		if bidErr == nil && askErr == nil && bid >= ask {
+			return w.invalidate(o, u.Pair, u.Asset, fmt.Errorf("%w best bid %v best ask %v",
+				errCrossedBook,
+				bid,
+				ask))
+		}
 	}
-	o.updateByPrice(u)
-	if w.checksum != nil {
+	if w.checksum != nil && !w.separateChecksum {
 		compare, err := o.ob.Retrieve()
 		if err != nil {
 			return err
 		}
 		err = w.checksum(compare, u.Checksum)
 		if err != nil {
-			return o.ob.Invalidate(err)
+			return w.invalidate(o, u.Pair, u.Asset, err)
 		}
-		o.updateID = u.UpdateID
 	}
 	return nil
 }
 
+// VerifyChecksum validates the current state of a book against a checksum
+// published separately to its updates. A book which fails is invalidated and
+// the data handler alerted so a new snapshot can be requested. Books which are
+// already invalid are not checked.
+func (w *Orderbook) VerifyChecksum(p currency.Pair, a asset.Item, checksum uint32) error {
+	if w.checksum == nil {
+		return fmt.Errorf(packageError, errChecksumNotSet)
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	book, ok := w.ob[p.Base][p.Quote][a]
+	if !ok {
+		return fmt.Errorf("%w for Exchange %s CurrencyPair: %s AssetType: %s",
+			errDepthNotFound,
+			w.exchangeName,
+			p,
+			a)
+	}
+	compare, err := book.ob.Retrieve()
+	if err != nil {
+		if errors.Is(err, orderbook.ErrOrderbookInvalid) {
+			return nil
+		}
+		return err
+	}
+	err = w.checksum(compare, checksum)
+	if err != nil {
+		return w.invalidate(book, p, a, err)
+	}
+	return nil
+}
+
+// invalidate invalidates the book and alerts the data handler so a new
+// snapshot can be requested
+func (w *Orderbook) invalidate(o *orderbookHolder, p currency.Pair, a asset.Item, reason error) error {
+	err := o.ob.Invalidate(reason)
+	w.dataHandler <- Desync{
+		Exchange: w.exchangeName,
+		Pair:     p,
+		Asset:    a,
+		Reason:   reason,
+	}
+	return err
+}
+
+// recordUpdate passes an applied update to the recorder if one is set
+func (w *Orderbook) recordUpdate(u *orderbook.Update) {
+	if w.recorder == nil {
+		return
+	}
+	if err := w.recorder.RecordUpdate(w.exchangeName, u); err != nil {
+		log.Errorf(log.WebsocketMgr,
+			"Exchange %s CurrencyPair: %s AssetType: %s cannot record orderbook update: %v",
+			w.exchangeName,
+			u.Pair,
+			u.Asset,
+			err)
+	}
+}
+
 // updateByPrice ammends amount if match occurs by price, deletes if amount is
 // zero or less and inserts if not found.
 func (o *orderbookHolder) updateByPrice(updts *orderbook.Update) {
@@ -349,6 +472,17 @@ func (w *Orderbook) LoadSnapshot(book *orderbook.Base) error {
 		book.LastUpdated,
 		false)
 
+	if w.recorder != nil {
+		if err = w.recorder.RecordSnapshot(book); err != nil {
+			log.Errorf(log.WebsocketMgr,
+				"Exchange %s CurrencyPair: %s AssetType: %s cannot record orderbook snapshot: %v",
+				w.exchangeName,
+				book.Pair,
+				book.Asset,
+				err)
+		}
+	}
+
 	if holder.ob.VerifyOrderbook {
 		// This is used here so as to not retrieve book if verification is off.
 		// Checks to see if orderbook snapshot that was deployed has not been
@@ -1178,3 +1178,220 @@ func TestFlushOrderbook(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
 	}
 }
+
+type testRecorder struct {
+	snapshots int
+	updates   int
+}
+
+func (r *testRecorder) RecordSnapshot(*orderbook.Base) error {
+	r.snapshots++
+	return nil
+}
+
+func (r *testRecorder) RecordUpdate(string, *orderbook.Update) error {
+	r.updates++
+	return errors.New("recording failures are logged only")
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+	rec := &testRecorder{}
+	holder := &Orderbook{
+		exchangeName: exchangeName,
+		dataHandler:  make(chan interface{}, 2),
+		ob:           make(map[currency.Code]map[currency.Code]map[asset.Item]*orderbookHolder),
+		recorder:     rec,
+	}
+	err := holder.LoadSnapshot(&orderbook.Base{
+		Exchange: exchangeName,
+		Asks:     orderbook.Items{{Price: 4000, Amount: 1}},
+		Bids:     orderbook.Items{{Price: 3000, Amount: 1}},
+		Asset:    asset.Spot,
+		Pair:     cp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = holder.Update(&orderbook.Update{
+		Bids:       orderbook.Items{{Price: 3000, Amount: 2}},
+		Pair:       cp,
+		UpdateTime: time.Now(),
+		Asset:      asset.Spot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.snapshots != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.snapshots, 1)
+	}
+	if rec.updates != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.updates, 1)
+	}
+}
+
+func TestIntegrityChecks(t *testing.T) {
+	t.Parallel()
+	newHolder := func() (*Orderbook, chan interface{}) {
+		ch := make(chan interface{}, 10)
+		holder := &Orderbook{
+			exchangeName: exchangeName,
+			dataHandler:  ch,
+			ob:           make(map[currency.Code]map[currency.Code]map[asset.Item]*orderbookHolder),
+		}
+		err := holder.LoadSnapshot(&orderbook.Base{
+			Exchange:     exchangeName,
+			Asks:         orderbook.Items{{Price: 4000, Amount: 1}},
+			Bids:         orderbook.Items{{Price: 3000, Amount: 1}},
+			Asset:        asset.Spot,
+			Pair:         cp,
+			LastUpdateID: 10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-ch // drain snapshot depth alert
+		return holder, ch
+	}
+
+	expectDesync := func(ch chan interface{}, expected error) {
+		t.Helper()
+		for {
+			select {
+			case data := <-ch:
+				d, ok := data.(Desync)
+				if !ok {
+					continue
+				}
+				if !errors.Is(d.Reason, expected) {
+					t.Fatalf("received: '%v' but expected: '%v'", d.Reason, expected)
+				}
+				if d.Exchange != exchangeName || !d.Pair.Equal(cp) || d.Asset != asset.Spot {
+					t.Fatalf("unexpected desync details %+v", d)
+				}
+				return
+			default:
+				t.Fatal("expected desync to be sent to data handler")
+			}
+		}
+	}
+
+	holder, ch := newHolder()
+	holder.updateIDSequential = true
+	err := holder.Update(&orderbook.Update{
+		Bids:     orderbook.Items{{Price: 3000, Amount: 2}},
+		Pair:     cp,
+		Asset:    asset.Spot,
+		UpdateID: 11,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = holder.Update(&orderbook.Update{
+		Bids:     orderbook.Items{{Price: 3000, Amount: 3}},
+		Pair:     cp,
+		Asset:    asset.Spot,
+		UpdateID: 13,
+	})
+	if !errors.Is(err, orderbook.ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
+	}
+	expectDesync(ch, errSequenceGap)
+
+	holder, ch = newHolder()
+	err = holder.Update(&orderbook.Update{
+		Bids:         orderbook.Items{{Price: 3000, Amount: 2}},
+		Pair:         cp,
+		Asset:        asset.Spot,
+		UpdateID:     20,
+		PrevUpdateID: 10,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = holder.Update(&orderbook.Update{
+		Bids:         orderbook.Items{{Price: 3000, Amount: 3}},
+		Pair:         cp,
+		Asset:        asset.Spot,
+		UpdateID:     40,
+		PrevUpdateID: 30,
+	})
+	if !errors.Is(err, orderbook.ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
+	}
+	expectDesync(ch, errSequenceGap)
+
+	holder, ch = newHolder()
+	holder.crossedBookDetection = true
+	err = holder.Update(&orderbook.Update{
+		Bids:  orderbook.Items{{Price: 3500, Amount: 1}},
+		Pair:  cp,
+		Asset: asset.Spot,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = holder.Update(&orderbook.Update{
+		Bids:  orderbook.Items{{Price: 4000, Amount: 1}},
+		Pair:  cp,
+		Asset: asset.Spot,
+	})
+	if !errors.Is(err, orderbook.ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
+	}
+	expectDesync(ch, errCrossedBook)
+
+	checksumErr := errors.New("checksum mismatch")
+	holder, ch = newHolder()
+	holder.updateEntriesByID = true
+	holder.checksum = func(*orderbook.Base, uint32) error { return checksumErr }
+	err = holder.Update(&orderbook.Update{
+		Bids:   orderbook.Items{{Price: 3000, Amount: 1, ID: 1}},
+		Pair:   cp,
+		Asset:  asset.Spot,
+		Action: orderbook.UpdateInsert,
+	})
+	if !errors.Is(err, orderbook.ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
+	}
+	expectDesync(ch, checksumErr)
+
+	// Separately published checksums are only checked when verified
+	holder, ch = newHolder()
+	err = holder.VerifyChecksum(cp, asset.Spot, 1)
+	if !errors.Is(err, errChecksumNotSet) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errChecksumNotSet)
+	}
+	holder.separateChecksum = true
+	holder.checksum = func(_ *orderbook.Base, checksum uint32) error {
+		if checksum != 1 {
+			return checksumErr
+		}
+		return nil
+	}
+	err = holder.Update(&orderbook.Update{
+		Bids:  orderbook.Items{{Price: 3000, Amount: 2}},
+		Pair:  cp,
+		Asset: asset.Spot,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = holder.VerifyChecksum(currency.NewPair(currency.LTC, currency.USD), asset.Spot, 1)
+	if !errors.Is(err, errDepthNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errDepthNotFound)
+	}
+	err = holder.VerifyChecksum(cp, asset.Spot, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = holder.VerifyChecksum(cp, asset.Spot, 2)
+	if !errors.Is(err, orderbook.ErrOrderbookInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, orderbook.ErrOrderbookInvalid)
+	}
+	expectDesync(ch, checksumErr)
+	err = holder.VerifyChecksum(cp, asset.Spot, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
@@ -23,8 +23,32 @@ type Config struct {
 	// UpdateIDProgression requires that the new update ID be greater than the
 	// prior ID. This will skip processing and not error.
 	UpdateIDProgression bool
+	// UpdateIDSequential requires each update ID to directly follow the prior
+	// update ID. A gap in the sequence invalidates the book.
+	UpdateIDSequential bool
 	// Checksum is a package defined checksum calculation for updated books.
 	Checksum func(state *orderbook.Base, checksum uint32) error
+	// SeparateChecksum is set when checksums are published in separate
+	// messages to updates. Books are then only checked via VerifyChecksum and
+	// not after each update.
+	SeparateChecksum bool
+}
+
+// Desync is sent to the data handler when a book fails an integrity check and
+// has been invalidated. The book will not be updated until a new snapshot is
+// loaded.
+type Desync struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	Reason   error
+}
+
+// Recorder is notified of every snapshot loaded and every update successfully
+// applied to an orderbook, allowing books to be reconstructed after the fact.
+type Recorder interface {
+	RecordSnapshot(book *orderbook.Base) error
+	RecordUpdate(exchange string, update *orderbook.Update) error
 }
 
 // Orderbook defines a local cache of orderbooks for amending, appending
@@ -43,10 +67,20 @@ type Orderbook struct {
 	// updateIDProgression requires that the new update ID be greater than the
 	// prior ID. This will skip processing and not error.
 	updateIDProgression bool
+	// updateIDSequential requires each update ID to directly follow the prior
+	// update ID.
+	updateIDSequential bool
+	// crossedBookDetection invalidates the book when the best bid meets or
+	// exceeds the best ask.
+	crossedBookDetection bool
 	// checksum is a package defined checksum calculation for updated books.
 	checksum func(state *orderbook.Base, checksum uint32) error
+	// separateChecksum skips the checksum after each update when checksums are
+	// published separately.
+	separateChecksum bool
 
 	publishPeriod time.Duration
+	recorder      Recorder
 	m             sync.Mutex
 }
 
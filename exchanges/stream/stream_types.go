@@ -104,3 +104,28 @@ type UnhandledMessageWarning struct {
 type Reporter interface {
 	Latency(name string, message []byte, t time.Duration)
 }
+
+// ConnectionReporter is an optional Reporter extension which is notified of
+// each websocket message received and each successful reconnection
+type ConnectionReporter interface {
+	Message(name string)
+	Reconnect(name string)
+}
+
+// OrderEntryReporter is an optional Reporter extension which is notified of
+// the latency of each order management request by transport, allowing
+// websocket and REST order entry to be compared
+type OrderEntryReporter interface {
+	OrderEntry(name, action, transport string, t time.Duration, err error)
+}
+
+// FrameRecorder records the raw frames sent and received over websocket
+// connections, allowing sessions to be replayed in tests. Each dialled
+// connection is recorded separately
+type FrameRecorder interface {
+	// RecordDial starts recording a newly dialled connection and returns its
+	// recording ID
+	RecordDial(url string) int
+	// RecordFrame records a frame sent or received over a dialled connection
+	RecordFrame(recording int, outbound bool, data []byte)
+}
@@ -54,6 +54,17 @@ func SetupGlobalReporter(r Reporter) {
 	globalReporter = r
 }
 
+// connectionReporter returns the exchange level reporter, or the global
+// reporter when unset, if it supports connection reporting
+func (w *Websocket) connectionReporter() ConnectionReporter {
+	rep := w.ExchangeLevelReporter
+	if rep == nil {
+		rep = globalReporter
+	}
+	cr, _ := rep.(ConnectionReporter)
+	return cr
+}
+
 // New initialises the websocket struct
 func New() *Websocket {
 	return &Websocket{
@@ -115,7 +126,8 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 	if w.features.Unsubscribe && s.Unsubscriber == nil {
 		return fmt.Errorf("%s %w", w.exchangeName, errWebsocketUnsubscriberUnset)
 	}
-	if s.ConnectionMonitorDelay <= 0 {
+	w.connectionMonitorDelay = s.ConnectionMonitorDelay
+	if w.connectionMonitorDelay <= 0 {
 		w.connectionMonitorDelay = defaultConnectionMonitorDelay
 	}
 	w.Unsubscriber = s.Unsubscriber
@@ -125,6 +137,26 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 	}
 	w.GenerateSubs = s.GenerateSubscriptions
 
+	if s.MaxSubscriptionsPerConnection < 0 {
+		return fmt.Errorf("%s %w", w.exchangeName, errInvalidMaxSubscriptions)
+	}
+	if s.MaxSubscriptionsPerConnection > 0 {
+		if s.ShardConnector == nil {
+			return fmt.Errorf("%s %w", w.exchangeName, errShardConnectorUnset)
+		}
+		if s.ShardSubscriber == nil {
+			return fmt.Errorf("%s %w", w.exchangeName, errShardSubscriberUnset)
+		}
+		if w.features.Unsubscribe && s.ShardUnsubscriber == nil {
+			return fmt.Errorf("%s %w", w.exchangeName, errShardUnsubscriberUnset)
+		}
+	}
+	w.maxSubscriptionsPerConnection = s.MaxSubscriptionsPerConnection
+	w.shardConnector = s.ShardConnector
+	w.shardSubscriber = s.ShardSubscriber
+	w.shardUnsubscriber = s.ShardUnsubscriber
+	w.authConnector = s.AuthConnector
+
 	if s.DefaultURL == "" {
 		return fmt.Errorf("%s websocket %w", w.exchangeName, errDefaultURLIsEmpty)
 	}
@@ -162,6 +194,11 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 
 	w.Trade.Setup(w.exchangeName, s.TradeFeed, w.DataHandler)
 	w.Fills.Setup(s.FillsFeed, w.DataHandler)
+	w.orderEntry = s.OrderEntryByDefault
+	w.orderEntrySignedPerMessage = s.OrderEntrySignedPerMessage
+	if s.ExchangeConfig.Features.Enabled.WebsocketOrderEntry != nil {
+		w.orderEntry = *s.ExchangeConfig.Features.Enabled.WebsocketOrderEntry
+	}
 	return nil
 }
 
@@ -212,12 +249,27 @@ func (w *Websocket) SetupNewConnection(c ConnectionSetup) error {
 		Match:             w.Match,
 		RateLimit:         c.RateLimit,
 		Reporter:          c.ConnectionLevelReporter,
+		Recorder:          w.getFrameRecorder(),
 	}
 
 	if c.Authenticated {
+		if w.authConnector != nil {
+			// The authenticated connection is monitored independently. Read
+			// errors are buffered so a disconnection is not dropped while the
+			// monitor is relaying traffic
+			w.authMonitor = &shard{
+				conn:       newConn,
+				traffic:    make(chan struct{}),
+				readErrors: make(chan error, 1),
+			}
+			newConn.Traffic = w.authMonitor.traffic
+			newConn.readMessageErrors = w.authMonitor.readErrors
+		}
 		w.AuthConn = newConn
 	} else {
 		w.Conn = newConn
+		// Pooled connections share the unauthenticated connection settings
+		w.shardConnectionSetup = &c
 	}
 
 	return nil
@@ -270,7 +322,11 @@ func (w *Websocket) Connect() error {
 	if err != nil {
 		return fmt.Errorf("%v %w: %v", w.exchangeName, ErrSubscriptionFailure, err)
 	}
-	err = w.Subscriber(subs)
+	if w.isPooled() {
+		err = w.subscribeToShards(subs)
+	} else {
+		err = w.Subscriber(subs)
+	}
 	if err != nil {
 		return fmt.Errorf("%v %w: %v", w.exchangeName, ErrSubscriptionFailure, err)
 	}
@@ -400,6 +456,8 @@ func (w *Websocket) connectionMonitor() error {
 					err := w.Connect()
 					if err != nil {
 						log.Error(log.WebsocketMgr, err)
+					} else if rep := w.connectionReporter(); rep != nil {
+						rep.Reconnect(w.exchangeName)
 					}
 				}
 				if !timer.Stop() {
@@ -445,12 +503,20 @@ func (w *Websocket) Shutdown() error {
 		}
 	}
 
-	if w.AuthConn != nil {
+	if w.authMonitor != nil {
+		if err := w.shutdownAuth(); err != nil {
+			return err
+		}
+	} else if w.AuthConn != nil {
 		if err := w.AuthConn.Shutdown(); err != nil {
 			return err
 		}
 	}
 
+	if err := w.shutdownShards(); err != nil {
+		return err
+	}
+
 	// flush any subscriptions from last connection if needed
 	w.subscriptionMutex.Lock()
 	w.subscriptions = nil
@@ -884,6 +950,9 @@ channels:
 			channels[x])
 	}
 	w.subscriptionMutex.Unlock()
+	if w.isPooled() {
+		return w.unsubscribeFromShards(channels)
+	}
 	return w.Unsubscriber(channels)
 }
 
@@ -914,7 +983,11 @@ func (w *Websocket) SubscribeToChannels(channels []ChannelSubscription) error {
 		}
 	}
 	w.subscriptionMutex.Unlock()
-	if err := w.Subscriber(channels); err != nil {
+	subscriber := w.Subscriber
+	if w.isPooled() {
+		subscriber = w.subscribeToShards
+	}
+	if err := subscriber(channels); err != nil {
 		return fmt.Errorf("%v %w: %v", w.exchangeName, ErrSubscriptionFailure, err)
 	}
 	return nil
@@ -975,6 +1048,28 @@ func (w *Websocket) CanUseAuthenticatedEndpoints() bool {
 	return w.canUseAuthenticatedEndpoints
 }
 
+// SetFrameRecorder records the frames of each connection dialled from now on,
+// such as to capture a session for replay in tests. It must be set before
+// connecting
+func (w *Websocket) SetFrameRecorder(r FrameRecorder) {
+	w.connectionMutex.Lock()
+	w.frameRecorder = r
+	w.connectionMutex.Unlock()
+	if c, ok := w.Conn.(*WebsocketConnection); ok {
+		c.Recorder = r
+	}
+	if c, ok := w.AuthConn.(*WebsocketConnection); ok {
+		c.Recorder = r
+	}
+}
+
+// getFrameRecorder returns the frame recorder for new connections
+func (w *Websocket) getFrameRecorder() FrameRecorder {
+	w.connectionMutex.RLock()
+	defer w.connectionMutex.RUnlock()
+	return w.frameRecorder
+}
+
 // isDisconnectionError Determines if the error sent over chan ReadMessageErrors is a disconnection error
 func isDisconnectionError(err error) bool {
 	if websocket.IsUnexpectedCloseError(err) {
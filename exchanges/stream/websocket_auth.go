@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errAuthConnectorUnset         = errors.New("websocket auth connector function needs to be set")
+	errAuthConnectionSetupMissing = errors.New("authenticated connection not set up, please call SetupNewConnection first")
+)
+
+// ConnectAuth dials the authenticated connection with the auth connector and
+// monitors it, redialling it when it disconnects without reconnecting the
+// other connections. If the dial fails the error is returned and the dial is
+// retried by the monitor
+func (w *Websocket) ConnectAuth() error {
+	if w.authConnector == nil {
+		return fmt.Errorf("%s %w", w.exchangeName, errAuthConnectorUnset)
+	}
+	w.authMutex.Lock()
+	defer w.authMutex.Unlock()
+	if w.authMonitor == nil {
+		return fmt.Errorf("%s %w", w.exchangeName, errAuthConnectionSetupMissing)
+	}
+	if w.authMonitor.stop != nil {
+		// Stops the monitor of a previous connection attempt
+		close(w.authMonitor.stop)
+		if err := w.authMonitor.conn.Shutdown(); err != nil {
+			log.Errorf(log.WebsocketMgr, "%v websocket: authenticated connection shutdown error: %v", w.exchangeName, err)
+		}
+	}
+	w.authMonitor.stop = make(chan struct{})
+	err := w.authConnector(w.authMonitor.conn)
+	w.Wg.Add(1)
+	go w.monitorAuth(w.authMonitor, w.authMonitor.stop, w.ShutdownC, err != nil)
+	if err != nil {
+		return fmt.Errorf("%v authenticated connection: %w", w.exchangeName, err)
+	}
+	return nil
+}
+
+// monitorAuth redials the authenticated connection when it disconnects,
+// retrying until it is reconnected. Traffic is relayed to the exchange level
+// traffic monitor
+func (w *Websocket) monitorAuth(s *shard, stop, shutdown chan struct{}, redial bool) {
+	defer w.Wg.Done()
+	timer := time.NewTimer(w.connectionMonitorDelay)
+	defer timer.Stop()
+	if !redial {
+		timer.Stop()
+	}
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-stop:
+			return
+		case <-s.traffic:
+			select {
+			case w.TrafficAlert <- struct{}{}:
+			default:
+			}
+		case err := <-s.readErrors:
+			if !isDisconnectionError(err) {
+				select {
+				case w.DataHandler <- err:
+				case <-shutdown:
+					return
+				}
+				continue
+			}
+			log.Warnf(log.WebsocketMgr, "%v websocket: authenticated connection has been disconnected. Reason: %v",
+				w.exchangeName, err)
+			if !w.reconnectAuth(s, stop) {
+				resetTimer(timer, w.connectionMonitorDelay)
+			}
+		case <-timer.C:
+			if !w.reconnectAuth(s, stop) {
+				timer.Reset(w.connectionMonitorDelay)
+			}
+		}
+	}
+}
+
+// shutdownAuth stops the authenticated connection monitor and closes the
+// connection
+func (w *Websocket) shutdownAuth() error {
+	w.authMutex.Lock()
+	defer w.authMutex.Unlock()
+	if w.authMonitor.stop != nil {
+		close(w.authMonitor.stop)
+		w.authMonitor.stop = nil
+	}
+	return w.authMonitor.conn.Shutdown()
+}
+
+// reconnectAuth redials the authenticated connection, returning if it was
+// reconnected
+func (w *Websocket) reconnectAuth(s *shard, stop chan struct{}) bool {
+	w.authMutex.Lock()
+	defer w.authMutex.Unlock()
+	select {
+	case <-stop:
+		return true
+	default:
+	}
+	if err := s.conn.Shutdown(); err != nil {
+		log.Errorf(log.WebsocketMgr, "%v websocket: authenticated connection shutdown error: %v", w.exchangeName, err)
+	}
+	if err := w.authConnector(s.conn); err != nil {
+		log.Errorf(log.WebsocketMgr, "%v websocket: authenticated connection reconnect error: %v", w.exchangeName, err)
+		return false
+	}
+	if rep := w.connectionReporter(); rep != nil {
+		rep.Reconnect(w.exchangeName)
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package stream
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+var errAuthTestDial = errors.New("dial failed")
+
+func TestConnectAuth(t *testing.T) {
+	t.Parallel()
+	srv := &poolTestServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	var shardDials, dials int32
+
+	w := newPoolTestWebsocket(t, "ws"+strings.TrimPrefix(server.URL, "http"), &shardDials)
+	err := w.ConnectAuth()
+	if !errors.Is(err, errAuthConnectorUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errAuthConnectorUnset)
+	}
+	w.authConnector = func(c Connection) error {
+		// The first dial fails and is retried by the monitor
+		if atomic.AddInt32(&dials, 1) == 1 {
+			return errAuthTestDial
+		}
+		if err := c.Dial(&websocket.Dialer{}, http.Header{}); err != nil {
+			return err
+		}
+		w.Wg.Add(1)
+		go func() {
+			defer w.Wg.Done()
+			for {
+				if resp := c.ReadMessage(); resp.Raw == nil {
+					return
+				}
+			}
+		}()
+		return nil
+	}
+	err = w.ConnectAuth()
+	if !errors.Is(err, errAuthConnectionSetupMissing) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errAuthConnectionSetupMissing)
+	}
+	err = w.SetupNewConnection(ConnectionSetup{
+		URL:           "ws" + strings.TrimPrefix(server.URL, "http"),
+		Authenticated: true,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.ConnectAuth()
+	if !errors.Is(err, errAuthTestDial) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errAuthTestDial)
+	}
+	waitForPoolTest(t, func() bool { return atomic.LoadInt32(&dials) == 2 })
+
+	// Dropping the authenticated connection only redials that connection
+	waitForPoolTest(t, func() bool {
+		w.authMutex.Lock()
+		defer w.authMutex.Unlock()
+		return w.AuthConn.SendRawMessage(websocket.TextMessage, []byte("ping")) == nil
+	})
+	w.authMutex.Lock()
+	err = w.AuthConn.SendRawMessage(websocket.TextMessage, []byte("drop"))
+	w.authMutex.Unlock()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	waitForPoolTest(t, func() bool { return atomic.LoadInt32(&dials) == 3 })
+	if atomic.LoadInt32(&shardDials) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", atomic.LoadInt32(&shardDials), 0)
+	}
+
+	err = w.shutdownAuth()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	w.Wg.Wait()
+}
@@ -24,13 +24,13 @@ import (
 func (w *WebsocketConnection) SendMessageReturnResponse(signature, request interface{}) ([]byte, error) {
 	m, err := w.Match.set(signature)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotSent, err)
 	}
 	defer m.Cleanup()
 
 	b, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling json for %s: %w", signature, err)
+		return nil, fmt.Errorf("%w: error marshaling json for %s: %v", ErrMessageNotSent, signature, err)
 	}
 
 	start := time.Now()
@@ -86,6 +86,10 @@ func (w *WebsocketConnection) Dial(dialer *websocket.Dialer, headers http.Header
 	}
 	defer conStatus.Body.Close()
 
+	if w.Recorder != nil {
+		w.recording = w.Recorder.RecordDial(w.URL)
+	}
+
 	if w.Verbose {
 		log.Infof(log.WebsocketMgr,
 			"%v Websocket connected to %s\n",
@@ -103,8 +107,8 @@ func (w *WebsocketConnection) Dial(dialer *websocket.Dialer, headers http.Header
 // SendJSONMessage sends a JSON encoded message over the connection
 func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
 	if !w.IsConnected() {
-		return fmt.Errorf("%s websocket connection: cannot send message to a disconnected websocket",
-			w.ExchangeName)
+		return fmt.Errorf("%s websocket connection: %w to a disconnected websocket",
+			w.ExchangeName, ErrMessageNotSent)
 	}
 
 	w.writeControl.Lock()
@@ -120,18 +124,34 @@ func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
 	if w.RateLimit > 0 {
 		time.Sleep(time.Duration(w.RateLimit) * time.Millisecond)
 		if !w.IsConnected() {
-			return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-				w.ExchangeName)
+			return fmt.Errorf("%v websocket connection: %w to a disconnected websocket",
+				w.ExchangeName, ErrMessageNotSent)
 		}
 	}
-	return w.Connection.WriteJSON(data)
+	if err := w.Connection.WriteJSON(data); err != nil {
+		return fmt.Errorf("%s websocket connection: %w: %v", w.ExchangeName, ErrMessageNotSent, err)
+	}
+	if w.Recorder != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		w.Recorder.RecordFrame(w.recording, true, payload)
+	}
+	return nil
 }
 
 // SendRawMessage sends a message over the connection without JSON encoding it
 func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) error {
+	return w.sendRawMessage(messageType, message, true)
+}
+
+// sendRawMessage sends a message over the connection, recording it when
+// required. Pings are not recorded as they are not part of a session's flow
+func (w *WebsocketConnection) sendRawMessage(messageType int, message []byte, record bool) error {
 	if !w.IsConnected() {
-		return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-			w.ExchangeName)
+		return fmt.Errorf("%v websocket connection: %w to a disconnected websocket",
+			w.ExchangeName, ErrMessageNotSent)
 	}
 
 	w.writeControl.Lock()
@@ -146,15 +166,21 @@ func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) er
 	if w.RateLimit > 0 {
 		time.Sleep(time.Duration(w.RateLimit) * time.Millisecond)
 		if !w.IsConnected() {
-			return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-				w.ExchangeName)
+			return fmt.Errorf("%v websocket connection: %w to a disconnected websocket",
+				w.ExchangeName, ErrMessageNotSent)
 		}
 	}
 	if !w.IsConnected() {
-		return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
-			w.ExchangeName)
+		return fmt.Errorf("%v websocket connection: %w to a disconnected websocket",
+			w.ExchangeName, ErrMessageNotSent)
+	}
+	if err := w.Connection.WriteMessage(messageType, message); err != nil {
+		return fmt.Errorf("%v websocket connection: %w: %v", w.ExchangeName, ErrMessageNotSent, err)
+	}
+	if record && w.Recorder != nil {
+		w.Recorder.RecordFrame(w.recording, true, message)
 	}
-	return w.Connection.WriteMessage(messageType, message)
+	return nil
 }
 
 // SetupPingHandler will automatically send ping or pong messages based on
@@ -185,7 +211,7 @@ func (w *WebsocketConnection) SetupPingHandler(handler PingHandler) {
 				ticker.Stop()
 				return
 			case <-ticker.C:
-				err := w.SendRawMessage(handler.MessageType, handler.Message)
+				err := w.sendRawMessage(handler.MessageType, handler.Message, false)
 				if err != nil {
 					log.Errorf(log.WebsocketMgr,
 						"%v websocket connection: ping handler failed to send message [%s]",
@@ -236,6 +262,10 @@ func (w *WebsocketConnection) ReadMessage() Response {
 	default: // causes contention, just bypass if there is no receiver.
 	}
 
+	if rep, ok := w.Reporter.(ConnectionReporter); ok {
+		rep.Message(w.ExchangeName)
+	}
+
 	var standardMessage []byte
 	switch mType {
 	case websocket.TextMessage:
@@ -250,6 +280,9 @@ func (w *WebsocketConnection) ReadMessage() Response {
 			return Response{}
 		}
 	}
+	if w.Recorder != nil {
+		w.Recorder.RecordFrame(w.recording, false, standardMessage)
+	}
 	if w.Verbose {
 		log.Debugf(log.WebsocketMgr,
 			"%v websocket connection: message received: %v",
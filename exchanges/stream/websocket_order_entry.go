@@ -0,0 +1,104 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Order entry actions
+const (
+	SubmitOrderAction       = "submit"
+	ModifyOrderAction       = "modify"
+	CancelOrderAction       = "cancel"
+	CancelBatchOrdersAction = "cancel_batch"
+	CancelAllOrdersAction   = "cancel_all"
+)
+
+// Order entry transports
+const (
+	WebsocketTransport = "websocket"
+	RESTTransport      = "rest"
+)
+
+// ErrMessageNotSent defines an error when a websocket request fails before it
+// is written to the connection, so the exchange cannot have acted on it
+var ErrMessageNotSent = errors.New("websocket message not sent")
+
+// IsOrderEntryEnabled returns if order management has been configured to use
+// the authenticated websocket connection
+func (w *Websocket) IsOrderEntryEnabled() bool {
+	w.connectionMutex.RLock()
+	defer w.connectionMutex.RUnlock()
+	return w.orderEntry
+}
+
+// SetOrderEntry sets if order management uses the authenticated websocket
+// connection
+func (w *Websocket) SetOrderEntry(enabled bool) {
+	w.connectionMutex.Lock()
+	w.orderEntry = enabled
+	w.connectionMutex.Unlock()
+}
+
+// CanUseWebsocketOrderEntry returns if order management can be sent over the
+// websocket, which requires order entry to be configured and the websocket to
+// be connected and authenticated
+func (w *Websocket) CanUseWebsocketOrderEntry() bool {
+	return w.IsOrderEntryEnabled() && w.IsConnected() && w.CanUseAuthenticatedEndpoints()
+}
+
+// OrderEntry sends an order management request over the websocket when
+// possible, otherwise over REST. Requests for a named account or with
+// overridden credentials use REST, as the websocket is authenticated with the
+// default credentials, unless the exchange signs each websocket request with
+// the credentials of its context. If the websocket request could not be sent
+// it falls back to REST, but any other websocket error is returned as the
+// exchange may have acted on the request. The latency of each attempt is
+// reported by transport
+func (w *Websocket) OrderEntry(ctx context.Context, action string, ws, rest func() error) error {
+	if ws == nil || !w.CanUseWebsocketOrderEntry() || !w.canSignOrderEntry(ctx) {
+		return w.timeOrderEntry(action, RESTTransport, rest)
+	}
+	err := w.timeOrderEntry(action, WebsocketTransport, ws)
+	if !errors.Is(err, ErrMessageNotSent) {
+		return err
+	}
+	log.Warnf(log.WebsocketMgr, "%s websocket order %s failed, falling back to REST: %v", w.exchangeName, action, err)
+	return w.timeOrderEntry(action, RESTTransport, rest)
+}
+
+// canSignOrderEntry returns if the websocket can send an order management
+// request with the credentials selected by context
+func (w *Websocket) canSignOrderEntry(ctx context.Context) bool {
+	if !account.HasCredentialsOverride(ctx) {
+		return true
+	}
+	w.connectionMutex.RLock()
+	defer w.connectionMutex.RUnlock()
+	return w.orderEntrySignedPerMessage
+}
+
+// timeOrderEntry calls the order entry function and reports its latency
+func (w *Websocket) timeOrderEntry(action, transport string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	if rep := w.orderEntryReporter(); rep != nil {
+		rep.OrderEntry(w.exchangeName, action, transport, time.Since(start), err)
+	}
+	return err
+}
+
+// orderEntryReporter returns the exchange level reporter, or the global
+// reporter when unset, if it supports order entry reporting
+func (w *Websocket) orderEntryReporter() OrderEntryReporter {
+	rep := w.ExchangeLevelReporter
+	if rep == nil {
+		rep = globalReporter
+	}
+	oer, _ := rep.(OrderEntryReporter)
+	return oer
+}
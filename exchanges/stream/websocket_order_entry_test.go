@@ -0,0 +1,185 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+)
+
+type orderEntryReporter struct {
+	m          sync.Mutex
+	transports []string
+	errs       []error
+}
+
+func (r *orderEntryReporter) Latency(string, []byte, time.Duration) {}
+
+func (r *orderEntryReporter) OrderEntry(_, _, transport string, _ time.Duration, err error) {
+	r.m.Lock()
+	r.transports = append(r.transports, transport)
+	r.errs = append(r.errs, err)
+	r.m.Unlock()
+}
+
+func TestOrderEntry(t *testing.T) {
+	t.Parallel()
+	rep := &orderEntryReporter{}
+	w := New()
+	w.exchangeName = "test"
+	w.ExchangeLevelReporter = rep
+
+	var wsCalls, restCalls int
+	errWS := errors.New("rejected")
+	wsErr := error(nil)
+	ws := func() error { wsCalls++; return wsErr }
+	rest := func() error { restCalls++; return nil }
+
+	// Order entry is not configured
+	w.setConnectedStatus(true)
+	w.SetCanUseAuthenticatedEndpoints(true)
+	if err := w.OrderEntry(context.Background(), SubmitOrderAction, ws, rest); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if wsCalls != 0 || restCalls != 1 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 0, 1)
+	}
+
+	w.SetOrderEntry(true)
+	if !w.CanUseWebsocketOrderEntry() {
+		t.Fatal("expected websocket order entry to be usable")
+	}
+	if err := w.OrderEntry(context.Background(), SubmitOrderAction, ws, rest); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if wsCalls != 1 || restCalls != 1 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 1, 1)
+	}
+
+	// Requests which were never sent fall back to REST
+	wsErr = fmt.Errorf("test websocket connection: %w to a disconnected websocket", ErrMessageNotSent)
+	if err := w.OrderEntry(context.Background(), CancelOrderAction, ws, rest); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if wsCalls != 2 || restCalls != 2 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 2, 2)
+	}
+
+	// The exchange may have acted on requests which were sent
+	wsErr = errWS
+	if err := w.OrderEntry(context.Background(), CancelOrderAction, ws, rest); !errors.Is(err, errWS) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errWS)
+	}
+	if wsCalls != 3 || restCalls != 2 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 3, 2)
+	}
+
+	// Unsupported websocket requests use REST
+	w.setConnectedStatus(false)
+	if err := w.OrderEntry(context.Background(), ModifyOrderAction, nil, rest); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	expected := []string{RESTTransport, WebsocketTransport, WebsocketTransport, RESTTransport, WebsocketTransport, RESTTransport}
+	if len(rep.transports) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", rep.transports, expected)
+	}
+	for i := range expected {
+		if rep.transports[i] != expected[i] {
+			t.Fatalf("received: '%v' but expected: '%v'", rep.transports, expected)
+		}
+	}
+	if !errors.Is(rep.errs[4], errWS) {
+		t.Errorf("received: '%v' but expected: '%v'", rep.errs[4], errWS)
+	}
+}
+
+func TestOrderEntryCredentialsOverride(t *testing.T) {
+	t.Parallel()
+	w := New()
+	w.exchangeName = "test"
+	w.setConnectedStatus(true)
+	w.SetCanUseAuthenticatedEndpoints(true)
+	w.SetOrderEntry(true)
+
+	var wsCalls, restCalls int
+	ws := func() error { wsCalls++; return nil }
+	rest := func() error { restCalls++; return nil }
+
+	// The websocket is authenticated with the default credentials
+	for _, ctx := range []context.Context{
+		account.DeployAccountToContext(context.Background(), "strategy"),
+		account.DeployCredentialsToContext(context.Background(), &account.Credentials{Key: "key", Secret: "secret"}),
+		account.DeploySubAccountOverrideToContext(context.Background(), "sub"),
+	} {
+		if err := w.OrderEntry(ctx, CancelAllOrdersAction, ws, rest); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	if wsCalls != 0 || restCalls != 3 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 0, 3)
+	}
+
+	// Requests signed with the credentials of their context use the websocket
+	w.orderEntrySignedPerMessage = true
+	if err := w.OrderEntry(account.DeployAccountToContext(context.Background(), "strategy"), CancelAllOrdersAction, ws, rest); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if wsCalls != 1 || restCalls != 3 {
+		t.Fatalf("received websocket calls: '%v' REST calls: '%v' but expected: '%v' '%v'", wsCalls, restCalls, 1, 3)
+	}
+}
+
+func TestSendMessageReturnResponseNotSent(t *testing.T) {
+	t.Parallel()
+	wc := &WebsocketConnection{ExchangeName: "test", Match: NewMatch()}
+	_, err := wc.SendMessageReturnResponse("sig", "payload")
+	if !errors.Is(err, ErrMessageNotSent) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrMessageNotSent)
+	}
+
+	m, err := wc.Match.set("sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Cleanup()
+	_, err = wc.SendMessageReturnResponse("sig", "payload")
+	if !errors.Is(err, ErrMessageNotSent) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrMessageNotSent)
+	}
+}
+
+func TestSetupOrderEntry(t *testing.T) {
+	t.Parallel()
+	exch := *defaultSetup.ExchangeConfig
+	features := *defaultSetup.ExchangeConfig.Features
+	exch.Features = &features
+	setup := *defaultSetup
+	setup.ExchangeConfig = &exch
+
+	for _, tc := range []struct {
+		byDefault  bool
+		configured *bool
+		expected   bool
+	}{
+		{false, nil, false},
+		{true, nil, true},
+		{true, convert.BoolPtr(false), false},
+		{false, convert.BoolPtr(true), true},
+	} {
+		setup.OrderEntryByDefault = tc.byDefault
+		features.Enabled.WebsocketOrderEntry = tc.configured
+		w := New()
+		if err := w.Setup(&setup); err != nil {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if w.IsOrderEntryEnabled() != tc.expected {
+			t.Fatalf("received: '%v' but expected: '%v'", w.IsOrderEntryEnabled(), tc.expected)
+		}
+	}
+}
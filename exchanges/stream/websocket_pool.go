@@ -0,0 +1,270 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errInvalidMaxSubscriptions     = errors.New("max subscriptions per connection cannot be negative")
+	errShardConnectorUnset         = errors.New("websocket shard connector function needs to be set")
+	errShardSubscriberUnset        = errors.New("websocket shard subscriber function needs to be set")
+	errShardUnsubscriberUnset      = errors.New("websocket unsubscriber functionality allowed but shard unsubscriber function not set")
+	errShardConnectionSetupMissing = errors.New("unauthenticated connection not set up, please call SetupNewConnection first")
+)
+
+// shard is a pooled connection holding a portion of the exchange's
+// subscriptions, it is reconnected and resubscribed independently of the
+// other connections
+type shard struct {
+	id         int
+	conn       Connection
+	subs       []ChannelSubscription
+	traffic    chan struct{}
+	readErrors chan error
+	stop       chan struct{}
+}
+
+// isPooled returns if subscriptions are sharded across pooled connections
+func (w *Websocket) isPooled() bool {
+	return w.maxSubscriptionsPerConnection > 0
+}
+
+// newShardConnection returns an unauthenticated connection which reports
+// traffic and read errors to the shard
+func (w *Websocket) newShardConnection(s *shard) *WebsocketConnection {
+	connectionURL := w.shardConnectionSetup.URL
+	if connectionURL == "" {
+		connectionURL = w.GetWebsocketURL()
+	}
+	return &WebsocketConnection{
+		ExchangeName:      w.exchangeName,
+		URL:               connectionURL,
+		ProxyURL:          w.GetProxyAddress(),
+		Verbose:           w.verbose,
+		ResponseMaxLimit:  w.shardConnectionSetup.ResponseMaxLimit,
+		Traffic:           s.traffic,
+		readMessageErrors: s.readErrors,
+		ShutdownC:         w.ShutdownC,
+		Wg:                w.Wg,
+		Match:             w.Match,
+		RateLimit:         w.shardConnectionSetup.RateLimit,
+		Reporter:          w.shardConnectionSetup.ConnectionLevelReporter,
+		Recorder:          w.getFrameRecorder(),
+	}
+}
+
+// subscribeToShards subscribes to channels on pooled connections with spare
+// capacity, dialling new connections once all are full
+func (w *Websocket) subscribeToShards(channels []ChannelSubscription) error {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	for len(channels) > 0 {
+		var s *shard
+		for i := range w.shards {
+			if len(w.shards[i].subs) < w.maxSubscriptionsPerConnection {
+				s = w.shards[i]
+				break
+			}
+		}
+		if s == nil {
+			var err error
+			s, err = w.newShard()
+			if err != nil {
+				return err
+			}
+		}
+		n := w.maxSubscriptionsPerConnection - len(s.subs)
+		if n > len(channels) {
+			n = len(channels)
+		}
+		err := w.shardSubscriber(s.conn, channels[:n])
+		if err != nil {
+			return fmt.Errorf("connection %d: %w", s.id, err)
+		}
+		s.subs = append(s.subs, channels[:n]...)
+		channels = channels[n:]
+	}
+	return nil
+}
+
+// unsubscribeFromShards unsubscribes from channels on the pooled connections
+// holding them, closing connections left without subscriptions
+func (w *Websocket) unsubscribeFromShards(channels []ChannelSubscription) error {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	for i := 0; i < len(w.shards); i++ {
+		s := w.shards[i]
+		var unsubs, remaining []ChannelSubscription
+	subs:
+		for x := range s.subs {
+			for y := range channels {
+				if s.subs[x].Equal(&channels[y]) {
+					unsubs = append(unsubs, s.subs[x])
+					continue subs
+				}
+			}
+			remaining = append(remaining, s.subs[x])
+		}
+		if len(unsubs) == 0 {
+			continue
+		}
+		err := w.shardUnsubscriber(s.conn, unsubs)
+		if err != nil {
+			return fmt.Errorf("connection %d: %w", s.id, err)
+		}
+		s.subs = remaining
+		if len(s.subs) == 0 {
+			close(s.stop)
+			if err = s.conn.Shutdown(); err != nil {
+				log.Errorf(log.WebsocketMgr, "%v websocket: connection %d shutdown error: %v", w.exchangeName, s.id, err)
+			}
+			w.shards = append(w.shards[:i], w.shards[i+1:]...)
+			i--
+		}
+	}
+	return nil
+}
+
+// newShard dials a new pooled connection and starts monitoring it, must be
+// called with the shard mutex held
+func (w *Websocket) newShard() (*shard, error) {
+	if w.shardConnectionSetup == nil {
+		return nil, fmt.Errorf("%s %w", w.exchangeName, errShardConnectionSetupMissing)
+	}
+	s := &shard{
+		traffic:    make(chan struct{}),
+		readErrors: make(chan error),
+		stop:       make(chan struct{}),
+	}
+	for i := range w.shards {
+		if w.shards[i].id >= s.id {
+			s.id = w.shards[i].id + 1
+		}
+	}
+	s.conn = w.newShardConnection(s)
+	if err := w.shardConnector(s.conn); err != nil {
+		return nil, fmt.Errorf("%v connection %d: %w", w.exchangeName, s.id, err)
+	}
+	w.shards = append(w.shards, s)
+	w.Wg.Add(1)
+	go w.monitorShard(s)
+	return s, nil
+}
+
+// monitorShard reconnects and resubscribes a pooled connection when it
+// disconnects or stops receiving traffic. Traffic is relayed to the exchange
+// level traffic monitor, which reconnects all connections only once every
+// connection has gone quiet
+func (w *Websocket) monitorShard(s *shard) {
+	defer w.Wg.Done()
+	timer := time.NewTimer(w.trafficTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.ShutdownC:
+			return
+		case <-s.stop:
+			return
+		case <-s.traffic:
+			select {
+			case w.TrafficAlert <- struct{}{}:
+			default:
+			}
+			resetTimer(timer, w.trafficTimeout)
+		case err := <-s.readErrors:
+			if !isDisconnectionError(err) {
+				select {
+				case w.DataHandler <- err:
+				case <-w.ShutdownC:
+					return
+				}
+				continue
+			}
+			log.Warnf(log.WebsocketMgr, "%v websocket: connection %d has been disconnected. Reason: %v",
+				w.exchangeName, s.id, err)
+			resetTimer(timer, w.reconnectShard(s))
+		case <-timer.C:
+			log.Warnf(log.WebsocketMgr, "%v websocket: connection %d has not received traffic in %v. Reconnecting",
+				w.exchangeName, s.id, w.trafficTimeout)
+			timer.Reset(w.reconnectShard(s))
+		}
+	}
+}
+
+// reconnectShard replaces a pooled connection and resubscribes to its
+// channels, returning how long to wait for traffic before reconnecting again
+func (w *Websocket) reconnectShard(s *shard) time.Duration {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	select {
+	case <-s.stop:
+		return w.trafficTimeout
+	case <-w.ShutdownC:
+		return w.trafficTimeout
+	default:
+	}
+	if err := s.conn.Shutdown(); err != nil {
+		log.Errorf(log.WebsocketMgr, "%v websocket: connection %d shutdown error: %v", w.exchangeName, s.id, err)
+	}
+	conn := w.newShardConnection(s)
+	if err := w.shardConnector(conn); err != nil {
+		log.Errorf(log.WebsocketMgr, "%v websocket: connection %d reconnect error: %v", w.exchangeName, s.id, err)
+		return w.connectionMonitorDelay
+	}
+	s.conn = conn
+	// Subscriptions are added back by the shard subscriber
+	w.RemoveSuccessfulUnsubscriptions(s.subs...)
+	if err := w.shardSubscriber(conn, s.subs); err != nil {
+		log.Errorf(log.WebsocketMgr, "%v websocket: connection %d resubscribe error: %v", w.exchangeName, s.id, err)
+		return w.connectionMonitorDelay
+	}
+	if rep := w.connectionReporter(); rep != nil {
+		rep.Reconnect(w.exchangeName)
+	}
+	return w.trafficTimeout
+}
+
+// shutdownShards closes all pooled connections and stops their monitors
+func (w *Websocket) shutdownShards() error {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	var err error
+	for i := range w.shards {
+		close(w.shards[i].stop)
+		if shutdownErr := w.shards[i].conn.Shutdown(); shutdownErr != nil && err == nil {
+			err = shutdownErr
+		}
+	}
+	w.shards = nil
+	return err
+}
+
+// GetConnectionSubscriptions returns a copy of the subscriptions held by each
+// pooled connection, nil if subscriptions are not sharded
+func (w *Websocket) GetConnectionSubscriptions() [][]ChannelSubscription {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	if len(w.shards) == 0 {
+		return nil
+	}
+	subs := make([][]ChannelSubscription, len(w.shards))
+	for i := range w.shards {
+		subs[i] = append(w.shards[i].subs[:0:0], w.shards[i].subs...)
+	}
+	return subs
+}
+
+// resetTimer stops, drains and resets a timer
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
@@ -0,0 +1,232 @@
+package stream
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+)
+
+// poolTestServer acknowledges each message received and closes the connection
+// when sent "drop"
+type poolTestServer struct {
+	upgrader websocket.Upgrader
+	received int32
+}
+
+func (p *poolTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c, err := p.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+	for {
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) == "drop" {
+			return
+		}
+		atomic.AddInt32(&p.received, 1)
+		if err = c.WriteMessage(websocket.TextMessage, []byte(`{"result":null}`)); err != nil {
+			return
+		}
+	}
+}
+
+func newPoolTestWebsocket(t *testing.T, serverURL string, dials *int32) *Websocket {
+	t.Helper()
+	w := New()
+	setup := &WebsocketSetup{
+		ExchangeConfig: &config.Exchange{
+			Features:                &config.FeaturesConfig{Enabled: config.FeaturesEnabledConfig{Websocket: true}},
+			WebsocketTrafficTimeout: time.Second * 5,
+			Name:                    "pool",
+		},
+		DefaultURL:   "wss://testDefaultURL",
+		RunningURL:   "wss://testRunningURL",
+		Connector:    func() error { return nil },
+		Subscriber:   func([]ChannelSubscription) error { return nil },
+		Unsubscriber: func([]ChannelSubscription) error { return nil },
+		GenerateSubscriptions: func() ([]ChannelSubscription, error) {
+			return []ChannelSubscription{{Channel: "1"}, {Channel: "2"}, {Channel: "3"}, {Channel: "4"}}, nil
+		},
+		Features:                      &protocol.Features{Subscribe: true, Unsubscribe: true},
+		MaxSubscriptionsPerConnection: 2,
+		ConnectionMonitorDelay:        time.Millisecond * 10,
+		ShardConnector: func(c Connection) error {
+			atomic.AddInt32(dials, 1)
+			if err := c.Dial(&websocket.Dialer{}, http.Header{}); err != nil {
+				return err
+			}
+			w.Wg.Add(1)
+			go func() {
+				defer w.Wg.Done()
+				for {
+					if resp := c.ReadMessage(); resp.Raw == nil {
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		ShardSubscriber: func(c Connection, subs []ChannelSubscription) error {
+			for i := range subs {
+				if err := c.SendRawMessage(websocket.TextMessage, []byte(subs[i].Channel)); err != nil {
+					return err
+				}
+			}
+			w.AddSuccessfulSubscriptions(subs...)
+			return nil
+		},
+		ShardUnsubscriber: func(_ Connection, subs []ChannelSubscription) error {
+			w.RemoveSuccessfulUnsubscriptions(subs...)
+			return nil
+		},
+	}
+	err := w.Setup(setup)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.SetupNewConnection(ConnectionSetup{URL: serverURL, ResponseMaxLimit: time.Second})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return w
+}
+
+func TestSetupPool(t *testing.T) {
+	t.Parallel()
+	setup := *defaultSetup
+	setup.MaxSubscriptionsPerConnection = -1
+	err := New().Setup(&setup)
+	if !errors.Is(err, errInvalidMaxSubscriptions) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMaxSubscriptions)
+	}
+	setup.MaxSubscriptionsPerConnection = 10
+	err = New().Setup(&setup)
+	if !errors.Is(err, errShardConnectorUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShardConnectorUnset)
+	}
+	setup.ShardConnector = func(Connection) error { return nil }
+	err = New().Setup(&setup)
+	if !errors.Is(err, errShardSubscriberUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShardSubscriberUnset)
+	}
+	setup.ShardSubscriber = func(Connection, []ChannelSubscription) error { return nil }
+	err = New().Setup(&setup)
+	if !errors.Is(err, errShardUnsubscriberUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShardUnsubscriberUnset)
+	}
+	setup.ShardUnsubscriber = func(Connection, []ChannelSubscription) error { return nil }
+	w := New()
+	err = w.Setup(&setup)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.subscribeToShards([]ChannelSubscription{{Channel: "1"}})
+	if !errors.Is(err, errShardConnectionSetupMissing) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShardConnectionSetupMissing)
+	}
+}
+
+func TestConnectionPool(t *testing.T) {
+	t.Parallel()
+	srv := &poolTestServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	var dials int32
+	w := newPoolTestWebsocket(t, "ws"+strings.TrimPrefix(server.URL, "http"), &dials)
+
+	err := w.Connect()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	subs := w.GetConnectionSubscriptions()
+	if len(subs) != 2 || len(subs[0]) != 2 || len(subs[1]) != 2 {
+		t.Fatalf("unexpected connection subscriptions: %v", subs)
+	}
+	if len(w.GetSubscriptions()) != 4 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(w.GetSubscriptions()), 4)
+	}
+
+	// A further subscription is sharded onto a new connection, which is
+	// closed once it no longer holds any subscriptions
+	err = w.SubscribeToChannels([]ChannelSubscription{{Channel: "5"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if subs = w.GetConnectionSubscriptions(); len(subs) != 3 || subs[2][0].Channel != "5" {
+		t.Fatalf("unexpected connection subscriptions: %v", subs)
+	}
+	err = w.UnsubscribeChannels([]ChannelSubscription{{Channel: "5"}, {Channel: "1"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if subs = w.GetConnectionSubscriptions(); len(subs) != 2 || len(subs[0]) != 1 || subs[0][0].Channel != "2" {
+		t.Fatalf("unexpected connection subscriptions: %v", subs)
+	}
+	if atomic.LoadInt32(&dials) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", atomic.LoadInt32(&dials), 3)
+	}
+
+	// Dropping one connection only reconnects and resubscribes that connection
+	waitForPoolTest(t, func() bool { return atomic.LoadInt32(&srv.received) == 5 })
+	w.shardMutex.Lock()
+	dropped := w.shards[1]
+	err = dropped.conn.SendRawMessage(websocket.TextMessage, []byte("drop"))
+	w.shardMutex.Unlock()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	waitForPoolTest(t, func() bool {
+		return atomic.LoadInt32(&dials) == 4 && atomic.LoadInt32(&srv.received) == 7
+	})
+	if subs = w.GetConnectionSubscriptions(); len(subs) != 2 || len(subs[1]) != 2 {
+		t.Fatalf("unexpected connection subscriptions: %v", subs)
+	}
+	if len(w.GetSubscriptions()) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(w.GetSubscriptions()), 3)
+	}
+
+	err = w.Shutdown()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if w.GetConnectionSubscriptions() != nil {
+		t.Error("expected pooled connections to be closed on shutdown")
+	}
+}
+
+// waitForPoolTest waits for the condition to be met before the connections'
+// traffic timeout
+func waitForPoolTest(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 4)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for pooled connections")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestResetTimer(t *testing.T) {
+	t.Parallel()
+	timer := time.NewTimer(0)
+	time.Sleep(time.Millisecond * 10)
+	resetTimer(timer, time.Hour)
+	select {
+	case <-timer.C:
+		t.Fatal("expected expired timer to be drained and reset")
+	default:
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"sync"
@@ -1383,3 +1384,126 @@ func TestLatency(t *testing.T) {
 		t.Errorf("expected %v, got %v", exch, r.name)
 	}
 }
+
+type connectionReporter struct {
+	reporter
+	messages   int
+	reconnects int
+}
+
+func (r *connectionReporter) Message(string) {
+	r.messages++
+}
+
+func (r *connectionReporter) Reconnect(string) {
+	r.reconnects++
+}
+
+func TestGetConnectionReporter(t *testing.T) {
+	t.Parallel()
+	w := &Websocket{ExchangeLevelReporter: &reporter{}}
+	if w.connectionReporter() != nil {
+		t.Fatal("expected nil connection reporter for a latency only reporter")
+	}
+	rep := &connectionReporter{}
+	w.ExchangeLevelReporter = rep
+	cr := w.connectionReporter()
+	if cr == nil {
+		t.Fatal("expected connection reporter")
+	}
+	cr.Reconnect("test")
+	cr.Message("test")
+	if rep.reconnects != 1 || rep.messages != 1 {
+		t.Fatalf("received: '%v' reconnects '%v' messages but expected one of each", rep.reconnects, rep.messages)
+	}
+}
+
+type frameRecorder struct {
+	m      sync.Mutex
+	dials  []string
+	frames []string
+}
+
+func (r *frameRecorder) RecordDial(url string) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.dials = append(r.dials, url)
+	return len(r.dials) - 1
+}
+
+func (r *frameRecorder) RecordFrame(recording int, outbound bool, data []byte) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	direction := "in"
+	if outbound {
+		direction = "out"
+	}
+	r.frames = append(r.frames, fmt.Sprintf("%d %s %s", recording, direction, data))
+}
+
+func TestFrameRecorder(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(&poolTestServer{})
+	defer server.Close()
+
+	rec := &frameRecorder{}
+	w := New()
+	w.Conn = &WebsocketConnection{}
+	w.AuthConn = &WebsocketConnection{}
+	w.SetFrameRecorder(rec)
+	if w.Conn.(*WebsocketConnection).Recorder != rec || w.AuthConn.(*WebsocketConnection).Recorder != rec {
+		t.Fatal("expected frame recorder to be set on existing connections")
+	}
+	w.shardConnectionSetup = &ConnectionSetup{URL: "ws://pool"}
+	if w.newShardConnection(&shard{}).Recorder != rec {
+		t.Fatal("expected frame recorder to be set on pooled connections")
+	}
+
+	wc := &WebsocketConnection{
+		ExchangeName:      "test",
+		URL:               "ws" + strings.TrimPrefix(server.URL, "http"),
+		Traffic:           make(chan struct{}, 1),
+		readMessageErrors: make(chan error, 1),
+		Match:             NewMatch(),
+		Recorder:          rec,
+	}
+	err := wc.Dial(&websocket.Dialer{}, http.Header{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer wc.Shutdown()
+	if err = wc.SendJSONMessage(map[string]int{"id": 1}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	wc.ReadMessage()
+	if err = wc.SendRawMessage(websocket.TextMessage, []byte("raw")); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	wc.ReadMessage()
+	// Pings are not recorded
+	if err = wc.sendRawMessage(websocket.TextMessage, []byte("ping"), false); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	wc.ReadMessage()
+
+	expected := []string{
+		`0 out {"id":1}`,
+		`0 in {"result":null}`,
+		`0 out raw`,
+		`0 in {"result":null}`,
+		`0 in {"result":null}`,
+	}
+	rec.m.Lock()
+	defer rec.m.Unlock()
+	if len(rec.dials) != 1 || rec.dials[0] != wc.URL {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.dials, []string{wc.URL})
+	}
+	if len(rec.frames) != len(expected) {
+		t.Fatalf("received: '%v' but expected: '%v'", rec.frames, expected)
+	}
+	for i := range expected {
+		if rec.frames[i] != expected[i] {
+			t.Fatalf("received: '%v' but expected: '%v'", rec.frames, expected)
+		}
+	}
+}
@@ -95,6 +95,29 @@ type Websocket struct {
 
 	// Latency reporter
 	ExchangeLevelReporter Reporter
+
+	// Pooled connections which subscriptions are sharded across
+	maxSubscriptionsPerConnection int
+	shardConnector                func(Connection) error
+	shardSubscriber               func(Connection, []ChannelSubscription) error
+	shardUnsubscriber             func(Connection, []ChannelSubscription) error
+	shardConnectionSetup          *ConnectionSetup
+	shards                        []*shard
+	shardMutex                    sync.Mutex
+
+	// Authenticated connection dialled and reconnected separately from the
+	// standard connection
+	authConnector func(Connection) error
+	authMonitor   *shard
+	authMutex     sync.Mutex
+
+	// orderEntry routes order management over the authenticated connection
+	orderEntry bool
+	// orderEntrySignedPerMessage allows order management for credentials
+	// other than the default credentials over the authenticated connection
+	orderEntrySignedPerMessage bool
+	// frameRecorder records the frames of each connection when set
+	frameRecorder FrameRecorder
 }
 
 // WebsocketSetup defines variables for setting up a websocket connection
@@ -117,6 +140,36 @@ type WebsocketSetup struct {
 
 	// Fill data config values
 	FillsFeed bool
+
+	// OrderEntryByDefault routes order management over the authenticated
+	// websocket unless websocketOrderEntry is disabled in config. It is set by
+	// exchanges which used websocket order entry before it was configurable
+	OrderEntryByDefault bool
+
+	// OrderEntrySignedPerMessage is set by exchanges which sign each websocket
+	// order request with the credentials of its context, so requests for named
+	// accounts and credential overrides can use the websocket. Otherwise they
+	// are sent over REST as the connection is authenticated with the default
+	// credentials
+	OrderEntrySignedPerMessage bool
+
+	// MaxSubscriptionsPerConnection shards subscriptions across a pool of
+	// unauthenticated connections when greater than zero, dialling another
+	// connection each time the limit is reached. Subscriptions are then made
+	// via the shard functions below rather than Subscriber and Unsubscriber
+	MaxSubscriptionsPerConnection int
+	// ShardConnector dials a pooled connection and starts reading from it
+	ShardConnector func(Connection) error
+	// ShardSubscriber subscribes to channels on a pooled connection
+	ShardSubscriber func(Connection, []ChannelSubscription) error
+	// ShardUnsubscriber unsubscribes from channels on a pooled connection
+	ShardUnsubscriber func(Connection, []ChannelSubscription) error
+
+	// AuthConnector dials the authenticated connection and starts reading
+	// from it when it is used separately from the standard connection, such
+	// as for order entry. The connection is then dialled via ConnectAuth and
+	// reconnected independently when it disconnects
+	AuthConnector func(Connection) error
 }
 
 // WebsocketConnection contains all the data needed to send a message to a WS
@@ -143,4 +196,7 @@ type WebsocketConnection struct {
 	readMessageErrors chan error
 
 	Reporter Reporter
+	// Recorder records the frames sent and received when set
+	Recorder  FrameRecorder
+	recording int
 }
@@ -178,6 +178,7 @@ func (z *ZB) Setup(exch *config.Exchange) error {
 		GenerateSubscriptions: z.GenerateDefaultSubscriptions,
 		Subscriber:            z.Subscribe,
 		Features:              &z.Features.Supports.WebsocketCapabilities,
+		OrderEntryByDefault:   true,
 	})
 	if err != nil {
 		return err
@@ -487,42 +488,42 @@ func (z *ZB) SubmitOrder(ctx context.Context, o *order.Submit) (*order.SubmitRes
 		return nil, err
 	}
 
-	if z.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+	var orderID int64
+	err = z.Websocket.OrderEntry(ctx, stream.SubmitOrderAction, func() error {
 		var isBuyOrder int64
 		if o.Side == order.Buy {
 			isBuyOrder = 1
-		} else {
-			isBuyOrder = 0
 		}
-		var response *WsSubmitOrderResponse
-		response, err = z.wsSubmitOrder(ctx, o.Pair, o.Amount, o.Price, isBuyOrder)
-		if err != nil {
-			return nil, err
+		response, wsErr := z.wsSubmitOrder(ctx, o.Pair, o.Amount, o.Price, isBuyOrder)
+		if wsErr != nil {
+			return wsErr
+		}
+		orderID = response.Data.EntrustID
+		return nil
+	}, func() error {
+		var oT = SpotNewOrderRequestParamsTypeSell
+		if o.Side == order.Buy {
+			oT = SpotNewOrderRequestParamsTypeBuy
 		}
-		return o.DeriveSubmitResponse(strconv.FormatInt(response.Data.EntrustID, 10))
-	}
-	var oT = SpotNewOrderRequestParamsTypeSell
-	if o.Side == order.Buy {
-		oT = SpotNewOrderRequestParamsTypeBuy
-	}
 
-	fPair, err := z.FormatExchangeCurrency(o.Pair, o.AssetType)
-	if err != nil {
-		return nil, err
-	}
+		fPair, restErr := z.FormatExchangeCurrency(o.Pair, o.AssetType)
+		if restErr != nil {
+			return restErr
+		}
 
-	var params = SpotNewOrderRequestParams{
-		Amount: o.Amount,
-		Price:  o.Price,
-		Symbol: fPair.Lower().String(),
-		Type:   oT,
-	}
-	var response int64
-	response, err = z.SpotNewOrder(ctx, params)
+		var params = SpotNewOrderRequestParams{
+			Amount: o.Amount,
+			Price:  o.Price,
+			Symbol: fPair.Lower().String(),
+			Type:   oT,
+		}
+		orderID, restErr = z.SpotNewOrder(ctx, params)
+		return restErr
+	})
 	if err != nil {
 		return nil, err
 	}
-	return o.DeriveSubmitResponse(strconv.FormatInt(response, 10))
+	return o.DeriveSubmitResponse(strconv.FormatInt(orderID, 10))
 }
 
 // ModifyOrder will allow of changing orderbook placement and limit to
@@ -542,22 +543,22 @@ func (z *ZB) CancelOrder(ctx context.Context, o *order.Cancel) error {
 		return err
 	}
 
-	if z.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		var response *WsCancelOrderResponse
-		response, err = z.wsCancelOrder(ctx, o.Pair, orderIDInt)
-		if err != nil {
-			return err
+	return z.Websocket.OrderEntry(ctx, stream.CancelOrderAction, func() error {
+		response, wsErr := z.wsCancelOrder(ctx, o.Pair, orderIDInt)
+		if wsErr != nil {
+			return wsErr
 		}
 		if !response.Success {
 			return fmt.Errorf("%v - Could not cancel order %v", z.Name, o.OrderID)
 		}
 		return nil
-	}
-	fpair, err := z.FormatExchangeCurrency(o.Pair, o.AssetType)
-	if err != nil {
-		return err
-	}
-	return z.CancelExistingOrder(ctx, orderIDInt, fpair.String())
+	}, func() error {
+		fpair, restErr := z.FormatExchangeCurrency(o.Pair, o.AssetType)
+		if restErr != nil {
+			return restErr
+		}
+		return z.CancelExistingOrder(ctx, orderIDInt, fpair.String())
+	})
 }
 
 // CancelBatchOrders cancels an orders by their corresponding ID numbers
@@ -99,6 +99,7 @@ type GoCryptoTraderServiceClient interface {
 	GetHistoricTrades(ctx context.Context, in *GetSavedTradesRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetHistoricTradesClient, error)
 	GetSavedTrades(ctx context.Context, in *GetSavedTradesRequest, opts ...grpc.CallOption) (*SavedTradesResponse, error)
 	ConvertTradesToCandles(ctx context.Context, in *ConvertTradesToCandlesRequest, opts ...grpc.CallOption) (*GetHistoricCandlesResponse, error)
+	GetBuiltCandles(ctx context.Context, in *GetBuiltCandlesRequest, opts ...grpc.CallOption) (*GetHistoricCandlesResponse, error)
 	FindMissingSavedCandleIntervals(ctx context.Context, in *FindMissingCandlePeriodsRequest, opts ...grpc.CallOption) (*FindMissingIntervalsResponse, error)
 	FindMissingSavedTradeIntervals(ctx context.Context, in *FindMissingTradePeriodsRequest, opts ...grpc.CallOption) (*FindMissingIntervalsResponse, error)
 	SetExchangeTradeProcessing(ctx context.Context, in *SetExchangeTradeProcessingRequest, opts ...grpc.CallOption) (*GenericResponse, error)
@@ -127,6 +128,28 @@ type GoCryptoTraderServiceClient interface {
 	GetOrderbookMovement(ctx context.Context, in *GetOrderbookMovementRequest, opts ...grpc.CallOption) (*GetOrderbookMovementResponse, error)
 	GetOrderbookAmountByNominal(ctx context.Context, in *GetOrderbookAmountByNominalRequest, opts ...grpc.CallOption) (*GetOrderbookAmountByNominalResponse, error)
 	GetOrderbookAmountByImpact(ctx context.Context, in *GetOrderbookAmountByImpactRequest, opts ...grpc.CallOption) (*GetOrderbookAmountByImpactResponse, error)
+	GetOrderbookDesyncs(ctx context.Context, in *GetOrderbookDesyncsRequest, opts ...grpc.CallOption) (*GetOrderbookDesyncsResponse, error)
+	GetConsolidatedOrderbook(ctx context.Context, in *GetConsolidatedOrderbookRequest, opts ...grpc.CallOption) (*ConsolidatedOrderbookResponse, error)
+	GetConsolidatedOrderbookStream(ctx context.Context, in *GetConsolidatedOrderbookRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetConsolidatedOrderbookStreamClient, error)
+	GetConsolidatedOrderbookMovement(ctx context.Context, in *GetConsolidatedOrderbookMovementRequest, opts ...grpc.CallOption) (*GetOrderbookMovementResponse, error)
+	GetArbitrageOpportunities(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (*GetArbitrageOpportunitiesResponse, error)
+	GetArbitrageOpportunityStream(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetArbitrageOpportunityStreamClient, error)
+	GetRebalancePlans(ctx context.Context, in *GetRebalancePlansRequest, opts ...grpc.CallOption) (*GetRebalancePlansResponse, error)
+	ProposeRebalance(ctx context.Context, in *ProposeRebalanceRequest, opts ...grpc.CallOption) (*RebalancePlan, error)
+	ApproveRebalancePlan(ctx context.Context, in *RebalancePlanRequest, opts ...grpc.CallOption) (*RebalancePlan, error)
+	RejectRebalancePlan(ctx context.Context, in *RebalancePlanRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GetPortfolioHistory(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioHistoryResponse, error)
+	GetPortfolioPerformance(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioPerformanceResponse, error)
+	GetPortfolioFlows(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioFlowsResponse, error)
+	GetCapitalGainsReport(ctx context.Context, in *GetCapitalGainsReportRequest, opts ...grpc.CallOption) (*GetCapitalGainsReportResponse, error)
+	GetTaxLots(ctx context.Context, in *GetTaxLotsRequest, opts ...grpc.CallOption) (*GetTaxLotsResponse, error)
+	ReconcileOrders(ctx context.Context, in *ReconcileOrdersRequest, opts ...grpc.CallOption) (*ReconcileOrdersResponse, error)
+	GetRiskLimits(ctx context.Context, in *GetRiskLimitsRequest, opts ...grpc.CallOption) (*GetRiskLimitsResponse, error)
+	SetRiskLimit(ctx context.Context, in *SetRiskLimitRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*SetKillSwitchResponse, error)
+	DeadManHeartbeat(ctx context.Context, in *DeadManHeartbeatRequest, opts ...grpc.CallOption) (*DeadManHeartbeatResponse, error)
+	InternalTransfer(ctx context.Context, in *InternalTransferRequest, opts ...grpc.CallOption) (*InternalTransferResponse, error)
+	GetExchangeHealth(ctx context.Context, in *GetExchangeHealthRequest, opts ...grpc.CallOption) (*GetExchangeHealthResponse, error)
 }
 
 type goCryptoTraderServiceClient struct {
@@ -968,6 +991,15 @@ func (c *goCryptoTraderServiceClient) ConvertTradesToCandles(ctx context.Context
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetBuiltCandles(ctx context.Context, in *GetBuiltCandlesRequest, opts ...grpc.CallOption) (*GetHistoricCandlesResponse, error) {
+	out := new(GetHistoricCandlesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetBuiltCandles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goCryptoTraderServiceClient) FindMissingSavedCandleIntervals(ctx context.Context, in *FindMissingCandlePeriodsRequest, opts ...grpc.CallOption) (*FindMissingIntervalsResponse, error) {
 	out := new(FindMissingIntervalsResponse)
 	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/FindMissingSavedCandleIntervals", in, out, opts...)
@@ -1220,6 +1252,250 @@ func (c *goCryptoTraderServiceClient) GetOrderbookAmountByImpact(ctx context.Con
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetOrderbookDesyncs(ctx context.Context, in *GetOrderbookDesyncsRequest, opts ...grpc.CallOption) (*GetOrderbookDesyncsResponse, error) {
+	out := new(GetOrderbookDesyncsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetOrderbookDesyncs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetConsolidatedOrderbook(ctx context.Context, in *GetConsolidatedOrderbookRequest, opts ...grpc.CallOption) (*ConsolidatedOrderbookResponse, error) {
+	out := new(ConsolidatedOrderbookResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetConsolidatedOrderbook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetConsolidatedOrderbookStream(ctx context.Context, in *GetConsolidatedOrderbookRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetConsolidatedOrderbookStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[6], "/gctrpc.GoCryptoTraderService/GetConsolidatedOrderbookStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetConsolidatedOrderbookStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetConsolidatedOrderbookStreamClient interface {
+	Recv() (*ConsolidatedOrderbookResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetConsolidatedOrderbookStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetConsolidatedOrderbookStreamClient) Recv() (*ConsolidatedOrderbookResponse, error) {
+	m := new(ConsolidatedOrderbookResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetConsolidatedOrderbookMovement(ctx context.Context, in *GetConsolidatedOrderbookMovementRequest, opts ...grpc.CallOption) (*GetOrderbookMovementResponse, error) {
+	out := new(GetOrderbookMovementResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetConsolidatedOrderbookMovement", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetArbitrageOpportunities(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (*GetArbitrageOpportunitiesResponse, error) {
+	out := new(GetArbitrageOpportunitiesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetArbitrageOpportunities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetArbitrageOpportunityStream(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetArbitrageOpportunityStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[7], "/gctrpc.GoCryptoTraderService/GetArbitrageOpportunityStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetArbitrageOpportunityStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetArbitrageOpportunityStreamClient interface {
+	Recv() (*ArbitrageOpportunity, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetArbitrageOpportunityStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetArbitrageOpportunityStreamClient) Recv() (*ArbitrageOpportunity, error) {
+	m := new(ArbitrageOpportunity)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetRebalancePlans(ctx context.Context, in *GetRebalancePlansRequest, opts ...grpc.CallOption) (*GetRebalancePlansResponse, error) {
+	out := new(GetRebalancePlansResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetRebalancePlans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) ProposeRebalance(ctx context.Context, in *ProposeRebalanceRequest, opts ...grpc.CallOption) (*RebalancePlan, error) {
+	out := new(RebalancePlan)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/ProposeRebalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) ApproveRebalancePlan(ctx context.Context, in *RebalancePlanRequest, opts ...grpc.CallOption) (*RebalancePlan, error) {
+	out := new(RebalancePlan)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/ApproveRebalancePlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) RejectRebalancePlan(ctx context.Context, in *RebalancePlanRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/RejectRebalancePlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetPortfolioHistory(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioHistoryResponse, error) {
+	out := new(GetPortfolioHistoryResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetPortfolioHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetPortfolioPerformance(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioPerformanceResponse, error) {
+	out := new(GetPortfolioPerformanceResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetPortfolioPerformance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetPortfolioFlows(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioFlowsResponse, error) {
+	out := new(GetPortfolioFlowsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetPortfolioFlows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetCapitalGainsReport(ctx context.Context, in *GetCapitalGainsReportRequest, opts ...grpc.CallOption) (*GetCapitalGainsReportResponse, error) {
+	out := new(GetCapitalGainsReportResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetCapitalGainsReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetTaxLots(ctx context.Context, in *GetTaxLotsRequest, opts ...grpc.CallOption) (*GetTaxLotsResponse, error) {
+	out := new(GetTaxLotsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetTaxLots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) ReconcileOrders(ctx context.Context, in *ReconcileOrdersRequest, opts ...grpc.CallOption) (*ReconcileOrdersResponse, error) {
+	out := new(ReconcileOrdersResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/ReconcileOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetRiskLimits(ctx context.Context, in *GetRiskLimitsRequest, opts ...grpc.CallOption) (*GetRiskLimitsResponse, error) {
+	out := new(GetRiskLimitsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetRiskLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SetRiskLimit(ctx context.Context, in *SetRiskLimitRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/SetRiskLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SetKillSwitch(ctx context.Context, in *SetKillSwitchRequest, opts ...grpc.CallOption) (*SetKillSwitchResponse, error) {
+	out := new(SetKillSwitchResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/SetKillSwitch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) DeadManHeartbeat(ctx context.Context, in *DeadManHeartbeatRequest, opts ...grpc.CallOption) (*DeadManHeartbeatResponse, error) {
+	out := new(DeadManHeartbeatResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/DeadManHeartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) InternalTransfer(ctx context.Context, in *InternalTransferRequest, opts ...grpc.CallOption) (*InternalTransferResponse, error) {
+	out := new(InternalTransferResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/InternalTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetExchangeHealth(ctx context.Context, in *GetExchangeHealthRequest, opts ...grpc.CallOption) (*GetExchangeHealthResponse, error) {
+	out := new(GetExchangeHealthResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTraderService/GetExchangeHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoCryptoTraderServiceServer is the server API for GoCryptoTraderService service.
 // All implementations must embed UnimplementedGoCryptoTraderServiceServer
 // for forward compatibility
@@ -1301,6 +1577,7 @@ type GoCryptoTraderServiceServer interface {
 	GetHistoricTrades(*GetSavedTradesRequest, GoCryptoTraderService_GetHistoricTradesServer) error
 	GetSavedTrades(context.Context, *GetSavedTradesRequest) (*SavedTradesResponse, error)
 	ConvertTradesToCandles(context.Context, *ConvertTradesToCandlesRequest) (*GetHistoricCandlesResponse, error)
+	GetBuiltCandles(context.Context, *GetBuiltCandlesRequest) (*GetHistoricCandlesResponse, error)
 	FindMissingSavedCandleIntervals(context.Context, *FindMissingCandlePeriodsRequest) (*FindMissingIntervalsResponse, error)
 	FindMissingSavedTradeIntervals(context.Context, *FindMissingTradePeriodsRequest) (*FindMissingIntervalsResponse, error)
 	SetExchangeTradeProcessing(context.Context, *SetExchangeTradeProcessingRequest) (*GenericResponse, error)
@@ -1329,6 +1606,28 @@ type GoCryptoTraderServiceServer interface {
 	GetOrderbookMovement(context.Context, *GetOrderbookMovementRequest) (*GetOrderbookMovementResponse, error)
 	GetOrderbookAmountByNominal(context.Context, *GetOrderbookAmountByNominalRequest) (*GetOrderbookAmountByNominalResponse, error)
 	GetOrderbookAmountByImpact(context.Context, *GetOrderbookAmountByImpactRequest) (*GetOrderbookAmountByImpactResponse, error)
+	GetOrderbookDesyncs(context.Context, *GetOrderbookDesyncsRequest) (*GetOrderbookDesyncsResponse, error)
+	GetConsolidatedOrderbook(context.Context, *GetConsolidatedOrderbookRequest) (*ConsolidatedOrderbookResponse, error)
+	GetConsolidatedOrderbookStream(*GetConsolidatedOrderbookRequest, GoCryptoTraderService_GetConsolidatedOrderbookStreamServer) error
+	GetConsolidatedOrderbookMovement(context.Context, *GetConsolidatedOrderbookMovementRequest) (*GetOrderbookMovementResponse, error)
+	GetArbitrageOpportunities(context.Context, *GetArbitrageOpportunitiesRequest) (*GetArbitrageOpportunitiesResponse, error)
+	GetArbitrageOpportunityStream(*GetArbitrageOpportunitiesRequest, GoCryptoTraderService_GetArbitrageOpportunityStreamServer) error
+	GetRebalancePlans(context.Context, *GetRebalancePlansRequest) (*GetRebalancePlansResponse, error)
+	ProposeRebalance(context.Context, *ProposeRebalanceRequest) (*RebalancePlan, error)
+	ApproveRebalancePlan(context.Context, *RebalancePlanRequest) (*RebalancePlan, error)
+	RejectRebalancePlan(context.Context, *RebalancePlanRequest) (*GenericResponse, error)
+	GetPortfolioHistory(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioHistoryResponse, error)
+	GetPortfolioPerformance(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioPerformanceResponse, error)
+	GetPortfolioFlows(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioFlowsResponse, error)
+	GetCapitalGainsReport(context.Context, *GetCapitalGainsReportRequest) (*GetCapitalGainsReportResponse, error)
+	GetTaxLots(context.Context, *GetTaxLotsRequest) (*GetTaxLotsResponse, error)
+	ReconcileOrders(context.Context, *ReconcileOrdersRequest) (*ReconcileOrdersResponse, error)
+	GetRiskLimits(context.Context, *GetRiskLimitsRequest) (*GetRiskLimitsResponse, error)
+	SetRiskLimit(context.Context, *SetRiskLimitRequest) (*GenericResponse, error)
+	SetKillSwitch(context.Context, *SetKillSwitchRequest) (*SetKillSwitchResponse, error)
+	DeadManHeartbeat(context.Context, *DeadManHeartbeatRequest) (*DeadManHeartbeatResponse, error)
+	InternalTransfer(context.Context, *InternalTransferRequest) (*InternalTransferResponse, error)
+	GetExchangeHealth(context.Context, *GetExchangeHealthRequest) (*GetExchangeHealthResponse, error)
 	mustEmbedUnimplementedGoCryptoTraderServiceServer()
 }
 
@@ -1567,6 +1866,9 @@ func (UnimplementedGoCryptoTraderServiceServer) GetSavedTrades(context.Context,
 func (UnimplementedGoCryptoTraderServiceServer) ConvertTradesToCandles(context.Context, *ConvertTradesToCandlesRequest) (*GetHistoricCandlesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConvertTradesToCandles not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetBuiltCandles(context.Context, *GetBuiltCandlesRequest) (*GetHistoricCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBuiltCandles not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) FindMissingSavedCandleIntervals(context.Context, *FindMissingCandlePeriodsRequest) (*FindMissingIntervalsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindMissingSavedCandleIntervals not implemented")
 }
@@ -1651,6 +1953,72 @@ func (UnimplementedGoCryptoTraderServiceServer) GetOrderbookAmountByNominal(cont
 func (UnimplementedGoCryptoTraderServiceServer) GetOrderbookAmountByImpact(context.Context, *GetOrderbookAmountByImpactRequest) (*GetOrderbookAmountByImpactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbookAmountByImpact not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetOrderbookDesyncs(context.Context, *GetOrderbookDesyncsRequest) (*GetOrderbookDesyncsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbookDesyncs not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetConsolidatedOrderbook(context.Context, *GetConsolidatedOrderbookRequest) (*ConsolidatedOrderbookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsolidatedOrderbook not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetConsolidatedOrderbookStream(*GetConsolidatedOrderbookRequest, GoCryptoTraderService_GetConsolidatedOrderbookStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetConsolidatedOrderbookStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetConsolidatedOrderbookMovement(context.Context, *GetConsolidatedOrderbookMovementRequest) (*GetOrderbookMovementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsolidatedOrderbookMovement not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetArbitrageOpportunities(context.Context, *GetArbitrageOpportunitiesRequest) (*GetArbitrageOpportunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArbitrageOpportunities not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetArbitrageOpportunityStream(*GetArbitrageOpportunitiesRequest, GoCryptoTraderService_GetArbitrageOpportunityStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetArbitrageOpportunityStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetRebalancePlans(context.Context, *GetRebalancePlansRequest) (*GetRebalancePlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRebalancePlans not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) ProposeRebalance(context.Context, *ProposeRebalanceRequest) (*RebalancePlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeRebalance not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) ApproveRebalancePlan(context.Context, *RebalancePlanRequest) (*RebalancePlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRebalancePlan not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) RejectRebalancePlan(context.Context, *RebalancePlanRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectRebalancePlan not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetPortfolioHistory(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetPortfolioPerformance(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioPerformanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioPerformance not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetPortfolioFlows(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioFlowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioFlows not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetCapitalGainsReport(context.Context, *GetCapitalGainsReportRequest) (*GetCapitalGainsReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapitalGainsReport not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetTaxLots(context.Context, *GetTaxLotsRequest) (*GetTaxLotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaxLots not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) ReconcileOrders(context.Context, *ReconcileOrdersRequest) (*ReconcileOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileOrders not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetRiskLimits(context.Context, *GetRiskLimitsRequest) (*GetRiskLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRiskLimits not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SetRiskLimit(context.Context, *SetRiskLimitRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRiskLimit not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SetKillSwitch(context.Context, *SetKillSwitchRequest) (*SetKillSwitchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKillSwitch not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) DeadManHeartbeat(context.Context, *DeadManHeartbeatRequest) (*DeadManHeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeadManHeartbeat not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) InternalTransfer(context.Context, *InternalTransferRequest) (*InternalTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InternalTransfer not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetExchangeHealth(context.Context, *GetExchangeHealthRequest) (*GetExchangeHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeHealth not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) mustEmbedUnimplementedGoCryptoTraderServiceServer() {}
 
 // UnsafeGoCryptoTraderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -3068,6 +3436,24 @@ func _GoCryptoTraderService_ConvertTradesToCandles_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoCryptoTraderService_GetBuiltCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBuiltCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetBuiltCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetBuiltCandles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetBuiltCandles(ctx, req.(*GetBuiltCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoCryptoTraderService_FindMissingSavedCandleIntervals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindMissingCandlePeriodsRequest)
 	if err := dec(in); err != nil {
@@ -3572,48 +3958,450 @@ func _GoCryptoTraderService_GetOrderbookAmountByImpact_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
-// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gctrpc.GoCryptoTraderService",
-	HandlerType: (*GoCryptoTraderServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetInfo",
-			Handler:    _GoCryptoTraderService_GetInfo_Handler,
-		},
-		{
-			MethodName: "GetSubsystems",
-			Handler:    _GoCryptoTraderService_GetSubsystems_Handler,
-		},
-		{
-			MethodName: "EnableSubsystem",
-			Handler:    _GoCryptoTraderService_EnableSubsystem_Handler,
-		},
-		{
-			MethodName: "DisableSubsystem",
-			Handler:    _GoCryptoTraderService_DisableSubsystem_Handler,
-		},
-		{
-			MethodName: "GetRPCEndpoints",
-			Handler:    _GoCryptoTraderService_GetRPCEndpoints_Handler,
-		},
-		{
-			MethodName: "GetCommunicationRelayers",
-			Handler:    _GoCryptoTraderService_GetCommunicationRelayers_Handler,
-		},
-		{
-			MethodName: "GetExchanges",
-			Handler:    _GoCryptoTraderService_GetExchanges_Handler,
-		},
-		{
-			MethodName: "DisableExchange",
-			Handler:    _GoCryptoTraderService_DisableExchange_Handler,
-		},
-		{
-			MethodName: "GetExchangeInfo",
-			Handler:    _GoCryptoTraderService_GetExchangeInfo_Handler,
+func _GoCryptoTraderService_GetOrderbookDesyncs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderbookDesyncsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetOrderbookDesyncs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetOrderbookDesyncs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetOrderbookDesyncs(ctx, req.(*GetOrderbookDesyncsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetConsolidatedOrderbook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsolidatedOrderbookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetConsolidatedOrderbook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetConsolidatedOrderbook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetConsolidatedOrderbook(ctx, req.(*GetConsolidatedOrderbookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetConsolidatedOrderbookStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetConsolidatedOrderbookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetConsolidatedOrderbookStream(m, &goCryptoTraderServiceGetConsolidatedOrderbookStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetConsolidatedOrderbookStreamServer interface {
+	Send(*ConsolidatedOrderbookResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetConsolidatedOrderbookStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetConsolidatedOrderbookStreamServer) Send(m *ConsolidatedOrderbookResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetConsolidatedOrderbookMovement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsolidatedOrderbookMovementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetConsolidatedOrderbookMovement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetConsolidatedOrderbookMovement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetConsolidatedOrderbookMovement(ctx, req.(*GetConsolidatedOrderbookMovementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetArbitrageOpportunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArbitrageOpportunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetArbitrageOpportunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetArbitrageOpportunities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetArbitrageOpportunities(ctx, req.(*GetArbitrageOpportunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetArbitrageOpportunityStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetArbitrageOpportunitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetArbitrageOpportunityStream(m, &goCryptoTraderServiceGetArbitrageOpportunityStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetArbitrageOpportunityStreamServer interface {
+	Send(*ArbitrageOpportunity) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetArbitrageOpportunityStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetArbitrageOpportunityStreamServer) Send(m *ArbitrageOpportunity) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetRebalancePlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRebalancePlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetRebalancePlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetRebalancePlans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetRebalancePlans(ctx, req.(*GetRebalancePlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ProposeRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeRebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ProposeRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/ProposeRebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ProposeRebalance(ctx, req.(*ProposeRebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ApproveRebalancePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalancePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ApproveRebalancePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/ApproveRebalancePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ApproveRebalancePlan(ctx, req.(*RebalancePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_RejectRebalancePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalancePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).RejectRebalancePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/RejectRebalancePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).RejectRebalancePlan(ctx, req.(*RebalancePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetPortfolioHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetPortfolioHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioHistory(ctx, req.(*GetPortfolioHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetPortfolioPerformance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioPerformance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetPortfolioPerformance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioPerformance(ctx, req.(*GetPortfolioHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetPortfolioFlows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioFlows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetPortfolioFlows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioFlows(ctx, req.(*GetPortfolioHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetCapitalGainsReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapitalGainsReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetCapitalGainsReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetCapitalGainsReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetCapitalGainsReport(ctx, req.(*GetCapitalGainsReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetTaxLots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaxLotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetTaxLots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetTaxLots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetTaxLots(ctx, req.(*GetTaxLotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ReconcileOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ReconcileOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/ReconcileOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ReconcileOrders(ctx, req.(*ReconcileOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetRiskLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRiskLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetRiskLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetRiskLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetRiskLimits(ctx, req.(*GetRiskLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SetRiskLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRiskLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SetRiskLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/SetRiskLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SetRiskLimit(ctx, req.(*SetRiskLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SetKillSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKillSwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SetKillSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/SetKillSwitch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SetKillSwitch(ctx, req.(*SetKillSwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_DeadManHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeadManHeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).DeadManHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/DeadManHeartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).DeadManHeartbeat(ctx, req.(*DeadManHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_InternalTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InternalTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).InternalTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/InternalTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).InternalTransfer(ctx, req.(*InternalTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetExchangeHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExchangeHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetExchangeHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTraderService/GetExchangeHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetExchangeHealth(ctx, req.(*GetExchangeHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gctrpc.GoCryptoTraderService",
+	HandlerType: (*GoCryptoTraderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _GoCryptoTraderService_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetSubsystems",
+			Handler:    _GoCryptoTraderService_GetSubsystems_Handler,
+		},
+		{
+			MethodName: "EnableSubsystem",
+			Handler:    _GoCryptoTraderService_EnableSubsystem_Handler,
+		},
+		{
+			MethodName: "DisableSubsystem",
+			Handler:    _GoCryptoTraderService_DisableSubsystem_Handler,
+		},
+		{
+			MethodName: "GetRPCEndpoints",
+			Handler:    _GoCryptoTraderService_GetRPCEndpoints_Handler,
+		},
+		{
+			MethodName: "GetCommunicationRelayers",
+			Handler:    _GoCryptoTraderService_GetCommunicationRelayers_Handler,
+		},
+		{
+			MethodName: "GetExchanges",
+			Handler:    _GoCryptoTraderService_GetExchanges_Handler,
+		},
+		{
+			MethodName: "DisableExchange",
+			Handler:    _GoCryptoTraderService_DisableExchange_Handler,
+		},
+		{
+			MethodName: "GetExchangeInfo",
+			Handler:    _GoCryptoTraderService_GetExchangeInfo_Handler,
 		},
 		{
 			MethodName: "GetExchangeOTPCode",
@@ -3863,6 +4651,10 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConvertTradesToCandles",
 			Handler:    _GoCryptoTraderService_ConvertTradesToCandles_Handler,
 		},
+		{
+			MethodName: "GetBuiltCandles",
+			Handler:    _GoCryptoTraderService_GetBuiltCandles_Handler,
+		},
 		{
 			MethodName: "FindMissingSavedCandleIntervals",
 			Handler:    _GoCryptoTraderService_FindMissingSavedCandleIntervals_Handler,
@@ -3975,6 +4767,86 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderbookAmountByImpact",
 			Handler:    _GoCryptoTraderService_GetOrderbookAmountByImpact_Handler,
 		},
+		{
+			MethodName: "GetOrderbookDesyncs",
+			Handler:    _GoCryptoTraderService_GetOrderbookDesyncs_Handler,
+		},
+		{
+			MethodName: "GetConsolidatedOrderbook",
+			Handler:    _GoCryptoTraderService_GetConsolidatedOrderbook_Handler,
+		},
+		{
+			MethodName: "GetConsolidatedOrderbookMovement",
+			Handler:    _GoCryptoTraderService_GetConsolidatedOrderbookMovement_Handler,
+		},
+		{
+			MethodName: "GetArbitrageOpportunities",
+			Handler:    _GoCryptoTraderService_GetArbitrageOpportunities_Handler,
+		},
+		{
+			MethodName: "GetRebalancePlans",
+			Handler:    _GoCryptoTraderService_GetRebalancePlans_Handler,
+		},
+		{
+			MethodName: "ProposeRebalance",
+			Handler:    _GoCryptoTraderService_ProposeRebalance_Handler,
+		},
+		{
+			MethodName: "ApproveRebalancePlan",
+			Handler:    _GoCryptoTraderService_ApproveRebalancePlan_Handler,
+		},
+		{
+			MethodName: "RejectRebalancePlan",
+			Handler:    _GoCryptoTraderService_RejectRebalancePlan_Handler,
+		},
+		{
+			MethodName: "GetPortfolioHistory",
+			Handler:    _GoCryptoTraderService_GetPortfolioHistory_Handler,
+		},
+		{
+			MethodName: "GetPortfolioPerformance",
+			Handler:    _GoCryptoTraderService_GetPortfolioPerformance_Handler,
+		},
+		{
+			MethodName: "GetPortfolioFlows",
+			Handler:    _GoCryptoTraderService_GetPortfolioFlows_Handler,
+		},
+		{
+			MethodName: "GetCapitalGainsReport",
+			Handler:    _GoCryptoTraderService_GetCapitalGainsReport_Handler,
+		},
+		{
+			MethodName: "GetTaxLots",
+			Handler:    _GoCryptoTraderService_GetTaxLots_Handler,
+		},
+		{
+			MethodName: "ReconcileOrders",
+			Handler:    _GoCryptoTraderService_ReconcileOrders_Handler,
+		},
+		{
+			MethodName: "GetRiskLimits",
+			Handler:    _GoCryptoTraderService_GetRiskLimits_Handler,
+		},
+		{
+			MethodName: "SetRiskLimit",
+			Handler:    _GoCryptoTraderService_SetRiskLimit_Handler,
+		},
+		{
+			MethodName: "SetKillSwitch",
+			Handler:    _GoCryptoTraderService_SetKillSwitch_Handler,
+		},
+		{
+			MethodName: "DeadManHeartbeat",
+			Handler:    _GoCryptoTraderService_DeadManHeartbeat_Handler,
+		},
+		{
+			MethodName: "InternalTransfer",
+			Handler:    _GoCryptoTraderService_InternalTransfer_Handler,
+		},
+		{
+			MethodName: "GetExchangeHealth",
+			Handler:    _GoCryptoTraderService_GetExchangeHealth_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4007,6 +4879,16 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoCryptoTraderService_GetHistoricTrades_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetConsolidatedOrderbookStream",
+			Handler:       _GoCryptoTraderService_GetConsolidatedOrderbookStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetArbitrageOpportunityStream",
+			Handler:       _GoCryptoTraderService_GetArbitrageOpportunityStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -27,6 +27,7 @@ const (
 	orderSubmitFunc    = "ordersubmit"
 	withdrawCryptoFunc = "withdrawcrypto"
 	withdrawFiatFunc   = "withdrawfiat"
+	transferFunc       = "transfer"
 	ohlcvFunc          = "ohlcv"
 )
 
@@ -42,6 +43,7 @@ var exchangeModule = map[string]objects.Object{
 	orderSubmitFunc:    &objects.UserFunction{Name: orderSubmitFunc, Value: ExchangeOrderSubmit},
 	withdrawCryptoFunc: &objects.UserFunction{Name: withdrawCryptoFunc, Value: ExchangeWithdrawCrypto},
 	withdrawFiatFunc:   &objects.UserFunction{Name: withdrawFiatFunc, Value: ExchangeWithdrawFiat},
+	transferFunc:       &objects.UserFunction{Name: transferFunc, Value: ExchangeTransfer},
 	ohlcvFunc:          &objects.UserFunction{Name: ohlcvFunc, Value: exchangeOHLCV},
 }
 
@@ -668,6 +670,74 @@ func ExchangeWithdrawFiat(args ...objects.Object) (objects.Object, error) {
 	return &objects.String{Value: rtn}, nil
 }
 
+// ExchangeTransfer submit request to transfer funds between the asset wallets
+// and sub-accounts of an exchange
+func ExchangeTransfer(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 8 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	scriptCtx, ok := objects.ToInterface(args[0]).(*Context)
+	if !ok {
+		return nil, constructRuntimeError(1, transferFunc, "*gct.Context", args[0])
+	}
+	exchangeName, ok := objects.ToString(args[1])
+	if !ok {
+		return nil, constructRuntimeError(2, transferFunc, "string", args[1])
+	}
+	cur, ok := objects.ToString(args[2])
+	if !ok {
+		return nil, constructRuntimeError(3, transferFunc, "string", args[2])
+	}
+	amount, ok := objects.ToFloat64(args[3])
+	if !ok {
+		return nil, constructRuntimeError(4, transferFunc, "float64", args[3])
+	}
+	fromParam, ok := objects.ToString(args[4])
+	if !ok {
+		return nil, constructRuntimeError(5, transferFunc, "string", args[4])
+	}
+	toParam, ok := objects.ToString(args[5])
+	if !ok {
+		return nil, constructRuntimeError(6, transferFunc, "string", args[5])
+	}
+	fromSubAccount, ok := objects.ToString(args[6])
+	if !ok {
+		return nil, constructRuntimeError(7, transferFunc, "string", args[6])
+	}
+	toSubAccount, ok := objects.ToString(args[7])
+	if !ok {
+		return nil, constructRuntimeError(8, transferFunc, "string", args[7])
+	}
+
+	from, err := asset.New(fromParam)
+	if err != nil {
+		return errorResponsef(standardFormatting, err)
+	}
+	to, err := asset.New(toParam)
+	if err != nil {
+		return errorResponsef(standardFormatting, err)
+	}
+
+	transferRequest := &withdraw.TransferRequest{
+		Exchange:       exchangeName,
+		Currency:       currency.NewCode(cur),
+		Amount:         amount,
+		From:           from,
+		To:             to,
+		FromSubAccount: fromSubAccount,
+		ToSubAccount:   toSubAccount,
+	}
+
+	ctx := processScriptContext(scriptCtx)
+	rtn, err := wrappers.GetWrapper().InternalTransfer(ctx, transferRequest)
+	if err != nil {
+		return errorResponsef(standardFormatting, err)
+	}
+
+	return &objects.String{Value: rtn}, nil
+}
+
 // OHLCV defines a custom Open High Low Close Volume tengo object
 type OHLCV struct {
 	objects.Map
@@ -295,6 +295,32 @@ func TestExchangeWithdrawFiat(t *testing.T) {
 	}
 }
 
+func TestExchangeTransfer(t *testing.T) {
+	t.Parallel()
+	_, err := ExchangeTransfer()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Error(err)
+	}
+
+	currCode := &objects.String{Value: "USDT"}
+	amount := &objects.Float{Value: 1.0}
+	from := &objects.String{Value: "spot"}
+	to := &objects.String{Value: "margin"}
+	sub := &objects.String{Value: ""}
+	rtn, err := ExchangeTransfer(ctx, exch, currCode, amount, from, &objects.String{Value: "bogus"}, sub, sub)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, ok := rtn.(*objects.Error); !ok {
+		t.Errorf("expected an error response for an invalid asset but received %v", rtn)
+	}
+
+	_, err = ExchangeTransfer(ctx, exch, currCode, amount, from, to, sub, sub)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestParseInterval(t *testing.T) {
 	t.Parallel()
 	v, err := parseInterval("1h")
@@ -0,0 +1,48 @@
+package indicators
+
+import (
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// HeikinAshiModule heikin ashi candle commands
+var HeikinAshiModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: heikinAshi},
+}
+
+// HeikinAshiCandles is the string constant
+const HeikinAshiCandles = "Heikin Ashi"
+
+// HeikinAshi defines a custom Heikin Ashi candle tengo object type
+type HeikinAshi struct {
+	objects.Array
+}
+
+// TypeName returns the name of the custom type.
+func (o *HeikinAshi) TypeName() string {
+	return HeikinAshiCandles
+}
+
+func heikinAshi(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(HeikinAshi)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := ohlc.GetHeikinAshi()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Value = toSeriesArray(ret.Open, ret.High, ret.Low, ret.Close)
+	return r, nil
+}
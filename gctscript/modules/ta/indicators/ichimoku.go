@@ -0,0 +1,84 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// IchimokuModule ichimoku cloud indicator commands
+var IchimokuModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: ichimoku},
+}
+
+// IchimokuCloud is the string constant
+const IchimokuCloud = "Ichimoku Cloud"
+
+// Ichimoku defines a custom Ichimoku Cloud tengo indicator object type
+type Ichimoku struct {
+	objects.Array
+	ConversionPeriod, BasePeriod, SpanBPeriod, Displacement int
+}
+
+// TypeName returns the name of the custom type.
+func (o *Ichimoku) TypeName() string {
+	return IchimokuCloud
+}
+
+func ichimoku(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Ichimoku)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inConversion, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inConversion))
+	}
+
+	inBase, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inBase))
+	}
+
+	inSpanB, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inSpanB))
+	}
+
+	inDisplacement, ok := objects.ToInt(args[4])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDisplacement))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetIchimokuCloud(int64(inConversion), int64(inBase), int64(inSpanB), int64(inDisplacement))
+	if err != nil {
+		return nil, err
+	}
+
+	r.ConversionPeriod = inConversion
+	r.BasePeriod = inBase
+	r.SpanBPeriod = inSpanB
+	r.Displacement = inDisplacement
+
+	r.Value = toSeriesArray(ret.Conversion, ret.Base, ret.LeadingSpanA, ret.LeadingSpanB, ret.LaggingSpan)
+	return r, nil
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 
+	objects "github.com/d5/tengo/v2"
 	"github.com/thrasher-corp/gct-ta/indicators"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 )
 
@@ -59,3 +61,65 @@ func ParseMAType(in string) (indicators.MaType, error) {
 		return 0, errInvalidSelector
 	}
 }
+
+// toOHLC converts script OHLCV data in the format of
+// [[time, open, high, low, close, volume], ...] to OHLC data for technical
+// analysis
+func toOHLC(data objects.Object) (*kline.OHLC, error) {
+	ohlcvInputData, valid := objects.ToInterface(data).([]interface{})
+	if !valid {
+		return nil, fmt.Errorf(modules.ErrParameterConvertFailed, OHLCV)
+	}
+
+	ohlc := &kline.OHLC{
+		Open:   make([]float64, len(ohlcvInputData)),
+		High:   make([]float64, len(ohlcvInputData)),
+		Low:    make([]float64, len(ohlcvInputData)),
+		Close:  make([]float64, len(ohlcvInputData)),
+		Volume: make([]float64, len(ohlcvInputData)),
+	}
+	sets := [][]float64{ohlc.Open, ohlc.High, ohlc.Low, ohlc.Close, ohlc.Volume}
+	var allErrors []string
+	for x := range ohlcvInputData {
+		t, ok := ohlcvInputData[x].([]interface{})
+		if !ok {
+			return nil, errors.New("ohlcvInputData type assert failed")
+		}
+		if len(t) < 6 {
+			return nil, errors.New("ohlcvInputData invalid data length")
+		}
+		for y := range sets {
+			value, err := toFloat64(t[y+1])
+			if err != nil {
+				allErrors = append(allErrors, err.Error())
+			}
+			sets[y][x] = value
+		}
+	}
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+	return ohlc, nil
+}
+
+// toSeriesArray converts one or more indicator series into a tengo array. A
+// single series is returned as a flat array, multiple series are returned as
+// an array per element in the order supplied.
+func toSeriesArray(series ...[]float64) []objects.Object {
+	if len(series) == 0 {
+		return nil
+	}
+	values := make([]objects.Object, len(series[0]))
+	for x := range values {
+		if len(series) == 1 {
+			values[x] = &objects.Float{Value: series[0][x]}
+			continue
+		}
+		element := &objects.Array{Value: make([]objects.Object, len(series))}
+		for y := range series {
+			element.Value[y] = &objects.Float{Value: series[y][x]}
+		}
+		values[x] = element
+	}
+	return values
+}
@@ -561,3 +561,69 @@ func TestParseMAType(t *testing.T) {
 		})
 	}
 }
+
+func TestExtendedIndicators(t *testing.T) {
+	testCases := []struct {
+		name     string
+		function objects.CallableFunc
+		args     []objects.Object
+	}{
+		{"stochastic", stochastic, []objects.Object{&objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3}}},
+		{"stochasticRSI", stochasticRSI, []objects.Object{&objects.Int{Value: 14}, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3}}},
+		{"adx", adx, []objects.Object{&objects.Int{Value: 14}}},
+		{"ichimoku", ichimoku, []objects.Object{&objects.Int{Value: 9}, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26}}},
+		{"keltner", keltner, []objects.Object{&objects.Int{Value: 20}, &objects.Int{Value: 10}, &objects.Float{Value: 2}}},
+		{"donchian", donchian, []objects.Object{&objects.Int{Value: 20}}},
+		{"supertrend", supertrend, []objects.Object{&objects.Int{Value: 10}, &objects.Float{Value: 3}}},
+		{"vwapBands", vwapBands, []objects.Object{&objects.Float{Value: 2}}},
+		{"cci", cci, []objects.Object{&objects.Int{Value: 20}}},
+		{"williamsR", williamsR, []objects.Object{&objects.Int{Value: 14}}},
+		{"parabolicSAR", parabolicSAR, []objects.Object{&objects.Float{Value: 0.02}, &objects.Float{Value: 0.2}}},
+		{"heikinAshi", heikinAshi, nil},
+		{"pivotPoints", pivotPoints, []objects.Object{&objects.String{Value: "fibonacci"}}},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.function()
+			if !errors.Is(err, objects.ErrWrongNumArguments) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, objects.ErrWrongNumArguments)
+			}
+
+			args := append([]objects.Object{ohlcvData}, test.args...)
+			ret, err := test.function(args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = ret.IndexGet(&objects.Int{Value: 99}); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = test.function(append([]objects.Object{ohlcvDataInvalid}, test.args...)...)
+			if err == nil {
+				t.Fatal("expected conversion failed error")
+			}
+
+			_, err = test.function(append([]objects.Object{&objects.String{Value: testString}}, test.args...)...)
+			if err == nil {
+				t.Fatal("expected conversion failed error")
+			}
+
+			if len(test.args) > 0 && test.name != "pivotPoints" {
+				invalidArgs := append([]objects.Object{ohlcvData}, test.args...)
+				invalidArgs[1] = &objects.String{Value: testString}
+				if _, err = test.function(invalidArgs...); err == nil {
+					t.Fatal("expected conversion failed error")
+				}
+			}
+
+			validator.IsTestExecution.Store(true)
+			_, err = test.function(args...)
+			validator.IsTestExecution.Store(false)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
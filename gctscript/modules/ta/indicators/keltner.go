@@ -0,0 +1,79 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// KeltnerModule keltner channels indicator commands
+var KeltnerModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: keltner},
+}
+
+// KeltnerChannels is the string constant
+const KeltnerChannels = "Keltner Channels"
+
+// Keltner defines a custom Keltner Channels tengo indicator object type
+type Keltner struct {
+	objects.Array
+	Period, ATRPeriod int
+	Multiplier        float64
+}
+
+// TypeName returns the name of the custom type.
+func (o *Keltner) TypeName() string {
+	return KeltnerChannels
+}
+
+func keltner(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Keltner)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inEMAPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inEMAPeriod))
+	}
+
+	inATRPeriod, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inATRPeriod))
+	}
+
+	inMultiplier, ok := objects.ToFloat64(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMultiplier))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetKeltnerChannels(int64(inEMAPeriod), int64(inATRPeriod), inMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Period = inEMAPeriod
+	r.ATRPeriod = inATRPeriod
+	r.Multiplier = inMultiplier
+
+	r.Value = toSeriesArray(ret.Upper, ret.Middle, ret.Lower)
+	return r, nil
+}
@@ -0,0 +1,67 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// PivotPointsModule pivot point indicator commands
+var PivotPointsModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: pivotPoints},
+}
+
+// PivotPointLevels is the string constant
+const PivotPointLevels = "Pivot Points"
+
+// PivotPoints defines a custom Pivot Points tengo indicator object type
+type PivotPoints struct {
+	objects.Array
+	Method string
+}
+
+// TypeName returns the name of the custom type.
+func (o *PivotPoints) TypeName() string {
+	return PivotPointLevels
+}
+
+func pivotPoints(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(PivotPoints)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inMethod, ok := objects.ToString(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMethod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetPivotPoints(kline.PivotMethod(inMethod))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Method = inMethod
+
+	r.Value = toSeriesArray(ret.Pivot, ret.Resistance1, ret.Resistance2, ret.Resistance3, ret.Support1, ret.Support2, ret.Support3)
+	return r, nil
+}
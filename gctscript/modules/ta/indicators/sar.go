@@ -0,0 +1,73 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// ParabolicSARModule parabolic stop and reverse indicator commands
+var ParabolicSARModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: parabolicSAR},
+}
+
+// ParabolicStopAndReverse is the string constant
+const ParabolicStopAndReverse = "Parabolic Stop And Reverse"
+
+// ParabolicSAR defines a custom Parabolic Stop And Reverse tengo indicator
+// object type
+type ParabolicSAR struct {
+	objects.Array
+	Step, Maximum float64
+}
+
+// TypeName returns the name of the custom type.
+func (o *ParabolicSAR) TypeName() string {
+	return ParabolicStopAndReverse
+}
+
+func parabolicSAR(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 3 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(ParabolicSAR)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inStep, ok := objects.ToFloat64(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inStep))
+	}
+
+	inMaximum, ok := objects.ToFloat64(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMaximum))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetParabolicSAR(inStep, inMaximum)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Step = inStep
+	r.Maximum = inMaximum
+
+	r.Value = toSeriesArray(ret)
+	return r, nil
+}
@@ -0,0 +1,78 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// StochasticModule stochastic oscillator indicator commands
+var StochasticModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: stochastic},
+}
+
+// StochasticOscillator is the string constant
+const StochasticOscillator = "Stochastic Oscillator"
+
+// Stochastic defines a custom Stochastic Oscillator tengo indicator object type
+type Stochastic struct {
+	objects.Array
+	Period, SmoothPeriod, SignalPeriod int
+}
+
+// TypeName returns the name of the custom type.
+func (o *Stochastic) TypeName() string {
+	return StochasticOscillator
+}
+
+func stochastic(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Stochastic)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inKPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inKPeriod))
+	}
+
+	inSmoothK, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inSmoothK))
+	}
+
+	inDPeriod, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDPeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetStochastic(int64(inKPeriod), int64(inSmoothK), int64(inDPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Period = inKPeriod
+	r.SmoothPeriod = inSmoothK
+	r.SignalPeriod = inDPeriod
+
+	r.Value = toSeriesArray(ret.K, ret.D)
+	return r, nil
+}
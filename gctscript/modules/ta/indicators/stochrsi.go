@@ -0,0 +1,85 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// StochasticRSIModule stochastic relative strength index indicator commands
+var StochasticRSIModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: stochasticRSI},
+}
+
+// StochasticRelativeStrengthIndex is the string constant
+const StochasticRelativeStrengthIndex = "Stochastic Relative Strength Index"
+
+// StochasticRSI defines a custom Stochastic Relative Strength Index tengo
+// indicator object type
+type StochasticRSI struct {
+	objects.Array
+	Period, StochasticPeriod, SmoothPeriod, SignalPeriod int
+}
+
+// TypeName returns the name of the custom type.
+func (o *StochasticRSI) TypeName() string {
+	return StochasticRelativeStrengthIndex
+}
+
+func stochasticRSI(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(StochasticRSI)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inRSIPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inRSIPeriod))
+	}
+
+	inStochPeriod, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inStochPeriod))
+	}
+
+	inSmoothK, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inSmoothK))
+	}
+
+	inDPeriod, ok := objects.ToInt(args[4])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDPeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetStochasticRSI(ohlc.Close, int64(inRSIPeriod), int64(inStochPeriod), int64(inSmoothK), int64(inDPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Period = inRSIPeriod
+	r.StochasticPeriod = inStochPeriod
+	r.SmoothPeriod = inSmoothK
+	r.SignalPeriod = inDPeriod
+
+	r.Value = toSeriesArray(ret.K, ret.D)
+	return r, nil
+}
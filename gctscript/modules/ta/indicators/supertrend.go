@@ -0,0 +1,73 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// SupertrendModule supertrend indicator commands
+var SupertrendModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: supertrend},
+}
+
+// SupertrendIndicator is the string constant
+const SupertrendIndicator = "Supertrend"
+
+// Supertrend defines a custom Supertrend tengo indicator object type
+type Supertrend struct {
+	objects.Array
+	Period     int
+	Multiplier float64
+}
+
+// TypeName returns the name of the custom type.
+func (o *Supertrend) TypeName() string {
+	return SupertrendIndicator
+}
+
+func supertrend(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 3 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Supertrend)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inTimePeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inTimePeriod))
+	}
+
+	inMultiplier, ok := objects.ToFloat64(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMultiplier))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetSupertrend(int64(inTimePeriod), inMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Period = inTimePeriod
+	r.Multiplier = inMultiplier
+
+	r.Value = toSeriesArray(ret.Values, ret.Direction)
+	return r, nil
+}
@@ -0,0 +1,67 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// VWAPBandsModule volume weighted average price bands indicator commands
+var VWAPBandsModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: vwapBands},
+}
+
+// VolumeWeightedAveragePriceBands is the string constant
+const VolumeWeightedAveragePriceBands = "Volume Weighted Average Price Bands"
+
+// VWAPBands defines a custom Volume Weighted Average Price Bands tengo
+// indicator object type
+type VWAPBands struct {
+	objects.Array
+	Multiplier float64
+}
+
+// TypeName returns the name of the custom type.
+func (o *VWAPBands) TypeName() string {
+	return VolumeWeightedAveragePriceBands
+}
+
+func vwapBands(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(VWAPBands)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inMultiplier, ok := objects.ToFloat64(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMultiplier))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetVWAPBands(inMultiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Multiplier = inMultiplier
+
+	r.Value = toSeriesArray(ret.Upper, ret.Middle, ret.Lower)
+	return r, nil
+}
@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// WilliamsRModule williams %R indicator commands
+var WilliamsRModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: williamsR},
+}
+
+// WilliamsPercentRange is the string constant
+const WilliamsPercentRange = "Williams Percent Range"
+
+// WilliamsR defines a custom Williams %R tengo indicator object type
+type WilliamsR struct {
+	objects.Array
+	Period int
+}
+
+// TypeName returns the name of the custom type.
+func (o *WilliamsR) TypeName() string {
+	return WilliamsPercentRange
+}
+
+func williamsR(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(WilliamsR)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	ohlc, err := toOHLC(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inTimePeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inTimePeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	ret, err := ohlc.GetWilliamsPercentRange(int64(inTimePeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Period = inTimePeriod
+
+	r.Value = toSeriesArray(ret)
+	return r, nil
+}
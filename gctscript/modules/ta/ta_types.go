@@ -16,4 +16,17 @@ var Modules = map[string]map[string]tengo.Object{
 	"indicator/mfi":                    indicators.MfiModule,
 	"indicator/atr":                    indicators.AtrModule,
 	"indicator/correlationcoefficient": indicators.CorrelationCoefficientModule,
+	"indicator/stochastic":             indicators.StochasticModule,
+	"indicator/stochrsi":               indicators.StochasticRSIModule,
+	"indicator/adx":                    indicators.ADXModule,
+	"indicator/ichimoku":               indicators.IchimokuModule,
+	"indicator/keltner":                indicators.KeltnerModule,
+	"indicator/donchian":               indicators.DonchianModule,
+	"indicator/supertrend":             indicators.SupertrendModule,
+	"indicator/vwapbands":              indicators.VWAPBandsModule,
+	"indicator/cci":                    indicators.CCIModule,
+	"indicator/willr":                  indicators.WilliamsRModule,
+	"indicator/sar":                    indicators.ParabolicSARModule,
+	"indicator/heikinashi":             indicators.HeikinAshiModule,
+	"indicator/pivot":                  indicators.PivotPointsModule,
 }
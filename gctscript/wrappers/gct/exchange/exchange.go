@@ -215,6 +215,16 @@ func (e Exchange) WithdrawalCryptoFunds(ctx context.Context, request *withdraw.R
 	return resp.Exchange.ID, nil
 }
 
+// InternalTransfer transfers funds between the asset wallets and sub-accounts
+// of an exchange
+func (e Exchange) InternalTransfer(ctx context.Context, request *withdraw.TransferRequest) (string, error) {
+	resp, err := engine.Bot.WithdrawManager.SubmitTransfer(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
 // OHLCV returns open high low close volume candles for requested exchange/pair/asset/start & end time
 func (e Exchange) OHLCV(ctx context.Context, exch string, pair currency.Pair, item asset.Item, start, end time.Time, interval kline.Interval) (kline.Item, error) {
 	ex, err := e.GetExchange(exch)
@@ -246,6 +246,15 @@ func (w Wrapper) WithdrawalFiatFunds(ctx context.Context, _ string, r *withdraw.
 	return "123", nil
 }
 
+// InternalTransfer validator for test execution/scripts
+func (w Wrapper) InternalTransfer(ctx context.Context, r *withdraw.TransferRequest) (out string, err error) {
+	if r.Exchange == exchError.String() {
+		return r.Exchange, errTestFailed
+	}
+
+	return "1337", nil
+}
+
 // OHLCV returns open high low close volume candles for requested exchange/pair/asset/start & end time
 func (w Wrapper) OHLCV(ctx context.Context, exch string, p currency.Pair, a asset.Item, start, end time.Time, i kline.Interval) (kline.Item, error) {
 	if exch == exchError.String() {
@@ -247,6 +247,20 @@ func TestWrapper_WithdrawalFiatFunds(t *testing.T) {
 	}
 }
 
+func TestWrapper_InternalTransfer(t *testing.T) {
+	_, err := testWrapper.InternalTransfer(context.Background(),
+		&withdraw.TransferRequest{Exchange: exchError.String()})
+	if err == nil {
+		t.Fatal("expected InternalTransfer to return error with invalid name")
+	}
+
+	_, err = testWrapper.InternalTransfer(context.Background(),
+		&withdraw.TransferRequest{Exchange: exchName})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestWrapper_OHLCV(t *testing.T) {
 	c, err := currency.NewPairDelimiter(pairs, delimiter)
 	if err != nil {
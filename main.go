@@ -58,6 +58,16 @@ func main() {
 	flag.BoolVar(&settings.EnableNTPClient, "ntpclient", true, "enables the NTP client to check system clock drift")
 	flag.BoolVar(&settings.EnableDispatcher, "dispatch", true, "enables the dispatch system")
 	flag.BoolVar(&settings.EnableCurrencyStateManager, "currencystatemanager", true, "enables the currency state manager")
+	flag.BoolVar(&settings.EnableCandleBuilder, "candlebuilder", false, "enables building candles from live websocket trades")
+	flag.BoolVar(&settings.EnableArbitrageManager, "arbitrage", false, "enables scanning orderbooks for triangular and cross exchange arbitrage")
+	flag.BoolVar(&settings.EnableRebalanceManager, "rebalancer", false, "enables rebalancing holdings towards target portfolio allocations")
+	flag.BoolVar(&settings.EnablePortfolioHistoryManager, "portfoliohistory", false, "enables saving valued portfolio snapshots to the database")
+	flag.BoolVar(&settings.EnableTaxLotManager, "taxlots", false, "enables tracking tax lots and realised capital gains of executed trades")
+	flag.BoolVar(&settings.EnableReconciliationManager, "reconciliation", false, "enables scheduled reconciliation of orders, fills and balances against exchange history")
+	flag.BoolVar(&settings.EnableDeadManSwitch, "deadmanswitch", false, "enables cancelling open orders when connectivity, market data or controller heartbeats are lost")
+	flag.BoolVar(&settings.EnableMetricsManager, "metrics", false, "enables serving exchange, websocket, order and sync metrics in the OpenMetrics format on the REST API server")
+	flag.BoolVar(&settings.EnableTracing, "tracing", false, "enables exporting order submission traces to an OTLP/HTTP collector")
+	flag.BoolVar(&settings.EnableOrderbookRecorder, "orderbookrecorder", false, "enables recording websocket orderbook snapshots and updates to disk")
 	flag.IntVar(&settings.DispatchMaxWorkerAmount, "dispatchworkers", dispatch.DefaultMaxWorkers, "sets the dispatch package max worker generation limit")
 	flag.IntVar(&settings.DispatchJobsLimit, "dispatchjobslimit", dispatch.DefaultJobsLimit, "sets the dispatch package max jobs limit")
 
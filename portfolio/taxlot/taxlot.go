@@ -0,0 +1,564 @@
+package taxlot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// NewTracker returns a tracker which values lots in the report currency. The
+// rate provider is optional and values currencies which are not observed in
+// fills against the report currency.
+func NewTracker(reportCurrency currency.Code, rates RateProvider) (*Tracker, error) {
+	if reportCurrency.IsEmpty() {
+		return nil, errEmptyReportCurrency
+	}
+	return &Tracker{
+		reportCurrency: reportCurrency.Upper(),
+		rates:          rates,
+		fills:          make(map[string]*Fill),
+		transfers:      make(map[string]*Transfer),
+		orderTrades:    make(map[string]bool),
+	}, nil
+}
+
+// ParseMethod returns the lot matching method from a string
+func ParseMethod(m string) (Method, error) {
+	switch Method(strings.ToUpper(m)) {
+	case FIFO:
+		return FIFO, nil
+	case LIFO:
+		return LIFO, nil
+	case HIFO:
+		return HIFO, nil
+	case AverageCost, "AVERAGECOST", "AVERAGE_COST":
+		return AverageCost, nil
+	}
+	return "", fmt.Errorf("%w %s", ErrInvalidMethod, m)
+}
+
+// AddFill records a fill. A fill with the same exchange and ID replaces the
+// existing fill so partially filled orders can be updated. Fills with an ID
+// which differs from their order ID are treated as trade level fills and
+// supersede the order level fill.
+func (t *Tracker) AddFill(f *Fill) error {
+	if f == nil {
+		return errNilFill
+	}
+	id := f.ID
+	if id == "" {
+		id = f.OrderID
+	}
+	if f.Exchange == "" || id == "" || f.Base.IsEmpty() || f.Quote.IsEmpty() ||
+		f.Amount <= 0 || f.Price <= 0 || f.Fee < 0 || f.Timestamp.IsZero() {
+		return fmt.Errorf("%w %+v", errInvalidFill, *f)
+	}
+	cpy := *f
+	cpy.ID = id
+	exch := strings.ToLower(f.Exchange)
+	t.m.Lock()
+	defer t.m.Unlock()
+	if f.OrderID != "" && f.OrderID != id {
+		orderKey := exch + "|" + f.OrderID
+		t.orderTrades[orderKey] = true
+		delete(t.fills, orderKey)
+	} else if t.orderTrades[exch+"|"+id] {
+		// Trade level fills have already been recorded for the order
+		return nil
+	}
+	t.fills[exch+"|"+id] = &cpy
+	return nil
+}
+
+// AddTransfer records a deposit or withdrawal. A transfer with the same
+// exchange, direction and ID replaces the existing transfer.
+func (t *Tracker) AddTransfer(tr *Transfer) error {
+	if tr == nil {
+		return errNilTransfer
+	}
+	id := tr.ID
+	if id == "" {
+		id = tr.TxID
+	}
+	if tr.Exchange == "" || id == "" || tr.Currency.IsEmpty() || tr.Amount <= 0 ||
+		tr.Fee < 0 || tr.Timestamp.IsZero() ||
+		(tr.Direction != TransferIn && tr.Direction != TransferOut) {
+		return fmt.Errorf("%w %+v", errInvalidTransfer, *tr)
+	}
+	cpy := *tr
+	cpy.ID = id
+	t.m.Lock()
+	t.transfers[strings.ToLower(tr.Exchange)+"|"+string(tr.Direction)+"|"+id] = &cpy
+	t.m.Unlock()
+	return nil
+}
+
+// GetLots returns the lots held after replaying all fills and transfers,
+// including lots withdrawn without a matching deposit
+func (t *Tracker) GetLots(method Method) ([]Lot, error) {
+	l, err := t.replay(method)
+	if err != nil {
+		return nil, err
+	}
+	var resp []Lot
+	for _, lots := range l.lots {
+		for x := range lots {
+			resp = append(resp, *lots[x])
+		}
+	}
+	for x := range l.pending {
+		for y := range l.pending[x].lots {
+			lot := *l.pending[x].lots[y]
+			lot.InTransit = true
+			resp = append(resp, lot)
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if !resp[i].Acquired.Equal(resp[j].Acquired) {
+			return resp[i].Acquired.Before(resp[j].Acquired)
+		}
+		return resp[i].ID < resp[j].ID
+	})
+	return resp, nil
+}
+
+// GenerateReport replays all fills and transfers and returns the realised
+// gains of disposals within a calendar year in UTC
+func (t *Tracker) GenerateReport(year int, method Method) (*Report, error) {
+	l, err := t.replay(method)
+	if err != nil {
+		return nil, err
+	}
+	r := &Report{
+		Year:           year,
+		Method:         method,
+		ReportCurrency: t.reportCurrency,
+	}
+	summaries := make(map[*currency.Item]*CurrencySummary)
+	for x := range l.gains {
+		g := &l.gains[x]
+		if g.Disposed.UTC().Year() != year {
+			continue
+		}
+		r.Gains = append(r.Gains, *g)
+		s, ok := summaries[g.Currency.Item]
+		if !ok {
+			s = &CurrencySummary{Currency: g.Currency}
+			summaries[g.Currency.Item] = s
+		}
+		s.Amount += g.Amount
+		s.Proceeds += g.Proceeds
+		s.CostBasis += g.CostBasis
+		s.Gain += g.Gain
+		if g.Term == LongTerm {
+			s.LongTermGain += g.Gain
+		} else {
+			s.ShortTermGain += g.Gain
+		}
+	}
+	for _, s := range summaries {
+		r.Proceeds += s.Proceeds
+		r.CostBasis += s.CostBasis
+		r.Gain += s.Gain
+		r.ShortTermGain += s.ShortTermGain
+		r.LongTermGain += s.LongTermGain
+		r.Currencies = append(r.Currencies, *s)
+	}
+	sort.Slice(r.Currencies, func(i, j int) bool {
+		return r.Currencies[i].Currency.String() < r.Currencies[j].Currency.String()
+	})
+	for x := range l.warnings {
+		if l.warnings[x].timestamp.UTC().Year() == year {
+			r.Warnings = append(r.Warnings, l.warnings[x].message)
+		}
+	}
+	return r, nil
+}
+
+// Write writes the report in CSV or JSON format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return r.WriteCSV(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	}
+	return fmt.Errorf("%w %s", errInvalidFormat, format)
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(r, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// WriteCSV writes a row for each gain followed by a total row
+func (r *Report) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	err := c.Write([]string{"exchange", "currency", "amount", "acquired", "disposed",
+		"proceeds", "cost_basis", "gain", "term", "lot_id", "disposal_id"})
+	if err != nil {
+		return err
+	}
+	for x := range r.Gains {
+		g := &r.Gains[x]
+		var acquired string
+		if !g.Acquired.IsZero() {
+			acquired = g.Acquired.UTC().Format(time.RFC3339)
+		}
+		err = c.Write([]string{
+			g.Exchange,
+			g.Currency.String(),
+			formatFloat(g.Amount),
+			acquired,
+			g.Disposed.UTC().Format(time.RFC3339),
+			formatFloat(g.Proceeds),
+			formatFloat(g.CostBasis),
+			formatFloat(g.Gain),
+			string(g.Term),
+			g.LotID,
+			g.DisposalID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err = c.Write([]string{"total", r.ReportCurrency.String(), "", "", "",
+		formatFloat(r.Proceeds), formatFloat(r.CostBasis), formatFloat(r.Gain), "", "", ""})
+	if err != nil {
+		return err
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// replay processes all fills and transfers in chronological order
+func (t *Tracker) replay(method Method) (*ledger, error) {
+	if _, err := ParseMethod(string(method)); err != nil {
+		return nil, err
+	}
+	t.m.Lock()
+	events := make([]event, 0, len(t.fills)+len(t.transfers))
+	for k, f := range t.fills {
+		events = append(events, event{timestamp: f.Timestamp, key: k, fill: f})
+	}
+	for k, tr := range t.transfers {
+		events = append(events, event{timestamp: tr.Timestamp, key: k, transfer: tr})
+	}
+	t.m.Unlock()
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].timestamp.Equal(events[j].timestamp) {
+			return events[i].timestamp.Before(events[j].timestamp)
+		}
+		return events[i].key < events[j].key
+	})
+
+	l := &ledger{
+		method: method,
+		report: t.reportCurrency,
+		rates:  t.rates,
+		lots:   make(map[ledgerKey][]*Lot),
+		prices: make(map[*currency.Item]float64),
+	}
+	for x := range events {
+		if events[x].fill != nil {
+			l.processFill(events[x].fill)
+			continue
+		}
+		if events[x].transfer.Direction == TransferOut {
+			l.withdraw(events[x].transfer)
+		} else {
+			l.deposit(events[x].transfer)
+		}
+	}
+	return l, nil
+}
+
+// tracked returns whether lots are held for a currency. The report currency
+// and fiat currencies are not tracked.
+func (l *ledger) tracked(c currency.Code) bool {
+	return !c.Equal(l.report) && !c.IsFiatCurrency()
+}
+
+// rate returns the value of a currency in the report currency, preferring the
+// last price observed in a fill
+func (l *ledger) rate(c currency.Code, at time.Time) (float64, error) {
+	if c.Equal(l.report) {
+		return 1, nil
+	}
+	if price, ok := l.prices[c.Item]; ok {
+		return price, nil
+	}
+	if l.rates == nil {
+		return 0, fmt.Errorf("no rate for %s in %s", c, l.report)
+	}
+	return l.rates.GetRate(c, at)
+}
+
+func (l *ledger) warn(at time.Time, format string, args ...interface{}) {
+	l.warnings = append(l.warnings, warning{
+		timestamp: at,
+		message:   at.UTC().Format(time.RFC3339) + " " + fmt.Sprintf(format, args...),
+	})
+}
+
+// processFill acquires and disposes of the base and quote currencies of a
+// fill. Fees charged in the received currency reduce the amount received,
+// while other fees are added to the cost of a purchase or deducted from the
+// proceeds of a sale.
+func (l *ledger) processFill(f *Fill) {
+	base, quote := f.Base.Upper(), f.Quote.Upper()
+	quoteRate, err := l.rate(quote, f.Timestamp)
+	if err != nil {
+		l.warn(f.Timestamp, "%s fill %s cannot be valued: %v", f.Exchange, f.ID, err)
+		return
+	}
+	if !base.Equal(l.report) && !base.IsFiatCurrency() {
+		l.prices[base.Item] = f.Price * quoteRate
+	}
+	value := f.Amount * f.Price * quoteRate
+	quoteAmount := f.Amount * f.Price
+
+	feeAsset := f.FeeAsset.Upper()
+	if feeAsset.IsEmpty() {
+		feeAsset = quote
+	}
+	var baseFee, quoteFee, otherFeeValue float64
+	switch {
+	case f.Fee == 0:
+	case feeAsset.Equal(base):
+		baseFee = f.Fee
+	case feeAsset.Equal(quote):
+		quoteFee = f.Fee
+	default:
+		feeRate, err := l.rate(feeAsset, f.Timestamp)
+		if err != nil {
+			l.warn(f.Timestamp, "%s fill %s fee cannot be valued: %v", f.Exchange, f.ID, err)
+		}
+		otherFeeValue = f.Fee * feeRate
+		if l.tracked(feeAsset) {
+			l.dispose(f.Exchange, feeAsset, f.Fee, otherFeeValue, f.Timestamp, f.ID)
+		}
+	}
+
+	if f.Buy {
+		if l.tracked(base) {
+			l.acquire(f.Exchange, base, f.Amount-baseFee, value+quoteFee*quoteRate+otherFeeValue, f.Timestamp)
+		}
+		if l.tracked(quote) {
+			l.dispose(f.Exchange, quote, quoteAmount+quoteFee, (quoteAmount+quoteFee)*quoteRate, f.Timestamp, f.ID)
+		}
+		return
+	}
+	if l.tracked(base) {
+		l.dispose(f.Exchange, base, f.Amount+baseFee, value-quoteFee*quoteRate-otherFeeValue, f.Timestamp, f.ID)
+	}
+	if l.tracked(quote) {
+		l.acquire(f.Exchange, quote, quoteAmount-quoteFee, (quoteAmount-quoteFee)*quoteRate, f.Timestamp)
+	}
+}
+
+// acquire adds a lot
+func (l *ledger) acquire(exch string, c currency.Code, amount, cost float64, at time.Time) {
+	if amount <= 0 {
+		return
+	}
+	l.nextLot++
+	key := ledgerKey{exchange: strings.ToLower(exch), currency: c.Item}
+	l.lots[key] = append(l.lots[key], &Lot{
+		ID:             strconv.Itoa(l.nextLot),
+		Exchange:       exch,
+		Currency:       c,
+		Acquired:       at,
+		OriginalAmount: amount,
+		Amount:         amount,
+		UnitCost:       cost / amount,
+	})
+}
+
+// dispose matches an amount against the lots held and records the gains
+func (l *ledger) dispose(exch string, c currency.Code, amount, proceeds float64, at time.Time, disposalID string) {
+	if amount <= 0 {
+		return
+	}
+	pieces, remaining := l.take(exch, c, amount)
+	for x := range pieces {
+		share := proceeds * pieces[x].Amount / amount
+		cost := pieces[x].Amount * pieces[x].UnitCost
+		g := Gain{
+			Exchange:   exch,
+			Currency:   c,
+			Amount:     pieces[x].Amount,
+			Acquired:   pieces[x].Acquired,
+			Disposed:   at,
+			Proceeds:   share,
+			CostBasis:  cost,
+			Gain:       share - cost,
+			Term:       ShortTerm,
+			LotID:      pieces[x].ID,
+			DisposalID: disposalID,
+		}
+		if at.Sub(pieces[x].Acquired) > LongTermHoldingPeriod {
+			g.Term = LongTerm
+		}
+		l.gains = append(l.gains, g)
+	}
+	if remaining > amountTolerance {
+		l.warn(at, "%s disposal %s of %v %s exceeds lots held by %v, costed at zero", exch, disposalID, amount, c, remaining)
+		share := proceeds * remaining / amount
+		l.gains = append(l.gains, Gain{
+			Exchange:   exch,
+			Currency:   c,
+			Amount:     remaining,
+			Disposed:   at,
+			Proceeds:   share,
+			Gain:       share,
+			Term:       ShortTerm,
+			DisposalID: disposalID,
+		})
+	}
+}
+
+// take removes an amount from the lots held in the order of the matching
+// method and returns the pieces removed along with any amount not held
+func (l *ledger) take(exch string, c currency.Code, amount float64) (pieces []Lot, remaining float64) {
+	key := ledgerKey{exchange: strings.ToLower(exch), currency: c.Item}
+	lots := l.lots[key]
+	remaining = amount
+	if l.method == AverageCost {
+		var held float64
+		for x := range lots {
+			held += lots[x].Amount
+		}
+		ratio := 1.0
+		if held > amount {
+			ratio = amount / held
+		}
+		for x := range lots {
+			piece := *lots[x]
+			piece.Amount = lots[x].Amount * ratio
+			lots[x].Amount -= piece.Amount
+			remaining -= piece.Amount
+			pieces = append(pieces, piece)
+		}
+	} else {
+		sort.SliceStable(lots, func(i, j int) bool {
+			switch l.method {
+			case LIFO:
+				return lots[i].Acquired.After(lots[j].Acquired)
+			case HIFO:
+				if lots[i].UnitCost != lots[j].UnitCost {
+					return lots[i].UnitCost > lots[j].UnitCost
+				}
+			}
+			return lots[i].Acquired.Before(lots[j].Acquired)
+		})
+		for x := 0; x < len(lots) && remaining > amountTolerance; x++ {
+			piece := *lots[x]
+			piece.Amount = math.Min(lots[x].Amount, remaining)
+			lots[x].Amount -= piece.Amount
+			remaining -= piece.Amount
+			pieces = append(pieces, piece)
+		}
+	}
+	held := lots[:0]
+	for x := range lots {
+		if lots[x].Amount > amountTolerance {
+			held = append(held, lots[x])
+		}
+	}
+	if len(held) == 0 {
+		delete(l.lots, key)
+	} else {
+		l.lots[key] = held
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return pieces, remaining
+}
+
+// withdraw removes the lots of a withdrawal and its fee from an exchange and
+// holds them until a matching deposit is recorded. The cost of the fee is
+// added to the lots withdrawn.
+func (l *ledger) withdraw(tr *Transfer) {
+	c := tr.Currency.Upper()
+	if !l.tracked(c) {
+		return
+	}
+	total := tr.Amount + tr.Fee
+	pieces, remaining := l.take(tr.Exchange, c, total)
+	if remaining > amountTolerance {
+		l.warn(tr.Timestamp, "%s withdrawal %s of %v %s exceeds lots held by %v", tr.Exchange, tr.ID, total, c, remaining)
+	}
+	p := &pendingTransfer{transfer: tr}
+	for x := range pieces {
+		lot := pieces[x]
+		lot.Amount = pieces[x].Amount * tr.Amount / total
+		lot.UnitCost = pieces[x].UnitCost * total / tr.Amount
+		p.lots = append(p.lots, &lot)
+	}
+	l.pending = append(l.pending, p)
+}
+
+// deposit moves the lots of a matching withdrawal to the exchange, otherwise
+// the deposit is acquired at its value
+func (l *ledger) deposit(tr *Transfer) {
+	c := tr.Currency.Upper()
+	if !l.tracked(c) {
+		return
+	}
+	match := -1
+	for x := range l.pending {
+		w := l.pending[x].transfer
+		if tr.TxID != "" && w.TxID != "" {
+			if tr.TxID == w.TxID {
+				match = x
+				break
+			}
+			continue
+		}
+		if w.Currency.Equal(c) &&
+			!strings.EqualFold(w.Exchange, tr.Exchange) &&
+			math.Abs(w.Amount-tr.Amount) <= w.Amount*transferTolerance {
+			match = x
+			break
+		}
+	}
+	if match == -1 {
+		rate, err := l.rate(c, tr.Timestamp)
+		if err != nil {
+			l.warn(tr.Timestamp, "%s deposit %s of %v %s has no matching withdrawal and cannot be valued: %v", tr.Exchange, tr.ID, tr.Amount, c, err)
+		}
+		l.acquire(tr.Exchange, c, tr.Amount, tr.Amount*rate, tr.Timestamp)
+		return
+	}
+	p := l.pending[match]
+	l.pending = append(l.pending[:match], l.pending[match+1:]...)
+	key := ledgerKey{exchange: strings.ToLower(tr.Exchange), currency: c.Item}
+	// Any difference in the amount received adjusts the unit cost so the
+	// cost basis is carried over in full
+	ratio := tr.Amount / p.transfer.Amount
+	for x := range p.lots {
+		p.lots[x].Exchange = tr.Exchange
+		p.lots[x].Amount *= ratio
+		p.lots[x].UnitCost /= ratio
+		l.lots[key] = append(l.lots[key], p.lots[x])
+	}
+}
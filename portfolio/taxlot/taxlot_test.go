@@ -0,0 +1,367 @@
+package taxlot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+type fakeRates map[*currency.Item]float64
+
+func (f fakeRates) GetRate(c currency.Code, _ time.Time) (float64, error) {
+	rate, ok := f[c.Item]
+	if !ok {
+		return 0, errors.New("no rate")
+	}
+	return rate, nil
+}
+
+var start = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fill(id string, buy bool, amount, price float64, at time.Time) *Fill {
+	return &Fill{
+		Exchange:  "one",
+		ID:        id,
+		Base:      currency.BTC,
+		Quote:     currency.USD,
+		Buy:       buy,
+		Amount:    amount,
+		Price:     price,
+		Timestamp: at,
+	}
+}
+
+// setupTracker buys 1 BTC at 10000, 1 BTC at 30000 and 1 BTC at 20000 then
+// sells 1.5 BTC at 40000 more than a year after the first purchase
+func setupTracker(t *testing.T) *Tracker {
+	t.Helper()
+	tr, err := NewTracker(currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fills := []*Fill{
+		fill("1", true, 1, 10000, start),
+		fill("2", true, 1, 30000, start.AddDate(0, 6, 0)),
+		fill("3", true, 1, 20000, start.AddDate(0, 9, 0)),
+		fill("4", false, 1.5, 40000, start.AddDate(1, 1, 0)),
+	}
+	for x := range fills {
+		if err = tr.AddFill(fills[x]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tr
+}
+
+func TestNewTracker(t *testing.T) {
+	t.Parallel()
+	_, err := NewTracker(currency.EMPTYCODE, nil)
+	if !errors.Is(err, errEmptyReportCurrency) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errEmptyReportCurrency)
+	}
+	tr, err := NewTracker(currency.USD, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = tr.AddFill(nil)
+	if !errors.Is(err, errNilFill) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilFill)
+	}
+	err = tr.AddFill(&Fill{Exchange: "one", ID: "1"})
+	if !errors.Is(err, errInvalidFill) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidFill)
+	}
+	err = tr.AddTransfer(nil)
+	if !errors.Is(err, errNilTransfer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilTransfer)
+	}
+	err = tr.AddTransfer(&Transfer{Exchange: "one", ID: "1", Currency: currency.BTC, Amount: 1, Timestamp: start})
+	if !errors.Is(err, errInvalidTransfer) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTransfer)
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"fifo", "LIFO", "hifo", "average"} {
+		if _, err := ParseMethod(s); err != nil {
+			t.Errorf("%s received: '%v' but expected: '%v'", s, err, nil)
+		}
+	}
+	_, err := ParseMethod("random")
+	if !errors.Is(err, ErrInvalidMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrInvalidMethod)
+	}
+}
+
+func TestGenerateReport(t *testing.T) {
+	t.Parallel()
+	tr := setupTracker(t)
+	_, err := tr.GenerateReport(2022, "random")
+	if !errors.Is(err, ErrInvalidMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrInvalidMethod)
+	}
+	testCases := []struct {
+		method    Method
+		costBasis float64
+		longTerm  float64
+		gains     int
+	}{
+		// 1 BTC at 10000 and 0.5 BTC at 30000
+		{method: FIFO, costBasis: 25000, longTerm: 30000, gains: 2},
+		// 1 BTC at 20000 and 0.5 BTC at 30000
+		{method: LIFO, costBasis: 35000, gains: 2},
+		// 1 BTC at 30000 and 0.5 BTC at 20000
+		{method: HIFO, costBasis: 40000, gains: 2},
+		// 1.5 BTC at an average of 20000
+		{method: AverageCost, costBasis: 30000, longTerm: 15000, gains: 3},
+	}
+	for x := range testCases {
+		tc := testCases[x]
+		t.Run(string(tc.method), func(t *testing.T) {
+			t.Parallel()
+			r, err := tr.GenerateReport(2022, tc.method)
+			if !errors.Is(err, nil) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+			}
+			if len(r.Gains) != tc.gains {
+				t.Fatalf("received: '%v' but expected: '%v' %+v", len(r.Gains), tc.gains, r.Gains)
+			}
+			if math.Abs(r.Proceeds-60000) > 1e-6 ||
+				math.Abs(r.CostBasis-tc.costBasis) > 1e-6 ||
+				math.Abs(r.Gain-(60000-tc.costBasis)) > 1e-6 ||
+				math.Abs(r.LongTermGain-tc.longTerm) > 1e-6 {
+				t.Fatalf("unexpected report %+v", r)
+			}
+			if len(r.Currencies) != 1 || !r.Currencies[0].Currency.Equal(currency.BTC) {
+				t.Fatalf("unexpected currencies %+v", r.Currencies)
+			}
+		})
+	}
+
+	r, err := tr.GenerateReport(2021, FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(r.Gains) != 0 || r.Gain != 0 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestFillUpdates(t *testing.T) {
+	t.Parallel()
+	tr, err := NewTracker(currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A partially filled order is replaced when updated
+	f := fill("", true, 0.5, 10000, start)
+	f.OrderID = "order"
+	if err = tr.AddFill(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Amount = 1
+	if err = tr.AddFill(f); err != nil {
+		t.Fatal(err)
+	}
+	lots, err := tr.GetLots(FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(lots) != 1 || lots[0].Amount != 1 {
+		t.Fatalf("unexpected lots %+v", lots)
+	}
+	// Trade level fills supersede the order level fill
+	for _, id := range []string{"t1", "t2"} {
+		trade := fill(id, true, 0.5, 10000, start)
+		trade.OrderID = "order"
+		if err = tr.AddFill(trade); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tr.AddFill(f); err != nil {
+		t.Fatal(err)
+	}
+	lots, err = tr.GetLots(FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(lots) != 2 || lots[0].Amount+lots[1].Amount != 1 {
+		t.Fatalf("unexpected lots %+v", lots)
+	}
+}
+
+func TestFees(t *testing.T) {
+	t.Parallel()
+	tr, err := NewTracker(currency.USD, fakeRates{currency.BNB.Item: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fee in the base currency reduces the amount acquired
+	buy := fill("1", true, 1, 10000, start)
+	buy.Fee, buy.FeeAsset = 0.01, currency.BTC
+	// Fee in the quote currency reduces the proceeds
+	sell := fill("2", false, 0.5, 20000, start.Add(time.Hour))
+	sell.Fee = 10
+	// Fee in another currency is disposed of and reduces the proceeds
+	bnb := fill("3", true, 1, 100, start)
+	bnb.Base = currency.BNB
+	sellBNBFee := fill("4", false, 0.49, 20000, start.Add(time.Hour*2))
+	sellBNBFee.Fee, sellBNBFee.FeeAsset = 0.1, currency.BNB
+	for _, f := range []*Fill{buy, sell, bnb, sellBNBFee} {
+		if err = tr.AddFill(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := tr.GenerateReport(2021, FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(r.Warnings) != 0 {
+		t.Fatalf("unexpected warnings %v", r.Warnings)
+	}
+	unitCost := 10000 / 0.99
+	expected := map[string][2]float64{
+		"BTCProceeds":  {r.Currencies[1].Proceeds, 9990 + 9790},
+		"BTCCostBasis": {r.Currencies[1].CostBasis, 0.99 * unitCost},
+		"BTCAmount":    {r.Currencies[1].Amount, 0.99},
+		"BNBAmount":    {r.Currencies[0].Amount, 0.1},
+		"BNBGain":      {r.Currencies[0].Gain, 0},
+	}
+	for k, v := range expected {
+		if math.Abs(v[0]-v[1]) > 1e-6 {
+			t.Errorf("%s received: '%v' but expected: '%v'", k, v[0], v[1])
+		}
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	t.Parallel()
+	tr, err := NewTracker(currency.USD, fakeRates{currency.ETH.Item: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.AddFill(fill("1", true, 1, 10000, start)); err != nil {
+		t.Fatal(err)
+	}
+	transfers := []*Transfer{
+		// 0.5 BTC withdrawn with a fee of 0.1 BTC and received on another
+		// exchange matched by amount
+		{Exchange: "one", ID: "w1", Direction: TransferOut, Currency: currency.BTC, Amount: 0.5, Fee: 0.1, Timestamp: start.Add(time.Hour)},
+		{Exchange: "two", ID: "d1", Direction: TransferIn, Currency: currency.BTC, Amount: 0.5, Timestamp: start.Add(time.Hour * 2)},
+		// 0.2 BTC withdrawn to an external wallet
+		{Exchange: "one", ID: "w2", TxID: "0x1", Direction: TransferOut, Currency: currency.BTC, Amount: 0.2, Timestamp: start.Add(time.Hour * 3)},
+		// An unmatched deposit is acquired at its value
+		{Exchange: "two", ID: "d2", TxID: "0x2", Direction: TransferIn, Currency: currency.ETH, Amount: 1, Timestamp: start.Add(time.Hour * 4)},
+	}
+	for x := range transfers {
+		if err = tr.AddTransfer(transfers[x]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sell := fill("2", false, 0.5, 20000, start.Add(time.Hour*5))
+	sell.Exchange = "two"
+	if err = tr.AddFill(sell); err != nil {
+		t.Fatal(err)
+	}
+
+	lots, err := tr.GetLots(FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(lots) != 3 {
+		t.Fatalf("received: '%v' but expected: '%v' %+v", len(lots), 3, lots)
+	}
+	for x := range lots {
+		switch {
+		case lots[x].Currency.Equal(currency.ETH):
+			if lots[x].Exchange != "two" || lots[x].UnitCost != 2000 {
+				t.Errorf("unexpected ETH lot %+v", lots[x])
+			}
+		case lots[x].InTransit:
+			if math.Abs(lots[x].Amount-0.2) > 1e-9 || lots[x].UnitCost != 10000 {
+				t.Errorf("unexpected in transit lot %+v", lots[x])
+			}
+		default:
+			if lots[x].Exchange != "one" || math.Abs(lots[x].Amount-0.2) > 1e-9 {
+				t.Errorf("unexpected lot %+v", lots[x])
+			}
+		}
+	}
+
+	r, err := tr.GenerateReport(2021, FIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// The fee cost is carried by the transferred lot
+	if len(r.Gains) != 1 || r.Gains[0].Exchange != "two" ||
+		math.Abs(r.CostBasis-6000) > 1e-6 || math.Abs(r.Gain-4000) > 1e-6 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestExcessDisposal(t *testing.T) {
+	t.Parallel()
+	tr, err := NewTracker(currency.USD, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.AddFill(fill("1", false, 1, 10000, start)); err != nil {
+		t.Fatal(err)
+	}
+	eth := fill("2", true, 1, 0.05, start)
+	eth.Base, eth.Quote = currency.ETH, currency.XRP
+	if err = tr.AddFill(eth); err != nil {
+		t.Fatal(err)
+	}
+	r, err := tr.GenerateReport(2021, HIFO)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(r.Warnings) != 2 || len(r.Gains) != 1 || r.Gain != 10000 || r.Gains[0].LotID != "" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestReportWrite(t *testing.T) {
+	t.Parallel()
+	r, err := setupTracker(t).GenerateReport(2022, FIFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err = r.Write(&buf, "xml")
+	if !errors.Is(err, errInvalidFormat) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidFormat)
+	}
+	err = r.Write(&buf, FormatCSV)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || rows[1][8] != string(LongTerm) || rows[3][0] != "total" || rows[3][7] != "35000" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+	buf.Reset()
+	err = r.Write(&buf, FormatJSON)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var decoded Report
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Gain != 35000 || len(decoded.Gains) != 2 || !decoded.ReportCurrency.Equal(currency.USD) {
+		t.Fatalf("unexpected report %+v", decoded)
+	}
+}
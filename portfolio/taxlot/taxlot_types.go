@@ -0,0 +1,227 @@
+package taxlot
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Lot matching methods
+const (
+	// FIFO disposes of the earliest acquired lots first
+	FIFO Method = "FIFO"
+	// LIFO disposes of the latest acquired lots first
+	LIFO Method = "LIFO"
+	// HIFO disposes of the lots with the highest unit cost first
+	HIFO Method = "HIFO"
+	// AverageCost disposes of all lots held pro rata so each disposal is
+	// costed at the average unit cost
+	AverageCost Method = "AVERAGE"
+)
+
+// Transfer directions
+const (
+	TransferIn  TransferDirection = "IN"
+	TransferOut TransferDirection = "OUT"
+)
+
+// Gain terms
+const (
+	ShortTerm Term = "SHORT"
+	LongTerm  Term = "LONG"
+)
+
+// Report formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// LongTermHoldingPeriod is the holding period after which a gain is long term
+const LongTermHoldingPeriod = time.Hour * 24 * 365
+
+const (
+	// amountTolerance is the remainder below which a lot is considered fully
+	// disposed
+	amountTolerance = 1e-9
+	// transferTolerance is the relative difference allowed between a
+	// withdrawal and deposit amount when matching transfers
+	transferTolerance = 0.005
+)
+
+var (
+	// ErrInvalidMethod is returned when a lot matching method is not supported
+	ErrInvalidMethod = errors.New("invalid lot matching method")
+
+	errNilFill             = errors.New("nil fill received")
+	errNilTransfer         = errors.New("nil transfer received")
+	errEmptyReportCurrency = errors.New("report currency is empty")
+	errInvalidFill         = errors.New("invalid fill")
+	errInvalidTransfer     = errors.New("invalid transfer")
+	errInvalidFormat       = errors.New("invalid report format")
+)
+
+// Method defines how lots are matched against disposals
+type Method string
+
+// TransferDirection defines whether holdings are transferred into or out of an
+// exchange
+type TransferDirection string
+
+// Term defines whether a gain is short or long term
+type Term string
+
+// RateProvider values a currency in the report currency at a point in time.
+// Rates observed from fills against the report currency take precedence.
+type RateProvider interface {
+	GetRate(c currency.Code, at time.Time) (float64, error)
+}
+
+// Fill defines an executed spot trade of a base currency against a quote
+// currency. Fees are charged in the fee currency, defaulting to the quote
+// currency.
+type Fill struct {
+	Exchange  string
+	ID        string
+	OrderID   string
+	Base      currency.Code
+	Quote     currency.Code
+	Buy       bool
+	Amount    float64
+	Price     float64
+	Fee       float64
+	FeeAsset  currency.Code
+	Timestamp time.Time
+}
+
+// Transfer defines holdings moved into or out of an exchange. Withdrawals are
+// matched with deposits by transaction ID, or otherwise by currency and
+// amount, so lots keep their acquisition date and cost basis. The fee of a
+// withdrawal is deducted in addition to the amount.
+type Transfer struct {
+	Exchange  string
+	ID        string
+	TxID      string
+	Direction TransferDirection
+	Currency  currency.Code
+	Amount    float64
+	Fee       float64
+	Timestamp time.Time
+}
+
+// Lot defines an amount of a currency acquired at a unit cost
+type Lot struct {
+	ID             string
+	Exchange       string
+	Currency       currency.Code
+	Acquired       time.Time
+	OriginalAmount float64
+	Amount         float64
+	// UnitCost is denominated in the report currency and includes fees
+	UnitCost float64
+	// InTransit is set when the lot was withdrawn from the exchange and no
+	// matching deposit has been recorded
+	InTransit bool
+}
+
+// Gain defines the realised gain of a disposal. Values are denominated in the
+// report currency.
+type Gain struct {
+	Exchange  string
+	Currency  currency.Code
+	Amount    float64
+	Acquired  time.Time
+	Disposed  time.Time
+	Proceeds  float64
+	CostBasis float64
+	Gain      float64
+	Term      Term
+	// LotID is the lot disposed of, empty when the disposal exceeded the lots
+	// held
+	LotID string
+	// DisposalID is the fill which disposed of the lot
+	DisposalID string
+}
+
+// CurrencySummary defines the realised gains of a currency within a report
+type CurrencySummary struct {
+	Currency      currency.Code
+	Amount        float64
+	Proceeds      float64
+	CostBasis     float64
+	Gain          float64
+	ShortTermGain float64
+	LongTermGain  float64
+}
+
+// Report defines the realised gains of disposals within a calendar year
+type Report struct {
+	Year           int
+	Method         Method
+	ReportCurrency currency.Code
+	Proceeds       float64
+	CostBasis      float64
+	Gain           float64
+	ShortTermGain  float64
+	LongTermGain   float64
+	Currencies     []CurrencySummary
+	Gains          []Gain
+	// Warnings lists events which could not be fully accounted for, such as
+	// disposals exceeding the lots held or currencies without a rate
+	Warnings []string
+}
+
+// Tracker records fills and transfers and replays them in chronological order
+// to match disposals against acquired lots
+type Tracker struct {
+	reportCurrency currency.Code
+	rates          RateProvider
+	fills          map[string]*Fill
+	transfers      map[string]*Transfer
+	// orderTrades tracks orders with trade level fills, which supersede
+	// order level fills
+	orderTrades map[string]bool
+	m           sync.Mutex
+}
+
+// ledger holds the state of a replay
+type ledger struct {
+	method   Method
+	report   currency.Code
+	rates    RateProvider
+	lots     map[ledgerKey][]*Lot
+	pending  []*pendingTransfer
+	prices   map[*currency.Item]float64
+	gains    []Gain
+	warnings []warning
+	nextLot  int
+}
+
+// warning is an event which could not be fully accounted for
+type warning struct {
+	timestamp time.Time
+	message   string
+}
+
+// ledgerKey identifies the lots of a currency held at an exchange
+type ledgerKey struct {
+	exchange string
+	currency *currency.Item
+}
+
+// pendingTransfer holds lots withdrawn from an exchange until a matching
+// deposit is recorded
+type pendingTransfer struct {
+	transfer *Transfer
+	lots     []*Lot
+}
+
+// event is a fill or transfer ordered by time when replayed
+type event struct {
+	timestamp time.Time
+	key       string
+	fill      *Fill
+	transfer  *Transfer
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/validate"
 )
 
@@ -81,3 +82,32 @@ func (r *Request) validateCrypto() []string {
 	}
 	return resp
 }
+
+// Validate checks the transfer request meets the requirements to submit
+func (r *TransferRequest) Validate() error {
+	if r == nil {
+		return ErrRequestCannotBeNil
+	}
+	if r.Exchange == "" {
+		return ErrExchangeNameUnset
+	}
+
+	var allErrors []string
+	if r.Amount <= 0 {
+		allErrors = append(allErrors, ErrStrAmountMustBeGreaterThanZero)
+	}
+	if r.Currency.Equal(currency.EMPTYCODE) {
+		allErrors = append(allErrors, ErrStrNoCurrencySet)
+	}
+	if !r.From.IsValid() || !r.To.IsValid() {
+		allErrors = append(allErrors, asset.ErrNotSupported.Error())
+	}
+	if r.From == r.To && strings.EqualFold(r.FromSubAccount, r.ToSubAccount) {
+		allErrors = append(allErrors, ErrTransferSameWallet.Error())
+	}
+
+	if len(allErrors) > 0 {
+		return errors.New(strings.Join(allErrors, ", "))
+	}
+	return nil
+}
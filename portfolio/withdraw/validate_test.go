@@ -8,6 +8,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/validate"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
@@ -320,3 +321,38 @@ func TestValidateCrypto(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTransfer(t *testing.T) {
+	t.Parallel()
+	var r *TransferRequest
+	if err := r.Validate(); !errors.Is(err, ErrRequestCannotBeNil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrRequestCannotBeNil)
+	}
+	r = &TransferRequest{}
+	if err := r.Validate(); !errors.Is(err, ErrExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrExchangeNameUnset)
+	}
+	r.Exchange = "test"
+	r.From = asset.Spot
+	r.To = asset.Spot
+	err := r.Validate()
+	expected := ErrStrAmountMustBeGreaterThanZero + ", " + ErrStrNoCurrencySet + ", " + ErrTransferSameWallet.Error()
+	if err == nil || err.Error() != expected {
+		t.Fatalf("received: '%v' but expected: '%v'", err, expected)
+	}
+	r.Amount = 1
+	r.Currency = currency.USDT
+	r.ToSubAccount = "sub"
+	if err = r.Validate(); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r.ToSubAccount = ""
+	r.To = asset.Empty
+	if err = r.Validate(); err == nil || err.Error() != asset.ErrNotSupported.Error() {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	r.To = asset.USDTMarginedFutures
+	if err = r.Validate(); err != nil {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
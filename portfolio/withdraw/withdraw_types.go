@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common/cache"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 )
 
@@ -48,6 +49,12 @@ var (
 	ErrStrAddressNotWhiteListed = errors.New("address is not whitelisted for withdrawals")
 	// ErrStrExchangeNotSupportedByAddress message to return when attemptign to withdraw to an unsupported exchange
 	ErrStrExchangeNotSupportedByAddress = errors.New("address is not supported by exchange")
+	// ErrTransferSameWallet message to return when a transfer source and
+	// destination are the same wallet
+	ErrTransferSameWallet = errors.New("transfer source and destination cannot be the same wallet")
+	// ErrTransferWalletUnsupported message to return when an exchange cannot
+	// transfer to or from a wallet
+	ErrTransferWalletUnsupported = errors.New("transfer wallet not supported")
 	// CacheSize cache size to use for withdrawal request history
 	CacheSize uint64 = 25
 	// Cache LRU cache for recent requests
@@ -120,3 +127,18 @@ type ExchangeResponse struct {
 	ID     string `json:"id"`
 	Status string `json:"status"`
 }
+
+// TransferRequest holds the details of an internal transfer between the
+// asset wallets and sub-accounts of an exchange. An empty sub-account refers
+// to the account of the credentials used
+type TransferRequest struct {
+	Exchange       string        `json:"exchange"`
+	Currency       currency.Code `json:"currency"`
+	Amount         float64       `json:"amount"`
+	From           asset.Item    `json:"from"`
+	To             asset.Item    `json:"to"`
+	FromSubAccount string        `json:"fromSubAccount"`
+	ToSubAccount   string        `json:"toSubAccount"`
+	// Symbol is required by exchanges with isolated margin wallets per pair
+	Symbol currency.Pair `json:"symbol"`
+}
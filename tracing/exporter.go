@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// export sends spans to the collector in the OTLP/HTTP JSON encoding
+func (t *Tracer) export(spans []*Span) error {
+	data := make([]spanData, len(spans))
+	for i := range spans {
+		data[i] = spans[i].toOTLP()
+	}
+	payload, err := json.Marshal(exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{Attributes: []keyValue{toKeyValue(String("service.name", t.serviceName))}},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: instrumentationScope},
+				Spans: data,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector responded with status %s: %s", resp.Status, body)
+	}
+	return nil
+}
+
+// toOTLP converts an ended span to its OTLP representation
+func (s *Span) toOTLP() spanData {
+	s.m.Lock()
+	defer s.m.Unlock()
+	d := spanData{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Status:            status{Code: statusCodeUnset},
+	}
+	if s.parentID != (SpanID{}) {
+		d.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if s.err != nil {
+		d.Status = status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	d.Attributes = make([]keyValue, len(s.attributes))
+	for i := range s.attributes {
+		d.Attributes[i] = toKeyValue(s.attributes[i])
+	}
+	return d
+}
+
+// toKeyValue converts an attribute to its OTLP representation
+func toKeyValue(a Attribute) keyValue {
+	kv := keyValue{Key: a.Key}
+	switch v := a.Value.(type) {
+	case string:
+		kv.Value.StringValue = &v
+	case bool:
+		kv.Value.BoolValue = &v
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &s
+	case float64:
+		kv.Value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
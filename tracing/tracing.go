@@ -0,0 +1,261 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Name is an exported subsystem name
+const Name = "tracing"
+
+var (
+	// ErrNotRunning defines an error when the tracer is not running
+	ErrNotRunning = errors.New("tracer not running")
+
+	errTracerAlreadyRunning = errors.New("tracer already running")
+)
+
+// Start starts exporting ended spans to the OTLP/HTTP collector traces
+// endpoint every interval. Spans are only recorded while the tracer is running
+func Start(endpoint, serviceName string, interval time.Duration) error {
+	return tracer.start(endpoint, serviceName, interval)
+}
+
+// Stop stops recording spans and exports those which have ended
+func Stop() error {
+	log.Debugln(log.Global, "Tracer shutting down...")
+	return tracer.stop()
+}
+
+// IsRunning checks to see if the tracer is running
+func IsRunning() bool {
+	return tracer.isRunning()
+}
+
+// StartSpan starts a span as a child of the span carried by ctx, if any, and
+// returns a context carrying the new span. A nil span is returned when the
+// tracer is not running, all span methods are safe to call on a nil span
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	if !tracer.isRunning() {
+		return ctx, nil
+	}
+	s := &Span{
+		tracer:     tracer,
+		spanID:     newSpanID(),
+		name:       name,
+		start:      time.Now(),
+		attributes: attrs,
+	}
+	if parent, ok := ctx.Value(spanContextKey{}).(spanContext); ok {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		s.traceID = newTraceID()
+	}
+	return context.WithValue(ctx, spanContextKey{}, spanContext{traceID: s.traceID, spanID: s.spanID}), s
+}
+
+// Extract returns a context carrying the remote parent described by a W3C
+// traceparent header, so spans started from it continue the caller's trace.
+// ctx is returned unchanged if the header is invalid
+func Extract(ctx context.Context, traceparent string) context.Context {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[3]) != 2 {
+		return ctx
+	}
+	var sc spanContext
+	if hex.EncodedLen(len(sc.traceID)) != len(parts[1]) || hex.EncodedLen(len(sc.spanID)) != len(parts[2]) {
+		return ctx
+	}
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return ctx
+	}
+	if sc.traceID == (TraceID{}) || sc.spanID == (SpanID{}) {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Float64 returns a float attribute
+func Float64(key string, value float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.m.Lock()
+	s.attributes = append(s.attributes, attrs...)
+	s.m.Unlock()
+}
+
+// RecordError marks the span as failed, a nil error is ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.m.Lock()
+	s.err = err
+	s.m.Unlock()
+}
+
+// End ends the span and queues it for export. Subsequent calls are ignored
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.m.Lock()
+	if s.ended {
+		s.m.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.m.Unlock()
+	s.tracer.enqueue(s)
+}
+
+// TraceParent returns the W3C traceparent header identifying the span
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return "00-" + hex.EncodeToString(s.traceID[:]) + "-" + hex.EncodeToString(s.spanID[:]) + "-01"
+}
+
+// start sets defaults and spawns the export routine
+func (t *Tracer) start(endpoint, serviceName string, interval time.Duration) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.running {
+		return errTracerAlreadyRunning
+	}
+	if endpoint == "" {
+		log.Warnf(log.Global, "tracing endpoint unset, using default value %s\n", DefaultEndpoint)
+		endpoint = DefaultEndpoint
+	}
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	if interval <= 0 {
+		log.Warnf(log.Global, "tracing export interval cannot be zero, using default value %s\n", DefaultExportInterval)
+		interval = DefaultExportInterval
+	}
+	t.endpoint = endpoint
+	t.serviceName = serviceName
+	t.interval = interval
+	t.client = &http.Client{Timeout: exportTimeout}
+	t.spans = nil
+	t.dropped = 0
+	t.shutdown = make(chan struct{})
+	t.running = true
+	t.wg.Add(1)
+	go t.run()
+	return nil
+}
+
+// stop stops the export routine once remaining spans have been exported
+func (t *Tracer) stop() error {
+	t.m.Lock()
+	if !t.running {
+		t.m.Unlock()
+		return ErrNotRunning
+	}
+	t.running = false
+	close(t.shutdown)
+	t.m.Unlock()
+	t.wg.Wait()
+	log.Debugln(log.Global, "Tracer shutdown.")
+	return nil
+}
+
+// isRunning returns if the tracer is running
+func (t *Tracer) isRunning() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.running
+}
+
+// enqueue queues an ended span for export
+func (t *Tracer) enqueue(s *Span) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !t.running {
+		return
+	}
+	if len(t.spans) >= maxQueuedSpans {
+		t.dropped++
+		return
+	}
+	t.spans = append(t.spans, s)
+}
+
+// run exports ended spans every interval until shutdown
+func (t *Tracer) run() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.flush()
+		case <-t.shutdown:
+			t.flush()
+			return
+		}
+	}
+}
+
+// flush exports all queued spans
+func (t *Tracer) flush() {
+	t.m.Lock()
+	spans, dropped := t.spans, t.dropped
+	t.spans, t.dropped = nil, 0
+	t.m.Unlock()
+	if dropped > 0 {
+		log.Warnf(log.Global, "Tracer dropped %d spans, export queue full\n", dropped)
+	}
+	if len(spans) == 0 {
+		return
+	}
+	if err := t.export(spans); err != nil {
+		log.Errorf(log.Global, "Tracer unable to export %d spans: %v\n", len(spans), err)
+	}
+}
+
+func newTraceID() (id TraceID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	_, _ = rand.Read(id[:])
+	return id
+}
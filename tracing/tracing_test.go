@@ -0,0 +1,183 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collector is a stand-in OTLP/HTTP collector which stores received spans
+type collector struct {
+	m     sync.Mutex
+	spans []spanData
+	names []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var req exportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.m.Lock()
+	for i := range req.ResourceSpans {
+		for j := range req.ResourceSpans[i].Resource.Attributes {
+			if a := req.ResourceSpans[i].Resource.Attributes[j]; a.Key == "service.name" {
+				c.names = append(c.names, *a.Value.StringValue)
+			}
+		}
+		for j := range req.ResourceSpans[i].ScopeSpans {
+			c.spans = append(c.spans, req.ResourceSpans[i].ScopeSpans[j].Spans...)
+		}
+	}
+	c.m.Unlock()
+}
+
+func (c *collector) span(name string) *spanData {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for i := range c.spans {
+		if c.spans[i].Name == name {
+			return &c.spans[i]
+		}
+	}
+	return nil
+}
+
+// The tracer is package global so tests which start it do not run in parallel
+
+func TestStartStop(t *testing.T) {
+	err := Stop()
+	if !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNotRunning)
+	}
+	err = Start("", "", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if tracer.endpoint != DefaultEndpoint || tracer.serviceName != DefaultServiceName || tracer.interval != DefaultExportInterval {
+		t.Error("expected defaults to be set")
+	}
+	if !IsRunning() {
+		t.Error("expected tracer to be running")
+	}
+	err = Start("", "", 0)
+	if !errors.Is(err, errTracerAlreadyRunning) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errTracerAlreadyRunning)
+	}
+	err = Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if IsRunning() {
+		t.Error("expected tracer to be stopped")
+	}
+}
+
+func TestExport(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	// Spans are not recorded while stopped
+	_, s := StartSpan(context.Background(), "stopped")
+	if s != nil {
+		t.Fatal("expected nil span when tracer is stopped")
+	}
+	s.SetAttributes(String("test", "test"))
+	s.RecordError(errors.New("test"))
+	s.End()
+
+	err := Start(srv.URL+"/v1/traces", "test", time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx, parent := StartSpan(context.Background(), "parent", String("exchange", "binance"), Int("attempt", 1))
+	_, child := StartSpan(ctx, "child")
+	child.SetAttributes(Bool("retry", true), Float64("amount", 1.5))
+	child.RecordError(errors.New("rejected"))
+	child.End()
+	child.End()
+	parent.End()
+	err = Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	if len(c.names) != 1 || c.names[0] != "test" {
+		t.Fatalf("received: '%v' but expected: '%v'", c.names, []string{"test"})
+	}
+	if len(c.spans) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(c.spans), 2)
+	}
+	p, ch := c.span("parent"), c.span("child")
+	if p == nil || ch == nil {
+		t.Fatal("expected parent and child spans to be exported")
+	}
+	if p.ParentSpanID != "" {
+		t.Errorf("received: '%v' but expected no parent", p.ParentSpanID)
+	}
+	if ch.TraceID != p.TraceID || ch.ParentSpanID != p.SpanID {
+		t.Error("expected child span to be linked to its parent")
+	}
+	if ch.Status.Code != statusCodeError || ch.Status.Message != "rejected" {
+		t.Errorf("received: '%v' but expected: '%v'", ch.Status, status{Code: statusCodeError, Message: "rejected"})
+	}
+	if len(p.Attributes) != 2 || *p.Attributes[0].Value.StringValue != "binance" || *p.Attributes[1].Value.IntValue != "1" {
+		t.Errorf("unexpected parent attributes: %+v", p.Attributes)
+	}
+	if len(ch.Attributes) != 2 || !*ch.Attributes[0].Value.BoolValue || *ch.Attributes[1].Value.DoubleValue != 1.5 {
+		t.Errorf("unexpected child attributes: %+v", ch.Attributes)
+	}
+}
+
+func TestExportFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	tr := &Tracer{endpoint: srv.URL, serviceName: "test", client: srv.Client()}
+	err := tr.export([]*Span{{name: "test"}})
+	if err == nil {
+		t.Fatal("expected error from unavailable collector")
+	}
+}
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := Extract(context.Background(), traceparent)
+	sc, ok := ctx.Value(spanContextKey{}).(spanContext)
+	if !ok {
+		t.Fatal("expected remote parent to be extracted")
+	}
+	s := &Span{traceID: sc.traceID, spanID: sc.spanID}
+	if s.TraceParent() != traceparent {
+		t.Errorf("received: '%v' but expected: '%v'", s.TraceParent(), traceparent)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-zzf067aa0ba902b7-01",
+	} {
+		if Extract(context.Background(), invalid).Value(spanContextKey{}) != nil {
+			t.Errorf("expected traceparent %q to be ignored", invalid)
+		}
+	}
+	var nilSpan *Span
+	if nilSpan.TraceParent() != "" {
+		t.Error("expected empty traceparent for nil span")
+	}
+}
@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpoint defines the default OTLP/HTTP collector traces endpoint
+	DefaultEndpoint = "http://localhost:4318/v1/traces"
+
+	// DefaultServiceName defines the default service name traces are exported
+	// under
+	DefaultServiceName = "gocryptotrader"
+
+	// DefaultExportInterval defines the default interval between exports of
+	// ended spans
+	DefaultExportInterval = 5 * time.Second
+
+	// maxQueuedSpans limits the ended spans held between exports, spans ended
+	// beyond this are dropped
+	maxQueuedSpans = 2048
+
+	// instrumentationScope is the OTLP scope spans are exported under
+	instrumentationScope = "github.com/thrasher-corp/gocryptotrader/tracing"
+
+	exportTimeout = 10 * time.Second
+
+	// OTLP status codes
+	statusCodeUnset = 0
+	statusCodeError = 2
+
+	// spanKindInternal is the OTLP span kind all spans are exported as
+	spanKindInternal = 1
+)
+
+// tracer is our main in memory instance
+var tracer = &Tracer{}
+
+// Tracer batches ended spans and exports them to an OTLP/HTTP collector
+type Tracer struct {
+	endpoint    string
+	serviceName string
+	interval    time.Duration
+	client      *http.Client
+
+	running  bool
+	spans    []*Span
+	dropped  uint64
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	m        sync.Mutex
+}
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+// Span defines a timed operation within a trace
+type Span struct {
+	tracer     *Tracer
+	traceID    TraceID
+	spanID     SpanID
+	parentID   SpanID
+	name       string
+	start      time.Time
+	end        time.Time
+	attributes []Attribute
+	err        error
+	ended      bool
+	m          sync.Mutex
+}
+
+// Attribute defines a key value pair describing a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// spanContext identifies a span which may be local or propagated from a
+// remote caller
+type spanContext struct {
+	traceID TraceID
+	spanID  SpanID
+}
+
+type spanContextKey struct{}
+
+// The following types define the OTLP/HTTP JSON encoding of exported spans
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanData `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type spanData struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}